@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// manifest lists the specs generated together by -batch.
+type manifest struct {
+	// Specs are the specs to generate, in order.
+	Specs []manifestEntry `yaml:"specs"`
+
+	// Report is the file the summary of the generation is written to. When
+	// unset, it's written to stdout.
+	Report string `yaml:"report,omitempty"`
+}
+
+// manifestEntry is a spec of a manifest, with its configuration.
+type manifestEntry struct {
+	// Spec is the path or URL of the spec, relative to the manifest.
+	Spec string `yaml:"spec"`
+
+	// Config is the configuration file of the spec, relative to the manifest.
+	// The output file it sets is relative to the configuration file.
+	Config string `yaml:"config"`
+}
+
+// batchResult is the outcome of the generation of a spec of a manifest.
+type batchResult struct {
+	Spec     string
+	Output   string
+	Status   string
+	Duration time.Duration
+	Err      error
+}
+
+// readManifest reads the manifest in a file, resolving the paths it contains.
+func readManifest(manifestFile string) (manifest, error) {
+	var m manifest
+	buf, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return m, fmt.Errorf("error reading manifest '%s': %w", manifestFile, err)
+	}
+	if err := yaml.UnmarshalStrict(buf, &m); err != nil {
+		return m, fmt.Errorf("error parsing manifest '%s' as YAML: %w", manifestFile, err)
+	}
+	if len(m.Specs) == 0 {
+		return m, fmt.Errorf("manifest '%s' lists no specs", manifestFile)
+	}
+
+	dir := filepath.Dir(manifestFile)
+	for i, entry := range m.Specs {
+		if entry.Spec == "" || entry.Config == "" {
+			return m, fmt.Errorf("manifest '%s': spec %d needs both a spec and a config", manifestFile, i+1)
+		}
+		m.Specs[i].Spec = resolvePath(dir, entry.Spec)
+		m.Specs[i].Config = resolvePath(dir, entry.Config)
+	}
+	if m.Report != "" {
+		m.Report = resolvePath(dir, m.Report)
+	}
+	return m, nil
+}
+
+// resolvePath returns a path relative to a directory, leaving URLs and
+// absolute paths alone.
+func resolvePath(dir, p string) string {
+	if u, err := url.Parse(p); err == nil && u.Scheme != "" && u.Host != "" {
+		return p
+	}
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, p)
+}
+
+// readBatchConfiguration reads the configuration file of a spec of a manifest.
+func readBatchConfiguration(entry manifestEntry) (configuration, error) {
+	var opts configuration
+	buf, err := os.ReadFile(entry.Config)
+	if err != nil {
+		return opts, fmt.Errorf("error reading config file '%s': %w", entry.Config, err)
+	}
+	if err := yaml.UnmarshalStrict(buf, &opts); err != nil {
+		return opts, fmt.Errorf("error parsing '%s' as YAML: %w", entry.Config, err)
+	}
+	if opts.OutputFile == "" {
+		return opts, fmt.Errorf("configuration error: '%s' sets no output file", entry.Config)
+	}
+	opts.OutputFile = resolvePath(filepath.Dir(entry.Config), opts.OutputFile)
+	if opts.Diff != nil && opts.Diff.Against != "" {
+		opts.Diff.Against = resolvePath(filepath.Dir(entry.Config), opts.Diff.Against)
+	}
+
+	opts.Configuration = opts.UpdateDefaults()
+	if err := detectPackageName(&opts, entry.Spec); err != nil {
+		return opts, err
+	}
+	resolveOutputFile(&opts)
+	if err := validateConfiguration(opts); err != nil {
+		return opts, fmt.Errorf("configuration error: %w", err)
+	}
+	return opts, nil
+}
+
+// generateBatch generates every spec of a manifest, reading the documents they
+// share once, and carrying on past the specs which fail.
+func generateBatch(m manifest) ([]batchResult, *util.DocumentCache) {
+	cache := util.NewDocumentCache()
+	results := make([]batchResult, 0, len(m.Specs))
+	for _, entry := range m.Specs {
+		start := time.Now()
+		output, generated, err := generateBatchEntry(entry, cache)
+		result := batchResult{Spec: entry.Spec, Output: output, Duration: time.Since(start), Err: err}
+		switch {
+		case err != nil:
+			result.Status = "failed"
+		case generated:
+			result.Status = "generated"
+		default:
+			result.Status = "up to date"
+		}
+		results = append(results, result)
+	}
+	return results, cache
+}
+
+// generateBatchEntry generates a spec of a manifest, and returns its output
+// file, and whether it was generated rather than up to date.
+func generateBatchEntry(entry manifestEntry, cache *util.DocumentCache) (string, bool, error) {
+	opts, err := readBatchConfiguration(entry)
+	if err != nil {
+		return "", false, err
+	}
+	swagger, err := loadSpecs([]string{entry.Spec}, opts, cache)
+	if err != nil {
+		return opts.OutputFile, false, err
+	}
+	generated, err := generateFiles(swagger, opts)
+	return opts.OutputFile, generated, err
+}
+
+// writeBatchReport writes the summary of the generation of a manifest.
+func writeBatchReport(w io.Writer, results []batchResult, cache *util.DocumentCache, total time.Duration) error {
+	var failed int
+	for _, result := range results {
+		_, err := fmt.Fprintf(w, "%-10s %s -> %s (%s)\n", result.Status, result.Spec, result.Output, result.Duration.Round(time.Millisecond))
+		if err != nil {
+			return err
+		}
+		if result.Err != nil {
+			failed++
+			if _, err := fmt.Fprintf(w, "           %s\n", result.Err); err != nil {
+				return err
+			}
+		}
+	}
+	documents, hits := cache.Stats()
+	_, err := fmt.Fprintf(w, "%d specs, %d failed, %d documents read, %d read from the cache, in %s\n",
+		len(results), failed, documents, hits, total.Round(time.Millisecond))
+	return err
+}
+
+// runBatch generates the specs of the manifest in a file, writes the report,
+// and returns an error when any of them failed.
+func runBatch(manifestFile string) error {
+	m, err := readManifest(manifestFile)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	results, cache := generateBatch(m)
+	total := time.Since(start)
+
+	w := io.Writer(os.Stdout)
+	if m.Report != "" {
+		f, err := os.Create(m.Report)
+		if err != nil {
+			return fmt.Errorf("error creating report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writeBatchReport(w, results, cache, total); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			return errors.New("some specs failed to generate, see the report")
+		}
+	}
+	return nil
+}
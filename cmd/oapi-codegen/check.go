@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// outdated are the diffs of the files which differ from the generated code,
+// recorded instead of writing them with -check.
+var outdated []string
+
+// checkFile records the unified diff of a file with the generated code, if
+// they differ. A missing file differs from any code.
+func checkFile(file string, code []byte) error {
+	existing, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil && bytes.Equal(existing, code) {
+		return nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existing)),
+		B:        difflib.SplitLines(string(code)),
+		FromFile: file,
+		ToFile:   file + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	outdated = append(outdated, diff)
+	return nil
+}
+
+// exitIfOutdated prints the diffs recorded by -check, and exits with an error
+// when there are any.
+func exitIfOutdated() {
+	if len(outdated) == 0 {
+		return
+	}
+	for _, diff := range outdated {
+		fmt.Print(diff)
+	}
+	errExit("%d of the generated files are out of date, run oapi-codegen to update them\n", len(outdated))
+}
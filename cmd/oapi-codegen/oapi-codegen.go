@@ -14,15 +14,20 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"gopkg.in/yaml.v2"
 
 	"github.com/deepmap/oapi-codegen/pkg/codegen"
@@ -44,6 +49,13 @@ var (
 	flagPrintUsage     bool
 	flagGenerate       string
 	flagTemplatesDir   string
+	flagLint           bool
+	flagDiff           string
+	flagProto          bool
+	flagGraphQL        bool
+	flagSyntheticNames bool
+	flagBatch          string
+	flagCheck          bool
 
 	// Deprecated: The options below will be removed in a future
 	// release. Please use the new config file format.
@@ -61,8 +73,57 @@ type configuration struct {
 
 	// OutputFile is the filename to output.
 	OutputFile string `yaml:"output,omitempty"`
+
+	// Cache skips generation when OutputFile was generated from the same spec
+	// and configuration, by recording their fingerprint in it.
+	Cache bool `yaml:"cache,omitempty"`
+
+	// Remote configures how the spec is fetched when it's given as a URL.
+	Remote util.RemoteOptions `yaml:"remote,omitempty"`
+
+	// Diff reports the changes of the spec since its previous version which
+	// break the code generated for it.
+	Diff *diffConfiguration `yaml:"diff,omitempty"`
+
+	// Merge configures how several specs given at once are merged into the
+	// one generated.
+	Merge *mergeConfiguration `yaml:"merge,omitempty"`
+
+	// FileSuffix ends the names of the generated files named after their
+	// package, like .gen.go or _gen.go: the output file when OutputFile is a
+	// directory, .gen.go by default, and the fixtures and the generators,
+	// .go by default.
+	FileSuffix string `yaml:"file-suffix,omitempty"`
+
+	// The configuration file and the specs given on the command line, which
+	// the go:generate line of the output file passes on.
+	configFile string
+	specs      []string
+}
+
+// diffConfiguration configures the comparison of the spec with its previous
+// version before generating.
+type diffConfiguration struct {
+	// Against is the previous version of the spec. When unset, it's the one
+	// embedded in OutputFile, if any.
+	Against string `yaml:"against,omitempty"`
+
+	// Fail exits with an error rather than generating when the spec has
+	// breaking changes.
+	Fail bool `yaml:"fail,omitempty"`
+}
+
+// mergeConfiguration configures the merging of several specs.
+type mergeConfiguration struct {
+	// Prefixes are the prefixes of the names of the components and of the
+	// operation IDs of the specs, by their path as given.
+	Prefixes map[string]string `yaml:"prefixes,omitempty"`
 }
 
+// fingerprintPrefix starts the line recording the fingerprint of the spec and
+// configuration in cached output files.
+const fingerprintPrefix = "// oapi-codegen fingerprint: "
+
 // oldConfiguration is deprecated. Please add no more flags here. It is here
 // for backwards compatibility, and it will be removed in the future.
 type oldConfiguration struct {
@@ -91,11 +152,19 @@ func main() {
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 	flag.BoolVar(&flagPrintUsage, "h", false, "Same as -help.")
+	flag.BoolVar(&flagLint, "lint", false, "When specified, check the spec for constructs the generator can't handle well, print the problems found and exit.")
+	flag.StringVar(&flagDiff, "diff", "", "When specified, compare the spec with the given previous version of it, print the changes breaking the generated code and exit.")
+	flag.BoolVar(&flagSyntheticNames, "synthetic-names", false, "When specified, print the names given to the inline schemas by the promote-inline-schemas or max-inline-depth output options, with their locations.")
+	flag.StringVar(&flagBatch, "batch", "", "When specified, generate every spec listed in the given manifest, with its configuration file, and print a summary report.")
+	flag.BoolVar(&flagCheck, "check", false, "When specified, generate in memory without writing, print how the files on disk differ and exit with an error if any does.")
+	flag.BoolVar(&flagCheck, "dry-run", false, "Same as -check.")
+	flag.BoolVar(&flagProto, "proto", false, "When specified, output .proto definitions of a gRPC service approximating the spec, in the package of -package, print what it can't map and exit.")
+	flag.BoolVar(&flagGraphQL, "graphql", false, "When specified, output a GraphQL schema approximating the spec, print what it can't map and exit.")
 
 	// All flags below are deprecated, and will be removed in a future release. Please do not
 	// update their behavior.
 	flag.StringVar(&flagGenerate, "generate", "types,client,server,spec",
-		`Comma-separated list of code to generate; valid options: "types", "client", "chi-server", "server", "gin", "gorilla", "spec", "skip-fmt", "skip-prune", "fiber", "iris".`)
+		`Comma-separated list of code to generate; valid options: "types", "client", "request-builders", "path-builders", "chi-server", "server", "gin", "gorilla", "spec", "server-urls", "security-middleware", "routes", "self-test", "fuzz", "contract-test", "cli", "terraform-models", "deep-copy", "kubernetes-crd", "constructors", "builders", "json-schema", "typescript", "docs", "fixtures", "generators", "scaffold", "skip-fmt", "skip-prune", "fiber", "iris".`)
 	flag.StringVar(&flagIncludeTags, "include-tags", "", "Only include operations with the given tags. Comma-separated list of tags.")
 	flag.StringVar(&flagExcludeTags, "exclude-tags", "", "Exclude operations that are tagged with the given tags. Comma-separated list of tags.")
 	flag.StringVar(&flagTemplatesDir, "templates", "", "Path to directory containing user templates.")
@@ -127,10 +196,16 @@ func main() {
 		return
 	}
 
+	if flagBatch != "" {
+		if err := runBatch(flagBatch); err != nil {
+			errExit("%s\n", err)
+		}
+		exitIfOutdated()
+		return
+	}
+
 	if flag.NArg() < 1 {
 		errExit("Please specify a path to a OpenAPI 3.0 spec file\n")
-	} else if flag.NArg() > 1 {
-		errExit("Only one OpenAPI 3.0 spec file is accepted and it must be the last CLI argument\n")
 	}
 
 	// We will try to infer whether the user has an old-style config, or a new
@@ -247,14 +322,19 @@ func main() {
 	// fields.
 	opts.Configuration = opts.UpdateDefaults()
 
-	if err := detectPackageName(&opts); err != nil {
+	if err := detectPackageName(&opts, flag.Arg(0)); err != nil {
 		errExit("%s\n", err)
 	}
+	resolveOutputFile(&opts)
+	opts.configFile, opts.specs = flagConfigFile, flag.Args()
 
 	// Now, ensure that the config options are valid.
-	if err := opts.Validate(); err != nil {
+	if err := validateConfiguration(opts); err != nil {
 		errExit("configuration error: %v\n", err)
 	}
+	if flagCheck && opts.OutputFile == "" {
+		errExit("configuration error: -check compares the generated code with the output file, which must be set\n")
+	}
 
 	// If the user asked to output configuration, output it to stdout and exit
 	if flagOutputConfig {
@@ -266,28 +346,503 @@ func main() {
 		return
 	}
 
-	swagger, err := util.LoadSwaggerWithCircularReferenceCount(flag.Arg(0), opts.Compatibility.CircularReferenceLimit)
+	swagger, err := loadSpecs(flag.Args(), opts, nil)
 	if err != nil {
-		errExit("error loading swagger spec in %s\n: %s", flag.Arg(0), err)
+		errExit("%s\n", err)
+	}
+
+	if flagLint {
+		diagnostics := codegen.Lint(swagger)
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic.Error())
+		}
+		if len(diagnostics) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flagDiff != "" {
+		previous, err := util.LoadSwaggerWithRemoteOptions(flagDiff, opts.Remote)
+		if err != nil {
+			errExit("error loading previous swagger spec in %s\n: %s", flagDiff, err)
+		}
+		changes := codegen.BreakingChanges(previous, swagger)
+		for _, change := range changes {
+			fmt.Fprintln(os.Stderr, change.Error())
+		}
+		if len(changes) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flagProto {
+		proto, diagnostics, err := codegen.ExportProto(swagger, opts.PackageName)
+		if err != nil {
+			errExit("error exporting proto definitions: %s\n", err)
+		}
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic.Error())
+		}
+		if opts.OutputFile != "" {
+			err = writeFileIfChanged(opts.OutputFile, []byte(proto))
+			if err != nil {
+				errExit("error writing proto definitions to file: %s\n", err)
+			}
+		} else {
+			fmt.Print(proto)
+		}
+		exitIfOutdated()
+		return
+	}
+
+	if flagGraphQL {
+		sdl, diagnostics, err := codegen.ExportGraphQL(swagger)
+		if err != nil {
+			errExit("error exporting the GraphQL schema: %s\n", err)
+		}
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic.Error())
+		}
+		if opts.OutputFile != "" {
+			err = writeFileIfChanged(opts.OutputFile, []byte(sdl))
+			if err != nil {
+				errExit("error writing the GraphQL schema to file: %s\n", err)
+			}
+		} else {
+			fmt.Print(sdl)
+		}
+		exitIfOutdated()
+		return
+	}
+
+	if _, err := generateFiles(swagger, opts); err != nil {
+		errExit("%s\n", err)
+	}
+	exitIfOutdated()
+}
+
+// validateConfiguration checks the configuration, and that the output file is
+// set when files are written next to it.
+func validateConfiguration(opts configuration) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if (opts.Generate.SelfTest || opts.Generate.Fuzz || opts.Generate.ContractTest) && opts.OutputFile == "" {
+		return errors.New("the self-test, fuzz and contract tests are written next to the output file, which must be set")
+	}
+	if opts.Generate.JSONSchema && opts.OutputFile == "" {
+		return errors.New("the JSON Schemas are written next to the output file, which must be set")
+	}
+	if opts.Generate.TypeScript && opts.OutputFile == "" {
+		return errors.New("the TypeScript declarations are written next to the output file, which must be set")
+	}
+	if opts.Generate.Docs && opts.OutputFile == "" {
+		return errors.New("the reference is written next to the output file, which must be set")
+	}
+	if opts.Generate.Fixtures && opts.OutputFile == "" {
+		return errors.New("the fixtures are written next to the output file, which must be set")
+	}
+	if opts.Generate.Generators && opts.OutputFile == "" {
+		return errors.New("the generators are written next to the output file, which must be set")
+	}
+	if opts.Generate.Scaffold && opts.OutputFile == "" {
+		return errors.New("the scaffold is written next to the output file, which must be set")
+	}
+	if opts.OutputOptions.ZeroDependencies {
+		if opts.OutputFile == "" && opts.OutputOptions.SharedRuntime.ImportPath == "" {
+			return errors.New("the runtime of zero-dependencies is written next to the output file, which must be set")
+		}
+		if runtimeFile(opts) == filepath.Clean(opts.OutputFile) {
+			return fmt.Errorf("the output file %s is where the runtime of zero-dependencies is written", opts.OutputFile)
+		}
+	}
+	if opts.FileSuffix != "" && (!strings.HasSuffix(opts.FileSuffix, ".go") || strings.HasSuffix(opts.FileSuffix, "_test.go") || strings.ContainsAny(opts.FileSuffix, `/\`)) {
+		return fmt.Errorf("the file suffix %q must end with .go, and not _test.go", opts.FileSuffix)
+	}
+	return nil
+}
+
+// generateFiles generates the code of a spec, and the files written next to
+// it, as configured. It returns false when the output file is cached and up
+// to date, in which case nothing is generated.
+func generateFiles(swagger *openapi3.T, opts configuration) (bool, error) {
+	var err error
+	if opts.Diff != nil {
+		previous, err := previousSpec(opts.Diff.Against, opts.OutputFile, opts.Remote)
+		if err != nil {
+			return false, fmt.Errorf("error loading previous swagger spec: %w", err)
+		}
+		if previous != nil {
+			changes := codegen.BreakingChanges(previous, swagger)
+			for _, change := range changes {
+				fmt.Fprintln(os.Stderr, change.Error())
+			}
+			if opts.Diff.Fail && len(changes) > 0 {
+				return false, errors.New("the spec has breaking changes, not generating code")
+			}
+		}
 	}
 
 	if len(noVCSVersionOverride) > 0 {
 		opts.Configuration.NoVCSVersionOverride = &noVCSVersionOverride
 	}
 
-	code, err := codegen.Generate(swagger, opts.Configuration)
+	if opts.OutputOptions.GoGenerate == codegen.GoGenerateAuto {
+		opts.OutputOptions.GoGenerate, err = goGenerateCommand(opts)
+		if err != nil {
+			return false, fmt.Errorf("configuration error: %w", err)
+		}
+	}
+
+	var fingerprint string
+	if opts.Cache && opts.OutputFile != "" {
+		fingerprint, err = codegen.Fingerprint(swagger, opts.Configuration)
+		if err != nil {
+			return false, fmt.Errorf("error fingerprinting spec: %w", err)
+		}
+		if readFingerprint(opts.OutputFile) == fingerprint {
+			return false, nil
+		}
+	}
+
+	if (opts.Generate.Scaffold || opts.Generate.Fixtures || opts.Generate.Generators) && opts.OutputOptions.ScaffoldImportPath == "" {
+		opts.OutputOptions.ScaffoldImportPath, err = packageImportPath(filepath.Dir(opts.OutputFile))
+		if err != nil {
+			return false, fmt.Errorf("configuration error: %w, which the scaffolded main.go, the fixtures and the generators import, so set scaffold-import-path", err)
+		}
+	}
+
+	output, err := codegen.GenerateOutput(swagger, opts.Configuration)
+	for _, warning := range output.Diagnostics.Warnings() {
+		fmt.Fprintln(os.Stderr, warning.Error())
+	}
 	if err != nil {
-		errExit("error generating code: %s\n", err)
+		return false, fmt.Errorf("error generating code: %w", err)
+	}
+	if flagSyntheticNames {
+		for _, name := range output.SyntheticNames {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name.Path, name.Name)
+		}
+	}
+
+	code := output.Code
+	if fingerprint != "" {
+		code += "\n" + fingerprintPrefix + fingerprint + "\n"
 	}
 
 	if opts.OutputFile != "" {
-		err = os.WriteFile(opts.OutputFile, []byte(code), 0o644)
+		err = writeFileIfChanged(opts.OutputFile, []byte(code))
 		if err != nil {
-			errExit("error writing generated code to file: %s\n", err)
+			return false, fmt.Errorf("error writing generated code to file: %w", err)
 		}
 	} else {
 		fmt.Print(code)
 	}
+
+	if opts.Generate.SelfTest || opts.Generate.Fuzz || opts.Generate.ContractTest {
+		err = writeFileIfChanged(selfTestFile(opts.OutputFile), []byte(output.SelfTest))
+		if err != nil {
+			return false, fmt.Errorf("error writing self-test to file: %w", err)
+		}
+	}
+
+	if len(output.JSONSchemas) != 0 {
+		dir := jsonSchemaDir(opts.OutputFile)
+		for _, file := range output.JSONSchemas {
+			err = writeFileIfChanged(filepath.Join(dir, file.FileName), file.Schema)
+			if err != nil {
+				return false, fmt.Errorf("error writing JSON Schema to file: %w", err)
+			}
+		}
+	}
+
+	if opts.Generate.TypeScript {
+		err = writeFileIfChanged(typeScriptFile(opts.OutputFile), []byte(output.TypeScript))
+		if err != nil {
+			return false, fmt.Errorf("error writing TypeScript declarations to file: %w", err)
+		}
+	}
+
+	if opts.Generate.Docs {
+		dir := docsDir(opts.OutputFile)
+		for _, file := range output.Docs {
+			err = writeFileIfChanged(filepath.Join(dir, file.FileName), []byte(file.Content))
+			if err != nil {
+				return false, fmt.Errorf("error writing reference to file: %w", err)
+			}
+		}
+	}
+
+	if output.Runtime != "" && runtimeFile(opts) != "" {
+		err = writeFileIfChanged(runtimeFile(opts), []byte(output.Runtime))
+		if err != nil {
+			return false, fmt.Errorf("error writing runtime to file: %w", err)
+		}
+	}
+
+	if opts.Generate.Fixtures {
+		err = writeFileIfChanged(fixturesFile(opts.OutputFile, opts.FileSuffix), []byte(output.Fixtures))
+		if err != nil {
+			return false, fmt.Errorf("error writing fixtures to file: %w", err)
+		}
+	}
+
+	if opts.Generate.Generators {
+		err = writeFileIfChanged(generatorsFile(opts.OutputFile, opts.FileSuffix), []byte(output.Generators))
+		if err != nil {
+			return false, fmt.Errorf("error writing generators to file: %w", err)
+		}
+	}
+
+	if opts.Generate.Scaffold {
+		if err := writeScaffold(output.Scaffold, opts.OutputFile); err != nil {
+			return false, fmt.Errorf("error writing scaffold: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// loadSpecs loads the specs at the given paths, merging them into one when
+// there are several. The documents they reference are read through the cache,
+// unless it's nil.
+func loadSpecs(paths []string, opts configuration, cache *util.DocumentCache) (*openapi3.T, error) {
+	if opts.Merge != nil {
+		for specPath := range opts.Merge.Prefixes {
+			if !codegen.StringInArray(specPath, paths) {
+				return nil, fmt.Errorf("configuration error: the merge prefixes name %s, which isn't one of the specs", specPath)
+			}
+		}
+	}
+
+	var sources []codegen.MergeSource
+	for _, specPath := range paths {
+		swagger, err := cache.LoadSwagger(specPath, opts.Compatibility.CircularReferenceLimit, opts.Remote)
+		if err != nil {
+			return nil, fmt.Errorf("error loading swagger spec in %s\n: %w", specPath, err)
+		}
+		if len(paths) == 1 {
+			return swagger, nil
+		}
+		source := codegen.MergeSource{Name: specPath, Spec: swagger}
+		if opts.Merge != nil {
+			source.Prefix = opts.Merge.Prefixes[specPath]
+		}
+		sources = append(sources, source)
+	}
+	swagger, err := codegen.Merge(sources)
+	if err != nil {
+		return nil, fmt.Errorf("error merging the specs: %w", err)
+	}
+	return swagger, nil
+}
+
+// jsonSchemaDir returns the directory the JSON Schemas of the given output
+// file are written to, schemas next to it.
+func jsonSchemaDir(outputFile string) string {
+	return filepath.Join(filepath.Dir(outputFile), "schemas")
+}
+
+// docsDir returns the directory the reference of the given output file is
+// written to, docs next to it.
+func docsDir(outputFile string) string {
+	return filepath.Join(filepath.Dir(outputFile), "docs")
+}
+
+// typeScriptFile returns the file the TypeScript declarations of the given
+// output file are written to, like api.gen.d.ts for api.gen.go.
+func typeScriptFile(outputFile string) string {
+	return strings.TrimSuffix(outputFile, ".go") + ".d.ts"
+}
+
+// fixturesFile returns the file the fixtures of the given output file are
+// written to, fixtures/fixtures.go next to it, with the given file suffix
+// instead of .go if any.
+func fixturesFile(outputFile string, suffix string) string {
+	return filepath.Join(filepath.Dir(outputFile), "fixtures", "fixtures"+fileSuffix(suffix, ".go"))
+}
+
+// runtimeFile returns the file the runtime is written to with
+// zero-dependencies, runtime.gen.go next to the output file, with the file
+// suffix instead of .gen.go if any, or else the output of the shared runtime,
+// which is empty when another generation writes it.
+func runtimeFile(opts configuration) string {
+	if shared := opts.OutputOptions.SharedRuntime; shared.ImportPath != "" {
+		if shared.Output == "" {
+			return ""
+		}
+		return filepath.Clean(shared.Output)
+	}
+	return filepath.Join(filepath.Dir(opts.OutputFile), "runtime"+fileSuffix(opts.FileSuffix, ".gen.go"))
+}
+
+// generatorsFile returns the file the generators of the given output file
+// are written to, generators/generators.go next to it, with the given file
+// suffix instead of .go if any.
+func generatorsFile(outputFile string, suffix string) string {
+	return filepath.Join(filepath.Dir(outputFile), "generators", "generators"+fileSuffix(suffix, ".go"))
+}
+
+// fileSuffix returns the file suffix configured, or else the given default.
+func fileSuffix(suffix string, defaultSuffix string) string {
+	if suffix == "" {
+		return defaultSuffix
+	}
+	return suffix
+}
+
+// resolveOutputFile names the output file after the package, with the file
+// suffix, .gen.go by default, when the output is a directory, like api.gen.go
+// in internal/api for internal/api/.
+func resolveOutputFile(cfg *configuration) {
+	if cfg.OutputFile == "" {
+		return
+	}
+	info, err := os.Stat(cfg.OutputFile)
+	if (err == nil && info.IsDir()) || strings.HasSuffix(cfg.OutputFile, "/") || strings.HasSuffix(cfg.OutputFile, string(filepath.Separator)) {
+		cfg.OutputFile = filepath.Join(cfg.OutputFile, cfg.PackageName+fileSuffix(cfg.FileSuffix, ".gen.go"))
+	}
+}
+
+// goGenerateCommand returns the command of the go:generate line of the
+// output file for go-generate: auto, running oapi-codegen again with the
+// configuration file and the specs it was given. Since go generate runs it in
+// the directory of the output file, oapi-codegen has to run there too, for
+// the paths to hold.
+func goGenerateCommand(opts configuration) (string, error) {
+	if opts.configFile == "" {
+		return "", errors.New("go-generate: auto passes on the configuration file given with -config, which is missing")
+	}
+	if opts.OutputFile == "" || filepath.Dir(opts.OutputFile) != "." {
+		return "", errors.New("go-generate: auto needs oapi-codegen to run in the directory of the output file, as go generate does")
+	}
+	command := "github.com/deepmap/oapi-codegen/cmd/oapi-codegen"
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Path != "" {
+		command = bi.Path
+	}
+	args := []string{"go", "run", command, "-config", opts.configFile}
+	args = append(args, opts.specs...)
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\"") {
+			args[i] = strconv.Quote(arg)
+		}
+	}
+	return strings.Join(args, " "), nil
+}
+
+// selfTestFile returns the file the self-test of the given output file is
+// written to, like api.gen_test.go for api.gen.go.
+func selfTestFile(outputFile string) string {
+	return strings.TrimSuffix(outputFile, ".go") + "_test.go"
+}
+
+// writeScaffold writes the handlers.go file of the scaffold next to the
+// output file, or completes the existing one with the handlers it lacks, and
+// writes the main.go file serving them to the server directory next to it,
+// unless it exists.
+func writeScaffold(scaffold codegen.Scaffold, outputFile string) error {
+	handlersFile := filepath.Join(filepath.Dir(outputFile), "handlers.go")
+	handlers := []byte(scaffold.Handlers)
+	if existing, err := os.ReadFile(handlersFile); err == nil {
+		handlers, err = scaffold.Complete(existing)
+		if err != nil {
+			return fmt.Errorf("%s: %w", handlersFile, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := writeFileIfChanged(handlersFile, handlers); err != nil {
+		return err
+	}
+
+	mainFile := filepath.Join(filepath.Dir(outputFile), "server", "main.go")
+	if _, err := os.Stat(mainFile); err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return writeFileIfChanged(mainFile, []byte(scaffold.Main))
+}
+
+// packageImportPath returns the import path of the package in a directory,
+// from the go.mod of the module it's in.
+func packageImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := dir; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+					rel, err := filepath.Rel(root, dir)
+					if err != nil {
+						return "", err
+					}
+					return path.Join(strings.Trim(fields[1], `"`), filepath.ToSlash(rel)), nil
+				}
+			}
+			return "", fmt.Errorf("%s declares no module", filepath.Join(root, "go.mod"))
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("the output file isn't in a Go module")
+		}
+	}
+}
+
+// previousSpec loads the previous version of the spec to compare it with,
+// which is the one embedded in the output file unless against is set. It
+// returns nil when there's no output file yet.
+func previousSpec(against, outputFile string, remote util.RemoteOptions) (*openapi3.T, error) {
+	if against != "" {
+		return util.LoadSwaggerWithRemoteOptions(against, remote)
+	}
+	if outputFile == "" {
+		return nil, errors.New("diff needs either an output file embedding the spec, or against")
+	}
+	code, err := os.ReadFile(outputFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	spec, err := codegen.EmbeddedSpec(code)
+	if errors.Is(err, codegen.ErrNoEmbeddedSpec) {
+		return nil, fmt.Errorf("%s doesn't embed the spec, generate it with the embedded-spec option or set diff.against", outputFile)
+	}
+	return spec, err
+}
+
+// readFingerprint returns the fingerprint recorded in a cached output file,
+// if any.
+func readFingerprint(outputFile string) string {
+	buf, err := os.ReadFile(outputFile)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(buf), "\n"), "\n")
+	fingerprint, found := strings.CutPrefix(lines[len(lines)-1], fingerprintPrefix)
+	if !found {
+		return ""
+	}
+	return fingerprint
+}
+
+// writeFileIfChanged writes the generated code, leaving the file untouched
+// when it already has the same contents, so that its modification time only
+// changes with them. With -check, it only records how the file differs.
+func writeFileIfChanged(outputFile string, code []byte) error {
+	if flagCheck {
+		return checkFile(outputFile, code)
+	}
+	if existing, err := os.ReadFile(outputFile); err == nil && bytes.Equal(existing, code) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(outputFile, code, 0o644)
 }
 
 func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
@@ -327,7 +882,7 @@ func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
 }
 
 // detectPackageName detects and sets PackageName if not already set.
-func detectPackageName(cfg *configuration) error {
+func detectPackageName(cfg *configuration, specPath string) error {
 	if cfg.PackageName != "" {
 		return nil
 	}
@@ -356,7 +911,7 @@ func detectPackageName(cfg *configuration) error {
 	}
 
 	// Fallback to determining from the spec file name.
-	parts := strings.Split(filepath.Base(flag.Arg(0)), ".")
+	parts := strings.Split(filepath.Base(specPath), ".")
 	cfg.PackageName = codegen.LowercaseFirstCharacter(codegen.ToCamelCase(parts[0]))
 
 	return nil
@@ -471,10 +1026,50 @@ func generationTargets(cfg *codegen.Configuration, targets []string) error {
 			opts.Strict = true
 		case "client":
 			opts.Client = true
+		case "request-builders":
+			opts.RequestBuilders = true
+		case "path-builders":
+			opts.PathBuilders = true
 		case "types", "models":
 			opts.Models = true
 		case "spec", "embedded-spec":
 			opts.EmbeddedSpec = true
+		case "server-urls":
+			opts.ServerURLs = true
+		case "security-middleware":
+			opts.SecurityMiddleware = true
+		case "routes":
+			opts.Routes = true
+		case "self-test":
+			opts.SelfTest = true
+		case "fuzz":
+			opts.Fuzz = true
+		case "contract-test":
+			opts.ContractTest = true
+		case "cli":
+			opts.CLI = true
+		case "terraform-models":
+			opts.TerraformModels = true
+		case "deep-copy":
+			opts.DeepCopy = true
+		case "kubernetes-crd":
+			opts.KubernetesCRD = true
+		case "constructors":
+			opts.Constructors = true
+		case "builders":
+			opts.Builders = true
+		case "json-schema":
+			opts.JSONSchema = true
+		case "typescript":
+			opts.TypeScript = true
+		case "docs":
+			opts.Docs = true
+		case "fixtures":
+			opts.Fixtures = true
+		case "generators":
+			opts.Generators = true
+		case "scaffold":
+			opts.Scaffold = true
 		case "skip-fmt":
 			cfg.OutputOptions.SkipFmt = true
 		case "skip-prune":
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
 	"github.com/deepmap/oapi-codegen/pkg/util"
 )
 
@@ -24,3 +28,236 @@ func TestLoader(t *testing.T) {
 		}
 	}
 }
+
+func TestReadFingerprint(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "api.gen.go")
+	if got := readFingerprint(outputFile); got != "" {
+		t.Errorf("fingerprint of a missing file: got %q", got)
+	}
+
+	if err := writeFileIfChanged(outputFile, []byte("package api\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFingerprint(outputFile); got != "" {
+		t.Errorf("fingerprint of an uncached file: got %q", got)
+	}
+
+	code := "package api\n\n" + fingerprintPrefix + "sha256:abc\n"
+	if err := writeFileIfChanged(outputFile, []byte(code)); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFingerprint(outputFile); got != "sha256:abc" {
+		t.Errorf("fingerprint of a cached file: got %q", got)
+	}
+}
+
+func TestPreviousSpec(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "api.gen.go")
+	if spec, err := previousSpec("", outputFile, util.RemoteOptions{}); spec != nil || err != nil {
+		t.Errorf("previous spec of a missing file: got %v, %v", spec, err)
+	}
+
+	if err := writeFileIfChanged(outputFile, []byte("package api\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := previousSpec("", outputFile, util.RemoteOptions{}); err == nil {
+		t.Error("previous spec of a file without embedded spec: expected an error")
+	}
+
+	spec, err := previousSpec("../../examples/petstore-expanded/petstore-expanded.yaml", outputFile, util.RemoteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Info.Title != "Swagger Petstore" {
+		t.Errorf("previous spec: got title %q", spec.Info.Title)
+	}
+}
+
+func TestSelfTestFile(t *testing.T) {
+	if got := selfTestFile("api/api.gen.go"); got != "api/api.gen_test.go" {
+		t.Errorf("self-test file: got %q", got)
+	}
+}
+
+func TestResolveOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg := configuration{Configuration: codegen.Configuration{PackageName: "api"}, OutputFile: dir}
+	resolveOutputFile(&cfg)
+	if want := filepath.Join(dir, "api.gen.go"); cfg.OutputFile != want {
+		t.Errorf("output directory: got %q, want %q", cfg.OutputFile, want)
+	}
+
+	cfg = configuration{Configuration: codegen.Configuration{PackageName: "api"}, OutputFile: "internal/api/", FileSuffix: "_gen.go"}
+	resolveOutputFile(&cfg)
+	if want := filepath.Join("internal", "api", "api_gen.go"); cfg.OutputFile != want {
+		t.Errorf("output directory with a suffix: got %q, want %q", cfg.OutputFile, want)
+	}
+
+	cfg.OutputFile = "api.go"
+	resolveOutputFile(&cfg)
+	if cfg.OutputFile != "api.go" {
+		t.Errorf("output file: got %q", cfg.OutputFile)
+	}
+	if got := fixturesFile("api/api.gen.go", "_gen.go"); got != filepath.Join("api", "fixtures", "fixtures_gen.go") {
+		t.Errorf("fixtures file with a suffix: got %q", got)
+	}
+}
+
+func TestRuntimeFile(t *testing.T) {
+	opts := configuration{OutputFile: "api/api.gen.go", FileSuffix: "_gen.go"}
+	if got := runtimeFile(opts); got != filepath.Join("api", "runtime_gen.go") {
+		t.Errorf("runtime file with a suffix: got %q", got)
+	}
+
+	opts.OutputOptions.SharedRuntime.ImportPath = "example.com/app/internal/oapiruntime"
+	if got := runtimeFile(opts); got != "" {
+		t.Errorf("shared runtime written elsewhere: got %q", got)
+	}
+	opts.OutputOptions.SharedRuntime.Output = "internal/oapiruntime/runtime.gen.go"
+	if got := runtimeFile(opts); got != filepath.Join("internal", "oapiruntime", "runtime.gen.go") {
+		t.Errorf("shared runtime: got %q", got)
+	}
+}
+
+func TestGoGenerateCommand(t *testing.T) {
+	opts := configuration{OutputFile: "api.gen.go", configFile: "cfg.yaml", specs: []string{"../specs/pet store.yaml"}}
+	command, err := goGenerateCommand(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(command, "go run ") || !strings.HasSuffix(command, ` -config cfg.yaml "../specs/pet store.yaml"`) {
+		t.Errorf("go:generate command: got %q", command)
+	}
+
+	opts.OutputFile = "internal/api/api.gen.go"
+	if _, err := goGenerateCommand(opts); err == nil {
+		t.Error("output file in another directory: expected an error")
+	}
+	opts.OutputFile, opts.configFile = "api.gen.go", ""
+	if _, err := goGenerateCommand(opts); err == nil {
+		t.Error("no configuration file: expected an error")
+	}
+}
+
+func TestLoadSpecs(t *testing.T) {
+	users := "../../pkg/codegen/test_specs/merge/users.yaml"
+	billing := "../../pkg/codegen/test_specs/merge/billing.yaml"
+
+	spec, err := loadSpecs([]string{users}, configuration{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Paths) != 2 {
+		t.Errorf("single spec: got %d paths", len(spec.Paths))
+	}
+
+	opts := configuration{Merge: &mergeConfiguration{Prefixes: map[string]string{billing: "Billing"}}}
+	spec, err = loadSpecs([]string{users, billing}, opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Paths) != 3 || spec.Components.Schemas["BillingInvoice"] == nil {
+		t.Errorf("merged specs: got %d paths and schemas %v", len(spec.Paths), spec.Components.Schemas)
+	}
+
+	opts.Merge.Prefixes["other.yaml"] = "Other"
+	if _, err := loadSpecs([]string{users, billing}, opts, nil); err == nil {
+		t.Error("prefix of an unknown spec: expected an error")
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"common.yaml": "components:\n  schemas:\n    Error:\n      type: object\n      properties:\n        message:\n          type: string\n",
+		"manifest.yaml": "report: report.txt\nspecs:\n" +
+			"  - {spec: users.yaml, config: users/cfg.yaml}\n" +
+			"  - {spec: billing.yaml, config: billing/cfg.yaml}\n",
+		"users/cfg.yaml":   "package: users\ngenerate:\n  models: true\noutput: users.gen.go\n",
+		"billing/cfg.yaml": "package: billing\ngenerate:\n  models: true\noutput: billing.gen.go\ncache: true\n",
+	}
+	for _, name := range []string{"users", "billing"} {
+		files[name+".yaml"] = `openapi: "3.0.1"
+info: {version: 1.0.0, title: ` + name + `}
+paths: {}
+components:
+  schemas:
+    Failure:
+      $ref: 'common.yaml#/components/schemas/Error'
+`
+	}
+	for name, contents := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifestFile := filepath.Join(dir, "manifest.yaml")
+	if err := runBatch(manifestFile); err != nil {
+		t.Fatal(err)
+	}
+	for _, output := range []string{"users/users.gen.go", "billing/billing.gen.go"} {
+		if _, err := os.Stat(filepath.Join(dir, output)); err != nil {
+			t.Errorf("output %s: %v", output, err)
+		}
+	}
+	report, err := os.ReadFile(filepath.Join(dir, "report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(report), "2 specs, 0 failed, 3 documents read, 1 read from the cache") {
+		t.Errorf("report:\n%s", report)
+	}
+
+	// The billing output is cached, and the users config can't be read
+	if err := os.Remove(filepath.Join(dir, "users/cfg.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := runBatch(manifestFile); err == nil {
+		t.Error("batch with a missing config: expected an error")
+	}
+	report, err = os.ReadFile(filepath.Join(dir, "report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(report), "up to date") || !strings.Contains(string(report), "2 specs, 1 failed") {
+		t.Errorf("report:\n%s", report)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	defer func() { outdated = nil }()
+	outputFile := filepath.Join(t.TempDir(), "api.gen.go")
+
+	if err := checkFile(outputFile, []byte("package api\n")); err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 1 {
+		t.Fatalf("missing file: got %d diffs", len(outdated))
+	}
+
+	if err := os.WriteFile(outputFile, []byte("package api\n\ntype Pet struct{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outdated = nil
+	if err := checkFile(outputFile, []byte("package api\n\ntype Pet struct{}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 0 {
+		t.Errorf("up to date file: got diffs %v", outdated)
+	}
+
+	if err := checkFile(outputFile, []byte("package api\n\ntype Pet struct {\n\tName string\n}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 1 || !strings.Contains(outdated[0], "-type Pet struct{}\n+type Pet struct {\n") {
+		t.Errorf("outdated file: got diffs %v", outdated)
+	}
+	if contents, _ := os.ReadFile(outputFile); string(contents) != "package api\n\ntype Pet struct{}\n" {
+		t.Errorf("outdated file was written: %q", contents)
+	}
+}
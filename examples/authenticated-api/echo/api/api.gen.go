@@ -118,6 +118,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// ListThings request
@@ -138,7 +152,7 @@ func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -150,7 +164,7 @@ func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -162,24 +176,14 @@ func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewListThingsRequest generates requests for ListThings
 func NewListThingsRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/things")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/things")
 	if err != nil {
 		return nil, err
 	}
@@ -207,29 +211,47 @@ func NewAddThingRequest(server string, body AddThingJSONRequestBody) (*http.Requ
 func NewAddThingRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/things")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/things")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -306,6 +328,16 @@ func (r ListThingsResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r ListThingsResponse) Success() ([]ThingWithID, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero []ThingWithID
+	return zero, false
+}
+
 type AddThingResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -328,6 +360,16 @@ func (r AddThingResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r AddThingResponse) Success() ([]ThingWithID, bool) {
+	if r.JSON201 != nil {
+		return *r.JSON201, true
+	}
+	var zero []ThingWithID
+	return zero, false
+}
+
 // ListThingsWithResponse request returning *ListThingsResponse
 func (c *ClientWithResponses) ListThingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListThingsResponse, error) {
 	rsp, err := c.ListThings(ctx, reqEditors...)
@@ -416,6 +458,19 @@ type ServerInterface interface {
 	AddThing(ctx echo.Context) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (GET /things)
+func (_ Unimplemented) ListThings(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /things)
+func (_ Unimplemented) AddThing(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
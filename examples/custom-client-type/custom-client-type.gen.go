@@ -6,7 +6,6 @@ package customclienttype
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -89,6 +88,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetClient request
@@ -104,34 +117,52 @@ func (c *CustomClientType) GetClient(ctx context.Context, reqEditors ...RequestE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewGetClientRequest generates requests for GetClient
 func NewGetClientRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/client")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/client")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *CustomClientType) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *CustomClientType) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -203,6 +234,16 @@ func (r GetClientResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetClientResponse) Success() (Client, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Client
+	return zero, false
+}
+
 // GetClientWithResponse request returning *GetClientResponse
 func (c *ClientWithResponses) GetClientWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetClientResponse, error) {
 	rsp, err := c.GetClient(ctx, reqEditors...)
@@ -117,24 +117,9 @@ type MiddlewareFunc func(http.Handler) http.Handler
 func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params FindPetsParams
-
-	// ------------- Optional query parameter "tags" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
+	params, err := BindFindPetsParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tags", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -168,14 +153,9 @@ func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request
 func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	id, err := BindDeletePetParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -194,14 +174,9 @@ func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Requ
 func (siw *ServerInterfaceWrapper) FindPetByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	id, err := BindFindPetByIDParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -216,6 +191,53 @@ func (siw *ServerInterfaceWrapper) FindPetByID(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// BindFindPetsParams binds the parameters of the FindPets operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindFindPetsParams(r *http.Request) (params FindPetsParams, err error) {
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "tags", Err: err}
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "limit", Err: err}
+	}
+
+	return params, nil
+}
+
+// BindDeletePetParams binds the parameters of the DeletePet operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindDeletePetParams(r *http.Request) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+// BindFindPetByIDParams binds the parameters of the FindPetByID operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindFindPetByIDParams(r *http.Request) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -34,11 +34,43 @@ type ServerInterface interface {
 	FindPetByID(c *gin.Context, id int64)
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// Returns all pets
+// (GET /pets)
+func (_ Unimplemented) FindPets(c *gin.Context, params FindPetsParams) {
+	c.Status(http.StatusNotImplemented)
+}
+
+// Creates a new pet
+// (POST /pets)
+func (_ Unimplemented) AddPet(c *gin.Context) {
+	c.Status(http.StatusNotImplemented)
+}
+
+// Deletes a pet by ID
+// (DELETE /pets/{id})
+func (_ Unimplemented) DeletePet(c *gin.Context, id int64) {
+	c.Status(http.StatusNotImplemented)
+}
+
+// Returns a pet by ID
+// (GET /pets/{id})
+func (_ Unimplemented) FindPetByID(c *gin.Context, id int64) {
+	c.Status(http.StatusNotImplemented)
+}
+
+// ErrorHandlerFunc handles the errors of binding the parameters of the
+// requests, one of the parameter errors below, with the status code to
+// respond with.
+type ErrorHandlerFunc func(c *gin.Context, err error, statusCode int)
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
 	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
+	ErrorHandler       ErrorHandlerFunc
 }
 
 type MiddlewareFunc func(c *gin.Context)
@@ -46,24 +78,9 @@ type MiddlewareFunc func(c *gin.Context)
 // FindPets operation middleware
 func (siw *ServerInterfaceWrapper) FindPets(c *gin.Context) {
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params FindPetsParams
-
-	// ------------- Optional query parameter "tags" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "tags", c.Request.URL.Query(), &params.Tags)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tags: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	params, err := BindFindPetsParams(c)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, err, http.StatusBadRequest)
 		return
 	}
 
@@ -93,14 +110,9 @@ func (siw *ServerInterfaceWrapper) AddPet(c *gin.Context) {
 // DeletePet operation middleware
 func (siw *ServerInterfaceWrapper) DeletePet(c *gin.Context) {
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	id, err := BindDeletePetParams(c)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, err, http.StatusBadRequest)
 		return
 	}
 
@@ -117,14 +129,9 @@ func (siw *ServerInterfaceWrapper) DeletePet(c *gin.Context) {
 // FindPetByID operation middleware
 func (siw *ServerInterfaceWrapper) FindPetByID(c *gin.Context) {
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	id, err := BindFindPetByIDParams(c)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, err, http.StatusBadRequest)
 		return
 	}
 
@@ -138,11 +145,130 @@ func (siw *ServerInterfaceWrapper) FindPetByID(c *gin.Context) {
 	siw.Handler.FindPetByID(c, id)
 }
 
+// BindFindPetsParams binds the parameters of the FindPets operation from a
+// request routed by gin. The error is one of the parameter errors below.
+func BindFindPetsParams(c *gin.Context) (params FindPetsParams, err error) {
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", c.Request.URL.Query(), &params.Tags)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "tags", Err: err}
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "limit", Err: err}
+	}
+
+	return params, nil
+}
+
+// BindDeletePetParams binds the parameters of the DeletePet operation from a
+// request routed by gin. The error is one of the parameter errors below.
+func BindDeletePetParams(c *gin.Context) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+// BindFindPetByIDParams binds the parameters of the FindPetByID operation from a
+// request routed by gin. The error is one of the parameter errors below.
+func BindFindPetByIDParams(c *gin.Context) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+	// ErrorHandler handles the errors of binding the parameters of the
+	// requests, responding with the status code and the error in JSON when
+	// unset.
+	ErrorHandler ErrorHandlerFunc
 }
 
 // RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
@@ -34,6 +34,33 @@ type ServerInterface interface {
 	FindPetByID(ctx iris.Context, id int64)
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// Returns all pets
+// (GET /pets)
+func (_ Unimplemented) FindPets(ctx iris.Context, params FindPetsParams) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// Creates a new pet
+// (POST /pets)
+func (_ Unimplemented) AddPet(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// Deletes a pet by ID
+// (DELETE /pets/{id})
+func (_ Unimplemented) DeletePet(ctx iris.Context, id int64) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// Returns a pet by ID
+// (GET /pets/{id})
+func (_ Unimplemented) FindPetByID(ctx iris.Context, id int64) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -52,8 +79,7 @@ func (w *ServerInterfaceWrapper) FindPets(ctx iris.Context) {
 
 	err = runtime.BindQueryParameter("form", true, false, "tags", ctx.Request().URL.Query(), &params.Tags)
 	if err != nil {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.Writef("Invalid format for parameter tags: %s", err)
+		writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter tags: %w", err))
 		return
 	}
 
@@ -61,8 +87,7 @@ func (w *ServerInterfaceWrapper) FindPets(ctx iris.Context) {
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.Request().URL.Query(), &params.Limit)
 	if err != nil {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.Writef("Invalid format for parameter limit: %s", err)
+		writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter limit: %w", err))
 		return
 	}
 
@@ -87,8 +112,7 @@ func (w *ServerInterfaceWrapper) DeletePet(ctx iris.Context) {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Params().Get("id"), &id)
 	if err != nil {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.Writef("Invalid format for parameter id: %s", err)
+		writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter id: %w", err))
 		return
 	}
 
@@ -106,8 +130,7 @@ func (w *ServerInterfaceWrapper) FindPetByID(ctx iris.Context) {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Params().Get("id"), &id)
 	if err != nil {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.Writef("Invalid format for parameter id: %s", err)
+		writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter id: %w", err))
 		return
 	}
 
@@ -115,6 +138,12 @@ func (w *ServerInterfaceWrapper) FindPetByID(ctx iris.Context) {
 	w.Handler.FindPetByID(ctx, id)
 }
 
+// writeBadRequest responds to a request whose parameters can't be bound.
+func writeBadRequest(ctx iris.Context, err error) {
+	ctx.StatusCode(http.StatusBadRequest)
+	ctx.WriteString(err.Error())
+}
+
 // IrisServerOption is the option for iris server
 type IrisServerOptions struct {
 	BaseURL     string
@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -129,6 +128,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// FindPets request
@@ -155,7 +168,7 @@ func (c *Client) FindPets(ctx context.Context, params *FindPetsParams, reqEditor
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -167,7 +180,7 @@ func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -179,7 +192,7 @@ func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -191,7 +204,7 @@ func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) FindPetByID(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -203,24 +216,14 @@ func (c *Client) FindPetByID(ctx context.Context, id int64, reqEditors ...Reques
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewFindPetsRequest generates requests for FindPets
 func NewFindPetsRequest(server string, params *FindPetsParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/pets")
 	if err != nil {
 		return nil, err
 	}
@@ -286,17 +289,7 @@ func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request,
 func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/pets")
 	if err != nil {
 		return nil, err
 	}
@@ -322,17 +315,7 @@ func NewDeletePetRequest(server string, id int64) (*http.Request, error) {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/pets/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -356,27 +339,45 @@ func NewFindPetByIDRequest(server string, id int64) (*http.Request, error) {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/pets/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -460,6 +461,26 @@ func (r FindPetsResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r FindPetsResponse) Success() ([]Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero []Pet
+	return zero, false
+}
+
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r FindPetsResponse) Error() (Error, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}
+
 type AddPetResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -483,6 +504,26 @@ func (r AddPetResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r AddPetResponse) Success() (Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Pet
+	return zero, false
+}
+
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r AddPetResponse) Error() (Error, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}
+
 type DeletePetResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -505,6 +546,16 @@ func (r DeletePetResponse) StatusCode() int {
 	return 0
 }
 
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r DeletePetResponse) Error() (Error, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}
+
 type FindPetByIDResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -528,6 +579,26 @@ func (r FindPetByIDResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r FindPetByIDResponse) Success() (Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Pet
+	return zero, false
+}
+
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r FindPetByIDResponse) Error() (Error, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}
+
 // FindPetsWithResponse request returning *FindPetsResponse
 func (c *ClientWithResponses) FindPetsWithResponse(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*FindPetsResponse, error) {
 	rsp, err := c.FindPets(ctx, params, reqEditors...)
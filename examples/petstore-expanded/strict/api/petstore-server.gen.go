@@ -78,24 +78,9 @@ type MiddlewareFunc func(http.Handler) http.Handler
 func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params FindPetsParams
-
-	// ------------- Optional query parameter "tags" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tags", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	params, err := BindFindPetsParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -129,14 +114,9 @@ func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request
 func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	id, err := BindDeletePetParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -155,14 +135,9 @@ func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Requ
 func (siw *ServerInterfaceWrapper) FindPetByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id int64
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	id, err := BindFindPetByIDParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -177,6 +152,53 @@ func (siw *ServerInterfaceWrapper) FindPetByID(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// BindFindPetsParams binds the parameters of the FindPets operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindFindPetsParams(r *http.Request) (params FindPetsParams, err error) {
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "tags", Err: err}
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "limit", Err: err}
+	}
+
+	return params, nil
+}
+
+// BindDeletePetParams binds the parameters of the DeletePet operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindDeletePetParams(r *http.Request) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+// BindFindPetByIDParams binds the parameters of the FindPetByID operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindFindPetByIDParams(r *http.Request) (id int64, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -437,6 +459,59 @@ type StrictServerInterface interface {
 	FindPetByID(ctx context.Context, request FindPetByIDRequestObject) (FindPetByIDResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// Returns all pets
+// (GET /pets)
+func (_ UnimplementedStrictServer) FindPets(ctx context.Context, request FindPetsRequestObject) (FindPetsResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// Creates a new pet
+// (POST /pets)
+func (_ UnimplementedStrictServer) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// Deletes a pet by ID
+// (DELETE /pets/{id})
+func (_ UnimplementedStrictServer) DeletePet(ctx context.Context, request DeletePetRequestObject) (DeletePetResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// Returns a pet by ID
+// (GET /pets/{id})
+func (_ UnimplementedStrictServer) FindPetByID(ctx context.Context, request FindPetByIDRequestObject) (FindPetByIDResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitFindPetsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitAddPetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitDeletePetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitFindPetByIDResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
 type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
 
@@ -6,7 +6,6 @@ package param
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -244,6 +243,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetTest request
@@ -259,24 +272,14 @@ func (c *Client) GetTest(ctx context.Context, params *GetTestParams, reqEditors
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewGetTestRequest generates requests for GetTest
 func NewGetTestRequest(server string, params *GetTestParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/test")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/test")
 	if err != nil {
 		return nil, err
 	}
@@ -327,6 +330,34 @@ func NewGetTestRequest(server string, params *GetTestParams) (*http.Request, err
 	return req, nil
 }
 
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
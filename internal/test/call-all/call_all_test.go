@@ -0,0 +1,54 @@
+package callall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallAllStopsOnceTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first call cancels the others
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		cancel()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Product{Name: "lamp"})
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	results := GetProductCallAll(ctx, client, []GetProductCall{{Id: "1"}, {Id: "2"}, {Id: "3"}}, 1)
+	require.Len(t, results, 3)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for _, result := range results[1:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.Nil(t, result.Response)
+	}
+}
+
+func TestCallAllKeepsTheOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Product{Name: r.URL.Path})
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	results := GetProductCallAll(context.Background(), client, []GetProductCall{{Id: "1"}, {Id: "2"}, {Id: "3"}}, 2)
+	for i, result := range results {
+		require.NoError(t, result.Err)
+		assert.Equal(t, "/products/"+string(rune('1'+i)), result.Response.JSON200.Name)
+	}
+}
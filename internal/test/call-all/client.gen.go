@@ -0,0 +1,350 @@
+// Package callall provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package callall
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Product defines model for Product.
+type Product struct {
+	Name string `json:"name"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetProduct request
+	GetProduct(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetProduct(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProductRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewGetProductRequest generates requests for GetProduct
+func NewGetProductRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/products/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetProductWithResponse request
+	GetProductWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetProductResponse, error)
+}
+
+type GetProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Product
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetProductResponse) Success() (Product, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Product
+	return zero, false
+}
+
+// GetProductWithResponse request returning *GetProductResponse
+func (c *ClientWithResponses) GetProductWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetProductResponse, error) {
+	rsp, err := c.GetProduct(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProductResponse(rsp)
+}
+
+// ParseGetProductResponse parses an HTTP response from a GetProductWithResponse call
+func ParseGetProductResponse(rsp *http.Response) (*GetProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Product
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// GetProductCall holds the parameters of a call of GetProduct, made by
+// GetProductCallAll.
+type GetProductCall struct {
+	Id string
+}
+
+// GetProductResult is the outcome of a call of GetProduct.
+type GetProductResult struct {
+	Response *GetProductResponse
+	Err      error
+}
+
+// GetProductCallAll calls GetProduct with each of calls, running at most
+// concurrency of them at once, or all of them when it isn't positive, and
+// returns their results in the order of calls. Once ctx is done, the calls
+// not yet started fail with its error.
+func GetProductCallAll(ctx context.Context, client ClientWithResponsesInterface, calls []GetProductCall, concurrency int, reqEditors ...RequestEditorFn) []GetProductResult {
+	results := make([]GetProductResult, len(calls))
+	started := callAll(ctx, len(calls), concurrency, func(i int) {
+		results[i].Response, results[i].Err = client.GetProductWithResponse(ctx, calls[i].Id, reqEditors...)
+	})
+	for i := started; i < len(calls); i++ {
+		results[i].Err = ctx.Err()
+	}
+	return results
+}
+
+// callAll calls fn with each index up to n, running at most concurrency of
+// the calls at once, or all of them when it isn't positive, until ctx is done,
+// and returns the number of calls started once they're all done.
+func callAll(ctx context.Context, n, concurrency int, fn func(i int)) int {
+	if concurrency <= 0 || concurrency > n {
+		concurrency = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	started := 0
+dispatch:
+	for started < n && ctx.Err() == nil {
+		select {
+		case indexes <- started:
+			started++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return started
+}
@@ -0,0 +1,139 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBank returns the URL of a server answering the orders and the cards
+// with sensitive values.
+func newBank(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cret"})
+		switch r.Method {
+		case http.MethodPost:
+			var order NewOrder
+			_ = json.NewDecoder(r.Body).Decode(&order)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Order{Item: &order.Item, PaymentToken: order.CardNumber})
+		default:
+			w.Header().Set("X-Card-Token", "tok-"+r.URL.Query().Get("pin"))
+			id := "order-1"
+			_ = json.NewEncoder(w).Encode(Order{Id: &id})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// withAPIKey sets the API key of the requests in their query.
+func withAPIKey(key string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set("api_key", key)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
+
+// bank calls the operations of the bank with the given card number, PIN and
+// API key.
+func bank(t *testing.T, client *ClientWithResponses, card, pin, key string) (*GetCardResponse, *CreateOrderResponse) {
+	cardRsp, err := client.GetCardWithResponse(context.Background(), card, &GetCardParams{Pin: &pin}, withAPIKey(key))
+	require.NoError(t, err)
+	orderRsp, err := client.CreateOrderWithResponse(context.Background(), nil, NewOrder{Item: "lamp", CardNumber: &card}, withAPIKey(key))
+	require.NoError(t, err)
+	return cardRsp, orderRsp
+}
+
+func TestCassetteRedactsSensitiveValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.json")
+
+	recorder, err := NewCassetteTransport(path, CassetteRecord, nil)
+	require.NoError(t, err)
+	client, err := NewClientWithResponses(newBank(t), WithHTTPClient(recorder))
+	require.NoError(t, err)
+	cardRsp, orderRsp := bank(t, client, "4111111111111111", "1234", "key1")
+	require.NoError(t, recorder.Save())
+
+	// The client gets the values as they are
+	assert.Equal(t, "tok-1234", cardRsp.HTTPResponse.Header.Get("X-Card-Token"))
+	assert.Equal(t, "session=s3cret", cardRsp.HTTPResponse.Header.Get("Set-Cookie"))
+	require.NotNil(t, orderRsp.JSON201)
+	assert.Equal(t, "4111111111111111", *orderRsp.JSON201.PaymentToken)
+
+	// The cassette holds none of them
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"4111111111111111", "1234", "key1", "s3cret"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	var cassette Cassette
+	require.NoError(t, json.Unmarshal(data, &cassette))
+	require.Len(t, cassette.Interactions, 2)
+	assert.Equal(t, "/cards/REDACTED?api_key=REDACTED&pin=REDACTED", cassette.Interactions[0].Request.URL)
+	assert.Equal(t, []string{CassetteRedacted}, cassette.Interactions[0].Response.Headers.Values("X-Card-Token"))
+	assert.Equal(t, []string{CassetteRedacted}, cassette.Interactions[1].Response.Headers.Values("Set-Cookie"))
+	assert.JSONEq(t, `{"item":"lamp","cardNumber":"REDACTED"}`, cassette.Interactions[1].Request.Body)
+	assert.JSONEq(t, `{"item":"lamp","paymentToken":"REDACTED"}`, cassette.Interactions[1].Response.Body)
+
+	// The requests with other sensitive values match the interactions
+	replayer, err := NewCassetteTransport(path, CassetteReplay, nil)
+	require.NoError(t, err)
+	client, err = NewClientWithResponses("http://replay.invalid", WithHTTPClient(replayer))
+	require.NoError(t, err)
+	cardRsp, orderRsp = bank(t, client, "5500000000000004", "9999", "key2")
+	assert.Equal(t, http.StatusOK, cardRsp.StatusCode())
+	require.NotNil(t, orderRsp.JSON201)
+	assert.Equal(t, CassetteRedacted, *orderRsp.JSON201.PaymentToken)
+}
+
+func TestCaptureRedactsSensitivePathParameters(t *testing.T) {
+	var capture bytes.Buffer
+	server := newBank(t)
+	client, err := NewClientWithResponses(server, WithCapture(&capture))
+	require.NoError(t, err)
+	bank(t, client, "4111111111111111", "1234", "key1")
+
+	assert.NotContains(t, capture.String(), "4111111111111111")
+	assert.NotContains(t, capture.String(), "1234")
+	assert.Contains(t, capture.String(), "/cards/REDACTED?")
+
+	// And replays them to the requests with other values
+	replay, err := NewReplayTransport(&capture)
+	require.NoError(t, err)
+	client, err = NewClientWithResponses(server, WithHTTPClient(replay))
+	require.NoError(t, err)
+	cardRsp, err := client.GetCardWithResponse(context.Background(), "5500000000000004", &GetCardParams{Pin: new(string)}, withAPIKey("key2"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, cardRsp.StatusCode())
+}
+
+func TestCassetteRedactsEscapedPathParameters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.json")
+
+	recorder, err := NewCassetteTransport(path, CassetteRecord, nil)
+	require.NoError(t, err)
+	client, err := NewClientWithResponses(newBank(t), WithHTTPClient(recorder))
+	require.NoError(t, err)
+	bank(t, client, "4111/1111", "1234", "key1")
+	require.NoError(t, recorder.Save())
+
+	// The card number is escaped in the path, and redacted all the same
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "4111")
+	var cassette Cassette
+	require.NoError(t, json.Unmarshal(data, &cassette))
+	assert.Equal(t, "/cards/REDACTED?api_key=REDACTED&pin=REDACTED", cassette.Interactions[0].Request.URL)
+}
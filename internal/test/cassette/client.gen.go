@@ -0,0 +1,1741 @@
+// Package cassette provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Line defines model for Line.
+type Line struct {
+	AddedAt *time.Time `json:"addedAt,omitempty"`
+	Sku     *string    `json:"sku,omitempty"`
+}
+
+// NewOrder defines model for NewOrder.
+type NewOrder struct {
+	CardNumber     *string `json:"cardNumber,omitempty"`
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+	Item           string  `json:"item"`
+	Lines          *[]Line `json:"lines,omitempty"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	Id           *string `json:"id,omitempty"`
+	Item         *string `json:"item,omitempty"`
+	PaymentToken *string `json:"paymentToken,omitempty"`
+}
+
+// GetCardParams defines parameters for GetCard.
+type GetCardParams struct {
+	Pin *string `form:"pin,omitempty" json:"pin,omitempty"`
+}
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	RequestedAt *time.Time `form:"requestedAt,omitempty" json:"requestedAt,omitempty"`
+}
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = NewOrder
+
+// String returns the fields of the NewOrder, with the values of those
+// declared sensitive redacted, keeping them out of logs.
+func (a NewOrder) String() string {
+	return formatLogFields(a.logFields())
+}
+
+// LogValue returns the fields of the NewOrder as a group of slog
+// attributes, with the values of those declared sensitive redacted.
+func (a NewOrder) LogValue() slog.Value {
+	return logFieldsValue(a.logFields())
+}
+
+func (a NewOrder) logFields() []logField {
+	return []logField{
+		{name: "CardNumber", key: "cardNumber", sensitive: true},
+		{name: "IdempotencyKey", key: "idempotencyKey", value: logPointee(a.IdempotencyKey)},
+		{name: "Item", key: "item", value: a.Item},
+		{name: "Lines", key: "lines", value: logPointee(a.Lines)},
+	}
+}
+
+// String returns the fields of the Order, with the values of those
+// declared sensitive redacted, keeping them out of logs.
+func (a Order) String() string {
+	return formatLogFields(a.logFields())
+}
+
+// LogValue returns the fields of the Order as a group of slog
+// attributes, with the values of those declared sensitive redacted.
+func (a Order) LogValue() slog.Value {
+	return logFieldsValue(a.logFields())
+}
+
+func (a Order) logFields() []logField {
+	return []logField{
+		{name: "Id", key: "id", value: logPointee(a.Id)},
+		{name: "Item", key: "item", value: logPointee(a.Item)},
+		{name: "PaymentToken", key: "paymentToken", sensitive: true},
+	}
+}
+
+// String returns the fields of the GetCardParams, with the values of those
+// declared sensitive redacted, keeping them out of logs.
+func (a GetCardParams) String() string {
+	return formatLogFields(a.logFields())
+}
+
+// LogValue returns the fields of the GetCardParams as a group of slog
+// attributes, with the values of those declared sensitive redacted.
+func (a GetCardParams) LogValue() slog.Value {
+	return logFieldsValue(a.logFields())
+}
+
+func (a GetCardParams) logFields() []logField {
+	return []logField{
+		{name: "Pin", key: "pin", sensitive: true},
+	}
+}
+
+// SensitiveRedacted is what the String and LogValue methods of the types
+// print and log for the values of their sensitive fields.
+const SensitiveRedacted = "REDACTED"
+
+// logField is a field of a type with sensitive fields, which String prints
+// and LogValue logs.
+type logField struct {
+	name      string
+	key       string
+	value     interface{}
+	sensitive bool
+}
+
+// formatLogFields formats the fields of a type like %+v does, with the values
+// of the sensitive ones redacted.
+func formatLogFields(fields []logField) string {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, field := range fields {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(field.name + ":")
+		if field.sensitive {
+			b.WriteString(SensitiveRedacted)
+		} else {
+			fmt.Fprintf(&b, "%+v", field.value)
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// logFieldsValue returns the fields of a type as a group of attributes, with
+// the values of the sensitive ones redacted.
+func logFieldsValue(fields []logField) slog.Value {
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		if field.sensitive {
+			attrs[i] = slog.String(field.key, SensitiveRedacted)
+		} else {
+			attrs[i] = slog.Any(field.key, field.value)
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logPointee returns the value of a pointer field, or nil.
+func logPointee[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// The recorder of the requests and responses set with WithCapture, if any.
+	capture *captureRecorder
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetCard request
+	GetCard(ctx context.Context, number string, params *GetCardParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateOrderWithBody request with any body
+	CreateOrderWithBody(ctx context.Context, params *CreateOrderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateOrder(ctx context.Context, params *CreateOrderParams, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLatestOrder request
+	GetLatestOrder(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOrder request
+	GetOrder(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetCard(ctx context.Context, number string, params *GetCardParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCardRequest(c.Server, number, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) CreateOrderWithBody(ctx context.Context, params *CreateOrderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrderRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) CreateOrder(ctx context.Context, params *CreateOrderParams, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrderRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetLatestOrder(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLatestOrderRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetOrder(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOrderRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewGetCardRequest generates requests for GetCard
+func NewGetCardRequest(server string, number string, params *GetCardParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "number", runtime.ParamLocationPath, number)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/cards/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Pin != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pin", runtime.ParamLocationQuery, *params.Pin); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateOrderRequest calls the generic CreateOrder builder with application/json body
+func NewCreateOrderRequest(server string, params *CreateOrderParams, body CreateOrderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateOrderRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateOrderRequestWithBody generates requests for CreateOrder with any type of body
+func NewCreateOrderRequestWithBody(server string, params *CreateOrderParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/orders")
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.RequestedAt != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "requestedAt", runtime.ParamLocationQuery, *params.RequestedAt); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLatestOrderRequest generates requests for GetLatestOrder
+func NewGetLatestOrderRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/orders/latest")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOrderRequest generates requests for GetOrder
+func NewGetOrderRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/orders/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one, recording
+// the request and its response with WithCapture.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	doer := c.Client
+	if d, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && d != nil {
+		doer = d
+	}
+	if c.capture != nil {
+		return &captureDoer{doer: doer, recorder: c.capture}
+	}
+	return doer
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CaptureEntry is a request sent by the client and its response, as recorded
+// by WithCapture, in the shape of the entries of HAR files.
+type CaptureEntry struct {
+	StartedDateTime time.Time       `json:"startedDateTime"`
+	Time            float64         `json:"time"` // How long the request took, in milliseconds
+	Request         CaptureRequest  `json:"request"`
+	Response        CaptureResponse `json:"response"`
+}
+
+// CaptureRequest is a request of a CaptureEntry.
+type CaptureRequest struct {
+	Method   string           `json:"method"`
+	URL      string           `json:"url"`
+	Headers  []CaptureHeader  `json:"headers"`
+	PostData *CapturePostData `json:"postData,omitempty"`
+}
+
+// CapturePostData is the body of a CaptureRequest.
+type CapturePostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"` // base64 for the bodies which aren't text
+}
+
+// CaptureResponse is a response of a CaptureEntry.
+type CaptureResponse struct {
+	Status     int             `json:"status"`
+	StatusText string          `json:"statusText"`
+	Headers    []CaptureHeader `json:"headers"`
+	Content    CaptureContent  `json:"content"`
+}
+
+// CaptureContent is the body of a CaptureResponse.
+type CaptureContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"` // base64 for the bodies which aren't text
+}
+
+// CaptureHeader is a header of a CaptureRequest or a CaptureResponse, given
+// once per value.
+type CaptureHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CaptureRedacted replaces the sensitive values of the captures: the
+// credentials, and the parameters, headers and fields declared with
+// x-sensitive or of the password format.
+const CaptureRedacted = "REDACTED"
+
+// WithCapture makes the client record every request it sends, and the
+// response, to w, as a CaptureEntry per line of JSON, with the sensitive
+// values redacted, to be replayed with a ReplayTransport. The bodies are read
+// in full before the call returns, and the errors writing to w are ignored, so
+// that capturing never fails a call.
+func WithCapture(w io.Writer) ClientOption {
+	return func(c *Client) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		c.capture = &captureRecorder{encoder: encoder}
+		return nil
+	}
+}
+
+// captureRecorder writes the CaptureEntry of the calls of a client.
+type captureRecorder struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func (r *captureRecorder) record(entry CaptureEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.encoder.Encode(entry)
+}
+
+// captureDoer sends requests with another Doer, recording them and their
+// responses.
+type captureDoer struct {
+	doer     HttpRequestDoer
+	recorder *captureRecorder
+}
+
+func (d *captureDoer) Do(req *http.Request) (*http.Response, error) {
+	requestBody, err := captureRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	rsp, err := d.doer.Do(req)
+	if err != nil {
+		return rsp, err
+	}
+	responseBody, err := io.ReadAll(rsp.Body)
+	_ = rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	redaction := findCaptureRedaction(req)
+	entry := CaptureEntry{
+		StartedDateTime: started,
+		Time:            float64(time.Since(started)) / float64(time.Millisecond),
+		Request: CaptureRequest{
+			Method:  req.Method,
+			URL:     redactCaptureURL(req.URL, redaction),
+			Headers: redactCaptureHeaders(req.Header, redaction.headers),
+		},
+		Response: CaptureResponse{
+			Status:     rsp.StatusCode,
+			StatusText: http.StatusText(rsp.StatusCode),
+			Headers:    redactCaptureHeaders(rsp.Header, redaction.responseHeaders),
+			Content: CaptureContent{
+				Size:     len(responseBody),
+				MimeType: rsp.Header.Get("Content-Type"),
+			},
+		},
+	}
+	entry.Response.Content.Text, entry.Response.Content.Encoding = captureBody(responseBody, entry.Response.Content.MimeType, redaction.responseFields)
+	if requestBody != nil {
+		postData := &CapturePostData{MimeType: req.Header.Get("Content-Type")}
+		postData.Text, postData.Encoding = captureBody(requestBody, postData.MimeType, redaction.requestFields)
+		entry.Request.PostData = postData
+	}
+	d.recorder.record(entry)
+	return rsp, nil
+}
+
+// captureRequestBody returns the body of req, or nil when it has none,
+// leaving the body to be sent.
+func captureRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// captureBody returns the text of a body, with its sensitive fields redacted
+// when it's JSON, and its encoding, base64 when it isn't text.
+func captureBody(body []byte, contentType string, fields [][]string) (string, string) {
+	if len(fields) != 0 && strings.Contains(contentType, "json") {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err == nil {
+			for _, field := range fields {
+				value = redactCaptureField(value, field)
+			}
+			if redacted, err := json.Marshal(value); err == nil {
+				body = redacted
+			}
+		}
+	}
+	if !utf8.Valid(body) {
+		return base64.StdEncoding.EncodeToString(body), "base64"
+	}
+	return string(body), ""
+}
+
+// redactCaptureField replaces the values at the path of a field in value,
+// where * stands for any item of an array or value of an object, and the
+// reference of a schema for the paths of its fields.
+func redactCaptureField(value interface{}, field []string) interface{} {
+	if len(field) == 0 {
+		return CaptureRedacted
+	}
+	if fields, ok := captureSchemaFields[field[0]]; ok && len(field) == 1 {
+		for _, field := range fields {
+			value = redactCaptureField(value, field)
+		}
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if field[0] == "*" {
+			for key, item := range v {
+				v[key] = redactCaptureField(item, field[1:])
+			}
+		} else if item, ok := v[field[0]]; ok {
+			v[field[0]] = redactCaptureField(item, field[1:])
+		}
+	case []interface{}:
+		if field[0] == "*" {
+			for i, item := range v {
+				v[i] = redactCaptureField(item, field[1:])
+			}
+		}
+	}
+	return value
+}
+
+// redactCaptureURL returns the URL of a request, with its sensitive path and
+// query parameters redacted.
+func redactCaptureURL(u *url.URL, redaction captureRedaction) string {
+	redactedURL := *u
+	if redaction.path != nil {
+		if loc := redaction.path.FindStringSubmatchIndex(u.EscapedPath()); len(loc) > 2 {
+			path := u.EscapedPath()
+			for i := len(loc) - 2; i >= 2; i -= 2 {
+				if loc[i] >= 0 {
+					path = path[:loc[i]] + CaptureRedacted + path[loc[i+1]:]
+				}
+			}
+			redactedURL.Path, _ = url.PathUnescape(path)
+			redactedURL.RawPath = path
+		}
+	}
+	query := u.Query()
+	redacted := false
+	for _, names := range [][]string{captureSensitiveQuery, redaction.query} {
+		for _, name := range names {
+			if values, ok := query[name]; ok {
+				for i := range values {
+					values[i] = CaptureRedacted
+				}
+				redacted = true
+			}
+		}
+	}
+	if redacted {
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.String()
+}
+
+// redactCaptureHeaders returns the headers of a request or a response, sorted
+// by name, with the credentials and the given sensitive headers redacted.
+func redactCaptureHeaders(header http.Header, sensitive []string) []CaptureHeader {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headers := make([]CaptureHeader, 0, len(names))
+	for _, name := range names {
+		redacted := false
+		for _, names := range [][]string{captureSensitiveHeaders, sensitive} {
+			for _, s := range names {
+				redacted = redacted || http.CanonicalHeaderKey(name) == s
+			}
+		}
+		for _, value := range header[name] {
+			if redacted {
+				value = CaptureRedacted
+			}
+			headers = append(headers, CaptureHeader{Name: name, Value: value})
+		}
+	}
+	return headers
+}
+
+// captureSensitiveHeaders are the headers redacted from all the captures, the
+// credentials.
+var captureSensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// captureSensitiveQuery are the query parameters redacted from all the
+// captures, the API keys.
+var captureSensitiveQuery = []string{"api_key"}
+
+// captureSchemaFields holds the paths of the sensitive values of the schemas
+// which those of the operations reference, keyed by reference.
+var captureSchemaFields = map[string][][]string{
+	"#/components/schemas/NewOrder": {{"cardNumber"}},
+	"#/components/schemas/Order":    {{"paymentToken"}},
+}
+
+// captureRedaction holds the sensitive values of the requests and responses
+// of an operation.
+type captureRedaction struct {
+	method          string
+	path            *regexp.Regexp // Capturing the sensitive path parameters
+	headers         []string
+	query           []string
+	requestFields   [][]string
+	responseFields  [][]string
+	responseHeaders []string
+}
+
+// captureRedactions holds the sensitive values of the operations which have
+// some of their own.
+var captureRedactions = []captureRedaction{
+	// GetCard
+	{
+		method:          "GET",
+		path:            regexp.MustCompile("^/cards/([^/]*)$"),
+		query:           []string{"pin"},
+		responseFields:  [][]string{{"#/components/schemas/Order"}},
+		responseHeaders: []string{"X-Card-Token"},
+	},
+	// CreateOrder
+	{
+		method:         "POST",
+		path:           regexp.MustCompile("^/orders$"),
+		requestFields:  [][]string{{"#/components/schemas/NewOrder"}},
+		responseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+	// GetLatestOrder
+	{
+		method:         "GET",
+		path:           regexp.MustCompile("^/orders/latest$"),
+		responseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+	// GetOrder
+	{
+		method:         "GET",
+		path:           regexp.MustCompile("^/orders/[^/]*$"),
+		responseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+}
+
+// findCaptureRedaction returns the sensitive values of the operation of req,
+// none when it has none of its own.
+func findCaptureRedaction(req *http.Request) captureRedaction {
+	for _, redaction := range captureRedactions {
+		if redaction.method == req.Method && redaction.path.MatchString(req.URL.EscapedPath()) {
+			return redaction
+		}
+	}
+	return captureRedaction{}
+}
+
+// ReplayTransport answers requests with the responses recorded by
+// WithCapture, for tests running offline. Each captured response answers one
+// request, with the method and the URL of its own, sensitive parameters
+// aside, in the order they were captured. It's both an http.RoundTripper, and
+// a Doer to pass to WithHTTPClient.
+type ReplayTransport struct {
+	mu       sync.Mutex
+	entries  []CaptureEntry
+	replayed []bool
+}
+
+// NewReplayTransport returns a ReplayTransport answering requests with the
+// captures read from r, written by WithCapture.
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	var t ReplayTransport
+	decoder := json.NewDecoder(r)
+	for {
+		var entry CaptureEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading capture %d: %w", len(t.entries)+1, err)
+		}
+		t.entries = append(t.entries, entry)
+	}
+	t.replayed = make([]bool, len(t.entries))
+	return &t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	u := redactCaptureURL(req.URL, findCaptureRedaction(req))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, entry := range t.entries {
+		if t.replayed[i] || entry.Request.Method != req.Method || entry.Request.URL != u {
+			continue
+		}
+		t.replayed[i] = true
+		body := []byte(entry.Response.Content.Text)
+		if entry.Response.Content.Encoding == "base64" {
+			var err error
+			if body, err = base64.StdEncoding.DecodeString(entry.Response.Content.Text); err != nil {
+				return nil, fmt.Errorf("error decoding the captured response to %s %s: %w", req.Method, u, err)
+			}
+		}
+		header := make(http.Header)
+		for _, h := range entry.Response.Headers {
+			header.Add(h.Name, h.Value)
+		}
+		// The body replayed is the one captured, with its sensitive values redacted
+		header.Del("Content-Length")
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText),
+			StatusCode:    entry.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no captured response to %s %s left to replay", req.Method, u)
+}
+
+func (t *ReplayTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
+
+// CassetteMode is how a CassetteTransport uses its cassette.
+type CassetteMode int
+
+const (
+	// CassetteAuto replays the cassette when its file exists, and records it
+	// otherwise, on the first run of a test.
+	CassetteAuto CassetteMode = iota
+	// CassetteReplay replays the cassette, failing when its file doesn't
+	// exist.
+	CassetteReplay
+	// CassetteRecord records the cassette, replacing its file.
+	CassetteRecord
+)
+
+// Cassette is the recording of the interactions of a client with a server.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is a request of a Cassette and its response.
+type CassetteInteraction struct {
+	OperationID string           `json:"operationId,omitempty"`
+	Request     CassetteRequest  `json:"request"`
+	Response    CassetteResponse `json:"response"`
+}
+
+// CassetteRedacted is what a CassetteTransport records for the sensitive
+// values of the interactions, those which WithCapture redacts.
+const CassetteRedacted = "REDACTED"
+
+// CassetteRequest is a request of a CassetteInteraction. Its headers aren't
+// recorded, nor matched.
+type CassetteRequest struct {
+	Method   string `json:"method"`
+	URL      string `json:"url"` // The path and the query, without the server
+	Body     string `json:"body,omitempty"`
+	Encoding string `json:"encoding,omitempty"` // base64 for the bodies which aren't text
+}
+
+// CassetteResponse is a response of a CassetteInteraction. Its headers
+// carrying credentials, like Set-Cookie, are recorded redacted.
+type CassetteResponse struct {
+	Status   int         `json:"status"`
+	Headers  http.Header `json:"headers,omitempty"`
+	Body     string      `json:"body,omitempty"`
+	Encoding string      `json:"encoding,omitempty"` // base64 for the bodies which aren't text
+}
+
+// CassetteTransport is an http.RoundTripper recording the interactions of a
+// client with a server to a cassette file, on the first run of a test, and
+// replaying them afterwards, for deterministic integration tests. It matches a
+// request with an interaction of the same operation, method, path, query and
+// JSON body, normalized, but for the values declared with x-volatile, in
+// the order they were recorded, each interaction answering one request. It
+// records the sensitive values of the interactions redacted, like WithCapture
+// does, and doesn't match them either. It's also a Doer to pass to
+// WithHTTPClient.
+type CassetteTransport struct {
+	path      string
+	next      http.RoundTripper
+	recording bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	keys     []string
+	replayed []bool
+}
+
+// NewCassetteTransport returns a CassetteTransport using the cassette at
+// path as the mode says, which sends the requests it records with next, or
+// http.DefaultTransport when nil.
+func NewCassetteTransport(path string, mode CassetteMode, next http.RoundTripper) (*CassetteTransport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &CassetteTransport{path: path, next: next, recording: mode == CassetteRecord}
+	if t.recording {
+		return t, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == CassetteAuto {
+		t.recording = true
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the cassette %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return nil, fmt.Errorf("error reading the cassette %s: %w", path, err)
+	}
+	for i, interaction := range t.cassette.Interactions {
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error reading interaction %d of the cassette %s: %w", i+1, path, err)
+		}
+		body, err := cassetteDecodeBody(interaction.Request.Body, interaction.Request.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error reading interaction %d of the cassette %s: %w", i+1, path, err)
+		}
+		_, key := cassetteKey(interaction.Request.Method, u, body)
+		t.keys = append(t.keys, key)
+	}
+	t.replayed = make([]bool, len(t.cassette.Interactions))
+	return t, nil
+}
+
+// Recording returns whether the transport records its cassette, rather than
+// replay it.
+func (t *CassetteTransport) Recording() bool {
+	return t.recording
+}
+
+// Save writes the cassette to its file, creating its directory, once
+// recorded. It does nothing when the cassette is replayed.
+func (t *CassetteTransport) Save() error {
+	if !t.recording {
+		return nil
+	}
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return fmt.Errorf("error writing the cassette %s: %w", t.path, err)
+	}
+	if err := os.WriteFile(t.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing the cassette %s: %w", t.path, err)
+	}
+	return nil
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := cassetteRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	operationID, key := cassetteKey(req.Method, req.URL, body)
+	if t.recording {
+		return t.record(req, operationID, body)
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.cassette.Interactions {
+		if t.replayed[i] || t.keys[i] != key {
+			continue
+		}
+		t.replayed[i] = true
+		body, err := cassetteDecodeBody(interaction.Response.Body, interaction.Response.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error reading interaction %d of the cassette %s: %w", i+1, t.path, err)
+		}
+		header := interaction.Response.Headers.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	if operationID != "" {
+		return nil, fmt.Errorf("no interaction of the cassette %s left matches the %s request %s %s", t.path, operationID, req.Method, req.URL.RequestURI())
+	}
+	return nil, fmt.Errorf("no interaction of the cassette %s left matches the request %s %s", t.path, req.Method, req.URL.RequestURI())
+}
+
+func (t *CassetteTransport) Do(req *http.Request) (*http.Response, error) {
+	return t.RoundTrip(req)
+}
+
+// record sends req, and records it and its response.
+func (t *CassetteTransport) record(req *http.Request, operationID string, requestBody []byte) (*http.Response, error) {
+	rsp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(rsp.Body)
+	_ = rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	operation, loc := findCassetteOperation(req.Method, req.URL.EscapedPath())
+	interaction := CassetteInteraction{
+		OperationID: operationID,
+		Request:     CassetteRequest{Method: req.Method, URL: cassetteRedactURL(req.URL, operation, loc)},
+		Response:    CassetteResponse{Status: rsp.StatusCode, Headers: rsp.Header.Clone()},
+	}
+	for _, names := range [][]string{operation.redactedResponseHeaders, cassetteRedactedHeaders} {
+		for _, name := range names {
+			if values := interaction.Response.Headers.Values(name); len(values) != 0 {
+				interaction.Response.Headers.Set(name, CassetteRedacted)
+			}
+		}
+	}
+	interaction.Request.Body, interaction.Request.Encoding = cassetteEncodeBody(cassetteRedactBody(requestBody, operation.redactedFields))
+	interaction.Response.Body, interaction.Response.Encoding = cassetteEncodeBody(cassetteRedactBody(responseBody, operation.redactedResponseFields))
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	t.mu.Unlock()
+	return rsp, nil
+}
+
+// cassetteRequestBody returns the body of req, or nil when it has none,
+// leaving the body to be sent.
+func cassetteRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// cassetteEncodeBody returns the text of a body, and its encoding, base64
+// when it isn't text.
+func cassetteEncodeBody(body []byte) (string, string) {
+	if !utf8.Valid(body) {
+		return base64.StdEncoding.EncodeToString(body), "base64"
+	}
+	return string(body), ""
+}
+
+// cassetteDecodeBody returns the body of a text of the given encoding.
+func cassetteDecodeBody(text, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(text)
+	}
+	return []byte(text), nil
+}
+
+// findCassetteOperation returns the operation of a request, and the indexes
+// of the groups its path pattern captures in the escaped path, or none when the
+// request isn't that of an operation.
+func findCassetteOperation(method, path string) (cassetteOperation, []int) {
+	for _, operation := range cassetteOperations {
+		if operation.method != method {
+			continue
+		}
+		if loc := operation.path.FindStringSubmatchIndex(path); loc != nil {
+			return operation, loc
+		}
+	}
+	return cassetteOperation{}, nil
+}
+
+// cassetteKey returns the operationId of a request, and the key matching it
+// with the interactions: its operation, method, path and query, and JSON
+// body, normalized, with the volatile and sensitive values left out.
+func cassetteKey(method string, u *url.URL, body []byte) (string, string) {
+	operation, loc := findCassetteOperation(method, u.EscapedPath())
+	path := u.EscapedPath()
+	for i := len(loc) - 2; i >= 2; i -= 2 {
+		if loc[i] >= 0 {
+			path = path[:loc[i]] + "*" + path[loc[i+1]:]
+		}
+	}
+
+	query := u.Query()
+	for _, names := range [][]string{operation.volatileQuery, operation.redactedQuery, cassetteRedactedQuery} {
+		for _, name := range names {
+			if _, ok := query[name]; ok {
+				query[name] = []string{"*"}
+			}
+		}
+	}
+
+	if value, ok := cassetteDecodeJSON(body); ok {
+		for _, field := range operation.volatileFields {
+			value = cassetteReplaceField(value, field, cassetteSchemaFields, "*")
+		}
+		for _, field := range operation.redactedFields {
+			value = cassetteReplaceField(value, field, cassetteRedactedSchemaFields, "*")
+		}
+		if normalized, err := json.Marshal(value); err == nil {
+			body = normalized
+		}
+	}
+	return operation.operationID, fmt.Sprintf("%s %s %s?%s\n%s", operation.operationID, method, path, query.Encode(), body)
+}
+
+// cassetteDecodeJSON returns the value of a body holding a single JSON value.
+func cassetteDecodeJSON(body []byte) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+	return value, true
+}
+
+// cassetteRedactURL returns the path and the query of the URL of a request of
+// the operation, whose path pattern captures loc in the path, with the
+// sensitive parameters redacted.
+func cassetteRedactURL(u *url.URL, operation cassetteOperation, loc []int) string {
+	redactedURL := *u
+	if len(operation.redactedGroups) != 0 && loc != nil {
+		path := u.EscapedPath()
+		for i := len(operation.redactedGroups) - 1; i >= 0; i-- {
+			group := operation.redactedGroups[i]
+			if loc[2*group] >= 0 {
+				path = path[:loc[2*group]] + CassetteRedacted + path[loc[2*group+1]:]
+			}
+		}
+		redactedURL.Path, _ = url.PathUnescape(path)
+		redactedURL.RawPath = path
+	}
+	query := u.Query()
+	redacted := false
+	for _, names := range [][]string{operation.redactedQuery, cassetteRedactedQuery} {
+		for _, name := range names {
+			if values, ok := query[name]; ok {
+				for i := range values {
+					values[i] = CassetteRedacted
+				}
+				redacted = true
+			}
+		}
+	}
+	if redacted {
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.RequestURI()
+}
+
+// cassetteRedactBody returns a JSON body with the values at the paths of the
+// sensitive fields redacted, and other bodies as they are.
+func cassetteRedactBody(body []byte, fields [][]string) []byte {
+	if len(fields) == 0 {
+		return body
+	}
+	value, ok := cassetteDecodeJSON(body)
+	if !ok {
+		return body
+	}
+	for _, field := range fields {
+		value = cassetteReplaceField(value, field, cassetteRedactedSchemaFields, CassetteRedacted)
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// cassetteReplaceField replaces the values at the path of a field in value
+// with replacement, where * stands for any item of an array or value of an
+// object, and the reference of a schema for the paths of its fields in
+// schemaFields.
+func cassetteReplaceField(value interface{}, field []string, schemaFields map[string][][]string, replacement string) interface{} {
+	if len(field) == 0 {
+		return replacement
+	}
+	if fields, ok := schemaFields[field[0]]; ok && len(field) == 1 {
+		for _, field := range fields {
+			value = cassetteReplaceField(value, field, schemaFields, replacement)
+		}
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if field[0] == "*" {
+			for key, item := range v {
+				v[key] = cassetteReplaceField(item, field[1:], schemaFields, replacement)
+			}
+		} else if item, ok := v[field[0]]; ok {
+			v[field[0]] = cassetteReplaceField(item, field[1:], schemaFields, replacement)
+		}
+	case []interface{}:
+		if field[0] == "*" {
+			for i, item := range v {
+				v[i] = cassetteReplaceField(item, field[1:], schemaFields, replacement)
+			}
+		}
+	}
+	return value
+}
+
+// cassetteOperation holds how a CassetteTransport matches the requests of an
+// operation, and what it redacts from its interactions.
+type cassetteOperation struct {
+	operationID             string
+	method                  string
+	path                    *regexp.Regexp // Capturing the volatile and sensitive path parameters
+	volatileQuery           []string
+	volatileFields          [][]string
+	redactedGroups          []int // The groups of path capturing the sensitive path parameters
+	redactedQuery           []string
+	redactedFields          [][]string
+	redactedResponseFields  [][]string
+	redactedResponseHeaders []string
+}
+
+// cassetteOperations holds how the requests of the operations are matched,
+// those of the most specific paths first.
+var cassetteOperations = []cassetteOperation{
+	{
+		operationID:            "CreateOrder",
+		method:                 "POST",
+		path:                   regexp.MustCompile("^/orders$"),
+		volatileQuery:          []string{"requestedAt"},
+		volatileFields:         [][]string{{"#/components/schemas/NewOrder"}},
+		redactedFields:         [][]string{{"#/components/schemas/NewOrder"}},
+		redactedResponseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+	{
+		operationID:            "GetLatestOrder",
+		method:                 "GET",
+		path:                   regexp.MustCompile("^/orders/latest$"),
+		redactedResponseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+	{
+		operationID:             "GetCard",
+		method:                  "GET",
+		path:                    regexp.MustCompile("^/cards/([^/]*)$"),
+		redactedGroups:          []int{1},
+		redactedQuery:           []string{"pin"},
+		redactedResponseFields:  [][]string{{"#/components/schemas/Order"}},
+		redactedResponseHeaders: []string{"X-Card-Token"},
+	},
+	{
+		operationID:            "GetOrder",
+		method:                 "GET",
+		path:                   regexp.MustCompile("^/orders/([^/]*)$"),
+		redactedResponseFields: [][]string{{"#/components/schemas/Order"}},
+	},
+}
+
+// cassetteRedactedHeaders holds the headers redacted from all the responses,
+// those carrying credentials, like Set-Cookie, and those of the API keys.
+var cassetteRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// cassetteRedactedQuery holds the query parameters redacted from all the
+// requests, like those of the API keys.
+var cassetteRedactedQuery = []string{"api_key"}
+
+// cassetteSchemaFields holds the paths of the volatile values of the schemas
+// which those of the operations reference, keyed by reference.
+var cassetteSchemaFields = map[string][][]string{
+	"#/components/schemas/Line":     {{"addedAt"}},
+	"#/components/schemas/NewOrder": {{"idempotencyKey"}, {"lines", "*", "#/components/schemas/Line"}},
+}
+
+// cassetteRedactedSchemaFields holds the paths of the sensitive values of the
+// schemas which those of the operations reference, keyed by reference.
+var cassetteRedactedSchemaFields = map[string][][]string{
+	"#/components/schemas/NewOrder": {{"cardNumber"}},
+	"#/components/schemas/Order":    {{"paymentToken"}},
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetCardWithResponse request
+	GetCardWithResponse(ctx context.Context, number string, params *GetCardParams, reqEditors ...RequestEditorFn) (*GetCardResponse, error)
+
+	// CreateOrderWithBodyWithResponse request with any body
+	CreateOrderWithBodyWithResponse(ctx context.Context, params *CreateOrderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error)
+
+	CreateOrderWithResponse(ctx context.Context, params *CreateOrderParams, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error)
+
+	// GetLatestOrderWithResponse request
+	GetLatestOrderWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLatestOrderResponse, error)
+
+	// GetOrderWithResponse request
+	GetOrderWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetOrderResponse, error)
+}
+
+type GetCardResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Order
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCardResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCardResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetCardResponse) Success() (Order, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Order
+	return zero, false
+}
+
+type CreateOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Order
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r CreateOrderResponse) Success() (Order, bool) {
+	if r.JSON201 != nil {
+		return *r.JSON201, true
+	}
+	var zero Order
+	return zero, false
+}
+
+type GetLatestOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Order
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLatestOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLatestOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetLatestOrderResponse) Success() (Order, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Order
+	return zero, false
+}
+
+type GetOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Order
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetOrderResponse) Success() (Order, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Order
+	return zero, false
+}
+
+// GetCardWithResponse request returning *GetCardResponse
+func (c *ClientWithResponses) GetCardWithResponse(ctx context.Context, number string, params *GetCardParams, reqEditors ...RequestEditorFn) (*GetCardResponse, error) {
+	rsp, err := c.GetCard(ctx, number, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCardResponse(rsp)
+}
+
+// CreateOrderWithBodyWithResponse request with arbitrary body returning *CreateOrderResponse
+func (c *ClientWithResponses) CreateOrderWithBodyWithResponse(ctx context.Context, params *CreateOrderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error) {
+	rsp, err := c.CreateOrderWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrderResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateOrderWithResponse(ctx context.Context, params *CreateOrderParams, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error) {
+	rsp, err := c.CreateOrder(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrderResponse(rsp)
+}
+
+// GetLatestOrderWithResponse request returning *GetLatestOrderResponse
+func (c *ClientWithResponses) GetLatestOrderWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLatestOrderResponse, error) {
+	rsp, err := c.GetLatestOrder(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLatestOrderResponse(rsp)
+}
+
+// GetOrderWithResponse request returning *GetOrderResponse
+func (c *ClientWithResponses) GetOrderWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetOrderResponse, error) {
+	rsp, err := c.GetOrder(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOrderResponse(rsp)
+}
+
+// ParseGetCardResponse parses an HTTP response from a GetCardWithResponse call
+func ParseGetCardResponse(rsp *http.Response) (*GetCardResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCardResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateOrderResponse parses an HTTP response from a CreateOrderWithResponse call
+func ParseCreateOrderResponse(rsp *http.Response) (*CreateOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLatestOrderResponse parses an HTTP response from a GetLatestOrderWithResponse call
+func ParseGetLatestOrderResponse(rsp *http.Response) (*GetLatestOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLatestOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOrderResponse parses an HTTP response from a GetOrderWithResponse call
+func ParseGetOrderResponse(rsp *http.Response) (*GetOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
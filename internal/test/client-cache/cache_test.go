@@ -0,0 +1,107 @@
+package clientcache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCatalog returns a client of a server answering the listing of the
+// products with the given Cache-Control and Vary headers, and with the
+// credentials and language of the request, counting the requests it gets.
+func newCatalog(t *testing.T, cacheControl, vary string) (*Client, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Header().Set("Cache-Control", cacheControl)
+		if vary != "" {
+			w.Header().Set("Vary", vary)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, r.Header.Get("Authorization")+r.Header.Get("X-Api-Key")+r.Header.Get("Accept-Language"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+	return client, &requests
+}
+
+// listProducts returns the body of the listing of the products, sent with
+// the given header.
+func listProducts(t *testing.T, client *Client, name, value string) string {
+	rsp, err := client.ListProducts(context.Background(), func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	})
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCacheKeyedOnCredentials(t *testing.T) {
+	client, requests := newCatalog(t, "max-age=60", "")
+
+	assert.Equal(t, "Bearer alice", listProducts(t, client, "Authorization", "Bearer alice"))
+	assert.Equal(t, "Bearer bob", listProducts(t, client, "Authorization", "Bearer bob"))
+	assert.Equal(t, "Bearer alice", listProducts(t, client, "Authorization", "Bearer alice"))
+	assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+
+	// Those of the apiKey schemes of the spec too
+	assert.Equal(t, "key1", listProducts(t, client, "X-Api-Key", "key1"))
+	assert.Equal(t, "key2", listProducts(t, client, "X-Api-Key", "key2"))
+	assert.EqualValues(t, 4, atomic.LoadInt32(requests))
+}
+
+func TestCacheKeyedOnVary(t *testing.T) {
+	client, requests := newCatalog(t, "max-age=60", "Accept-Language")
+
+	assert.Equal(t, "de", listProducts(t, client, "Accept-Language", "de"))
+	assert.Equal(t, "fr", listProducts(t, client, "Accept-Language", "fr"))
+	// Both variants stay cached
+	assert.Equal(t, "de", listProducts(t, client, "Accept-Language", "de"))
+	assert.Equal(t, "fr", listProducts(t, client, "Accept-Language", "fr"))
+	assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+}
+
+func TestCacheSkipsPrivateAndNoStore(t *testing.T) {
+	for _, cacheControl := range []string{"private, max-age=60", "no-store", "max-age=60, Private"} {
+		t.Run(cacheControl, func(t *testing.T) {
+			client, requests := newCatalog(t, cacheControl, "")
+
+			listProducts(t, client, "Accept-Language", "de")
+			listProducts(t, client, "Accept-Language", "de")
+			assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+		})
+	}
+}
+
+func TestCacheInvalidatedByUnsafeRequests(t *testing.T) {
+	client, requests := newCatalog(t, "max-age=60", "")
+
+	listProducts(t, client, "Authorization", "Bearer alice")
+	listProducts(t, client, "Authorization", "Bearer alice")
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+
+	rsp, err := client.CreateProductWithBody(context.Background(), "text/plain", strings.NewReader("lamp"))
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, http.StatusCreated, rsp.StatusCode)
+
+	// The responses to the URL are gone, whatever their credentials
+	listProducts(t, client, "Authorization", "Bearer alice")
+	assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+}
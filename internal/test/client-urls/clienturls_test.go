@@ -0,0 +1,117 @@
+package clienturls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinServerURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		server        string
+		operationPath string
+		want          string
+	}{
+		{"host only", "https://api.example.com", "/files", "https://api.example.com/files"},
+		{"host with trailing slash", "https://api.example.com/", "/files", "https://api.example.com/files"},
+		{"base path", "https://api.example.com/v1", "/files", "https://api.example.com/v1/files"},
+		{"base path with trailing slash", "https://api.example.com/v1/", "/files", "https://api.example.com/v1/files"},
+		{"nested base path", "https://api.example.com/api/v1", "/files", "https://api.example.com/api/v1/files"},
+		{"escaped base path", "https://api.example.com/a%2Fb/", "/files", "https://api.example.com/a%2Fb/files"},
+		{"escaped operation path", "https://api.example.com", "/files/a%2Fb%20c", "https://api.example.com/files/a%2Fb%20c"},
+		{"port", "http://localhost:8080/v1", "/files", "http://localhost:8080/v1/files"},
+		{"query on server", "https://api.example.com/v1?key=abc", "/files", "https://api.example.com/v1/files?key=abc"},
+		{"relative server", "/v1", "/files", "/v1/files"},
+		{"empty server", "", "/files", "/files"},
+		{"root operation path", "https://api.example.com/v1", "/", "https://api.example.com/v1/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := joinServerURL(tt.server, tt.operationPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, u.String())
+		})
+	}
+
+	t.Run("invalid server", func(t *testing.T) {
+		_, err := joinServerURL("://api.example.com", "/files")
+		assert.Error(t, err)
+	})
+	t.Run("invalid escape in server path", func(t *testing.T) {
+		_, err := joinServerURL("https://api.example.com/%zz", "/files")
+		assert.Error(t, err)
+	})
+}
+
+func TestPathParamEscaping(t *testing.T) {
+	const server = "https://api.example.com/v1"
+
+	t.Run("styled", func(t *testing.T) {
+		tests := map[string]string{
+			"plain":      "/v1/files/plain",
+			"a/b c":      "/v1/files/a%2Fb%20c",
+			"a?b#c":      "/v1/files/a%3Fb%23c",
+			"semi;colon": "/v1/files/semi%3Bcolon",
+			"100%":       "/v1/files/100%25",
+			"..":         "/v1/files/..",
+			"":           "/v1/files/",
+		}
+		for value, want := range tests {
+			req, err := NewGetFileRequest(server, value)
+			require.NoError(t, err)
+			assert.Equal(t, want, req.URL.EscapedPath(), value)
+			assert.Equal(t, "api.example.com", req.URL.Host)
+		}
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		req, err := NewGetArchiveRequest(server, 2023, 7)
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.example.com/v1/archive/2023/7", req.URL.String())
+	})
+
+	t.Run("pass through", func(t *testing.T) {
+		req, err := NewGetRawRequest(server, "x/y;z,w")
+		require.NoError(t, err)
+		assert.Equal(t, "/v1/raw/x%2Fy%3Bz%2Cw", req.URL.EscapedPath())
+		assert.Equal(t, "/v1/raw/x/y;z,w", req.URL.Path)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		name := "a/b"
+		req, err := NewGetJSONRequest(server, Filter{Name: &name})
+		require.NoError(t, err)
+		assert.Equal(t, `/v1/json/%7B%22name%22:%22a%2Fb%22%7D`, req.URL.EscapedPath())
+		assert.Equal(t, `/v1/json/{"name":"a/b"}`, req.URL.Path)
+	})
+}
+
+func TestQueryEscaping(t *testing.T) {
+	const server = "https://api.example.com/v1/"
+	const reserved = "a/b:c?d@e!f$g'h(i)j*k,l[m]"
+
+	t.Run("allowReserved", func(t *testing.T) {
+		req, err := NewSearchRequest(server, &SearchParams{Q: reserved})
+		require.NoError(t, err)
+		assert.Equal(t, "q="+reserved, req.URL.RawQuery)
+		assert.Equal(t, reserved, req.URL.Query().Get("q"))
+	})
+
+	t.Run("query delimiters are escaped despite allowReserved", func(t *testing.T) {
+		req, err := NewSearchRequest(server, &SearchParams{Q: "a&b=c+d#e;f g"})
+		require.NoError(t, err)
+		assert.Equal(t, "q=a%26b%3Dc%2Bd%23e%3Bf+g", req.URL.RawQuery)
+		assert.Equal(t, "a&b=c+d#e;f g", req.URL.Query().Get("q"))
+	})
+
+	t.Run("other parameters are escaped", func(t *testing.T) {
+		page := 2
+		escaped := "a/b:c"
+		req, err := NewSearchRequest(server, &SearchParams{Q: "x/y", Escaped: &escaped, Page: &page})
+		require.NoError(t, err)
+		assert.Equal(t, "escaped=a%2Fb%3Ac&page=2&q=x/y", req.URL.RawQuery)
+		assert.Equal(t, "/v1/search", req.URL.EscapedPath())
+	})
+}
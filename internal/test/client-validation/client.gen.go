@@ -0,0 +1,915 @@
+// Package clientvalidation provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package clientvalidation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for PutThingParamsSort.
+const (
+	Asc  PutThingParamsSort = "asc"
+	Desc PutThingParamsSort = "desc"
+)
+
+// Gadget defines model for Gadget.
+type Gadget struct {
+	Id     *string  `json:"id,omitempty"`
+	Labels *Labels  `json:"labels,omitempty"`
+	Name   string   `json:"name"`
+	Parts  []Part   `json:"parts"`
+	Weight *float32 `json:"weight,omitempty"`
+}
+
+// Labels defines model for Labels.
+type Labels map[string]string
+
+// Part defines model for Part.
+type Part struct {
+	Sku string `json:"sku"`
+}
+
+// CreateGadgetParams defines parameters for CreateGadget.
+type CreateGadgetParams struct {
+	Level *int32 `form:"level,omitempty" json:"level,omitempty"`
+}
+
+// PingParams defines parameters for Ping.
+type PingParams struct {
+	Free *string `form:"free,omitempty" json:"free,omitempty"`
+}
+
+// PutThingParams defines parameters for PutThing.
+type PutThingParams struct {
+	Name  string              `form:"name" json:"name"`
+	Sort  *PutThingParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Ratio *float32            `form:"ratio,omitempty" json:"ratio,omitempty"`
+	Tags  *[]string           `form:"tags,omitempty" json:"tags,omitempty"`
+	XStep *int                `json:"X-Step,omitempty"`
+}
+
+// PutThingParamsSort defines parameters for PutThing.
+type PutThingParamsSort string
+
+// CreateGadgetJSONRequestBody defines body for CreateGadget for application/json ContentType.
+type CreateGadgetJSONRequestBody = Gadget
+
+// PutThingJSONRequestBody defines body for PutThing for application/json ContentType.
+type PutThingJSONRequestBody = Labels
+
+// Validate checks the Labels against the constraints of the spec on
+// its property names and number of properties.
+func (a Labels) Validate() error {
+	properties := a
+	count := len(properties)
+	if count > 1 {
+		return fmt.Errorf("%d properties, more than the maximum of 1", count)
+	}
+	return nil
+}
+
+// validateRequest checks the Gadget of a request body against the
+// constraints of the spec on its properties.
+func (a Gadget) validateRequest() error {
+	var problems []string
+	if a.Labels != nil {
+		v := *a.Labels
+		if err := validateRequestValue(v); err != nil {
+			problems = append(problems, fmt.Sprintf("property %q: %s", "labels", err))
+		}
+	}
+	{
+		v := a.Name
+		if utf8.RuneCountInString(string(v)) < 1 {
+			problems = append(problems, "property \"name\" must be at least 1 characters long")
+		}
+		if utf8.RuneCountInString(string(v)) > 10 {
+			problems = append(problems, "property \"name\" must be at most 10 characters long")
+		}
+	}
+	if a.Parts == nil {
+		problems = append(problems, "property \"parts\" is required")
+	}
+	{
+		v := a.Parts
+		if len(v) > 2 {
+			problems = append(problems, "property \"parts\" must have at most 2 items")
+		}
+		for i, item := range v {
+			if err := validateRequestValue(item); err != nil {
+				problems = append(problems, fmt.Sprintf("property %q: item %d: %s", "parts", i, err))
+			}
+		}
+	}
+	if a.Weight != nil {
+		v := *a.Weight
+		if v < 0 {
+			problems = append(problems, "property \"weight\" must be at least 0")
+		}
+	}
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateRequest checks the Part of a request body against the
+// constraints of the spec on its properties.
+func (a Part) validateRequest() error {
+	var problems []string
+	{
+		v := a.Sku
+		if !matchesPattern("^[A-Z]{3}$", string(v)) {
+			problems = append(problems, "property \"sku\" must match the pattern ^[A-Z]{3}$")
+		}
+	}
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// CreateGadgetWithBody request with any body
+	CreateGadgetWithBody(ctx context.Context, params *CreateGadgetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateGadget(ctx context.Context, params *CreateGadgetParams, body CreateGadgetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Ping request
+	Ping(ctx context.Context, params *PingParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutThingWithBody request with any body
+	PutThingWithBody(ctx context.Context, id int32, params *PutThingParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutThing(ctx context.Context, id int32, params *PutThingParams, body PutThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) CreateGadgetWithBody(ctx context.Context, params *CreateGadgetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateGadgetRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) CreateGadget(ctx context.Context, params *CreateGadgetParams, body CreateGadgetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateGadgetRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) Ping(ctx context.Context, params *PingParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPingRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) PutThingWithBody(ctx context.Context, id int32, params *PutThingParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutThingRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) PutThing(ctx context.Context, id int32, params *PutThingParams, body PutThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutThingRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewCreateGadgetRequest calls the generic CreateGadget builder with application/json body
+func NewCreateGadgetRequest(server string, params *CreateGadgetParams, body CreateGadgetJSONRequestBody) (*http.Request, error) {
+	if fields := validateRequestBody(validateCreateGadgetParams(params), body); len(fields) != 0 {
+		return nil, &RequestValidationError{OperationID: "CreateGadget", Fields: fields}
+	}
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateGadgetRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateGadgetRequestWithBody generates requests for CreateGadget with any type of body
+func NewCreateGadgetRequestWithBody(server string, params *CreateGadgetParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+	if fields := validateCreateGadgetParams(params); len(fields) != 0 {
+		return nil, &RequestValidationError{OperationID: "CreateGadget", Fields: fields}
+	}
+
+	queryURL, err := joinServerURL(server, "/gadgets")
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Level != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "level", runtime.ParamLocationQuery, *params.Level); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPingRequest generates requests for Ping
+func NewPingRequest(server string, params *PingParams) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/ping")
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Free != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "free", runtime.ParamLocationQuery, *params.Free); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutThingRequest calls the generic PutThing builder with application/json body
+func NewPutThingRequest(server string, id int32, params *PutThingParams, body PutThingJSONRequestBody) (*http.Request, error) {
+	if fields := validateRequestBody(validatePutThingParams(id, params), body); len(fields) != 0 {
+		return nil, &RequestValidationError{OperationID: "PutThing", Fields: fields}
+	}
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutThingRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPutThingRequestWithBody generates requests for PutThing with any type of body
+func NewPutThingRequestWithBody(server string, id int32, params *PutThingParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+	if fields := validatePutThingParams(id, params); len(fields) != 0 {
+		return nil, &RequestValidationError{OperationID: "PutThing", Fields: fields}
+	}
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/things/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, params.Name); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Ratio != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ratio", runtime.ParamLocationQuery, *params.Ratio); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tags != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tags", runtime.ParamLocationQuery, *params.Tags); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XStep != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Step", runtime.ParamLocationHeader, *params.XStep)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Step", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// RequestValidationError is the error of the request builders, and of the
+// client, when the parameters or the body of a request don't satisfy the
+// constraints of the spec, returned without sending the request.
+type RequestValidationError struct {
+	// OperationID is the ID of the operation of the request
+	OperationID string
+	// Fields are the errors of the parameters and of the body
+	Fields []RequestFieldError
+}
+
+func (e *RequestValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return fmt.Sprintf("invalid %s request: %s", e.OperationID, strings.Join(messages, "; "))
+}
+
+// RequestFieldError is the error of a parameter, or of the body, of a request
+// which doesn't satisfy the constraints of the spec.
+type RequestFieldError struct {
+	// Name is the name of the parameter, empty for the body
+	Name string
+	// In is the location of the parameter, path, query, header or cookie, or
+	// body for the body
+	In  string
+	Err error
+}
+
+func (e RequestFieldError) Error() string {
+	if e.In == "body" {
+		return "body: " + e.Err.Error()
+	}
+	return fmt.Sprintf("%s parameter %s: %s", e.In, e.Name, e.Err)
+}
+
+func (e RequestFieldError) Unwrap() error {
+	return e.Err
+}
+
+// validateRequestBody adds the error of the validation of the body of a
+// request to fields, when it's invalid.
+func validateRequestBody(fields []RequestFieldError, body interface{}) []RequestFieldError {
+	if err := validateRequestValue(body); err != nil {
+		fields = append(fields, RequestFieldError{In: "body", Err: err})
+	}
+	return fields
+}
+
+// validateRequestValue validates a value of a request body with the
+// validateRequest method of its type, which the structs with constraints on
+// their properties have, or else its Validate method, when it has one.
+func validateRequestValue(v interface{}) error {
+	switch v := v.(type) {
+	case interface{ validateRequest() error }:
+		return v.validateRequest()
+	case interface{ Validate() error }:
+		return v.Validate()
+	}
+	return nil
+}
+
+// requestPatterns caches the compiled patterns of the parameters.
+var requestPatterns sync.Map
+
+// matchesPattern returns whether s matches the pattern of a parameter.
+func matchesPattern(pattern, s string) bool {
+	re, ok := requestPatterns.Load(pattern)
+	if !ok {
+		re, _ = requestPatterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
+
+// validateCreateGadgetParams checks the parameters of a request of CreateGadget
+// against the constraints of the spec.
+func validateCreateGadgetParams(params *CreateGadgetParams) []RequestFieldError {
+	var fields []RequestFieldError
+	if params != nil && params.Level != nil {
+		v := *params.Level
+		if v > 0 || v <= 0 {
+			fields = append(fields, RequestFieldError{Name: "level", In: "query", Err: errors.New("must be at least 5000000000")})
+		}
+	}
+	return fields
+}
+
+// validatePutThingParams checks the parameters of a request of PutThing
+// against the constraints of the spec.
+func validatePutThingParams(id int32, params *PutThingParams) []RequestFieldError {
+	var fields []RequestFieldError
+	{
+		v := id
+		if v < 1 {
+			fields = append(fields, RequestFieldError{Name: "id", In: "path", Err: errors.New("must be at least 1")})
+		}
+		if v > 1000000 {
+			fields = append(fields, RequestFieldError{Name: "id", In: "path", Err: errors.New("must be at most 1000000")})
+		}
+	}
+	if params != nil {
+		v := params.Name
+		if utf8.RuneCountInString(string(v)) < 2 {
+			fields = append(fields, RequestFieldError{Name: "name", In: "query", Err: errors.New("must be at least 2 characters long")})
+		}
+		if utf8.RuneCountInString(string(v)) > 8 {
+			fields = append(fields, RequestFieldError{Name: "name", In: "query", Err: errors.New("must be at most 8 characters long")})
+		}
+		if !matchesPattern("^[a-z]+$", string(v)) {
+			fields = append(fields, RequestFieldError{Name: "name", In: "query", Err: errors.New("must match the pattern ^[a-z]+$")})
+		}
+	}
+	if params != nil && params.Sort != nil {
+		v := *params.Sort
+		if !(v == "asc" || v == "desc") {
+			fields = append(fields, RequestFieldError{Name: "sort", In: "query", Err: errors.New("must be one of asc, desc")})
+		}
+	}
+	if params != nil && params.Ratio != nil {
+		v := *params.Ratio
+		if v < 0 {
+			fields = append(fields, RequestFieldError{Name: "ratio", In: "query", Err: errors.New("must be at least 0")})
+		}
+		if v >= 1 {
+			fields = append(fields, RequestFieldError{Name: "ratio", In: "query", Err: errors.New("must be less than 1")})
+		}
+	}
+	if params != nil && params.Tags != nil {
+		v := *params.Tags
+		if len(v) > 3 {
+			fields = append(fields, RequestFieldError{Name: "tags", In: "query", Err: errors.New("must have at most 3 items")})
+		}
+		for i, item := range v {
+			if utf8.RuneCountInString(string(item)) > 4 {
+				fields = append(fields, RequestFieldError{Name: "tags", In: "query", Err: fmt.Errorf("item %d %s", i, "must be at most 4 characters long")})
+			}
+		}
+	}
+	if params != nil && params.XStep != nil {
+		v := *params.XStep
+		if v < 1 {
+			fields = append(fields, RequestFieldError{Name: "X-Step", In: "header", Err: errors.New("must be greater than 0.5")})
+		}
+		if v%5 != 0 {
+			fields = append(fields, RequestFieldError{Name: "X-Step", In: "header", Err: errors.New("must be a multiple of 5")})
+		}
+	}
+	return fields
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// CreateGadgetWithBodyWithResponse request with any body
+	CreateGadgetWithBodyWithResponse(ctx context.Context, params *CreateGadgetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateGadgetResponse, error)
+
+	CreateGadgetWithResponse(ctx context.Context, params *CreateGadgetParams, body CreateGadgetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateGadgetResponse, error)
+
+	// PingWithResponse request
+	PingWithResponse(ctx context.Context, params *PingParams, reqEditors ...RequestEditorFn) (*PingResponse, error)
+
+	// PutThingWithBodyWithResponse request with any body
+	PutThingWithBodyWithResponse(ctx context.Context, id int32, params *PutThingParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutThingResponse, error)
+
+	PutThingWithResponse(ctx context.Context, id int32, params *PutThingParams, body PutThingJSONRequestBody, reqEditors ...RequestEditorFn) (*PutThingResponse, error)
+}
+
+type CreateGadgetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateGadgetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateGadgetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutThingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PutThingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutThingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// CreateGadgetWithBodyWithResponse request with arbitrary body returning *CreateGadgetResponse
+func (c *ClientWithResponses) CreateGadgetWithBodyWithResponse(ctx context.Context, params *CreateGadgetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateGadgetResponse, error) {
+	rsp, err := c.CreateGadgetWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateGadgetResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateGadgetWithResponse(ctx context.Context, params *CreateGadgetParams, body CreateGadgetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateGadgetResponse, error) {
+	rsp, err := c.CreateGadget(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateGadgetResponse(rsp)
+}
+
+// PingWithResponse request returning *PingResponse
+func (c *ClientWithResponses) PingWithResponse(ctx context.Context, params *PingParams, reqEditors ...RequestEditorFn) (*PingResponse, error) {
+	rsp, err := c.Ping(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePingResponse(rsp)
+}
+
+// PutThingWithBodyWithResponse request with arbitrary body returning *PutThingResponse
+func (c *ClientWithResponses) PutThingWithBodyWithResponse(ctx context.Context, id int32, params *PutThingParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutThingResponse, error) {
+	rsp, err := c.PutThingWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutThingResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutThingWithResponse(ctx context.Context, id int32, params *PutThingParams, body PutThingJSONRequestBody, reqEditors ...RequestEditorFn) (*PutThingResponse, error) {
+	rsp, err := c.PutThing(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutThingResponse(rsp)
+}
+
+// ParseCreateGadgetResponse parses an HTTP response from a CreateGadgetWithResponse call
+func ParseCreateGadgetResponse(rsp *http.Response) (*CreateGadgetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateGadgetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePingResponse parses an HTTP response from a PingWithResponse call
+func ParsePingResponse(rsp *http.Response) (*PingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePutThingResponse parses an HTTP response from a PutThingWithResponse call
+func ParsePutThingResponse(rsp *http.Response) (*PutThingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutThingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,71 @@
+package clientvalidation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client of a server counting the requests it gets.
+func newClient(t *testing.T) (*Client, *int) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func TestStructBodyValidation(t *testing.T) {
+	client, requests := newClient(t)
+
+	weight := float32(-1)
+	labels := Labels{"a": "1", "b": "2"}
+	gadget := Gadget{
+		Name:   "a gadget too long",
+		Weight: &weight,
+		Parts:  []Part{{Sku: "ABC"}, {Sku: "abc"}, {Sku: "XYZ"}},
+		Labels: &labels,
+	}
+	_, err := client.CreateGadget(context.Background(), nil, gadget)
+	var validationErr *RequestValidationError
+	require.True(t, errors.As(err, &validationErr), "%v", err)
+	assert.Equal(t, "CreateGadget", validationErr.OperationID)
+	require.Len(t, validationErr.Fields, 1)
+	assert.Equal(t, "body", validationErr.Fields[0].In)
+	message := validationErr.Fields[0].Err.Error()
+	assert.Contains(t, message, `property "labels": 2 properties, more than the maximum of 1`)
+	assert.Contains(t, message, `property "name" must be at most 10 characters long`)
+	assert.Contains(t, message, `property "parts" must have at most 2 items`)
+	assert.Contains(t, message, `property "parts": item 1: property "sku" must match the pattern ^[A-Z]{3}$`)
+	assert.Contains(t, message, `property "weight" must be at least 0`)
+	assert.Zero(t, *requests)
+
+	// The required properties are checked, those read-only aside
+	_, err = client.CreateGadget(context.Background(), nil, Gadget{Name: "gadget"})
+	assert.ErrorContains(t, err, `body: property "parts" is required`)
+	assert.Zero(t, *requests)
+
+	rsp, err := client.CreateGadget(context.Background(), nil, Gadget{Name: "gadget", Parts: []Part{{Sku: "ABC"}}})
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, 1, *requests)
+}
+
+func TestUnsatisfiableBound(t *testing.T) {
+	client, requests := newClient(t)
+
+	// No int32 is at least 5000000000, so every level is rejected
+	level := int32(2147483647)
+	_, err := client.CreateGadget(context.Background(), &CreateGadgetParams{Level: &level}, Gadget{Name: "gadget", Parts: []Part{}})
+	assert.ErrorContains(t, err, "query parameter level: must be at least 5000000000")
+	assert.Zero(t, *requests)
+}
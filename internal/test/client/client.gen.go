@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -107,11 +106,73 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// UploadProgressFn is the function signature of the callback reporting the
+// progress of a request body upload, with the number of bytes sent so far,
+// and the size of the body, or -1 when it's unknown.
+type UploadProgressFn func(sent, total int64)
+
+// WithUploadProgress returns a request editor which reports the progress of
+// the upload of the request body to fn, as the body is read.
+func WithUploadProgress(fn UploadProgressFn) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if req.Body == nil || req.Body == http.NoBody {
+			return nil
+		}
+		req.Body = &progressReader{ReadCloser: req.Body, total: req.ContentLength, fn: fn}
+		if getBody := req.GetBody; getBody != nil {
+			// A redirected request sends its body again, from the start.
+			req.GetBody = func() (io.ReadCloser, error) {
+				body, err := getBody()
+				if err != nil || body == http.NoBody {
+					return body, err
+				}
+				return &progressReader{ReadCloser: body, total: req.ContentLength, fn: fn}, nil
+			}
+		}
+		return nil
+	}
+}
+
+// progressReader reports the bytes read from a request body to an
+// UploadProgressFn.
+type progressReader struct {
+	io.ReadCloser
+	total int64
+	sent  int64
+	fn    UploadProgressFn
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.fn(r.sent, r.total)
+	}
+	return n, err
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// PostBothWithBody request with any body
 	PostBothWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostBothWithBinaryBody request with a binary body of the given size, or -1 when it's unknown
+	PostBothWithBinaryBody(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	PostBoth(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetBoth request
@@ -128,6 +189,9 @@ type ClientInterface interface {
 	// PostOtherWithBody request with any body
 	PostOtherWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostOtherWithBinaryBody request with a binary body of the given size, or -1 when it's unknown
+	PostOtherWithBinaryBody(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetOther request
 	GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -149,7 +213,19 @@ func (c *Client) PostBothWithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) PostBothWithBinaryBody(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBothRequestWithBinaryBody(c.Server, contentType, body, size)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostBoth(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -161,7 +237,7 @@ func (c *Client) PostBoth(ctx context.Context, body PostBothJSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetBoth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -173,7 +249,7 @@ func (c *Client) GetBoth(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostJsonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -185,7 +261,7 @@ func (c *Client) PostJsonWithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostJson(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -197,7 +273,7 @@ func (c *Client) PostJson(ctx context.Context, body PostJsonJSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetJson(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -209,7 +285,7 @@ func (c *Client) GetJson(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostOtherWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -221,7 +297,19 @@ func (c *Client) PostOtherWithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) PostOtherWithBinaryBody(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostOtherRequestWithBinaryBody(c.Server, contentType, body, size)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -233,7 +321,7 @@ func (c *Client) GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetJsonWithTrailingSlash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -245,7 +333,7 @@ func (c *Client) GetJsonWithTrailingSlash(ctx context.Context, reqEditors ...Req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostVendorJsonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -257,7 +345,7 @@ func (c *Client) PostVendorJsonWithBody(ctx context.Context, contentType string,
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) PostVendorJsonWithApplicationVndAPIPlusJSONBody(ctx context.Context, body PostVendorJsonApplicationVndAPIPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -269,7 +357,7 @@ func (c *Client) PostVendorJsonWithApplicationVndAPIPlusJSONBody(ctx context.Con
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewPostBothRequest calls the generic PostBoth builder with application/json body
@@ -283,21 +371,32 @@ func NewPostBothRequest(server string, body PostBothJSONRequestBody) (*http.Requ
 	return NewPostBothRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostBothRequestWithBody generates requests for PostBoth with any type of body
-func NewPostBothRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+// NewPostBothRequestWithBinaryBody calls the generic PostBoth builder with a binary body of the
+// given size in bytes, sent as its Content-Length, or -1 when it's unknown, sending the body with
+// chunked transfer encoding unless its length is known from its type.
+func NewPostBothRequestWithBinaryBody(server string, contentType string, body io.Reader, size int64) (*http.Request, error) {
+	req, err := NewPostBothRequestWithBody(server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/with_both_bodies")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	switch {
+	case size == 0:
+		req.Body = http.NoBody
+		req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		req.ContentLength = 0
+	case size > 0:
+		req.ContentLength = size
+	case req.ContentLength == 0:
+		req.ContentLength = -1
 	}
+	return req, nil
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// NewPostBothRequestWithBody generates requests for PostBoth with any type of body
+func NewPostBothRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/with_both_bodies")
 	if err != nil {
 		return nil, err
 	}
@@ -316,17 +415,7 @@ func NewPostBothRequestWithBody(server string, contentType string, body io.Reade
 func NewGetBothRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_both_responses")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/with_both_responses")
 	if err != nil {
 		return nil, err
 	}
@@ -354,17 +443,7 @@ func NewPostJsonRequest(server string, body PostJsonJSONRequestBody) (*http.Requ
 func NewPostJsonRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_json_body")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/with_json_body")
 	if err != nil {
 		return nil, err
 	}
@@ -383,26 +462,37 @@ func NewPostJsonRequestWithBody(server string, contentType string, body io.Reade
 func NewGetJsonRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/with_json_response")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/with_json_response")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	return req, nil
+}
+
+// NewPostOtherRequestWithBinaryBody calls the generic PostOther builder with a binary body of the
+// given size in bytes, sent as its Content-Length, or -1 when it's unknown, sending the body with
+// chunked transfer encoding unless its length is known from its type.
+func NewPostOtherRequestWithBinaryBody(server string, contentType string, body io.Reader, size int64) (*http.Request, error) {
+	req, err := NewPostOtherRequestWithBody(server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
+	switch {
+	case size == 0:
+		req.Body = http.NoBody
+		req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		req.ContentLength = 0
+	case size > 0:
+		req.ContentLength = size
+	case req.ContentLength == 0:
+		req.ContentLength = -1
+	}
 	return req, nil
 }
 
@@ -410,17 +500,7 @@ func NewGetJsonRequest(server string) (*http.Request, error) {
 func NewPostOtherRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_other_body")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/with_other_body")
 	if err != nil {
 		return nil, err
 	}
@@ -439,17 +519,7 @@ func NewPostOtherRequestWithBody(server string, contentType string, body io.Read
 func NewGetOtherRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_other_response")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/with_other_response")
 	if err != nil {
 		return nil, err
 	}
@@ -466,17 +536,7 @@ func NewGetOtherRequest(server string) (*http.Request, error) {
 func NewGetJsonWithTrailingSlashRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_trailing_slash/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/with_trailing_slash/")
 	if err != nil {
 		return nil, err
 	}
@@ -504,29 +564,47 @@ func NewPostVendorJsonRequestWithApplicationVndAPIPlusJSONBody(server string, bo
 func NewPostVendorJsonRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/with_vendor_json")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/with_vendor_json")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -575,6 +653,9 @@ type ClientWithResponsesInterface interface {
 	// PostBothWithBodyWithResponse request with any body
 	PostBothWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBothResponse, error)
 
+	// PostBothWithBinaryBodyWithResponse request with a binary body of the given size, or -1 when it's unknown
+	PostBothWithBinaryBodyWithResponse(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*PostBothResponse, error)
+
 	PostBothWithResponse(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBothResponse, error)
 
 	// GetBothWithResponse request
@@ -591,6 +672,9 @@ type ClientWithResponsesInterface interface {
 	// PostOtherWithBodyWithResponse request with any body
 	PostOtherWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostOtherResponse, error)
 
+	// PostOtherWithBinaryBodyWithResponse request with a binary body of the given size, or -1 when it's unknown
+	PostOtherWithBinaryBodyWithResponse(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*PostOtherResponse, error)
+
 	// GetOtherWithResponse request
 	GetOtherWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOtherResponse, error)
 
@@ -780,6 +864,15 @@ func (c *ClientWithResponses) PostBothWithBodyWithResponse(ctx context.Context,
 	return ParsePostBothResponse(rsp)
 }
 
+// PostBothWithBinaryBodyWithResponse request with a binary body of the given size, or -1 when it's unknown, returning *PostBothResponse
+func (c *ClientWithResponses) PostBothWithBinaryBodyWithResponse(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*PostBothResponse, error) {
+	rsp, err := c.PostBothWithBinaryBody(ctx, contentType, body, size, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBothResponse(rsp)
+}
+
 func (c *ClientWithResponses) PostBothWithResponse(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBothResponse, error) {
 	rsp, err := c.PostBoth(ctx, body, reqEditors...)
 	if err != nil {
@@ -832,6 +925,15 @@ func (c *ClientWithResponses) PostOtherWithBodyWithResponse(ctx context.Context,
 	return ParsePostOtherResponse(rsp)
 }
 
+// PostOtherWithBinaryBodyWithResponse request with a binary body of the given size, or -1 when it's unknown, returning *PostOtherResponse
+func (c *ClientWithResponses) PostOtherWithBinaryBodyWithResponse(ctx context.Context, contentType string, body io.Reader, size int64, reqEditors ...RequestEditorFn) (*PostOtherResponse, error) {
+	rsp, err := c.PostOtherWithBinaryBody(ctx, contentType, body, size, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostOtherResponse(rsp)
+}
+
 // GetOtherWithResponse request returning *GetOtherResponse
 func (c *ClientWithResponses) GetOtherWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOtherResponse, error) {
 	rsp, err := c.GetOther(ctx, reqEditors...)
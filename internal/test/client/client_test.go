@@ -1,6 +1,11 @@
 package client
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/deepmap/oapi-codegen/pkg/securityprovider"
@@ -77,3 +82,67 @@ func TestSecurityProviders(t *testing.T) {
 	assert.Equal(t, withTrailingSlash, client2.Server)
 	assert.Equal(t, withTrailingSlash, client3.Server)
 }
+
+type recordingDoer struct {
+	requests []*http.Request
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestWithRequestDoer(t *testing.T) {
+	clientDoer := &recordingDoer{}
+	callDoer := &recordingDoer{}
+
+	client, err := NewClient(withTrailingSlash, WithHTTPClient(clientDoer))
+	assert.NoError(t, err)
+
+	_, err = client.GetJson(context.Background(), WithRequestDoer(callDoer))
+	assert.NoError(t, err)
+	assert.Len(t, callDoer.requests, 1)
+	assert.Len(t, clientDoer.requests, 0)
+
+	_, err = client.GetJson(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, callDoer.requests, 1)
+	assert.Len(t, clientDoer.requests, 1)
+}
+
+func TestBinaryBodyUpload(t *testing.T) {
+	type received struct {
+		contentLength    int64
+		transferEncoding []string
+		contentType      string
+		body             string
+	}
+	var got received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = received{r.ContentLength, r.TransferEncoding, r.Header.Get("Content-Type"), string(body)}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	assert.NoError(t, err)
+
+	// A known size is sent as the Content-Length, whatever the reader
+	var progress [][2]int64
+	trackProgress := WithUploadProgress(func(sent, total int64) {
+		progress = append(progress, [2]int64{sent, total})
+	})
+	body := io.MultiReader(strings.NewReader("hello "), strings.NewReader("world"))
+	_, err = client.PostOtherWithBinaryBody(context.Background(), "image/png", body, 11, trackProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, received{11, nil, "image/png", "hello world"}, got)
+	if assert.NotEmpty(t, progress) {
+		assert.Equal(t, [2]int64{11, 11}, progress[len(progress)-1])
+	}
+
+	// An unknown size is sent chunked
+	body = io.MultiReader(strings.NewReader("hello "), strings.NewReader("world"))
+	_, err = client.PostOtherWithBinaryBody(context.Background(), "application/octet-stream", body, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, received{-1, []string{"chunked"}, "application/octet-stream", "hello world"}, got)
+}
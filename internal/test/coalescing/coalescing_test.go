@@ -0,0 +1,60 @@
+package coalescing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userKey struct{}
+
+func TestCoalescingKeepsTheCredentialsApart(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Product{Name: "for " + r.Header.Get("X-Api-Key")})
+	}))
+	t.Cleanup(server.Close)
+
+	// The API key of each call is that of the user of its context
+	client, err := NewClientWithResponses(server.URL, WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Api-Key", ctx.Value(userKey{}).(string))
+		return nil
+	}))
+	require.NoError(t, err)
+	coalescing := NewCoalescingClient(client)
+
+	users := []string{"alice", "bob", "alice", "bob"}
+	names := make([]string, len(users))
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func(i int, user string) {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), userKey{}, user)
+			rsp, err := coalescing.GetProductWithResponse(ctx, "42")
+			if assert.NoError(t, err) && assert.NotNil(t, rsp.JSON200) {
+				names[i] = rsp.JSON200.Name
+			}
+		}(i, user)
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 2 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// The calls of each user share a request, but not those of the others
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, []string{"for alice", "for bob", "for alice", "for bob"}, names)
+}
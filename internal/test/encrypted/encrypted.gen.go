@@ -0,0 +1,1524 @@
+// Package encrypted provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Card defines model for Card.
+type Card struct {
+	Expiry *string `json:"expiry,omitempty"`
+	Holder string  `json:"holder"`
+	Number string  `json:"number"`
+}
+
+// Patient defines model for Patient.
+type Patient struct {
+	Contact *Patient_Contact `json:"contact,omitempty"`
+	Name    *string          `json:"name,omitempty"`
+	Record  *struct {
+		Allergies *[]string `json:"allergies,omitempty"`
+		Diagnosis *string   `json:"diagnosis,omitempty"`
+	} `json:"record,omitempty"`
+	Tags *Tagged `json:"tags,omitempty"`
+}
+
+// Patient_Contact defines model for Patient.Contact.
+type Patient_Contact struct {
+	Email *string `json:"email,omitempty"`
+	Ssn   *string `json:"ssn,omitempty"`
+}
+
+// Tagged defines model for Tagged.
+type Tagged struct {
+	Secret               *string           `json:"secret,omitempty"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// AddCardJSONRequestBody defines body for AddCard for application/json ContentType.
+type AddCardJSONRequestBody = Card
+
+// Getter for additional properties for Tagged. Returns the specified
+// element and whether it was found
+func (a Tagged) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Tagged
+func (a *Tagged) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Tagged to handle AdditionalProperties
+func (a *Tagged) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["secret"]; found {
+		err = json.Unmarshal(raw, &a.Secret)
+		if err != nil {
+			return fmt.Errorf("error reading 'secret': %w", err)
+		}
+		delete(object, "secret")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Tagged to handle AdditionalProperties
+func (a Tagged) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.Secret != nil {
+		object["secret"], err = json.Marshal(a.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'secret': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// FieldEncrypter encrypts and decrypts the values of the properties declared
+// with x-encrypted, for the field-level encryption of payloads. Encrypt is
+// called with the JSON of a value, and returns the ciphertext sent as a JSON
+// string in its place, which Decrypt is called with to get the JSON back. The
+// field is the name of the type and the JSON name of the property, like
+// Card.number.
+type FieldEncrypter interface {
+	Encrypt(field string, plaintext []byte) (string, error)
+	Decrypt(field string, ciphertext string) ([]byte, error)
+}
+
+// ErrNoFieldEncrypter is the error of encoding or decoding a value with
+// encrypted properties without a FieldEncrypter.
+var ErrNoFieldEncrypter = errors.New("no FieldEncrypter is set for the encrypted properties")
+
+type fieldEncrypterContextKey struct{}
+
+// ContextWithFieldEncrypter returns a copy of ctx carrying the FieldEncrypter
+// of the bodies of the requests sent, or served, with it. It takes precedence
+// over that of the client, and is the one of the strict servers without one in
+// their options.
+func ContextWithFieldEncrypter(ctx context.Context, encrypter FieldEncrypter) context.Context {
+	return context.WithValue(ctx, fieldEncrypterContextKey{}, encrypter)
+}
+
+// FieldEncrypterFromContext returns the FieldEncrypter carried by ctx, if any.
+func FieldEncrypterFromContext(ctx context.Context) FieldEncrypter {
+	encrypter, _ := ctx.Value(fieldEncrypterContextKey{}).(FieldEncrypter)
+	return encrypter
+}
+
+// WithFieldEncrypter sets the FieldEncrypter of the encrypted properties of
+// the request and response bodies of the client.
+func WithFieldEncrypter(encrypter FieldEncrypter) ClientOption {
+	return func(c *Client) error {
+		c.FieldEncrypter = encrypter
+		return nil
+	}
+}
+
+// EncryptJSON encodes v as JSON, with the values of the encrypted properties
+// of the types it holds encrypted by the encrypter.
+func EncryptJSON(encrypter FieldEncrypter, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || v == nil {
+		return data, err
+	}
+	return transformEncrypted(encrypter, reflect.TypeOf(v), data, true)
+}
+
+// DecryptJSON decodes v from JSON, with the values of the encrypted
+// properties of the types it holds decrypted by the encrypter.
+func DecryptJSON(encrypter FieldEncrypter, data []byte, v interface{}) error {
+	plaintext, err := decryptJSON(encrypter, data, v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, v)
+}
+
+// decryptJSON returns the JSON of v with the values of the encrypted
+// properties of the types it holds decrypted by the encrypter.
+func decryptJSON(encrypter FieldEncrypter, data []byte, v interface{}) ([]byte, error) {
+	if v == nil {
+		return data, nil
+	}
+	return transformEncrypted(encrypter, reflect.TypeOf(v), data, false)
+}
+
+// encryptRequestBody replaces the JSON body of req, encoded from body, by
+// that with its encrypted properties encrypted by the FieldEncrypter of the
+// context of req.
+func encryptRequestBody(req *http.Request, body interface{}) error {
+	if body == nil || !holdsEncrypted(reflect.TypeOf(body)) {
+		return nil
+	}
+	data, err := EncryptJSON(FieldEncrypterFromContext(req.Context()), body)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil
+}
+
+// decryptResponseBody returns the JSON body of rsp, decoded into v, with its
+// encrypted properties decrypted by the FieldEncrypter of the context of the
+// request.
+func decryptResponseBody(rsp *http.Response, data []byte, v interface{}) ([]byte, error) {
+	var encrypter FieldEncrypter
+	if rsp.Request != nil {
+		encrypter = FieldEncrypterFromContext(rsp.Request.Context())
+	}
+	return decryptJSON(encrypter, data, v)
+}
+
+// decryptRequestBody replaces the JSON body of r, decoded into v, by that with
+// its encrypted properties decrypted by the encrypter, or else the
+// FieldEncrypter of the context of r.
+func decryptRequestBody(r *http.Request, encrypter FieldEncrypter, v interface{}) error {
+	if !holdsEncrypted(reflect.TypeOf(v)) {
+		return nil
+	}
+	if encrypter == nil {
+		encrypter = FieldEncrypterFromContext(r.Context())
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if data, err = decryptJSON(encrypter, data, v); err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return nil
+}
+
+// fieldEncryptingWriter is the http.ResponseWriter of a response whose JSON
+// body encodeJSONResponse encrypts with its FieldEncrypter.
+type fieldEncryptingWriter struct {
+	http.ResponseWriter
+	encrypter FieldEncrypter
+}
+
+// Unwrap returns the http.ResponseWriter wrapped, for http.ResponseController.
+func (w fieldEncryptingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// withFieldEncrypter returns w, wrapped to encrypt the JSON body of the
+// response with the encrypter, or else the FieldEncrypter of ctx.
+func withFieldEncrypter(ctx context.Context, w http.ResponseWriter, encrypter FieldEncrypter) http.ResponseWriter {
+	if encrypter == nil {
+		encrypter = FieldEncrypterFromContext(ctx)
+	}
+	if encrypter == nil {
+		return w
+	}
+	return fieldEncryptingWriter{ResponseWriter: w, encrypter: encrypter}
+}
+
+// encodeJSONResponse writes v as the JSON body of a response, with its
+// encrypted properties encrypted by the FieldEncrypter of w.
+func encodeJSONResponse(w http.ResponseWriter, v interface{}) error {
+	var encrypter FieldEncrypter
+	if ew, ok := w.(fieldEncryptingWriter); ok {
+		encrypter = ew.encrypter
+	}
+	data, err := EncryptJSON(encrypter, v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// encryptedType is implemented by the types with encrypted properties.
+type encryptedType interface {
+	encryptedProperties() []string
+}
+
+var encryptedTypeType = reflect.TypeOf((*encryptedType)(nil)).Elem()
+
+// holdsEncryptedTypes caches whether the values of a type hold encrypted
+// properties.
+var holdsEncryptedTypes sync.Map
+
+// holdsEncrypted returns whether the values of t hold encrypted properties,
+// in their own or those of the types of their fields, items and values.
+func holdsEncrypted(t reflect.Type) bool {
+	if held, ok := holdsEncryptedTypes.Load(t); ok {
+		return held.(bool)
+	}
+	held := holdsEncryptedIn(t, make(map[reflect.Type]bool))
+	holdsEncryptedTypes.Store(t, held)
+	return held
+}
+
+func holdsEncryptedIn(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	if t.Implements(encryptedTypeType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); (f.PkgPath == "" || f.Anonymous) && holdsEncryptedIn(f.Type, seen) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return holdsEncryptedIn(t.Elem(), seen)
+	}
+	return false
+}
+
+// transformEncrypted replaces the values of the encrypted properties held by
+// data, the JSON of a value of type t, by their ciphertext, or the other way
+// round.
+func transformEncrypted(encrypter FieldEncrypter, t reflect.Type, data []byte, encrypt bool) ([]byte, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !holdsEncrypted(t) {
+		return data, nil
+	}
+	data = bytes.TrimSpace(data)
+	switch {
+	case (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) && bytes.HasPrefix(data, []byte("{")):
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		if t.Kind() == reflect.Map {
+			for key, value := range object {
+				transformed, err := transformEncrypted(encrypter, t.Elem(), value, encrypt)
+				if err != nil {
+					return nil, err
+				}
+				object[key] = transformed
+			}
+			return json.Marshal(object)
+		}
+		var properties []string
+		if typ, ok := reflect.Zero(t).Interface().(encryptedType); ok {
+			properties = typ.encryptedProperties()
+		}
+		if !encrypt {
+			if err := decryptProperties(encrypter, object, t.Name(), properties); err != nil {
+				return nil, err
+			}
+		}
+		if err := transformFields(encrypter, t, object, encrypt); err != nil {
+			return nil, err
+		}
+		if encrypt {
+			if err := encryptProperties(encrypter, object, t.Name(), properties); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(object)
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && bytes.HasPrefix(data, []byte("[")):
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			transformed, err := transformEncrypted(encrypter, t.Elem(), item, encrypt)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = transformed
+		}
+		return json.Marshal(items)
+	}
+	return data, nil
+}
+
+// transformFields transforms the values of the fields of the struct type t
+// in its JSON object, by their JSON names, and those of its embedded structs.
+func transformFields(encrypter FieldEncrypter, t reflect.Type, object map[string]json.RawMessage, encrypt bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := transformFields(encrypter, embedded, object, encrypt); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		value, ok := object[name]
+		if !ok {
+			continue
+		}
+		transformed, err := transformEncrypted(encrypter, f.Type, value, encrypt)
+		if err != nil {
+			return err
+		}
+		object[name] = transformed
+	}
+	return nil
+}
+
+// encryptProperties replaces the values of the given properties of a JSON
+// object, but the null ones, by their ciphertext.
+func encryptProperties(encrypter FieldEncrypter, object map[string]json.RawMessage, typeName string, properties []string) error {
+	for _, property := range properties {
+		value, ok := object[property]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if encrypter == nil {
+			return ErrNoFieldEncrypter
+		}
+		ciphertext, err := encrypter.Encrypt(typeName+"."+property, value)
+		if err != nil {
+			return fmt.Errorf("encrypting %s.%s: %w", typeName, property, err)
+		}
+		if object[property], err = json.Marshal(ciphertext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptProperties replaces the ciphertext of the given properties of a JSON
+// object, but the null ones, by the JSON of their values.
+func decryptProperties(encrypter FieldEncrypter, object map[string]json.RawMessage, typeName string, properties []string) error {
+	for _, property := range properties {
+		value, ok := object[property]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		var ciphertext string
+		if err := json.Unmarshal(value, &ciphertext); err != nil {
+			return fmt.Errorf("the encrypted %s.%s isn't a string: %w", typeName, property, err)
+		}
+		if encrypter == nil {
+			return ErrNoFieldEncrypter
+		}
+		plaintext, err := encrypter.Decrypt(typeName+"."+property, ciphertext)
+		if err != nil {
+			return fmt.Errorf("decrypting %s.%s: %w", typeName, property, err)
+		}
+		object[property] = plaintext
+	}
+	return nil
+}
+
+// encryptedProperties returns the JSON names of the encrypted properties of
+// Card.
+func (Card) encryptedProperties() []string {
+	return []string{"expiry", "number"}
+}
+
+// encryptedProperties returns the JSON names of the encrypted properties of
+// Patient.
+func (Patient) encryptedProperties() []string {
+	return []string{"record"}
+}
+
+// encryptedProperties returns the JSON names of the encrypted properties of
+// Patient_Contact.
+func (Patient_Contact) encryptedProperties() []string {
+	return []string{"ssn"}
+}
+
+// encryptedProperties returns the JSON names of the encrypted properties of
+// Tagged.
+func (Tagged) encryptedProperties() []string {
+	return []string{"secret"}
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// The encrypter of the encrypted properties of the JSON bodies, used
+	// unless the context of a request carries one.
+	FieldEncrypter FieldEncrypter
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// AddCardWithBody request with any body
+	AddCardWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddCard(ctx context.Context, body AddCardJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCard request
+	GetCard(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPatient request
+	GetPatient(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) AddCardWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCardRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) AddCard(ctx context.Context, body AddCardJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCardRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	if err := encryptRequestBody(req, body); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetCard(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCardRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetPatient(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPatientRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewAddCardRequest calls the generic AddCard builder with application/json body
+func NewAddCardRequest(server string, body AddCardJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddCardRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddCardRequestWithBody generates requests for AddCard with any type of body
+func NewAddCardRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/cards")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCardRequest generates requests for GetCard
+func NewGetCardRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/cards/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPatientRequest generates requests for GetPatient
+func NewGetPatientRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/patients/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	if c.FieldEncrypter != nil && FieldEncrypterFromContext(req.Context()) == nil {
+		*req = *req.WithContext(ContextWithFieldEncrypter(req.Context(), c.FieldEncrypter))
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// AddCardWithBodyWithResponse request with any body
+	AddCardWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCardResponse, error)
+
+	AddCardWithResponse(ctx context.Context, body AddCardJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCardResponse, error)
+
+	// GetCardWithResponse request
+	GetCardWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetCardResponse, error)
+
+	// GetPatientWithResponse request
+	GetPatientWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetPatientResponse, error)
+}
+
+type AddCardResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Card
+}
+
+// Status returns HTTPResponse.Status
+func (r AddCardResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddCardResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r AddCardResponse) Success() (Card, bool) {
+	if r.JSON201 != nil {
+		return *r.JSON201, true
+	}
+	var zero Card
+	return zero, false
+}
+
+type GetCardResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Card
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCardResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCardResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetCardResponse) Success() (Card, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Card
+	return zero, false
+}
+
+type GetPatientResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Patient
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPatientResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPatientResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetPatientResponse) Success() (Patient, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Patient
+	return zero, false
+}
+
+// AddCardWithBodyWithResponse request with arbitrary body returning *AddCardResponse
+func (c *ClientWithResponses) AddCardWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCardResponse, error) {
+	rsp, err := c.AddCardWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCardResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddCardWithResponse(ctx context.Context, body AddCardJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCardResponse, error) {
+	rsp, err := c.AddCard(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCardResponse(rsp)
+}
+
+// GetCardWithResponse request returning *GetCardResponse
+func (c *ClientWithResponses) GetCardWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetCardResponse, error) {
+	rsp, err := c.GetCard(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCardResponse(rsp)
+}
+
+// GetPatientWithResponse request returning *GetPatientResponse
+func (c *ClientWithResponses) GetPatientWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetPatientResponse, error) {
+	rsp, err := c.GetPatient(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPatientResponse(rsp)
+}
+
+// ParseAddCardResponse parses an HTTP response from a AddCardWithResponse call
+func ParseAddCardResponse(rsp *http.Response) (*AddCardResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddCardResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Card
+		data, err := decryptResponseBody(rsp, bodyBytes, &dest)
+		if err == nil {
+			err = json.Unmarshal(data, &dest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCardResponse parses an HTTP response from a GetCardWithResponse call
+func ParseGetCardResponse(rsp *http.Response) (*GetCardResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCardResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Card
+		data, err := decryptResponseBody(rsp, bodyBytes, &dest)
+		if err == nil {
+			err = json.Unmarshal(data, &dest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPatientResponse parses an HTTP response from a GetPatientWithResponse call
+func ParseGetPatientResponse(rsp *http.Response) (*GetPatientResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPatientResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Patient
+		data, err := decryptResponseBody(rsp, bodyBytes, &dest)
+		if err == nil {
+			err = json.Unmarshal(data, &dest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (POST /cards)
+	AddCard(w http.ResponseWriter, r *http.Request)
+
+	// (GET /cards/{id})
+	GetCard(w http.ResponseWriter, r *http.Request, id string)
+
+	// (GET /patients/{id})
+	GetPatient(w http.ResponseWriter, r *http.Request, id string)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// (POST /cards)
+func (_ Unimplemented) AddCard(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /cards/{id})
+func (_ Unimplemented) GetCard(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /patients/{id})
+func (_ Unimplemented) GetPatient(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// AddCard operation middleware
+func (siw *ServerInterfaceWrapper) AddCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddCard(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCard operation middleware
+func (siw *ServerInterfaceWrapper) GetCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := BindGetCardParams(r)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, err)
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCard(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetPatient operation middleware
+func (siw *ServerInterfaceWrapper) GetPatient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := BindGetPatientParams(r)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, err)
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPatient(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BindGetCardParams binds the parameters of the GetCard operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindGetCardParams(r *http.Request) (id string, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chiPathParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+// BindGetPatientParams binds the parameters of the GetPatient operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindGetPatientParams(r *http.Request) (id string, err error) {
+	// ------------- Path parameter "id" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chiPathParam(r, "id"), &id)
+	if err != nil {
+		return id, &InvalidParamFormatError{ParamName: "id", Err: err}
+	}
+
+	return id, nil
+}
+
+// chiPathParam returns the escaped value of a path parameter of a request,
+// which chi routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func chiPathParam(r *http.Request, name string) string {
+	value := chi.URLParam(r, name)
+	if r.URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cards", wrapper.AddCard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cards/{id}", wrapper.GetCard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/patients/{id}", wrapper.GetPatient)
+	})
+
+	return r
+}
+
+type CardJSONResponse Card
+
+type AddCardRequestObject struct {
+	Body *AddCardJSONRequestBody
+}
+
+type AddCardResponseObject interface {
+	VisitAddCardResponse(w http.ResponseWriter) error
+}
+
+type AddCard201JSONResponse Card
+
+func (response AddCard201JSONResponse) VisitAddCardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return encodeJSONResponse(w, Card(response))
+}
+
+type GetCardRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetCardResponseObject interface {
+	VisitGetCardResponse(w http.ResponseWriter) error
+}
+
+type GetCard200JSONResponse struct{ CardJSONResponse }
+
+func (response GetCard200JSONResponse) VisitGetCardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return encodeJSONResponse(w, Card(response.CardJSONResponse))
+}
+
+type GetPatientRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetPatientResponseObject interface {
+	VisitGetPatientResponse(w http.ResponseWriter) error
+}
+
+type GetPatient200JSONResponse Patient
+
+func (response GetPatient200JSONResponse) VisitGetPatientResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return encodeJSONResponse(w, Patient(response))
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (POST /cards)
+	AddCard(ctx context.Context, request AddCardRequestObject) (AddCardResponseObject, error)
+
+	// (GET /cards/{id})
+	GetCard(ctx context.Context, request GetCardRequestObject) (GetCardResponseObject, error)
+
+	// (GET /patients/{id})
+	GetPatient(ctx context.Context, request GetPatientRequestObject) (GetPatientResponseObject, error)
+}
+
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (POST /cards)
+func (_ UnimplementedStrictServer) AddCard(ctx context.Context, request AddCardRequestObject) (AddCardResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /cards/{id})
+func (_ UnimplementedStrictServer) GetCard(ctx context.Context, request GetCardRequestObject) (GetCardResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /patients/{id})
+func (_ UnimplementedStrictServer) GetPatient(ctx context.Context, request GetPatientRequestObject) (GetPatientResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitAddCardResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitGetCardResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitGetPatientResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// The encrypter of the encrypted properties of the JSON bodies, used
+	// unless nil, for the FieldEncrypter of the request context.
+	FieldEncrypter FieldEncrypter
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// AddCard operation middleware
+func (sh *strictHandler) AddCard(w http.ResponseWriter, r *http.Request) {
+	var request AddCardRequestObject
+
+	var body AddCardJSONRequestBody
+	if err := decryptRequestBody(r, sh.options.FieldEncrypter, &body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decrypt JSON body: %w", err))
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddCard(ctx, request.(AddCardRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddCard")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddCardResponseObject); ok {
+		if err := validResponse.VisitAddCardResponse(withFieldEncrypter(r.Context(), w, sh.options.FieldEncrypter)); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCard operation middleware
+func (sh *strictHandler) GetCard(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetCardRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCard(ctx, request.(GetCardRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCard")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCardResponseObject); ok {
+		if err := validResponse.VisitGetCardResponse(withFieldEncrypter(r.Context(), w, sh.options.FieldEncrypter)); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPatient operation middleware
+func (sh *strictHandler) GetPatient(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetPatientRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPatient(ctx, request.(GetPatientRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPatient")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPatientResponseObject); ok {
+		if err := validResponse.VisitGetPatientResponse(withFieldEncrypter(r.Context(), w, sh.options.FieldEncrypter)); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
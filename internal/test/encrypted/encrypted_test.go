@@ -0,0 +1,205 @@
+package encrypted
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyEncrypter "encrypts" with its key, prefixing it to the base64 of the
+// plaintext, and only decrypts what it encrypted.
+type keyEncrypter string
+
+func (k keyEncrypter) Encrypt(field string, plaintext []byte) (string, error) {
+	return string(k) + ":" + field + ":" + base64.StdEncoding.EncodeToString(plaintext), nil
+}
+
+func (k keyEncrypter) Decrypt(field string, ciphertext string) ([]byte, error) {
+	encoded := strings.TrimPrefix(ciphertext, string(k)+":"+field+":")
+	if encoded == ciphertext {
+		return nil, errors.New("not encrypted with " + string(k))
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+type server struct {
+	patient Patient
+}
+
+func (s server) AddCard(ctx context.Context, request AddCardRequestObject) (AddCardResponseObject, error) {
+	return AddCard201JSONResponse(*request.Body), nil
+}
+
+func (s server) GetCard(ctx context.Context, request GetCardRequestObject) (GetCardResponseObject, error) {
+	return GetCard200JSONResponse{CardJSONResponse{Holder: "Ada", Number: request.Id}}, nil
+}
+
+func (s server) GetPatient(ctx context.Context, request GetPatientRequestObject) (GetPatientResponseObject, error) {
+	return GetPatient200JSONResponse(s.patient), nil
+}
+
+// newServer returns the URL of the strict server of s, encrypting with the
+// encrypter, which records the bodies of the requests and responses.
+func newServer(t *testing.T, s server, encrypter FieldEncrypter, bodies *[]string) string {
+	handler := Handler(NewStrictHandlerWithOptions(s, nil, StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+		FieldEncrypter: encrypter,
+	}))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		*bodies = append(*bodies, string(body), rec.Body.String())
+		for name, values := range rec.Header() {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+	}))
+	t.Cleanup(ts.Close)
+	return ts.URL
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	var bodies []string
+	url := newServer(t, server{}, keyEncrypter("k1"), &bodies)
+	client, err := NewClientWithResponses(url, WithFieldEncrypter(keyEncrypter("k1")))
+	require.NoError(t, err)
+
+	expiry := "12/30"
+	rsp, err := client.AddCardWithResponse(context.Background(), Card{Holder: "Ada", Number: "4111", Expiry: &expiry})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rsp.StatusCode(), string(rsp.Body))
+	assert.Equal(t, &Card{Holder: "Ada", Number: "4111", Expiry: &expiry}, rsp.JSON201)
+
+	// Only the encrypted properties are encrypted on the wire, both ways
+	require.Len(t, bodies, 2)
+	for _, body := range bodies {
+		var wire map[string]string
+		require.NoError(t, json.Unmarshal([]byte(body), &wire))
+		assert.Equal(t, "Ada", wire["holder"])
+		assert.Equal(t, "k1:Card.number:"+base64.StdEncoding.EncodeToString([]byte(`"4111"`)), wire["number"])
+		assert.True(t, strings.HasPrefix(wire["expiry"], "k1:Card.expiry:"), wire["expiry"])
+	}
+
+	// Those of the responses declared in the components too
+	bodies = nil
+	cardRsp, err := client.GetCardWithResponse(context.Background(), "5500")
+	require.NoError(t, err)
+	assert.Equal(t, &Card{Holder: "Ada", Number: "5500"}, cardRsp.JSON200)
+	assert.Contains(t, bodies[1], `"number":"k1:Card.number:`)
+}
+
+func TestEncryptedNestedProperties(t *testing.T) {
+	diagnosis, email, ssn, secret := "flu", "ada@example.com", "078-05-1120", "s3cret"
+	patient := Patient{
+		Record: &struct {
+			Allergies *[]string `json:"allergies,omitempty"`
+			Diagnosis *string   `json:"diagnosis,omitempty"`
+		}{Diagnosis: &diagnosis},
+		Contact: &Patient_Contact{Email: &email, Ssn: &ssn},
+		Tags:    &Tagged{Secret: &secret, AdditionalProperties: map[string]string{"color": "blue"}},
+	}
+	var bodies []string
+	url := newServer(t, server{patient: patient}, keyEncrypter("k1"), &bodies)
+	client, err := NewClientWithResponses(url, WithFieldEncrypter(keyEncrypter("k1")))
+	require.NoError(t, err)
+
+	rsp, err := client.GetPatientWithResponse(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode(), string(rsp.Body))
+	assert.Equal(t, &patient, rsp.JSON200)
+
+	wire := bodies[1]
+	assert.NotContains(t, wire, diagnosis)
+	assert.NotContains(t, wire, ssn)
+	assert.NotContains(t, wire, secret)
+	assert.Contains(t, wire, `"record":"k1:Patient.record:`)
+	assert.Contains(t, wire, `"ssn":"k1:Patient_Contact.ssn:`)
+	assert.Contains(t, wire, `"secret":"k1:Tagged.secret:`)
+	assert.Contains(t, wire, email)
+	assert.Contains(t, wire, `"color":"blue"`)
+}
+
+func TestClientsKeepTheirEncrypters(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(ts.Close)
+
+	first, err := NewClient(ts.URL, WithFieldEncrypter(keyEncrypter("first")))
+	require.NoError(t, err)
+	second, err := NewClient(ts.URL, WithFieldEncrypter(keyEncrypter("second")))
+	require.NoError(t, err)
+
+	card := Card{Holder: "Ada", Number: "4111"}
+	for _, client := range []*Client{first, second, first} {
+		rsp, err := client.AddCard(context.Background(), card)
+		require.NoError(t, err)
+		rsp.Body.Close()
+	}
+	// The encrypter of a request's context takes precedence
+	ctx := ContextWithFieldEncrypter(context.Background(), keyEncrypter("call"))
+	rsp, err := first.AddCard(ctx, card)
+	require.NoError(t, err)
+	rsp.Body.Close()
+
+	require.Len(t, bodies, 4)
+	for i, key := range []string{"first", "second", "first", "call"} {
+		assert.Contains(t, bodies[i], `"number":"`+key+`:Card.number:`)
+	}
+}
+
+func TestNoFieldEncrypter(t *testing.T) {
+	var bodies []string
+	url := newServer(t, server{}, nil, &bodies)
+
+	// Neither the client
+	client, err := NewClient(url)
+	require.NoError(t, err)
+	_, err = client.AddCard(context.Background(), Card{Holder: "Ada", Number: "4111"})
+	assert.ErrorIs(t, err, ErrNoFieldEncrypter)
+	assert.Empty(t, bodies)
+
+	// Nor the server, which can't decrypt them
+	encrypting, err := NewClient(url, WithFieldEncrypter(keyEncrypter("k1")))
+	require.NoError(t, err)
+	rsp, err := encrypting.AddCard(context.Background(), Card{Holder: "Ada", Number: "4111"})
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	body, err := io.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), ErrNoFieldEncrypter.Error())
+	assert.NotContains(t, bodies[1], "4111")
+}
+
+func TestServerEncrypterFromContext(t *testing.T) {
+	// Without one in its options, the strict server uses that of the request
+	// context
+	handler := Handler(NewStrictHandler(server{}, nil))
+	req := httptest.NewRequest(http.MethodGet, "/cards/4111", nil)
+	req = req.WithContext(ContextWithFieldEncrypter(req.Context(), keyEncrypter("ctx")))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"number":"ctx:Card.number:`)
+}
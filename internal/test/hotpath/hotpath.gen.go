@@ -0,0 +1,877 @@
+// Package hotpath provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package hotpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for Kind.
+const (
+	Cat Kind = "cat"
+	Dog Kind = "dog"
+)
+
+// Kind defines model for Kind.
+type Kind string
+
+// Owner defines model for Owner.
+type Owner struct {
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+	Name string              `json:"name"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	CreatedAt  time.Time       `json:"createdAt"`
+	Height     *float64        `json:"height,omitempty"`
+	Id         int64           `json:"id"`
+	Kind       *Kind           `json:"kind,omitempty"`
+	Metadata   *interface{}    `json:"metadata,omitempty"`
+	Name       string          `json:"name"`
+	Nickname   *string         `json:"nickname"`
+	Owner      *Owner          `json:"owner,omitempty"`
+	Parent     *Pet            `json:"parent,omitempty"`
+	Scores     *map[string]int `json:"scores,omitempty"`
+	Siblings   *[]Pet          `json:"siblings,omitempty"`
+	Tags       []string        `json:"tags"`
+	Toy        *Toy            `json:"toy,omitempty"`
+	Vaccinated *bool           `json:"vaccinated,omitempty"`
+	Weight     *float32        `json:"weight,omitempty"`
+}
+
+// Toy defines model for Toy.
+type Toy struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// MarshalJSON encodes the Owner as JSON without reflection.
+func (a Owner) MarshalJSON() ([]byte, error) {
+	return a.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Owner to buf.
+func (a Owner) appendJSON(buf []byte) ([]byte, error) {
+	var b []byte
+	var err error
+	start := len(buf)
+	if a.Id != nil {
+		buf = append(buf, `,"id":`...)
+		if b, err = json.Marshal(*a.Id); err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
+	buf = append(buf, `,"name":`...)
+	buf = appendJSONString(buf, a.Name)
+	if len(buf) == start {
+		return append(buf, '{', '}'), nil
+	}
+	buf[start] = '{'
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON decodes the Owner from JSON without reflection.
+func (a *Owner) UnmarshalJSON(data []byte) error {
+	l := jsonLexer{data: data}
+	a.decodeJSON(&l)
+	l.End()
+	return l.err
+}
+
+// decodeJSON decodes the Owner from the next value of l.
+func (a *Owner) decodeJSON(l *jsonLexer) {
+	if l.Null() {
+		return
+	}
+	l.Begin('{')
+	for i := 0; l.More('}', i); i++ {
+		switch l.Field(l.Key(), "id", "name") {
+		case "id":
+			l.Unmarshal(&a.Id)
+		case "name":
+			if !l.Null() {
+				a.Name = l.String()
+			}
+		default:
+			l.Skip()
+		}
+	}
+}
+
+// MarshalJSON encodes the Pet as JSON without reflection.
+func (a Pet) MarshalJSON() ([]byte, error) {
+	return a.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Pet to buf.
+func (a Pet) appendJSON(buf []byte) ([]byte, error) {
+	var b []byte
+	var err error
+	start := len(buf)
+	buf = append(buf, `,"createdAt":`...)
+	if b, err = a.CreatedAt.MarshalJSON(); err != nil {
+		return nil, err
+	}
+	buf = append(buf, b...)
+	if a.Height != nil {
+		buf = append(buf, `,"height":`...)
+		if buf, err = appendJSONFloat(buf, *a.Height, 64); err != nil {
+			return nil, err
+		}
+	}
+	buf = append(buf, `,"id":`...)
+	buf = strconv.AppendInt(buf, a.Id, 10)
+	if a.Kind != nil {
+		buf = append(buf, `,"kind":`...)
+		buf = appendJSONString(buf, string(*a.Kind))
+	}
+	if a.Metadata != nil {
+		buf = append(buf, `,"metadata":`...)
+		if b, err = json.Marshal(*a.Metadata); err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
+	buf = append(buf, `,"name":`...)
+	buf = appendJSONString(buf, a.Name)
+	buf = append(buf, `,"nickname":`...)
+	if a.Nickname == nil {
+		buf = append(buf, "null"...)
+	} else {
+		buf = appendJSONString(buf, *a.Nickname)
+	}
+	if a.Owner != nil {
+		buf = append(buf, `,"owner":`...)
+		if buf, err = a.Owner.appendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	if a.Parent != nil {
+		buf = append(buf, `,"parent":`...)
+		if buf, err = a.Parent.appendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	if a.Scores != nil {
+		buf = append(buf, `,"scores":`...)
+		if *a.Scores == nil {
+			buf = append(buf, "null"...)
+		} else {
+			keys0 := make([]string, 0, len(*a.Scores))
+			for k0 := range *a.Scores {
+				keys0 = append(keys0, k0)
+			}
+			sort.Strings(keys0)
+			buf = append(buf, '{')
+			for i0, k0 := range keys0 {
+				if i0 > 0 {
+					buf = append(buf, ',')
+				}
+				buf = appendJSONString(buf, k0)
+				buf = append(buf, ':')
+				buf = strconv.AppendInt(buf, int64((*a.Scores)[k0]), 10)
+			}
+			buf = append(buf, '}')
+		}
+	}
+	if a.Siblings != nil {
+		buf = append(buf, `,"siblings":`...)
+		if *a.Siblings == nil {
+			buf = append(buf, "null"...)
+		} else {
+			buf = append(buf, '[')
+			for i0, v0 := range *a.Siblings {
+				if i0 > 0 {
+					buf = append(buf, ',')
+				}
+				if buf, err = v0.appendJSON(buf); err != nil {
+					return nil, err
+				}
+			}
+			buf = append(buf, ']')
+		}
+	}
+	buf = append(buf, `,"tags":`...)
+	if a.Tags == nil {
+		buf = append(buf, "null"...)
+	} else {
+		buf = append(buf, '[')
+		for i0, v0 := range a.Tags {
+			if i0 > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, v0)
+		}
+		buf = append(buf, ']')
+	}
+	if a.Toy != nil {
+		buf = append(buf, `,"toy":`...)
+		if b, err = json.Marshal(*a.Toy); err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
+	if a.Vaccinated != nil {
+		buf = append(buf, `,"vaccinated":`...)
+		buf = strconv.AppendBool(buf, *a.Vaccinated)
+	}
+	if a.Weight != nil {
+		buf = append(buf, `,"weight":`...)
+		if buf, err = appendJSONFloat(buf, float64(*a.Weight), 32); err != nil {
+			return nil, err
+		}
+	}
+	if len(buf) == start {
+		return append(buf, '{', '}'), nil
+	}
+	buf[start] = '{'
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON decodes the Pet from JSON without reflection.
+func (a *Pet) UnmarshalJSON(data []byte) error {
+	l := jsonLexer{data: data}
+	a.decodeJSON(&l)
+	l.End()
+	return l.err
+}
+
+// decodeJSON decodes the Pet from the next value of l.
+func (a *Pet) decodeJSON(l *jsonLexer) {
+	if l.Null() {
+		return
+	}
+	l.Begin('{')
+	for i := 0; l.More('}', i); i++ {
+		switch l.Field(l.Key(), "createdAt", "height", "id", "kind", "metadata", "name", "nickname", "owner", "parent", "scores", "siblings", "tags", "toy", "vaccinated", "weight") {
+		case "createdAt":
+			if !l.Null() {
+				l.Unmarshaler(&a.CreatedAt)
+			}
+		case "height":
+			if l.Null() {
+				a.Height = nil
+			} else {
+				if a.Height == nil {
+					a.Height = new(float64)
+				}
+				*a.Height = l.Float(64)
+			}
+		case "id":
+			if !l.Null() {
+				a.Id = l.Int(64)
+			}
+		case "kind":
+			if l.Null() {
+				a.Kind = nil
+			} else {
+				if a.Kind == nil {
+					a.Kind = new(Kind)
+				}
+				*a.Kind = Kind(l.String())
+			}
+		case "metadata":
+			l.Unmarshal(&a.Metadata)
+		case "name":
+			if !l.Null() {
+				a.Name = l.String()
+			}
+		case "nickname":
+			if l.Null() {
+				a.Nickname = nil
+			} else {
+				if a.Nickname == nil {
+					a.Nickname = new(string)
+				}
+				*a.Nickname = l.String()
+			}
+		case "owner":
+			if l.Null() {
+				a.Owner = nil
+			} else {
+				if a.Owner == nil {
+					a.Owner = new(Owner)
+				}
+				a.Owner.decodeJSON(l)
+			}
+		case "parent":
+			if l.Null() {
+				a.Parent = nil
+			} else {
+				if a.Parent == nil {
+					a.Parent = new(Pet)
+				}
+				a.Parent.decodeJSON(l)
+			}
+		case "scores":
+			if l.Null() {
+				a.Scores = nil
+			} else {
+				if a.Scores == nil {
+					a.Scores = new(map[string]int)
+				}
+				if *a.Scores == nil {
+					*a.Scores = make(map[string]int)
+				}
+				l.Begin('{')
+				for i0 := 0; l.More('}', i0); i0++ {
+					k0 := string(l.Key())
+					var v0 int
+					if !l.Null() {
+						v0 = int(l.Int(strconv.IntSize))
+					}
+					(*a.Scores)[k0] = v0
+				}
+			}
+		case "siblings":
+			if l.Null() {
+				a.Siblings = nil
+			} else {
+				if a.Siblings == nil {
+					a.Siblings = new([]Pet)
+				}
+				*a.Siblings = (*a.Siblings)[:0]
+				if *a.Siblings == nil {
+					*a.Siblings = []Pet{}
+				}
+				l.Begin('[')
+				for i0 := 0; l.More(']', i0); i0++ {
+					var v0 Pet
+					v0.decodeJSON(l)
+					*a.Siblings = append(*a.Siblings, v0)
+				}
+			}
+		case "tags":
+			if l.Null() {
+				a.Tags = nil
+			} else {
+				a.Tags = a.Tags[:0]
+				if a.Tags == nil {
+					a.Tags = []string{}
+				}
+				l.Begin('[')
+				for i0 := 0; l.More(']', i0); i0++ {
+					var v0 string
+					if !l.Null() {
+						v0 = l.String()
+					}
+					a.Tags = append(a.Tags, v0)
+				}
+			}
+		case "toy":
+			l.Unmarshal(&a.Toy)
+		case "vaccinated":
+			if l.Null() {
+				a.Vaccinated = nil
+			} else {
+				if a.Vaccinated == nil {
+					a.Vaccinated = new(bool)
+				}
+				*a.Vaccinated = l.Bool()
+			}
+		case "weight":
+			if l.Null() {
+				a.Weight = nil
+			} else {
+				if a.Weight == nil {
+					a.Weight = new(float32)
+				}
+				*a.Weight = float32(l.Float(32))
+			}
+		default:
+			l.Skip()
+		}
+	}
+}
+
+// appendJSONString appends s to buf as a JSON string, escaped like
+// encoding/json escapes it since Go 1.22, which writes \b and \f rather than
+// \u0008 and \u000c.
+func appendJSONString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+		} else if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[r&0xf])
+		} else {
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+
+// appendJSONFloat appends f, a floating point number of the given bits, to
+// buf as a JSON number, formatted like encoding/json formats it.
+func appendJSONFloat(buf []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf, nil
+}
+
+// jsonLexer reads JSON without reflection, for the UnmarshalJSON methods of
+// the hot-path types. It keeps the first error it meets, after which it reads
+// nothing more.
+type jsonLexer struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// fail records err, unless an error was recorded before, and stops reading.
+func (l *jsonLexer) fail(err error) {
+	if l.err == nil {
+		l.err = err
+	}
+	l.pos = len(l.data)
+}
+
+// syntaxError fails on the next character, which isn't the one expected.
+func (l *jsonLexer) syntaxError(expected string) {
+	if l.pos >= len(l.data) {
+		l.fail(errors.New("unexpected end of JSON input"))
+		return
+	}
+	l.fail(fmt.Errorf("invalid character %q at offset %d, expected %s", l.data[l.pos], l.pos, expected))
+}
+
+// skipSpace skips the spaces before the next token.
+func (l *jsonLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case ' ', '\t', '\n', '\r':
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+// consume reads the character c, and returns whether it came next.
+func (l *jsonLexer) consume(c byte) bool {
+	l.skipSpace()
+	if l.pos < len(l.data) && l.data[l.pos] == c {
+		l.pos++
+		return true
+	}
+	return false
+}
+
+// literal reads a literal, like null, and returns whether it came next.
+func (l *jsonLexer) literal(literal string) bool {
+	l.skipSpace()
+	if len(l.data)-l.pos >= len(literal) && string(l.data[l.pos:l.pos+len(literal)]) == literal {
+		l.pos += len(literal)
+		return true
+	}
+	return false
+}
+
+// Null reads a null, and returns whether the next value was one.
+func (l *jsonLexer) Null() bool {
+	return l.literal("null")
+}
+
+// Begin reads the bracket or the brace beginning an array or an object.
+func (l *jsonLexer) Begin(c byte) {
+	if !l.consume(c) {
+		l.syntaxError(strconv.QuoteRune(rune(c)))
+	}
+}
+
+// More returns whether the array or the object being read has a value after
+// the i it had, reading the comma before it, or else the end of the array or
+// the object.
+func (l *jsonLexer) More(end byte, i int) bool {
+	if l.consume(end) {
+		return false
+	}
+	if i > 0 && !l.consume(',') {
+		l.syntaxError("',' or " + strconv.QuoteRune(rune(end)))
+		return false
+	}
+	return l.err == nil
+}
+
+// Key reads the name of the next field of an object, and the colon after it.
+func (l *jsonLexer) Key() []byte {
+	key := l.stringBytes()
+	if !l.consume(':') {
+		l.syntaxError("':'")
+	}
+	return key
+}
+
+// Field returns the name of the field of an object with a key, among names:
+// the one equal to it, or else the first one equal to it under Unicode
+// case-folding, like encoding/json matches them, or "" when none is.
+func (l *jsonLexer) Field(key []byte, names ...string) string {
+	for _, name := range names {
+		if string(key) == name {
+			return name
+		}
+	}
+	for _, name := range names {
+		if bytes.EqualFold(key, []byte(name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// String reads a string.
+func (l *jsonLexer) String() string {
+	return string(l.stringBytes())
+}
+
+// stringBytes reads a string, returning its bytes, which are those of the
+// data unless the string has escapes or invalid UTF-8, which is replaced with
+// U+FFFD like encoding/json does.
+func (l *jsonLexer) stringBytes() []byte {
+	if !l.consume('"') {
+		l.syntaxError("a string")
+		return nil
+	}
+	start := l.pos
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case c == '"':
+			l.pos++
+			return l.data[start : l.pos-1]
+		case c == '\\':
+			return l.unescape(append([]byte(nil), l.data[start:l.pos]...))
+		case c < 0x20:
+			l.syntaxError("a string character")
+			return nil
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(l.data[l.pos:])
+			if r == utf8.RuneError && size == 1 {
+				return l.unescape(append([]byte(nil), l.data[start:l.pos]...))
+			}
+			l.pos += size
+		default:
+			l.pos++
+		}
+	}
+	l.syntaxError(`'"'`)
+	return nil
+}
+
+// unescape reads the rest of a string from its first escape or invalid
+// UTF-8, appending it to s.
+func (l *jsonLexer) unescape(s []byte) []byte {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return s
+		case c < 0x20:
+			l.syntaxError("a string character")
+			return nil
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(l.data[l.pos:])
+			if r == utf8.RuneError && size == 1 {
+				s = append(s, "\uFFFD"...)
+			} else {
+				s = append(s, l.data[l.pos:l.pos+size]...)
+			}
+			l.pos += size
+			continue
+		case c != '\\':
+			s = append(s, c)
+			l.pos++
+			continue
+		}
+		if l.pos++; l.pos >= len(l.data) {
+			break
+		}
+		switch c = l.data[l.pos]; c {
+		case '"', '\\', '/':
+			s = append(s, c)
+		case 'b':
+			s = append(s, '\b')
+		case 'f':
+			s = append(s, '\f')
+		case 'n':
+			s = append(s, '\n')
+		case 'r':
+			s = append(s, '\r')
+		case 't':
+			s = append(s, '\t')
+		case 'u':
+			l.pos++
+			var encoded [utf8.UTFMax]byte
+			s = append(s, encoded[:utf8.EncodeRune(encoded[:], l.escapedRune())]...)
+			continue
+		default:
+			l.syntaxError("an escape character")
+			return nil
+		}
+		l.pos++
+	}
+	l.syntaxError(`'"'`)
+	return nil
+}
+
+// escapedRune reads the four hexadecimal digits of a \u escape, and those of
+// the escape of the low surrogate following a high one.
+func (l *jsonLexer) escapedRune() rune {
+	r := l.hex4()
+	if !utf16.IsSurrogate(r) {
+		return r
+	}
+	if pos := l.pos; pos+6 <= len(l.data) && l.data[pos] == '\\' && l.data[pos+1] == 'u' {
+		l.pos += 2
+		if pair := utf16.DecodeRune(r, l.hex4()); pair != utf8.RuneError {
+			return pair
+		}
+		l.pos = pos
+	}
+	return utf8.RuneError
+}
+
+// hex4 reads four hexadecimal digits.
+func (l *jsonLexer) hex4() rune {
+	if l.pos+4 > len(l.data) {
+		l.syntaxError("four hexadecimal digits")
+		return utf8.RuneError
+	}
+	var r rune
+	for _, c := range l.data[l.pos : l.pos+4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			l.syntaxError("a hexadecimal digit")
+			return utf8.RuneError
+		}
+		r = r<<4 | rune(c)
+	}
+	l.pos += 4
+	return r
+}
+
+// number reads a number, returning its text.
+func (l *jsonLexer) number() []byte {
+	l.skipSpace()
+	start := l.pos
+	digits := func() bool {
+		from := l.pos
+		for l.pos < len(l.data) && '0' <= l.data[l.pos] && l.data[l.pos] <= '9' {
+			l.pos++
+		}
+		return l.pos > from
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '-' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '0' {
+		l.pos++
+	} else if !digits() {
+		l.syntaxError("a number")
+		return nil
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '.' {
+		l.pos++
+		if !digits() {
+			l.syntaxError("a digit")
+			return nil
+		}
+	}
+	if l.pos < len(l.data) && (l.data[l.pos] == 'e' || l.data[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.data) && (l.data[l.pos] == '+' || l.data[l.pos] == '-') {
+			l.pos++
+		}
+		if !digits() {
+			l.syntaxError("a digit")
+			return nil
+		}
+	}
+	return l.data[start:l.pos]
+}
+
+// Int reads an integer of the given bits.
+func (l *jsonLexer) Int(bits int) int64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	i, err := strconv.ParseInt(string(number), 10, bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit integer", number, bits))
+	}
+	return i
+}
+
+// Uint reads an unsigned integer of the given bits.
+func (l *jsonLexer) Uint(bits int) uint64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	u, err := strconv.ParseUint(string(number), 10, bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit unsigned integer", number, bits))
+	}
+	return u
+}
+
+// Float reads a floating point number of the given bits.
+func (l *jsonLexer) Float(bits int) float64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(string(number), bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit floating point number", number, bits))
+	}
+	return f
+}
+
+// Bool reads a boolean.
+func (l *jsonLexer) Bool() bool {
+	if l.literal("true") {
+		return true
+	}
+	if !l.literal("false") {
+		l.syntaxError("a boolean")
+	}
+	return false
+}
+
+// Skip reads the next value, whatever it is.
+func (l *jsonLexer) Skip() {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		l.syntaxError("a value")
+		return
+	}
+	switch l.data[l.pos] {
+	case '{':
+		l.pos++
+		for i := 0; l.More('}', i); i++ {
+			l.Key()
+			l.Skip()
+		}
+	case '[':
+		l.pos++
+		for i := 0; l.More(']', i); i++ {
+			l.Skip()
+		}
+	case '"':
+		l.stringBytes()
+	case 't', 'f':
+		l.Bool()
+	case 'n':
+		if !l.Null() {
+			l.syntaxError("null")
+		}
+	default:
+		l.number()
+	}
+}
+
+// Raw reads the next value, returning its JSON.
+func (l *jsonLexer) Raw() []byte {
+	l.skipSpace()
+	start := l.pos
+	l.Skip()
+	return l.data[start:l.pos]
+}
+
+// Unmarshal decodes the next value into v with encoding/json, for the types
+// which have no hot-path methods.
+func (l *jsonLexer) Unmarshal(v interface{}) {
+	if raw := l.Raw(); l.err == nil {
+		if err := json.Unmarshal(raw, v); err != nil {
+			l.fail(err)
+		}
+	}
+}
+
+// Unmarshaler decodes the next value with the UnmarshalJSON method of u.
+func (l *jsonLexer) Unmarshaler(u json.Unmarshaler) {
+	if raw := l.Raw(); l.err == nil {
+		if err := u.UnmarshalJSON(raw); err != nil {
+			l.fail(err)
+		}
+	}
+}
+
+// End checks that nothing but spaces follows the values read.
+func (l *jsonLexer) End() {
+	l.skipSpace()
+	if l.pos < len(l.data) {
+		l.syntaxError("the end of the JSON")
+	}
+}
@@ -0,0 +1,160 @@
+package hotpath
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reflectedPet has the fields of Pet, but not its methods, so that
+// encoding/json encodes and decodes it by reflection.
+type reflectedPet Pet
+
+func newPet() Pet {
+	kind := Cat
+	weight := float32(4.5)
+	height := 1e-7
+	vaccinated := true
+	nickname := "Tom <the \"cat\"> & co \x01\xff\b\f"
+	scores := map[string]int{"speed": 3, "agility": -2}
+	ownerID := openapi_types.UUID{0x5c, 0x2b, 0x2a, 0x6e, 0x7f, 0x4a, 0x4b, 0xd1, 0x9b, 0x1d, 0x9a, 0x3a, 0x0f, 0x1c, 0x6e, 0x7d}
+	var metadata interface{} = map[string]interface{}{"chip": "A-1", "vet": []interface{}{1.5, true}}
+	parent := Pet{Id: 1, Name: "Felix", Tags: []string{}, CreatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+	siblings := []Pet{parent, {Id: 3, Name: "Kitty"}}
+	return Pet{
+		Id:         2,
+		Name:       "Tom ☺",
+		Kind:       &kind,
+		Weight:     &weight,
+		Height:     &height,
+		Vaccinated: &vaccinated,
+		Tags:       []string{"indoor", "gray"},
+		Scores:     &scores,
+		CreatedAt:  time.Date(2023, 5, 6, 7, 8, 9, 123, time.FixedZone("", 3600)),
+		Owner:      &Owner{Name: "Jerry", Id: &ownerID},
+		Toy:        &Toy{Name: &nickname},
+		Nickname:   &nickname,
+		Metadata:   &metadata,
+		Parent:     &parent,
+		Siblings:   &siblings,
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for name, pet := range map[string]Pet{
+		"full":  newPet(),
+		"empty": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hot, err := pet.MarshalJSON()
+			require.NoError(t, err)
+			reflected, err := json.Marshal(reflectedPet(pet))
+			require.NoError(t, err)
+			assert.Equal(t, string(reflected), string(hot))
+
+			encoded, err := json.Marshal(pet)
+			require.NoError(t, err)
+			assert.Equal(t, string(hot), string(encoded))
+		})
+	}
+
+	owner, err := Owner{}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":""}`, string(owner))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	data, err := json.Marshal(newPet())
+	require.NoError(t, err)
+
+	for name, data := range map[string]string{
+		"marshaled": string(data),
+		"spaced": ` { "id" : 7 , "name" : "Tom\t☺😀\/" , "tags" : [ ] , "unknown" : { "a" : [ 1 , "b" , null , false ] } ,
+			"createdAt" : "2023-05-06T07:08:09Z" , "nickname" : null , "scores" : { } , "weight" : -1.5e2 , "toy" : null } `,
+		"null": `null`,
+		// The keys match the fields case-insensitively, the exact ones first
+		"cased": `{"ID":7,"Name":"Tom","name":"Jerry","NAME":"Spike","CreatedAt":"2023-05-06T07:08:09Z","ſcores":{"a":1},"TOY":{"NAME":"ball"}}`,
+		// And invalid UTF-8 is replaced, escaped or not
+		"invalid utf-8": "{\"name\":\"a\xffb\xc3\",\"nickname\":\"\\n\xe2\x82\",\"tags\":[\"\xed\xa0\x80\",\"\\ud800\"],\"n\xffame\":\"x\"}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var hot Pet
+			require.NoError(t, json.Unmarshal([]byte(data), &hot))
+			var reflected reflectedPet
+			require.NoError(t, json.Unmarshal([]byte(data), &reflected))
+			assert.Equal(t, Pet(reflected), hot)
+		})
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	for _, data := range []string{
+		``,
+		`[]`,
+		`{"id":"7"}`,
+		`{"id":1.5}`,
+		`{"id":99999999999999999999}`,
+		`{"name":"unterminated}`,
+		`{"name":"\x"}`,
+		`{"tags":["a",]}`,
+		`{"tags":["a" "b"]}`,
+		`{"vaccinated":tru}`,
+		`{"weight":1e}`,
+		`{"weight":1e39}`,
+		`{"createdAt":"yesterday"}`,
+		`{"id":1} {}`,
+	} {
+		var pet Pet
+		assert.Error(t, pet.UnmarshalJSON([]byte(data)), data)
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	pet := newPet()
+	b.Run("hot-path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := pet.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		reflected := reflectedPet(pet)
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(reflected); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data, err := json.Marshal(newPet())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("hot-path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pet Pet
+			if err := pet.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pet reflectedPet
+			if err := json.Unmarshal(data, &pet); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -104,6 +104,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetThings request
@@ -119,34 +133,52 @@ func (c *Client) GetThings(ctx context.Context, reqEditors ...RequestEditorFn) (
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewGetThingsRequest generates requests for GetThings
 func NewGetThingsRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/api/my/path")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/my/path")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -222,6 +254,25 @@ func (r GetThingsResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetThingsResponse) Success() (ThingResponse, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero ThingResponse
+	return zero, false
+}
+
+// ETag returns the ETag header of HTTPResponse, identifying the version of
+// the resource
+func (r GetThingsResponse) ETag() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("ETag")
+	}
+	return ""
+}
+
 // GetThingsWithResponse request returning *GetThingsResponse
 func (c *ClientWithResponses) GetThingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetThingsResponse, error) {
 	rsp, err := c.GetThings(ctx, reqEditors...)
@@ -28,11 +28,24 @@ type ServerInterface interface {
 	GetPets(c *gin.Context)
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (GET /pets)
+func (_ Unimplemented) GetPets(c *gin.Context) {
+	c.Status(http.StatusNotImplemented)
+}
+
+// ErrorHandlerFunc handles the errors of binding the parameters of the
+// requests, one of the parameter errors below, with the status code to
+// respond with.
+type ErrorHandlerFunc func(c *gin.Context, err error, statusCode int)
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
 	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
+	ErrorHandler       ErrorHandlerFunc
 }
 
 type MiddlewareFunc func(c *gin.Context)
@@ -50,11 +63,83 @@ func (siw *ServerInterfaceWrapper) GetPets(c *gin.Context) {
 	siw.Handler.GetPets(c)
 }
 
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+	// ErrorHandler handles the errors of binding the parameters of the
+	// requests, responding with the status code and the error in JSON when
+	// unset.
+	ErrorHandler ErrorHandlerFunc
 }
 
 // RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
@@ -103,6 +188,25 @@ type StrictServerInterface interface {
 	GetPets(ctx context.Context, request GetPetsRequestObject) (GetPetsResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (GET /pets)
+func (_ UnimplementedStrictServer) GetPets(ctx context.Context, request GetPetsRequestObject) (GetPetsResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitGetPetsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
 type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
 
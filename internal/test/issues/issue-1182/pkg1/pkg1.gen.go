@@ -92,6 +92,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// TestGet request
@@ -107,34 +121,52 @@ func (c *Client) TestGet(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewTestGetRequest generates requests for TestGet
 func NewTestGetRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/test")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/test")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -237,6 +269,15 @@ type ServerInterface interface {
 	TestGet(ctx echo.Context) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// get test response
+// (GET /test)
+func (_ Unimplemented) TestGet(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -304,6 +345,26 @@ type StrictServerInterface interface {
 	TestGet(ctx context.Context, request TestGetRequestObject) (TestGetResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// get test response
+// (GET /test)
+func (_ UnimplementedStrictServer) TestGet(ctx context.Context, request TestGetRequestObject) (TestGetResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitTestGetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
 type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
 
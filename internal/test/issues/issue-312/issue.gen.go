@@ -116,6 +116,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetPet request
@@ -136,7 +150,7 @@ func (c *Client) GetPet(ctx context.Context, petId string, reqEditors ...Request
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) ValidatePetsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -148,7 +162,7 @@ func (c *Client) ValidatePetsWithBody(ctx context.Context, contentType string, b
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) ValidatePets(ctx context.Context, body ValidatePetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -160,7 +174,7 @@ func (c *Client) ValidatePets(ctx context.Context, body ValidatePetsJSONRequestB
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewGetPetRequest generates requests for GetPet
@@ -174,17 +188,7 @@ func NewGetPetRequest(server string, petId string) (*http.Request, error) {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/pets/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -212,29 +216,47 @@ func NewValidatePetsRequest(server string, body ValidatePetsJSONRequestBody) (*h
 func NewValidatePetsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/pets:validate")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/pets:validate")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -311,6 +333,16 @@ func (r GetPetResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetPetResponse) Success() (Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Pet
+	return zero, false
+}
+
 type ValidatePetsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -334,6 +366,26 @@ func (r ValidatePetsResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r ValidatePetsResponse) Success() ([]Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero []Pet
+	return zero, false
+}
+
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r ValidatePetsResponse) Error() (Error, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}
+
 // GetPetWithResponse request returning *GetPetResponse
 func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, petId string, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
 	rsp, err := c.GetPet(ctx, petId, reqEditors...)
@@ -429,6 +481,21 @@ type ServerInterface interface {
 	ValidatePets(ctx echo.Context) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// Get pet given identifier.
+// (GET /pets/{petId})
+func (_ Unimplemented) GetPet(ctx echo.Context, petId string) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// Validate pets
+// (POST /pets:validate)
+func (_ Unimplemented) ValidatePets(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -440,7 +507,7 @@ func (w *ServerInterfaceWrapper) GetPet(ctx echo.Context) error {
 	// ------------- Path parameter "petId" -------------
 	var petId string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "petId", runtime.ParamLocationPath, ctx.Param("petId"), &petId)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "petId", runtime.ParamLocationPath, echoPathParam(ctx, "petId"), &petId)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter petId: %s", err))
 	}
@@ -459,6 +526,17 @@ func (w *ServerInterfaceWrapper) ValidatePets(ctx echo.Context) error {
 	return err
 }
 
+// echoPathParam returns the escaped value of a path parameter of a request,
+// which echo routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func echoPathParam(ctx echo.Context, name string) string {
+	value := ctx.Param(name)
+	if ctx.Request().URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
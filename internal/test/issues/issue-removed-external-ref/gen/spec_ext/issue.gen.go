@@ -165,6 +165,15 @@ type PascalJSONResponse PascalSchema
 type StrictServerInterface interface {
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
 type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
 type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
 
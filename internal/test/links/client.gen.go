@@ -0,0 +1,1024 @@
+// Package links provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package links
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Owner defines model for Owner.
+type Owner struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+	OwnerId int    `json:"ownerId"`
+}
+
+// ListPetsParams defines parameters for ListPets.
+type ListPetsParams struct {
+	Owner int  `form:"owner" json:"owner"`
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreatePetJSONRequestBody defines body for CreatePet for application/json ContentType.
+type CreatePetJSONRequestBody = Pet
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetOwner request
+	GetOwner(ctx context.Context, ownerId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets request
+	ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePetWithBody request with any body
+	CreatePetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePet(ctx context.Context, body CreatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPet request
+	GetPet(ctx context.Context, petId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProfile request
+	GetProfile(ctx context.Context, profileId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetOwner(ctx context.Context, ownerId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOwnerRequest(c.Server, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) CreatePetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) CreatePet(ctx context.Context, body CreatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetPet(ctx context.Context, petId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, petId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetProfile(ctx context.Context, profileId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProfileRequest(c.Server, profileId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewGetOwnerRequest generates requests for GetOwner
+func NewGetOwnerRequest(server string, ownerId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ownerId", runtime.ParamLocationPath, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/owners/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string, params *ListPetsParams) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/pets")
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "owner", runtime.ParamLocationQuery, params.Owner); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePetRequest calls the generic CreatePet builder with application/json body
+func NewCreatePetRequest(server string, body CreatePetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreatePetRequestWithBody generates requests for CreatePet with any type of body
+func NewCreatePetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/pets")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, petId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "petId", runtime.ParamLocationPath, petId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/pets/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProfileRequest generates requests for GetProfile
+func NewGetProfileRequest(server string, profileId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileId", runtime.ParamLocationPath, profileId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := joinServerURL(server, "/profiles/"+pathParam0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetOwnerWithResponse request
+	GetOwnerWithResponse(ctx context.Context, ownerId int, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error)
+
+	// ListPetsWithResponse request
+	ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// CreatePetWithBodyWithResponse request with any body
+	CreatePetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePetResponse, error)
+
+	CreatePetWithResponse(ctx context.Context, body CreatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePetResponse, error)
+
+	// GetPetWithResponse request
+	GetPetWithResponse(ctx context.Context, petId int, reqEditors ...RequestEditorFn) (*GetPetResponse, error)
+
+	// GetProfileWithResponse request
+	GetProfileWithResponse(ctx context.Context, profileId string, reqEditors ...RequestEditorFn) (*GetProfileResponse, error)
+}
+
+type GetOwnerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Owner
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOwnerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOwnerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetOwnerResponse) Success() (Owner, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Owner
+	return zero, false
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r ListPetsResponse) Success() ([]Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero []Pet
+	return zero, false
+}
+
+type CreatePetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r CreatePetResponse) Success() (Pet, bool) {
+	if r.JSON201 != nil {
+		return *r.JSON201, true
+	}
+	var zero Pet
+	return zero, false
+}
+
+// GetCreatedPet follows the GetCreatedPet link of the 201 response, calling
+// GetPet with the parameters which the link maps from the response
+func (r *CreatePetResponse) GetCreatedPet(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	if r.HTTPResponse == nil {
+		return nil, fmt.Errorf("the GetCreatedPet link can't be followed without a response")
+	}
+	if r.HTTPResponse.StatusCode != 201 {
+		return nil, fmt.Errorf("the GetCreatedPet link is only followed from the 201 response, not from %s", r.Status())
+	}
+
+	var petId int
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$response.body", name: "/id"}, &petId); err != nil {
+		return nil, fmt.Errorf("error mapping the petId parameter of the GetCreatedPet link: %w", err)
+	}
+	return client.GetPetWithResponse(ctx, petId, reqEditors...)
+}
+
+type GetPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetPetResponse) Success() (Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Pet
+	return zero, false
+}
+
+// GetOwnerProfile follows the GetOwnerProfile link of the 200 response, calling
+// GetProfile with the parameters which the link maps from the response
+func (r *GetPetResponse) GetOwnerProfile(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*GetProfileResponse, error) {
+	if r.HTTPResponse == nil {
+		return nil, fmt.Errorf("the GetOwnerProfile link can't be followed without a response")
+	}
+	if r.HTTPResponse.StatusCode != 200 {
+		return nil, fmt.Errorf("the GetOwnerProfile link is only followed from the 200 response, not from %s", r.Status())
+	}
+
+	var profileId string
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$response.body", name: "/ownerId"}, &profileId); err != nil {
+		return nil, fmt.Errorf("error mapping the profileId parameter of the GetOwnerProfile link: %w", err)
+	}
+	return client.GetProfileWithResponse(ctx, profileId, reqEditors...)
+}
+
+// GetPetOwner follows the GetPetOwner link of the 200 response, calling
+// GetOwner with the parameters which the link maps from the response
+func (r *GetPetResponse) GetPetOwner(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error) {
+	if r.HTTPResponse == nil {
+		return nil, fmt.Errorf("the GetPetOwner link can't be followed without a response")
+	}
+	if r.HTTPResponse.StatusCode != 200 {
+		return nil, fmt.Errorf("the GetPetOwner link is only followed from the 200 response, not from %s", r.Status())
+	}
+
+	var ownerId int
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$response.body", name: "/ownerId"}, &ownerId); err != nil {
+		return nil, fmt.Errorf("error mapping the ownerId parameter of the GetPetOwner link: %w", err)
+	}
+	return client.GetOwnerWithResponse(ctx, ownerId, reqEditors...)
+}
+
+// ListSiblings follows the ListSiblings link of the 200 response, calling
+// ListPets with the parameters which the link maps from the response
+func (r *GetPetResponse) ListSiblings(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	if r.HTTPResponse == nil {
+		return nil, fmt.Errorf("the ListSiblings link can't be followed without a response")
+	}
+	if r.HTTPResponse.StatusCode != 200 {
+		return nil, fmt.Errorf("the ListSiblings link is only followed from the 200 response, not from %s", r.Status())
+	}
+
+	var params ListPetsParams
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{value: "10"}, &params.Limit); err != nil {
+		return nil, fmt.Errorf("error mapping the limit parameter of the ListSiblings link: %w", err)
+	}
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$response.body", name: "/ownerId"}, &params.Owner); err != nil {
+		return nil, fmt.Errorf("error mapping the owner parameter of the ListSiblings link: %w", err)
+	}
+	return client.ListPetsWithResponse(ctx, &params, reqEditors...)
+}
+
+// Refresh follows the Refresh link of the 200 response, calling
+// GetPet with the parameters which the link maps from the response
+func (r *GetPetResponse) Refresh(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	if r.HTTPResponse == nil {
+		return nil, fmt.Errorf("the Refresh link can't be followed without a response")
+	}
+	if r.HTTPResponse.StatusCode != 200 {
+		return nil, fmt.Errorf("the Refresh link is only followed from the 200 response, not from %s", r.Status())
+	}
+
+	var petId int
+	if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$request.path", name: "petId", pattern: "^/pets/([^/]*)$"}, &petId); err != nil {
+		return nil, fmt.Errorf("error mapping the petId parameter of the Refresh link: %w", err)
+	}
+	return client.GetPetWithResponse(ctx, petId, reqEditors...)
+}
+
+type GetProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Owner
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetProfileResponse) Success() (Owner, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Owner
+	return zero, false
+}
+
+// GetOwnerWithResponse request returning *GetOwnerResponse
+func (c *ClientWithResponses) GetOwnerWithResponse(ctx context.Context, ownerId int, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error) {
+	rsp, err := c.GetOwner(ctx, ownerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOwnerResponse(rsp)
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// CreatePetWithBodyWithResponse request with arbitrary body returning *CreatePetResponse
+func (c *ClientWithResponses) CreatePetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePetResponse, error) {
+	rsp, err := c.CreatePetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePetResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePetWithResponse(ctx context.Context, body CreatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePetResponse, error) {
+	rsp, err := c.CreatePet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePetResponse(rsp)
+}
+
+// GetPetWithResponse request returning *GetPetResponse
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, petId int, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	rsp, err := c.GetPet(ctx, petId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPetResponse(rsp)
+}
+
+// GetProfileWithResponse request returning *GetProfileResponse
+func (c *ClientWithResponses) GetProfileWithResponse(ctx context.Context, profileId string, reqEditors ...RequestEditorFn) (*GetProfileResponse, error) {
+	rsp, err := c.GetProfile(ctx, profileId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProfileResponse(rsp)
+}
+
+// ParseGetOwnerResponse parses an HTTP response from a GetOwnerWithResponse call
+func ParseGetOwnerResponse(rsp *http.Response) (*GetOwnerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOwnerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Owner
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+		response.Body = nil
+
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+		response.Body = nil
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePetResponse parses an HTTP response from a CreatePetWithResponse call
+func ParseCreatePetResponse(rsp *http.Response) (*CreatePetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParseGetPetResponse(rsp *http.Response) (*GetPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProfileResponse parses an HTTP response from a GetProfileWithResponse call
+func ParseGetProfileResponse(rsp *http.Response) (*GetProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Owner
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+		response.Body = nil
+
+	}
+
+	return response, nil
+}
+
+// linkExpression is the runtime expression, or the constant, from which a
+// link of a response maps a parameter of the linked operation.
+type linkExpression struct {
+	// source is $statusCode, $method, $url, $request.path, $request.query,
+	// $request.header, $response.header or $response.body, or empty for a
+	// constant
+	source string
+	// name is the name of the parameter or header, or the JSON pointer into
+	// the body
+	name string
+	// pattern matches the path of the request, capturing the path parameter
+	pattern string
+	// value is the JSON of the constant
+	value string
+}
+
+// evaluate returns the value of the expression for a response and its body.
+func (e linkExpression) evaluate(rsp *http.Response, body []byte) (interface{}, error) {
+	switch e.source {
+	case "":
+		return json.RawMessage(e.value), nil
+	case "$statusCode":
+		return rsp.StatusCode, nil
+	case "$response.header":
+		return linkValue(rsp.Header.Values(e.name), "the response has no %s header", e.name)
+	case "$response.body":
+		return linkBodyValue(body, e.name)
+	}
+
+	req := rsp.Request
+	if req == nil {
+		return nil, fmt.Errorf("the response has no request to evaluate %s", e.source)
+	}
+	switch e.source {
+	case "$method":
+		return req.Method, nil
+	case "$url":
+		return req.URL.String(), nil
+	case "$request.header":
+		return linkValue(req.Header.Values(e.name), "the request has no %s header", e.name)
+	case "$request.query":
+		return linkValue(req.URL.Query()[e.name], "the request has no %s query parameter", e.name)
+	case "$request.path":
+		match := regexp.MustCompile(e.pattern).FindStringSubmatch(req.URL.EscapedPath())
+		if match == nil {
+			return nil, fmt.Errorf("the path of the request has no %s parameter", e.name)
+		}
+		return url.PathUnescape(match[1])
+	}
+	return nil, fmt.Errorf("unsupported expression %s", e.source)
+}
+
+// linkValue returns the first of the values of a header or parameter, or an
+// error when it has none.
+func linkValue(values []string, format string, name string) (interface{}, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf(format, name)
+	}
+	return values[0], nil
+}
+
+// linkBodyValue returns the value at a JSON pointer of a JSON body, the whole
+// body for an empty pointer.
+func linkBodyValue(body []byte, pointer string) (interface{}, error) {
+	if body == nil {
+		return nil, fmt.Errorf("the response has no body")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("error decoding the body of the response: %w", err)
+	}
+	if pointer == "" {
+		return value, nil
+	}
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("the body of the response has nothing at %s", pointer)
+			}
+			value = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("the body of the response has nothing at %s", pointer)
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("the body of the response has nothing at %s", pointer)
+		}
+	}
+	return value, nil
+}
+
+// followLink sets a parameter of a linked operation to the value of the
+// expression of the link for a response.
+func followLink(rsp *http.Response, body []byte, e linkExpression, dest interface{}) error {
+	value, err := e.evaluate(rsp, body)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, dest)
+	if err == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		// The values of headers and parameters are strings, like "42" for
+		// integers.
+		if json.Unmarshal([]byte(s), dest) == nil {
+			return nil
+		}
+	} else if len(data) > 0 && data[0] != '{' && data[0] != '[' && string(data) != "null" {
+		// And the numbers and booleans map string parameters as their text,
+		// like an integer ID of a body to a string path parameter.
+		quoted, _ := json.Marshal(string(data))
+		if json.Unmarshal(quoted, dest) == nil {
+			return nil
+		}
+	}
+	return err
+}
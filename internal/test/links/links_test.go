@@ -0,0 +1,52 @@
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client of a server answering the pets, owners and
+// profiles, which records the paths of the requests.
+func newClient(t *testing.T) (*ClientWithResponses, *[]string) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/pets/1" {
+			_ = json.NewEncoder(w).Encode(Pet{Id: 1, OwnerId: 7, Name: "Rex"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Owner{Id: 7, Name: "Ada"})
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	return client, &paths
+}
+
+func TestLinksKeepTheBodies(t *testing.T) {
+	client, paths := newClient(t)
+
+	pet, err := client.GetPetWithResponse(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, pet.JSON200)
+	assert.NotNil(t, pet.Body)
+
+	owner, err := pet.GetPetOwner(context.Background(), client)
+	require.NoError(t, err)
+	require.NotNil(t, owner.JSON200)
+	assert.Equal(t, "Ada", owner.JSON200.Name)
+	assert.Nil(t, owner.Body)
+
+	// The integer of the body maps the string path parameter
+	profile, err := pet.GetOwnerProfile(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, profile.StatusCode())
+	assert.Equal(t, []string{"/pets/1", "/owners/7", "/profiles/7"}, *paths)
+}
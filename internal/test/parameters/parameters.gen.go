@@ -210,6 +210,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetContentObject request
@@ -285,7 +299,7 @@ func (c *Client) GetContentObject(ctx context.Context, param ComplexObject, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -297,7 +311,7 @@ func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) EnumParams(ctx context.Context, params *EnumParamsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -309,7 +323,7 @@ func (c *Client) EnumParams(ctx context.Context, params *EnumParamsParams, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -321,7 +335,7 @@ func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -333,7 +347,7 @@ func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -345,7 +359,7 @@ func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -357,7 +371,7 @@ func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -369,7 +383,7 @@ func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -381,7 +395,7 @@ func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEdito
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -393,7 +407,7 @@ func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEdito
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -405,7 +419,7 @@ func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -417,7 +431,7 @@ func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -429,7 +443,7 @@ func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -441,7 +455,7 @@ func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams,
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -453,7 +467,7 @@ func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, r
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -465,7 +479,7 @@ func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -477,7 +491,7 @@ func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -489,7 +503,7 @@ func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -501,7 +515,7 @@ func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -513,7 +527,7 @@ func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -525,7 +539,7 @@ func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewGetContentObjectRequest generates requests for GetContentObject
@@ -539,19 +553,9 @@ func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Reque
 	if err != nil {
 		return nil, err
 	}
-	pathParam0 = string(pathParamBuf0)
+	pathParam0 = url.PathEscape(string(pathParamBuf0))
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/contentObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/contentObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -568,17 +572,7 @@ func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Reque
 func NewGetCookieRequest(server string, params *GetCookieParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/cookie")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/cookie")
 	if err != nil {
 		return nil, err
 	}
@@ -719,17 +713,7 @@ func NewGetCookieRequest(server string, params *GetCookieParams) (*http.Request,
 func NewEnumParamsRequest(server string, params *EnumParamsParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/enums")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/enums")
 	if err != nil {
 		return nil, err
 	}
@@ -768,17 +752,7 @@ func NewEnumParamsRequest(server string, params *EnumParamsParams) (*http.Reques
 func NewGetHeaderRequest(server string, params *GetHeaderParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/header")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/header")
 	if err != nil {
 		return nil, err
 	}
@@ -896,17 +870,7 @@ func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/labelExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/labelExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -930,17 +894,7 @@ func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/labelExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/labelExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -964,17 +918,7 @@ func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Reque
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/labelNoExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/labelNoExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -998,17 +942,7 @@ func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Reque
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/labelNoExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/labelNoExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1032,17 +966,7 @@ func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request,
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/matrixExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/matrixExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1066,17 +990,7 @@ func NewGetMatrixExplodeObjectRequest(server string, id Object) (*http.Request,
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/matrixExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/matrixExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1100,17 +1014,7 @@ func NewGetMatrixNoExplodeArrayRequest(server string, id []int32) (*http.Request
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/matrixNoExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/matrixNoExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1134,17 +1038,7 @@ func NewGetMatrixNoExplodeObjectRequest(server string, id Object) (*http.Request
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/matrixNoExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/matrixNoExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1163,19 +1057,9 @@ func NewGetPassThroughRequest(server string, param string) (*http.Request, error
 
 	var pathParam0 string
 
-	pathParam0 = param
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/passThrough/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	pathParam0 = url.PathEscape(param)
 
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/passThrough/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1192,17 +1076,7 @@ func NewGetPassThroughRequest(server string, param string) (*http.Request, error
 func NewGetDeepObjectRequest(server string, params *GetDeepObjectParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/queryDeepObject")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/queryDeepObject")
 	if err != nil {
 		return nil, err
 	}
@@ -1237,17 +1111,7 @@ func NewGetDeepObjectRequest(server string, params *GetDeepObjectParams) (*http.
 func NewGetQueryFormRequest(server string, params *GetQueryFormParams) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/queryForm")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/queryForm")
 	if err != nil {
 		return nil, err
 	}
@@ -1415,17 +1279,7 @@ func NewGetSimpleExplodeArrayRequest(server string, param []int32) (*http.Reques
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/simpleExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/simpleExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1449,17 +1303,7 @@ func NewGetSimpleExplodeObjectRequest(server string, param Object) (*http.Reques
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/simpleExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/simpleExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1483,17 +1327,7 @@ func NewGetSimpleNoExplodeArrayRequest(server string, param []int32) (*http.Requ
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/simpleNoExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/simpleNoExplodeArray/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1517,17 +1351,7 @@ func NewGetSimpleNoExplodeObjectRequest(server string, param Object) (*http.Requ
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/simpleNoExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/simpleNoExplodeObject/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1551,17 +1375,7 @@ func NewGetSimplePrimitiveRequest(server string, param int32) (*http.Request, er
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/simplePrimitive/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/simplePrimitive/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -1580,29 +1394,47 @@ func NewGetStartingWithNumberRequest(server string, n1param string) (*http.Reque
 
 	var pathParam0 string
 
-	pathParam0 = n1param
+	pathParam0 = url.PathEscape(n1param)
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/startingWithNumber/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/startingWithNumber/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -2678,6 +2510,52 @@ func ParseGetStartingWithNumberResponse(rsp *http.Response) (*GetStartingWithNum
 	return response, nil
 }
 
+// bindHeaderObject binds the value of an object header parameter to dest, like
+// runtime.BindStyledParameterWithLocation, leaving the values of the
+// properties named by unquoted, which are numbers or booleans, unquoted, so
+// that they decode.
+func bindHeaderObject(explode bool, paramName string, value string, unquoted []string, dest interface{}) error {
+	if value == "" {
+		return fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName)
+	}
+	parts := strings.Split(value, ",")
+	var keys, values []string
+	if explode {
+		for _, part := range parts {
+			key, val, found := strings.Cut(part, "=")
+			if !found {
+				return fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+			}
+			keys = append(keys, key)
+			values = append(values, val)
+		}
+	} else {
+		if len(parts)%2 != 0 {
+			return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
+		}
+		for i := 0; i < len(parts); i += 2 {
+			keys = append(keys, parts[i])
+			values = append(values, parts[i+1])
+		}
+	}
+
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		name, _ := json.Marshal(key)
+		field, _ := json.Marshal(values[i])
+		for _, property := range unquoted {
+			if property == key && json.Valid([]byte(values[i])) {
+				field = []byte(values[i])
+			}
+		}
+		fields[i] = string(name) + ":" + string(field)
+	}
+	if err := json.Unmarshal([]byte("{"+strings.Join(fields, ",")+"}"), dest); err != nil {
+		return fmt.Errorf("error binding parameter %s fields: %s", paramName, err)
+	}
+	return nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
@@ -2745,6 +2623,114 @@ type ServerInterface interface {
 	GetStartingWithNumber(ctx echo.Context, n1param string) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (GET /contentObject/{param})
+func (_ Unimplemented) GetContentObject(ctx echo.Context, param ComplexObject) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /cookie)
+func (_ Unimplemented) GetCookie(ctx echo.Context, params GetCookieParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /enums)
+func (_ Unimplemented) EnumParams(ctx echo.Context, params EnumParamsParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /header)
+func (_ Unimplemented) GetHeader(ctx echo.Context, params GetHeaderParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /labelExplodeArray/{.param*})
+func (_ Unimplemented) GetLabelExplodeArray(ctx echo.Context, param []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /labelExplodeObject/{.param*})
+func (_ Unimplemented) GetLabelExplodeObject(ctx echo.Context, param Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /labelNoExplodeArray/{.param})
+func (_ Unimplemented) GetLabelNoExplodeArray(ctx echo.Context, param []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /labelNoExplodeObject/{.param})
+func (_ Unimplemented) GetLabelNoExplodeObject(ctx echo.Context, param Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /matrixExplodeArray/{.id*})
+func (_ Unimplemented) GetMatrixExplodeArray(ctx echo.Context, id []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /matrixExplodeObject/{.id*})
+func (_ Unimplemented) GetMatrixExplodeObject(ctx echo.Context, id Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /matrixNoExplodeArray/{.id})
+func (_ Unimplemented) GetMatrixNoExplodeArray(ctx echo.Context, id []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /matrixNoExplodeObject/{.id})
+func (_ Unimplemented) GetMatrixNoExplodeObject(ctx echo.Context, id Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /passThrough/{param})
+func (_ Unimplemented) GetPassThrough(ctx echo.Context, param string) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /queryDeepObject)
+func (_ Unimplemented) GetDeepObject(ctx echo.Context, params GetDeepObjectParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /queryForm)
+func (_ Unimplemented) GetQueryForm(ctx echo.Context, params GetQueryFormParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /simpleExplodeArray/{param*})
+func (_ Unimplemented) GetSimpleExplodeArray(ctx echo.Context, param []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /simpleExplodeObject/{param*})
+func (_ Unimplemented) GetSimpleExplodeObject(ctx echo.Context, param Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /simpleNoExplodeArray/{param})
+func (_ Unimplemented) GetSimpleNoExplodeArray(ctx echo.Context, param []int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /simpleNoExplodeObject/{param})
+func (_ Unimplemented) GetSimpleNoExplodeObject(ctx echo.Context, param Object) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /simplePrimitive/{param})
+func (_ Unimplemented) GetSimplePrimitive(ctx echo.Context, param int32) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /startingWithNumber/{1param})
+func (_ Unimplemented) GetStartingWithNumber(ctx echo.Context, n1param string) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -2756,7 +2742,11 @@ func (w *ServerInterfaceWrapper) GetContentObject(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param ComplexObject
 
-	err = json.Unmarshal([]byte(ctx.Param("param")), &param)
+	if unescaped, unescapeErr := url.PathUnescape(echoPathParam(ctx, "param")); unescapeErr != nil {
+		err = unescapeErr
+	} else {
+		err = json.Unmarshal([]byte(unescaped), &param)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'param' as JSON")
 	}
@@ -2930,12 +2920,8 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Array-Exploded" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Array-Exploded")]; found {
 		var XArrayExploded []int32
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array-Exploded, got %d", n))
-		}
 
-		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Array-Exploded", runtime.ParamLocationHeader, valueList[0], &XArrayExploded)
+		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Array-Exploded", runtime.ParamLocationHeader, strings.Join(valueList, ","), &XArrayExploded)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array-Exploded: %s", err))
 		}
@@ -2945,12 +2931,8 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Array" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Array")]; found {
 		var XArray []int32
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array, got %d", n))
-		}
 
-		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Array", runtime.ParamLocationHeader, valueList[0], &XArray)
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Array", runtime.ParamLocationHeader, strings.Join(valueList, ","), &XArray)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array: %s", err))
 		}
@@ -2965,7 +2947,7 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object-Exploded, got %d", n))
 		}
 
-		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Object-Exploded", runtime.ParamLocationHeader, valueList[0], &XObjectExploded)
+		err = bindHeaderObject(true, "X-Object-Exploded", valueList[0], nil, &XObjectExploded)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object-Exploded: %s", err))
 		}
@@ -2980,7 +2962,7 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object, got %d", n))
 		}
 
-		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Object", runtime.ParamLocationHeader, valueList[0], &XObject)
+		err = bindHeaderObject(false, "X-Object", valueList[0], nil, &XObject)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object: %s", err))
 		}
@@ -3029,7 +3011,7 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeArray(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param []int32
 
-	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3045,7 +3027,7 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeObject(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param Object
 
-	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3061,7 +3043,7 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeArray(ctx echo.Context) error
 	// ------------- Path parameter "param" -------------
 	var param []int32
 
-	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3077,7 +3059,7 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeObject(ctx echo.Context) error
 	// ------------- Path parameter "param" -------------
 	var param Object
 
-	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3093,7 +3075,7 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeArray(ctx echo.Context) error {
 	// ------------- Path parameter "id" -------------
 	var id []int32
 
-	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, echoPathParam(ctx, "id"), &id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
@@ -3109,7 +3091,7 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeObject(ctx echo.Context) error
 	// ------------- Path parameter "id" -------------
 	var id Object
 
-	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, echoPathParam(ctx, "id"), &id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
@@ -3125,7 +3107,7 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeArray(ctx echo.Context) error
 	// ------------- Path parameter "id" -------------
 	var id []int32
 
-	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, echoPathParam(ctx, "id"), &id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
@@ -3141,7 +3123,7 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeObject(ctx echo.Context) erro
 	// ------------- Path parameter "id" -------------
 	var id Object
 
-	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, echoPathParam(ctx, "id"), &id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
@@ -3157,7 +3139,10 @@ func (w *ServerInterfaceWrapper) GetPassThrough(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param string
 
-	param = ctx.Param("param")
+	param, err = url.PathUnescape(echoPathParam(ctx, "param"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetPassThrough(ctx, param)
@@ -3268,7 +3253,7 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeArray(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param []int32
 
-	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3284,7 +3269,7 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeObject(ctx echo.Context) error
 	// ------------- Path parameter "param" -------------
 	var param Object
 
-	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3300,7 +3285,7 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeArray(ctx echo.Context) error
 	// ------------- Path parameter "param" -------------
 	var param []int32
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3316,7 +3301,7 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeObject(ctx echo.Context) erro
 	// ------------- Path parameter "param" -------------
 	var param Object
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3332,7 +3317,7 @@ func (w *ServerInterfaceWrapper) GetSimplePrimitive(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param int32
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, echoPathParam(ctx, "param"), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
 	}
@@ -3348,13 +3333,27 @@ func (w *ServerInterfaceWrapper) GetStartingWithNumber(ctx echo.Context) error {
 	// ------------- Path parameter "1param" -------------
 	var n1param string
 
-	n1param = ctx.Param("1param")
+	n1param, err = url.PathUnescape(echoPathParam(ctx, "1param"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetStartingWithNumber(ctx, n1param)
 	return err
 }
 
+// echoPathParam returns the escaped value of a path parameter of a request,
+// which echo routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func echoPathParam(ctx echo.Context, name string) string {
+	value := ctx.Param(name)
+	if ctx.Request().URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -184,6 +184,20 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// EnsureEverythingIsReferenced request
@@ -230,7 +244,7 @@ func (c *Client) EnsureEverythingIsReferenced(ctx context.Context, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue1051(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -242,7 +256,7 @@ func (c *Client) Issue1051(ctx context.Context, reqEditors ...RequestEditorFn) (
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue127(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -254,7 +268,7 @@ func (c *Client) Issue127(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue185WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -266,7 +280,7 @@ func (c *Client) Issue185WithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue185(ctx context.Context, body Issue185JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -278,7 +292,7 @@ func (c *Client) Issue185(ctx context.Context, body Issue185JSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue209(ctx context.Context, str StringInPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -290,7 +304,7 @@ func (c *Client) Issue209(ctx context.Context, str StringInPath, reqEditors ...R
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue30(ctx context.Context, pFallthrough string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -302,7 +316,7 @@ func (c *Client) Issue30(ctx context.Context, pFallthrough string, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) GetIssues375(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -314,7 +328,7 @@ func (c *Client) GetIssues375(ctx context.Context, reqEditors ...RequestEditorFn
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue41(ctx context.Context, n1param N5StartsWithNumber, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -326,7 +340,7 @@ func (c *Client) Issue41(ctx context.Context, n1param N5StartsWithNumber, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue9WithBody(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -338,7 +352,7 @@ func (c *Client) Issue9WithBody(ctx context.Context, params *Issue9Params, conte
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue9(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -350,7 +364,7 @@ func (c *Client) Issue9(ctx context.Context, params *Issue9Params, body Issue9JS
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 func (c *Client) Issue975(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -362,24 +376,14 @@ func (c *Client) Issue975(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.requestDoer(req).Do(req)
 }
 
 // NewEnsureEverythingIsReferencedRequest generates requests for EnsureEverythingIsReferenced
 func NewEnsureEverythingIsReferencedRequest(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/ensure-everything-is-referenced")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/ensure-everything-is-referenced")
 	if err != nil {
 		return nil, err
 	}
@@ -396,17 +400,7 @@ func NewEnsureEverythingIsReferencedRequest(server string) (*http.Request, error
 func NewIssue1051Request(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/1051")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/1051")
 	if err != nil {
 		return nil, err
 	}
@@ -423,17 +417,7 @@ func NewIssue1051Request(server string) (*http.Request, error) {
 func NewIssue127Request(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/127")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/127")
 	if err != nil {
 		return nil, err
 	}
@@ -461,17 +445,7 @@ func NewIssue185Request(server string, body Issue185JSONRequestBody) (*http.Requ
 func NewIssue185RequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/185")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/185")
 	if err != nil {
 		return nil, err
 	}
@@ -497,17 +471,7 @@ func NewIssue209Request(server string, str StringInPath) (*http.Request, error)
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/209/$%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/209/$"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -531,17 +495,7 @@ func NewIssue30Request(server string, pFallthrough string) (*http.Request, error
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/30/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/30/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -558,17 +512,7 @@ func NewIssue30Request(server string, pFallthrough string) (*http.Request, error
 func NewGetIssues375Request(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/375")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/375")
 	if err != nil {
 		return nil, err
 	}
@@ -592,17 +536,7 @@ func NewIssue41Request(server string, n1param N5StartsWithNumber) (*http.Request
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/41/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/41/"+pathParam0)
 	if err != nil {
 		return nil, err
 	}
@@ -630,17 +564,7 @@ func NewIssue9Request(server string, params *Issue9Params, body Issue9JSONReques
 func NewIssue9RequestWithBody(server string, params *Issue9Params, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/9")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := joinServerURL(server, "/issues/9")
 	if err != nil {
 		return nil, err
 	}
@@ -677,27 +601,45 @@ func NewIssue9RequestWithBody(server string, params *Issue9Params, contentType s
 func NewIssue975Request(server string) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	queryURL, err := joinServerURL(server, "/issues/975")
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/issues/975")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
 	if err != nil {
 		return nil, err
 	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
 
-	return req, nil
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
 }
 
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
@@ -808,6 +750,32 @@ func (r EnsureEverythingIsReferencedResponse) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r EnsureEverythingIsReferencedResponse) Success() (struct {
+	AnyType1 *AnyType1 `json:"anyType1,omitempty"`
+
+	// AnyType2 AnyType2 represents any type.
+	//
+	// This should be an interface{}
+	AnyType2         *AnyType2         `json:"anyType2,omitempty"`
+	CustomStringType *CustomStringType `foo:"bar" json:"customStringType,omitempty"`
+}, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero struct {
+		AnyType1 *AnyType1 `json:"anyType1,omitempty"`
+
+		// AnyType2 AnyType2 represents any type.
+		//
+		// This should be an interface{}
+		AnyType2         *AnyType2         `json:"anyType2,omitempty"`
+		CustomStringType *CustomStringType `foo:"bar" json:"customStringType,omitempty"`
+	}
+	return zero, false
+}
+
 type Issue1051Response struct {
 	Body                             []byte
 	HTTPResponse                     *http.Response
@@ -831,6 +799,19 @@ func (r Issue1051Response) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r Issue1051Response) Success() (map[string]interface{}, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	if r.ApplicationvndSomethingV1JSON200 != nil {
+		return *r.ApplicationvndSomethingV1JSON200, true
+	}
+	var zero map[string]interface{}
+	return zero, false
+}
+
 type Issue127Response struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -856,6 +837,32 @@ func (r Issue127Response) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r Issue127Response) Success() (GenericObject, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	if r.XML200 != nil {
+		return *r.XML200, true
+	}
+	if r.YAML200 != nil {
+		return *r.YAML200, true
+	}
+	var zero GenericObject
+	return zero, false
+}
+
+// Error returns the decoded body of the error response, and whether the
+// response was one
+func (r Issue127Response) Error() (GenericObject, bool) {
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero GenericObject
+	return zero, false
+}
+
 type Issue185Response struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -941,6 +948,16 @@ func (r GetIssues375Response) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetIssues375Response) Success() (EnumInObjInArray, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero EnumInObjInArray
+	return zero, false
+}
+
 type Issue41Response struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1005,6 +1022,16 @@ func (r Issue975Response) StatusCode() int {
 	return 0
 }
 
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r Issue975Response) Success() (DeprecatedProperty, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero DeprecatedProperty
+	return zero, false
+}
+
 // EnsureEverythingIsReferencedWithResponse request returning *EnsureEverythingIsReferencedResponse
 func (c *ClientWithResponses) EnsureEverythingIsReferencedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnsureEverythingIsReferencedResponse, error) {
 	rsp, err := c.EnsureEverythingIsReferenced(ctx, reqEditors...)
@@ -1397,6 +1424,59 @@ type ServerInterface interface {
 	Issue975(ctx echo.Context) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (GET /ensure-everything-is-referenced)
+func (_ Unimplemented) EnsureEverythingIsReferenced(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/1051)
+func (_ Unimplemented) Issue1051(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/127)
+func (_ Unimplemented) Issue127(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/185)
+func (_ Unimplemented) Issue185(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/209/${str})
+func (_ Unimplemented) Issue209(ctx echo.Context, str StringInPath) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/30/{fallthrough})
+func (_ Unimplemented) Issue30(ctx echo.Context, pFallthrough string) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/375)
+func (_ Unimplemented) GetIssues375(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/41/{1param})
+func (_ Unimplemented) Issue41(ctx echo.Context, n1param N5StartsWithNumber) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/9)
+func (_ Unimplemented) Issue9(ctx echo.Context, params Issue9Params) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /issues/975)
+func (_ Unimplemented) Issue975(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -1452,7 +1532,7 @@ func (w *ServerInterfaceWrapper) Issue209(ctx echo.Context) error {
 	// ------------- Path parameter "str" -------------
 	var str StringInPath
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "str", runtime.ParamLocationPath, ctx.Param("str"), &str)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "str", runtime.ParamLocationPath, echoPathParam(ctx, "str"), &str)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter str: %s", err))
 	}
@@ -1470,7 +1550,7 @@ func (w *ServerInterfaceWrapper) Issue30(ctx echo.Context) error {
 	// ------------- Path parameter "fallthrough" -------------
 	var pFallthrough string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, ctx.Param("fallthrough"), &pFallthrough)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, echoPathParam(ctx, "fallthrough"), &pFallthrough)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fallthrough: %s", err))
 	}
@@ -1499,7 +1579,7 @@ func (w *ServerInterfaceWrapper) Issue41(ctx echo.Context) error {
 	// ------------- Path parameter "1param" -------------
 	var n1param N5StartsWithNumber
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "1param", runtime.ParamLocationPath, ctx.Param("1param"), &n1param)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "1param", runtime.ParamLocationPath, echoPathParam(ctx, "1param"), &n1param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err))
 	}
@@ -1542,6 +1622,17 @@ func (w *ServerInterfaceWrapper) Issue975(ctx echo.Context) error {
 	return err
 }
 
+// echoPathParam returns the escaped value of a path parameter of a request,
+// which echo routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func echoPathParam(ctx echo.Context, name string) string {
+	value := ctx.Param(name)
+	if ctx.Request().URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -0,0 +1,590 @@
+// Package security provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	ApiKeyScopes = "apiKey.Scopes"
+	OauthScopes  = "oauth.Scopes"
+)
+
+// SecurityScheme describes a security scheme declared in the spec, and where
+// requests carry its credential.
+type SecurityScheme struct {
+	Name      string // The name of the scheme in components.securitySchemes
+	Type      string // apiKey, http, oauth2, openIdConnect or mutualTLS
+	In        string // Where an apiKey is passed: header, query or cookie
+	ParamName string // The name of the header, query parameter or cookie carrying an apiKey
+	Scheme    string // The authorization scheme of an http scheme, like bearer or basic
+}
+
+// SecuritySchemes are the security schemes declared in the spec, by name.
+var SecuritySchemes = map[string]SecurityScheme{
+	"apiKey": {Name: "apiKey", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+	"oauth":  {Name: "oauth", Type: "oauth2"},
+}
+
+// The scopes of the oauth security scheme.
+const (
+	OauthScopePetsAdmin = "pets:admin"
+	OauthScopePetsRead  = "pets:read"
+	OauthScopePetsWrite = "pets:write"
+)
+
+// SecurityRequirement maps each of the schemes which must all be satisfied to
+// the scopes it requires.
+type SecurityRequirement map[string][]string
+
+// Scopes returns the scopes required by all the schemes of the requirement,
+// sorted and without duplicates.
+func (requirement SecurityRequirement) Scopes() []string {
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _, schemeScopes := range requirement {
+		for _, scope := range schemeScopes {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// OperationSecurity holds the alternative security requirements of the
+// operations, by operation ID. Meeting any of them grants access, and
+// operations without any are public.
+var OperationSecurity = map[string][]SecurityRequirement{
+	"ListPets": {
+		{"oauth": []string{"pets:read"}},
+		{"apiKey": []string{}},
+	},
+	"CreatePet": {
+		{"oauth": []string{"pets:write"}},
+		{"oauth": []string{"pets:admin"}},
+	},
+}
+
+// OperationScopes holds the scopes named by each of the alternative security
+// requirements of the operations, by operation ID. Those of any one of them
+// suffice, and they're empty for a requirement naming none.
+var OperationScopes = map[string][][]string{
+	"ListPets":  [][]string{{"pets:read"}, {}},
+	"CreatePet": [][]string{{"pets:write"}, {"pets:admin"}},
+}
+
+// AuthenticationFunc checks the credential presented for the given security
+// scheme, which CredentialFromContext returns, and that it grants the given
+// scopes. It returns an error to reject it.
+type AuthenticationFunc func(ctx context.Context, scheme string, scopes []string) error
+
+// ErrMissingCredential is the reason a security requirement isn't met when
+// the request doesn't carry the credential of one of its schemes.
+var ErrMissingCredential = errors.New("missing credential")
+
+// AuthenticationError is returned by Authenticate when a request meets none of
+// the security requirements of its operation.
+type AuthenticationError struct {
+	OperationID string
+	// The reasons each of the requirements wasn't met, in order.
+	Errors []error
+}
+
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("request to %s is not authenticated: %v", e.OperationID, errors.Join(e.Errors...))
+}
+
+func (e *AuthenticationError) Unwrap() []error {
+	return e.Errors
+}
+
+// credentialContextKey is the context key under which the credential being
+// authenticated is stored.
+type credentialContextKey struct{}
+
+// requirementsContextKey is the context key under which SecurityMiddleware
+// stores the security requirements which the request meets.
+type requirementsContextKey struct{}
+
+// CredentialFromContext returns the credential which an AuthenticationFunc is
+// called to check: the API key of an apiKey scheme, or the part of the
+// Authorization header following the authorization scheme for the http,
+// oauth2 and openIdConnect schemes.
+func CredentialFromContext(ctx context.Context) string {
+	credential, _ := ctx.Value(credentialContextKey{}).(string)
+	return credential
+}
+
+// Authenticate checks the request against the security requirements of the
+// given operation. Each requirement is met when the request carries the
+// credential of each of its schemes, and authenticate accepts all of them.
+// It returns an *AuthenticationError when none is met.
+func Authenticate(r *http.Request, operationID string, authenticate AuthenticationFunc) error {
+	requirements := OperationSecurity[operationID]
+	if len(requirements) == 0 {
+		return nil
+	}
+	authErr := &AuthenticationError{OperationID: operationID}
+	for _, requirement := range requirements {
+		err := authenticateRequirement(r, requirement, authenticate)
+		if err == nil {
+			return nil
+		}
+		authErr.Errors = append(authErr.Errors, err)
+	}
+	return authErr
+}
+
+// authenticateAll is Authenticate, returning all the security requirements of
+// the operation which the request meets rather than stopping at the first.
+func authenticateAll(r *http.Request, operationID string, authenticate AuthenticationFunc) ([]SecurityRequirement, error) {
+	requirements := OperationSecurity[operationID]
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+	var met []SecurityRequirement
+	authErr := &AuthenticationError{OperationID: operationID}
+	for _, requirement := range requirements {
+		if err := authenticateRequirement(r, requirement, authenticate); err != nil {
+			authErr.Errors = append(authErr.Errors, err)
+			continue
+		}
+		met = append(met, requirement)
+	}
+	if len(met) == 0 {
+		return nil, authErr
+	}
+	return met, nil
+}
+
+// SecurityRequirementsFromContext returns the security requirements which
+// SecurityMiddleware found the request of ctx to meet, in the order of the
+// operation, and false when it didn't authenticate it.
+func SecurityRequirementsFromContext(ctx context.Context) ([]SecurityRequirement, bool) {
+	requirements, ok := ctx.Value(requirementsContextKey{}).([]SecurityRequirement)
+	return requirements, ok
+}
+
+// authenticateRequirement checks that the request meets all the schemes of a
+// security requirement.
+func authenticateRequirement(r *http.Request, requirement SecurityRequirement, authenticate AuthenticationFunc) error {
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		scheme, ok := SecuritySchemes[name]
+		if !ok {
+			return fmt.Errorf("unknown security scheme %q", name)
+		}
+		credential, ok := extractCredential(r, scheme)
+		if !ok {
+			return fmt.Errorf("%s: %w", name, ErrMissingCredential)
+		}
+		ctx := context.WithValue(r.Context(), credentialContextKey{}, credential)
+		if err := authenticate(ctx, name, requirement[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// extractCredential returns the credential the request carries for a
+// security scheme, and whether it carries one at all.
+func extractCredential(r *http.Request, scheme SecurityScheme) (string, bool) {
+	switch scheme.Type {
+	case "apiKey":
+		switch scheme.In {
+		case "header":
+			value := r.Header.Get(scheme.ParamName)
+			return value, value != ""
+		case "query":
+			value := r.URL.Query().Get(scheme.ParamName)
+			return value, value != ""
+		case "cookie":
+			cookie, err := r.Cookie(scheme.ParamName)
+			if err != nil || cookie.Value == "" {
+				return "", false
+			}
+			return cookie.Value, true
+		}
+	case "http":
+		return authorizationCredential(r, scheme.Scheme)
+	case "oauth2", "openIdConnect":
+		return authorizationCredential(r, "bearer")
+	case "mutualTLS":
+		return "", r.TLS != nil && len(r.TLS.PeerCertificates) > 0
+	}
+	return "", false
+}
+
+// authorizationCredential returns what follows the given authorization scheme
+// in the Authorization header of the request.
+func authorizationCredential(r *http.Request, scheme string) (string, bool) {
+	authorization := r.Header.Get("Authorization")
+	prefix, credential, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(prefix, scheme) {
+		return "", false
+	}
+	credential = strings.TrimSpace(credential)
+	return credential, credential != ""
+}
+
+// SecurityMiddleware returns a middleware enforcing the security requirements
+// of the given operation with Authenticate. Requests meeting none of them are
+// rejected with WriteAuthenticationError, without calling the next handler.
+// It gets the others with the requirements they meet in their context, for
+// SecurityRequirementsFromContext.
+func SecurityMiddleware(operationID string, authenticate AuthenticationFunc) func(http.Handler) http.Handler {
+	if authenticate == nil {
+		panic("SecurityMiddleware requires an AuthenticationFunc")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			met, err := authenticateAll(r, operationID, authenticate)
+			if err != nil {
+				WriteAuthenticationError(w, r, err)
+				return
+			}
+			if met != nil {
+				r = r.WithContext(context.WithValue(r.Context(), requirementsContextKey{}, met))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ScopesFunc is called with the scopes named by a security requirement of an
+// operation, as listed in OperationScopes, to check that the authenticated
+// caller was granted them. It returns an error to reject them.
+type ScopesFunc func(ctx context.Context, operationID string, scopes []string) error
+
+// ScopesMiddleware returns a middleware calling hook with the scopes named by
+// the security requirements of the given operation, if any, for example to
+// check them against the claims of a token validated by SecurityMiddleware.
+// The scopes of each of the alternative requirements are checked in turn, and
+// the request is accepted as soon as one of them is granted, or names none.
+// Behind SecurityMiddleware, only the requirements which the request meets
+// are checked. Requests it rejects get a 403 response from
+// WriteInsufficientScopeError.
+func ScopesMiddleware(operationID string, hook ScopesFunc) func(http.Handler) http.Handler {
+	if hook == nil {
+		panic("ScopesMiddleware requires a ScopesFunc")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := checkScopes(r.Context(), operationID, hook); err != nil {
+				WriteInsufficientScopeError(w, r, operationID)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// checkScopes calls hook with the scopes of the alternative security
+// requirements of the operation until one of them is granted, returning the
+// error of the last one otherwise.
+func checkScopes(ctx context.Context, operationID string, hook ScopesFunc) error {
+	alternatives := OperationScopes[operationID]
+	if met, ok := SecurityRequirementsFromContext(ctx); ok {
+		alternatives = make([][]string, len(met))
+		for i, requirement := range met {
+			alternatives[i] = requirement.Scopes()
+		}
+	}
+	var err error
+	for _, scopes := range alternatives {
+		if len(scopes) == 0 {
+			return nil
+		}
+		if err = hook(ctx, operationID, scopes); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// WriteInsufficientScopeError rejects a request lacking the scopes required by
+// the given operation with a 403 response. It carries the insufficient_scope
+// challenge of RFC 6750, with the scopes of the first security requirement
+// which the request meets, or else of the first of the operation, and an
+// RFC 7807 problem body.
+func WriteInsufficientScopeError(w http.ResponseWriter, r *http.Request, operationID string) {
+	var scopes []string
+	if met, ok := SecurityRequirementsFromContext(r.Context()); ok {
+		scopes = met[0].Scopes()
+	} else if alternatives := OperationScopes[operationID]; len(alternatives) > 0 {
+		scopes = alternatives[0]
+	}
+	w.Header().Add("WWW-Authenticate", fmt.Sprintf("Bearer error=\"insufficient_scope\", scope=%q", strings.Join(scopes, " ")))
+	writeSecurityProblem(w, http.StatusForbidden, "The credentials of the request lack the scopes required by the operation.")
+}
+
+// WriteAuthenticationError rejects an unauthenticated request with a 401
+// response. It challenges the client with the http and oauth2 schemes of the
+// operation in WWW-Authenticate headers, as RFC 7235 requires, and describes
+// the problem in an RFC 7807 body, leaving out the details of err.
+func WriteAuthenticationError(w http.ResponseWriter, r *http.Request, err error) {
+	var authErr *AuthenticationError
+	if errors.As(err, &authErr) {
+		challenged := make(map[string]bool)
+		for _, requirement := range OperationSecurity[authErr.OperationID] {
+			for name := range requirement {
+				switch scheme := SecuritySchemes[name]; scheme.Type {
+				case "http":
+					if scheme.Scheme != "" {
+						challenged[scheme.Scheme] = true
+					}
+				case "oauth2", "openIdConnect":
+					challenged["bearer"] = true
+				}
+			}
+		}
+		challenges := make([]string, 0, len(challenged))
+		for challenge := range challenged {
+			challenges = append(challenges, challenge)
+		}
+		sort.Strings(challenges)
+		for _, challenge := range challenges {
+			w.Header().Add("WWW-Authenticate", strings.ToUpper(challenge[:1])+challenge[1:])
+		}
+	}
+	writeSecurityProblem(w, http.StatusUnauthorized, "The request does not carry valid credentials for the operation.")
+}
+
+// writeSecurityProblem writes an RFC 7807 problem response.
+func writeSecurityProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "about:blank",
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": detail,
+	})
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request)
+
+	// (POST /pets)
+	CreatePet(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// (GET /pets)
+func (_ Unimplemented) ListPets(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /pets)
+func (_ Unimplemented) CreatePet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, OauthScopes, []string{"pets:read"})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreatePet operation middleware
+func (siw *ServerInterfaceWrapper) CreatePet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, OauthScopes, []string{"pets:write"})
+
+	ctx = context.WithValue(ctx, OauthScopes, []string{"pets:admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// AuthenticationFunc checks the credentials of requests to the operations
+	// with security requirements, which are enforced by SecurityMiddleware. It
+	// must be set when there are any.
+	AuthenticationFunc AuthenticationFunc
+	// ScopesFunc, when set, is called by ScopesMiddleware with the scopes
+	// named by the security requirement which each request to the operations
+	// naming any meets, after their security requirements are enforced.
+	ScopesFunc ScopesFunc
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(SecurityMiddleware("ListPets", options.AuthenticationFunc))
+		if options.ScopesFunc != nil {
+			r.Use(ScopesMiddleware("ListPets", options.ScopesFunc))
+		}
+		r.Get(options.BaseURL+"/pets", wrapper.ListPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(SecurityMiddleware("CreatePet", options.AuthenticationFunc))
+		if options.ScopesFunc != nil {
+			r.Use(ScopesMiddleware("CreatePet", options.ScopesFunc))
+		}
+		r.Post(options.BaseURL+"/pets", wrapper.CreatePet)
+	})
+
+	return r
+}
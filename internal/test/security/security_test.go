@@ -0,0 +1,108 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) ListPets(w http.ResponseWriter, r *http.Request) {}
+
+func (server) CreatePet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+}
+
+// authenticate accepts any bearer token, and the API key "key".
+func authenticate(ctx context.Context, scheme string, scopes []string) error {
+	if scheme == "apiKey" && CredentialFromContext(ctx) != "key" {
+		return errors.New("unknown key")
+	}
+	return nil
+}
+
+type grantedContextKey struct{}
+
+// grantScopes accepts the scopes granted by withGrantedScopes.
+func grantScopes(ctx context.Context, operationID string, scopes []string) error {
+	granted, _ := ctx.Value(grantedContextKey{}).(string)
+	for _, scope := range scopes {
+		if !strings.Contains(","+granted+",", ","+scope+",") {
+			return errors.New("scope not granted: " + scope)
+		}
+	}
+	return nil
+}
+
+// withGrantedScopes passes the scopes granted by the bearer token of the
+// request to grantScopes. The bearer tokens of the tests are the scopes they
+// grant, separated by commas.
+func withGrantedScopes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		granted := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), grantedContextKey{}, granted)))
+	})
+}
+
+func TestAlternativeScopes(t *testing.T) {
+	handler := withGrantedScopes(HandlerWithOptions(server{}, ChiServerOptions{
+		AuthenticationFunc: authenticate,
+		ScopesFunc:         grantScopes,
+	}))
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		header http.Header
+		want   int
+		scope  string
+	}{
+		{"api key without scopes", http.MethodGet, http.Header{"X-Api-Key": {"key"}}, http.StatusOK, ""},
+		{"token lacking the scope", http.MethodGet, http.Header{"Authorization": {"Bearer pets:write"}}, http.StatusForbidden, "pets:read"},
+		{"token granting the scope", http.MethodGet, http.Header{"Authorization": {"Bearer pets:read"}}, http.StatusOK, ""},
+		{"token granting the first alternative", http.MethodPost, http.Header{"Authorization": {"Bearer pets:write"}}, http.StatusCreated, ""},
+		{"token granting the second alternative", http.MethodPost, http.Header{"Authorization": {"Bearer pets:admin"}}, http.StatusCreated, ""},
+		{"token granting neither alternative", http.MethodPost, http.Header{"Authorization": {"Bearer pets:read"}}, http.StatusForbidden, "pets:write"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/pets", nil)
+			req.Header = tt.header
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+			if tt.scope != "" {
+				assert.Equal(t, `Bearer error="insufficient_scope", scope="`+tt.scope+`"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestScopesMiddlewareAlone(t *testing.T) {
+	// Without SecurityMiddleware, each alternative is tried in turn
+	handler := withGrantedScopes(ScopesMiddleware("CreatePet", grantScopes)(http.HandlerFunc(server{}.CreatePet)))
+
+	for granted, want := range map[string]int{
+		"pets:admin": http.StatusCreated,
+		"pets:write": http.StatusCreated,
+		"pets:read":  http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/pets", nil)
+		req.Header.Set("Authorization", "Bearer "+granted)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, granted)
+	}
+
+	// An alternative naming no scopes needs none
+	handler = ScopesMiddleware("ListPets", grantScopes)(http.HandlerFunc(server{}.ListPets))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
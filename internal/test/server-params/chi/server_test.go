@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) GetFile(w http.ResponseWriter, r *http.Request, name string) {
+	fmt.Fprint(w, name)
+}
+
+func (server) GetJSON(w http.ResponseWriter, r *http.Request, name string) {
+	fmt.Fprint(w, name)
+}
+
+func (server) GetRaw(w http.ResponseWriter, r *http.Request, name string) {
+	fmt.Fprint(w, name)
+}
+
+func (server) GetThing(w http.ResponseWriter, r *http.Request, id int, params GetThingParams) {
+	fmt.Fprint(w, id)
+}
+
+func TestEscapedPathParams(t *testing.T) {
+	handler := HandlerFromMux(server{}, chi.NewRouter())
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"/files/50%25off", "50%off"},
+		{"/files/a%2541", "a%41"},
+		{"/files/a%2Fb", "a/b"},
+		{"/raw/50%25off", "50%off"},
+		{"/raw/a%2541", "a%41"},
+		{"/raw/a%2Fb", "a/b"},
+		{"/json/%2250%25off%22", "50%off"},
+		{"/json/%22a%2Fb%22", "a/b"},
+		{"/things/7", "7"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) GetFile(ctx echo.Context, name string) error {
+	return ctx.String(http.StatusOK, name)
+}
+
+func (server) GetJSON(ctx echo.Context, name string) error {
+	return ctx.String(http.StatusOK, name)
+}
+
+func (server) GetRaw(ctx echo.Context, name string) error {
+	return ctx.String(http.StatusOK, name)
+}
+
+func (server) GetThing(ctx echo.Context, id int, params GetThingParams) error {
+	return ctx.String(http.StatusOK, strconv.Itoa(id))
+}
+
+func TestEscapedPathParams(t *testing.T) {
+	e := echo.New()
+	RegisterHandlers(e, server{})
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"/files/50%25off", "50%off"},
+		{"/files/a%2541", "a%41"},
+		{"/files/a%2Fb", "a/b"},
+		{"/raw/50%25off", "50%off"},
+		{"/raw/a%2541", "a%41"},
+		{"/raw/a%2Fb", "a/b"},
+		{"/json/%2250%25off%22", "50%off"},
+		{"/json/%22a%2Fb%22", "a/b"},
+		{"/things/7", "7"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) GetFile(c *fiber.Ctx, name string) error {
+	return c.SendString(name)
+}
+
+func (server) GetJSON(c *fiber.Ctx, name string) error {
+	return c.SendString(name)
+}
+
+func (server) GetRaw(c *fiber.Ctx, name string) error {
+	return c.SendString(name)
+}
+
+func (server) GetThing(c *fiber.Ctx, id int, params GetThingParams) error {
+	return c.SendString(strconv.Itoa(id))
+}
+
+func TestPathParams(t *testing.T) {
+	app := fiber.New()
+	RegisterHandlers(app, server{})
+
+	rsp, err := app.Test(httptest.NewRequest(http.MethodGet, "/things/7", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode, string(body))
+	assert.Equal(t, "7", string(body))
+
+	// The errors of all the parameters are answered at once
+	rsp, err = app.Test(httptest.NewRequest(http.MethodGet, "/things/seven?limit=ten", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	var errs ParamErrorsResponse
+	require.NoError(t, json.NewDecoder(rsp.Body).Decode(&errs))
+	require.Len(t, errs.Errors, 2)
+	assert.Equal(t, "id", errs.Errors[0].Name)
+	assert.Equal(t, "seven", errs.Errors[0].Value)
+	assert.Equal(t, "limit", errs.Errors[1].Name)
+}
+
+func TestEscapedPathParams(t *testing.T) {
+	app := fiber.New()
+	RegisterHandlers(app, server{})
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"/files/50%25off", "50%off"},
+		{"/files/a%2541", "a%41"},
+		{"/files/a%2Fb", "a/b"},
+		{"/raw/50%25off", "50%off"},
+		{"/raw/a%2541", "a%41"},
+		{"/raw/a%2Fb", "a/b"},
+		{"/json/%2250%25off%22", "50%off"},
+		{"/json/%22a%2Fb%22", "a/b"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			rsp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.NoError(t, err)
+			body, err := io.ReadAll(rsp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rsp.StatusCode, string(body))
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
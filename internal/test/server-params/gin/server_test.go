@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) GetFile(c *gin.Context, name string) {
+	c.String(http.StatusOK, name)
+}
+
+func (server) GetJSON(c *gin.Context, name string) {
+	c.String(http.StatusOK, name)
+}
+
+func (server) GetRaw(c *gin.Context, name string) {
+	c.String(http.StatusOK, name)
+}
+
+func (server) GetThing(c *gin.Context, id int, params GetThingParams) {
+	c.String(http.StatusOK, strconv.Itoa(id))
+}
+
+func TestEscapedPathParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterHandlers(router, server{})
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"/files/50%25off", "50%off"},
+		{"/files/a%2541", "a%41"},
+		{"/raw/50%25off", "50%off"},
+		{"/json/%2250%25off%22", "50%off"},
+		{"/things/7", "7"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
@@ -6,6 +6,7 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -270,55 +271,12 @@ func (siw *ServerInterfaceWrapper) GetSimple(w http.ResponseWriter, r *http.Requ
 func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetWithArgsParams
-
-	// ------------- Optional query parameter "optional_argument" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "optional_argument", r.URL.Query(), &params.OptionalArgument)
+	params, err := BindGetWithArgsParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "optional_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
-	// ------------- Required query parameter "required_argument" -------------
-
-	if paramValue := r.URL.Query().Get("required_argument"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "required_argument"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "required_argument", r.URL.Query(), &params.RequiredArgument)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "required_argument", Err: err})
-		return
-	}
-
-	headers := r.Header
-
-	// ------------- Optional header parameter "header_argument" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("header_argument")]; found {
-		var HeaderArgument int32
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "header_argument", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithLocation("simple", false, "header_argument", runtime.ParamLocationHeader, valueList[0], &HeaderArgument)
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "header_argument", Err: err})
-			return
-		}
-
-		params.HeaderArgument = &HeaderArgument
-
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetWithArgs(w, r, params)
 	}))
@@ -334,23 +292,9 @@ func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Re
 func (siw *ServerInterfaceWrapper) GetWithReferences(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "global_argument" -------------
-	var globalArgument int64
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "global_argument", runtime.ParamLocationPath, chi.URLParam(r, "global_argument"), &globalArgument)
+	globalArgument, argument, err := BindGetWithReferencesParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "global_argument", Err: err})
-		return
-	}
-
-	// ------------- Path parameter "argument" -------------
-	var argument Argument
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "argument", runtime.ParamLocationPath, chi.URLParam(r, "argument"), &argument)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -369,14 +313,9 @@ func (siw *ServerInterfaceWrapper) GetWithReferences(w http.ResponseWriter, r *h
 func (siw *ServerInterfaceWrapper) GetWithContentType(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "content_type" -------------
-	var contentType GetWithContentTypeParamsContentType
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "content_type", runtime.ParamLocationPath, chi.URLParam(r, "content_type"), &contentType)
+	contentType, err := BindGetWithContentTypeParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "content_type", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -410,14 +349,9 @@ func (siw *ServerInterfaceWrapper) GetReservedKeyword(w http.ResponseWriter, r *
 func (siw *ServerInterfaceWrapper) CreateResource(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "argument" -------------
-	var argument Argument
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "argument", runtime.ParamLocationPath, chi.URLParam(r, "argument"), &argument)
+	argument, err := BindCreateResourceParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -436,25 +370,9 @@ func (siw *ServerInterfaceWrapper) CreateResource(w http.ResponseWriter, r *http
 func (siw *ServerInterfaceWrapper) CreateResource2(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "inline_argument" -------------
-	var inlineArgument int
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "inline_argument", runtime.ParamLocationPath, chi.URLParam(r, "inline_argument"), &inlineArgument)
+	inlineArgument, params, err := BindCreateResource2Params(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inline_argument", Err: err})
-		return
-	}
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateResource2Params
-
-	// ------------- Optional query parameter "inline_query_argument" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "inline_query_argument", r.URL.Query(), &params.InlineQueryArgument)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inline_query_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -473,14 +391,9 @@ func (siw *ServerInterfaceWrapper) CreateResource2(w http.ResponseWriter, r *htt
 func (siw *ServerInterfaceWrapper) UpdateResource3(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "fallthrough" -------------
-	var pFallthrough int
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, chi.URLParam(r, "fallthrough"), &pFallthrough)
+	pFallthrough, err := BindUpdateResource3Params(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fallthrough", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -510,6 +423,142 @@ func (siw *ServerInterfaceWrapper) GetResponseWithReference(w http.ResponseWrite
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// BindGetWithArgsParams binds the parameters of the GetWithArgs operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindGetWithArgsParams(r *http.Request) (params GetWithArgsParams, err error) {
+
+	// ------------- Optional query parameter "optional_argument" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "optional_argument", r.URL.Query(), &params.OptionalArgument)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "optional_argument", Err: err}
+	}
+
+	// ------------- Required query parameter "required_argument" -------------
+
+	if paramValue := r.URL.Query().Get("required_argument"); paramValue != "" {
+
+	} else {
+		return params, &RequiredParamError{ParamName: "required_argument"}
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "required_argument", r.URL.Query(), &params.RequiredArgument)
+	if err != nil {
+		return params, &InvalidParamFormatError{ParamName: "required_argument", Err: err}
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "header_argument" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("header_argument")]; found {
+		var HeaderArgument int32
+		n := len(valueList)
+		if n != 1 {
+			return params, &TooManyValuesForParamError{ParamName: "header_argument", Count: n}
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "header_argument", runtime.ParamLocationHeader, valueList[0], &HeaderArgument)
+		if err != nil {
+			return params, &InvalidParamFormatError{ParamName: "header_argument", Err: err}
+		}
+
+		params.HeaderArgument = &HeaderArgument
+
+	}
+
+	return params, nil
+}
+
+// BindGetWithReferencesParams binds the parameters of the GetWithReferences operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindGetWithReferencesParams(r *http.Request) (globalArgument int64, argument Argument, err error) {
+	// ------------- Path parameter "global_argument" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "global_argument", runtime.ParamLocationPath, chiPathParam(r, "global_argument"), &globalArgument)
+	if err != nil {
+		return globalArgument, argument, &InvalidParamFormatError{ParamName: "global_argument", Err: err}
+	}
+
+	// ------------- Path parameter "argument" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "argument", runtime.ParamLocationPath, chiPathParam(r, "argument"), &argument)
+	if err != nil {
+		return globalArgument, argument, &InvalidParamFormatError{ParamName: "argument", Err: err}
+	}
+
+	return globalArgument, argument, nil
+}
+
+// BindGetWithContentTypeParams binds the parameters of the GetWithContentType operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindGetWithContentTypeParams(r *http.Request) (contentType GetWithContentTypeParamsContentType, err error) {
+	// ------------- Path parameter "content_type" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "content_type", runtime.ParamLocationPath, chiPathParam(r, "content_type"), &contentType)
+	if err != nil {
+		return contentType, &InvalidParamFormatError{ParamName: "content_type", Err: err}
+	}
+
+	return contentType, nil
+}
+
+// BindCreateResourceParams binds the parameters of the CreateResource operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindCreateResourceParams(r *http.Request) (argument Argument, err error) {
+	// ------------- Path parameter "argument" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "argument", runtime.ParamLocationPath, chiPathParam(r, "argument"), &argument)
+	if err != nil {
+		return argument, &InvalidParamFormatError{ParamName: "argument", Err: err}
+	}
+
+	return argument, nil
+}
+
+// BindCreateResource2Params binds the parameters of the CreateResource2 operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindCreateResource2Params(r *http.Request) (inlineArgument int, params CreateResource2Params, err error) {
+	// ------------- Path parameter "inline_argument" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "inline_argument", runtime.ParamLocationPath, chiPathParam(r, "inline_argument"), &inlineArgument)
+	if err != nil {
+		return inlineArgument, params, &InvalidParamFormatError{ParamName: "inline_argument", Err: err}
+	}
+
+	// ------------- Optional query parameter "inline_query_argument" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "inline_query_argument", r.URL.Query(), &params.InlineQueryArgument)
+	if err != nil {
+		return inlineArgument, params, &InvalidParamFormatError{ParamName: "inline_query_argument", Err: err}
+	}
+
+	return inlineArgument, params, nil
+}
+
+// BindUpdateResource3Params binds the parameters of the UpdateResource3 operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindUpdateResource3Params(r *http.Request) (pFallthrough int, err error) {
+	// ------------- Path parameter "fallthrough" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, chiPathParam(r, "fallthrough"), &pFallthrough)
+	if err != nil {
+		return pFallthrough, &InvalidParamFormatError{ParamName: "fallthrough", Err: err}
+	}
+
+	return pFallthrough, nil
+}
+
+// chiPathParam returns the escaped value of a path parameter of a request,
+// which chi routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func chiPathParam(r *http.Request, name string) string {
+	value := chi.URLParam(r, name)
+	if r.URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
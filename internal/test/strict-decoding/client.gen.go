@@ -0,0 +1,1391 @@
+// Package strictdecoding provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v0.0.0-00010101000000-000000000000 DO NOT EDIT.
+package strictdecoding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Animal defines model for Animal.
+type Animal struct {
+	union json.RawMessage
+}
+
+// Cat defines model for Cat.
+type Cat struct {
+	Kind  string `json:"kind"`
+	Meows *bool  `json:"meows,omitempty"`
+}
+
+// Catalog defines model for Catalog.
+type Catalog struct {
+	Animal   *Animal `json:"animal,omitempty"`
+	Featured *Fast   `json:"featured,omitempty"`
+	Labels   *Labels `json:"labels,omitempty"`
+}
+
+// Dog defines model for Dog.
+type Dog struct {
+	Barks *bool  `json:"barks,omitempty"`
+	Kind  string `json:"kind"`
+}
+
+// Fast defines model for Fast.
+type Fast struct {
+	Id  *int    `json:"id,omitempty"`
+	Tag *string `json:"tag,omitempty"`
+}
+
+// Labels defines model for Labels.
+type Labels struct {
+	Kind                 *string          `json:"kind,omitempty"`
+	AdditionalProperties map[string]Owner `json:"-"`
+}
+
+// Owner defines model for Owner.
+type Owner struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Name string `json:"name"`
+}
+
+// Getter for additional properties for Labels. Returns the specified
+// element and whether it was found
+func (a Labels) Get(fieldName string) (value Owner, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Labels
+func (a *Labels) Set(fieldName string, value Owner) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]Owner)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Labels to handle AdditionalProperties
+func (a *Labels) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["kind"]; found {
+		err = json.Unmarshal(raw, &a.Kind)
+		if err != nil {
+			return fmt.Errorf("error reading 'kind': %w", err)
+		}
+		delete(object, "kind")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]Owner)
+		for fieldName, fieldBuf := range object {
+			var fieldVal Owner
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Labels to handle AdditionalProperties
+func (a Labels) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.Kind != nil {
+		object["kind"], err = json.Marshal(a.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'kind': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// AsCat returns the union data inside the Animal as a Cat
+func (t Animal) AsCat() (Cat, error) {
+	var body Cat
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromCat overwrites any union data inside the Animal as the provided Cat
+func (t *Animal) FromCat(v Cat) error {
+	v.Kind = "cat"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeCat performs a merge with any union data inside the Animal, using the provided Cat
+func (t *Animal) MergeCat(v Cat) error {
+	v.Kind = "cat"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsDog returns the union data inside the Animal as a Dog
+func (t Animal) AsDog() (Dog, error) {
+	var body Dog
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromDog overwrites any union data inside the Animal as the provided Dog
+func (t *Animal) FromDog(v Dog) error {
+	v.Kind = "dog"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeDog performs a merge with any union data inside the Animal, using the provided Dog
+func (t *Animal) MergeDog(v Dog) error {
+	v.Kind = "dog"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Animal) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"kind"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t Animal) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "cat":
+		return t.AsCat()
+	case "dog":
+		return t.AsDog()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t Animal) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Animal) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// MarshalJSON encodes the Fast as JSON without reflection.
+func (a Fast) MarshalJSON() ([]byte, error) {
+	return a.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Fast to buf.
+func (a Fast) appendJSON(buf []byte) ([]byte, error) {
+	start := len(buf)
+	if a.Id != nil {
+		buf = append(buf, `,"id":`...)
+		buf = strconv.AppendInt(buf, int64(*a.Id), 10)
+	}
+	if a.Tag != nil {
+		buf = append(buf, `,"tag":`...)
+		buf = appendJSONString(buf, *a.Tag)
+	}
+	if len(buf) == start {
+		return append(buf, '{', '}'), nil
+	}
+	buf[start] = '{'
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON decodes the Fast from JSON without reflection.
+func (a *Fast) UnmarshalJSON(data []byte) error {
+	l := jsonLexer{data: data}
+	a.decodeJSON(&l)
+	l.End()
+	return l.err
+}
+
+// decodeJSON decodes the Fast from the next value of l.
+func (a *Fast) decodeJSON(l *jsonLexer) {
+	if l.Null() {
+		return
+	}
+	l.Begin('{')
+	for i := 0; l.More('}', i); i++ {
+		switch l.Field(l.Key(), "id", "tag") {
+		case "id":
+			if l.Null() {
+				a.Id = nil
+			} else {
+				if a.Id == nil {
+					a.Id = new(int)
+				}
+				*a.Id = int(l.Int(strconv.IntSize))
+			}
+		case "tag":
+			if l.Null() {
+				a.Tag = nil
+			} else {
+				if a.Tag == nil {
+					a.Tag = new(string)
+				}
+				*a.Tag = l.String()
+			}
+		default:
+			l.Skip()
+		}
+	}
+}
+
+// appendJSONString appends s to buf as a JSON string, escaped like
+// encoding/json escapes it since Go 1.22, which writes \b and \f rather than
+// \u0008 and \u000c.
+func appendJSONString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+		} else if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[r&0xf])
+		} else {
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+
+// appendJSONFloat appends f, a floating point number of the given bits, to
+// buf as a JSON number, formatted like encoding/json formats it.
+func appendJSONFloat(buf []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf, nil
+}
+
+// jsonLexer reads JSON without reflection, for the UnmarshalJSON methods of
+// the hot-path types. It keeps the first error it meets, after which it reads
+// nothing more.
+type jsonLexer struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// fail records err, unless an error was recorded before, and stops reading.
+func (l *jsonLexer) fail(err error) {
+	if l.err == nil {
+		l.err = err
+	}
+	l.pos = len(l.data)
+}
+
+// syntaxError fails on the next character, which isn't the one expected.
+func (l *jsonLexer) syntaxError(expected string) {
+	if l.pos >= len(l.data) {
+		l.fail(errors.New("unexpected end of JSON input"))
+		return
+	}
+	l.fail(fmt.Errorf("invalid character %q at offset %d, expected %s", l.data[l.pos], l.pos, expected))
+}
+
+// skipSpace skips the spaces before the next token.
+func (l *jsonLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case ' ', '\t', '\n', '\r':
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+// consume reads the character c, and returns whether it came next.
+func (l *jsonLexer) consume(c byte) bool {
+	l.skipSpace()
+	if l.pos < len(l.data) && l.data[l.pos] == c {
+		l.pos++
+		return true
+	}
+	return false
+}
+
+// literal reads a literal, like null, and returns whether it came next.
+func (l *jsonLexer) literal(literal string) bool {
+	l.skipSpace()
+	if len(l.data)-l.pos >= len(literal) && string(l.data[l.pos:l.pos+len(literal)]) == literal {
+		l.pos += len(literal)
+		return true
+	}
+	return false
+}
+
+// Null reads a null, and returns whether the next value was one.
+func (l *jsonLexer) Null() bool {
+	return l.literal("null")
+}
+
+// Begin reads the bracket or the brace beginning an array or an object.
+func (l *jsonLexer) Begin(c byte) {
+	if !l.consume(c) {
+		l.syntaxError(strconv.QuoteRune(rune(c)))
+	}
+}
+
+// More returns whether the array or the object being read has a value after
+// the i it had, reading the comma before it, or else the end of the array or
+// the object.
+func (l *jsonLexer) More(end byte, i int) bool {
+	if l.consume(end) {
+		return false
+	}
+	if i > 0 && !l.consume(',') {
+		l.syntaxError("',' or " + strconv.QuoteRune(rune(end)))
+		return false
+	}
+	return l.err == nil
+}
+
+// Key reads the name of the next field of an object, and the colon after it.
+func (l *jsonLexer) Key() []byte {
+	key := l.stringBytes()
+	if !l.consume(':') {
+		l.syntaxError("':'")
+	}
+	return key
+}
+
+// Field returns the name of the field of an object with a key, among names:
+// the one equal to it, or else the first one equal to it under Unicode
+// case-folding, like encoding/json matches them, or "" when none is.
+func (l *jsonLexer) Field(key []byte, names ...string) string {
+	for _, name := range names {
+		if string(key) == name {
+			return name
+		}
+	}
+	for _, name := range names {
+		if bytes.EqualFold(key, []byte(name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// String reads a string.
+func (l *jsonLexer) String() string {
+	return string(l.stringBytes())
+}
+
+// stringBytes reads a string, returning its bytes, which are those of the
+// data unless the string has escapes or invalid UTF-8, which is replaced with
+// U+FFFD like encoding/json does.
+func (l *jsonLexer) stringBytes() []byte {
+	if !l.consume('"') {
+		l.syntaxError("a string")
+		return nil
+	}
+	start := l.pos
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case c == '"':
+			l.pos++
+			return l.data[start : l.pos-1]
+		case c == '\\':
+			return l.unescape(append([]byte(nil), l.data[start:l.pos]...))
+		case c < 0x20:
+			l.syntaxError("a string character")
+			return nil
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(l.data[l.pos:])
+			if r == utf8.RuneError && size == 1 {
+				return l.unescape(append([]byte(nil), l.data[start:l.pos]...))
+			}
+			l.pos += size
+		default:
+			l.pos++
+		}
+	}
+	l.syntaxError(`'"'`)
+	return nil
+}
+
+// unescape reads the rest of a string from its first escape or invalid
+// UTF-8, appending it to s.
+func (l *jsonLexer) unescape(s []byte) []byte {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return s
+		case c < 0x20:
+			l.syntaxError("a string character")
+			return nil
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(l.data[l.pos:])
+			if r == utf8.RuneError && size == 1 {
+				s = append(s, "\uFFFD"...)
+			} else {
+				s = append(s, l.data[l.pos:l.pos+size]...)
+			}
+			l.pos += size
+			continue
+		case c != '\\':
+			s = append(s, c)
+			l.pos++
+			continue
+		}
+		if l.pos++; l.pos >= len(l.data) {
+			break
+		}
+		switch c = l.data[l.pos]; c {
+		case '"', '\\', '/':
+			s = append(s, c)
+		case 'b':
+			s = append(s, '\b')
+		case 'f':
+			s = append(s, '\f')
+		case 'n':
+			s = append(s, '\n')
+		case 'r':
+			s = append(s, '\r')
+		case 't':
+			s = append(s, '\t')
+		case 'u':
+			l.pos++
+			var encoded [utf8.UTFMax]byte
+			s = append(s, encoded[:utf8.EncodeRune(encoded[:], l.escapedRune())]...)
+			continue
+		default:
+			l.syntaxError("an escape character")
+			return nil
+		}
+		l.pos++
+	}
+	l.syntaxError(`'"'`)
+	return nil
+}
+
+// escapedRune reads the four hexadecimal digits of a \u escape, and those of
+// the escape of the low surrogate following a high one.
+func (l *jsonLexer) escapedRune() rune {
+	r := l.hex4()
+	if !utf16.IsSurrogate(r) {
+		return r
+	}
+	if pos := l.pos; pos+6 <= len(l.data) && l.data[pos] == '\\' && l.data[pos+1] == 'u' {
+		l.pos += 2
+		if pair := utf16.DecodeRune(r, l.hex4()); pair != utf8.RuneError {
+			return pair
+		}
+		l.pos = pos
+	}
+	return utf8.RuneError
+}
+
+// hex4 reads four hexadecimal digits.
+func (l *jsonLexer) hex4() rune {
+	if l.pos+4 > len(l.data) {
+		l.syntaxError("four hexadecimal digits")
+		return utf8.RuneError
+	}
+	var r rune
+	for _, c := range l.data[l.pos : l.pos+4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			l.syntaxError("a hexadecimal digit")
+			return utf8.RuneError
+		}
+		r = r<<4 | rune(c)
+	}
+	l.pos += 4
+	return r
+}
+
+// number reads a number, returning its text.
+func (l *jsonLexer) number() []byte {
+	l.skipSpace()
+	start := l.pos
+	digits := func() bool {
+		from := l.pos
+		for l.pos < len(l.data) && '0' <= l.data[l.pos] && l.data[l.pos] <= '9' {
+			l.pos++
+		}
+		return l.pos > from
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '-' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '0' {
+		l.pos++
+	} else if !digits() {
+		l.syntaxError("a number")
+		return nil
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '.' {
+		l.pos++
+		if !digits() {
+			l.syntaxError("a digit")
+			return nil
+		}
+	}
+	if l.pos < len(l.data) && (l.data[l.pos] == 'e' || l.data[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.data) && (l.data[l.pos] == '+' || l.data[l.pos] == '-') {
+			l.pos++
+		}
+		if !digits() {
+			l.syntaxError("a digit")
+			return nil
+		}
+	}
+	return l.data[start:l.pos]
+}
+
+// Int reads an integer of the given bits.
+func (l *jsonLexer) Int(bits int) int64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	i, err := strconv.ParseInt(string(number), 10, bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit integer", number, bits))
+	}
+	return i
+}
+
+// Uint reads an unsigned integer of the given bits.
+func (l *jsonLexer) Uint(bits int) uint64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	u, err := strconv.ParseUint(string(number), 10, bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit unsigned integer", number, bits))
+	}
+	return u
+}
+
+// Float reads a floating point number of the given bits.
+func (l *jsonLexer) Float(bits int) float64 {
+	number := l.number()
+	if l.err != nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(string(number), bits)
+	if err != nil {
+		l.fail(fmt.Errorf("cannot decode the number %s as a %d-bit floating point number", number, bits))
+	}
+	return f
+}
+
+// Bool reads a boolean.
+func (l *jsonLexer) Bool() bool {
+	if l.literal("true") {
+		return true
+	}
+	if !l.literal("false") {
+		l.syntaxError("a boolean")
+	}
+	return false
+}
+
+// Skip reads the next value, whatever it is.
+func (l *jsonLexer) Skip() {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		l.syntaxError("a value")
+		return
+	}
+	switch l.data[l.pos] {
+	case '{':
+		l.pos++
+		for i := 0; l.More('}', i); i++ {
+			l.Key()
+			l.Skip()
+		}
+	case '[':
+		l.pos++
+		for i := 0; l.More(']', i); i++ {
+			l.Skip()
+		}
+	case '"':
+		l.stringBytes()
+	case 't', 'f':
+		l.Bool()
+	case 'n':
+		if !l.Null() {
+			l.syntaxError("null")
+		}
+	default:
+		l.number()
+	}
+}
+
+// Raw reads the next value, returning its JSON.
+func (l *jsonLexer) Raw() []byte {
+	l.skipSpace()
+	start := l.pos
+	l.Skip()
+	return l.data[start:l.pos]
+}
+
+// Unmarshal decodes the next value into v with encoding/json, for the types
+// which have no hot-path methods.
+func (l *jsonLexer) Unmarshal(v interface{}) {
+	if raw := l.Raw(); l.err == nil {
+		if err := json.Unmarshal(raw, v); err != nil {
+			l.fail(err)
+		}
+	}
+}
+
+// Unmarshaler decodes the next value with the UnmarshalJSON method of u.
+func (l *jsonLexer) Unmarshaler(u json.Unmarshaler) {
+	if raw := l.Raw(); l.err == nil {
+		if err := u.UnmarshalJSON(raw); err != nil {
+			l.fail(err)
+		}
+	}
+}
+
+// End checks that nothing but spaces follows the values read.
+func (l *jsonLexer) End() {
+	l.skipSpace()
+	if l.pos < len(l.data) {
+		l.syntaxError("the end of the JSON")
+	}
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// requestDoerContextKey is the context key under which WithRequestDoer stores
+// the Doer for a single request.
+type requestDoerContextKey struct{}
+
+// WithRequestDoer returns a request editor which makes the client send the
+// request with the given Doer, rather than its own. Pass it to an operation to
+// use, for example, a transport with a longer timeout for that call only.
+func WithRequestDoer(doer HttpRequestDoer) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestDoerContextKey{}, doer))
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetCatalog request
+	GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOwner request
+	GetOwner(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets request
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCatalogRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) GetOwner(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOwnerRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.requestDoer(req).Do(req)
+}
+
+// NewGetCatalogRequest generates requests for GetCatalog
+func NewGetCatalogRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/catalog")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOwnerRequest generates requests for GetOwner
+func NewGetOwnerRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/owner")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	queryURL, err := joinServerURL(server, "/pets")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// joinServerURL appends the operation path, in which path parameters are
+// already escaped, to the path of the server URL. Unlike resolving a relative
+// reference against the server URL, this keeps the whole server path whether
+// or not it ends with a slash.
+func joinServerURL(server string, operationPath string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	rawPath := strings.TrimSuffix(serverURL.EscapedPath(), "/") + operationPath
+	unescapedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.Path = unescapedPath
+	serverURL.RawPath = rawPath
+	return serverURL, nil
+}
+
+// requestDoer returns the Doer to send the request with, which is the one set
+// with WithRequestDoer, if any, or else the client-wide one.
+func (c *Client) requestDoer(req *http.Request) HttpRequestDoer {
+	if doer, ok := req.Context().Value(requestDoerContextKey{}).(HttpRequestDoer); ok && doer != nil {
+		return doer
+	}
+	return c.Client
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetCatalogWithResponse request
+	GetCatalogWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCatalogResponse, error)
+
+	// GetOwnerWithResponse request
+	GetOwnerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error)
+
+	// ListPetsWithResponse request
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+}
+
+type GetCatalogResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Catalog
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCatalogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCatalogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetCatalogResponse) Success() (Catalog, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Catalog
+	return zero, false
+}
+
+type GetOwnerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Owner
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOwnerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOwnerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r GetOwnerResponse) Success() (Owner, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero Owner
+	return zero, false
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Success returns the decoded body of the successful response, and whether
+// the response was one
+func (r ListPetsResponse) Success() ([]Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	var zero []Pet
+	return zero, false
+}
+
+// GetCatalogWithResponse request returning *GetCatalogResponse
+func (c *ClientWithResponses) GetCatalogWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCatalogResponse, error) {
+	rsp, err := c.GetCatalog(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCatalogResponse(rsp)
+}
+
+// GetOwnerWithResponse request returning *GetOwnerResponse
+func (c *ClientWithResponses) GetOwnerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error) {
+	rsp, err := c.GetOwner(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOwnerResponse(rsp)
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// ParseGetCatalogResponse parses an HTTP response from a GetCatalogWithResponse call
+func ParseGetCatalogResponse(rsp *http.Response) (*GetCatalogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCatalogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Catalog
+		if err := unmarshalJSONStrict(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOwnerResponse parses an HTTP response from a GetOwnerWithResponse call
+func ParseGetOwnerResponse(rsp *http.Response) (*GetOwnerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOwnerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Owner
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Pet
+		if err := unmarshalJSONStrict(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// StrictDecoding turns on the strict decoding of the JSON responses of the
+// client declared strict, rejecting unknown fields and trailing data, to catch
+// drift from the spec. It's off by default, for lenient decoding in
+// production, and can be turned on in integration environments, for example
+// in a file of the package built with their tag only:
+//
+//	//go:build integration
+//
+//	func init() { StrictDecoding = true }
+var StrictDecoding = false
+
+// unmarshalJSONStrict unmarshals JSON like json.Unmarshal, but rejects unknown
+// fields while StrictDecoding is on, those of the types with their own
+// UnmarshalJSON methods included.
+func unmarshalJSONStrict(data []byte, v interface{}) error {
+	if !StrictDecoding {
+		return json.Unmarshal(data, v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	var value interface{}
+	decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	return checkUnknownFields(value, reflect.ValueOf(v), "")
+}
+
+// checkUnknownFields returns an error naming the first property of a decoded
+// JSON value which the Go value it was decoded into has no field for. The
+// decoder can't tell for the types decoding themselves: the hot-path types,
+// the types with additional properties, whose other properties are checked,
+// and the unions with a discriminator mapping, checked against the type their
+// discriminator selects. The unions without one accept any property.
+func checkUnknownFields(value interface{}, v reflect.Value, path string) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		if union, ok := v.Interface().(interface{ ValueByDiscriminator() (interface{}, error) }); ok {
+			variant, err := union.ValueByDiscriminator()
+			if err != nil {
+				return nil
+			}
+			return checkUnknownFields(value, reflect.ValueOf(variant), path)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := make(map[string]reflect.Value)
+		var additional reflect.Value
+		if !jsonFields(v, fields, &additional) {
+			return nil
+		}
+		for key, item := range object {
+			field, found := fields[key]
+			if !found {
+				for name, f := range fields {
+					if strings.EqualFold(name, key) {
+						field, found = f, true
+						break
+					}
+				}
+			}
+			if !found && additional.IsValid() {
+				field, found = additional.MapIndex(reflect.ValueOf(key)), true
+			}
+			if !found {
+				if path == "" {
+					return fmt.Errorf("json: unknown field %q", key)
+				}
+				return fmt.Errorf("json: unknown field %q in %s", key, path)
+			}
+			if err := checkUnknownFields(item, field, jsonPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		for key, item := range object {
+			if err := checkUnknownFields(item, v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())), jsonPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			if err := checkUnknownFields(items[i], v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields collects the fields of a struct by their JSON names, those of
+// embedded structs included, and its AdditionalProperties map, if any. It
+// returns false for the unions without a discriminator mapping, whose
+// properties can be those of any of their types.
+func jsonFields(v reflect.Value, fields map[string]reflect.Value, additional *reflect.Value) bool {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name == "union" {
+			return false
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch {
+		case name == "-" && field.Name == "AdditionalProperties" && field.Type.Kind() == reflect.Map:
+			*additional = v.Field(i)
+		case name == "-":
+		case field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct:
+			if !jsonFields(v.Field(i), fields, additional) {
+				return false
+			}
+		case field.PkgPath == "":
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = v.Field(i)
+		}
+	}
+	return true
+}
+
+// jsonPath appends the name of a property to the path of the object holding
+// it, like pets[0].owner.
+func jsonPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,67 @@
+package strictdecoding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getCatalog returns the catalog which the client decodes from the given
+// body.
+func getCatalog(t *testing.T, body string) (*GetCatalogResponse, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	return client.GetCatalogWithResponse(context.Background())
+}
+
+func TestStrictDecodingOfTypesDecodingThemselves(t *testing.T) {
+	StrictDecoding = true
+	t.Cleanup(func() { StrictDecoding = false })
+
+	rsp, err := getCatalog(t, `{"featured":{"id":1,"tag":"new"},"labels":{"kind":"team","lead":{"name":"Ada"}},"animal":{"kind":"cat","meows":true}}`)
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Equal(t, "new", *rsp.JSON200.Featured.Tag)
+	assert.Equal(t, "Ada", *rsp.JSON200.Labels.AdditionalProperties["lead"].Name)
+	cat, err := rsp.JSON200.Animal.AsCat()
+	require.NoError(t, err)
+	assert.True(t, *cat.Meows)
+
+	tests := map[string]struct {
+		body string
+		err  string
+	}{
+		"top level": {`{"extra":1}`, `json: unknown field "extra"`},
+		"hot path":  {`{"featured":{"id":1,"extra":true}}`, `json: unknown field "extra" in featured`},
+		"additional properties": {
+			`{"labels":{"kind":"team","lead":{"name":"Ada","extra":1}}}`,
+			`json: unknown field "extra" in labels.lead`,
+		},
+		"union": {`{"animal":{"kind":"cat","barks":true}}`, `json: unknown field "barks" in animal`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := getCatalog(t, tt.body)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	// The properties match the fields case-insensitively, like encoding/json
+	_, err = getCatalog(t, `{"Featured":{"ID":1}}`)
+	assert.NoError(t, err)
+}
+
+func TestLenientDecoding(t *testing.T) {
+	rsp, err := getCatalog(t, `{"extra":1,"featured":{"id":1,"extra":true},"animal":{"kind":"cat","barks":true}}`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *rsp.JSON200.Featured.Id)
+}
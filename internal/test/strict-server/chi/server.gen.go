@@ -177,14 +177,9 @@ func (siw *ServerInterfaceWrapper) MultipleRequestAndResponseTypes(w http.Respon
 func (siw *ServerInterfaceWrapper) ReservedGoKeywordParameters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "type" -------------
-	var pType string
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, chi.URLParam(r, "type"), &pType)
+	pType, err := BindReservedGoKeywordParametersParams(r)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		siw.ErrorHandlerFunc(w, r, err)
 		return
 	}
 
@@ -278,10 +273,54 @@ func (siw *ServerInterfaceWrapper) URLEncodedExample(w http.ResponseWriter, r *h
 func (siw *ServerInterfaceWrapper) HeadersExample(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
+	params, err := BindHeadersExampleParams(r)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, err)
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.HeadersExample(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnionExample operation middleware
+func (siw *ServerInterfaceWrapper) UnionExample(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnionExample(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BindReservedGoKeywordParametersParams binds the parameters of the ReservedGoKeywordParameters operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindReservedGoKeywordParametersParams(r *http.Request) (pType string, err error) {
+	// ------------- Path parameter "type" -------------
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, chiPathParam(r, "type"), &pType)
+	if err != nil {
+		return pType, &InvalidParamFormatError{ParamName: "type", Err: err}
+	}
+
+	return pType, nil
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params HeadersExampleParams
+// BindHeadersExampleParams binds the parameters of the HeadersExample operation from a
+// request routed by chi. The error is one of the parameter errors below.
+func BindHeadersExampleParams(r *http.Request) (params HeadersExampleParams, err error) {
 
 	headers := r.Header
 
@@ -290,22 +329,19 @@ func (siw *ServerInterfaceWrapper) HeadersExample(w http.ResponseWriter, r *http
 		var Header1 string
 		n := len(valueList)
 		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "header1", Count: n})
-			return
+			return params, &TooManyValuesForParamError{ParamName: "header1", Count: n}
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "header1", runtime.ParamLocationHeader, valueList[0], &Header1)
 		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "header1", Err: err})
-			return
+			return params, &InvalidParamFormatError{ParamName: "header1", Err: err}
 		}
 
 		params.Header1 = Header1
 
 	} else {
 		err := fmt.Errorf("Header parameter header1 is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "header1", Err: err})
-		return
+		return params, &RequiredHeaderError{ParamName: "header1", Err: err}
 	}
 
 	// ------------- Optional header parameter "header2" -------------
@@ -313,44 +349,30 @@ func (siw *ServerInterfaceWrapper) HeadersExample(w http.ResponseWriter, r *http
 		var Header2 int
 		n := len(valueList)
 		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "header2", Count: n})
-			return
+			return params, &TooManyValuesForParamError{ParamName: "header2", Count: n}
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "header2", runtime.ParamLocationHeader, valueList[0], &Header2)
 		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "header2", Err: err})
-			return
+			return params, &InvalidParamFormatError{ParamName: "header2", Err: err}
 		}
 
 		params.Header2 = &Header2
 
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.HeadersExample(w, r, params)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return params, nil
 }
 
-// UnionExample operation middleware
-func (siw *ServerInterfaceWrapper) UnionExample(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnionExample(w, r)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+// chiPathParam returns the escaped value of a path parameter of a request,
+// which chi routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func chiPathParam(r *http.Request, name string) string {
+	value := chi.URLParam(r, name)
+	if r.URL.RawPath == "" {
+		return url.PathEscape(value)
 	}
-
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return value
 }
 
 type UnescapedCookieParamError struct {
@@ -1035,6 +1057,125 @@ type StrictServerInterface interface {
 	UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (POST /json)
+func (_ UnimplementedStrictServer) JSONExample(ctx context.Context, request JSONExampleRequestObject) (JSONExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multipart)
+func (_ UnimplementedStrictServer) MultipartExample(ctx context.Context, request MultipartExampleRequestObject) (MultipartExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multiple)
+func (_ UnimplementedStrictServer) MultipleRequestAndResponseTypes(ctx context.Context, request MultipleRequestAndResponseTypesRequestObject) (MultipleRequestAndResponseTypesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ UnimplementedStrictServer) ReservedGoKeywordParameters(ctx context.Context, request ReservedGoKeywordParametersRequestObject) (ReservedGoKeywordParametersResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /reusable-responses)
+func (_ UnimplementedStrictServer) ReusableResponses(ctx context.Context, request ReusableResponsesRequestObject) (ReusableResponsesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /text)
+func (_ UnimplementedStrictServer) TextExample(ctx context.Context, request TextExampleRequestObject) (TextExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unknown)
+func (_ UnimplementedStrictServer) UnknownExample(ctx context.Context, request UnknownExampleRequestObject) (UnknownExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unspecified-content-type)
+func (_ UnimplementedStrictServer) UnspecifiedContentType(ctx context.Context, request UnspecifiedContentTypeRequestObject) (UnspecifiedContentTypeResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /urlencoded)
+func (_ UnimplementedStrictServer) URLEncodedExample(ctx context.Context, request URLEncodedExampleRequestObject) (URLEncodedExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-headers)
+func (_ UnimplementedStrictServer) HeadersExample(ctx context.Context, request HeadersExampleRequestObject) (HeadersExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-union)
+func (_ UnimplementedStrictServer) UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitJSONExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipartExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipleRequestAndResponseTypesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReservedGoKeywordParametersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReusableResponsesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitTextExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnknownExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnspecifiedContentTypeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitURLEncodedExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitHeadersExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnionExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
 type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
 
@@ -1437,23 +1578,23 @@ func (sh *strictHandler) UnionExample(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYS2/jNhD+KwTb01aynGxOunWDxbbdtimc5FTkQIsjm7sSyQ5HVgzD/72gKL9ixbW3",
-	"fhRBb3oMvxl+8+BwZjwzpTUaNDmezjiCs0Y7aF6GQiL8VYEj/ybBZagsKaN5yj8IOWj/zSOOUDkxLGCx",
-	"3MtnRhPoZqmwtlCZ8EuTL86vn3GXjaEU/ul7hJyn/LtkZUoS/roEnkVpC+Dz+Tx6YcHdZx7xMQgJ2Fgb",
-	"Hq82sWlqgafcESo94h4kiF13iilNMAL02rxoa4QXWNiRzrhFYwFJBY4moqigW1P7xQy/QEZhB0rnZpvL",
-	"W6NJKO2YVHkOCJpYSx7zGI65ylqDBJINp8xryIg5wAkgjzgp8obx+/XvrDXY8YhPAF1QdNXr9/reX8aC",
-	"FlbxlL9vPkXcCho3G1o6yJouv/9yf/c7U46JikwpSGWiKKasFOjGogDJlCbjTawycj3eaMLG8T/LdvXH",
-	"lkofNU0AfTByeoqAaeJyLZyv+/0zxeU84jdBWRfG0qhkLcEamFxURQfnj/qrNrVmgGiw3VlSVgUpK5DW",
-	"fbXJ9m8LkX0oX+IlucEyloLEiVg/lqaLEt/Wgs4cuR+b2rGxqRkZJkEUrFY0ZouFL5JbaSaYU3pUAFsY",
-	"FXV6soC25P6o5aDdy4PHOHkuRRsoz3Fd13HjvAoL0JmRIL8NVpViBInVo83lHlsQT/lwSj5st4vrkYIo",
-	"4gTPlNhCKL375DhTOfmf6aMldkhXhOZElPHIxF9hWhuUsRUoSiBAl8y89rkHHkFHKv+xlGSZ0GwITIsS",
-	"JBM5AbJPhrWQbitlB63eT+ZzEFlBNcft8iX9c8Y9Jc0RzCPuFfA0sBLyWqF3OmEF0Q7anv4xPv+VAxZs",
-	"hkYv3lDVXQYXJWpJHULufEns8lwHf0HTYE3iMg3D7ojban3PcQZ5T75+7j/A815H/hFL37lz+1DCqvDx",
-	"dc7aVfvQ9o2VdA8WJ0qCSUp7cyDyxUh1FjKVK5Bxu4s42PZaSbg1OkOgzRbIXye0IbYE87ccGgMLDETM",
-	"GVYDKytHzArnmKKmihQq3JQkbBWPx5Vlt0HTw6qc7vLquxP59N2lPHrTvzp8yfsTx81GK/NKPg5+/Rhk",
-	"Dr0vHq1nOrDjO57eC6Wzv6TEawOV7hT+KQiszvQM1MR3RFoyBKpQg2QTJRZDgK3cbAFWbu3qhYIZq25o",
-	"Mdw5pCGKdmJd82jXAOjpDY8nTjk2O1ecVlrtGlM9+t+s7aFfng3K6P/oEEoUBKgFqQn8cJwb5DaK0XCX",
-	"N5n2wsvRnhqe3l5UzSMe5qahBFVY+DpBZNMkCfPWnqvFaATYUyYRVnkW/g4AAP//Pk3lbjwXAAA=",
+	"H4sIAAAAAAAC/+xYS3PbNhD+K5htTykpKo5PvDWeTNqmrTuyfer4ABFLCQkJoMBStEaj/94BQb0sSpVS",
+	"PTqZ3vjYF759YmeQ6dJohYocpDOw6IxWDpuXIRcW/6rQkX8T6DIrDUmtIIX3XAzaf/MILFaODwtcsHv6",
+	"TCtC1bByYwqZcc+afHaefwYuG2PJ/dP3FnNI4btkZUoS/roEX3hpCoT5fB69suD+E0QwRi7QNtaGx7eb",
+	"smlqEFJwZKUagRcSyG46yaQiHKH12jxpa4QnWNiRzsBYbdCSDBhNeFFht6b2ix5+xozCCaTK9TaWd1oR",
+	"l8oxIfMcLSpiLXjMy3DMVcZoSyjYcMq8hoyYQztBCxGQJG8YPKx/Z63BDiKYoHVB0dtev9f3/tIGFTcS",
+	"UnjXfIrAcBo3B1o6yOguv//ycP87k47xinTJSWa8KKas5NaNeYGCSUXam1hl5HrQaLKN438WLfeHFkof",
+	"NU0Avddieo6AaeJyLZxv+v0LxeU8gtt+f5eMpVHJWoI1YnJeFR2YP6kvSteKobXatidLyqogabildV9t",
+	"ov3bguQQyJfyklzbMhac+JlQP5WmqwJf4Drum2wPY107NtY1I80E8oLVksZswfgquaVinDmpRgWyhVFR",
+	"pycLbEvuj0oM2rM8ehlnz6VoQ8pLXNd13DivsgWqTAsUXydWlnyEiVGjTXYvmxOkMJwSQtRRXE8URBEQ",
+	"vlBiCi7V/s5xoXLyP9InS+yQrhabjijikY6/4LTWVsSGW14ioXXJzGufe8Ej7EjlP5aULOOKDZEpXqJg",
+	"PCe07KNmrUi3lbKDVu9H/SmQrEQ17Xb5kv45Aw9J04IhAq8A0oBKyGtpvdPJVhjtge35H+PzXzlggWYY",
+	"9OINVd1lcFGiltBZzJ0viV2e68AvaBqsUVxnYNgfcVuj7yV6kPfk7r7/iC8HtfwTlr5L5/axgFXh427M",
+	"Wq5DYPvKSnoAihMpUCeluT1S8tVAdQYzmUsUcXuKONi2qyTcaZVZpM0RyF8nlCa2FOZvOTRGFhCImNOs",
+	"RlZWjpjhzjFJTRUpZLgpCdwqHk8ry+6CpsepOcSrb87k0zfX8uht/+3xLO/OHDcbo8yOfBz8+iHQHHtf",
+	"PNnMdOTEdzq9V0pnf0mJ1xYq3Sn8UyBY9fQM5cRPREowi1RZhYJNJF8sAbZysxWwcmvXLBTMWE1Di+XO",
+	"MQNRtFfWDUT7FkDP3/B64pxrs0vFaaXkvjXVk//N2hn6dW+QWv1Hl1C8ILSKk5zgD6e5QW5L0Qrv8ybT",
+	"Xnk5OlDD87cXVfMIwt40lKDKFpDCmMikSRL2rT1X89EIbU/qhBvpUfh7AD5N5W48FwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -60,6 +60,64 @@ type ServerInterface interface {
 	UnionExample(ctx echo.Context) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (POST /json)
+func (_ Unimplemented) JSONExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /multipart)
+func (_ Unimplemented) MultipartExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /multiple)
+func (_ Unimplemented) MultipleRequestAndResponseTypes(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ Unimplemented) ReservedGoKeywordParameters(ctx echo.Context, pType string) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /reusable-responses)
+func (_ Unimplemented) ReusableResponses(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /text)
+func (_ Unimplemented) TextExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /unknown)
+func (_ Unimplemented) UnknownExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /unspecified-content-type)
+func (_ Unimplemented) UnspecifiedContentType(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /urlencoded)
+func (_ Unimplemented) URLEncodedExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /with-headers)
+func (_ Unimplemented) HeadersExample(ctx echo.Context, params HeadersExampleParams) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
+// (POST /with-union)
+func (_ Unimplemented) UnionExample(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -98,7 +156,7 @@ func (w *ServerInterfaceWrapper) ReservedGoKeywordParameters(ctx echo.Context) e
 	// ------------- Path parameter "type" -------------
 	var pType string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, ctx.Param("type"), &pType)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, echoPathParam(ctx, "type"), &pType)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
 	}
@@ -208,6 +266,17 @@ func (w *ServerInterfaceWrapper) UnionExample(ctx echo.Context) error {
 	return err
 }
 
+// echoPathParam returns the escaped value of a path parameter of a request,
+// which echo routes by its escaped path only when it differs from the default
+// escaping of the path, handing back unescaped values otherwise.
+func echoPathParam(ctx echo.Context, name string) string {
+	value := ctx.Param(name)
+	if ctx.Request().URL.RawPath == "" {
+		return url.PathEscape(value)
+	}
+	return value
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -782,6 +851,125 @@ type StrictServerInterface interface {
 	UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (POST /json)
+func (_ UnimplementedStrictServer) JSONExample(ctx context.Context, request JSONExampleRequestObject) (JSONExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multipart)
+func (_ UnimplementedStrictServer) MultipartExample(ctx context.Context, request MultipartExampleRequestObject) (MultipartExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multiple)
+func (_ UnimplementedStrictServer) MultipleRequestAndResponseTypes(ctx context.Context, request MultipleRequestAndResponseTypesRequestObject) (MultipleRequestAndResponseTypesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ UnimplementedStrictServer) ReservedGoKeywordParameters(ctx context.Context, request ReservedGoKeywordParametersRequestObject) (ReservedGoKeywordParametersResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /reusable-responses)
+func (_ UnimplementedStrictServer) ReusableResponses(ctx context.Context, request ReusableResponsesRequestObject) (ReusableResponsesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /text)
+func (_ UnimplementedStrictServer) TextExample(ctx context.Context, request TextExampleRequestObject) (TextExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unknown)
+func (_ UnimplementedStrictServer) UnknownExample(ctx context.Context, request UnknownExampleRequestObject) (UnknownExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unspecified-content-type)
+func (_ UnimplementedStrictServer) UnspecifiedContentType(ctx context.Context, request UnspecifiedContentTypeRequestObject) (UnspecifiedContentTypeResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /urlencoded)
+func (_ UnimplementedStrictServer) URLEncodedExample(ctx context.Context, request URLEncodedExampleRequestObject) (URLEncodedExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-headers)
+func (_ UnimplementedStrictServer) HeadersExample(ctx context.Context, request HeadersExampleRequestObject) (HeadersExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-union)
+func (_ UnimplementedStrictServer) UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitJSONExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipartExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipleRequestAndResponseTypesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReservedGoKeywordParametersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReusableResponsesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitTextExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnknownExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnspecifiedContentTypeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitURLEncodedExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitHeadersExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnionExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
 type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
 
@@ -59,6 +59,64 @@ type ServerInterface interface {
 	UnionExample(c *fiber.Ctx) error
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (POST /json)
+func (_ Unimplemented) JSONExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /multipart)
+func (_ Unimplemented) MultipartExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /multiple)
+func (_ Unimplemented) MultipleRequestAndResponseTypes(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ Unimplemented) ReservedGoKeywordParameters(c *fiber.Ctx, pType string) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /reusable-responses)
+func (_ Unimplemented) ReusableResponses(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /text)
+func (_ Unimplemented) TextExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /unknown)
+func (_ Unimplemented) UnknownExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /unspecified-content-type)
+func (_ Unimplemented) UnspecifiedContentType(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /urlencoded)
+func (_ Unimplemented) URLEncodedExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /with-headers)
+func (_ Unimplemented) HeadersExample(c *fiber.Ctx, params HeadersExampleParams) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
+// (POST /with-union)
+func (_ Unimplemented) UnionExample(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -92,7 +150,7 @@ func (siw *ServerInterfaceWrapper) ReservedGoKeywordParameters(c *fiber.Ctx) err
 	// ------------- Path parameter "type" -------------
 	var pType string
 
-	err = runtime.BindStyledParameter("simple", false, "type", c.Params("type"), &pType)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, c.Params("type"), &pType)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter type: %w", err).Error())
 	}
@@ -755,6 +813,114 @@ type StrictServerInterface interface {
 	UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (POST /json)
+func (_ UnimplementedStrictServer) JSONExample(ctx context.Context, request JSONExampleRequestObject) (JSONExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multipart)
+func (_ UnimplementedStrictServer) MultipartExample(ctx context.Context, request MultipartExampleRequestObject) (MultipartExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multiple)
+func (_ UnimplementedStrictServer) MultipleRequestAndResponseTypes(ctx context.Context, request MultipleRequestAndResponseTypesRequestObject) (MultipleRequestAndResponseTypesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ UnimplementedStrictServer) ReservedGoKeywordParameters(ctx context.Context, request ReservedGoKeywordParametersRequestObject) (ReservedGoKeywordParametersResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /reusable-responses)
+func (_ UnimplementedStrictServer) ReusableResponses(ctx context.Context, request ReusableResponsesRequestObject) (ReusableResponsesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /text)
+func (_ UnimplementedStrictServer) TextExample(ctx context.Context, request TextExampleRequestObject) (TextExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unknown)
+func (_ UnimplementedStrictServer) UnknownExample(ctx context.Context, request UnknownExampleRequestObject) (UnknownExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unspecified-content-type)
+func (_ UnimplementedStrictServer) UnspecifiedContentType(ctx context.Context, request UnspecifiedContentTypeRequestObject) (UnspecifiedContentTypeResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /urlencoded)
+func (_ UnimplementedStrictServer) URLEncodedExample(ctx context.Context, request URLEncodedExampleRequestObject) (URLEncodedExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-headers)
+func (_ UnimplementedStrictServer) HeadersExample(ctx context.Context, request HeadersExampleRequestObject) (HeadersExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-union)
+func (_ UnimplementedStrictServer) UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitJSONExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitMultipartExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitMultipleRequestAndResponseTypesResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitReservedGoKeywordParametersResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitReusableResponsesResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitTextExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitUnknownExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitUnspecifiedContentTypeResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitURLEncodedExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitHeadersExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
+func (unimplementedResponse) VisitUnionExampleResponse(ctx *fiber.Ctx) error {
+	return ctx.SendStatus(fiber.StatusNotImplemented)
+}
+
 type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
 
 type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -60,6 +61,64 @@ type ServerInterface interface {
 	UnionExample(ctx iris.Context)
 }
 
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type Unimplemented struct{}
+
+// (POST /json)
+func (_ Unimplemented) JSONExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /multipart)
+func (_ Unimplemented) MultipartExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /multiple)
+func (_ Unimplemented) MultipleRequestAndResponseTypes(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ Unimplemented) ReservedGoKeywordParameters(ctx iris.Context, pType string) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /reusable-responses)
+func (_ Unimplemented) ReusableResponses(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /text)
+func (_ Unimplemented) TextExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /unknown)
+func (_ Unimplemented) UnknownExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /unspecified-content-type)
+func (_ Unimplemented) UnspecifiedContentType(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /urlencoded)
+func (_ Unimplemented) URLEncodedExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /with-headers)
+func (_ Unimplemented) HeadersExample(ctx iris.Context, params HeadersExampleParams) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
+// (POST /with-union)
+func (_ Unimplemented) UnionExample(ctx iris.Context) {
+	ctx.StatusCode(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler ServerInterface
@@ -98,8 +157,7 @@ func (w *ServerInterfaceWrapper) ReservedGoKeywordParameters(ctx iris.Context) {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "type", runtime.ParamLocationPath, ctx.Params().Get("type"), &pType)
 	if err != nil {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.Writef("Invalid format for parameter type: %s", err)
+		writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter type: %w", err))
 		return
 	}
 
@@ -156,22 +214,19 @@ func (w *ServerInterfaceWrapper) HeadersExample(ctx iris.Context) {
 		var Header1 string
 		n := len(valueList)
 		if n != 1 {
-			ctx.StatusCode(http.StatusBadRequest)
-			ctx.Writef("Expected one value for header1, got %d", n)
+			writeBadRequest(ctx, fmt.Errorf("Expected one value for header1, got %d", n))
 			return
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "header1", runtime.ParamLocationHeader, valueList[0], &Header1)
 		if err != nil {
-			ctx.StatusCode(http.StatusBadRequest)
-			ctx.Writef("Invalid format for parameter header1: %s", err)
+			writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter header1: %w", err))
 			return
 		}
 
 		params.Header1 = Header1
 	} else {
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.WriteString("Header header1 is required, but not found")
+		writeBadRequest(ctx, errors.New("Header header1 is required, but not found"))
 		return
 	}
 	// ------------- Optional header parameter "header2" -------------
@@ -179,15 +234,13 @@ func (w *ServerInterfaceWrapper) HeadersExample(ctx iris.Context) {
 		var Header2 int
 		n := len(valueList)
 		if n != 1 {
-			ctx.StatusCode(http.StatusBadRequest)
-			ctx.Writef("Expected one value for header2, got %d", n)
+			writeBadRequest(ctx, fmt.Errorf("Expected one value for header2, got %d", n))
 			return
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "header2", runtime.ParamLocationHeader, valueList[0], &Header2)
 		if err != nil {
-			ctx.StatusCode(http.StatusBadRequest)
-			ctx.Writef("Invalid format for parameter header2: %s", err)
+			writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter header2: %w", err))
 			return
 		}
 
@@ -205,6 +258,12 @@ func (w *ServerInterfaceWrapper) UnionExample(ctx iris.Context) {
 	w.Handler.UnionExample(ctx)
 }
 
+// writeBadRequest responds to a request whose parameters can't be bound.
+func writeBadRequest(ctx iris.Context, err error) {
+	ctx.StatusCode(http.StatusBadRequest)
+	ctx.WriteString(err.Error())
+}
+
 // IrisServerOption is the option for iris server
 type IrisServerOptions struct {
 	BaseURL     string
@@ -770,6 +829,125 @@ type StrictServerInterface interface {
 	UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error)
 }
 
+// UnimplementedStrictServer is a strict server implementation that returns
+// http.StatusNotImplemented for each endpoint. Embed it to implement the
+// endpoints one at a time.
+type UnimplementedStrictServer struct{}
+
+// (POST /json)
+func (_ UnimplementedStrictServer) JSONExample(ctx context.Context, request JSONExampleRequestObject) (JSONExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multipart)
+func (_ UnimplementedStrictServer) MultipartExample(ctx context.Context, request MultipartExampleRequestObject) (MultipartExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /multiple)
+func (_ UnimplementedStrictServer) MultipleRequestAndResponseTypes(ctx context.Context, request MultipleRequestAndResponseTypesRequestObject) (MultipleRequestAndResponseTypesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (GET /reserved-go-keyword-parameters/{type})
+func (_ UnimplementedStrictServer) ReservedGoKeywordParameters(ctx context.Context, request ReservedGoKeywordParametersRequestObject) (ReservedGoKeywordParametersResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /reusable-responses)
+func (_ UnimplementedStrictServer) ReusableResponses(ctx context.Context, request ReusableResponsesRequestObject) (ReusableResponsesResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /text)
+func (_ UnimplementedStrictServer) TextExample(ctx context.Context, request TextExampleRequestObject) (TextExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unknown)
+func (_ UnimplementedStrictServer) UnknownExample(ctx context.Context, request UnknownExampleRequestObject) (UnknownExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /unspecified-content-type)
+func (_ UnimplementedStrictServer) UnspecifiedContentType(ctx context.Context, request UnspecifiedContentTypeRequestObject) (UnspecifiedContentTypeResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /urlencoded)
+func (_ UnimplementedStrictServer) URLEncodedExample(ctx context.Context, request URLEncodedExampleRequestObject) (URLEncodedExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-headers)
+func (_ UnimplementedStrictServer) HeadersExample(ctx context.Context, request HeadersExampleRequestObject) (HeadersExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// (POST /with-union)
+func (_ UnimplementedStrictServer) UnionExample(ctx context.Context, request UnionExampleRequestObject) (UnionExampleResponseObject, error) {
+	return unimplementedResponse{}, nil
+}
+
+// unimplementedResponse is the response of the endpoints of the
+// UnimplementedStrictServer, with http.StatusNotImplemented.
+type unimplementedResponse struct{}
+
+func (unimplementedResponse) VisitJSONExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipartExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitMultipleRequestAndResponseTypesResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReservedGoKeywordParametersResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitReusableResponsesResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitTextExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnknownExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnspecifiedContentTypeResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitURLEncodedExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitHeadersExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
+func (unimplementedResponse) VisitUnionExampleResponse(ctx iris.Context) error {
+	ctx.StatusCode(http.StatusNotImplemented)
+	return nil
+}
+
 type StrictHandlerFunc = strictiris.StrictIrisHandlerFunc
 type StrictMiddlewareFunc = strictiris.StrictIrisMiddlewareFunc
 
@@ -72,6 +72,35 @@ func TestFiberServer(t *testing.T) {
 	testImpl(t, adaptor.FiberApp(r))
 }
 
+func TestUnimplementedStrictServer(t *testing.T) {
+	chiRouter := chi.NewRouter()
+	e := echo.New()
+	echoAPI.RegisterHandlers(e, echoAPI.NewStrictHandler(echoAPI.UnimplementedStrictServer{}, nil))
+	gin.SetMode(gin.ReleaseMode)
+	g := gin.New()
+	ginAPI.RegisterHandlers(g, ginAPI.NewStrictHandler(ginAPI.UnimplementedStrictServer{}, nil))
+	f := fiber.New()
+	fiberAPI.RegisterHandlers(f, fiberAPI.NewStrictHandler(fiberAPI.UnimplementedStrictServer{}, nil))
+	i := iris.New()
+	irisAPI.RegisterHandlers(i, irisAPI.NewStrictHandler(irisAPI.UnimplementedStrictServer{}, nil))
+
+	handlers := map[string]http.Handler{
+		"chi":   chiAPI.HandlerFromMux(chiAPI.NewStrictHandler(chiAPI.UnimplementedStrictServer{}, nil), chiRouter),
+		"echo":  e,
+		"gin":   g,
+		"fiber": adaptor.FiberApp(f),
+		"iris":  i,
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			value := "123"
+			requestBody := clientAPI.Example{Value: &value}
+			rr := testutil.NewRequest().Post("/json").WithJsonBody(requestBody).GoWithHTTPHandler(t, handler).Recorder
+			assert.Equal(t, http.StatusNotImplemented, rr.Code)
+		})
+	}
+}
+
 func testImpl(t *testing.T, handler http.Handler) {
 	t.Run("JSONExample", func(t *testing.T) {
 		value := "123"
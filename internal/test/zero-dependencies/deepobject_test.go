@@ -0,0 +1,71 @@
+package zerodependencies
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/oapi-codegen/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// TestDeepObjectMatchesRuntime checks that the deepObject parameters are
+// serialized into the same query as with the runtime package.
+func TestDeepObjectMatchesRuntime(t *testing.T) {
+	filters := map[string]Filter{
+		"empty":     {},
+		"primitive": {Name: ptr("lamp")},
+		"map":       {Labels: &map[string]string{"color": "blue", "size": "large"}},
+		"nested":    {Range: &Range{Min: ptr(1), Max: ptr(5)}},
+		"slice":     {Ids: &[]int{3, 1, 2}},
+		"objects":   {Ranges: &[]Range{{Min: ptr(1)}, {Max: ptr(2)}}},
+		"all": {
+			Name:   ptr("lamp"),
+			Labels: &map[string]string{"color": "blue"},
+			Range:  &Range{Min: ptr(0)},
+			Ids:    &[]int{7},
+			Ranges: &[]Range{{Min: ptr(1), Max: ptr(2)}},
+		},
+	}
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			expected, err := runtime.StyleParamWithLocation("deepObject", true, "filter", runtime.ParamLocationQuery, filter)
+			require.NoError(t, err)
+			actual, err := styleParamWithLocation("deepObject", true, "filter", paramLocationQuery, filter)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+
+			expectedQuery, err := url.ParseQuery(expected)
+			require.NoError(t, err)
+			actualQuery, err := url.ParseQuery(actual)
+			require.NoError(t, err)
+			assert.Equal(t, expectedQuery, actualQuery)
+		})
+	}
+}
+
+func TestDeepObjectEscaping(t *testing.T) {
+	filter := Filter{
+		Name:   ptr("a b&c"),
+		Labels: &map[string]string{"a&b=c": "r", "[x]": "y"},
+	}
+	query, err := styleParamWithLocation("deepObject", true, "filter", paramLocationQuery, filter)
+	require.NoError(t, err)
+	assert.Equal(t, "filter[labels][%5Bx%5D]=y&filter[labels][a%26b%3Dc]=r&filter[name]=a+b%26c", query)
+
+	// The request has the names and values as they are
+	req, err := NewSearchRequest("https://example.com", &SearchParams{Filter: &filter})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"filter[labels][a&b=c]": {"r"},
+		"filter[labels][[x]]":   {"y"},
+		"filter[name]":          {"a b&c"},
+	}, req.URL.Query())
+
+	_, err = styleParamWithLocation("deepObject", false, "filter", paramLocationQuery, filter)
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// APIVersionDefinition describes how the client sends the version of the API
+// with the requests of an operation.
+type APIVersionDefinition struct {
+	Version    string // The version, the info.version of the spec unless the operation overrides it
+	Header     string // The header sending the version, if any
+	PathPrefix string // The prefix of the path of the operation, with the version in place of {version}, if any
+
+	options APIVersionOptions // The options declaring how the version is sent
+}
+
+// specAPIVersion returns how the client sends the version of the API, as the
+// api-version output option, or else the x-api-version extension of the spec,
+// declares it, or nil when they don't.
+func specAPIVersion(spec *openapi3.T, options *APIVersionOptions) (*APIVersionDefinition, error) {
+	if options == nil {
+		extension, ok := spec.Extensions[extAPIVersion]
+		if !ok {
+			return nil, nil
+		}
+		parsed, err := extParseAPIVersion(extension)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", extAPIVersion, err)
+		}
+		options = &parsed
+	}
+	version := options.Version
+	if version == "" && spec.Info != nil {
+		version = spec.Info.Version
+	}
+	if version == "" {
+		return nil, fmt.Errorf("the API version is sent with the requests, but the spec has no info.version")
+	}
+	return newAPIVersion(*options, version), nil
+}
+
+// newAPIVersion returns the APIVersionDefinition sending the given version as
+// the options declare.
+func newAPIVersion(options APIVersionOptions, version string) *APIVersionDefinition {
+	return &APIVersionDefinition{
+		Version:    version,
+		Header:     options.Header,
+		PathPrefix: strings.ReplaceAll(options.PathPrefix, "{version}", version),
+		options:    options,
+	}
+}
+
+// operationAPIVersion returns how the client sends the version of the API
+// with the requests of an operation, whose x-api-version overrides the version.
+func operationAPIVersion(operationID string, op *openapi3.Operation) (*APIVersionDefinition, error) {
+	apiVersion := globalState.apiVersion
+	extension, ok := op.Extensions[extAPIVersion]
+	if !ok {
+		return apiVersion, nil
+	}
+	if apiVersion == nil {
+		return nil, fmt.Errorf("%s declares its version with %q, but the spec doesn't declare how to send it, with %q or the api-version output option", operationID, extAPIVersion, extAPIVersion)
+	}
+	version, err := extParseOperationAPIVersion(extension)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q on %s: %w", extAPIVersion, operationID, err)
+	}
+	return newAPIVersion(apiVersion.options, version), nil
+}
+
+// GenerateAPIVersion generates the constants of the version of the API.
+func GenerateAPIVersion(t *template.Template) (string, error) {
+	return GenerateTemplates([]string{"api-version.tmpl"}, t, globalState.apiVersion)
+}
@@ -0,0 +1,349 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// AsyncOperation is a publish or subscribe operation of a channel of an
+// AsyncAPI document, whose helper encodes or decodes the payloads of its
+// message. As AsyncAPI 2 words them from the point of view of the clients of
+// the application, the application receives the messages of the publish
+// operations, and sends those of the subscribe ones.
+type AsyncOperation struct {
+	Action      string // publish or subscribe, as the AsyncAPI document words it
+	Sends       bool   // Whether the application sends the messages, those of a subscribe operation, rather than receiving them
+	FuncName    string // The name of the helper, like PublishUserSignedUp for a subscribe operation
+	Channel     string // The name of the channel, used as it is
+	Summary     string
+	MessageName string
+	ContentType string
+	PayloadType string // The Go type of the payload of the message
+
+	payload *openapi3.SchemaRef // A reference to the component of the payload
+}
+
+// SummaryAsComment returns the summary of the operation as a comment.
+func (o AsyncOperation) SummaryAsComment() string {
+	if o.Summary == "" {
+		return ""
+	}
+	parts := strings.Split(strings.TrimSuffix(o.Summary, "\n"), "\n")
+	for i, p := range parts {
+		parts[i] = "// " + p
+	}
+	return strings.Join(parts, "\n")
+}
+
+// asyncAPIDocument is the subset of an AsyncAPI 2 document, or of the x-async
+// section of a spec, describing the messages of the channels.
+type asyncAPIDocument struct {
+	AsyncAPI           string                     `json:"asyncapi,omitempty"`
+	DefaultContentType string                     `json:"defaultContentType,omitempty"`
+	Channels           map[string]asyncAPIChannel `json:"channels,omitempty"`
+	Components         struct {
+		Schemas  openapi3.Schemas            `json:"schemas,omitempty"`
+		Messages map[string]*asyncAPIMessage `json:"messages,omitempty"`
+	} `json:"components,omitempty"`
+
+	path string // Where the document is, prefixing the paths of the warnings
+}
+
+type asyncAPIChannel struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Publish    *asyncAPIOperation     `json:"publish,omitempty"`
+	Subscribe  *asyncAPIOperation     `json:"subscribe,omitempty"`
+}
+
+type asyncAPIOperation struct {
+	OperationID string           `json:"operationId,omitempty"`
+	Summary     string           `json:"summary,omitempty"`
+	Message     *asyncAPIMessage `json:"message,omitempty"`
+}
+
+type asyncAPIMessage struct {
+	Ref         string              `json:"$ref,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	ContentType string              `json:"contentType,omitempty"`
+	Payload     *openapi3.SchemaRef `json:"payload,omitempty"`
+	OneOf       []*asyncAPIMessage  `json:"oneOf,omitempty"`
+}
+
+// asyncAPIDocuments returns the documents describing the messages of the
+// service: the x-async section of the spec, and the AsyncAPI document of the
+// asyncapi option.
+func asyncAPIDocuments(spec *openapi3.T, path string) ([]asyncAPIDocument, error) {
+	var docs []asyncAPIDocument
+	if section, ok := spec.Extensions[extAsync]; ok {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", extAsync, err)
+		}
+		doc := asyncAPIDocument{path: "/" + extAsync}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", extAsync, err)
+		}
+		docs = append(docs, doc)
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the AsyncAPI document: %w", err)
+		}
+		doc := asyncAPIDocument{path: path + "#"}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing the AsyncAPI document %s: %w", path, err)
+		}
+		if !strings.HasPrefix(doc.AsyncAPI, "2.") {
+			return nil, fmt.Errorf("%s is an AsyncAPI %q document, only AsyncAPI 2 ones are supported", path, doc.AsyncAPI)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// asyncOperations returns the operations of the channels of the AsyncAPI
+// documents, merging their schemas into the components of the spec, along
+// with the inline payloads of their messages, named <Message>Payload, so
+// that they're generated like the other types.
+func asyncOperations(spec *openapi3.T, opts Configuration) ([]AsyncOperation, error) {
+	docs, err := asyncAPIDocuments(spec, opts.AsyncAPI)
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+	if spec.Components == nil {
+		spec.Components = &openapi3.Components{}
+	}
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = make(openapi3.Schemas)
+	}
+
+	toCamelCase := ToCamelCase
+	if opts.OutputOptions.InitialismOverrides {
+		toCamelCase = ToCamelCaseWithInitialism
+	}
+
+	var added []*openapi3.SchemaRef
+	addSchema := func(name string, schema *openapi3.SchemaRef) error {
+		if _, ok := spec.Components.Schemas[name]; ok {
+			return fmt.Errorf("the schema %s of the AsyncAPI document clashes with the one of the spec", name)
+		}
+		spec.Components.Schemas[name] = schema
+		added = append(added, schema)
+		return nil
+	}
+
+	var operations []AsyncOperation
+	channelsByFunc := make(map[string]string)
+	for _, doc := range docs {
+		for _, name := range SortedSchemaKeys(doc.Components.Schemas) {
+			if err := addSchema(name, doc.Components.Schemas[name]); err != nil {
+				return nil, err
+			}
+		}
+
+		// The payloads of the messages, which several operations may share.
+		payloads := make(map[*asyncAPIMessage]*openapi3.SchemaRef)
+
+		channels := make([]string, 0, len(doc.Channels))
+		for channel := range doc.Channels {
+			channels = append(channels, channel)
+		}
+		sort.Strings(channels)
+		for _, channel := range channels {
+			ch := doc.Channels[channel]
+			if len(ch.Parameters) != 0 {
+				warn(nil, doc.path+jsonPointer("channels", channel, "parameters"), "the parameters of the channel aren't supported, its helpers use its name as it is")
+			}
+			for _, action := range []string{"publish", "subscribe"} {
+				op := ch.Publish
+				if action == "subscribe" {
+					op = ch.Subscribe
+				}
+				if op == nil {
+					continue
+				}
+				path := doc.path + jsonPointer("channels", channel, action)
+				if op.Message == nil {
+					warn(nil, path, "the operation has no message, so it's skipped")
+					continue
+				}
+				message, name, err := doc.message(op.Message)
+				if err != nil {
+					return nil, fmt.Errorf("error resolving the message of %s: %w", path, err)
+				}
+				if len(message.OneOf) != 0 {
+					warn(nil, path+"/message", "oneOf messages aren't supported, so the operation is skipped")
+					continue
+				}
+				contentType := message.ContentType
+				if contentType == "" {
+					contentType = doc.DefaultContentType
+				}
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				if !util.IsMediaTypeJson(contentType) {
+					warn(nil, path+"/message", "the payloads of %s messages aren't supported, so the operation is skipped", contentType)
+					continue
+				}
+				if message.Payload == nil {
+					warn(nil, path+"/message", "the message has no payload, so the operation is skipped")
+					continue
+				}
+				if name == "" {
+					name = op.OperationID
+				}
+				if name == "" {
+					return nil, fmt.Errorf("the message of %s needs a name, which the type of its payload is named after", path)
+				}
+
+				payload, ok := payloads[message]
+				switch {
+				case ok:
+				case message.Payload.Ref != "":
+					payload = message.Payload
+				default:
+					payloadName := name + "Payload"
+					if err := addSchema(payloadName, message.Payload); err != nil {
+						return nil, err
+					}
+					payload = &openapi3.SchemaRef{Ref: "#/components/schemas/" + payloadName, Value: message.Payload.Value}
+				}
+				payloads[message] = payload
+
+				// The application subscribes to the channels of the publish
+				// operations, and publishes to those of the subscribe ones
+				sends := action == "subscribe"
+				prefix := "Subscribe"
+				if sends {
+					prefix = "Publish"
+				}
+				funcName := op.OperationID
+				if funcName == "" {
+					funcName = name
+				}
+				funcName = toCamelCase(funcName)
+				if !strings.HasPrefix(funcName, prefix) {
+					funcName = prefix + funcName
+				}
+				if other, ok := channelsByFunc[funcName]; ok {
+					return nil, fmt.Errorf("the helpers of the channels %s and %s have the same name %s, which their operationId can change", other, channel, funcName)
+				}
+				channelsByFunc[funcName] = channel
+
+				operations = append(operations, AsyncOperation{
+					Action:      action,
+					Sends:       sends,
+					FuncName:    funcName,
+					Channel:     channel,
+					Summary:     op.Summary,
+					MessageName: name,
+					ContentType: contentType,
+					payload:     payload,
+				})
+			}
+		}
+	}
+
+	// The schemas of the documents reference those of the spec, or each
+	// other, which they're resolved to now that they're all components.
+	resolve := func(ref *openapi3.SchemaRef) error {
+		var err error
+		_ = walkSchemaRef(ref, func(ref RefWrapper) (bool, error) {
+			schemaRef := ref.SourceRef.(*openapi3.SchemaRef)
+			if ref.Ref == "" {
+				return true, nil
+			}
+			if !ref.HasValue {
+				value, resolveErr := resolveComponentSchema(spec, ref.Ref)
+				if resolveErr != nil && err == nil {
+					err = resolveErr
+				}
+				schemaRef.Value = value
+			}
+			return false, nil
+		})
+		return err
+	}
+	for _, schema := range added {
+		if err := resolve(schema); err != nil {
+			return nil, err
+		}
+	}
+	for _, op := range operations {
+		if err := resolve(op.payload); err != nil {
+			return nil, err
+		}
+	}
+	return operations, nil
+}
+
+// message returns the message a reference of the document points to, or the
+// inline message, with its name, or its key among the components.
+func (doc asyncAPIDocument) message(message *asyncAPIMessage) (*asyncAPIMessage, string, error) {
+	if message.Ref == "" {
+		return message, message.Name, nil
+	}
+	key, ok := strings.CutPrefix(message.Ref, "#/components/messages/")
+	if !ok {
+		return nil, "", fmt.Errorf("the reference %s isn't supported, only those to #/components/messages are", message.Ref)
+	}
+	resolved, ok := doc.Components.Messages[key]
+	if !ok || resolved == nil {
+		return nil, "", fmt.Errorf("the message %s isn't found", key)
+	}
+	if resolved.Ref != "" {
+		return nil, "", fmt.Errorf("the message %s references another one", key)
+	}
+	name := resolved.Name
+	if name == "" {
+		name = key
+	}
+	return resolved, name, nil
+}
+
+// resolveComponentSchema returns the schema of the component a reference
+// points to, following the references of the components to each other.
+func resolveComponentSchema(spec *openapi3.T, ref string) (*openapi3.Schema, error) {
+	seen := make(map[string]bool)
+	for !seen[ref] {
+		seen[ref] = true
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		component := spec.Components.Schemas[name]
+		if !ok || component == nil {
+			return nil, fmt.Errorf("the reference %s of the AsyncAPI document isn't found among the schemas", ref)
+		}
+		if component.Value != nil || component.Ref == "" {
+			return component.Value, nil
+		}
+		ref = component.Ref
+	}
+	return nil, fmt.Errorf("the reference %s of the AsyncAPI document is circular", ref)
+}
+
+// GenerateAsyncHelpers generates the envelopes of the messages of the
+// channels of the AsyncAPI documents, and the helpers publishing and
+// subscribing to them.
+func GenerateAsyncHelpers(t *template.Template, operations []AsyncOperation) (string, error) {
+	if len(operations) == 0 {
+		return "", nil
+	}
+	for i, op := range operations {
+		payload, err := GenerateGoSchema(op.payload, nil)
+		if err != nil {
+			return "", fmt.Errorf("error generating the type of the payload of the %s message: %w", op.MessageName, err)
+		}
+		operations[i].PayloadType = payload.TypeDecl()
+	}
+	return GenerateTemplates([]string{"asyncapi.tmpl"}, t, operations)
+}
@@ -0,0 +1,316 @@
+package codegen
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Bundle moves everything the spec references in other documents, be they
+// files or URLs, into its own components, and points the references at them,
+// so that the spec is self-contained. The components keep the names they have
+// in their documents, unless these are already taken, in which case they are
+// prefixed with the name of their document, like PetsPet for the Pet schema
+// of pets.yaml.
+//
+// Schemas, parameters, request bodies, responses and headers are bundled.
+func Bundle(spec *openapi3.T) {
+	newBundler(spec, nil).bundle()
+}
+
+// bundleExternalRefs bundles the documents referenced by the spec, except
+// those in the import mapping, whose types are imported rather than generated.
+func bundleExternalRefs(spec *openapi3.T, importMapping map[string]string) {
+	mapped := make(map[string]bool, len(importMapping))
+	for document := range importMapping {
+		mapped[cleanDocument(document)] = true
+	}
+	newBundler(spec, mapped).bundle()
+}
+
+// bundler tracks the components of the spec while Bundle adds to them.
+type bundler struct {
+	spec *openapi3.T
+	// The documents whose references are left as they are
+	mapped map[string]bool
+	// The names of the components, by kind
+	names map[string]map[string]bool
+	// The local references to the values which are components
+	refs map[interface{}]string
+	// Whether the spec declares components, else they're only kept when
+	// something is bundled into them
+	declared bool
+	// Whether a component was added
+	added bool
+}
+
+func newBundler(spec *openapi3.T, mapped map[string]bool) *bundler {
+	declared := spec.Components != nil
+	if !declared {
+		spec.Components = &openapi3.Components{}
+	}
+	return &bundler{
+		spec:     spec,
+		mapped:   mapped,
+		names:    make(map[string]map[string]bool),
+		refs:     make(map[interface{}]string),
+		declared: declared,
+	}
+}
+
+func (b *bundler) bundle() {
+	components := b.spec.Components
+
+	// The components of the spec keep their names, so they are registered
+	// before any is added.
+	for _, name := range SortedSchemaKeys(components.Schemas) {
+		b.registerComponent("schemas", name, components.Schemas[name].Ref, components.Schemas[name].Value)
+	}
+	for _, name := range SortedParameterKeys(components.Parameters) {
+		b.registerComponent("parameters", name, components.Parameters[name].Ref, components.Parameters[name].Value)
+	}
+	for _, name := range SortedRequestBodyKeys(components.RequestBodies) {
+		b.registerComponent("requestBodies", name, components.RequestBodies[name].Ref, components.RequestBodies[name].Value)
+	}
+	for _, name := range SortedResponsesKeys(components.Responses) {
+		b.registerComponent("responses", name, components.Responses[name].Ref, components.Responses[name].Value)
+	}
+	for _, name := range SortedHeadersKeys(components.Headers) {
+		b.registerComponent("headers", name, components.Headers[name].Ref, components.Headers[name].Value)
+	}
+
+	for _, name := range SortedSchemaKeys(components.Schemas) {
+		b.bundleSchema(components.Schemas[name], "")
+	}
+	for _, name := range SortedParameterKeys(components.Parameters) {
+		b.bundleParameter(components.Parameters[name], "")
+	}
+	for _, name := range SortedRequestBodyKeys(components.RequestBodies) {
+		b.bundleRequestBody(components.RequestBodies[name], "")
+	}
+	for _, name := range SortedResponsesKeys(components.Responses) {
+		b.bundleResponse(components.Responses[name], "")
+	}
+	for _, name := range SortedHeadersKeys(components.Headers) {
+		b.bundleHeader(components.Headers[name], "")
+	}
+
+	for _, requestPath := range SortedPathsKeys(b.spec.Paths) {
+		pathItem := b.spec.Paths[requestPath]
+		for _, param := range pathItem.Parameters {
+			b.bundleParameter(param, "")
+		}
+		pathOps := pathItem.Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			op := pathOps[opName]
+			for _, param := range op.Parameters {
+				b.bundleParameter(param, "")
+			}
+			b.bundleRequestBody(op.RequestBody, "")
+			for _, responseName := range SortedResponsesKeys(op.Responses) {
+				b.bundleResponse(op.Responses[responseName], "")
+			}
+		}
+	}
+
+	// A spec without components stays so when it references no other document
+	if !b.declared && !b.added {
+		b.spec.Components = nil
+	}
+}
+
+// registerComponent records a component of the spec. Only those declared
+// inline are referenced in place of their values, the others being aliases.
+func (b *bundler) registerComponent(kind, name, ref string, value interface{}) {
+	if b.names[kind] == nil {
+		b.names[kind] = make(map[string]bool)
+	}
+	b.names[kind][name] = true
+	if _, ok := b.refs[value]; !ok && ref == "" {
+		b.refs[value] = "#/components/" + kind + "/" + name
+	}
+}
+
+// rebase points a reference found in the given document at the component
+// holding the value it references, adding the component if needed. It returns
+// the document the value is declared in, and whether it's newly added, in
+// which case the references it contains must be rebased too.
+func (b *bundler) rebase(ref *string, kind, document string, value interface{}) (string, bool) {
+	if *ref == "" {
+		return document, true
+	}
+	if document == "" && strings.HasPrefix(*ref, "#") {
+		// A reference within the spec
+		return "", false
+	}
+	if local, ok := b.refs[value]; ok {
+		*ref = local
+		return "", false
+	}
+	target := documentOf(document, *ref)
+	if b.mapped[target] {
+		return "", false
+	}
+
+	name := openapi3.DefaultRefNameResolver(*ref)
+	if b.names[kind][name] {
+		// Prefix the name with the one of its document, and number it when
+		// that's not enough.
+		base := path.Base(target)
+		base = strings.TrimSuffix(base, path.Ext(base))
+		prefixed := UppercaseFirstCharacter(ToCamelCase(base)) + name
+		name = prefixed
+		for i := 2; b.names[kind][name]; i++ {
+			name = prefixed + strconv.Itoa(i)
+		}
+	}
+	b.registerComponent(kind, name, "", value)
+	b.added = true
+
+	components := b.spec.Components
+	switch v := value.(type) {
+	case *openapi3.Schema:
+		if components.Schemas == nil {
+			components.Schemas = make(openapi3.Schemas)
+		}
+		components.Schemas[name] = &openapi3.SchemaRef{Value: v}
+	case *openapi3.Parameter:
+		if components.Parameters == nil {
+			components.Parameters = make(openapi3.ParametersMap)
+		}
+		components.Parameters[name] = &openapi3.ParameterRef{Value: v}
+	case *openapi3.RequestBody:
+		if components.RequestBodies == nil {
+			components.RequestBodies = make(openapi3.RequestBodies)
+		}
+		components.RequestBodies[name] = &openapi3.RequestBodyRef{Value: v}
+	case *openapi3.Response:
+		if components.Responses == nil {
+			components.Responses = make(openapi3.Responses)
+		}
+		components.Responses[name] = &openapi3.ResponseRef{Value: v}
+	case *openapi3.Header:
+		if components.Headers == nil {
+			components.Headers = make(openapi3.Headers)
+		}
+		components.Headers[name] = &openapi3.HeaderRef{Value: v}
+	}
+
+	*ref = b.refs[value]
+	return target, true
+}
+
+func (b *bundler) bundleSchema(ref *openapi3.SchemaRef, document string) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if document, ok := b.rebase(&ref.Ref, "schemas", document, ref.Value); ok {
+		b.bundleSchemaValue(ref.Value, document)
+	}
+}
+
+func (b *bundler) bundleSchemaValue(schema *openapi3.Schema, document string) {
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		b.bundleSchema(schema.Properties[name], document)
+	}
+	b.bundleSchema(schema.Items, document)
+	b.bundleSchema(schema.AdditionalProperties.Schema, document)
+	b.bundleSchema(schema.Not, document)
+	for _, member := range schema.AllOf {
+		b.bundleSchema(member, document)
+	}
+	for _, member := range schema.OneOf {
+		b.bundleSchema(member, document)
+	}
+	for _, member := range schema.AnyOf {
+		b.bundleSchema(member, document)
+	}
+}
+
+func (b *bundler) bundleParameter(ref *openapi3.ParameterRef, document string) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if document, ok := b.rebase(&ref.Ref, "parameters", document, ref.Value); ok {
+		b.bundleParameterValue(ref.Value, document)
+	}
+}
+
+func (b *bundler) bundleParameterValue(param *openapi3.Parameter, document string) {
+	b.bundleSchema(param.Schema, document)
+	b.bundleContent(param.Content, document)
+}
+
+func (b *bundler) bundleRequestBody(ref *openapi3.RequestBodyRef, document string) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if document, ok := b.rebase(&ref.Ref, "requestBodies", document, ref.Value); ok {
+		b.bundleContent(ref.Value.Content, document)
+	}
+}
+
+func (b *bundler) bundleResponse(ref *openapi3.ResponseRef, document string) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if document, ok := b.rebase(&ref.Ref, "responses", document, ref.Value); ok {
+		b.bundleResponseValue(ref.Value, document)
+	}
+}
+
+func (b *bundler) bundleResponseValue(response *openapi3.Response, document string) {
+	for _, name := range SortedHeadersKeys(response.Headers) {
+		b.bundleHeader(response.Headers[name], document)
+	}
+	b.bundleContent(response.Content, document)
+}
+
+func (b *bundler) bundleHeader(ref *openapi3.HeaderRef, document string) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if document, ok := b.rebase(&ref.Ref, "headers", document, ref.Value); ok {
+		b.bundleParameterValue(&ref.Value.Parameter, document)
+	}
+}
+
+func (b *bundler) bundleContent(content openapi3.Content, document string) {
+	for _, contentType := range SortedContentKeys(content) {
+		b.bundleSchema(content[contentType].Schema, document)
+	}
+}
+
+// documentOf returns the document which a reference found in the given
+// document points into. The spec itself is the empty document, and the
+// others are identified by their path relative to it, or their URL.
+func documentOf(document, ref string) string {
+	location, _, _ := strings.Cut(ref, "#")
+	if location == "" {
+		return document
+	}
+	if u, err := url.Parse(location); err == nil && u.IsAbs() {
+		return location
+	}
+	if base, err := url.Parse(document); err == nil && base.IsAbs() {
+		if u, err := base.Parse(location); err == nil {
+			return u.String()
+		}
+	}
+	if path.IsAbs(location) {
+		return path.Clean(location)
+	}
+	return cleanDocument(path.Join(path.Dir(document), location))
+}
+
+// cleanDocument normalizes the path of a document, so that different
+// spellings of it compare equal.
+func cleanDocument(document string) string {
+	if u, err := url.Parse(document); err == nil && u.IsAbs() {
+		return document
+	}
+	return path.Clean(document)
+}
@@ -0,0 +1,106 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestBundle(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/bundle/spec.yaml")
+	require.NoError(t, err)
+
+	Bundle(swagger)
+
+	schemas := swagger.Components.Schemas
+	require.Len(t, schemas, 4)
+
+	// The external schemas are referenced locally
+	pets := swagger.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Pets", pets.Ref)
+	assert.Same(t, pets.Value, schemas["Pets"].Value)
+
+	// The Pet schema of pets.yaml doesn't get mixed up with the one of the
+	// spec, and is renamed after its document
+	assert.Contains(t, schemas["Pet"].Value.Properties, "local")
+	assert.Equal(t, "#/components/schemas/PetsPet", schemas["Pets"].Value.Items.Ref)
+	assert.Contains(t, schemas["PetsPet"].Value.Properties, "name")
+
+	// Whole documents are named after their file
+	assert.Equal(t, "#/components/schemas/owner", schemas["PetsPet"].Value.Properties["owner"].Ref)
+	assert.Contains(t, schemas["owner"].Value.Properties, "id")
+}
+
+func TestBundleWithoutComponents(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.0"
+info: {version: 1.0.0, title: No components}
+paths: {}
+`))
+	require.NoError(t, err)
+
+	// Nothing to bundle, so the spec keeps having no components
+	Bundle(swagger)
+	assert.Nil(t, swagger.Components)
+}
+
+func TestBundleExternalRefs(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/bundle/spec.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			BundleExternalRefs: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// The types of the external schemas are generated in the package
+	assert.Contains(t, code, "type Pets = []PetsPet")
+	assert.Contains(t, code, "type PetsPet struct {")
+	assert.Contains(t, code, "Owner *Owner  `json:\"owner,omitempty\"`")
+	assert.Contains(t, code, "type Owner struct {")
+	assert.Contains(t, code, "JSON200      *Pets")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestDocumentOf(t *testing.T) {
+	assert.Equal(t, "", documentOf("", "#/components/schemas/Pet"))
+	assert.Equal(t, "sub/pets.yaml", documentOf("", "./sub/pets.yaml#/components/schemas/Pet"))
+	assert.Equal(t, "sub/pets.yaml", documentOf("sub/pets.yaml", "#/components/schemas/Pet"))
+	assert.Equal(t, "sub/owner.yaml", documentOf("sub/pets.yaml", "owner.yaml"))
+	assert.Equal(t, "common.yaml", documentOf("sub/pets.yaml", "../common.yaml#/Error"))
+	assert.Equal(t, "https://example.com/specs/owner.yaml", documentOf("https://example.com/specs/pets.yaml", "owner.yaml"))
+}
+
+func TestBundleExternalRefsImportMapping(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/bundle/spec.yaml")
+	require.NoError(t, err)
+
+	bundleExternalRefs(swagger, map[string]string{
+		"./sub/owner.yaml": "github.com/example/owner",
+	})
+
+	// The mapped document keeps its reference, while the others are bundled
+	schemas := swagger.Components.Schemas
+	assert.Contains(t, schemas, "PetsPet")
+	assert.NotContains(t, schemas, "owner")
+	assert.Equal(t, "owner.yaml", schemas["PetsPet"].Value.Properties["owner"].Ref)
+}
@@ -0,0 +1,177 @@
+package codegen
+
+import (
+	"fmt"
+	"net/textproto"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// captureSensitiveHeaders are the headers which WithCapture always redacts,
+// since they carry credentials whatever the spec says.
+var captureSensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// CaptureRedactions describes what WithCapture redacts from the requests and
+// responses it records.
+type CaptureRedactions struct {
+	Headers    []string           // The headers redacted from all the requests and responses, like those of the API keys
+	Query      []string           // The query parameters redacted from all the requests, like those of the API keys
+	Operations []CaptureRedaction // What is redacted from the operations with sensitive values of their own
+	Schemas    []SchemaFieldPaths // The sensitive values of the components which the fields of the operations reference
+}
+
+// CaptureRedaction describes the sensitive values of the requests and
+// responses of an operation.
+type CaptureRedaction struct {
+	OperationId     string
+	Method          string
+	PathPattern     string     // The regular expression matching the paths of the requests of the operation, capturing the sensitive path parameters
+	PathParams      []string   // The sensitive path parameters
+	Headers         []string   // The sensitive request headers
+	Query           []string   // The sensitive query parameters
+	RequestFields   [][]string // The paths of the sensitive values of the JSON request bodies, * matching any item or map value, ending with the reference of a component continuing them, if any
+	ResponseFields  [][]string // The paths of the sensitive values of the JSON response bodies
+	ResponseHeaders []string   // The sensitive response headers
+}
+
+// captureRedactions returns what WithCapture redacts from the requests and
+// responses of the operations: the values of the parameters, headers and
+// properties declared with x-sensitive, or of the password format, and the
+// credentials of the security schemes.
+func captureRedactions(ops []OperationDefinition) (CaptureRedactions, error) {
+	redactions := CaptureRedactions{Headers: append([]string(nil), captureSensitiveHeaders...)}
+	finder := newFieldPathsFinder(extSensitive, sensitiveSchema)
+	if spec := globalState.spec; spec != nil && spec.Components != nil {
+		names := make([]string, 0, len(spec.Components.SecuritySchemes))
+		for name := range spec.Components.SecuritySchemes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scheme := spec.Components.SecuritySchemes[name]
+			if scheme == nil || scheme.Value == nil || scheme.Value.Type != "apiKey" {
+				continue
+			}
+			switch scheme.Value.In {
+			case "header":
+				redactions.Headers = appendUnique(redactions.Headers, textproto.CanonicalMIMEHeaderKey(scheme.Value.Name))
+			case "query":
+				redactions.Query = appendUnique(redactions.Query, scheme.Value.Name)
+			}
+		}
+	}
+
+	for _, op := range ops {
+		redaction := CaptureRedaction{
+			OperationId: op.OperationId,
+			Method:      op.Method,
+		}
+		for _, param := range op.AllParams() {
+			sensitive, err := sensitiveParameter(param.Spec)
+			if err != nil {
+				return CaptureRedactions{}, fmt.Errorf("invalid value for %q on the parameter %s of %s: %w", extSensitive, param.ParamName, op.OperationId, err)
+			}
+			if !sensitive {
+				continue
+			}
+			// The cookies are redacted along with the Cookie header
+			switch param.In {
+			case "path":
+				redaction.PathParams = appendUnique(redaction.PathParams, param.ParamName)
+			case "header":
+				redaction.Headers = appendUnique(redaction.Headers, textproto.CanonicalMIMEHeaderKey(param.ParamName))
+			case "query":
+				redaction.Query = appendUnique(redaction.Query, param.ParamName)
+			}
+		}
+		redaction.PathPattern = capturingPathPattern(&op, op.RequestPath(), redaction.PathParams)
+		if op.Spec == nil {
+			redactions.Operations = append(redactions.Operations, redaction)
+			continue
+		}
+
+		var err error
+		if op.Spec.RequestBody != nil && op.Spec.RequestBody.Value != nil {
+			redaction.RequestFields, err = finder.contentFields(op.Spec.RequestBody.Value.Content)
+			if err != nil {
+				return CaptureRedactions{}, fmt.Errorf("error finding the sensitive fields of the request body of %s: %w", op.OperationId, err)
+			}
+		}
+		for _, status := range SortedResponsesKeys(op.Spec.Responses) {
+			response := op.Spec.Responses[status]
+			if response == nil || response.Value == nil {
+				continue
+			}
+			fields, err := finder.contentFields(response.Value.Content)
+			if err != nil {
+				return CaptureRedactions{}, fmt.Errorf("error finding the sensitive fields of the %s response of %s: %w", status, op.OperationId, err)
+			}
+			redaction.ResponseFields = appendUniqueFields(redaction.ResponseFields, fields...)
+			for _, name := range SortedHeadersKeys(response.Value.Headers) {
+				header := response.Value.Headers[name]
+				if header == nil || header.Value == nil {
+					continue
+				}
+				sensitive, err := sensitiveParameter(&header.Value.Parameter)
+				if err != nil {
+					return CaptureRedactions{}, fmt.Errorf("invalid value for %q on the header %s of the %s response of %s: %w", extSensitive, name, status, op.OperationId, err)
+				}
+				if sensitive {
+					redaction.ResponseHeaders = appendUnique(redaction.ResponseHeaders, textproto.CanonicalMIMEHeaderKey(name))
+				}
+			}
+		}
+
+		redactions.Operations = append(redactions.Operations, redaction)
+	}
+
+	var lists []*[][]string
+	for i := range redactions.Operations {
+		lists = append(lists, &redactions.Operations[i].RequestFields, &redactions.Operations[i].ResponseFields)
+	}
+	redactions.Schemas = finder.componentFields(lists...)
+	operations := redactions.Operations[:0]
+	for _, redaction := range redactions.Operations {
+		if len(redaction.PathParams) != 0 || len(redaction.Headers) != 0 || len(redaction.Query) != 0 || len(redaction.RequestFields) != 0 ||
+			len(redaction.ResponseFields) != 0 || len(redaction.ResponseHeaders) != 0 {
+			operations = append(operations, redaction)
+		}
+	}
+	redactions.Operations = operations
+	return redactions, nil
+}
+
+// sensitiveParameter returns whether the values of a parameter or a header
+// are sensitive, from its x-sensitive, or those of its schema.
+func sensitiveParameter(param *openapi3.Parameter) (bool, error) {
+	if param == nil {
+		return false, nil
+	}
+	if extension, ok := param.Extensions[extSensitive]; ok {
+		return extParseSensitive(extension)
+	}
+	if param.Schema == nil || param.Schema.Value == nil {
+		return false, nil
+	}
+	return sensitiveSchema(param.Schema.Value)
+}
+
+// sensitiveSchema returns whether the values of a schema are sensitive, from
+// its x-sensitive, or its password format.
+func sensitiveSchema(schema *openapi3.Schema) (bool, error) {
+	if extension, ok := schema.Extensions[extSensitive]; ok {
+		return extParseSensitive(extension)
+	}
+	return schema.Format == "password", nil
+}
+
+// appendUnique appends the values which values doesn't hold yet.
+func appendUnique(values []string, more ...string) []string {
+	for _, value := range more {
+		if !StringInArray(value, values) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
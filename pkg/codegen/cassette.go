@@ -0,0 +1,148 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// CassetteMatchers describes how the CassetteTransport matches the requests
+// of the operations with the interactions of its cassette.
+type CassetteMatchers struct {
+	Operations      []CassetteOperation // The operations, those of the most specific paths first
+	Schemas         []SchemaFieldPaths  // The volatile values of the components which the fields of the operations reference
+	RedactedHeaders []string            // The headers redacted from all the responses, like Set-Cookie and those of the API keys
+	RedactedQuery   []string            // The query parameters redacted from all the requests, like those of the API keys
+	RedactedSchemas []SchemaFieldPaths  // The sensitive values of the components which the fields of the operations reference
+}
+
+// CassetteOperation describes how the requests of an operation are matched,
+// and what is redacted from its interactions.
+type CassetteOperation struct {
+	OperationId             string     // The ID of the operation, keying its interactions
+	Method                  string     // GET, POST, DELETE, etc.
+	PathPattern             string     // The regular expression matching the paths of the requests, capturing the volatile and sensitive path parameters
+	VolatileQuery           []string   // The volatile query parameters
+	VolatileFields          [][]string // The paths of the volatile values of the JSON request bodies, like those of WithCapture
+	RedactedGroups          []int      // The groups of PathPattern capturing the sensitive path parameters
+	RedactedQuery           []string   // The sensitive query parameters
+	RedactedFields          [][]string // The paths of the sensitive values of the JSON request bodies
+	RedactedResponseFields  [][]string // The paths of the sensitive values of the JSON response bodies
+	RedactedResponseHeaders []string   // The sensitive response headers
+}
+
+// cassetteMatchers returns how the CassetteTransport matches the requests of
+// the operations, ignoring the values of the parameters and properties
+// declared with x-volatile, and what it redacts from the interactions it
+// records, the sensitive values which WithCapture redacts, which aren't
+// matched either.
+func cassetteMatchers(ops []OperationDefinition) (CassetteMatchers, error) {
+	redactions, err := captureRedactions(ops)
+	if err != nil {
+		return CassetteMatchers{}, err
+	}
+	redacted := make(map[string]CaptureRedaction, len(redactions.Operations))
+	for _, redaction := range redactions.Operations {
+		redacted[redaction.OperationId] = redaction
+	}
+	matchers := CassetteMatchers{RedactedHeaders: redactions.Headers, RedactedQuery: redactions.Query, RedactedSchemas: redactions.Schemas}
+	finder := newFieldPathsFinder(extVolatile, volatileSchema)
+	params := make([]int, 0, len(ops))
+	for _, op := range ops {
+		redaction := redacted[op.OperationId]
+		operation := CassetteOperation{
+			OperationId:             op.OperationId,
+			Method:                  op.Method,
+			RedactedQuery:           redaction.Query,
+			RedactedFields:          redaction.RequestFields,
+			RedactedResponseFields:  redaction.ResponseFields,
+			RedactedResponseHeaders: redaction.ResponseHeaders,
+		}
+
+		var volatilePath []string
+		for _, param := range op.AllParams() {
+			volatile, err := volatileParameter(param.Spec)
+			if err != nil {
+				return CassetteMatchers{}, fmt.Errorf("invalid value for %q on the parameter %s of %s: %w", extVolatile, param.ParamName, op.OperationId, err)
+			}
+			if !volatile {
+				continue
+			}
+			switch param.In {
+			case "path":
+				volatilePath = append(volatilePath, param.ParamName)
+			case "query":
+				operation.VolatileQuery = appendUnique(operation.VolatileQuery, param.ParamName)
+			}
+		}
+		captured := append(volatilePath, redaction.PathParams...)
+		operation.PathPattern = capturingPathPattern(&op, op.RequestPath(), captured)
+		group := 0
+		for _, loc := range pathParamRegexp.FindAllStringIndex(op.RequestPath(), -1) {
+			name := op.RequestPath()[loc[0]+1 : loc[1]-1]
+			if !StringInArray(name, captured) {
+				continue
+			}
+			group++
+			if StringInArray(name, redaction.PathParams) {
+				operation.RedactedGroups = append(operation.RedactedGroups, group)
+			}
+		}
+
+		if op.Spec != nil && op.Spec.RequestBody != nil && op.Spec.RequestBody.Value != nil {
+			operation.VolatileFields, err = finder.contentFields(op.Spec.RequestBody.Value.Content)
+			if err != nil {
+				return CassetteMatchers{}, fmt.Errorf("error finding the volatile fields of the request body of %s: %w", op.OperationId, err)
+			}
+		}
+		matchers.Operations = append(matchers.Operations, operation)
+		params = append(params, len(op.PathParams))
+	}
+
+	var lists []*[][]string
+	for i := range matchers.Operations {
+		lists = append(lists, &matchers.Operations[i].VolatileFields)
+	}
+	matchers.Schemas = finder.componentFields(lists...)
+
+	// The paths with fewer parameters are more specific, like /pets/mine
+	// rather than /pets/{id}
+	indexes := make([]int, len(matchers.Operations))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return params[indexes[i]] < params[indexes[j]]
+	})
+	operations := make([]CassetteOperation, len(indexes))
+	for i, index := range indexes {
+		operations[i] = matchers.Operations[index]
+	}
+	matchers.Operations = operations
+	return matchers, nil
+}
+
+// volatileParameter returns whether the values of a parameter are volatile,
+// from its x-volatile, or that of its schema.
+func volatileParameter(param *openapi3.Parameter) (bool, error) {
+	if param == nil {
+		return false, nil
+	}
+	if extension, ok := param.Extensions[extVolatile]; ok {
+		return extParseVolatile(extension)
+	}
+	if param.Schema == nil || param.Schema.Value == nil {
+		return false, nil
+	}
+	return volatileSchema(param.Schema.Value)
+}
+
+// volatileSchema returns whether the values of a schema are volatile, from
+// its x-volatile.
+func volatileSchema(schema *openapi3.Schema) (bool, error) {
+	if extension, ok := schema.Extensions[extVolatile]; ok {
+		return extParseVolatile(extension)
+	}
+	return false, nil
+}
@@ -0,0 +1,142 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// cliReservedFlags are the flags of the generated CLI which parameters can't
+// be named after.
+var cliReservedFlags = []string{"server", "output", "body", "content-type", "help"}
+
+// CLIContext is the data passed to the CLI template.
+type CLIContext struct {
+	Title    string       // The title of the spec, describing the root command
+	Server   string       // The URL of the first server of the spec, the default of --server
+	Commands []CLICommand // The subcommands running each operation
+}
+
+// CLICommand describes the subcommand of the generated CLI running an
+// operation.
+type CLICommand struct {
+	OperationDefinition
+	Name        string    // The kebab-case name of the subcommand, like find-pets
+	PathFlags   []CLIFlag // The flags of the path parameters, passed as arguments to the client
+	ParamFlags  []CLIFlag // The flags of the query, header and cookie parameters, set in the params struct
+	ContentType string    // The default content type of the body, for operations with one
+}
+
+// CLIFlag describes the flag setting a parameter of an operation.
+type CLIFlag struct {
+	Name  string // The name of the flag, the parameter name unless it's taken
+	Param ParameterDefinition
+}
+
+// Usage returns the help text of the flag, the first line of the description
+// of its parameter.
+func (f CLIFlag) Usage() string {
+	usage, _, _ := strings.Cut(strings.TrimSpace(f.Param.Spec.Description), "\n")
+	if usage == "" {
+		usage = fmt.Sprintf("the %s parameter %s", f.Param.In, f.Param.ParamName)
+	}
+	return usage
+}
+
+// DescribeCLICommands describes the subcommands running the operations,
+// naming the flags of their parameters after them, or after their location
+// and name when they're taken.
+func DescribeCLICommands(ops []OperationDefinition) ([]CLICommand, error) {
+	commands := make([]CLICommand, 0, len(ops))
+	names := make(map[string]string)
+	for _, op := range ops {
+		command := CLICommand{
+			OperationDefinition: op,
+			Name:                toKebabCase(op.OperationId),
+		}
+		if other, found := names[command.Name]; found {
+			return nil, fmt.Errorf("operations %s and %s have the same command name %s", other, op.OperationId, command.Name)
+		}
+		names[command.Name] = op.OperationId
+
+		taken := make(map[string]bool)
+		for _, name := range cliReservedFlags {
+			taken[name] = true
+		}
+		flag := func(param ParameterDefinition) (CLIFlag, error) {
+			name := param.ParamName
+			if taken[name] {
+				name = param.In + "-" + name
+			}
+			if taken[name] {
+				return CLIFlag{}, fmt.Errorf("the flag of the %s parameter %s of operation %s is taken", param.In, param.ParamName, op.OperationId)
+			}
+			taken[name] = true
+			return CLIFlag{Name: name, Param: param}, nil
+		}
+		for _, param := range op.PathParams {
+			f, err := flag(param)
+			if err != nil {
+				return nil, err
+			}
+			command.PathFlags = append(command.PathFlags, f)
+		}
+		for _, param := range op.Params() {
+			f, err := flag(param)
+			if err != nil {
+				return nil, err
+			}
+			command.ParamFlags = append(command.ParamFlags, f)
+		}
+
+		// JSON is the default, as it is for the client.
+		for _, body := range op.Bodies {
+			if command.ContentType == "" || body.Default {
+				command.ContentType = body.ContentType
+			}
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+// toKebabCase converts a camel case operation ID, like getHTTPStatus, to
+// kebab case, like get-http-status.
+func toKebabCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		if r == '_' || r == ' ' {
+			r = '-'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// GenerateCLI generates the cobra command line interface running the
+// operations with the client.
+func GenerateCLI(t *template.Template, spec *openapi3.T, ops []OperationDefinition) (string, error) {
+	commands, err := DescribeCLICommands(ops)
+	if err != nil {
+		return "", err
+	}
+	context := CLIContext{Commands: commands}
+	if spec.Info != nil {
+		context.Title = spec.Info.Title
+	}
+	if len(spec.Servers) > 0 {
+		context.Server = spec.Servers[0].URL
+	}
+	return GenerateTemplates([]string{"cli.tmpl"}, t, context)
+}
@@ -46,6 +46,48 @@ var globalState struct {
 	options       Configuration
 	spec          *openapi3.T
 	importMapping importMap
+	diagnostics   Diagnostics
+	// The names given to the inline schemas moved into the components by the
+	// promote-inline-schemas option.
+	syntheticNames []SyntheticName
+	// The schemas of XML bodies, whose types get xml tags.
+	xmlSchemas map[*openapi3.Schema]bool
+	// The properties whose fields are pointers, as their types contain the
+	// struct they're in.
+	recursiveProperties map[*openapi3.SchemaRef]bool
+	// The JSON Schemas exported with the json-schema option.
+	jsonSchemas []JSONSchemaFile
+	// The TypeScript declarations of the models, with the typescript option.
+	typeScript string
+	// The pages of the reference of the API, with the docs option.
+	docs []DocsFile
+	// The fixtures package, with the fixtures option.
+	fixtures string
+	// The generators package, with the generators option.
+	generators string
+	// The runtime file of the package, with the zero-dependencies option.
+	runtime string
+	// The skeleton of the implementation of the server, with the scaffold
+	// option.
+	scaffold Scaffold
+	// The URL which the client sends requests to a Unix domain socket to,
+	// when generated with WithUnixSocket.
+	unixSocketServer string
+	// How the client sends the version of the API, declared with
+	// x-api-version or the api-version option, if it does.
+	apiVersion *APIVersionDefinition
+	// What the code is generated from, with the provenance option.
+	provenance *ProvenanceDefinition
+	// The operations of the channels of the AsyncAPI documents, whose
+	// message payloads are among the components.
+	asyncOperations []AsyncOperation
+	// Whether the models have encrypted properties, which the clients and
+	// servers encrypt and decrypt the bodies of.
+	encrypted bool
+	// The types defined for the components, computed once for the parts of
+	// the output which look them up, like the self-test, the docs, the
+	// fixtures and the generators.
+	componentTypes []TypeDefinition
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -103,15 +145,98 @@ func constructImportMapping(importMapping map[string]string) importMap {
 // the descriptions we've built up above from the schema objects.
 // opts defines
 func Generate(spec *openapi3.T, opts Configuration) (string, error) {
+	code, _, err := GenerateWithDiagnostics(spec, opts)
+	return code, err
+}
+
+// GenerateWithDiagnostics works like Generate, and also returns the warnings
+// about the spec collected along the way. When problems with the spec prevent
+// generation, the error wraps the Diagnostics describing all of them.
+func GenerateWithDiagnostics(spec *openapi3.T, opts Configuration) (string, Diagnostics, error) {
+	output, err := GenerateOutput(spec, opts)
+	return output.Code, output.Diagnostics, err
+}
+
+// Output is everything generated for a spec.
+type Output struct {
+	Code        string           // The generated code
+	SelfTest    string           // The tests of the generated code, with the self-test, fuzz or contract-test options, to be written next to it in a _test.go file
+	JSONSchemas []JSONSchemaFile // The JSON Schemas of the component schemas, with the json-schema option, to be written next to the code
+	Docs        []DocsFile       // The pages of the Markdown reference of the API, with the docs option, to be written next to the code
+	TypeScript  string           // The TypeScript declarations of the models, with the typescript option, to be written next to the code in a .d.ts file
+	Fixtures    string           // The fixtures package, with the fixtures option, to be written to fixtures/fixtures.go next to the code
+	Generators  string           // The generators package, with the generators option, to be written to generators/generators.go next to the code
+	Runtime     string           // The helpers and types of the runtime packages the code uses, with the zero-dependencies option, to be written next to it in runtime.gen.go, or else the shared runtime package, with the shared-runtime option
+	Scaffold    Scaffold         // The skeleton of the implementation of the server, with the scaffold option, to be written next to the code once
+	Diagnostics Diagnostics      // The warnings about the spec collected while generating
+
+	SyntheticNames []SyntheticName // The names given to the inline schemas moved into the components, with the promote-inline-schemas option
+}
+
+// GenerateOutput works like GenerateWithDiagnostics, returning all that's
+// generated for the spec.
+func GenerateOutput(spec *openapi3.T, opts Configuration) (Output, error) {
+	globalState.diagnostics = nil
+	globalState.syntheticNames = nil
+	globalState.jsonSchemas = nil
+	globalState.typeScript = ""
+	globalState.docs = nil
+	globalState.fixtures = ""
+	globalState.generators = ""
+	globalState.runtime = ""
+	globalState.scaffold = Scaffold{}
+	util.RetainPropertyOrders(spec)
+	code, selfTest, err := generate(spec, opts)
+	// Templates executed concurrently report warnings in any order
+	diagnostics := globalState.diagnostics
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Path < diagnostics[j].Path
+	})
+	return Output{Code: code, SelfTest: selfTest, JSONSchemas: globalState.jsonSchemas, TypeScript: globalState.typeScript, Docs: globalState.docs, Fixtures: globalState.fixtures, Generators: globalState.generators, Runtime: globalState.runtime, Scaffold: globalState.scaffold, Diagnostics: diagnostics, SyntheticNames: globalState.syntheticNames}, err
+}
+
+func generate(spec *openapi3.T, opts Configuration) (string, string, error) {
+	if opts.OutputOptions.GoGenerate == GoGenerateAuto {
+		return "", "", fmt.Errorf("the go-generate command %q is only filled in by the oapi-codegen command", GoGenerateAuto)
+	}
 	// This is global state
 	globalState.options = opts
 	globalState.spec = spec
 	globalState.importMapping = constructImportMapping(opts.ImportMapping)
 
+	// The spec is hashed as given, before the changes below.
+	provenance, err := specProvenance(spec, opts)
+	if err != nil {
+		return "", "", err
+	}
+	globalState.provenance = provenance
+
+	if opts.OutputOptions.BundleExternalRefs {
+		bundleExternalRefs(spec, opts.ImportMapping)
+	}
+	if err := parsePrefixItems(spec); err != nil {
+		return "", "", err
+	}
+
 	filterOperationsByTag(spec, opts)
+	filterOperationsByOperationId(spec, opts)
+	if opts.OutputOptions.PromoteInlineSchemas || opts.OutputOptions.MaxInlineDepth != 0 {
+		promoteInlineSchemas(spec, opts.OutputOptions.MaxInlineDepth)
+	}
+	globalState.asyncOperations, err = asyncOperations(spec, opts)
+	if err != nil {
+		return "", "", fmt.Errorf("error loading the AsyncAPI messages: %w", err)
+	}
 	if !opts.OutputOptions.SkipPrune {
 		pruneUnusedComponents(spec)
 	}
+	globalState.xmlSchemas = xmlSchemas(spec)
+	globalState.encrypted = opts.Generate.Models && specEncrypted(spec)
+	recursive, err := recursiveProperties(spec)
+	if err != nil {
+		return "", "", fmt.Errorf("error resolving recursive schemas: %w", err)
+	}
+	globalState.recursiveProperties = recursive
 
 	// if we are provided an override for the response type suffix update it
 	if opts.OutputOptions.ResponseTypeSuffix != "" {
@@ -122,14 +247,29 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		globalState.options.OutputOptions.ClientTypeName = defaultClientTypeName
 	}
 
+	globalState.unixSocketServer, err = unixSocketServer(spec)
+	if err != nil {
+		return "", "", err
+	}
+	if globalState.unixSocketServer != "" {
+		globalState.options.OutputOptions.ClientUnixSocket = true
+	} else if globalState.options.OutputOptions.ClientUnixSocket {
+		globalState.unixSocketServer = defaultUnixSocketServer
+	}
+
+	globalState.apiVersion, err = specAPIVersion(spec, opts.OutputOptions.APIVersion)
+	if err != nil {
+		return "", "", err
+	}
+
 	// This creates the golang templates text package
 	TemplateFunctions["opts"] = func() Configuration { return globalState.options }
 	t := template.New("oapi-codegen").Funcs(TemplateFunctions)
 	// This parses all of our own template files into the template object
 	// above
-	err := LoadTemplates(templates, t)
+	err = LoadTemplates(templates, t)
 	if err != nil {
-		return "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
+		return "", "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
 	}
 
 	// load user-provided templates. Will Override built-in versions.
@@ -138,140 +278,399 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 
 		txt, err := GetUserTemplateText(template)
 		if err != nil {
-			return "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
+			return "", "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
 		}
 
 		_, err = utpl.Parse(txt)
 		if err != nil {
-			return "", fmt.Errorf("error parsing user-provided template %q: %w", name, err)
+			return "", "", fmt.Errorf("error parsing user-provided template %q: %w", name, err)
 		}
 	}
 
 	ops, err := OperationDefinitions(spec, opts.OutputOptions.InitialismOverrides)
 	if err != nil {
-		return "", fmt.Errorf("error creating operation definitions: %w", err)
+		return "", "", fmt.Errorf("error creating operation definitions: %w", err)
+	}
+
+	if diagnostics := checkOperations(ops, opts); len(diagnostics) > 0 {
+		return "", "", fmt.Errorf("error checking operations: %w", diagnostics)
 	}
 
 	xGoTypeImports, err := OperationImports(ops)
 	if err != nil {
-		return "", fmt.Errorf("error getting operation imports: %w", err)
+		return "", "", fmt.Errorf("error getting operation imports: %w", err)
 	}
 
-	var typeDefinitions, constantDefinitions string
 	if opts.Generate.Models {
-		typeDefinitions, err = GenerateTypeDefinitions(t, spec, ops, opts.OutputOptions.ExcludeSchemas)
+		imprts, err := GetTypeDefinitionsImports(spec, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
-			return "", fmt.Errorf("error generating type definitions: %w", err)
+			return "", "", fmt.Errorf("error getting type definition imports: %w", err)
 		}
+		MergeImports(xGoTypeImports, imprts)
+	}
 
-		constantDefinitions, err = GenerateConstants(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating constants: %w", err)
-		}
+	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
+	if base := opts.OutputOptions.ClientResponseBase; base != nil && base.Import != "" && opts.Generate.Client {
+		externalImports = append(externalImports, goImport{Name: base.PackageName(), Path: base.Import}.String())
+	}
 
-		imprts, err := GetTypeDefinitionsImports(spec, opts.OutputOptions.ExcludeSchemas)
-		if err != nil {
-			return "", fmt.Errorf("error getting type definition imports: %w", err)
+	globalState.componentTypes = nil
+	if opts.Generate.Models || opts.Generate.Docs || opts.Generate.Fixtures || opts.Generate.Generators {
+		if globalState.componentTypes, err = componentTypeDefinitions(t, spec, opts.OutputOptions.ExcludeSchemas); err != nil {
+			return "", "", err
 		}
-		MergeImports(xGoTypeImports, imprts)
+	}
+
+	// The parts of the output are rendered concurrently, and then written in
+	// a fixed order below.
+	var parts []func() error
+
+	var typeDefinitions, constantDefinitions string
+	if opts.Generate.Models {
+		parts = append(parts, func() (err error) {
+			typeDefinitions, err = GenerateTypeDefinitions(t, spec, ops, opts.OutputOptions.ExcludeSchemas)
+			if err != nil {
+				return fmt.Errorf("error generating type definitions: %w", err)
+			}
+			return nil
+		}, func() (err error) {
+			constantDefinitions, err = GenerateConstants(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating constants: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var irisServerOut string
 	if opts.Generate.IrisServer {
-		irisServerOut, err = GenerateIrisServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			irisServerOut, err = GenerateIrisServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var echoServerOut string
 	if opts.Generate.EchoServer {
-		echoServerOut, err = GenerateEchoServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			echoServerOut, err = GenerateEchoServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var chiServerOut string
 	if opts.Generate.ChiServer {
-		chiServerOut, err = GenerateChiServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			chiServerOut, err = GenerateChiServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var fiberServerOut string
 	if opts.Generate.FiberServer {
-		fiberServerOut, err = GenerateFiberServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			fiberServerOut, err = GenerateFiberServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var ginServerOut string
 	if opts.Generate.GinServer {
-		ginServerOut, err = GenerateGinServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			ginServerOut, err = GenerateGinServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var gorillaServerOut string
 	if opts.Generate.GorillaServer {
-		gorillaServerOut, err = GenerateGorillaServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			gorillaServerOut, err = GenerateGorillaServer(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var strictServerOut string
 	if opts.Generate.Strict {
-		var responses []ResponseDefinition
-		if spec.Components != nil {
-			responses, err = GenerateResponseDefinitions("", spec.Components.Responses)
+		parts = append(parts, func() error {
+			var responses []ResponseDefinition
+			if spec.Components != nil {
+				var err error
+				responses, err = GenerateResponseDefinitions("", spec.Components.Responses)
+				if err != nil {
+					return fmt.Errorf("error generation response definitions for schema: %w", err)
+				}
+			}
+			strictServerResponses, err := GenerateStrictResponses(t, responses)
 			if err != nil {
-				return "", fmt.Errorf("error generation response definitions for schema: %w", err)
+				return fmt.Errorf("error generation response definitions for schema: %w", err)
 			}
-		}
-		strictServerResponses, err := GenerateStrictResponses(t, responses)
-		if err != nil {
-			return "", fmt.Errorf("error generation response definitions for schema: %w", err)
-		}
-		strictServerOut, err = GenerateStrictServer(t, ops, opts)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
-		strictServerOut = strictServerResponses + strictServerOut
+			strictServerOut, err = GenerateStrictServer(t, ops, opts)
+			if err != nil {
+				return fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			}
+			strictServerOut = strictServerResponses + strictServerOut
+			return nil
+		})
 	}
 
 	var clientOut string
 	if opts.Generate.Client {
-		clientOut, err = GenerateClient(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating client: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			clientOut, err = GenerateClient(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating client: %w", err)
+			}
+			return nil
+		})
 	}
 
 	var clientWithResponsesOut string
 	if opts.Generate.Client {
-		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating client with responses: %w", err)
-		}
+		parts = append(parts, func() (err error) {
+			clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating client with responses: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var serverURLsOut string
+	if opts.Generate.ServerURLs {
+		parts = append(parts, func() (err error) {
+			serverURLsOut, err = GenerateServerURLs(t, spec, ops)
+			if err != nil {
+				return fmt.Errorf("error generating server URLs: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var apiVersionOut string
+	if globalState.apiVersion != nil {
+		parts = append(parts, func() (err error) {
+			apiVersionOut, err = GenerateAPIVersion(t)
+			if err != nil {
+				return fmt.Errorf("error generating API version: %w", err)
+			}
+			return nil
+		})
 	}
 
+	var provenanceOut string
+	if globalState.provenance != nil {
+		parts = append(parts, func() (err error) {
+			provenanceOut, err = GenerateProvenance(t)
+			if err != nil {
+				return fmt.Errorf("error generating provenance: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var operationIDsOut string
+	if opts.Generate.Routes || (opts.Generate.Client && opts.OutputOptions.ClientHooks) {
+		parts = append(parts, func() (err error) {
+			operationIDsOut, err = GenerateOperationIDs(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating operation IDs: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var problemOut string
+	if opts.OutputOptions.ProblemResponses {
+		parts = append(parts, func() (err error) {
+			problemOut, err = GenerateProblem(t, spec)
+			if err != nil {
+				return fmt.Errorf("error generating problem responses: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var operationContextOut string
+	if opts.OutputOptions.OperationContext {
+		parts = append(parts, func() (err error) {
+			operationContextOut, err = GenerateTemplates([]string{"operation-context.tmpl"}, t, nil)
+			if err != nil {
+				return fmt.Errorf("error generating operation context: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var headerParamsOut string
+	if (opts.Generate.ChiServer || opts.Generate.GorillaServer || opts.Generate.EchoServer || opts.Generate.GinServer || opts.Generate.FiberServer || opts.Generate.IrisServer) && hasObjectHeaderParams(ops) {
+		parts = append(parts, func() (err error) {
+			if err := checkGoVersion(opts.OutputOptions.GoVersion, "object header parameters", 18, "strings.Cut"); err != nil {
+				return err
+			}
+			headerParamsOut, err = GenerateTemplates([]string{"header-params.tmpl"}, t, nil)
+			if err != nil {
+				return fmt.Errorf("error generating header params: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var paramErrorsOut string
+	if opts.OutputOptions.ParamErrors {
+		parts = append(parts, func() (err error) {
+			paramErrorsOut, err = GenerateTemplates([]string{"param-errors.tmpl"}, t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating param errors: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var routesOut string
+	if opts.Generate.Routes {
+		parts = append(parts, func() (err error) {
+			routesOut, err = GenerateRoutes(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating routes: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var securityMiddlewareOut string
+	if opts.Generate.SecurityMiddleware {
+		parts = append(parts, func() (err error) {
+			securityMiddlewareOut, err = GenerateSecurityMiddleware(t, spec, ops)
+			if err != nil {
+				return fmt.Errorf("error generating security middleware: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var cliOut string
+	if opts.Generate.CLI {
+		parts = append(parts, func() (err error) {
+			cliOut, err = GenerateCLI(t, spec, ops)
+			if err != nil {
+				return fmt.Errorf("error generating CLI: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var requestBuildersOut string
+	if opts.Generate.RequestBuilders && !opts.Generate.Client {
+		parts = append(parts, func() (err error) {
+			requestBuildersOut, err = GenerateRequestBuilders(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating request builders: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var pathBuildersOut string
+	if opts.Generate.PathBuilders {
+		parts = append(parts, func() (err error) {
+			pathBuildersOut, err = GeneratePathBuilders(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating path builders: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var selfTestOut string
+	if opts.Generate.SelfTest || opts.Generate.Fuzz || opts.Generate.ContractTest {
+		parts = append(parts, func() (err error) {
+			selfTestOut, err = GenerateSelfTest(t, spec, ops, externalImports)
+			if err != nil {
+				return fmt.Errorf("error generating self-test: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if opts.Generate.Docs {
+		parts = append(parts, func() (err error) {
+			globalState.docs, err = GenerateDocs(t, spec, ops)
+			if err != nil {
+				return fmt.Errorf("error generating the reference: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if opts.Generate.Fixtures {
+		parts = append(parts, func() (err error) {
+			globalState.fixtures, err = GenerateFixtures(t, spec, ops)
+			if err != nil {
+				return fmt.Errorf("error generating fixtures: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if opts.Generate.Generators {
+		parts = append(parts, func() (err error) {
+			globalState.generators, err = GenerateGenerators(t, spec)
+			if err != nil {
+				return fmt.Errorf("error generating generators: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if opts.Generate.Scaffold {
+		parts = append(parts, func() (err error) {
+			globalState.scaffold, err = GenerateScaffold(t, ops)
+			if err != nil {
+				return fmt.Errorf("error generating scaffold: %w", err)
+			}
+			return nil
+		})
+	}
+
+	err = runParallel(len(parts), func(i int) error {
+		return parts[i]()
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	// Inlining the spec internalizes its references, changing it, so this
+	// comes once the other parts are done.
 	var inlinedSpec string
 	if opts.Generate.EmbeddedSpec {
 		inlinedSpec, err = GenerateInlinedSpec(t, globalState.importMapping, spec)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return "", "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
 	var buf bytes.Buffer
 	w := bufio.NewWriter(&buf)
 
-	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
 	importsOut, err := GenerateImports(
 		t,
 		externalImports,
@@ -279,39 +678,116 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		opts.NoVCSVersionOverride,
 	)
 	if err != nil {
-		return "", fmt.Errorf("error generating imports: %w", err)
+		return "", "", fmt.Errorf("error generating imports: %w", err)
 	}
 
 	_, err = w.WriteString(importsOut)
 	if err != nil {
-		return "", fmt.Errorf("error writing imports: %w", err)
+		return "", "", fmt.Errorf("error writing imports: %w", err)
+	}
+
+	_, err = w.WriteString(provenanceOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing provenance: %w", err)
 	}
 
 	_, err = w.WriteString(constantDefinitions)
 	if err != nil {
-		return "", fmt.Errorf("error writing constants: %w", err)
+		return "", "", fmt.Errorf("error writing constants: %w", err)
+	}
+
+	_, err = w.WriteString(apiVersionOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing API version: %w", err)
+	}
+
+	_, err = w.WriteString(operationIDsOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing operation IDs: %w", err)
 	}
 
 	_, err = w.WriteString(typeDefinitions)
 	if err != nil {
-		return "", fmt.Errorf("error writing type definitions: %w", err)
+		return "", "", fmt.Errorf("error writing type definitions: %w", err)
+	}
+
+	if opts.Generate.ServerURLs {
+		_, err = w.WriteString(serverURLsOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing server URLs: %w", err)
+		}
+	}
+
+	if opts.Generate.SecurityMiddleware {
+		_, err = w.WriteString(securityMiddlewareOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing security middleware: %w", err)
+		}
+	}
+
+	if opts.Generate.Routes {
+		_, err = w.WriteString(routesOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing routes: %w", err)
+		}
 	}
 
 	if opts.Generate.Client {
 		_, err = w.WriteString(clientOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
+			return "", "", fmt.Errorf("error writing client: %w", err)
 		}
 		_, err = w.WriteString(clientWithResponsesOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
+			return "", "", fmt.Errorf("error writing client: %w", err)
 		}
 	}
 
+	if opts.Generate.CLI {
+		_, err = w.WriteString(cliOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing CLI: %w", err)
+		}
+	}
+
+	if requestBuildersOut != "" {
+		_, err = w.WriteString(requestBuildersOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing request builders: %w", err)
+		}
+	}
+
+	if opts.Generate.PathBuilders {
+		_, err = w.WriteString(pathBuildersOut)
+		if err != nil {
+			return "", "", fmt.Errorf("error writing path builders: %w", err)
+		}
+	}
+
+	_, err = w.WriteString(problemOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing problem responses: %w", err)
+	}
+
+	_, err = w.WriteString(operationContextOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing operation context: %w", err)
+	}
+
+	_, err = w.WriteString(headerParamsOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing header params: %w", err)
+	}
+
+	_, err = w.WriteString(paramErrorsOut)
+	if err != nil {
+		return "", "", fmt.Errorf("error writing param errors: %w", err)
+	}
+
 	if opts.Generate.IrisServer {
 		_, err = w.WriteString(irisServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 
 	}
@@ -319,98 +795,140 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.EchoServer {
 		_, err = w.WriteString(echoServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.ChiServer {
 		_, err = w.WriteString(chiServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.FiberServer {
 		_, err = w.WriteString(fiberServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.GinServer {
 		_, err = w.WriteString(ginServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.GorillaServer {
 		_, err = w.WriteString(gorillaServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.Strict {
 		_, err = w.WriteString(strictServerOut)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return "", "", fmt.Errorf("error writing server path handlers: %w", err)
 		}
 	}
 
 	if opts.Generate.EmbeddedSpec {
 		_, err = w.WriteString(inlinedSpec)
 		if err != nil {
-			return "", fmt.Errorf("error writing inlined spec: %w", err)
+			return "", "", fmt.Errorf("error writing inlined spec: %w", err)
 		}
 	}
 
 	err = w.Flush()
 	if err != nil {
-		return "", fmt.Errorf("error flushing output buffer: %w", err)
+		return "", "", fmt.Errorf("error flushing output buffer: %w", err)
 	}
 
 	// remove any byte-order-marks which break Go-Code
 	goCode := SanitizeCode(buf.String())
+	selfTestOut = SanitizeCode(selfTestOut)
+
+	if opts.Generate.Client && opts.OutputOptions.ClientServices {
+		if err := checkClientServices(goCode, ops); err != nil {
+			return "", "", err
+		}
+	}
+
+	if opts.Generate.Scaffold {
+		if err := checkScaffold(goCode); err != nil {
+			return "", "", err
+		}
+	}
 
 	// The generation code produces unindented horrors. Use the Go Imports
 	// to make it all pretty.
 	if opts.OutputOptions.SkipFmt {
-		return goCode, nil
+		return goCode, selfTestOut, nil
 	}
 
 	outBytes, err := imports.Process(opts.PackageName+".go", []byte(goCode), nil)
 	if err != nil {
-		return "", fmt.Errorf("error formatting Go code %s: %w", goCode, err)
+		return "", "", fmt.Errorf("error formatting Go code %s: %w", goCode, err)
 	}
-	return string(outBytes), nil
-}
-
-func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
-	var allTypes []TypeDefinition
-	if swagger.Components != nil {
-		schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component schemas: %w", err)
+	if goVersionAtLeast(18) {
+		if outBytes, err = useAny(outBytes); err != nil {
+			return "", "", fmt.Errorf("error replacing the empty interfaces: %w", err)
 		}
-
-		paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component parameters: %w", err)
+	}
+	runtimeUses := make(map[string]bool)
+	runtimeTarget, _ := sharedRuntimeTarget()
+	if opts.OutputOptions.ZeroDependencies {
+		if outBytes, err = dropRuntimeImports(outBytes, runtimeTarget, runtimeUses); err != nil {
+			return "", "", err
 		}
-		allTypes = append(schemaTypes, paramTypes...)
-
-		responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component responses: %w", err)
+		if err = checkStandardImports(outBytes, runtimeTarget.importPath); err != nil {
+			return "", "", err
 		}
-		allTypes = append(allTypes, responseTypes...)
-
-		bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
+	}
+	if outBytes, err = rewriteImports(outBytes); err != nil {
+		return "", "", fmt.Errorf("error rewriting the imports: %w", err)
+	}
+	if selfTestOut != "" {
+		testBytes, err := imports.Process(opts.PackageName+"_test.go", []byte(selfTestOut), nil)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component request bodies: %w", err)
+			return "", "", fmt.Errorf("error formatting self-test %s: %w", selfTestOut, err)
 		}
-		allTypes = append(allTypes, bodyTypes...)
+		if goVersionAtLeast(18) {
+			if testBytes, err = useAny(testBytes); err != nil {
+				return "", "", fmt.Errorf("error replacing the empty interfaces of the self-test: %w", err)
+			}
+		}
+		if opts.OutputOptions.ZeroDependencies {
+			if testBytes, err = dropRuntimeImports(testBytes, runtimeTarget, runtimeUses); err != nil {
+				return "", "", fmt.Errorf("error in the self-test: %w", err)
+			}
+			if err = checkStandardImports(testBytes, runtimeTarget.importPath); err != nil {
+				return "", "", fmt.Errorf("error in the self-test: %w", err)
+			}
+		}
+		if testBytes, err = rewriteImports(testBytes); err != nil {
+			return "", "", fmt.Errorf("error rewriting the imports of the self-test: %w", err)
+		}
+		selfTestOut = string(testBytes)
+	}
+	if opts.OutputOptions.ZeroDependencies {
+		if globalState.runtime, err = GenerateRuntime(t, runtimeUses, outBytes); err != nil {
+			return "", "", err
+		}
+	}
+	return string(outBytes), selfTestOut, nil
+}
+
+func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
+	allTypes, err := componentTypeDefinitions(t, swagger, excludeSchemas)
+	if err != nil {
+		return "", err
+	}
+	page, err := declarePages(allTypes)
+	if err != nil {
+		return "", err
 	}
 
 	// Go through all operations, and add their types to allTypes, so that we can
@@ -452,10 +970,205 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []Op
 		return "", fmt.Errorf("error generating boilerplate for union types with additionalProperties: %w", err)
 	}
 
-	typeDefinitions := strings.Join([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate}, "")
+	timeFormatBoilerplate, err := GenerateTimeFormatBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating time format boilerplate: %w", err)
+	}
+
+	mapValidation, err := GenerateMapValidation(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating map validation: %w", err)
+	}
+
+	structValidation, err := GenerateStructValidation(t, allTypes, ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating struct validation: %w", err)
+	}
+
+	tupleBoilerplate, err := GenerateTupleBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating tuple boilerplate: %w", err)
+	}
+
+	redaction, err := GenerateRedaction(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating redaction: %w", err)
+	}
+
+	encryption, err := GenerateEncryption(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating encryption: %w", err)
+	}
+
+	// The types, and functions, which options generate with the code.
+	outputOptions := globalState.options.OutputOptions
+	generatedTypes := []struct {
+		name     string
+		template string
+		enabled  bool
+	}{
+		{"Date", "date.tmpl", outputOptions.TimeTypes.Date == "date"},
+		{nullableTypeName, "nullable.tmpl", outputOptions.NullableCollections},
+		{"Decimal", "decimal.tmpl", outputOptions.DecimalType != ""},
+		{"", "json-number.tmpl", outputOptions.JsonNumber},
+		{"URL", "url.tmpl", formatMapped(outputOptions.FormatMapping, "URL")},
+		{"Email", "email.tmpl", formatMapped(outputOptions.FormatMapping, "Email")},
+	}
+	var generatedOut []string
+	for _, generated := range generatedTypes {
+		if !generated.enabled {
+			continue
+		}
+		for _, typ := range enumTypes {
+			if generated.name != "" && typ.TypeName == generated.name {
+				return "", fmt.Errorf("the generated %s type collides with the %s type of the spec, which x-go-type-name can rename", generated.name, generated.name)
+			}
+		}
+		out, err := GenerateTemplates([]string{generated.template}, t, nil)
+		if err != nil {
+			return "", fmt.Errorf("error generating %s: %w", generated.template, err)
+		}
+		generatedOut = append(generatedOut, out)
+	}
+
+	if globalState.options.Generate.TerraformModels {
+		terraformOut, err := GenerateTerraformModels(t, allTypes)
+		if err != nil {
+			return "", fmt.Errorf("error generating Terraform models: %w", err)
+		}
+		generatedOut = append(generatedOut, terraformOut)
+	}
+
+	if globalState.options.OutputOptions.DbTags != "" {
+		dbTablesOut, err := GenerateDbTables(t, allTypes)
+		if err != nil {
+			return "", fmt.Errorf("error generating table names: %w", err)
+		}
+		generatedOut = append(generatedOut, dbTablesOut)
+	}
+
+	pageOut, err := GeneratePage(t, page)
+	if err != nil {
+		return "", fmt.Errorf("error generating the page type: %w", err)
+	}
+	generatedOut = append(generatedOut, pageOut)
+
+	interfacesOut, err := GenerateInterfaces(t, allTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating interfaces: %w", err)
+	}
+	generatedOut = append(generatedOut, interfacesOut)
+
+	conversionsOut, err := GenerateConversions(t, allTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating conversions: %w", err)
+	}
+	generatedOut = append(generatedOut, conversionsOut)
+
+	patchOut, err := GeneratePatchBoilerplate(t, ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating patch types: %w", err)
+	}
+	generatedOut = append(generatedOut, patchOut)
+
+	hotPathOut, err := GenerateHotPathMethods(t, allTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating hot-path marshalers: %w", err)
+	}
+	generatedOut = append(generatedOut, hotPathOut)
+
+	if globalState.options.Generate.JSONSchema {
+		files, err := ExportJSONSchemas(swagger, excludeSchemas)
+		if err != nil {
+			return "", fmt.Errorf("error exporting JSON Schemas: %w", err)
+		}
+		registryOut, err := GenerateJSONSchemaRegistry(t, files)
+		if err != nil {
+			return "", fmt.Errorf("error generating the JSON Schema registry: %w", err)
+		}
+		globalState.jsonSchemas = files
+		generatedOut = append(generatedOut, registryOut)
+	}
+
+	if globalState.options.Generate.DeepCopy {
+		deepCopyOut, err := GenerateDeepCopyMethods(t, enumTypes)
+		if err != nil {
+			return "", fmt.Errorf("error generating DeepCopy and Equal methods: %w", err)
+		}
+		generatedOut = append(generatedOut, deepCopyOut)
+	}
+
+	if globalState.options.Generate.KubernetesCRD {
+		kubernetesOut, err := GenerateKubernetesObjects(t, enumTypes)
+		if err != nil {
+			return "", fmt.Errorf("error generating Kubernetes objects: %w", err)
+		}
+		generatedOut = append(generatedOut, kubernetesOut)
+	}
+
+	if generate := globalState.options.Generate; generate.Constructors || generate.Builders {
+		constructorsOut, err := GenerateConstructors(t, enumTypes, ops, generate.Constructors, generate.Builders)
+		if err != nil {
+			return "", fmt.Errorf("error generating constructors: %w", err)
+		}
+		generatedOut = append(generatedOut, constructorsOut)
+	}
+
+	if globalState.options.Generate.TypeScript {
+		globalState.typeScript = GenerateTypeScript(enumTypes)
+	}
+
+	asyncOut, err := GenerateAsyncHelpers(t, globalState.asyncOperations)
+	if err != nil {
+		return "", fmt.Errorf("error generating the AsyncAPI helpers: %w", err)
+	}
+	generatedOut = append(generatedOut, asyncOut)
+
+	typeDefinitions := strings.Join(append([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate, timeFormatBoilerplate, mapValidation, structValidation, tupleBoilerplate, redaction, encryption}, generatedOut...), "")
 	return typeDefinitions, nil
 }
 
+// formatMapped returns whether the format mapping maps a format to goType.
+func formatMapped(formatMapping map[string]string, goType string) bool {
+	for _, mapped := range formatMapping {
+		if mapped == goType {
+			return true
+		}
+	}
+	return false
+}
+
+// componentTypeDefinitions returns the types defined for the components of the
+// spec.
+func componentTypeDefinitions(t *template.Template, swagger *openapi3.T, excludeSchemas []string) ([]TypeDefinition, error) {
+	var allTypes []TypeDefinition
+	if swagger.Components != nil {
+		schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component schemas: %w", err)
+		}
+
+		paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component parameters: %w", err)
+		}
+		allTypes = append(schemaTypes, paramTypes...)
+
+		responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component responses: %w", err)
+		}
+		allTypes = append(allTypes, responseTypes...)
+
+		bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component request bodies: %w", err)
+		}
+		allTypes = append(allTypes, bodyTypes...)
+	}
+	return allTypes, nil
+}
+
 // GenerateConstants generates operation ids, context keys, paths, etc. to be exported as constants
 func GenerateConstants(t *template.Template, ops []OperationDefinition) (string, error) {
 	constants := Constants{
@@ -489,31 +1202,44 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 	for _, schema := range excludeSchemas {
 		excludeSchemasMap[schema] = true
 	}
-	types := make([]TypeDefinition, 0)
-	// We're going to define Go types for every object under components/schemas
+	var schemaNames []string
 	for _, schemaName := range SortedSchemaKeys(schemas) {
-		if _, ok := excludeSchemasMap[schemaName]; ok {
-			continue
+		if _, ok := excludeSchemasMap[schemaName]; !ok {
+			schemaNames = append(schemaNames, schemaName)
 		}
+	}
+
+	// We're going to define Go types for every object under components/schemas,
+	// translating the schemas concurrently.
+	schemaTypes := make([][]TypeDefinition, len(schemaNames))
+	err := runParallel(len(schemaNames), func(i int) error {
+		schemaName := schemaNames[i]
 		schemaRef := schemas[schemaName]
 
 		goSchema, err := GenerateGoSchema(schemaRef, []string{schemaName})
 		if err != nil {
-			return nil, fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
+			return fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
 		}
 
 		goTypeName, err := renameSchema(schemaName, schemaRef)
 		if err != nil {
-			return nil, fmt.Errorf("error making name for components/schemas/%s: %w", schemaName, err)
+			return fmt.Errorf("error making name for components/schemas/%s: %w", schemaName, err)
 		}
 
-		types = append(types, TypeDefinition{
+		schemaTypes[i] = append([]TypeDefinition{{
 			JsonName: schemaName,
 			TypeName: goTypeName,
 			Schema:   goSchema,
-		})
+		}}, goSchema.GetAdditionalTypeDefs()...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		types = append(types, goSchema.GetAdditionalTypeDefs()...)
+	types := make([]TypeDefinition, 0)
+	for _, typeDefs := range schemaTypes {
+		types = append(types, typeDefs...)
 	}
 	return types, nil
 }
@@ -697,6 +1423,39 @@ func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error)
 }
 
 func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error) {
+	enums := enumDefinitions(types)
+	if globalState.options.OutputOptions.UnknownEnumValues {
+		if err := checkUnknownEnumConstructors(enums, types); err != nil {
+			return "", err
+		}
+	}
+	return GenerateTemplates([]string{"constants.tmpl"}, t, Constants{EnumDefinitions: enums})
+}
+
+// checkUnknownEnumConstructors fails if the Unknown constructor of an enum,
+// with the unknown-enum-values output option, has the name of a type or of an
+// enum constant.
+func checkUnknownEnumConstructors(enums []EnumDefinition, types []TypeDefinition) error {
+	declared := make(map[string]bool)
+	for _, tp := range types {
+		declared[tp.TypeName] = true
+	}
+	for _, enum := range enums {
+		for name := range enum.GetValues() {
+			declared[name] = true
+		}
+	}
+	for _, enum := range enums {
+		if name := "Unknown" + enum.TypeName; declared[name] {
+			return fmt.Errorf("unknown-enum-values: the constructor %s of the enum %s collides with a type or an enum constant, which x-go-type-name or x-enum-varnames can rename", name, enum.TypeName)
+		}
+	}
+	return nil
+}
+
+// enumDefinitions returns the enums of the given types, with the names of
+// their values made unique.
+func enumDefinitions(types []TypeDefinition) []EnumDefinition {
 	enums := []EnumDefinition{}
 
 	// Keep track of which enums we've generated
@@ -769,15 +1528,37 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 
 	// Now see if enums conflict with any non-enum typenames
 
-	return GenerateTemplates([]string{"constants.tmpl"}, t, Constants{EnumDefinitions: enums})
+	return enums
 }
 
 // GenerateImports generates our import statements and package definition.
 func GenerateImports(t *template.Template, externalImports []string, packageName string, versionOverride *string) (string, error) {
-	// Read build version for incorporating into generated files
+	modulePath, moduleVersion := generatorVersion(versionOverride)
+
+	context := struct {
+		ExternalImports   []string
+		PackageName       string
+		ModuleName        string
+		Version           string
+		AdditionalImports []AdditionalImport
+		Provenance        *ProvenanceDefinition
+	}{
+		ExternalImports:   externalImports,
+		PackageName:       packageName,
+		ModuleName:        modulePath,
+		Version:           moduleVersion,
+		AdditionalImports: globalState.options.AdditionalImports,
+		Provenance:        globalState.provenance,
+	}
+
+	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
+}
+
+// generatorVersion returns the module path and version of the generator, for
+// incorporating into generated files.
+func generatorVersion(versionOverride *string) (string, string) {
 	// Unit tests have ok=false, so we'll just use "unknown" for the
 	// version if we can't read this.
-
 	modulePath := "unknown module path"
 	moduleVersion := "unknown version"
 	if bi, ok := debug.ReadBuildInfo(); ok {
@@ -791,22 +1572,35 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 			moduleVersion = *versionOverride
 		}
 	}
+	return modulePath, moduleVersion
+}
+
+// GenerateTimeFormatBoilerplate generates the methods formatting and parsing
+// the times of the types declared with x-go-time-format.
+func GenerateTimeFormatBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+
+	m := map[string]bool{}
+
+	for _, t := range typeDefs {
+		if found := m[t.TypeName]; found {
+			continue
+		}
+
+		m[t.TypeName] = true
+
+		if t.Schema.TimeFormat != "" {
+			filteredTypes = append(filteredTypes, t)
+		}
+	}
 
 	context := struct {
-		ExternalImports   []string
-		PackageName       string
-		ModuleName        string
-		Version           string
-		AdditionalImports []AdditionalImport
+		Types []TypeDefinition
 	}{
-		ExternalImports:   externalImports,
-		PackageName:       packageName,
-		ModuleName:        modulePath,
-		Version:           moduleVersion,
-		AdditionalImports: globalState.options.AdditionalImports,
+		Types: filteredTypes,
 	}
 
-	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
+	return GenerateTemplates([]string{"time-format.tmpl"}, t, context)
 }
 
 // GenerateAdditionalPropertyBoilerplate generates all the glue code which provides
@@ -1073,6 +1867,13 @@ func GoSchemaImports(schemas ...*openapi3.SchemaRef) (map[string]goImport, error
 				}
 				MergeImports(res, imprts)
 			}
+			if schemaVal.AdditionalProperties.Schema != nil {
+				imprts, err := GoSchemaImports(schemaVal.AdditionalProperties.Schema)
+				if err != nil {
+					return nil, err
+				}
+				MergeImports(res, imprts)
+			}
 		case "array":
 			imprts, err := GoSchemaImports(schemaVal.Items)
 			if err != nil {
@@ -1100,6 +1901,12 @@ func GetSchemaImports(schemas map[string]*openapi3.SchemaRef, excludeSchemas []s
 			return nil, err
 		}
 		MergeImports(res, imprts)
+
+		imprts, err = equivalentImports(schema)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the imports of %s: %w", schemaName, err)
+		}
+		MergeImports(res, imprts)
 	}
 	return res, nil
 }
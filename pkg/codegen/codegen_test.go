@@ -2,7 +2,9 @@ package codegen
 
 import (
 	_ "embed"
+	"encoding/json"
 	"go/format"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -85,7 +87,7 @@ type GetTestByNameResponse struct {
 	assert.Contains(t, code, "Top *int `form:\"$top,omitempty\" json:\"$top,omitempty\"`")
 	assert.Contains(t, code, "func (c *Client) GetTestByName(ctx context.Context, name string, params *GetTestByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error) {")
 	assert.Contains(t, code, "func (c *ClientWithResponses) GetTestByNameWithResponse(ctx context.Context, name string, params *GetTestByNameParams, reqEditors ...RequestEditorFn) (*GetTestByNameResponse, error) {")
-	assert.Contains(t, code, "DeadSince *time.Time    `json:\"dead_since,omitempty\" tag1:\"value1\" tag2:\"value2\"`")
+	assert.Contains(t, code, "DeadSince *time.Time    `json:\"dead_since,omitempty\" tag1:\"value1\" tag2:\"value2\" xml:\"dead_since,omitempty\"`")
 	assert.Contains(t, code, "type EnumTestNumerics int")
 	assert.Contains(t, code, "N2 EnumTestNumerics = 2")
 	assert.Contains(t, code, "type EnumTestEnumNames int")
@@ -134,117 +136,3831 @@ func TestExtPropGoTypeSkipOptionalPointer(t *testing.T) {
 	assert.Contains(t, code, "RequiredField          string  `json:\"requiredField\"`")
 }
 
-func TestGoTypeImport(t *testing.T) {
-	packageName := "api"
+func TestStructTags(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/struct-tags.yaml")
+	require.NoError(t, err)
+
 	opts := Configuration{
-		PackageName: packageName,
+		PackageName: "api",
 		Generate: GenerateOptions{
-			EchoServer:   true,
-			Models:       true,
-			EmbeddedSpec: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:      true,
+			MirrorJsonTags: []string{"yaml", "db"},
 		},
 	}
-	spec := "test_specs/x-go-type-import-pet.yaml"
-	swagger, err := util.LoadSwagger(spec)
+	code, err := Generate(swagger, opts)
 	require.NoError(t, err)
 
-	// Run our code generation:
+	// The json tags are mirrored, and x-oapi-codegen-extra-tags take
+	// precedence over them
+	assert.Contains(t, code, "Name                 string            `db:\"name\" json:\"name\" yaml:\"name\"`")
+	assert.Contains(t, code, "Nickname             *string           `db:\"nickname,omitempty\" json:\"nickname,omitempty\" yaml:\"nickname,omitempty\"`")
+	assert.Contains(t, code, "Owner                *string           `db:\"owner_id\" json:\"owner,omitempty\" yaml:\"owner,omitempty\"`")
+	assert.Contains(t, code, "AdditionalProperties map[string]string `db:\"-\" json:\"-\" yaml:\"-\"`")
+
+	// x-omitempty: false keeps empty values, and x-go-json-ignore drops the
+	// field, including from the marshaling of additional properties
+	assert.Contains(t, code, "Age                  *int              `db:\"age\" json:\"age\" yaml:\"age\"`")
+	assert.Contains(t, code, "Secret               *string           `db:\"-\" json:\"-\" yaml:\"-\"`")
+	assert.Contains(t, code, "if a.Nickname != nil {")
+	assert.NotContains(t, code, "if a.Age != nil {")
+	assert.Contains(t, code, "delete(object, \"secret\")")
+	assert.NotContains(t, code, "object[\"secret\"], err = json.Marshal(a.Secret)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestXMLTags(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/xml.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	}
 	code, err := Generate(swagger, opts)
-	assert.NoError(t, err)
-	assert.NotEmpty(t, code)
+	require.NoError(t, err)
 
-	// Check that we have valid (formattable) code:
-	_, err = format.Source([]byte(code))
-	assert.NoError(t, err)
+	// The xml objects name, namespace and wrap the elements, or make
+	// attributes of them
+	assert.Contains(t, code, "XMLName   xml.Name  `json:\"-\" xml:\"pet\"`")
+	assert.Contains(t, code, "Id        int       `json:\"id\" xml:\"id,attr\"`")
+	assert.Contains(t, code, "Name      string    `json:\"name\" xml:\"https://example.com/schema name\"`")
+	assert.Contains(t, code, "PhotoUrls *[]string `json:\"photoUrls,omitempty\" xml:\"photos>photo,omitempty\"`")
+	assert.Contains(t, code, "Tags      *[]Tag    `json:\"tags,omitempty\" xml:\"tag,omitempty\"`")
+	assert.Contains(t, code, "Name *string `json:\"name,omitempty\" xml:\"name,omitempty\"`")
 
-	imports := []string{
-		`github.com/CavernaTechnologies/pgext`, // schemas - direct object
-		`myuuid "github.com/google/uuid"`,      // schemas - object
-		`github.com/lib/pq`,                    // schemas - array
-		`github.com/spf13/viper`,               // responses - direct object
-		`golang.org/x/text`,                    // responses - complex object
-		`golang.org/x/email`,                   // requestBodies - in components
-		`github.com/fatih/color`,               // parameters - query
-		`github.com/go-openapi/swag`,           // parameters - path
-		`github.com/jackc/pgtype`,              // direct parameters - path
-		`github.com/mailru/easyjson`,           // direct parameters - query
-		`github.com/subosito/gotenv`,           // direct request body
+	// Schemas only used with JSON don't get xml tags
+	assert.Contains(t, code, "Message string `json:\"message\"`")
+
+	// XML arrays are decoded from the element wrapping them
+	assert.Contains(t, code, "Items []Pet `xml:\",any\"`")
+	assert.Contains(t, code, "response.XML200 = &dest.Items")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+}
+
+func TestTimeTypes(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/time-types.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
 	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
 
-	// Check import
-	for _, imp := range imports {
-		assert.Contains(t, code, imp)
+	// The formats keep their types by default
+	assert.Contains(t, code, "CreatedAt       *time.Time         `json:\"createdAt,omitempty\"`")
+	assert.Contains(t, code, "Day             openapi_types.Date `json:\"day\"`")
+	assert.Contains(t, code, "OpensAt         *string            `json:\"opensAt,omitempty\"`")
+
+	// x-go-time-format declares types formatting times with its layout, in
+	// bodies and parameters
+	assert.Contains(t, code, "StartsAt        *EventStartsAt     `json:\"startsAt,omitempty\"`")
+	assert.Contains(t, code, "type LegacyTimestamp struct {\n\tTime time.Time\n}")
+	assert.Contains(t, code, "StartsAfter *ListEventsParamsStartsAfter `form:\"startsAfter,omitempty\" json:\"startsAfter,omitempty\"`")
+	assert.Contains(t, code, "parsed, err := time.Parse(\"2006-01-02 15:04\", string(data))")
+	assert.Contains(t, code, "return t.Time.Format(\"Jan _2 2006 15:04:05\")")
+	assert.Contains(t, code, "func (t *ListEventsParamsStartsAfter) Bind(src string) error {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The Date type generated with the code
+	opts.OutputOptions.TimeTypes = TimeTypesOptions{Date: "date"}
+	require.NoError(t, opts.Validate())
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Day             Date             `json:\"day\"`")
+	assert.Contains(t, code, "XNotBefore  *Date                        `json:\"X-Not-Before,omitempty\"`")
+	assert.Contains(t, code, "type Date struct {")
+	assert.Contains(t, code, "func (d Date) MarshalText() ([]byte, error) {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The civil types, whose query parameters aren't exploded so that they're
+	// bound from their text
+	opts.OutputOptions.TimeTypes = TimeTypesOptions{Date: "civil", DateTime: "civil", Time: "civil"}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "\"cloud.google.com/go/civil\"")
+	assert.Contains(t, code, "CreatedAt       *civil.DateTime  `json:\"createdAt,omitempty\"`")
+	assert.Contains(t, code, "Day             civil.Date       `json:\"day\"`")
+	assert.Contains(t, code, "OpensAt         *civil.Time      `json:\"opensAt,omitempty\"`")
+	assert.Contains(t, code, "runtime.BindQueryParameter(\"form\", false, false, \"since\", r.URL.Query(), &params.Since)")
+	assert.Contains(t, code, "runtime.BindQueryParameter(\"form\", true, false, \"startsAfter\", r.URL.Query(), &params.StartsAfter)")
+
+	// Unknown types are rejected
+	opts.OutputOptions.TimeTypes = TimeTypesOptions{DateTime: "unix"}
+	assert.ErrorContains(t, opts.Validate(), "unknown date-time type \"unix\"")
+}
+
+func TestDecimalAndJsonNumber(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/decimal.yaml")
+	require.NoError(t, err)
+
+	// Decimal numbers need a Decimal type
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+			Strict:    true,
+		},
 	}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "invalid number format: decimal")
+
+	// format: decimal and x-go-type: decimal map to it, in bodies and
+	// parameters
+	opts.OutputOptions.DecimalType = "shopspring"
+	opts.OutputOptions.JsonNumber = true
+	require.NoError(t, opts.Validate())
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "\"github.com/shopspring/decimal\"")
+	assert.Contains(t, code, "type Decimal struct {\n\tdecimal.Decimal\n}")
+	assert.Contains(t, code, "Tax                  *Decimal                `json:\"tax,omitempty\"`")
+	assert.Contains(t, code, "Total                Decimal                 `json:\"total\"`")
+	assert.Contains(t, code, "Discount *Decimal `form:\"discount,omitempty\" json:\"discount,omitempty\"`")
+
+	// The models, client and server unmarshal with json.Number
+	assert.Contains(t, code, "func unmarshalJSONNumbers(data []byte, v interface{}) error {")
+	assert.Contains(t, code, "err := unmarshalJSONNumbers(fieldBuf, &fieldVal)")
+	assert.Contains(t, code, "err := unmarshalJSONNumbers(t.union, &body)")
+	assert.Contains(t, code, "if err := unmarshalJSONNumbers(bodyBytes, &dest); err != nil {")
+	assert.Contains(t, code, "decoder.UseNumber()\n\tif err := decoder.Decode(&body); err != nil {")
+	assert.NotContains(t, code, "json.Unmarshal(")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	opts.OutputOptions.DecimalType = "big"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "\"math/big\"")
+	assert.Contains(t, code, "type Decimal struct {\n\tbig.Float\n}")
+
+	// Deep copies of them get mantissas of their own
+	opts.Generate.DeepCopy = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "out.Total.Float = *new(big.Float).Copy(&a.Total.Float)")
 
-	// Make sure the generated code is valid:
 	checkLint(t, "test.gen.go", []byte(code))
 
+	// Unknown decimal types are rejected
+	opts.OutputOptions.DecimalType = "float"
+	assert.ErrorContains(t, opts.Validate(), "unknown decimal type \"float\"")
 }
 
-func TestRemoteExternalReference(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test that interacts with the network")
+func TestFormatMapping(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/format-mapping.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
 	}
 
-	packageName := "api"
+	// Without the format mapping, the formats keep their default types
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Homepage string               `json:\"homepage\"`")
+	assert.Contains(t, code, "Id       openapi_types.UUID   `json:\"id\"`")
+	assert.NotContains(t, code, "type URL struct")
+
+	opts.OutputOptions.FormatMapping = map[string]string{
+		"uuid":  "uuid.UUID",
+		"uri":   "URL",
+		"ipv4":  "netip.Addr",
+		"ipv6":  "netip.Addr",
+		"email": "Email",
+	}
+	require.NoError(t, opts.Validate())
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "\"github.com/google/uuid\"")
+	assert.Contains(t, code, "\"net/netip\"")
+	assert.Contains(t, code, "Contact  *Email      `json:\"contact,omitempty\"`")
+	assert.Contains(t, code, "Homepage URL         `json:\"homepage\"`")
+	assert.Contains(t, code, "Id       uuid.UUID   `json:\"id\"`")
+	assert.Contains(t, code, "Ipv4     *netip.Addr `json:\"ipv4,omitempty\"`")
+
+	// The URL and Email types are generated with the code
+	assert.Contains(t, code, "type URL struct {\n\turl.URL\n}")
+	assert.Contains(t, code, "type Email string")
+
+	// The netip addresses, lacking runtime.Binder, are bound unexploded
+	assert.Contains(t, code, "runtime.BindQueryParameter(\"form\", false, false, \"address\", r.URL.Query(), &params.Address)")
+	assert.Contains(t, code, "runtime.BindQueryParameter(\"form\", true, false, \"contact\", r.URL.Query(), &params.Contact)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Formats mapped to no type are rejected
+	opts.OutputOptions.FormatMapping = map[string]string{"uri": ""}
+	assert.ErrorContains(t, opts.Validate(), "the format mapping of \"uri\" has no Go type")
+}
+
+func TestCLI(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/cli.yaml")
+	require.NoError(t, err)
+
 	opts := Configuration{
-		PackageName: packageName,
+		PackageName: "api",
 		Generate: GenerateOptions{
 			Models: true,
+			CLI:    true,
 		},
-		ImportMapping: map[string]string{
-			remoteRefFile: remoteRefImport,
+	}
+	assert.ErrorContains(t, opts.Validate(), "the CLI needs the client")
+
+	opts.Generate.Client = true
+	require.NoError(t, opts.Validate())
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "\"github.com/spf13/cobra\"")
+	assert.Contains(t, code, "root.PersistentFlags().String(\"server\", \"https://reports.example.com/v1\", \"the URL of the server\")")
+
+	// Each operation has a kebab-case subcommand
+	assert.Contains(t, code, "root.AddCommand(newUpdateHTTPReportCommand(newClient))")
+	assert.Contains(t, code, "Use:   \"update-http-report\",\n\t\tShort: \"Updates a report\",")
+
+	// The flags are named after the parameters, or their location when taken
+	assert.Contains(t, code, "cmd.Flags().String(\"id\", \"\", \"The ID of the report\")")
+	assert.Contains(t, code, "cmd.Flags().String(\"query-output\", \"\", \"the query parameter output\")\n\t_ = cmd.MarkFlagRequired(\"query-output\")")
+	assert.Contains(t, code, "cmd.Flags().String(\"header-id\", \"\", \"the header parameter id\")")
+	assert.Contains(t, code, "if err := bindCLIFlag(cmd, \"filter\", true, &value); err != nil {")
+
+	// The body is read from a file, as JSON by default
+	assert.Contains(t, code, "cmd.Flags().String(\"content-type\", \"application/json\", \"the content type of the request body\")\n\t_ = cmd.MarkFlagRequired(\"body\")")
+	assert.Contains(t, code, "rsp, err := client.UpdateHTTPReportWithBody(cmd.Context(), id, &params, contentType, body)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestToKebabCase(t *testing.T) {
+	assert.Equal(t, "find-pets", toKebabCase("FindPets"))
+	assert.Equal(t, "get-http-status", toKebabCase("GetHTTPStatus"))
+	assert.Equal(t, "find-pet-by-id", toKebabCase("FindPetByID"))
+	assert.Equal(t, "list-v2-items", toKebabCase("ListV2Items"))
+}
+
+func TestTerraformModels(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/terraform.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			TerraformModels: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
 		},
 	}
-	spec := "test_specs/remote-external-reference.yaml"
-	swagger, err := util.LoadSwagger(spec)
+	assert.ErrorContains(t, opts.Validate(), "the Terraform models need the models")
+
+	opts.Generate.Models = true
+	require.NoError(t, opts.Validate())
+	code, diagnostics, err := GenerateWithDiagnostics(swagger, opts)
 	require.NoError(t, err)
+	assert.Contains(t, code, "\"github.com/hashicorp/terraform-plugin-framework/types\"")
 
-	// Run our code generation:
+	// The attributes are named in snake case, with nested models for objects
+	assert.Contains(t, code, "type PetModel struct {")
+	assert.Contains(t, code, "NickName       types.String   `tfsdk:\"nick_name\"`")
+	assert.Contains(t, code, "Owner          *OwnerModel    `tfsdk:\"owner\"`")
+	assert.Contains(t, code, "PreviousOwners []OwnerModel   `tfsdk:\"previous_owners\"`")
+	assert.Contains(t, code, "Tags           []types.String `tfsdk:\"tags\"`")
+	assert.NotContains(t, code, "tfsdk:\"labels\"")
+	assert.NotContains(t, code, "type LabelsModel")
+
+	// The conversions go through the underlying types of enums, and parse
+	// dates and UUIDs
+	assert.Contains(t, code, "func NewPetModel(v Pet) PetModel {")
+	assert.Contains(t, code, "m.Kind = types.StringValue(string(v.Kind))")
+	assert.Contains(t, code, "m.Born = types.StringValue((*v.Born).Format(time.RFC3339Nano))")
+	assert.Contains(t, code, "func (m PetModel) ToAPI() (Pet, error) {")
+	assert.Contains(t, code, "result.Kind = Kind(m.Kind.ValueString())")
+	assert.Contains(t, code, "value, err := time.Parse(time.RFC3339Nano, m.Born.ValueString())")
+	assert.Contains(t, code, "return result, fmt.Errorf(\"invalid chip: %w\", err)")
+
+	// Properties without a Terraform type are left out, with a warning
+	require.Len(t, diagnostics, 1)
+	assert.True(t, diagnostics[0].Warning)
+	assert.Equal(t, "/components/schemas/Pet/properties/labels", diagnostics[0].Path)
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestDeepCopy(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/deep-copy.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			DeepCopy: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+	assert.ErrorContains(t, opts.Validate(), "the DeepCopy and Equal methods need the models")
+
+	opts.Generate.Models = true
+	require.NoError(t, opts.Validate())
 	code, err := Generate(swagger, opts)
-	assert.NoError(t, err)
-	assert.NotEmpty(t, code)
+	require.NoError(t, err)
 
-	// Check that we have valid (formattable) code:
-	_, err = format.Source([]byte(code))
-	assert.NoError(t, err)
+	// Every type but aliases has the methods, which those of the types made
+	// of it call
+	assert.Contains(t, code, "func (a Pet) DeepCopy() Pet {")
+	assert.Contains(t, code, "func (a Pet) Equal(other Pet) bool {")
+	assert.Contains(t, code, "func (a Kind) DeepCopy() Kind {\n\treturn a\n}")
+	assert.NotContains(t, code, "func (a Tags) DeepCopy()")
+	assert.Contains(t, code, "out.Owner = a.Owner.DeepCopy()")
+	assert.Contains(t, code, "if !a.Owner.Equal(other.Owner) {")
+	assert.Contains(t, code, "if a.Parent != nil && !a.Parent.Equal(*other.Parent) {")
 
-	// Check that we have a package:
-	assert.Contains(t, code, "package api")
+	// Pointers, slices and maps are copied, and compared by what they hold
+	assert.Contains(t, code, "out.Tags = append(a.Tags[:0:0], a.Tags...)")
+	assert.Contains(t, code, "m1 := make(map[string]*string, len(c0))")
+	assert.Contains(t, code, "c0[i1] = c0[i1].DeepCopy()")
+	assert.Contains(t, code, "if a.Nickname != nil && *a.Nickname != *other.Nickname {")
+	assert.Contains(t, code, "if !a.Born.Equal(other.Born) {")
+	assert.Contains(t, code, "if !bytes.Equal(a.Photo, other.Photo) {")
+	assert.Contains(t, code, "out.union = append(a.union[:0:0], a.union...)")
+	assert.Contains(t, code, "out.Rest = append(a.Rest[:0:0], a.Rest...)")
 
-	// Check import
-	assert.Contains(t, code, `externalRef0 "github.com/deepmap/oapi-codegen/examples/petstore-expanded"`)
+	// Values of unknown types are copied as JSON values, and compared by
+	// reflection
+	assert.Contains(t, code, "c0 = deepCopyJSON(c0)")
+	assert.Contains(t, code, "func deepCopyJSON(v interface{}) interface{} {")
+	assert.Contains(t, code, "if a.Anything != nil && !reflect.DeepEqual(*a.Anything, *other.Anything) {")
 
-	// Check generated oneOf structure:
-	assert.Contains(t, code, `
-// ExampleSchema_Item defines model for ExampleSchema.Item.
-type ExampleSchema_Item struct {
-	union json.RawMessage
+	checkLint(t, "test.gen.go", []byte(code))
 }
-`)
 
-	// Check generated oneOf structure As method:
-	assert.Contains(t, code, `
-// AsExternalRef0NewPet returns the union data inside the ExampleSchema_Item as a externalRef0.NewPet
-func (t ExampleSchema_Item) AsExternalRef0NewPet() (externalRef0.NewPet, error) {
-`)
+func TestKubernetesCRD(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/kubernetes.yaml")
+	require.NoError(t, err)
 
-	// Check generated oneOf structure From method:
-	assert.Contains(t, code, `
-// FromExternalRef0NewPet overwrites any union data inside the ExampleSchema_Item as the provided externalRef0.NewPet
-func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error {
-`)
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			DeepCopy:      true,
+			KubernetesCRD: true,
+		},
+	}
 
-	// Check generated oneOf structure Merge method:
-	assert.Contains(t, code, `
-// FromExternalRef0NewPet overwrites any union data inside the ExampleSchema_Item as the provided externalRef0.NewPet
-func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error {
-`)
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The resources are the roots of custom resources, with a status
+	// subresource when they have a status
+	assert.Contains(t, code, `// Widget defines model for Widget.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type Widget struct {`)
+	assert.Contains(t, code, `// WidgetList defines model for WidgetList.
+// +kubebuilder:object:root=true
+type WidgetList struct {`)
+
+	// The types and fields get the markers of the constraints of their schemas
+	assert.Contains(t, code, `// +kubebuilder:validation:Enum=red;green;blue
+type Color string`)
+	assert.Contains(t, code, `// +kubebuilder:validation:Enum=Pending;Running;"Shutting down"
+type WidgetStatusPhase string`)
+	assert.Contains(t, code, `	// +required
+	// +kubebuilder:validation:Format=int32
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Size int32 `+"`"+`json:"size"`+"`")
+	assert.Contains(t, code, "// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9-]*$`")
+	assert.Contains(t, code, "// +kubebuilder:validation:items:Maximum=65535")
+	assert.Contains(t, code, "// +kubebuilder:default=1")
+	assert.Contains(t, code, `	// +optional
+	Color *Color `+"`"+`json:"color,omitempty"`+"`")
+
+	// The resources implement runtime.Object
+	assert.Contains(t, code, "func (a *Widget) GetObjectKind() k8sschema.ObjectKind {")
+	assert.Contains(t, code, "return k8sschema.FromAPIVersionAndKind(a.ApiVersion, a.Kind)")
+	assert.Contains(t, code, "func (a *Widget) DeepCopyObject() k8sruntime.Object {")
+	assert.Contains(t, code, "a.ApiVersion = &apiVersion")
+
+	// Their metadata is copied with its own DeepCopy
+	assert.Contains(t, code, "out.Metadata = *a.Metadata.DeepCopy()")
+
+	// The resources without a list in the spec get one
+	assert.Contains(t, code, "// GadgetList is a list of Gadget resources.\n// +kubebuilder:object:root=true\ntype GadgetList struct {")
+	assert.Contains(t, code, "func (a *GadgetList) DeepCopyObject() k8sruntime.Object {")
+	assert.Contains(t, code, "out.Items[i] = a.Items[i].DeepCopy()")
+	assert.NotContains(t, code, "WidgetListList")
+	assert.Equal(t, 1, strings.Count(code, "type WidgetList struct {"))
+
+	// Patterns which can't be raw strings are quoted
+	assert.Contains(t, code, "// +kubebuilder:validation:Pattern=\"^`[a-z]+`$\"")
 
-	// Make sure the generated code is valid:
 	checkLint(t, "test.gen.go", []byte(code))
 
+	opts.Generate.DeepCopy = false
+	assert.EqualError(t, opts.Validate(), "the Kubernetes CRD types need the models and the DeepCopy methods")
 }
+func TestConstructors(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/constructors.yaml")
+	require.NoError(t, err)
 
-//go:embed test_spec.yaml
-var testOpenAPIDefinition string
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client:       true,
+			Constructors: true,
+			Builders:     true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+	assert.ErrorContains(t, opts.Validate(), "the constructors and builders need the models")
+
+	opts.Generate.Models = true
+	require.NoError(t, opts.Validate())
+	code, diagnostics, err := GenerateWithDiagnostics(swagger, opts)
+	require.NoError(t, err)
+
+	// Constructors take the required properties, named like parameters
+	assert.Contains(t, code, "func NewNewPet(name string, pType string) NewPet {")
+	assert.Contains(t, code, "func NewOwner(name string) Owner {")
+	assert.Contains(t, code, "func NewPoint(item0 float32, item1 float32) Point {")
+
+	// Builders set the optional ones
+	assert.Contains(t, code, "func NewOwnerBuilder(name string) *OwnerBuilder {")
+	assert.Contains(t, code, "func (b *OwnerBuilder) WithPets(value []Pet) *OwnerBuilder {")
+	assert.Contains(t, code, "func (b *NewPetBuilder) WithNickname(value string) *NewPetBuilder {")
+	assert.Contains(t, code, "b.value.Nickname = &value")
+	assert.Contains(t, code, "func (b *PointBuilder) WithItem2(value float32) *PointBuilder {")
+	assert.Contains(t, code, "func (b *NewPetBuilder) Build() NewPet {")
+
+	// Unions and enums have none
+	assert.NotContains(t, code, "func NewToy(")
+	assert.NotContains(t, code, "func NewKind(")
+
+	// Those whose names are taken are left out, with a warning
+	assert.NotContains(t, code, "func NewPet(")
+	assert.NotContains(t, code, "type PetBuilder struct")
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "/components/schemas/Pet", diagnostics[0].Path)
+	assert.Contains(t, diagnostics[0].Reason, "the NewPet constructor of Pet collides with another type or function")
+	assert.Contains(t, diagnostics[1].Reason, "the PetBuilder builder of Pet collides with another type or function")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestFieldOrder(t *testing.T) {
+	// structFields returns the names of the fields of a generated struct.
+	structFields := func(code, typeName string) []string {
+		start := strings.Index(code, "type "+typeName+" struct {\n")
+		require.NotEqual(t, -1, start, typeName)
+		body := code[start:]
+		body = body[strings.Index(body, "\n")+1 : strings.Index(body, "\n}")]
+		var fields []string
+		for _, line := range strings.Split(body, "\n") {
+			if strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "\t\t") && !strings.HasPrefix(line, "\t//") && !strings.HasPrefix(line, "\t}") {
+				fields = append(fields, strings.Fields(line)[0])
+			}
+		}
+		return fields
+	}
+
+	generate := func(fieldOrder string) string {
+		swagger, err := util.LoadSwagger("test_specs/field-order/spec.yaml")
+		require.NoError(t, err)
+		opts := Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				SkipPrune:  true,
+				FieldOrder: fieldOrder,
+			},
+		}
+		require.NoError(t, opts.Validate())
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+		checkLint(t, "test.gen.go", []byte(code))
+		return code
+	}
+
+	// Alphabetical by default
+	code := generate("")
+	assert.Equal(t, []string{"Age", "Kind", "Name", "Vaccinated", "Weight"}, structFields(code, "NewPet"))
+	assert.Equal(t, code, generate(FieldOrderAlphabetical))
+
+	// In the order of the spec, allOf parts one after the other, across documents
+	code = generate(FieldOrderSpec)
+	assert.Equal(t, []string{"Vaccinated", "Name", "Age", "Kind", "Weight"}, structFields(code, "NewPet"))
+	assert.Equal(t, []string{"Vaccinated", "Name", "Age", "Kind", "Weight", "Id", "Owner"}, structFields(code, "Pet"))
+	assert.Contains(t, code, "Verified bool   `json:\"verified\"`\n\t\tName     string `json:\"name\"`")
+
+	// By alignment, then alphabetically
+	code = generate(FieldOrderAlignment)
+	assert.Equal(t, []string{"Name", "Weight", "Kind", "Age", "Vaccinated"}, structFields(code, "NewPet"))
+	assert.Equal(t, []string{"Id", "Name", "Owner", "Weight", "Kind", "Age", "Vaccinated"}, structFields(code, "Pet"))
+
+	opts := Configuration{PackageName: "api", OutputOptions: OutputOptions{FieldOrder: "random"}}
+	assert.ErrorContains(t, opts.Validate(), `unknown field order "random"`)
+}
+
+func TestDbTags(t *testing.T) {
+	generate := func(dbTags string) (string, []Diagnostic) {
+		swagger, err := util.LoadSwagger("test_specs/db-tags.yaml")
+		require.NoError(t, err)
+		opts := Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				SkipPrune: true,
+				DbTags:    dbTags,
+			},
+		}
+		require.NoError(t, opts.Validate())
+		code, diagnostics, err := GenerateWithDiagnostics(swagger, opts)
+		require.NoError(t, err)
+		checkLint(t, "test.gen.go", []byte(code))
+		return code, diagnostics
+	}
+
+	code, diagnostics := generate(DbTagsGorm)
+	// Columns are named like the properties, unless x-db-column renames them
+	assert.Contains(t, code, "Id     int64      `gorm:\"column:id\" json:\"id\"`")
+	assert.Contains(t, code, "Name   string     `gorm:\"column:pet_name\" json:\"name\"`")
+	assert.Contains(t, code, "Cached *bool      `gorm:\"-\" json:\"cached,omitempty\"`")
+	// Objects and arrays are stored as JSON, enums and times aren't
+	assert.Contains(t, code, "Owner  *Owner     `gorm:\"column:owner;serializer:json\" json:\"owner,omitempty\"`")
+	assert.Contains(t, code, "Tags   *[]string  `gorm:\"column:tags;serializer:json\" json:\"tags,omitempty\"`")
+	assert.Contains(t, code, "Kind   *Kind      `gorm:\"column:kind\" json:\"kind,omitempty\"`")
+	assert.Contains(t, code, "BornAt *time.Time `gorm:\"column:bornAt\" json:\"bornAt,omitempty\"`")
+	assert.Contains(t, code, "AdditionalProperties map[string]string `gorm:\"-\" json:\"-\"`")
+	// Tables are named with x-db-table
+	assert.Contains(t, code, "func (Pet) TableName() string {\n\treturn \"pets\"\n}")
+	assert.Contains(t, code, "func (Owner) TableName() string {\n\treturn \"owners\"\n}")
+	assert.NotContains(t, code, "func (Broken) TableName()")
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "/components/schemas/Broken", diagnostics[0].Path)
+	assert.Contains(t, diagnostics[0].Reason, "isn't a table name")
+
+	code, _ = generate(DbTagsEnt)
+	assert.Contains(t, code, "Name   string     `json:\"name\" sql:\"pet_name\"`")
+	assert.Contains(t, code, "Owner  *Owner     `json:\"owner,omitempty\" sql:\"owner\"`")
+	assert.Contains(t, code, "func (Pet) TableName() string {")
+
+	opts := Configuration{PackageName: "api", OutputOptions: OutputOptions{DbTags: "sqlc"}}
+	assert.ErrorContains(t, opts.Validate(), `unknown db tags "sqlc"`)
+}
+
+func TestJSONSchema(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/json-schema.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			JSONSchema: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+	assert.ErrorContains(t, opts.Validate(), "the JSON Schema registry needs the models")
+
+	opts.Generate.Models = true
+	require.NoError(t, opts.Validate())
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(output.Code))
+
+	// One file per component schema, in the registry by type name
+	require.Len(t, output.JSONSchemas, 3)
+	assert.Equal(t, "Kind.schema.json", output.JSONSchemas[0].FileName)
+	assert.Contains(t, output.Code, "func JSONSchemas() JSONSchemaRegistry {")
+	assert.Contains(t, output.Code, `"Pet":   json.RawMessage(`+"`"+`{"$defs":`)
+
+	pet := output.JSONSchemas[2]
+	assert.Equal(t, "Pet", pet.TypeName)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(pet.Schema, &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "Pet.schema.json", schema["$id"])
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.NotContains(t, schema, "discriminator")
+	assert.NotContains(t, schema, "x-go-type-name")
+
+	properties := schema["properties"].(map[string]interface{})
+	// OpenAPI keywords translate to JSON Schema ones
+	assert.Equal(t, map[string]interface{}{"type": "integer", "exclusiveMinimum": 0.0}, properties["age"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}}, properties["nickname"])
+	assert.Equal(t, []interface{}{"Rex"}, properties["name"].(map[string]interface{})["examples"])
+	// References point into the $defs, or to the schema itself
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/Owner"}, properties["owner"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#"}, properties["parent"])
+	defs := schema["$defs"].(map[string]interface{})
+	assert.Len(t, defs, 2)
+	assert.Equal(t, []interface{}{"cat", "dog", nil}, defs["Kind"].(map[string]interface{})["enum"])
+}
+
+func TestResponseAccessors(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/response-accessors.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Successful responses of the same type
+	assert.Contains(t, code, `func (r AddPetResponse) Success() (Pet, bool) {
+	if r.JSON200 != nil {
+		return *r.JSON200, true
+	}
+	if r.JSON201 != nil {
+		return *r.JSON201, true
+	}
+	var zero Pet
+	return zero, false
+}`)
+	// Error responses, the default one when its status is an error
+	assert.Contains(t, code, `func (r AddPetResponse) Error() (Error, bool) {
+	if r.JSON404 != nil {
+		return *r.JSON404, true
+	}
+	if r.JSONDefault != nil && r.StatusCode() >= 400 {
+		return *r.JSONDefault, true
+	}
+	var zero Error
+	return zero, false
+}`)
+	// Successful responses of different types, and undecoded ones, have none
+	assert.NotContains(t, code, "func (r ListOwnersResponse) Success()")
+	assert.NotContains(t, code, "func (r ListOwnersResponse) Error()")
+	assert.Contains(t, code, "func (r ListOwnersResponse) StatusCode() int {")
+}
+
+func TestClientErrorTypes(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/response-accessors.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientErrorTypes: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Each step of a call fails with its own error type, matching a sentinel
+	for _, errType := range []string{"TransportError", "BodyReadError", "DecodeError", "APIError"} {
+		assert.Contains(t, code, "type "+errType+" struct {")
+	}
+	assert.Contains(t, code, "ErrTransport = errors.New(")
+	assert.Contains(t, code, "return nil, &TransportError{OperationID: \"AddPet\", Err: err}")
+	assert.Contains(t, code, "return nil, &BodyReadError{OperationID: \"AddPet\", Err: err}")
+	assert.Contains(t, code, "if err := json.Unmarshal(bodyBytes, &dest); err != nil {\n\t\t\treturn nil, newDecodeError(\"AddPet\", rsp, err)\n\t\t}")
+
+	// Error responses are returned along with an *APIError of their body
+	assert.Contains(t, code, "apiErr := &APIError{OperationID: \"AddPet\", StatusCode: rsp.StatusCode, Body: bodyBytes}\n\t\tif decoded, ok := response.Error(); ok {\n\t\t\tapiErr.Decoded = decoded\n\t\t}\n\t\treturn response, apiErr")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, errors are returned as they are
+	opts.OutputOptions.ClientErrorTypes = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "TransportError")
+	assert.NotContains(t, code, "APIError")
+}
+
+func TestStrictDecoding(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/strict-decoding.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Responses of schemas declared with x-strict-decoding, or of arrays of
+	// them, are decoded strictly while StrictDecoding is on
+	assert.Contains(t, code, "var StrictDecoding = false")
+	assert.Contains(t, code, "decoder.DisallowUnknownFields()")
+	assert.Contains(t, code, "var dest []Pet\n\t\tif err := unmarshalJSONStrict(bodyBytes, &dest); err != nil {")
+	assert.Contains(t, code, "var dest Owner\n\t\tif err := json.Unmarshal(bodyBytes, &dest); err != nil {")
+	// Looking into the types decoding themselves
+	assert.Contains(t, code, "return checkUnknownFields(value, reflect.ValueOf(v), \"\")")
+	assert.Contains(t, code, "var dest Catalog\n\t\tif err := unmarshalJSONStrict(bodyBytes, &dest); err != nil {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The option makes all of them strict
+	opts.OutputOptions.StrictDecoding = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "var dest Owner\n\t\tif err := unmarshalJSONStrict(bodyBytes, &dest); err != nil {")
+
+	// Without strict schemas, nothing is generated
+	opts.OutputOptions.StrictDecoding = false
+	delete(swagger.Components.Schemas["Pet"].Value.Extensions, extStrictDecoding)
+	delete(swagger.Components.Schemas["Catalog"].Value.Extensions, extStrictDecoding)
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "StrictDecoding")
+
+	swagger.Components.Schemas["Pet"].Value.Extensions[extStrictDecoding] = "yes"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-strict-decoding": failed to convert type: string`)
+}
+
+func TestClientResponseDecoding(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/response-accessors.yaml")
+	require.NoError(t, err)
+
+	generate := func(decoding string) string {
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Client: true,
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				ClientResponseDecoding: decoding,
+			},
+		})
+		require.NoError(t, err)
+		checkLint(t, "test.gen.go", []byte(code))
+		return code
+	}
+
+	// Eager decoding keeps the body
+	code := generate(ResponseDecodingEager)
+	assert.Contains(t, code, "response.JSON200 = &dest\n")
+	assert.NotContains(t, code, "response.Body = nil")
+	assert.NotContains(t, code, "DecodeJSON200")
+
+	// Dropping the body once decoded
+	code = generate(ResponseDecodingDropBody)
+	assert.Contains(t, code, `response.JSON200 = &dest
+		response.Body = nil`)
+
+	// Lazy decoding leaves it to the Decode methods
+	code = generate(ResponseDecodingLazy)
+	assert.Contains(t, code, `func (r *AddPetResponse) DecodeJSON200() (*Pet, error) {
+	if _, err := r.decode(); err != nil {
+		return nil, err
+	}
+	return r.JSON200, nil
+}`)
+	assert.Contains(t, code, "func (r *ListOwnersResponse) DecodeJSON200() (*[]string, error) {")
+	assert.Contains(t, code, "func (r *AddPetResponse) decode() (*AddPetResponse, error) {")
+	assert.NotContains(t, code, "func (r AddPetResponse) Success()")
+	parse := code[strings.Index(code, "func ParseAddPetResponse("):]
+	parse = parse[:strings.Index(parse, "\n}\n")]
+	assert.NotContains(t, parse, "json.Unmarshal")
+
+	swagger, err = util.LoadSwagger("test_specs/long-running.yaml")
+	require.NoError(t, err)
+	code = generate(ResponseDecodingLazy)
+	assert.Contains(t, code, "if _, err := poll.decode(); err != nil {")
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Client: true, Models: true},
+	}
+	opts.OutputOptions.ClientResponseDecoding = "never"
+	assert.ErrorContains(t, opts.Validate(), `unknown client response decoding "never"`)
+}
+
+func TestHotPathMarshalers(t *testing.T) {
+	generate := func(all bool) (string, []Diagnostic) {
+		swagger, err := util.LoadSwagger("test_specs/hot-path.yaml")
+		require.NoError(t, err)
+		code, diagnostics, err := GenerateWithDiagnostics(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				SkipPrune:         true,
+				HotPathMarshalers: all,
+			},
+		})
+		require.NoError(t, err)
+		checkLint(t, "test.gen.go", []byte(code))
+		return code, diagnostics
+	}
+
+	code, diagnostics := generate(false)
+	assert.Contains(t, code, "func (a Pet) MarshalJSON() ([]byte, error) {")
+	assert.Contains(t, code, "func (a *Owner) UnmarshalJSON(data []byte) error {")
+	assert.Contains(t, code, "type jsonLexer struct {")
+	// Fields are encoded by type, and hot-path types by their methods
+	assert.Contains(t, code, "buf = strconv.AppendInt(buf, a.Id, 10)")
+	assert.Contains(t, code, "buf = appendJSONString(buf, string(*a.Kind))")
+	assert.Contains(t, code, `if buf, err = a.Owner.appendJSON(buf); err != nil {`)
+	assert.Contains(t, code, `if b, err = a.CreatedAt.MarshalJSON(); err != nil {`)
+	assert.Contains(t, code, `if b, err = json.Marshal(*a.Toy); err != nil {`)
+	assert.Contains(t, code, "*a.Kind = Kind(l.String())")
+	assert.Contains(t, code, "a.Owner.decodeJSON(l)")
+	assert.Contains(t, code, `switch l.Field(l.Key(), "id", "name") {`)
+	assert.Contains(t, code, "l.Unmarshal(&a.Toy)")
+	// Optional fields are left out when empty, ignored ones always are
+	assert.Contains(t, code, "if a.Weight != nil {\n\t\tbuf = append(buf, `,\"weight\":`...)")
+	assert.NotContains(t, code, `"secret"`)
+	// Types without x-hot-path keep encoding/json, and unions their own
+	assert.NotContains(t, code, "func (a Toy) MarshalJSON()")
+	assert.NotContains(t, code, "func (a Cold) MarshalJSON()")
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "/components/schemas/Shape", diagnostics[0].Path)
+	assert.Contains(t, diagnostics[0].Reason, "has JSON methods of its own")
+
+	// The option covers all the struct types, but those opting out
+	code, diagnostics = generate(true)
+	assert.Contains(t, code, "func (a Toy) MarshalJSON() ([]byte, error) {")
+	assert.Contains(t, code, `if buf, err = a.Toy.appendJSON(buf); err != nil {`)
+	assert.NotContains(t, code, "func (a Cold) MarshalJSON()")
+	assert.Len(t, diagnostics, 1)
+}
+
+func TestPatchBodies(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/patch-bodies.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			PatchBodies: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// JSON Patch bodies are JSONPatch documents, whatever their schema
+	assert.Contains(t, code, "type PatchPetApplicationJSONPatchPlusJSONRequestBody = JSONPatch")
+	assert.Contains(t, code, "type JSONPatch []JSONPatchOperation")
+	assert.Contains(t, code, "func JSONPointer(tokens ...string) string {")
+
+	// The merge patch of Pet is shared by the operations, without its read-only id
+	assert.Contains(t, code, "type PatchPetApplicationMergePatchPlusJSONRequestBody = PetMergePatch")
+	assert.Contains(t, code, "type UpdatePetApplicationMergePatchPlusJSONRequestBody = PetMergePatch")
+	assert.Equal(t, 1, strings.Count(code, "type PetMergePatch struct {"))
+	assert.Contains(t, code, "Name PatchField[string]   `json:\"name\"`")
+	assert.Contains(t, code, "Kind PatchField[PetKind]  `json:\"kind\"`")
+	assert.NotContains(t, code, "Id   PatchField")
+	assert.Contains(t, code, "func (p PetMergePatch) MarshalJSON() ([]byte, error) {")
+	assert.Contains(t, code, "type PatchField[T any] struct {")
+
+	// Inline schemas have merge patches of their own, with their enums
+	assert.Contains(t, code, "type PatchOwnerApplicationMergePatchPlusJSONBody struct {")
+	assert.Contains(t, code, "PatchField[PatchOwnerApplicationMergePatchPlusJSONBodyKind] `json:\"kind\"`")
+	assert.Contains(t, code, "Company PatchOwnerApplicationMergePatchPlusJSONBodyKind = \"company\"")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The JSON bodies of PATCH operations keep their types
+	assert.Contains(t, code, "type PatchPetJSONRequestBody = Pet\n")
+
+	// Unless they get tri-state fields, unlike those of other operations
+	opts.OutputOptions.PatchBodies = false
+	opts.OutputOptions.PatchTriStateFields = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type PatchPetJSONRequestBody = PetMergePatch")
+	assert.Contains(t, code, "type PatchPetApplicationMergePatchPlusJSONRequestBody = PetMergePatch")
+	assert.Contains(t, code, "type PatchPetApplicationJSONPatchPlusJSONRequestBody = PatchPetApplicationJSONPatchPlusJSONBody")
+	assert.Contains(t, code, "type UpdatePetJSONRequestBody = Pet\n")
+	assert.Contains(t, code, "type UpdatePetApplicationMergePatchPlusJSONRequestBody = Pet\n")
+	assert.Contains(t, code, "type PatchOwnerApplicationMergePatchPlusJSONBody struct {")
+	assert.NotContains(t, code, "JSONPatch ")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the options, the bodies have the types of their schemas
+	opts.OutputOptions.PatchTriStateFields = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type PatchPetApplicationMergePatchPlusJSONRequestBody = Pet")
+	assert.NotContains(t, code, "JSONPatch ")
+	assert.NotContains(t, code, "PatchField")
+}
+
+func TestEquivalentTo(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/equivalent-to.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The packages of the equivalent types are imported, named after the
+	// last element of their paths by default
+	assert.Contains(t, code, `v1 "github.com/acme/petstore/v1"`)
+	assert.Contains(t, code, `peoplev3 "github.com/acme/people"`)
+
+	// The types are of the same name by default
+	assert.Contains(t, code, "func PetFromV1(v v1.Pet) (Pet, error) {")
+	assert.Contains(t, code, "func PetToV1(v Pet) (v1.Pet, error) {")
+	assert.Contains(t, code, "func OwnerFromV1(v v1.Person) (Owner, error) {")
+	assert.Contains(t, code, "func OwnerToPeoplev3(v Owner) (peoplev3.Owner, error) {")
+	assert.Contains(t, code, "decoder.DisallowUnknownFields()")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	swagger.Components.Schemas["Pet"].Value.Extensions[extEquivalentTo] = map[string]interface{}{"path": "gopkg.in/yaml.v3"}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-equivalent-to": the last element of gopkg.in/yaml.v3 isn't a package name, so name is required`)
+
+	swagger.Components.Schemas["Pet"].Value.Extensions[extEquivalentTo] = map[string]interface{}{"type": "Pet"}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "path is the import path of the package of the equivalent type, and is required")
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "photo_urls", toSnakeCase("photoUrls"))
+	assert.Equal(t, "http_status", toSnakeCase("HTTPStatus"))
+	assert.Equal(t, "created_at", toSnakeCase("created-at"))
+}
+
+func TestLongRunning(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/long-running.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The poll operation can be sent to the status URL
+	assert.Contains(t, code, "GetJobWithURL(ctx context.Context, statusURL string, reqEditors ...RequestEditorFn) (*http.Response, error)")
+	assert.Contains(t, code, "req, err := http.NewRequest(\"GET\", statusURL, nil)")
+
+	// Each variant of the long-running operations waits for the poll response
+	assert.Contains(t, code, "func (c *ClientWithResponses) CreateReportAndWait(ctx context.Context, body CreateReportJSONRequestBody, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) CreateReportWithBodyAndWait(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) RefreshReportAndWait(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {")
+
+	// The status is compared with the terminal states
+	assert.Contains(t, code, "statusURL, err := longRunningStatusURL(rsp, \"Location\")")
+	assert.Contains(t, code, "switch string(poll.JSON200.Status) {\n\t\tcase \"succeeded\", \"failed\":")
+	assert.Contains(t, code, "statusURL, err := longRunningStatusURL(rsp, \"Operation-Location\")")
+	assert.Contains(t, code, "if status := poll.JSON200.State; status != nil {\n\t\t\tswitch string(*status) {\n\t\t\tcase \"done\":")
+	assert.Contains(t, code, "var DefaultPollBackoff = PollBackoff{Initial: time.Second, Max: 30 * time.Second}")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The poll operation must answer with the status
+	swagger, err = util.LoadSwagger("test_specs/long-running.yaml")
+	require.NoError(t, err)
+	swagger.Paths["/reports"].Post.Extensions[extLongRunning] = map[string]interface{}{
+		"operation":       "getJob",
+		"status":          "progress",
+		"terminal-states": []interface{}{"done"},
+	}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation GetJob polled for CreateReport has no JSON 200 response with the status property progress")
+
+	swagger.Paths["/reports"].Post.Extensions[extLongRunning] = map[string]interface{}{
+		"operation":       "getJobs",
+		"terminal-states": []interface{}{"done"},
+	}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation getJobs polled for CreateReport is missing")
+}
+
+func TestConditionalRequests(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/conditional.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The responses declaring an ETag header expose it
+	assert.Contains(t, code, "func (r GetPetResponse) ETag() string {")
+	assert.Contains(t, code, "func (r UpdatePetResponse) ETag() string {")
+	assert.NotContains(t, code, "func (r DeletePetResponse) ETag() string {")
+
+	// Each variant of the conditional operations has a helper sending the ETag
+	assert.Contains(t, code, "func WithIfMatch(etag string) RequestEditorFn {")
+	assert.Contains(t, code, "func WithIfNoneMatch(etag string) RequestEditorFn {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) GetPetIfNoneMatch(ctx context.Context, etag string, id int64, params *GetPetParams, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) UpdatePetIfMatch(ctx context.Context, etag string, id int64, params *UpdatePetParams, body UpdatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdatePetResponse, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) UpdatePetWithBodyIfMatch(ctx context.Context, etag string, id int64, params *UpdatePetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdatePetResponse, error) {")
+	assert.NotContains(t, code, "UpdatePetIfNoneMatch")
+	assert.NotContains(t, code, "DeletePetIfMatch")
+
+	// A 412 response fails with the typed error
+	assert.Contains(t, code, "return rsp, preconditionFailed(rsp.HTTPResponse, \"If-Match\", etag)")
+	assert.Contains(t, code, "type PreconditionFailedError struct {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestBatch(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/batch.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The builder adds each batched operation, with a typed result
+	assert.Contains(t, code, "func (c *ClientWithResponses) NewBatchBuilder() *BatchBuilder {")
+	assert.Contains(t, code, "func (b *BatchBuilder) AddGetUser(id string) *GetUserBatchResult {")
+	assert.Contains(t, code, "func (b *BatchBuilder) AddUpdateUser(id string, params *UpdateUserParams, body UpdateUserJSONRequestBody) *UpdateUserBatchResult {")
+	assert.Contains(t, code, "func (b *BatchBuilder) AddListUsers(params *ListUsersParams) *ListUsersBatchResult {")
+	assert.Contains(t, code, "func (r *GetUserBatchResult) Response() (*GetUserResponse, error) {")
+	assert.Contains(t, code, "r.response, r.err = ParseGetUserResponse(rsp)")
+
+	// The batch is sent at once, within its maximum size
+	assert.Contains(t, code, "rsp, err := b.client.BatchWithBody(ctx, \"application/json\", bytes.NewReader(data), reqEditors...)")
+	assert.Contains(t, code, "if len(requests) > 20 {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The batched operations must exist, and take JSON bodies
+	swagger.Paths["/$batch"].Post.Extensions[extBatch] = map[string]interface{}{
+		"operations": []interface{}{"getUsers"},
+	}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation getUsers batched by Batch is missing")
+
+	swagger.Paths["/$batch"].Post.Extensions[extBatch] = map[string]interface{}{
+		"operations": []interface{}{"batch"},
+	}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation Batch batched by Batch must not be a batch operation")
+
+	// The batch operation takes the envelope as a JSON body
+	swagger.Paths["/$batch"].Post.RequestBody = nil
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `Batch is declared a batch operation with "x-batch", but doesn't take a JSON body without parameters`)
+}
+
+func TestGoTypeImport(t *testing.T) {
+	packageName := "api"
+	opts := Configuration{
+		PackageName: packageName,
+		Generate: GenerateOptions{
+			EchoServer:   true,
+			Models:       true,
+			EmbeddedSpec: true,
+		},
+	}
+	spec := "test_specs/x-go-type-import-pet.yaml"
+	swagger, err := util.LoadSwagger(spec)
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	imports := []string{
+		`github.com/CavernaTechnologies/pgext`, // schemas - direct object
+		`myuuid "github.com/google/uuid"`,      // schemas - object
+		`github.com/lib/pq`,                    // schemas - array
+		`github.com/spf13/viper`,               // responses - direct object
+		`golang.org/x/text`,                    // responses - complex object
+		`golang.org/x/email`,                   // requestBodies - in components
+		`github.com/fatih/color`,               // parameters - query
+		`github.com/go-openapi/swag`,           // parameters - path
+		`github.com/jackc/pgtype`,              // direct parameters - path
+		`github.com/mailru/easyjson`,           // direct parameters - query
+		`github.com/subosito/gotenv`,           // direct request body
+	}
+
+	// Check import
+	for _, imp := range imports {
+		assert.Contains(t, code, imp)
+	}
+
+	// Make sure the generated code is valid:
+	checkLint(t, "test.gen.go", []byte(code))
+
+}
+
+func TestRemoteExternalReference(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping test that interacts with the network")
+	}
+
+	packageName := "api"
+	opts := Configuration{
+		PackageName: packageName,
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		ImportMapping: map[string]string{
+			remoteRefFile: remoteRefImport,
+		},
+	}
+	spec := "test_specs/remote-external-reference.yaml"
+	swagger, err := util.LoadSwagger(spec)
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that we have a package:
+	assert.Contains(t, code, "package api")
+
+	// Check import
+	assert.Contains(t, code, `externalRef0 "github.com/deepmap/oapi-codegen/examples/petstore-expanded"`)
+
+	// Check generated oneOf structure:
+	assert.Contains(t, code, `
+// ExampleSchema_Item defines model for ExampleSchema.Item.
+type ExampleSchema_Item struct {
+	union json.RawMessage
+}
+`)
+
+	// Check generated oneOf structure As method:
+	assert.Contains(t, code, `
+// AsExternalRef0NewPet returns the union data inside the ExampleSchema_Item as a externalRef0.NewPet
+func (t ExampleSchema_Item) AsExternalRef0NewPet() (externalRef0.NewPet, error) {
+`)
+
+	// Check generated oneOf structure From method:
+	assert.Contains(t, code, `
+// FromExternalRef0NewPet overwrites any union data inside the ExampleSchema_Item as the provided externalRef0.NewPet
+func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error {
+`)
+
+	// Check generated oneOf structure Merge method:
+	assert.Contains(t, code, `
+// FromExternalRef0NewPet overwrites any union data inside the ExampleSchema_Item as the provided externalRef0.NewPet
+func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error {
+`)
+
+	// Make sure the generated code is valid:
+	checkLint(t, "test.gen.go", []byte(code))
+
+}
+
+func TestRateLimitExtension(t *testing.T) {
+	packageName := "api"
+	opts := Configuration{
+		PackageName: packageName,
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+	spec := "test_specs/x-ratelimit.yaml"
+	swagger, err := util.LoadSwagger(spec)
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that the limiters are declared once per tag or operation
+	assert.Contains(t, code, `"golang.org/x/time/rate"`)
+	assert.Contains(t, code, "RateLimiters map[string]*rate.Limiter")
+	assert.Contains(t, code, `client.RateLimiters["tag:pets"] = rate.NewLimiter(2, 4)`)
+	assert.Contains(t, code, `client.RateLimiters["op:Search"] = rate.NewLimiter(0.5, 1)`)
+	assert.Contains(t, code, `client.RateLimiters["tag:Search"] = rate.NewLimiter(3, 1)`)
+	assert.Contains(t, code, "func WithRateLimiter(key string, limiter *rate.Limiter) ClientOption {")
+
+	// Check that limited operations wait on their limiter, and others don't
+	assert.Contains(t, code, `c.waitRateLimit(ctx, "tag:pets")`)
+	assert.Contains(t, code, `c.waitRateLimit(ctx, "op:Search")`)
+	assert.Contains(t, code, `c.waitRateLimit(ctx, "tag:Search")`)
+	assert.Equal(t, 4, strings.Count(code, "c.waitRateLimit(ctx,"))
+
+	// Check that the rate limit headers are exposed on the responses
+	assert.Contains(t, code, "func (r ListPetsResponse) RateLimit() RateLimitHeaders {")
+	assert.NotContains(t, code, "func (r HealthResponse) RateLimit() RateLimitHeaders {")
+	assert.Contains(t, code, "func ParseRateLimitHeaders(rsp *http.Response) RateLimitHeaders {")
+
+	// Make sure the generated code is valid:
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestRateLimitExtensionInvalid(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/x-ratelimit.yaml")
+	require.NoError(t, err)
+	swagger.Paths["/health"].Get.Extensions[extRateLimit] = map[string]interface{}{"burst": float64(2)}
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Client: true},
+	})
+	assert.ErrorContains(t, err, "requests-per-second")
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCircuitBreaker: &CircuitBreakerOptions{
+				Scope:            "operation",
+				FailureThreshold: 3,
+				OpenTimeout:      "1m30s",
+			},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that the thresholds from the configuration are used by default
+	assert.Contains(t, code, "DefaultCircuitBreakerFailureThreshold = 3")
+	assert.Contains(t, code, "= 90 * time.Second")
+	assert.Contains(t, code, "client.CircuitBreaker = NewCircuitBreaker(DefaultCircuitBreakerFailureThreshold, DefaultCircuitBreakerOpenTimeout)")
+
+	// Check that the breaker can be replaced, and that calls are keyed by operation
+	assert.Contains(t, code, "func WithCircuitBreaker(cb CircuitBreaker) ClientOption {")
+	assert.Contains(t, code, `return c.doWithCircuitBreaker("GetTestByName", req)`)
+	assert.NotContains(t, code, "return c.Client.Do(req)\n}")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The default scope groups calls by host
+	opts.OutputOptions.ClientCircuitBreaker = &CircuitBreakerOptions{}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "DefaultCircuitBreakerFailureThreshold = 5")
+	assert.Contains(t, code, "return c.doWithCircuitBreaker(req.URL.Host, req)")
+
+	// Unknown scopes are rejected
+	opts.OutputOptions.ClientCircuitBreaker = &CircuitBreakerOptions{Scope: "path"}
+	assert.ErrorContains(t, opts.Validate(), "unknown circuit breaker scope")
+}
+
+func TestClientCache(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-cache.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCache: &ClientCacheOptions{
+				Operations: []string{"getProduct"},
+				MaxEntries: 50,
+			},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The default cache holds the configured number of responses
+	assert.Contains(t, code, "const DefaultCacheMaxEntries = 50")
+	assert.Contains(t, code, "client.Cache = NewMemoryCache(DefaultCacheMaxEntries)")
+	assert.Contains(t, code, "func WithResponseCache(cache ResponseCache) ClientOption {")
+
+	// The operations with x-cacheable, and those of the option, are cached
+	assert.Contains(t, code, "func (c *Client) ListProducts(ctx context.Context, params *ListProductsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {\n\treq, err := NewListProductsRequest(c.Server, params)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treq = req.WithContext(ctx)\n\tif err := c.applyEditors(ctx, req, reqEditors); err != nil {\n\t\treturn nil, err\n\t}\n\tcachedRsp, stale := c.cachedResponse(req)")
+	assert.Contains(t, code, "func (c *Client) GetProduct(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {\n\treq, err := NewGetProductRequest(c.Server, id)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treq = req.WithContext(ctx)\n\tif err := c.applyEditors(ctx, req, reqEditors); err != nil {\n\t\treturn nil, err\n\t}\n\tcachedRsp, stale := c.cachedResponse(req)")
+	assert.Equal(t, 2, strings.Count(code, "return c.storeResponse(req, stale, rsp, err)"))
+
+	// The responses are keyed on the credentials, and dropped by unsafe requests
+	assert.Contains(t, code, `var cacheCredentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}`)
+	assert.Contains(t, code, "return &invalidatingDoer{doer: doer, cache: c.Cache}")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, nothing is cached
+	opts.OutputOptions.ClientCache = nil
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "ResponseCache")
+
+	// Only GET operations can be cached
+	opts.OutputOptions.ClientCache = &ClientCacheOptions{Operations: []string{"createProduct"}}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation createProduct of the client-cache option must be a GET operation")
+
+	opts.OutputOptions.ClientCache = &ClientCacheOptions{Operations: []string{"getProducts"}}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the operation getProducts of the client-cache option is missing")
+
+	swagger.Paths["/products"].Post.Extensions[extCacheable] = true
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `CreateProduct is declared cacheable with "x-cacheable", but only GET operations are`)
+
+	opts.OutputOptions.ClientCache = &ClientCacheOptions{MaxEntries: -1}
+	assert.ErrorContains(t, opts.Validate(), "client cache max entries must not be negative")
+}
+
+func TestClientCoalescing(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-cache.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCoalescing: true,
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func NewCoalescingClient(client ClientWithResponsesInterface) *CoalescingClient {")
+
+	// The GET operations are coalesced, keyed by their parameters
+	assert.Contains(t, code, "func ListProductsCoalesceKey(params *ListProductsParams) (string, error) {")
+	assert.Contains(t, code, "func GetProductCoalesceKey(id string) (string, error) {")
+	assert.Contains(t, code, "func (c *CoalescingClient) GetProductWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetProductResponse, error) {")
+	assert.NotContains(t, code, "CreateProductCoalesceKey")
+
+	// And by their credentials, read from the requests built without sending them
+	assert.Contains(t, code, "credentials, err = coalesceCredentials(func(probe RequestEditorFn) error {")
+	assert.Contains(t, code, `var coalesceCredentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, nothing is coalesced
+	opts.OutputOptions.ClientCoalescing = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "CoalescingClient")
+
+	opts.OutputOptions.ClientCoalescing = true
+	opts.Generate.Client = false
+	assert.ErrorContains(t, opts.Validate(), "the client coalescing needs the client")
+}
+
+func TestClientCallAll(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-cache.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCallAll: true,
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Every operation has its calls, holding its parameters and body
+	assert.Contains(t, code, "type GetProductCall struct {\n\tId string\n}")
+	assert.Contains(t, code, "type ListProductsCall struct {\n\tParams *ListProductsParams\n}")
+	assert.Contains(t, code, "func GetProductCallAll(ctx context.Context, client ClientWithResponsesInterface, calls []GetProductCall, concurrency int, reqEditors ...RequestEditorFn) []GetProductResult {")
+	assert.Contains(t, code, "results[i].Response, results[i].Err = client.GetProductWithResponse(ctx, calls[i].Id, reqEditors...)")
+	assert.Contains(t, code, "func callAll(ctx context.Context, n, concurrency int, fn func(i int)) int {")
+	assert.Contains(t, code, "case <-ctx.Done():\n\t\t\tbreak dispatch")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, there are none
+	opts.OutputOptions.ClientCallAll = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "CallAll")
+
+	// Their types can't collide with those of the schemas
+	opts.OutputOptions.ClientCallAll = true
+	opts.OutputOptions.SkipPrune = true
+	swagger.Components.Schemas["GetProductResult"] = openapi3.NewSchemaRef("", openapi3.NewObjectSchema())
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the GetProductResult type of the client-call-all option collides with the GetProductResult schema")
+
+	opts.Generate.Client = false
+	assert.ErrorContains(t, opts.Validate(), "the CallAll functions need the client")
+}
+
+func TestClientBufferPool(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-cache.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientBufferPool: &ClientBufferPoolOptions{MaxBufferSize: 1 << 20},
+			ClientCache:      &ClientCacheOptions{},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Buffers up to the configured size are pooled
+	assert.Contains(t, code, "const MaxPooledBufferSize = 1048576")
+	assert.Contains(t, code, "var bufferPool = sync.Pool{")
+
+	// JSON request bodies are encoded into pooled buffers, released once sent
+	assert.Contains(t, code, "buf := getBuffer()\n\tif err := json.NewEncoder(buf).Encode(body); err != nil {")
+	assert.Contains(t, code, "req.GetBody = pooled.reader")
+	assert.Contains(t, code, "req, err := NewCreateProductRequest(c.Server, body)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\t// The buffer of the body returns to the pool once the request is sent.\n\tdefer pooledRequestBody(req).release()")
+	assert.NotContains(t, code, "json.Marshal(body)")
+
+	// Response bodies are read through pooled buffers
+	assert.Contains(t, code, "bodyBytes, err := readResponseBody(rsp)")
+	assert.Contains(t, code, "body, err := readResponseBody(rsp)")
+	assert.NotContains(t, code, "bodyBytes, err := io.ReadAll(rsp.Body)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The default size caps buffers at 64KiB
+	opts.OutputOptions.ClientBufferPool = &ClientBufferPoolOptions{}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "const MaxPooledBufferSize = 65536")
+
+	// Request builders generated without the client don't pool buffers
+	opts.Generate = GenerateOptions{RequestBuilders: true, Models: true}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "getBuffer")
+	assert.Contains(t, code, "json.Marshal(body)")
+
+	opts.OutputOptions.ClientBufferPool = &ClientBufferPoolOptions{MaxBufferSize: -1}
+	assert.ErrorContains(t, opts.Validate(), "client buffer pool max buffer size must not be negative")
+}
+
+func TestClientTransportOptions(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-cache.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTransportOptions: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Options tune the transport of the default http.Client
+	assert.Contains(t, code, "transport  *http.Transport\n\tforceHTTP2 bool\n}")
+	assert.Contains(t, code, "c.transport = http.DefaultTransport.(*http.Transport).Clone()")
+	for _, option := range []string{
+		"func WithMaxIdleConns(n int) ClientOption {",
+		"func WithMaxIdleConnsPerHost(n int) ClientOption {",
+		"func WithMaxConnsPerHost(n int) ClientOption {",
+		"func WithIdleConnTimeout(timeout time.Duration) ClientOption {",
+		"func WithTLSConfig(config *tls.Config) ClientOption {",
+		"func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {",
+		"func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {",
+		"func WithForceHTTP2() ClientOption {",
+	} {
+		assert.Contains(t, code, option)
+	}
+	assert.Contains(t, code, "client.Client = &http.Client{Transport: client.roundTripper()}")
+	assert.Contains(t, code, `"golang.org/x/net/http2"`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, the client has none of them
+	opts.OutputOptions.ClientTransportOptions = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "WithMaxIdleConns")
+	assert.NotContains(t, code, "golang.org/x/net/http2")
+}
+
+func TestClientUnixSocket(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/unix-socket.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// A server declared with x-server-transport gets the client WithUnixSocket,
+	// sending requests to its URL, along with the transport options
+	assert.Contains(t, code, `const unixSocketServer = "http://unix/v1"`)
+	assert.Contains(t, code, "func WithUnixSocket(path string) ClientOption {")
+	assert.Contains(t, code, `return dialer.DialContext(ctx, "unix", path)`)
+	assert.Contains(t, code, "transport.Proxy = nil")
+	assert.Contains(t, code, "if socket := strings.TrimPrefix(server, \"unix://\"); socket != server {\n\t\tclient.Server = unixSocketServer\n\t\topts = append([]ClientOption{WithUnixSocket(socket)}, opts...)\n\t}")
+	assert.Contains(t, code, "func WithDialContext(")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The option generates it for any spec
+	swagger.Servers[0].Extensions[extServerTransport] = "tcp"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "WithUnixSocket")
+
+	opts.OutputOptions.ClientUnixSocket = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, `const unixSocketServer = "http://unix"`)
+
+	swagger.Servers[0].Extensions[extServerTransport] = "pipe"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-server-transport" on the server http://unix/v1: unknown server transport "pipe"`)
+}
+
+func TestClientServices(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-services.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientServices: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Each tag gets a method of the clients, returning the interface of its
+	// operations, which appear in the services of all their tags
+	assert.Contains(t, code, "func (c *Client) Pets() PetsService {\n\treturn c\n}")
+	assert.Contains(t, code, "func (c *ClientWithResponses) Pets() PetsServiceWithResponses {\n\treturn c\n}")
+	assert.Contains(t, code, "func (c *Client) UserAccounts() UserAccountsService {")
+	assert.Contains(t, code, "type AdminService interface {\n\t// CreatePetWithBody request with any body")
+	assert.Contains(t, code, "type PetsService interface {\n\t// ListPets request")
+	assert.Contains(t, code, "\tCreatePetWithResponse(ctx context.Context, body CreatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePetResponse, error)\n}")
+	assert.NotContains(t, code, "HealthService")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The services can't be named like operations
+	swagger.Paths["/health"].Get.Tags = []string{"listPets"}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `the service of the tag "listPets" is named ListPets, like an operation`)
+
+	// Nor like the fields and methods of the clients, whatever their tags
+	swagger.Paths["/health"].Get.Tags = []string{"server"}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `the service of the tag "server" is named Server, like a field of Client`)
+
+	swagger.Paths["/health"].Get.Tags = []string{"client"}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `the service of the tag "client" is named Client, like a field of Client`)
+
+	// Nor their interfaces like the schemas
+	swagger.Paths["/health"].Get.Tags = nil
+	swagger.Components = &openapi3.Components{Schemas: openapi3.Schemas{
+		"AdminService": openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+	}}
+	opts.OutputOptions.SkipPrune = true
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `the service of the tag "admin" is declared as AdminService, like another type`)
+	opts.OutputOptions.SkipPrune = false
+	swagger.Components = nil
+
+	opts.OutputOptions.ClientServices = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "PetsService")
+}
+
+func TestAPIVersion(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/api-version.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The version of the spec is sent in the header and the path prefix,
+	// unless the operation overrides it
+	assert.Contains(t, code, `const APIVersion = "2.1"`)
+	assert.Contains(t, code, `const APIVersionHeader = "X-API-Version"`)
+	assert.Contains(t, code, `const APIVersionPathPrefix = "/v2.1"`)
+	assert.Contains(t, code, `joinServerURL(server, "/v2.1/pets")`)
+	assert.Contains(t, code, `joinServerURL(server, "/v3.0/pets/"+pathParam0)`)
+	assert.Contains(t, code, `req.Header.Set("X-API-Version", "2.1")`)
+	assert.Contains(t, code, `req.Header.Set("X-API-Version", "3.0")`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The output option overrides the extension
+	opts.OutputOptions.APIVersion = &APIVersionOptions{Header: "Api-Version", Version: "2023-10-01"}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, `req.Header.Set("Api-Version", "2023-10-01")`)
+	assert.Contains(t, code, `joinServerURL(server, "/pets")`)
+	assert.NotContains(t, code, "APIVersionPathPrefix")
+
+	opts.OutputOptions.APIVersion = &APIVersionOptions{PathPrefix: "v{version}/"}
+	assert.ErrorContains(t, opts.Validate(), "must start with a slash, and not end with one")
+	opts.OutputOptions.APIVersion = &APIVersionOptions{Header: "API Version"}
+	assert.ErrorContains(t, opts.Validate(), "isn't a valid header name")
+	opts.OutputOptions.APIVersion = &APIVersionOptions{}
+	assert.ErrorContains(t, opts.Validate(), "needs a header or a path prefix")
+
+	// An operation can't declare its version when the spec doesn't declare
+	// how to send it
+	opts.OutputOptions.APIVersion = nil
+	delete(swagger.Extensions, "x-api-version")
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "GetPet declares its version")
+}
+
+func TestClientCapture(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/capture.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCapture: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func WithCapture(w io.Writer) ClientOption {")
+	assert.Contains(t, code, "func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {")
+	assert.Contains(t, code, "return &captureDoer{doer: doer, recorder: c.capture}")
+
+	// The credentials of the security schemes, and the values declared with
+	// x-sensitive or of the password format are redacted
+	assert.Contains(t, code, `var captureSensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-Api-Key"}`)
+	assert.Contains(t, code, `headers:         []string{"X-Device-Secret"},`)
+	assert.Contains(t, code, `query:           []string{"otp"},`)
+	assert.Contains(t, code, `responseHeaders: []string{"X-Session-Token"},`)
+	assert.Contains(t, code, `"#/components/schemas/Credentials": {{"password"}},`)
+
+	// The fields of recursive schemas are redacted at any depth, and the
+	// sensitive path parameters are captured to be redacted
+	assert.Contains(t, code, `"#/components/schemas/Account":     {{"cards", "*", "number"}, {"referrer", "#/components/schemas/Account"}, {"secrets", "#/components/schemas/Secrets"}},`)
+	assert.Contains(t, code, `"#/components/schemas/Secrets":     {{"*"}},`)
+	assert.Contains(t, code, `path:   regexp.MustCompile("^/cards/([^/]*)$"),`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	swagger.Components.Schemas["Session"].Value.Properties["token"].Value.Extensions["x-sensitive"] = "yes"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-sensitive" at $.token`)
+
+	opts.OutputOptions.ClientCapture = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "WithCapture")
+	assert.NotContains(t, code, "captureDoer")
+}
+
+func TestSensitiveRedaction(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/capture.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The types with properties declared with x-sensitive or of the password
+	// format get the String and LogValue methods
+	assert.Contains(t, code, `const SensitiveRedacted = "REDACTED"`)
+	assert.Contains(t, code, "func (a Credentials) String() string {")
+	assert.Contains(t, code, "func (a Credentials) LogValue() slog.Value {")
+	assert.Contains(t, code, `{name: "Password", key: "password", sensitive: true},`)
+	assert.Contains(t, code, `{name: "Username", key: "username", value: a.Username},`)
+
+	// The inline objects are named to get them
+	assert.Contains(t, code, "type Account_Cards_Item struct {")
+	assert.Contains(t, code, "func (a Account_Cards_Item) LogValue() slog.Value {")
+	assert.Contains(t, code, `{name: "Number", key: "number", sensitive: true},`)
+
+	// The parameter objects get them from the x-sensitive of the parameters
+	assert.Contains(t, code, "func (a LoginParams) String() string {")
+	assert.Contains(t, code, `{name: "XDeviceSecret", key: "X-Device-Secret", sensitive: true},`)
+
+	// Those with sensitive additional properties too
+	assert.Contains(t, code, "func (a Secrets) String() string {")
+	assert.Contains(t, code, `{name: "AdditionalProperties", key: "additionalProperties", sensitive: true},`)
+
+	// The types without sensitive properties don't
+	assert.NotContains(t, code, "func (a Account) String() string {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestEncryptedProperties(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/encrypted.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "type FieldEncrypter interface {")
+	assert.Contains(t, code, "func WithFieldEncrypter(encrypter FieldEncrypter) ClientOption {")
+	assert.Contains(t, code, "func ContextWithFieldEncrypter(ctx context.Context, encrypter FieldEncrypter) context.Context {")
+
+	// Each client has its own encrypter, rather than the package
+	assert.NotContains(t, code, "SetFieldEncrypter")
+	assert.Contains(t, code, "FieldEncrypter FieldEncrypter\n}")
+	assert.Contains(t, code, "c.FieldEncrypter = encrypter")
+
+	// The types with properties declared with x-encrypted list them
+	assert.Contains(t, code, "func (Card) encryptedProperties() []string {\n\treturn []string{\"expiry\", \"number\"}\n}")
+
+	// Those of the inline objects too, which are named to get them
+	assert.Contains(t, code, "func (Patient) encryptedProperties() []string {\n\treturn []string{\"record\"}\n}")
+	assert.Contains(t, code, "type Patient_Contact struct {")
+	assert.Contains(t, code, "func (Patient_Contact) encryptedProperties() []string {\n\treturn []string{\"ssn\"}\n}")
+
+	// And those with JSON methods of their own, as the bodies are encrypted
+	// as they're sent and received
+	assert.Contains(t, code, "func (Tagged) encryptedProperties() []string {")
+
+	// The bodies of the requests are encrypted, and those of the responses
+	// decrypted, with the encrypter of the request
+	assert.Contains(t, code, "if err := encryptRequestBody(req, body); err != nil {")
+	assert.Contains(t, code, "data, err := decryptResponseBody(rsp, bodyBytes, &dest)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The strict servers take it from their options, or the request context
+	strictOpts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Strict:    true,
+			Models:    true,
+		},
+	}
+	code, err = Generate(swagger, strictOpts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "FieldEncrypter FieldEncrypter\n}")
+	assert.Contains(t, code, "if err := decryptRequestBody(r, sh.options.FieldEncrypter, &body); err != nil {")
+	assert.Contains(t, code, "validResponse.VisitAddCardResponse(withFieldEncrypter(r.Context(), w, sh.options.FieldEncrypter))")
+	assert.Contains(t, code, "return encodeJSONResponse(w, Card(response))")
+	assert.Contains(t, code, "return encodeJSONResponse(w, Card(response.CardJSONResponse))")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	swagger.Components.Schemas["Card"].Value.Properties["number"].Value.Extensions["x-encrypted"] = "yes"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-encrypted" of Card.number`)
+}
+
+func TestClientCassettes(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/cassette.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientCassettes: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func NewCassetteTransport(path string, mode CassetteMode, next http.RoundTripper) (*CassetteTransport, error) {")
+
+	// The requests are matched but for their volatile values, with the most
+	// specific paths first
+	assert.Contains(t, code, `volatileQuery:          []string{"requestedAt"},`)
+	assert.Contains(t, code, `volatileFields:         [][]string{{"#/components/schemas/NewOrder"}},`)
+	assert.Contains(t, code, `"#/components/schemas/NewOrder": {{"idempotencyKey"}, {"lines", "*", "#/components/schemas/Line"}},`)
+	assert.Contains(t, code, `path:                   regexp.MustCompile("^/orders/([^/]*)$"),`)
+	assert.Less(t, strings.Index(code, `"^/orders/latest$"`), strings.Index(code, `"^/orders/([^/]*)$"`))
+
+	// The sensitive values are redacted from the interactions, and left out
+	// of the matching
+	assert.Contains(t, code, `const CassetteRedacted = "REDACTED"`)
+	assert.Contains(t, code, `redactedGroups:          []int{1},`)
+	assert.Contains(t, code, `redactedQuery:           []string{"pin"},`)
+	assert.Contains(t, code, `redactedResponseHeaders: []string{"X-Card-Token"},`)
+	assert.Contains(t, code, `redactedFields:         [][]string{{"#/components/schemas/NewOrder"}},`)
+	assert.Contains(t, code, `var cassetteRedactedQuery = []string{"api_key"}`)
+	assert.Contains(t, code, `"#/components/schemas/Order":    {{"paymentToken"}},`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	swagger.Components.Schemas["Line"].Value.Properties["addedAt"].Value.Extensions["x-volatile"] = "always"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, `invalid value for "x-volatile" at $.addedAt`)
+
+	opts.OutputOptions.ClientCassettes = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "CassetteTransport")
+}
+
+func TestClientResponseBase(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/response-base.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientResponseBase: &ClientResponseBaseOptions{Type: "meta.Info", Import: "github.com/acme/api/meta"},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Every response embeds the type, which the hook of the client populates
+	assert.Contains(t, code, `meta "github.com/acme/api/meta"`)
+	assert.Contains(t, code, "type GetPetResponse struct {\n\tmeta.Info\n")
+	assert.Contains(t, code, "type AddPetResponse struct {\n\tmeta.Info\n")
+	assert.Contains(t, code, "type ResponseHook func(ctx context.Context, base *meta.Info, operationID string, rsp *http.Response, elapsed time.Duration)")
+	assert.Contains(t, code, "func WithResponseHook(hook ResponseHook) ClientOption {")
+	assert.Contains(t, code, `c.applyResponseHook(ctx, &response.Info, "GetPet", rsp, started)`)
+	assert.Contains(t, code, `c.applyResponseHook(ctx, &response.Info, "AddPet", rsp, started)`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	for _, base := range []ClientResponseBaseOptions{
+		{Type: "meta.Info"},
+		{Type: "Info", Import: "github.com/acme/api/meta"},
+		{Type: "*meta.Info", Import: "github.com/acme/api/meta"},
+		{Type: "Body"},
+	} {
+		assert.Error(t, base.Validate(), base.Type)
+	}
+
+	opts.OutputOptions.ClientResponseBase = nil
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "ResponseHook")
+	assert.NotContains(t, code, "started := time.Now()")
+}
+
+func TestClientHooks(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/response-base.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientHooks: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The requests carry the ID of their operation, which the hooks get
+	assert.Contains(t, code, `OperationAddPet = "AddPet"`)
+	assert.Contains(t, code, `OperationGetPet = "GetPet"`)
+	assert.Contains(t, code, "req = req.WithContext(context.WithValue(ctx, operationIDContextKey{}, OperationGetPet))")
+	assert.Contains(t, code, "OnResponse func(operationID string, rsp *http.Response, elapsed time.Duration)")
+	assert.Contains(t, code, "doer = &hooksDoer{doer: doer, operationID: operationID, hooks: c.Hooks}")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// They get along with the operation of the server in the context
+	opts.Generate.ChiServer = true
+	opts.OutputOptions.OperationContext = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(code, "func ClientOperationIDFromContext(ctx context.Context) (string, bool) {"))
+	assert.Equal(t, 1, strings.Count(code, "func OperationIDFromContext(ctx context.Context) string {"))
+	checkLint(t, "test.gen.go", []byte(code))
+
+	opts.Generate.ChiServer = false
+	opts.OutputOptions = OutputOptions{}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "ClientHooks")
+	assert.NotContains(t, code, "operationIDContextKey")
+}
+
+func TestClientLinks(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/links.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The links are followed by methods of the response types
+	assert.Contains(t, code, "func (r *GetPetResponse) GetPetOwner(ctx context.Context, client ClientWithResponsesInterface, reqEditors ...RequestEditorFn) (*GetOwnerResponse, error) {")
+	assert.Contains(t, code, `if err := followLink(r.HTTPResponse, r.Body, linkExpression{source: "$response.body", name: "/ownerId"}, &ownerId); err != nil {`)
+	assert.Contains(t, code, "return client.GetOwnerWithResponse(ctx, ownerId, reqEditors...)")
+	assert.Contains(t, code, "func (r *CreatePetResponse) GetCreatedPet(")
+	assert.Contains(t, code, "if r.HTTPResponse.StatusCode != 201 {")
+
+	// The parameters of the params struct, qualified or not
+	assert.Contains(t, code, `linkExpression{value: "10"}, &params.Limit)`)
+	assert.Contains(t, code, `linkExpression{source: "$response.body", name: "/ownerId"}, &params.Owner)`)
+	assert.Contains(t, code, "return client.ListPetsWithResponse(ctx, &params, reqEditors...)")
+
+	// The operationRef of the link, and a path parameter of the request
+	assert.Contains(t, code, `linkExpression{source: "$request.path", name: "petId", pattern: "^/pets/([^/]*)$"}`)
+
+	// The link to an operation with a body isn't followed
+	assert.NotContains(t, code, "CreateAnother")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// With drop-body, the responses whose links map values from their bodies
+	// keep them, unlike those of listPets, getOwner and getProfile
+	opts.OutputOptions.ClientResponseDecoding = ResponseDecodingDropBody
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "response.JSON200 = &dest\n\t\tresponse.Body = nil")
+	assert.Equal(t, 3, strings.Count(code, "response.Body = nil"))
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestRequestBuildersWithoutClient(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			RequestBuilders: true,
+			Models:          true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that only the request builders are generated
+	assert.Contains(t, code, "func NewGetTestByNameRequest(server string, name string, params *GetTestByNameParams) (*http.Request, error) {")
+	assert.NotContains(t, code, "type Client struct {")
+	assert.NotContains(t, code, "type ClientWithResponses struct {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestPathBuilders(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/routes.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			PathBuilders: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func PathListPets() (string, error) {")
+	assert.Contains(t, code, "func PathListOwnerPets(ownerId string) (string, error) {")
+	assert.Contains(t, code, `return "/owners/" + pathParam0 + "/pets", nil`)
+	assert.NotContains(t, code, "func NewListPetsRequest")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestServerURLs(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/servers.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client:     true,
+			Models:     true,
+			ServerURLs: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check the registry of top level servers
+	assert.Contains(t, code, "func (s APIServer) Expand(vars map[string]string) (string, error) {")
+	assert.Contains(t, code, `URL:         "https://{environment}.example.com/{version}",`)
+	assert.Contains(t, code, `"environment": {Default: "api", Enum: []string{"api", "staging"}},`)
+
+	// Check that path level servers apply to operations, unless overridden
+	assert.Contains(t, code, `"CreateUpload": {`)
+	assert.Contains(t, code, `URL: "https://uploads.example.com",`)
+	assert.Contains(t, code, `"ListUploads": {`)
+	assert.Contains(t, code, `URL: "https://uploads-{region}.example.com",`)
+	assert.NotContains(t, code, `"ListPets": {`)
+
+	// Check that the client uses the overriding servers
+	assert.Contains(t, code, "OperationServers map[string]string")
+	assert.Contains(t, code, `NewCreateUploadRequest(c.OperationServers["CreateUpload"])`)
+	assert.Contains(t, code, "NewListPetsRequest(c.Server)")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestEchoV5Server(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			EchoServer: true,
+			Models:     true,
+		},
+		OutputOptions: OutputOptions{
+			EchoVersion: 5,
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, `"github.com/labstack/echo/v5"`)
+	assert.NotContains(t, code, `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, code, "GetTestByName(ctx *echo.Context, name string, params GetTestByNameParams) error")
+	assert.Contains(t, code, "func (w *ServerInterfaceWrapper) GetTestByName(ctx *echo.Context) error {")
+	assert.Contains(t, code, "GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The strict server only supports echo v4
+	opts.Generate.Strict = true
+	assert.ErrorContains(t, opts.Validate(), "echo v5")
+	opts.Generate.Strict = false
+	opts.OutputOptions.EchoVersion = 3
+	assert.ErrorContains(t, opts.Validate(), "unsupported echo version 3")
+}
+
+func TestChiServer(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/x-middleware.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Models:    true,
+		},
+		OutputOptions: OutputOptions{
+			ChiRender: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// The wrappers delegate parameter binding to the exported binders
+	assert.Contains(t, code, "func BindDeletePetParams(r *http.Request) (id int64, err error) {")
+	assert.Contains(t, code, "func BindListPetsParams(r *http.Request) (params ListPetsParams, err error) {")
+	assert.Contains(t, code, "id, err := BindDeletePetParams(r)")
+
+	// The middlewares named in x-middleware are applied to their operations only
+	assert.Contains(t, code, "NamedMiddlewares map[string]MiddlewareFunc")
+	assert.Contains(t, code, "func (o *ChiServerOptions) WithAuthMiddleware(mw MiddlewareFunc) *ChiServerOptions {")
+	assert.Contains(t, code, "func (o *ChiServerOptions) WithAuditLogMiddleware(mw MiddlewareFunc) *ChiServerOptions {")
+	assert.Contains(t, code, `r.Use(options.namedMiddleware("auth"))
+		r.Use(options.namedMiddleware("audit-log"))
+		r.Delete(options.BaseURL+"/pets/{id}", wrapper.DeletePet)`)
+	assert.Equal(t, 2, strings.Count(code, "r.Use("))
+
+	// The JSON responses get render helpers
+	assert.Contains(t, code, `"github.com/go-chi/render"`)
+	assert.Contains(t, code, "func RenderListPets200JSONResponse(w http.ResponseWriter, r *http.Request, body []Pet) {")
+	assert.Contains(t, code, "func RenderListPetsdefaultJSONResponse(w http.ResponseWriter, r *http.Request, statusCode int, body Error) {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestGinServer(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/x-middleware.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			GinServer: true,
+			Models:    true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// The wrappers delegate parameter binding to the exported binders, whose
+	// errors all go to the error handler
+	assert.Contains(t, code, "type ErrorHandlerFunc func(c *gin.Context, err error, statusCode int)")
+	assert.Contains(t, code, "func BindDeletePetParams(c *gin.Context) (id int64, err error) {")
+	assert.Contains(t, code, "func BindListPetsParams(c *gin.Context) (params ListPetsParams, err error) {")
+	assert.Contains(t, code, "id, err := BindDeletePetParams(c)")
+	assert.Contains(t, code, "siw.ErrorHandler(c, err, http.StatusBadRequest)")
+	assert.Contains(t, code, `return id, &InvalidParamFormatError{ParamName: "id", Err: err}`)
+	assert.Contains(t, code, "ErrorHandler ErrorHandlerFunc")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestUnimplementedServers(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/problem.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains []string
+	}{
+		{
+			name:     "gorilla",
+			generate: GenerateOptions{GorillaServer: true},
+			contains: []string{"func (_ Unimplemented) GetPet(w http.ResponseWriter, r *http.Request, id int64) {\n\tw.WriteHeader(http.StatusNotImplemented)"},
+		},
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true},
+			contains: []string{"func (_ Unimplemented) GetPet(ctx echo.Context, id int64) error {\n\treturn ctx.NoContent(http.StatusNotImplemented)"},
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true},
+			contains: []string{"func (_ Unimplemented) GetPet(c *gin.Context, id int64) {\n\tc.Status(http.StatusNotImplemented)"},
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true, Strict: true},
+			contains: []string{
+				"func (_ Unimplemented) GetPet(c *fiber.Ctx, id int64) error {\n\treturn c.SendStatus(fiber.StatusNotImplemented)",
+				"func (_ UnimplementedStrictServer) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {\n\treturn unimplementedResponse{}, nil",
+				"func (unimplementedResponse) VisitGetPetResponse(ctx *fiber.Ctx) error {\n\treturn ctx.SendStatus(fiber.StatusNotImplemented)",
+			},
+		},
+		{
+			name:     "iris",
+			generate: GenerateOptions{IrisServer: true},
+			contains: []string{"func (_ Unimplemented) GetPet(ctx iris.Context, id int64) {\n\tctx.StatusCode(http.StatusNotImplemented)"},
+		},
+		{
+			name:     "chi strict",
+			generate: GenerateOptions{ChiServer: true, Strict: true},
+			contains: []string{
+				"func (_ UnimplementedStrictServer) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {",
+				"func (unimplementedResponse) VisitAddPetResponse(w http.ResponseWriter) error {\n\tw.WriteHeader(http.StatusNotImplemented)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			code, err := Generate(swagger, Configuration{PackageName: "api", Generate: tt.generate})
+			require.NoError(t, err)
+
+			assert.Contains(t, code, "type Unimplemented struct{}")
+			for _, s := range tt.contains {
+				assert.Contains(t, code, s)
+			}
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+}
+
+func TestContextHandlers(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/problem.yaml")
+	require.NoError(t, err)
+
+	for _, generate := range []GenerateOptions{
+		{ChiServer: true, Strict: true},
+		{EchoServer: true, Strict: true},
+		{FiberServer: true, Strict: true},
+	} {
+		generate.Models = true
+		opts := Configuration{
+			PackageName: "api",
+			Generate:    generate,
+			OutputOptions: OutputOptions{
+				ContextHandlers: true,
+			},
+		}
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "type ContextServerInterface interface {")
+		assert.Contains(t, code, "ListPets(ctx context.Context, params ListPetsParams) (ListPetsResponseObject, error)")
+		assert.Contains(t, code, "AddPet(ctx context.Context, body *AddPetJSONRequestBody) (AddPetResponseObject, error)")
+		assert.Contains(t, code, "GetPet(ctx context.Context, id int64) (GetPetResponseObject, error)")
+		assert.Contains(t, code, "func NewContextHandler(csi ContextServerInterface) StrictServerInterface {")
+		assert.Contains(t, code, "return h.csi.GetPet(ctx, request.Id)")
+		assert.Contains(t, code, "func (_ UnimplementedContextServer) AddPet(ctx context.Context, body *AddPetJSONRequestBody) (AddPetResponseObject, error) {")
+
+		checkLint(t, "test.gen.go", []byte(code))
+	}
+
+	// They need the strict server
+	err = Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true, ChiServer: true},
+		OutputOptions: OutputOptions{ContextHandlers: true},
+	}.Validate()
+	assert.ErrorContains(t, err, "the context handlers need the strict server")
+}
+
+func TestProblemResponses(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/problem.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains []string
+	}{
+		{
+			name:     "chi",
+			generate: GenerateOptions{ChiServer: true, Strict: true},
+			contains: []string{
+				"WriteProblem(w, NewProblem(http.StatusBadRequest, err))",
+			},
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true, Strict: true},
+			contains: []string{
+				"WriteProblem(c.Writer, NewProblem(statusCode, err))",
+				"WriteProblem(ctx.Writer, NewProblem(http.StatusBadRequest, err))",
+			},
+		},
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true},
+			contains: []string{
+				"func ProblemHTTPErrorHandler(err error, c echo.Context) {",
+				"WriteProblem(c.Response(), problem)",
+			},
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true},
+			contains: []string{
+				"func ProblemErrorHandler(c *fiber.Ctx, err error) error {",
+				`return fiber.NewError(fiber.StatusBadRequest, "Query argument limit is required, but not found")`,
+			},
+		},
+		{
+			name:     "iris",
+			generate: GenerateOptions{IrisServer: true},
+			contains: []string{
+				`writeBadRequest(ctx, fmt.Errorf("Invalid format for parameter limit: %w", err))`,
+				"WriteProblem(ctx.ResponseWriter(), NewProblem(http.StatusBadRequest, err))",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    tt.generate,
+				OutputOptions: OutputOptions{
+					ProblemResponses: true,
+				},
+			}
+			code, err := Generate(swagger, opts)
+			require.NoError(t, err)
+
+			assert.Contains(t, code, "type Problem struct {")
+			assert.Contains(t, code, `w.Header().Set("Content-Type", "application/problem+json")`)
+			for _, s := range tt.contains {
+				assert.Contains(t, code, s)
+			}
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+
+	// Without them, fiber answers the missing required query parameters as before
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, FiberServer: true},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, `err = fmt.Errorf("Query argument limit is required, but not found")`)
+	assert.NotContains(t, code, `fiber.NewError(fiber.StatusBadRequest, "Query argument limit is required, but not found")`)
+
+	// They need a server
+	err = Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{ProblemResponses: true},
+	}.Validate()
+	assert.ErrorContains(t, err, "the problem responses need a chi, gorilla, echo, gin, fiber or iris server")
+}
+
+func TestRequestLimits(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/limits.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains []string
+	}{
+		{
+			name:     "chi",
+			generate: GenerateOptions{ChiServer: true, Strict: true},
+			contains: []string{
+				"r.Body = http.MaxBytesReader(w, r.Body, 10485760)",
+				"ctx, cancel := context.WithTimeout(ctx, 90*time.Second)",
+			},
+		},
+		{
+			name:     "gorilla",
+			generate: GenerateOptions{GorillaServer: true},
+			contains: []string{
+				"r.Body = http.MaxBytesReader(w, r.Body, 4096)",
+				"ctx, cancel := context.WithTimeout(ctx, 90*time.Second)",
+			},
+		},
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true, Strict: true},
+			contains: []string{
+				"ctx.Request().Body = http.MaxBytesReader(ctx.Response(), ctx.Request().Body, 10485760)",
+				"ctx.SetRequest(ctx.Request().WithContext(requestCtx))",
+			},
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true},
+			contains: []string{
+				"c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 4096)",
+				"c.Request = c.Request.WithContext(ctx)",
+			},
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true, Strict: true},
+			contains: []string{
+				"if c.Request().Header.ContentLength() > 10485760 || len(c.Body()) > 10485760 {",
+				"c.SetUserContext(ctx)",
+			},
+		},
+		{
+			name:     "iris",
+			generate: GenerateOptions{IrisServer: true},
+			contains: []string{
+				"ctx.Request().Body = http.MaxBytesReader(ctx.ResponseWriter(), ctx.Request().Body, 4096)",
+				"ctx.ResetRequest(ctx.Request().WithContext(requestCtx))",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			code, err := Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate:    tt.generate,
+			})
+			require.NoError(t, err)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, code, s)
+			}
+			// Only the operation declaring a timeout has one
+			assert.Equal(t, 1, strings.Count(code, "defer cancel()"))
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+
+	// Invalid limits are reported with their operation
+	swagger.Paths["/pets"].Post.Extensions[extRequestTimeout] = "forever"
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{ChiServer: true, Models: true},
+	})
+	assert.ErrorContains(t, err, `invalid value for "x-request-timeout" on AddPet`)
+}
+
+func TestHeaderParams(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/header-params.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+	})
+	require.NoError(t, err)
+
+	// The repeated header is sent on a line per value
+	assert.Contains(t, code, `req.Header.Add("X-Ids", headerValue)`)
+	assert.Contains(t, code, `req.Header.Set("X-Tags", headerParam0)`)
+
+	// The lines of array headers are joined, and objects are bound with
+	// their number properties
+	assert.Contains(t, code, `runtime.BindStyledParameterWithLocation("simple", false, "X-Tags", runtime.ParamLocationHeader, strings.Join(valueList, ","), &XTags)`)
+	assert.Contains(t, code, `err = bindHeaderObject(true, "X-Filter", valueList[0], []string{"limit"}, &XFilter)`)
+	assert.Contains(t, code, "func bindHeaderObject(explode bool, paramName string, value string, unquoted []string, dest interface{}) error {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Only array header parameters can be repeated
+	params := swagger.Paths["/things"].Get.Parameters
+	params[2].Value.Extensions[extHeaderStyle] = "repeated"
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Client: true, Models: true},
+	})
+	assert.ErrorContains(t, err, `param (X-Filter) has "x-header-style" "repeated", but only array header params can be repeated`)
+
+	params[2].Value.Extensions[extHeaderStyle] = "lines"
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Client: true, Models: true},
+	})
+	assert.ErrorContains(t, err, `invalid value for "x-header-style" on param (X-Filter): unknown header style "lines"`)
+}
+
+func TestOptionalParams(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/optional-params.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+		OutputOptions: OutputOptions{
+			OptionalParams: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The parameters allowing empty values and the nullable ones are
+	// optional, the others are left alone
+	assert.Contains(t, code, "Flag   OptionalParam[bool]     `form:\"flag,omitempty\" json:\"flag,omitempty\"`")
+	assert.Contains(t, code, "Name   OptionalParam[string]   `form:\"name\" json:\"name\"`")
+	assert.Contains(t, code, "Limit  OptionalParam[int]      `form:\"limit,omitempty\" json:\"limit,omitempty\"`")
+	assert.Contains(t, code, "Tags   OptionalParam[[]string] `form:\"tags,omitempty\" json:\"tags,omitempty\"`")
+	assert.Contains(t, code, "Plain  *string                 `form:\"plain,omitempty\" json:\"plain,omitempty\"`")
+	assert.Contains(t, code, "XSince OptionalParam[string]   `json:\"X-Since,omitempty\"`")
+
+	// The client sends their states, and the server binds them
+	assert.Contains(t, code, `if err := addOptionalQueryParam(queryValues, &bareParams, "form", true, "flag", params.Flag); err != nil {`)
+	assert.Contains(t, code, "queryURL.RawQuery = withBareQueryParams(queryURL.RawQuery, bareParams)")
+	assert.Contains(t, code, `if err := setOptionalHeaderParam(req.Header, "simple", false, "X-Since", params.XSince); err != nil {`)
+	assert.Contains(t, code, `err = bindOptionalQueryParam("form", true, true, "name", r.URL.RawQuery, &params.Name)`)
+	assert.Contains(t, code, `err = bindOptionalHeaderParam("simple", false, "X-Since", valueList, &params.XSince)`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Without the option, they're pointers
+	opts.OutputOptions.OptionalParams = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "OptionalParam")
+	assert.Contains(t, code, "Flag   *bool     `form:\"flag,omitempty\" json:\"flag,omitempty\"`")
+}
+
+func TestParamErrors(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/param-errors.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains []string
+	}{
+		{
+			name:     "chi",
+			generate: GenerateOptions{ChiServer: true},
+			contains: []string{
+				`pathParams := map[string]string{"id": chiPathParam(r, "id")}`,
+				"return id, params, errs",
+				"WriteParamErrors(w, paramErrs)",
+			},
+		},
+		{
+			name:     "gorilla",
+			generate: GenerateOptions{GorillaServer: true},
+			contains: []string{
+				`pathParams := map[string]string{"id": url.PathEscape(mux.Vars(r)["id"])}`,
+				"if errs := CheckGetThingParams(pathParams, r.URL.RawQuery, r.Header); errs != nil {",
+				"WriteParamErrors(w, paramErrs)",
+			},
+		},
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true},
+			contains: []string{
+				`pathParams := map[string]string{"id": echoPathParam(ctx, "id")}`,
+				"return ctx.JSON(http.StatusBadRequest, ParamErrorsResponse{Message: errs.Error(), Errors: errs})",
+			},
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true},
+			contains: []string{
+				`c.JSON(statusCode, gin.H{"msg": err.Error(), "errors": paramErrs})`,
+			},
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true},
+			contains: []string{
+				`pathParams := map[string]string{"id": c.Params("id")}`,
+				"if errs := CheckGetThingParams(pathParams, string(c.Request().URI().QueryString()), fiberRequestHeader(c)); errs != nil {",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    tt.generate,
+				OutputOptions: OutputOptions{
+					ParamErrors: true,
+				},
+			}
+			code, err := Generate(swagger, opts)
+			require.NoError(t, err)
+
+			// Every parameter is checked, with its location and expected type
+			assert.Contains(t, code, "func CheckGetThingParams(pathParams map[string]string, rawQuery string, header http.Header) ParamErrors {")
+			assert.NotContains(t, code, "func CheckPingParams(")
+			assert.Contains(t, code, `errs.add("id", "path", "integer (int64)", value, err)`)
+			assert.Contains(t, code, `errs.add("sort", "query", "string, one of asc, desc", value, err)`)
+			assert.Contains(t, code, `errs.add("filter", "query", "JSON object", value, err)`)
+			assert.Contains(t, code, `errs.add("X-Tags", "header", "array of string", strings.Join(valueList, ","), err)`)
+			assert.Contains(t, code, `errs.add("session", "cookie", "string", value, err)`)
+			for _, s := range tt.contains {
+				assert.Contains(t, code, s)
+			}
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+
+	// Problem documents hold them
+	code, err := Generate(swagger, Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{EchoServer: true, Models: true},
+		OutputOptions: OutputOptions{ParamErrors: true, ProblemResponses: true},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, "Errors ParamErrors `json:\"errors,omitempty\"`")
+	assert.Contains(t, code, "problem = NewProblem(http.StatusBadRequest, err)")
+
+	// They need a server
+	err = Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{ParamErrors: true},
+	}.Validate()
+	assert.ErrorContains(t, err, "the param errors need a chi, gorilla, echo, gin, fiber or iris server")
+}
+
+func TestClientValidation(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-validation.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientValidation: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The request builders check the parameters, and the body
+	assert.Contains(t, code, "if fields := validateRequestBody(validatePutThingParams(id, params), body); len(fields) != 0 {")
+	assert.Contains(t, code, "if fields := validatePutThingParams(id, params); len(fields) != 0 {")
+	assert.NotContains(t, code, "func validatePingParams(")
+
+	// Integers are compared with rounded bounds
+	assert.Contains(t, code, "if v > 1000000 {")
+	assert.Contains(t, code, `Err: errors.New("must be at most 1000000")`)
+	assert.Contains(t, code, "if v < 1 {")
+	assert.Contains(t, code, `Err: errors.New("must be greater than 0.5")`)
+	assert.Contains(t, code, "if v%5 != 0 {")
+	assert.Contains(t, code, "if v >= 1 {")
+
+	// Strings, enums and the items of arrays
+	assert.Contains(t, code, "if utf8.RuneCountInString(string(v)) < 2 {")
+	assert.Contains(t, code, `if !matchesPattern("^[a-z]+$", string(v)) {`)
+	assert.Contains(t, code, `if !(v == "asc" || v == "desc") {`)
+	assert.Contains(t, code, "if len(v) > 3 {")
+	assert.Contains(t, code, "if utf8.RuneCountInString(string(item)) > 4 {")
+
+	// The struct bodies are checked against the constraints on their
+	// properties, the read-only ones aside, and those of their items
+	assert.Contains(t, code, "func (a Gadget) validateRequest() error {")
+	assert.Contains(t, code, "func (a Part) validateRequest() error {")
+	assert.Contains(t, code, "if a.Parts == nil {\n\t\tproblems = append(problems, \"property \\\"parts\\\" is required\")")
+	assert.Contains(t, code, "if err := validateRequestValue(item); err != nil {")
+	assert.NotContains(t, code, `"property \"id\"`)
+	assert.NotContains(t, code, "func (a PutThingParams) validateRequest() error {")
+
+	// A bound which no value of the type satisfies rejects them all
+	assert.Contains(t, code, "if v > 0 || v <= 0 {")
+	assert.Contains(t, code, `Err: errors.New("must be at least 5000000000")`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// It needs the client, or the request builders
+	opts.Generate = GenerateOptions{Models: true}
+	assert.ErrorContains(t, opts.Validate(), "the client validation needs the client or the request builders")
+}
+
+func TestOperationContext(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/security.yaml")
+	require.NoError(t, err)
+
+	createPet := `OperationInfo{OperationID: "CreatePet", PathTemplate: "/pets", Scopes: [][]string{{"pets:write"}}}`
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains string
+	}{
+		{
+			name:     "chi",
+			generate: GenerateOptions{ChiServer: true, Strict: true},
+			contains: "ctx = withOperation(ctx, " + createPet + ")",
+		},
+		{
+			name:     "gorilla",
+			generate: GenerateOptions{GorillaServer: true},
+			contains: "ctx = withOperation(ctx, " + createPet + ")",
+		},
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true, Strict: true},
+			contains: "ctx.SetRequest(ctx.Request().WithContext(withOperation(ctx.Request().Context(), " + createPet + ")))",
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true},
+			contains: "c.Request = c.Request.WithContext(withOperation(c.Request.Context(), " + createPet + "))",
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true, Strict: true},
+			contains: "c.SetUserContext(withOperation(c.UserContext(), " + createPet + "))",
+		},
+		{
+			name:     "iris",
+			generate: GenerateOptions{IrisServer: true},
+			contains: "ctx.ResetRequest(ctx.Request().WithContext(withOperation(ctx.Request().Context(), " + createPet + ")))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			code, err := Generate(swagger, Configuration{
+				PackageName:   "api",
+				Generate:      tt.generate,
+				OutputOptions: OutputOptions{OperationContext: true},
+			})
+			require.NoError(t, err)
+
+			assert.Contains(t, code, "func OperationFromContext(ctx context.Context) (OperationInfo, bool) {")
+			assert.Contains(t, code, tt.contains)
+			// Operations without scopes leave them out
+			assert.Contains(t, code, `OperationInfo{OperationID: "Health", PathTemplate: "/health"})`)
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+
+	opts := Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Client: true},
+		OutputOptions: OutputOptions{OperationContext: true},
+	}
+	assert.ErrorContains(t, opts.Validate(), "the operation context needs a chi, gorilla, echo, gin, fiber or iris server")
+}
+
+func TestNamedMiddlewares(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/x-middleware.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		generate GenerateOptions
+		contains []string
+	}{
+		{
+			name:     "echo",
+			generate: GenerateOptions{EchoServer: true},
+			contains: []string{
+				"func RegisterHandlersWithMiddlewares(router EchoRouter, si ServerInterface, baseURL string, middlewares map[string]echo.MiddlewareFunc) {",
+				`router.GET(baseURL+"/pets", wrapper.ListPets)`,
+				`router.DELETE(baseURL+"/pets/:id", wrapper.DeletePet, namedMiddleware(middlewares, "auth"), namedMiddleware(middlewares, "audit-log"))`,
+			},
+		},
+		{
+			name:     "gin",
+			generate: GenerateOptions{GinServer: true},
+			contains: []string{
+				"NamedMiddlewares map[string]MiddlewareFunc",
+				`router.GET(options.BaseURL+"/pets", wrapper.ListPets)`,
+				`router.DELETE(options.BaseURL+"/pets/:id", options.namedMiddleware("auth"), options.namedMiddleware("audit-log"), wrapper.DeletePet)`,
+			},
+		},
+		{
+			name:     "gorilla",
+			generate: GenerateOptions{GorillaServer: true},
+			contains: []string{
+				"NamedMiddlewares map[string]MiddlewareFunc",
+				`r.HandleFunc(options.BaseURL+"/pets", wrapper.ListPets).Methods("GET")`,
+				`r.Handle(options.BaseURL+"/pets/{id}", options.withNamedMiddlewares(http.HandlerFunc(wrapper.DeletePet), "auth", "audit-log")).Methods("DELETE")`,
+			},
+		},
+		{
+			name:     "fiber",
+			generate: GenerateOptions{FiberServer: true},
+			contains: []string{
+				"NamedMiddlewares map[string]MiddlewareFunc",
+				`router.Delete(options.BaseURL+"/pets/:id", options.namedMiddleware("auth"), options.namedMiddleware("audit-log"), wrapper.DeletePet)`,
+			},
+		},
+		{
+			name:     "iris",
+			generate: GenerateOptions{IrisServer: true},
+			contains: []string{
+				"NamedMiddlewares map[string]MiddlewareFunc",
+				`router.Delete(options.BaseURL+"/pets/:id", options.namedMiddleware("auth"), options.namedMiddleware("audit-log"), wrapper.DeletePet)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.generate.Models = true
+			code, err := Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate:    tt.generate,
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			assert.NoError(t, err)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, code, s)
+			}
+
+			checkLint(t, "test.gen.go", []byte(code))
+		})
+	}
+}
+
+func TestSecurityMiddleware(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/security.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer:          true,
+			SecurityMiddleware: true,
+			Models:             true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// The schemes and requirements of the spec are described
+	assert.Contains(t, code, `"apiKey":     {Name: "apiKey", Type: "apiKey", In: "header", ParamName: "X-API-Key"},`)
+	assert.Contains(t, code, `"bearerAuth": {Name: "bearerAuth", Type: "http", Scheme: "bearer"},`)
+	assert.Contains(t, code, `"ListPets": {
+		{"bearerAuth": []string{}},
+		{"apiKey": []string{}},
+		{"oauth": []string{"pets:read"}},
+	},`)
+	assert.Contains(t, code, `{"apiKey": []string{}, "oauth": []string{"pets:write"}},`)
+	// Operations inherit the top level requirements
+	assert.Contains(t, code, `"GetPet": {`)
+	// Public operations have none
+	assert.NotContains(t, code, `"Health": {`)
+
+	assert.Contains(t, code, "type AuthenticationFunc func(ctx context.Context, scheme string, scopes []string) error")
+	assert.Contains(t, code, "func SecurityMiddleware(operationID string, authenticate AuthenticationFunc) func(http.Handler) http.Handler {")
+
+	// The chi server enforces them on registration
+	assert.Contains(t, code, "AuthenticationFunc AuthenticationFunc")
+	assert.Contains(t, code, `r.Use(SecurityMiddleware("ListPets", options.AuthenticationFunc))`)
+	assert.Equal(t, 3, strings.Count(code, "r.Use(SecurityMiddleware("))
+
+	// The scopes get constants, and are passed to the ScopesFunc
+	assert.Contains(t, code, `OauthScopePetsRead  = "pets:read"`)
+	assert.Contains(t, code, `OauthScopePetsWrite = "pets:write"`)
+	assert.Contains(t, code, `CreatePetRequiredScopes = "pets:write"`)
+	assert.Contains(t, code, `"CreatePet": [][]string{{"pets:write"}},`)
+	assert.Contains(t, code, "type ScopesFunc func(ctx context.Context, operationID string, scopes []string) error")
+	assert.Contains(t, code, `r.Use(ScopesMiddleware("CreatePet", options.ScopesFunc))`)
+	// Those of alternative requirements are kept apart, any of them sufficing
+	assert.NotContains(t, code, "ListPetsRequiredScopes")
+	assert.Contains(t, code, `"ListPets":  [][]string{{}, {}, {"pets:read"}},`)
+	assert.Contains(t, code, `r.Use(ScopesMiddleware("ListPets", options.ScopesFunc))`)
+	assert.Equal(t, 2, strings.Count(code, "r.Use(ScopesMiddleware("))
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The other servers don't apply it, so it's generated on its own for them
+	opts.Generate.ChiServer = false
+	require.NoError(t, opts.Validate())
+	for _, server := range []*bool{&opts.Generate.EchoServer, &opts.Generate.GinServer, &opts.Generate.FiberServer, &opts.Generate.IrisServer} {
+		*server = true
+		assert.EqualError(t, opts.Validate(), "the security middleware is only applied by the chi and gorilla servers, so generate it on its own to wrap it around an echo, gin, fiber or iris server")
+		*server = false
+	}
+}
+
+func TestGenerateWithDiagnostics(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/diagnostics.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+	}
+
+	// The problems of all the operations are reported together, rather than
+	// panicking on the first one
+	_, _, err = GenerateWithDiagnostics(swagger, opts)
+	require.Error(t, err)
+
+	var diagnostics Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	require.Len(t, diagnostics.Errors(), 2)
+	assert.Empty(t, diagnostics.Warnings())
+	assert.Equal(t, "ListPets", diagnostics[0].OperationID)
+	assert.Equal(t, "/paths/~1pets/get/responses", diagnostics[0].Path)
+	assert.Equal(t, "GetPet", diagnostics[1].OperationID)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/responses", diagnostics[1].Path)
+	assert.Contains(t, err.Error(), "operation ListPets: /paths/~1pets/get/responses: ")
+}
+
+func TestGenerateParallelism(t *testing.T) {
+	opts := Configuration{
+		PackageName: "testswagger",
+		Generate: GenerateOptions{
+			EchoServer:   true,
+			ChiServer:    true,
+			Client:       true,
+			Models:       true,
+			EmbeddedSpec: true,
+		},
+	}
+
+	// Generating concurrently gives the same code as generating sequentially
+	var outputs []string
+	for _, parallelism := range []int{1, 8} {
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+		require.NoError(t, err)
+
+		opts.OutputOptions.Parallelism = parallelism
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+		outputs = append(outputs, code)
+	}
+	assert.Equal(t, outputs[0], outputs[1])
+}
+
+//go:embed test_spec.yaml
+var testOpenAPIDefinition string
+
+func TestProvenance(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/servers.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "GeneratedFrom")
+
+	opts.OutputOptions.Provenance = &ProvenanceOptions{}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Regexp(t, `DO NOT EDIT\.\n//\n// Generated from .+, spec sha256:[0-9a-f]{64},\n// configuration sha256:[0-9a-f]{64}\.\npackage api`, code)
+	assert.Contains(t, code, "func GeneratedFrom() Provenance {")
+	assert.Regexp(t, `SpecHash: +"sha256:[0-9a-f]{64}"`, code)
+	assert.NotContains(t, code, "time.Unix(")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// It's stable
+	again, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Equal(t, code, again)
+
+	// Unless the time of generation is recorded
+	opts.OutputOptions.Provenance.Timestamp = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Regexp(t, `configuration sha256:[0-9a-f]{64}, at \d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ\.\n`, code)
+	assert.Contains(t, code, "GeneratedAt:      time.Unix(")
+
+	// The title and the version of the spec are quoted, so that they can't
+	// end the comment
+	swagger.Info.Title, swagger.Info.Version = "Pets\npackage evil", "1.0`"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "// Generated from \"Pets\\npackage evil\" \"1.0`\", spec sha256:")
+	assert.Contains(t, code, "SpecTitle:        \"Pets\\npackage evil\",")
+}
+
+func TestAsyncAPI(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/asyncapi.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		AsyncAPI: "test_specs/asyncapi-events.yaml",
+	}
+
+	code, diagnostics, err := GenerateWithDiagnostics(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "type Envelope[T any] struct {")
+	assert.Contains(t, code, "type Publisher interface {")
+	assert.Contains(t, code, "type Subscriber interface {")
+
+	// The payloads of the x-async section reference the schemas of the spec,
+	// or are named after their messages. The application subscribes to the
+	// messages of the publish operations, and publishes those of the
+	// subscribe ones
+	assert.Contains(t, code, "func SubscribeOnUserSignedUp(ctx context.Context, subscriber Subscriber, handle func(context.Context, Envelope[User]) error) error {")
+	assert.Contains(t, code, "type UserDeletedPayload struct {")
+	assert.Contains(t, code, "func PublishDeleteUser(ctx context.Context, publisher Publisher, payload UserDeletedPayload, headers map[string]string) error {")
+	assert.Contains(t, code, `EncodedMessage{Channel: "user/deleted", ContentType: "application/json", Headers: headers, Payload: encoded}`)
+
+	// Those of the AsyncAPI document too, whose schemas are merged into the
+	// components, and may reference those of the spec
+	assert.Contains(t, code, "type Order struct {")
+	assert.Contains(t, code, "Buyer User")
+	assert.Contains(t, code, "type OrderLine struct {")
+	assert.Contains(t, code, "func PublishOrderPlaced(ctx context.Context, publisher Publisher, payload Order, headers map[string]string) error {")
+	assert.Contains(t, code, "func SubscribeOrderPlaced(ctx context.Context, subscriber Subscriber, handle func(context.Context, Envelope[Order]) error) error {")
+	assert.Contains(t, code, "func SubscribeOrderShipped(ctx context.Context, subscriber Subscriber, handle func(context.Context, Envelope[OrderShippedPayload]) error) error {")
+
+	// The schemas referenced by neither the operations nor the messages are
+	// still pruned
+	assert.NotContains(t, code, "type Unused ")
+
+	assert.Equal(t, Diagnostics{
+		{Path: "/x-async/channels/user~1avatar/publish/message", Reason: "the payloads of image/png messages aren't supported, so the operation is skipped", Warning: true},
+		{Path: "test_specs/asyncapi-events.yaml#/channels/orders~1{orderId}~1shipped/parameters", Reason: "the parameters of the channel aren't supported, its helpers use its name as it is", Warning: true},
+	}, diagnostics)
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	swagger, err = util.LoadSwagger("test_specs/asyncapi.yaml")
+	require.NoError(t, err)
+	swagger.Components.Schemas["Order"] = swagger.Components.Schemas["User"]
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "the schema Order of the AsyncAPI document clashes with the one of the spec")
+}
+
+func TestTypeScript(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/typescript.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			TypeScript: true,
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	ts := output.TypeScript
+
+	// The objects are interfaces, with the optional and nullable properties
+	// of the fields of the structs
+	assert.Contains(t, ts, "/** A pet of the store. */\nexport interface Pet {\n")
+	assert.Contains(t, ts, "  id: number;\n")
+	assert.Contains(t, ts, "  owner: string | null;\n")
+	assert.Contains(t, ts, "  nickname?: string | null;\n")
+	assert.Contains(t, ts, "  born?: string;\n")
+	assert.Contains(t, ts, "  tags?: string[];\n")
+	assert.Contains(t, ts, `  "content-type"?: string;`)
+	assert.Contains(t, ts, "  /** @deprecated */\n  legacyId?: string;\n")
+	assert.Contains(t, ts, "  location?: { lat?: number; lng?: number; };\n")
+	assert.Contains(t, ts, "  labels?: Record<string, string>;\n")
+	assert.NotContains(t, ts, "internal")
+
+	// The other types are aliases, named like the Go ones
+	assert.Contains(t, ts, "  size?: PetSize;\n")
+	assert.Contains(t, ts, `export type PetSize = "small" | "large";`)
+	assert.Contains(t, ts, `export type Status = "available" | "sold";`)
+	assert.Contains(t, ts, "export type Pets = Pet[];")
+	assert.Contains(t, ts, "export type PetOrStatus = Pet | Status;")
+	assert.Contains(t, ts, "export interface ListPetsParams {\n  status?: Status;\n}")
+
+	// The int64 values are the numbers JSON.parse gives, unless the option
+	// says the frontend parses them into something else
+	opts.OutputOptions.TypeScriptInt64 = "bigint"
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.TypeScript, "  id: bigint;\n")
+	opts.OutputOptions.TypeScriptInt64 = "string"
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.TypeScript, "  id: string;\n")
+	opts.OutputOptions.TypeScriptInt64 = "int"
+	assert.EqualError(t, opts.Validate(), `unknown TypeScript int64 type "int", must be "number", "bigint" or "string"`)
+	opts.OutputOptions.TypeScriptInt64 = ""
+
+	opts.Generate.Models = false
+	assert.EqualError(t, opts.Validate(), "the TypeScript declarations need the models")
+}
+
+func TestDocs(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/docs.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+			Docs:   true,
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+
+	var names []string
+	pages := make(map[string]string)
+	for _, file := range output.Docs {
+		names = append(names, file.FileName)
+		pages[file.FileName] = file.Content
+	}
+	// A page per tag, in the order the spec declares them, and the untagged
+	// operations last
+	assert.Equal(t, []string{"README.md", "pet-store.md", "admin.md", "other.md", "models.md"}, names)
+
+	index := pages["README.md"]
+	assert.Contains(t, index, "# Pet store 2.0\n\nSells pets.\n")
+	assert.Contains(t, index, `client, err := api.NewClientWithResponses("https://pets.example.com/v2")`)
+	assert.Contains(t, index, "- [Pet store](pet-store.md): The pets \\| and their owners.\n")
+	assert.Contains(t, index, "  - [`GET /pets/{id}`](pet-store.md#getpet) Returns a pet.\n")
+	assert.Contains(t, index, "  - [`GET /health`](other.md#health)\n")
+
+	// The operations, with their parameters, bodies and responses, whose
+	// types link to the models, and a sample calling them with the client
+	petStore := pages["pet-store.md"]
+	assert.Contains(t, petStore, "## GetPet\n\n`GET /pets/{id}`\n\nReturns a pet.\n")
+	assert.Contains(t, petStore, "| `fields` | query | `[]string` | no | The fields to return. |\n")
+	assert.Contains(t, petStore, "| 200 | The pet | `application/json` | [`Pet`](models.md#pet) |\n")
+	assert.Contains(t, petStore, "| 404 | Not found | | |\n")
+	assert.Contains(t, petStore, "resp, err := client.GetPetWithResponse(ctx, id, &api.GetPetParams{})\n")
+	assert.Contains(t, petStore, "if resp.JSON200 != nil {\n")
+	assert.Contains(t, petStore, "## AddPet\n\n`POST /pets`\n\n**Deprecated.**\n")
+	assert.Contains(t, petStore, "resp, err := client.AddPetWithResponse(ctx, api.AddPetJSONRequestBody{})\n")
+	assert.Contains(t, pages["admin.md"], "## AddPet\n")
+	assert.Contains(t, pages["other.md"], "resp, err := client.HealthWithResponse(ctx)\n")
+
+	models := pages["models.md"]
+	assert.Contains(t, models, "## Pet\n\nA pet.\n")
+	assert.Contains(t, models, "| `friends` | `[]`[`Pet`](models.md#pet) | no |  |\n")
+	assert.Contains(t, models, "| `status` | [`Status`](models.md#status) | no |  |\n")
+	assert.Contains(t, models, "| `legacy` | `string` | no | Deprecated. Use id. |\n")
+	assert.Contains(t, models, "## Status\n\nType: `string`\n\nValues: `available`, `sold`\n")
+
+	// Without the client, there's nothing to sample
+	opts.Generate.Client = false
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	for _, file := range output.Docs {
+		assert.NotContains(t, file.Content, "WithResponse", file.FileName)
+		assert.NotContains(t, file.Content, "### Example", file.FileName)
+	}
+	assert.Equal(t, models, output.Docs[len(output.Docs)-1].Content)
+}
+
+func TestFixtures(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/fixtures.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Client:   true,
+			Fixtures: true,
+		},
+		OutputOptions: OutputOptions{
+			ScaffoldImportPath: "example.com/petstore/api",
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	fixtures := output.Fixtures
+
+	assert.Contains(t, fixtures, "package fixtures\n")
+	assert.Contains(t, fixtures, `"example.com/petstore/api"`)
+
+	// The schemas, whose examples are put together from those of their
+	// required properties
+	assert.Contains(t, fixtures, "// PetExample returns the example of the Pet schema.\nfunc PetExample() api.Pet {\n\treturn decode[api.Pet](\"PetExample\", `{\"id\":7,\"name\":\"Rex\"}`)\n}\n")
+	assert.Contains(t, fixtures, "func StatusExample() api.Status {\n\treturn decode[api.Status](\"StatusExample\", `\"available\"`)\n}\n")
+
+	// The request bodies, with a fixture per named example, quoted when they
+	// have backticks
+	assert.Contains(t, fixtures, "func AddPetRequestCatExample() api.AddPetJSONRequestBody {\n\treturn decode[api.AddPetJSONRequestBody](\"AddPetRequestCatExample\", `{\"name\":\"Tom\"}`)\n}\n")
+	assert.Contains(t, fixtures, `return decode[api.AddPetJSONRequestBody]("AddPetRequestDogExample", "{\"name\":\"Rex\",\"tag\":\"`+"`dog`"+`\"}")`)
+
+	// But not the examples that don't match their schemas
+	assert.NotContains(t, fixtures, "AddPetRequestBirdExample")
+	assert.NotContains(t, fixtures, "AgeExample")
+	assert.Contains(t, output.Diagnostics.Error(), "warning: the bird example of the application/json request body of AddPet has no fixture, as it doesn't match its schema")
+	assert.Contains(t, output.Diagnostics.Error(), "warning: the example of the Age schema has no fixture, as it doesn't match its schema")
+
+	// The responses, but those of inline structs
+	assert.Contains(t, fixtures, "func ListPets200ResponseExample() []api.Pet {\n")
+	assert.Contains(t, fixtures, "func AddPet201ResponseExample() api.Pet {\n")
+	assert.NotContains(t, fixtures, "AddPetDefaultResponseExample")
+	assert.NotEmpty(t, output.Diagnostics.Warnings())
+
+	// The generated package is aliased when its path doesn't end with its name
+	opts.OutputOptions.ScaffoldImportPath = "example.com/petstore/v2"
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.Fixtures, `api "example.com/petstore/v2"`)
+
+	opts.OutputOptions.ScaffoldImportPath = ""
+	_, err = GenerateOutput(swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestGenerators(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/generators.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Generators: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:          true,
+			ScaffoldImportPath: "example.com/petstore/api",
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	generators := output.Generators
+
+	assert.Contains(t, generators, "package generators\n")
+	assert.Contains(t, generators, "func Pet() *rapid.Generator[api.Pet] {\n\treturn rapid.Custom(func(t *rapid.T) api.Pet {\n\t\tvar value api.Pet\n")
+
+	// The constraints of the properties, and the optional ones drawn present
+	// or not
+	assert.Contains(t, generators, "value.Id = rapid.Int64Min(1).Draw(t, \"id\")\n")
+	assert.Contains(t, generators, "value.Name = rapid.StringN(1, 20, -1).Draw(t, \"name\")\n")
+	assert.Contains(t, generators, "*p0 = rapid.StringMatching(`^[A-Z]{3}-[0-9]{2}$`).Draw(t, \"code\")\n")
+	assert.Contains(t, generators, "*p0 = rapid.Int32Range(0, 29).Draw(t, \"age\")\n")
+	assert.Contains(t, generators, "return v != 0\n")
+	assert.Contains(t, generators, "if rapid.Bool().Draw(t, \"has owner\") {\n\t\t\tp0 := alloc(&value.Owner)\n\t\t\tp0.Email = openapi_types.Email(")
+	assert.Contains(t, generators, "sample(&value, t, \"Status\", \"available\", \"sold\")\n")
+	assert.Contains(t, generators, "grow(p0, rapid.IntRange(1, 3).Draw(t, \"len tags\"))\n")
+	assert.Contains(t, generators, "(*p0)[i1] = Pet().Draw(t, \"friends\")\n")
+	assert.Contains(t, generators, "rapid.SliceOfNDistinct(rapid.StringN(1, 16, -1), 0, 2, rapid.ID[string])")
+
+	// The types made of others call their generators, and the unions set
+	// one of their elements
+	assert.Contains(t, generators, "value.Status = Status().Draw(t, \"status\")\n")
+	assert.Contains(t, generators, "value[i0] = Pet().Draw(t, \"Pets\")\n")
+	assert.Contains(t, generators, "e0 := Dog().Draw(t, \"Animal\")\n\t\t\tif err := value.FromDog(e0); err != nil {\n")
+
+	opts.Generate.Models = false
+	assert.EqualError(t, opts.Validate(), "the generators need the models")
+}
+
+func TestInterfaces(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/implements.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+			InterfaceMethods: map[string][]string{
+				"Billable": {"Amount() float32"},
+			},
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The getters of the common fields, which a type implementing several
+	// interfaces gets once
+	assert.Contains(t, code, "// Resource is declared with x-implements by Owner and Pet.\ntype Resource interface {\n\tGetId() string\n\tGetName() string\n}\n")
+	assert.Contains(t, code, "type Named interface {\n\tGetId() string\n\tGetName() string\n}\n")
+	assert.Contains(t, code, "var _ Resource = (*Pet)(nil)\n")
+	assert.Contains(t, code, "func (a Pet) GetId() string {\n\treturn a.Id\n}\n")
+	assert.Equal(t, 1, strings.Count(code, "func (a Pet) GetId() string"))
+	assert.NotContains(t, code, "GetTag")
+
+	// Without fields in common, a marker method
+	assert.Contains(t, code, "type Event interface {\n\tisEvent()\n}\n")
+	assert.Contains(t, code, "func (Order) isEvent() {}\n")
+
+	// The methods implemented by hand
+	assert.Contains(t, code, "type Billable interface {\n\tAmount() float32\n}\n")
+	assert.NotContains(t, code, "func (Invoice)")
+
+	swagger.Components.Schemas["Invoice"].Value.Extensions[extImplements] = "Pet"
+	_, err = Generate(swagger, opts)
+	assert.EqualError(t, err, "error generating type definitions: error generating interfaces: the interface Pet of x-implements collides with the type Pet")
+}
+
+func TestPages(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/pages.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+			GoVersion: "1.18",
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The envelopes of the same shape are instances of Page
+	assert.Contains(t, code, "// Page is the list envelope of OwnerPage, PetPage and TagPage, of items of type T.\ntype Page[T any] struct {\n\tItems []T     `json:\"items\"`\n\tNext  *string `json:\"next,omitempty\"`\n\tTotal int     `json:\"total\"`\n}\n")
+	assert.Contains(t, code, "// PetPage A page of pets\ntype PetPage = Page[Pet]\n")
+	assert.Contains(t, code, "type OwnerPage = Page[Owner]\n")
+	assert.Contains(t, code, "type TagPage = Page[string]\n")
+
+	// But not those with methods, which an alias would lose
+	opts.Generate.DeepCopy = true
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, output.Code, "Page[")
+	assert.Contains(t, output.Code, "func (a PetPage) DeepCopy() PetPage {\n")
+	assert.Contains(t, output.Diagnostics.Error(), "warning: /components/schemas/PetPage: PetPage has DeepCopy and Equal methods, which an instance of Page can't have, so it's declared as a struct")
+	opts.Generate.DeepCopy = false
+
+	opts.OutputOptions.HotPathMarshalers = true
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, output.Code, "Page[")
+	assert.Contains(t, output.Code, "func (a TagPage) MarshalJSON() ([]byte, error) {\n")
+	assert.Contains(t, output.Diagnostics.Error(), "warning: /components/schemas/TagPage: TagPage has hot-path marshalers, which an instance of Page can't have, so it's declared as a struct")
+	opts.OutputOptions.HotPathMarshalers = false
+
+	// The envelopes of other shapes are structs
+	assert.Contains(t, code, "type Cursor struct {\n")
+
+	// Before Go 1.18, they're all structs
+	opts.OutputOptions.GoVersion = "1.17"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "Page[")
+	assert.Contains(t, code, "type PetPage struct {\n")
+
+	opts.OutputOptions.GoVersion = "go1.18"
+	assert.EqualError(t, opts.Validate(), "invalid go version \"go1.18\", which should be like 1.21")
+}
+
+func TestGoVersion(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/deep-copy.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			DeepCopy: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+
+	// Without a version, the code doesn't change
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func deepCopyJSON(v interface{}) interface{} {")
+	assert.Contains(t, code, "out.Tags = append(a.Tags[:0:0], a.Tags...)")
+
+	// From Go 1.18, any
+	opts.OutputOptions.GoVersion = "1.18"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func deepCopyJSON(v any) any {")
+	assert.NotContains(t, code, "interface{}")
+	assert.NotContains(t, code, "slices.")
+
+	// From Go 1.21, the slices and maps packages
+	opts.OutputOptions.GoVersion = "1.21.3"
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+	assert.Contains(t, code, "out.Tags = slices.Clone(a.Tags)")
+	assert.Contains(t, code, "if !slices.Equal(a.Tags, other.Tags) {")
+	assert.Contains(t, code, "out.AdditionalProperties = maps.Clone(a.AdditionalProperties)")
+	assert.Contains(t, code, "if !maps.Equal(a.AdditionalProperties, other.AdditionalProperties) {")
+
+	// The options needing a later version are ruled out
+	opts.OutputOptions.GoVersion = "1.17"
+	opts.OutputOptions.OptionalParams = true
+	assert.EqualError(t, opts.Validate(), "optional-params: needs go 1.18 or later, which has generics, but the go-version is 1.17")
+	opts.OutputOptions.OptionalParams = false
+	opts.OutputOptions.GoVersion = "1.19"
+	opts.Generate.SecurityMiddleware = true
+	assert.EqualError(t, opts.Validate(), "security-middleware: needs go 1.20 or later, which has errors.Join, but the go-version is 1.19")
+}
+
+func TestBuildTags(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/deep-copy.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			BuildTags:  "!codeanalysis",
+			GoGenerate: "go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen -config cfg.yaml api.yaml",
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+	assert.True(t, strings.HasPrefix(code, "//go:build !codeanalysis\n\n// Package api provides"))
+	assert.Contains(t, code, "package api\n\n//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen -config cfg.yaml api.yaml\n")
+
+	opts.OutputOptions.GoGenerate = GoGenerateAuto
+	_, err = Generate(swagger, opts)
+	assert.EqualError(t, err, "the go-generate command \"auto\" is only filled in by the oapi-codegen command")
+
+	opts.OutputOptions.BuildTags = "linux &&"
+	assert.ErrorContains(t, opts.Validate(), "invalid build tags \"linux &&\"")
+}
+
+func TestImportRewrites(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/contract.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
+		OutputOptions: OutputOptions{
+			ImportRewrites: map[string]string{
+				"github.com/go-chi/chi":   "corp.example/forks/chi",
+				"github.com/oapi-codegen": "corp.example/oapi-codegen",
+			},
+			ImportAliases: map[string]string{
+				"github.com/oapi-codegen/runtime/types": "oapitypes",
+			},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// The paths are rewritten, and the packages renamed where they're used
+	assert.Contains(t, code, "\t\"corp.example/forks/chi/v5\"\n")
+	assert.Contains(t, code, "\t\"corp.example/oapi-codegen/runtime\"\n")
+	assert.Contains(t, code, "\toapitypes \"corp.example/oapi-codegen/runtime/types\"\n")
+	assert.Contains(t, code, "oapitypes.UUID")
+	assert.NotContains(t, code, "openapi_types")
+	assert.NotContains(t, code, "github.com/go-chi/chi")
+	assert.Contains(t, code, "chi.NewRouter()")
+
+	// An alias taken by another import
+	opts.OutputOptions.ImportAliases["github.com/oapi-codegen/runtime/types"] = "chi"
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "are both named chi, which import-aliases can rename")
+
+	opts.OutputOptions.ImportAliases["github.com/oapi-codegen/runtime/types"] = "open-api"
+	assert.EqualError(t, opts.Validate(), "invalid import alias \"open-api\" of github.com/oapi-codegen/runtime/types, which isn't a Go identifier")
+}
+
+func TestZeroDependencies(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/zero-dependencies.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ZeroDependencies: true,
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "runtime.gen.go", []byte(output.Runtime))
+
+	// Only the standard library is imported, the runtime helpers and types
+	// being generated next to the code
+	assert.NotContains(t, output.Code, "\"github.com/")
+	assert.NotContains(t, output.Code, "runtime.")
+	assert.NotContains(t, output.Code, "openapi_types")
+	assert.Contains(t, output.Code, "Id    UUID")
+	assert.Contains(t, output.Code, "styleParamWithLocation(\"form\", true, \"tags\", paramLocationQuery, *params.Tags)")
+	assert.Contains(t, output.Code, "marshalForm(body, nil)")
+	assert.Contains(t, output.Code, "jsonMerge(t.union, b)")
+	assert.NotContains(t, output.Runtime, "\"github.com/")
+	for _, declaration := range []string{"func styleParamWithLocation(", "func marshalForm(", "func jsonMerge(", "type Date struct", "type Email string", "type UUID [16]byte", "type File struct"} {
+		assert.Contains(t, output.Runtime, declaration)
+	}
+
+	// Only the parts which are used are generated
+	opts.Generate.Client = false
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.Runtime, "func jsonMerge(")
+	assert.NotContains(t, output.Runtime, "func styleParamWithLocation(")
+
+	// A spec type taking the name of a runtime type
+	swagger.Components.Schemas["UUID"] = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+	opts.OutputOptions.SkipPrune = true
+	_, err = GenerateOutput(swagger, opts)
+	assert.EqualError(t, err, "zero-dependencies: the generated runtime declares UUID, colliding with the generated code, whose types x-go-type-name can rename")
+	delete(swagger.Components.Schemas, "UUID")
+	opts.OutputOptions.SkipPrune = false
+
+	// The servers need the runtime packages
+	opts.Generate.ChiServer = true
+	_, err = GenerateOutput(swagger, opts)
+	assert.ErrorContains(t, err, "zero-dependencies: the generated code uses github.com/oapi-codegen/runtime.BindStyledParameterWithLocation, which has no replacement in the generated runtime")
+
+	opts.Generate.ChiServer = false
+	opts.OutputOptions.SkipFmt = true
+	assert.EqualError(t, opts.Validate(), "zero-dependencies needs the code formatted, which skip-fmt turns off")
+}
+
+func TestSharedRuntime(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/zero-dependencies.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ZeroDependencies: true,
+			SharedRuntime: SharedRuntimeOptions{
+				ImportPath: "example.com/app/internal/oapiruntime",
+			},
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "runtime.gen.go", []byte(output.Runtime))
+
+	// The code refers to the shared runtime package, which exports all of
+	// the runtime under the names of the runtime packages
+	assert.Contains(t, output.Code, "\n\n\t\"example.com/app/internal/oapiruntime\"\n)")
+	assert.Contains(t, output.Code, "Id    oapiruntime.UUID")
+	assert.Contains(t, output.Code, "oapiruntime.StyleParamWithLocation(\"simple\", false, \"id\", oapiruntime.ParamLocationPath, id)")
+	assert.Contains(t, output.Code, "oapiruntime.JsonMerge(t.union, b)")
+	assert.Contains(t, output.Runtime, "package oapiruntime\n")
+	for _, declaration := range []string{"func StyleParamWithLocation(", "func MarshalForm(", "func JsonMerge(", "type Date struct", "type Email string", "type UUID [16]byte", "type File struct"} {
+		assert.Contains(t, output.Runtime, declaration)
+	}
+	assert.NotContains(t, output.Runtime, "styleParamWithLocation")
+
+	// The shared runtime is whole, even for the models alone
+	opts.Generate.Client = false
+	models, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Equal(t, output.Runtime, models.Runtime)
+
+	opts.OutputOptions.SharedRuntime.ImportPath = "example.com/app/1runtime"
+	assert.EqualError(t, opts.Validate(), "invalid shared runtime import path example.com/app/1runtime, whose package name \"1runtime\" isn't a Go identifier")
+
+	opts.OutputOptions.SharedRuntime.ImportPath = "example.com/app/oapiruntime"
+	opts.OutputOptions.ZeroDependencies = false
+	assert.EqualError(t, opts.Validate(), "the shared runtime holds the runtime of zero-dependencies, which it needs")
+}
+
+func TestNullableCollections(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/nullable-collections.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			DeepCopy: true,
+		},
+		OutputOptions: OutputOptions{
+			NullableCollections: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+	assert.Contains(t, code, "type Nullable[T any] map[bool]T")
+
+	// A nullable array or map is a Nullable, which an optional property leaves
+	// out of JSON when it's unspecified
+	assert.Regexp(t, `Aliases +Nullable\[\[\]string\] +`+"`json:\"aliases\"`", code)
+	assert.Regexp(t, `Nicknames +Nullable\[\[\]string\] +`+"`json:\"nicknames,omitempty\"`", code)
+	assert.Regexp(t, `Labels +Nullable\[map\[string\]string\] +`+"`json:\"labels,omitempty\"`", code)
+	assert.Regexp(t, `Tags +Nullable\[Tags\] +`+"`json:\"tags,omitempty\"`", code)
+	assert.Regexp(t, `Toys +Nullable\[\[\]Pet_Toys_Item\] +`+"`json:\"toys,omitempty\"`", code)
+	assert.Regexp(t, `Friends +\*\[\]string +`+"`json:\"friends,omitempty\"`", code)
+	assert.Contains(t, code, "n0 := make(Nullable[[]string], len(a.Aliases))")
+
+	// Without the option, they're pointers
+	opts.OutputOptions.NullableCollections = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "Nullable")
+	assert.Regexp(t, `Nicknames +\*\[\]string +`+"`json:\"nicknames\"`", code)
+
+	// Nullable needs generics
+	opts.OutputOptions.NullableCollections = true
+	opts.OutputOptions.GoVersion = "1.17"
+	assert.EqualError(t, opts.Validate(), "nullable-collections: needs go 1.18 or later, which has generics, but the go-version is 1.17")
+}
+
+func TestUnknownEnumValues(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/unknown-enum-values.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientValidation: true,
+		},
+	}
+
+	// By default, the client rejects the values the spec doesn't list
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "IsKnown")
+	assert.Contains(t, code, "must be one of red, green")
+
+	opts.OutputOptions.UnknownEnumValues = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	checkLint(t, "test.gen.go", []byte(code))
+	assert.Contains(t, code, "func UnknownColor(raw string) Color {")
+	assert.Contains(t, code, "func (e Color) IsKnown() bool {")
+	assert.Contains(t, code, "case Green, Red:")
+	assert.Contains(t, code, "func UnknownListPetsParamsPriority(raw int) ListPetsParamsPriority {")
+	assert.Contains(t, code, "case N1, N2, N3:")
+	assert.NotContains(t, code, "must be one of")
+
+	// The constructors mustn't collide with the constants
+	swagger.Components.Schemas["Color"].Value.Extensions = map[string]interface{}{
+		"x-enum-varnames": []interface{}{"UnknownColor", "Green"},
+	}
+	_, err = Generate(swagger, opts)
+	assert.EqualError(t, err, "error generating type definitions: error generating code for type enums: unknown-enum-values: the constructor UnknownColor of the enum Color collides with a type or an enum constant, which x-go-type-name or x-enum-varnames can rename")
+}
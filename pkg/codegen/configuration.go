@@ -2,7 +2,14 @@ package codegen
 
 import (
 	"errors"
+	"fmt"
+	"go/build/constraint"
+	"go/token"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
 )
 
 type AdditionalImport struct {
@@ -10,6 +17,10 @@ type AdditionalImport struct {
 	Package string `yaml:"package"`
 }
 
+// GoGenerateAuto is the go-generate output option having the command line
+// tool fill in the command regenerating the output file.
+const GoGenerateAuto = "auto"
+
 // Configuration defines code generation customizations
 type Configuration struct {
 	PackageName       string               `yaml:"package"` // PackageName to generate
@@ -18,6 +29,7 @@ type Configuration struct {
 	OutputOptions     OutputOptions        `yaml:"output-options,omitempty"`
 	ImportMapping     map[string]string    `yaml:"import-mapping,omitempty"` // ImportMapping specifies the golang package path for each external reference
 	AdditionalImports []AdditionalImport   `yaml:"additional-imports,omitempty"`
+	AsyncAPI          string               `yaml:"asyncapi,omitempty"` // AsyncAPI is the path of an AsyncAPI 2 document, whose message payloads and publish/subscribe helpers are generated with the models
 	// NoVCSVersionOverride allows overriding the version of the application for cases where no Version Control System (VCS) is available when building, for instance when using a Nix derivation.
 	// See documentation for how to use it in examples/no-vcs-version-override/README.md
 	NoVCSVersionOverride *string `yaml:"-"`
@@ -25,16 +37,36 @@ type Configuration struct {
 
 // GenerateOptions specifies which supported output formats to generate.
 type GenerateOptions struct {
-	IrisServer    bool `yaml:"iris-server,omitempty"`    // IrisServer specifies whether to generate iris server boilerplate
-	ChiServer     bool `yaml:"chi-server,omitempty"`     // ChiServer specifies whether to generate chi server boilerplate
-	FiberServer   bool `yaml:"fiber-server,omitempty"`   // FiberServer specifies whether to generate fiber server boilerplate
-	EchoServer    bool `yaml:"echo-server,omitempty"`    // EchoServer specifies whether to generate echo server boilerplate
-	GinServer     bool `yaml:"gin-server,omitempty"`     // GinServer specifies whether to generate gin server boilerplate
-	GorillaServer bool `yaml:"gorilla-server,omitempty"` // GorillaServer specifies whether to generate Gorilla server boilerplate
-	Strict        bool `yaml:"strict-server,omitempty"`  // Strict specifies whether to generate strict server wrapper
-	Client        bool `yaml:"client,omitempty"`         // Client specifies whether to generate client boilerplate
-	Models        bool `yaml:"models,omitempty"`         // Models specifies whether to generate type definitions
-	EmbeddedSpec  bool `yaml:"embedded-spec,omitempty"`  // Whether to embed the swagger spec in the generated code
+	IrisServer         bool `yaml:"iris-server,omitempty"`         // IrisServer specifies whether to generate iris server boilerplate
+	ChiServer          bool `yaml:"chi-server,omitempty"`          // ChiServer specifies whether to generate chi server boilerplate
+	FiberServer        bool `yaml:"fiber-server,omitempty"`        // FiberServer specifies whether to generate fiber server boilerplate
+	EchoServer         bool `yaml:"echo-server,omitempty"`         // EchoServer specifies whether to generate echo server boilerplate
+	GinServer          bool `yaml:"gin-server,omitempty"`          // GinServer specifies whether to generate gin server boilerplate
+	GorillaServer      bool `yaml:"gorilla-server,omitempty"`      // GorillaServer specifies whether to generate Gorilla server boilerplate
+	Strict             bool `yaml:"strict-server,omitempty"`       // Strict specifies whether to generate strict server wrapper
+	Client             bool `yaml:"client,omitempty"`              // Client specifies whether to generate client boilerplate
+	RequestBuilders    bool `yaml:"request-builders,omitempty"`    // RequestBuilders specifies whether to generate the request builders on their own, without the client
+	PathBuilders       bool `yaml:"path-builders,omitempty"`       // PathBuilders specifies whether to generate the Path<Operation> functions building the paths of the requests of the operations from their path parameters
+	Models             bool `yaml:"models,omitempty"`              // Models specifies whether to generate type definitions
+	EmbeddedSpec       bool `yaml:"embedded-spec,omitempty"`       // Whether to embed the swagger spec in the generated code
+	ServerURLs         bool `yaml:"server-urls,omitempty"`         // ServerURLs specifies whether to generate a registry of the servers declared in the spec, which the client honors for operations overriding them
+	SecurityMiddleware bool `yaml:"security-middleware,omitempty"` // SecurityMiddleware specifies whether to generate a net/http middleware enforcing the security requirements of operations
+	Routes             bool `yaml:"routes,omitempty"`              // Routes specifies whether to generate the route table of the operations, with their IDs, methods, paths, tags and scopes, and RouteForRequest matching requests with them
+	SelfTest           bool `yaml:"self-test,omitempty"`           // SelfTest specifies whether to generate tests checking the generated code against the examples, enums and paths of the spec
+	Fuzz               bool `yaml:"fuzz,omitempty"`                // Fuzz specifies whether to generate fuzz tests of the server parameter binding and body decoding, next to the self-test
+	ContractTest       bool `yaml:"contract-test,omitempty"`       // ContractTest specifies whether to generate a test running the client against the server, next to the self-test
+	CLI                bool `yaml:"cli,omitempty"`                 // CLI specifies whether to generate a cobra command line interface running the operations with the client
+	TerraformModels    bool `yaml:"terraform-models,omitempty"`    // TerraformModels specifies whether to generate Terraform Plugin Framework models of the object types, with conversions from and to them
+	DeepCopy           bool `yaml:"deep-copy,omitempty"`           // DeepCopy specifies whether to generate DeepCopy and Equal methods of the model types
+	KubernetesCRD      bool `yaml:"kubernetes-crd,omitempty"`      // KubernetesCRD specifies whether to annotate the models with the kubebuilder markers of the constraints of their schemas, and implement runtime.Object on the resources, the objects with apiVersion, kind and metadata properties, to reuse them as the types of custom resources
+	Constructors       bool `yaml:"constructors,omitempty"`        // Constructors specifies whether to generate constructors of the object types, taking their required properties
+	Builders           bool `yaml:"builders,omitempty"`            // Builders specifies whether to generate builders of the object types, taking their required properties and setting the optional ones
+	JSONSchema         bool `yaml:"json-schema,omitempty"`         // JSONSchema specifies whether to export the component schemas as JSON Schemas, written next to the code, with a registry of them by type name
+	TypeScript         bool `yaml:"typescript,omitempty"`          // TypeScript specifies whether to write TypeScript declarations of the model types next to the code, with the same names and optional properties, for a frontend to share them
+	Docs               bool `yaml:"docs,omitempty"`                // Docs specifies whether to write a Markdown reference of the operations and models to a docs directory next to the code, with a page per tag and code samples using the client
+	Fixtures           bool `yaml:"fixtures,omitempty"`            // Fixtures specifies whether to write a fixtures package next to the code, with functions returning the examples of the schemas, request bodies and responses as values of the generated types
+	Generators         bool `yaml:"generators,omitempty"`          // Generators specifies whether to write a generators package next to the code, with rapid generators of the valid values of the model types, for property-based tests
+	Scaffold           bool `yaml:"scaffold,omitempty"`            // Scaffold specifies whether to write a handlers.go implementing the server interface with TODO bodies next to the code, and a server/main.go serving it, which are never overwritten, but only completed with the handlers of new operations
 }
 
 // CompatibilityOptions specifies backward compatibility settings for the
@@ -94,10 +126,273 @@ type OutputOptions struct {
 	ExcludeTags   []string          `yaml:"exclude-tags,omitempty"`   // Exclude operations that have one of these tags. Ignored when empty.
 	UserTemplates map[string]string `yaml:"user-templates,omitempty"` // Override built-in templates from user-provided files
 
+	IncludeOperationIds []string `yaml:"include-operation-ids,omitempty"` // Only include the operations with these IDs. Ignored when empty.
+	ExcludeOperationIds []string `yaml:"exclude-operation-ids,omitempty"` // Exclude the operations with these IDs. Ignored when empty.
+
 	ExcludeSchemas      []string `yaml:"exclude-schemas,omitempty"`      // Exclude from generation schemas with given names. Ignored when empty.
 	ResponseTypeSuffix  string   `yaml:"response-type-suffix,omitempty"` // The suffix used for responses types
 	ClientTypeName      string   `yaml:"client-type-name,omitempty"`     // Override the default generated client type with the value
 	InitialismOverrides bool     `yaml:"initialism-overrides,omitempty"` // Whether to use the initialism overrides
+
+	ClientCircuitBreaker *CircuitBreakerOptions `yaml:"client-circuit-breaker,omitempty"` // Wrap client calls in a circuit breaker when set
+	ClientCache          *ClientCacheOptions    `yaml:"client-cache,omitempty"`           // Cache the responses of the cacheable GET operations in the client when set
+	EchoVersion          int                    `yaml:"echo-version,omitempty"`           // The major version of echo to generate the echo server for, 4 when unset, or 5
+	ChiRender            bool                   `yaml:"chi-render,omitempty"`             // Generate helpers writing the JSON responses of the chi server with go-chi/render
+	Parallelism          int                    `yaml:"parallelism,omitempty"`            // How many schemas, operations and templates are generated concurrently, the number of CPUs when unset
+	BundleExternalRefs   bool                   `yaml:"bundle-external-refs,omitempty"`   // Move what the spec references in documents missing from the import mapping into its components, and generate it
+	PromoteInlineSchemas bool                   `yaml:"promote-inline-schemas,omitempty"` // Move the inline object schemas of the spec into its components, under names made of their operation or component, location and property path, and generate them as named types
+	MaxInlineDepth       int                    `yaml:"max-inline-depth,omitempty"`       // How many levels deep the inline object schemas may nest as anonymous structs, those nested deeper being promoted like with promote-inline-schemas, unlimited when unset
+	InterfaceMethods     map[string][]string    `yaml:"interface-methods,omitempty"`      // The methods of the interfaces declared with x-implements, by interface name, like GetKind() string, which the types implement by hand, instead of the generated getters of their common fields
+	GoVersion            string                 `yaml:"go-version,omitempty"`             // The lowest version of Go the generated code has to compile with, like 1.21, which enables the features of the versions up to it, like any instead of interface{} and a generic Page type of the list envelopes from 1.18, or the slices and maps packages from 1.21, and rules out the options needing a later one
+	BuildTags            string                 `yaml:"build-tags,omitempty"`             // The build constraint of the generated files, like !codeanalysis, declared with a //go:build line
+	GoGenerate           string                 `yaml:"go-generate,omitempty"`            // The command of a //go:generate line added to the output file, regenerating it, like go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen -config cfg.yaml api.yaml, which auto has the command line tool fill in
+	ImportRewrites       map[string]string      `yaml:"import-rewrites,omitempty"`        // Rewrite the import paths of the generated code starting with the keys to start with the values instead, like github.com/labstack/echo/v4 to an internal fork of echo
+	ImportAliases        map[string]string      `yaml:"import-aliases,omitempty"`         // The names the generated code imports packages with, by import path, like oapitypes for github.com/oapi-codegen/runtime/types
+	ZeroDependencies     bool                   `yaml:"zero-dependencies,omitempty"`      // Whether the generated code only imports the standard library, with the helpers and types of github.com/oapi-codegen/runtime it uses generated in a runtime file next to it, for clients and models without a server
+	SharedRuntime        SharedRuntimeOptions   `yaml:"shared-runtime,omitempty"`         // The package the runtime of zero-dependencies is shared in by the generated packages, instead of being generated in each of them
+	MirrorJsonTags       []string               `yaml:"mirror-json-tags,omitempty"`       // Tags added to every struct field with the value of its json tag, like yaml, bson or db
+	TimeTypes            TimeTypesOptions       `yaml:"time-types,omitempty"`             // The Go types of the date, date-time and time string formats
+	DecimalType          string                 `yaml:"decimal-type,omitempty"`           // The implementation of the Decimal type of decimal numbers, "shopspring" for shopspring/decimal or "big" for math/big
+	JsonNumber           bool                   `yaml:"json-number,omitempty"`            // Decode the numbers of interface{} values as json.Number rather than float64, so that large integers stay exact
+	NullableCollections  bool                   `yaml:"nullable-collections,omitempty"`   // Wrap the nullable array and map properties in a generated Nullable type, telling apart null, empty and left out, rather than pointers, which can't tell null from left out
+	UnknownEnumValues    bool                   `yaml:"unknown-enum-values,omitempty"`    // Generate the IsKnown methods of the enum types and their Unknown constructors, like UnknownColor, for the clients to tell apart the values which servers add to enums, which client-validation then lets through
+	FormatMapping        map[string]string      `yaml:"format-mapping,omitempty"`         // The Go types of string formats, overriding the default ones, like uuid.UUID for uuid, URL for uri, netip.Addr for ipv4 and ipv6, or Email for email
+	FieldOrder           string                 `yaml:"field-order,omitempty"`            // The order of the fields of the generated structs, "alphabetical" (the default), "spec" for that of the properties in the spec, or "alignment" for the least padding
+	DbTags               string                 `yaml:"db-tags,omitempty"`                // Add database tags to the fields of the models, "gorm" for gorm column tags or "ent" for the sql tags ent scans, with TableName methods of the models declared with x-db-table
+	HotPathMarshalers    bool                   `yaml:"hot-path-marshalers,omitempty"`    // Generate MarshalJSON and UnmarshalJSON methods encoding and decoding the JSON of all the struct models without reflection, rather than only that of those declared with x-hot-path
+	ClientErrorTypes     bool                   `yaml:"client-error-types,omitempty"`     // Return typed errors from the client with responses, telling transport, body read, decoding and API errors apart with errors.Is and errors.As, with an *APIError for the responses with an error status
+	StrictDecoding       bool                   `yaml:"strict-decoding,omitempty"`        // Decode all the JSON responses of the client strictly, rejecting unknown fields, while StrictDecoding is on, rather than only those of the schemas declared with x-strict-decoding
+	PatchBodies          bool                   `yaml:"patch-bodies,omitempty"`           // Generate the JSONPatch type of the application/json-patch+json request bodies, and JSON Merge Patch types with tri-state fields for the application/merge-patch+json ones, rather than the types of their schemas
+	PatchTriStateFields  bool                   `yaml:"patch-tri-state-fields,omitempty"` // Generate the JSON request bodies of the PATCH operations, of object schemas, as their JSON Merge Patch types with tri-state fields, left out, null or set, like the application/merge-patch+json ones with patch-bodies
+	ClientServices       bool                   `yaml:"client-services,omitempty"`        // Generate a method of the clients per tag, like Pets, returning the interface of the client operations of the tag, for a service-struct layout of large APIs
+	APIVersion           *APIVersionOptions     `yaml:"api-version,omitempty"`            // Send the version of the API with every request of the client, in a header or a path prefix, overriding the x-api-version extension of the spec, and generate the APIVersion constants
+
+	ClientBufferPool       *ClientBufferPoolOptions   `yaml:"client-buffer-pool,omitempty"`       // Reuse pooled buffers for the JSON request bodies and the response bodies of the client when set
+	ClientResponseDecoding string                     `yaml:"client-response-decoding,omitempty"` // How the client with responses decodes response bodies, "eager" (the default), "lazy" for Decode methods decoding them on demand, or "drop-body" to drop the Body once decoded
+	ClientTransportOptions bool                       `yaml:"client-transport-options,omitempty"` // Generate client options tuning the transport of the default http.Client, its connection limits, TLS config, proxy and dialer, or forcing HTTP/2
+	ClientUnixSocket       bool                       `yaml:"client-unix-socket,omitempty"`       // Generate the client with WithUnixSocket, and unix:// server URLs, to talk to servers over a Unix domain socket, along with the transport options, as for a server declared with x-server-transport
+	ClientCapture          bool                       `yaml:"client-capture,omitempty"`           // Generate the WithCapture client option, recording the requests and responses in a HAR-like format, with the values declared with x-sensitive redacted, and the ReplayTransport answering requests from such captures
+	ClientCassettes        bool                       `yaml:"client-cassettes,omitempty"`         // Generate the CassetteTransport, recording the interactions of the client to a cassette file on the first run of a test, and replaying them afterwards, matching the requests on their operation, path, query and JSON body, but for the values declared with x-volatile, with the values declared with x-sensitive redacted
+	ClientResponseBase     *ClientResponseBaseOptions `yaml:"client-response-base,omitempty"`     // Embed a type in every response of the client with responses, populated after each call by the ResponseHook set with WithResponseHook, for the metadata of all the calls, like request IDs or timings
+	ClientHooks            bool                       `yaml:"client-hooks,omitempty"`             // Generate the ClientHooks of the client, called with the ID of the operation before sending every request, and with its response or error, along with the constants of the operation IDs
+	ProblemResponses       bool                       `yaml:"problem-responses,omitempty"`        // Respond to the requests whose parameters or body the server can't bind with RFC 7807 application/problem+json documents of the generated Problem type, rather than plain text
+	ContextHandlers        bool                       `yaml:"context-handlers,omitempty"`         // Generate the ContextServerInterface of the strict server, whose handlers take a context.Context with the typed path parameters, params and body of the operations, and NewContextHandler mounting it with NewStrictHandler on any router
+	ScaffoldImportPath     string                     `yaml:"scaffold-import-path,omitempty"`     // The import path of the generated package, which the scaffolded main.go, the fixtures and the generators import, found from the go.mod of the output file when unset
+	Provenance             *ProvenanceOptions         `yaml:"provenance,omitempty"`               // Record the version of the generator and the hashes of the spec and the configuration in the header of the output file, and generate GeneratedFrom returning them
+	OperationContext       bool                       `yaml:"operation-context,omitempty"`        // Put the ID, path template and required scopes of the operation which the server routed a request to in the context of the request, read with OperationFromContext and the like by middleware and handlers
+	ClientCoalescing       bool                       `yaml:"client-coalescing,omitempty"`        // Generate the CoalescingClient wrapping the client with responses, sharing a single request and its response among the concurrent calls of a GET operation with the same parameters
+	ClientCallAll          bool                       `yaml:"client-call-all,omitempty"`          // Generate a CallAll function per operation, calling it with each of a slice of parameter sets with bounded concurrency, and returning the typed results in order
+	OptionalParams         bool                       `yaml:"optional-params,omitempty"`          // Hold the query parameters allowing empty values, and the nullable query and header parameters, in OptionalParam fields telling apart the parameters left out, sent without a value, and sent empty
+	ParamErrors            bool                       `yaml:"param-errors,omitempty"`             // Check all the parameters of a request before binding them, answering the requests with any which can't be bound with the ParamErrors of all of them, with their name, location, expected type and received value, rather than with the first error
+	ClientValidation       bool                       `yaml:"client-validation,omitempty"`        // Check the parameters of the requests against the constraints of the spec on their lengths, patterns, bounds, enums and numbers of items, and their bodies against those on their properties, or with their Validate methods, before building them, returning a RequestValidationError rather than sending them
+	TypeScriptInt64        string                     `yaml:"typescript-int64,omitempty"`         // The TypeScript type of the int64 and uint64 values of the typescript declarations, "number" (the default), which JSON.parse decodes them into, or "bigint" or "string" for the frontends parsing them into those to keep those beyond 2^53 exact
+}
+
+// The ways the client with responses decodes the bodies of the responses, set
+// with the client-response-decoding output option.
+const (
+	ResponseDecodingEager    = "eager"
+	ResponseDecodingLazy     = "lazy"
+	ResponseDecodingDropBody = "drop-body"
+)
+
+// TimeTypesOptions chooses the Go types of the string formats of dates and
+// times, in bodies, parameters and headers alike. The x-go-time-format
+// extension takes precedence over them, with a type of its own formatting
+// times with a custom layout.
+type TimeTypesOptions struct {
+	Date     string `yaml:"date,omitempty"`      // "openapi" for openapi_types.Date (the default), "date" for a Date type generated with the code, "time" for time.Time, or "civil" for civil.Date
+	DateTime string `yaml:"date-time,omitempty"` // "time" for time.Time (the default), or "civil" for civil.DateTime
+	Time     string `yaml:"time,omitempty"`      // "string" (the default), or "civil" for civil.Time
+}
+
+// Validate checks whether TimeTypesOptions represent a valid configuration
+func (o TimeTypesOptions) Validate() error {
+	switch o.Date {
+	case "", "openapi", "date", "time", "civil":
+	default:
+		return fmt.Errorf("unknown date type %q, must be \"openapi\", \"date\", \"time\" or \"civil\"", o.Date)
+	}
+	switch o.DateTime {
+	case "", "time", "civil":
+	default:
+		return fmt.Errorf("unknown date-time type %q, must be \"time\" or \"civil\"", o.DateTime)
+	}
+	switch o.Time {
+	case "", "string", "civil":
+	default:
+		return fmt.Errorf("unknown time type %q, must be \"string\" or \"civil\"", o.Time)
+	}
+	return nil
+}
+
+// SharedRuntimeOptions configures the package which the generated packages
+// of a module share the helpers and types of zero-dependencies in, rather than
+// each declaring them. The runtime is written to the package by the
+// generation configuring its output, and only referenced by the others. It
+// only holds the replacements of the runtime packages: the helpers of the
+// other options, like OptionalParam, Nullable, PatchField and the jsonLexer of
+// the hot-path marshalers, are still declared by each package.
+type SharedRuntimeOptions struct {
+	ImportPath string `yaml:"import-path,omitempty"` // The import path of the shared runtime package, like example.com/app/internal/oapiruntime
+	Output     string `yaml:"output,omitempty"`      // The file to write the shared runtime to, like internal/oapiruntime/runtime.gen.go, unless another generation writes it
+}
+
+// Validate checks whether SharedRuntimeOptions represent a valid
+// configuration
+func (o SharedRuntimeOptions) Validate() error {
+	if o.ImportPath == "" {
+		if o.Output != "" {
+			return errors.New("the shared runtime needs an import path")
+		}
+		return nil
+	}
+	if name := assumedPackageName(o.ImportPath); !token.IsIdentifier(name) {
+		return fmt.Errorf("invalid shared runtime import path %s, whose package name %q isn't a Go identifier", o.ImportPath, name)
+	}
+	if o.Output != "" && !strings.HasSuffix(o.Output, ".go") {
+		return fmt.Errorf("the shared runtime output %s must be a .go file", o.Output)
+	}
+	return nil
+}
+
+// ProvenanceOptions configures the record of what the code was generated
+// from. The output only changes with the spec, the configuration and the
+// generator, unless the time of generation is recorded too.
+type ProvenanceOptions struct {
+	Timestamp bool `yaml:"timestamp,omitempty"` // Record the time of generation, which changes the output on every run
+}
+
+// CircuitBreakerOptions configures the circuit breaker which the generated
+// client consults before every request. Any implementation of the generated
+// CircuitBreaker interface can be plugged in at runtime; these settings only
+// affect the default one created by NewClient.
+type CircuitBreakerOptions struct {
+	Scope            string `yaml:"scope,omitempty"`             // Either "host" (the default) or "operation", which calls share a breaker
+	FailureThreshold int    `yaml:"failure-threshold,omitempty"` // Consecutive failures after which the breaker opens, 5 when unset
+	OpenTimeout      string `yaml:"open-timeout,omitempty"`      // How long the breaker stays open before a trial call, 30s when unset
+}
+
+// Validate checks whether CircuitBreakerOptions represent a valid configuration
+func (o CircuitBreakerOptions) Validate() error {
+	switch o.Scope {
+	case "", "host", "operation":
+	default:
+		return fmt.Errorf("unknown circuit breaker scope %q, must be \"host\" or \"operation\"", o.Scope)
+	}
+	if o.FailureThreshold < 0 {
+		return errors.New("circuit breaker failure threshold must not be negative")
+	}
+	if o.OpenTimeout != "" {
+		d, err := time.ParseDuration(o.OpenTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid circuit breaker open timeout: %w", err)
+		}
+		if d <= 0 {
+			return errors.New("circuit breaker open timeout must be positive")
+		}
+	}
+	return nil
+}
+
+// ClientCacheOptions configures the cache of the responses of the GET
+// operations declared cacheable with x-cacheable, which the generated client
+// stores in its ResponseCache, following their Cache-Control, ETag and
+// Last-Modified headers.
+type ClientCacheOptions struct {
+	Operations []string `yaml:"operations,omitempty"`  // The IDs of other GET operations whose responses are cached, as in the spec
+	MaxEntries int      `yaml:"max-entries,omitempty"` // How many responses the default memory cache holds, evicting the least recently used ones, 1000 when unset
+}
+
+// Validate checks whether ClientCacheOptions represent a valid configuration
+func (o ClientCacheOptions) Validate() error {
+	if o.MaxEntries < 0 {
+		return errors.New("client cache max entries must not be negative")
+	}
+	return nil
+}
+
+// ClientBufferPoolOptions configures the pool of buffers which the generated
+// client encodes JSON request bodies into and reads response bodies with,
+// reusing them across calls rather than allocating new ones every time.
+type ClientBufferPoolOptions struct {
+	MaxBufferSize int `yaml:"max-buffer-size,omitempty"` // The capacity in bytes above which a used buffer is dropped rather than returned to the pool, 65536 when unset
+}
+
+// Validate checks whether ClientBufferPoolOptions represent a valid configuration
+func (o ClientBufferPoolOptions) Validate() error {
+	if o.MaxBufferSize < 0 {
+		return errors.New("client buffer pool max buffer size must not be negative")
+	}
+	return nil
+}
+
+// APIVersionOptions declares how the client sends the version of the API
+// with every request, in a header, a path prefix or both.
+type APIVersionOptions struct {
+	Header     string `yaml:"header,omitempty"`      // The header sending the version, like X-API-Version
+	PathPrefix string `yaml:"path-prefix,omitempty"` // The prefix of the paths of the requests, in which {version} is replaced by the version, like /v{version}
+	Version    string `yaml:"version,omitempty"`     // The version, the info.version of the spec when unset
+}
+
+// Validate checks whether APIVersionOptions represent a valid configuration
+func (o APIVersionOptions) Validate() error {
+	if o.Header == "" && o.PathPrefix == "" {
+		return errors.New("the API version needs a header or a path prefix to be sent in")
+	}
+	if o.Header != "" && strings.IndexFunc(o.Header, func(r rune) bool {
+		// The characters of tokens, which header names are
+		return !(r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("!#$%&'*+-.^_`|~", r)))
+	}) != -1 {
+		return fmt.Errorf("the API version header %q isn't a valid header name", o.Header)
+	}
+	if o.PathPrefix != "" && (!strings.HasPrefix(o.PathPrefix, "/") || strings.HasSuffix(o.PathPrefix, "/")) {
+		return fmt.Errorf("the API version path prefix %q must start with a slash, and not end with one", o.PathPrefix)
+	}
+	return nil
+}
+
+// ClientResponseBaseOptions declares the type which every response of the
+// client with responses embeds, holding the metadata common to all the calls.
+type ClientResponseBaseOptions struct {
+	Type   string `yaml:"type"`             // The type, like ResponseMeta when it's declared in the package of the generated code, or meta.Info
+	Import string `yaml:"import,omitempty"` // The import path of the package of a qualified type, like github.com/acme/api/meta
+}
+
+// Validate checks whether ClientResponseBaseOptions represent a valid configuration
+func (o ClientResponseBaseOptions) Validate() error {
+	if !responseBaseTypeRegexp.MatchString(o.Type) {
+		return fmt.Errorf("the client response base type %q must be the name of a type, qualified by its package or not", o.Type)
+	}
+	if o.PackageName() != "" && o.Import == "" {
+		return fmt.Errorf("the client response base type %q needs the import path of its package", o.Type)
+	}
+	if o.PackageName() == "" && o.Import != "" {
+		return fmt.Errorf("the client response base type %q must be qualified by the package imported from %s", o.Type, o.Import)
+	}
+	switch o.FieldName() {
+	case "Body", "HTTPResponse":
+		return fmt.Errorf("the client response base type %q conflicts with the %s field of the responses", o.Type, o.FieldName())
+	}
+	return nil
+}
+
+// responseBaseTypeRegexp matches the names of the types which the responses
+// can embed.
+var responseBaseTypeRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*\.)?[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PackageName returns the package which qualifies the type, if any.
+func (o ClientResponseBaseOptions) PackageName() string {
+	if i := strings.LastIndex(o.Type, "."); i != -1 {
+		return o.Type[:i]
+	}
+	return ""
+}
+
+// FieldName returns the name of the embedded field of the responses, that of
+// the type without its package.
+func (o ClientResponseBaseOptions) FieldName() string {
+	return o.Type[strings.LastIndex(o.Type, ".")+1:]
 }
 
 // UpdateDefaults sets reasonable default values for unset fields in Configuration
@@ -138,5 +433,201 @@ func (o Configuration) Validate() error {
 	if nServers > 1 {
 		return errors.New("only one server type is supported at a time")
 	}
+	switch o.OutputOptions.EchoVersion {
+	case 0, 4:
+	case 5:
+		if o.Generate.Strict && o.Generate.EchoServer {
+			return errors.New("the strict server doesn't support echo v5 yet")
+		}
+	default:
+		return fmt.Errorf("unsupported echo version %d, must be 4 or 5", o.OutputOptions.EchoVersion)
+	}
+	if o.Generate.ContractTest {
+		g := o.Generate
+		if !g.Client || !g.EmbeddedSpec || !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer) {
+			return errors.New("the contract test needs the client, the embedded spec, and a chi, gorilla, echo, gin or fiber server")
+		}
+	}
+	if o.OutputOptions.ProblemResponses {
+		g := o.Generate
+		if !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer) {
+			return errors.New("the problem responses need a chi, gorilla, echo, gin, fiber or iris server")
+		}
+	}
+	if v := o.OutputOptions.GoVersion; v != "" && !goVersion.MatchString(v) {
+		return fmt.Errorf("invalid go version %q, which should be like 1.21", v)
+	}
+	if tags := o.OutputOptions.BuildTags; tags != "" {
+		if _, err := constraint.Parse("//go:build " + tags); err != nil {
+			return fmt.Errorf("invalid build tags %q: %w", tags, err)
+		}
+	}
+	if strings.Contains(o.OutputOptions.GoGenerate, "\n") {
+		return errors.New("the go:generate command can't span several lines")
+	}
+	for from, to := range o.OutputOptions.ImportRewrites {
+		if from == "" || to == "" {
+			return fmt.Errorf("invalid import rewrite of %q to %q, which can't be empty", from, to)
+		}
+	}
+	for importPath, alias := range o.OutputOptions.ImportAliases {
+		if !token.IsIdentifier(alias) {
+			return fmt.Errorf("invalid import alias %q of %s, which isn't a Go identifier", alias, importPath)
+		}
+	}
+	if o.OutputOptions.ZeroDependencies {
+		if o.OutputOptions.SkipFmt {
+			return errors.New("zero-dependencies needs the code formatted, which skip-fmt turns off")
+		}
+		if o.Generate.Generators {
+			return errors.New("the generators use rapid, which zero-dependencies rules out")
+		}
+	}
+	if err := o.OutputOptions.SharedRuntime.Validate(); err != nil {
+		return err
+	}
+	if o.OutputOptions.SharedRuntime.ImportPath != "" && !o.OutputOptions.ZeroDependencies {
+		return errors.New("the shared runtime holds the runtime of zero-dependencies, which it needs")
+	}
+	for _, feature := range goVersionFeatures {
+		if feature.enabled(o) {
+			if err := checkGoVersion(o.OutputOptions.GoVersion, feature.name, feature.minor, feature.needs); err != nil {
+				return err
+			}
+		}
+	}
+	if o.OutputOptions.MaxInlineDepth < 0 {
+		return errors.New("the max inline depth can't be negative")
+	}
+	if o.OutputOptions.MaxInlineDepth != 0 && o.OutputOptions.PromoteInlineSchemas {
+		return errors.New("the max inline depth can't be set with promote-inline-schemas, which promotes all the inline schemas")
+	}
+	if o.OutputOptions.ParamErrors {
+		g := o.Generate
+		if !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer) {
+			return errors.New("the param errors need a chi, gorilla, echo, gin, fiber or iris server")
+		}
+	}
+	if o.Generate.SecurityMiddleware {
+		g := o.Generate
+		if g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer {
+			return errors.New("the security middleware is only applied by the chi and gorilla servers, so generate it on its own to wrap it around an echo, gin, fiber or iris server")
+		}
+	}
+	if o.OutputOptions.OperationContext {
+		g := o.Generate
+		if !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer) {
+			return errors.New("the operation context needs a chi, gorilla, echo, gin, fiber or iris server")
+		}
+	}
+	if o.OutputOptions.ContextHandlers {
+		g := o.Generate
+		if !g.Strict || !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer) {
+			return errors.New("the context handlers need the strict server of a chi, gorilla, echo, gin, fiber or iris server")
+		}
+	}
+	if o.Generate.Scaffold {
+		g := o.Generate
+		if !(g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer || g.IrisServer) {
+			return errors.New("the scaffold needs a chi, gorilla, echo, gin, fiber or iris server")
+		}
+	}
+	if o.Generate.CLI && !o.Generate.Client {
+		return errors.New("the CLI needs the client")
+	}
+	if o.OutputOptions.ClientCoalescing && !o.Generate.Client {
+		return errors.New("the client coalescing needs the client")
+	}
+	if o.OutputOptions.ClientCallAll && !o.Generate.Client {
+		return errors.New("the CallAll functions need the client")
+	}
+	if o.OutputOptions.ClientValidation && !(o.Generate.Client || o.Generate.RequestBuilders) {
+		return errors.New("the client validation needs the client or the request builders")
+	}
+	if o.Generate.TerraformModels && !o.Generate.Models {
+		return errors.New("the Terraform models need the models")
+	}
+	if o.Generate.DeepCopy && !o.Generate.Models {
+		return errors.New("the DeepCopy and Equal methods need the models")
+	}
+	if o.Generate.KubernetesCRD && !(o.Generate.Models && o.Generate.DeepCopy) {
+		return errors.New("the Kubernetes CRD types need the models and the DeepCopy methods")
+	}
+	if (o.Generate.Constructors || o.Generate.Builders) && !o.Generate.Models {
+		return errors.New("the constructors and builders need the models")
+	}
+	if o.Generate.JSONSchema && !o.Generate.Models {
+		return errors.New("the JSON Schema registry needs the models")
+	}
+	if o.Generate.TypeScript && !o.Generate.Models {
+		return errors.New("the TypeScript declarations need the models")
+	}
+	if o.Generate.Fixtures && !o.Generate.Models {
+		return errors.New("the fixtures need the models")
+	}
+	if o.Generate.Generators && !o.Generate.Models {
+		return errors.New("the generators need the models")
+	}
+	if o.OutputOptions.Parallelism < 0 {
+		return errors.New("parallelism must not be negative")
+	}
+	if err := o.OutputOptions.TimeTypes.Validate(); err != nil {
+		return err
+	}
+	switch o.OutputOptions.DecimalType {
+	case "", "shopspring", "big":
+	default:
+		return fmt.Errorf("unknown decimal type %q, must be \"shopspring\" or \"big\"", o.OutputOptions.DecimalType)
+	}
+	switch o.OutputOptions.TypeScriptInt64 {
+	case "", "number", "bigint", "string":
+	default:
+		return fmt.Errorf("unknown TypeScript int64 type %q, must be \"number\", \"bigint\" or \"string\"", o.OutputOptions.TypeScriptInt64)
+	}
+	switch o.OutputOptions.ClientResponseDecoding {
+	case "", ResponseDecodingEager, ResponseDecodingLazy, ResponseDecodingDropBody:
+	default:
+		return fmt.Errorf("unknown client response decoding %q, must be \"eager\", \"lazy\" or \"drop-body\"", o.OutputOptions.ClientResponseDecoding)
+	}
+	switch o.OutputOptions.DbTags {
+	case "", DbTagsGorm, DbTagsEnt:
+	default:
+		return fmt.Errorf("unknown db tags %q, must be \"gorm\" or \"ent\"", o.OutputOptions.DbTags)
+	}
+	switch o.OutputOptions.FieldOrder {
+	case "", FieldOrderAlphabetical, FieldOrderSpec, FieldOrderAlignment:
+	default:
+		return fmt.Errorf("unknown field order %q, must be \"alphabetical\", \"spec\" or \"alignment\"", o.OutputOptions.FieldOrder)
+	}
+	for format, goType := range o.OutputOptions.FormatMapping {
+		if goType == "" {
+			return fmt.Errorf("the format mapping of %q has no Go type", format)
+		}
+	}
+	if o.OutputOptions.ClientCircuitBreaker != nil {
+		if err := o.OutputOptions.ClientCircuitBreaker.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.OutputOptions.ClientCache != nil {
+		if err := o.OutputOptions.ClientCache.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.OutputOptions.ClientBufferPool != nil {
+		if err := o.OutputOptions.ClientBufferPool.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.OutputOptions.APIVersion != nil {
+		if err := o.OutputOptions.APIVersion.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.OutputOptions.ClientResponseBase != nil {
+		if err := o.OutputOptions.ClientResponseBase.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
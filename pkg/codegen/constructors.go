@@ -0,0 +1,148 @@
+package codegen
+
+import (
+	"strings"
+	"text/template"
+)
+
+// ModelConstructor describes the constructor of an object type, taking its
+// required properties, and the builder setting its optional ones.
+type ModelConstructor struct {
+	TypeName    string             // The name of the type, like Pet
+	Constructor string             // The name of the constructor, like NewPet, empty when it isn't generated
+	Builder     string             // The name of the builder type, like PetBuilder, empty when it isn't generated
+	Required    []ConstructorField // The fields of the required properties, which the constructors take
+	Optional    []ConstructorField // The fields of the optional properties, which the builder sets
+}
+
+// ConstructorField describes a field of an object type which a constructor
+// or builder sets.
+type ConstructorField struct {
+	Name    string // The Go name of the field
+	Param   string // The name of the parameter of the constructors setting it, like name
+	Type    string // The type of the value it's set to
+	Pointer bool   // Whether the field points to the value, like optional properties do
+}
+
+// generatedConstructors are the functions of the generated code which
+// constructors could collide with.
+var generatedConstructors = []string{
+	"NewClient", "NewClientWithResponses", "NewStrictHandler", "NewStrictHandlerWithOptions",
+	"NewCircuitBreaker", "NewMemoryCache", "NewCLI",
+}
+
+// DescribeConstructors describes the constructors, and the builders when
+// builders is set, of the object types among the given ones. Those whose
+// names are taken, like NewPet when the spec has a NewPet schema, are left
+// out with a warning.
+func DescribeConstructors(types []TypeDefinition, ops []OperationDefinition, constructors, builders bool) []ModelConstructor {
+	defined := make(map[string]TypeDefinition)
+	taken := make(map[string]bool)
+	for _, td := range types {
+		defined[td.TypeName] = td
+		taken[td.TypeName] = true
+	}
+	for _, name := range generatedConstructors {
+		taken[name] = true
+	}
+	available := func(name string) bool {
+		for _, op := range ops {
+			// The request builders, like NewAddPetRequestWithBody
+			if strings.HasPrefix(name, "New"+op.OperationId+"Request") {
+				return false
+			}
+		}
+		return !taken[name]
+	}
+
+	var result []ModelConstructor
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if td.IsAlias() || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+
+		// Types defined as other types have their fields.
+		schema := td.Schema
+		for i := 0; i < len(defined); i++ {
+			other, found := defined[schema.TypeDecl()]
+			if !found || other.TypeName == td.TypeName {
+				break
+			}
+			schema = other.Schema
+		}
+		if schema.IsRef() || schema.TimeFormat != "" || len(schema.UnionElements) != 0 ||
+			(!strings.HasPrefix(schema.GoType, "struct") && len(schema.TupleElements) == 0) {
+			continue
+		}
+
+		model := ModelConstructor{TypeName: td.TypeName}
+		for _, p := range schema.Properties {
+			field := constructorField(p.structFieldName(), p.structFieldType())
+			if p.Required && !p.JsonIgnored() {
+				field.Pointer = false
+				field.Type = p.structFieldType()
+				model.Required = append(model.Required, field)
+			} else {
+				model.Optional = append(model.Optional, field)
+			}
+		}
+		for _, element := range schema.TupleElements {
+			field := constructorField(element.GoName, element.GoTypeDef())
+			if element.Optional {
+				model.Optional = append(model.Optional, field)
+			} else {
+				model.Required = append(model.Required, field)
+			}
+		}
+		if schema.TupleRest != nil {
+			model.Optional = append(model.Optional, constructorField("Rest", "[]"+schema.TupleRest.TypeDecl()))
+		}
+
+		path := jsonPointer("components", "schemas", td.JsonName)
+		if constructors {
+			if name := "New" + td.TypeName; available(name) {
+				model.Constructor = name
+				taken[name] = true
+			} else {
+				warn(nil, path, "the %s constructor of %s collides with another type or function, so it isn't generated; x-go-type-name can rename %s", name, td.TypeName, td.TypeName)
+			}
+		}
+		if builders {
+			if name := td.TypeName + "Builder"; available(name) && available("New"+name) {
+				model.Builder = name
+				taken[name] = true
+				taken["New"+name] = true
+			} else {
+				warn(nil, path, "the %s builder of %s collides with another type or function, so it isn't generated; x-go-type-name can rename %s", name, td.TypeName, td.TypeName)
+			}
+		}
+		if model.Constructor != "" || model.Builder != "" {
+			result = append(result, model)
+		}
+	}
+	return result
+}
+
+// constructorField describes the field of a given name and type, which
+// optional fields are set to a pointer to a value of, when it's a pointer.
+func constructorField(name, fieldType string) ConstructorField {
+	param := LowercaseFirstCharacter(name)
+	if IsGoKeyword(param) || IsPredeclaredGoIdentifier(param) {
+		param = "p" + name
+	}
+	valueType, pointer := strings.CutPrefix(fieldType, "*")
+	return ConstructorField{
+		Name:    name,
+		Param:   param,
+		Type:    valueType,
+		Pointer: pointer,
+	}
+}
+
+// GenerateConstructors generates the constructors, and the builders when
+// builders is set, of the object types.
+func GenerateConstructors(t *template.Template, types []TypeDefinition, ops []OperationDefinition, constructors, builders bool) (string, error) {
+	return GenerateTemplates([]string{"constructors.tmpl"}, t, DescribeConstructors(types, ops, constructors, builders))
+}
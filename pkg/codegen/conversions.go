@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EquivalentType is a type of another package, like that of another version of
+// the API, with the same JSON shape as a type of the spec, declared with
+// x-equivalent-to.
+type EquivalentType struct {
+	Import   goImport // The package of the type, named after the last element of its path by default
+	TypeName string   // The name of the type in the package, that of the type of the spec by default
+}
+
+// Conversion describes the functions converting a type of the spec to and
+// from an EquivalentType.
+type Conversion struct {
+	TypeName   string // The type of the spec, like Pet
+	Package    string // The name of the package of the equivalent type, like v1
+	Equivalent string // The name of the equivalent type, like Pet
+}
+
+// Suffix returns the suffix of the names of the functions of the conversion,
+// after its package, like V1 in PetFromV1.
+func (c Conversion) Suffix() string {
+	return UppercaseFirstCharacter(c.Package)
+}
+
+// equivalentTypes returns the types which a schema declares itself equivalent
+// to with x-equivalent-to, if any.
+func equivalentTypes(sref *openapi3.SchemaRef) ([]EquivalentType, error) {
+	if sref == nil || sref.Value == nil {
+		return nil, nil
+	}
+	extension, ok := sref.Value.Extensions[extEquivalentTo]
+	if !ok {
+		return nil, nil
+	}
+	equivalents, err := extParseEquivalentTo(extension)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", extEquivalentTo, err)
+	}
+	return equivalents, nil
+}
+
+// equivalentImports returns the imports of the packages of the types which a
+// schema declares itself equivalent to.
+func equivalentImports(sref *openapi3.SchemaRef) (map[string]goImport, error) {
+	equivalents, err := equivalentTypes(sref)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]goImport{}
+	for _, equivalent := range equivalents {
+		res[equivalent.Import.String()] = equivalent.Import
+	}
+	return res, nil
+}
+
+// DescribeConversions describes the conversions of the types declared with
+// x-equivalent-to among the given types.
+func DescribeConversions(types []TypeDefinition) ([]Conversion, error) {
+	var conversions []Conversion
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if seen[td.TypeName] || td.Schema.OAPISchema == nil {
+			continue
+		}
+		seen[td.TypeName] = true
+		equivalents, err := equivalentTypes(&openapi3.SchemaRef{Value: td.Schema.OAPISchema})
+		if err != nil {
+			return nil, fmt.Errorf("error describing the conversions of %s: %w", td.TypeName, err)
+		}
+		for _, equivalent := range equivalents {
+			conversion := Conversion{
+				TypeName:   td.TypeName,
+				Package:    equivalent.Import.Name,
+				Equivalent: equivalent.TypeName,
+			}
+			if conversion.Equivalent == "" {
+				conversion.Equivalent = td.TypeName
+			}
+			conversions = append(conversions, conversion)
+		}
+	}
+	return conversions, nil
+}
+
+// GenerateConversions generates the functions converting the types declared
+// with x-equivalent-to to and from their equivalent types.
+func GenerateConversions(t *template.Template, types []TypeDefinition) (string, error) {
+	conversions, err := DescribeConversions(types)
+	if err != nil {
+		return "", err
+	}
+	if len(conversions) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"conversions.tmpl"}, t, conversions)
+}
+
+// equivalentPackageName returns the name of the package of an equivalent
+// type, the last element of its path, when it's an identifier.
+func equivalentPackageName(packagePath string) (string, error) {
+	name := path.Base(packagePath)
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("the last element of %s isn't a package name, so name is required", packagePath)
+	}
+	return name, nil
+}
@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"strings"
+	"text/template"
+)
+
+// The styles of the database tags of the generated struct fields, set with
+// the db-tags output option.
+const (
+	DbTagsGorm = "gorm"
+	DbTagsEnt  = "ent"
+)
+
+// DbTable describes the table a model is stored in, declared with x-db-table.
+type DbTable struct {
+	TypeName string // The name of the model, like Pet
+	Table    string // The name of its table, like pets
+}
+
+// dbColumn returns the column of the field of a property, which x-db-column
+// overrides, or "-" for fields which aren't stored.
+func dbColumn(p Property) string {
+	if extension, ok := p.Extensions[extDbColumn]; ok {
+		if column, err := extString(extension); err == nil && column != "" {
+			return column
+		}
+	}
+	return p.JsonFieldName
+}
+
+// dbFieldTag returns the database tag of the field of a property, in the style
+// of the db-tags output option, and whether it has one.
+func dbFieldTag(p Property) (string, string, bool) {
+	column := dbColumn(p)
+	switch globalState.options.OutputOptions.DbTags {
+	case DbTagsGorm:
+		if column == "-" {
+			return "gorm", "-", true
+		}
+		tag := "column:" + column
+		if dbStoredAsJSON(p.Schema) {
+			tag += ";serializer:json"
+		}
+		return "gorm", tag, true
+	case DbTagsEnt:
+		// ent's scanner reads the columns of the sql tags
+		return "sql", column, true
+	}
+	return "", "", false
+}
+
+// dbIgnoredFieldTag returns the database tag of a field which isn't stored,
+// like that of the additional properties, and whether it has one.
+func dbIgnoredFieldTag() (string, string, bool) {
+	switch globalState.options.OutputOptions.DbTags {
+	case DbTagsGorm:
+		return "gorm", "-", true
+	case DbTagsEnt:
+		return "sql", "-", true
+	}
+	return "", "", false
+}
+
+// dbStoredAsJSON returns whether the values of a schema are objects or
+// arrays, which gorm stores as JSON, rather than in a column of their own
+// type.
+func dbStoredAsJSON(s Schema) bool {
+	if s.TimeFormat != "" || len(s.EnumValues) != 0 {
+		return false
+	}
+	if strings.HasPrefix(s.GoType, "struct") || strings.HasPrefix(s.GoType, "[]") || strings.HasPrefix(s.GoType, "map[") ||
+		len(s.UnionElements) != 0 || len(s.TupleElements) != 0 {
+		return true
+	}
+	schema := s.OAPISchema
+	if schema == nil {
+		return false
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return false
+	}
+	return schema.Type == "object" || schema.Type == "array" || len(schema.Properties) != 0 ||
+		len(schema.AllOf) != 0 || len(schema.AnyOf) != 0 || len(schema.OneOf) != 0
+}
+
+// DescribeDbTables describes the tables of the models declared with
+// x-db-table among the given types.
+func DescribeDbTables(types []TypeDefinition) []DbTable {
+	var tables []DbTable
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if seen[td.TypeName] || td.IsAlias() || td.Schema.OAPISchema == nil {
+			continue
+		}
+		seen[td.TypeName] = true
+		extension, ok := td.Schema.OAPISchema.Extensions[extDbTable]
+		if !ok {
+			continue
+		}
+		table, err := extString(extension)
+		if err != nil || table == "" {
+			warn(nil, jsonPointer("components", "schemas", td.JsonName), "the %s of %s isn't a table name, so it has no TableName method", extDbTable, td.TypeName)
+			continue
+		}
+		tables = append(tables, DbTable{TypeName: td.TypeName, Table: table})
+	}
+	return tables
+}
+
+// GenerateDbTables generates the TableName methods of the models declared
+// with x-db-table.
+func GenerateDbTables(t *template.Template, types []TypeDefinition) (string, error) {
+	return GenerateTemplates([]string{"db-tables.tmpl"}, t, DescribeDbTables(types))
+}
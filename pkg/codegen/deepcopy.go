@@ -0,0 +1,384 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DeepCopyMethods describes the DeepCopy and Equal methods of a type.
+type DeepCopyMethods struct {
+	TypeName string // The name of the type, like Pet
+	Copy     string // The statements making out, which starts as a copy of a, share no memory with a
+	Equal    string // The statements returning false when a and other differ
+}
+
+// deepCopier describes the DeepCopy and Equal methods of the types, which all
+// the types but aliases have, so that the methods of a type call those of the
+// types it's made of.
+type deepCopier struct {
+	types map[string]TypeDefinition
+	// Whether values of unknown types are copied, which needs the
+	// deepCopyJSON function
+	copiesJSON bool
+}
+
+// comparableTypes are the types whose values are equal when == says so.
+var comparableTypes = map[string]bool{
+	"string": true, "bool": true, "json.Number": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+	"openapi_types.Email": true, "Email": true, "openapi_types.UUID": true, "uuid.UUID": true,
+	"netip.Addr": true, "civil.Date": true, "civil.DateTime": true, "civil.Time": true,
+}
+
+// DescribeDeepCopyMethods describes the DeepCopy and Equal methods of the
+// given types, leaving out aliases, which share the methods of the types they
+// alias. It returns whether the methods copy values of unknown types.
+func DescribeDeepCopyMethods(types []TypeDefinition) ([]DeepCopyMethods, bool, error) {
+	d := deepCopier{types: make(map[string]TypeDefinition)}
+	for _, td := range types {
+		d.types[td.TypeName] = td
+	}
+
+	var result []DeepCopyMethods
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if td.IsAlias() || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+
+		// Types defined as other types have their structure, but not their
+		// methods.
+		schema := td.Schema
+		for i := 0; i < len(d.types); i++ {
+			defined, found := d.types[schema.TypeDecl()]
+			if !found || defined.TypeName == td.TypeName {
+				break
+			}
+			schema = defined.Schema
+		}
+		for _, name := range fieldNames(schema) {
+			if name == "DeepCopy" || name == "Equal" {
+				return nil, false, fmt.Errorf("the %s method of %s collides with its %s field, which x-go-name can rename", name, td.TypeName, name)
+			}
+		}
+
+		a, other := "a", "other"
+		if schema.GoType == "time.Time" && !schema.IsRef() {
+			// Types defined as time.Time don't have its Equal method.
+			a, other = "time.Time(a)", "time.Time(other)"
+		}
+		result = append(result, DeepCopyMethods{
+			TypeName: td.TypeName,
+			Copy:     d.copyResolved(schema, "out", "a", 0),
+			Equal:    d.equalResolved(schema, a, other, 0),
+		})
+	}
+	return result, d.copiesJSON, nil
+}
+
+// fieldNames returns the names of the fields of a struct type.
+func fieldNames(schema Schema) []string {
+	var names []string
+	for _, p := range schema.Properties {
+		names = append(names, p.structFieldName())
+	}
+	for _, element := range schema.TupleElements {
+		names = append(names, element.GoName)
+	}
+	return names
+}
+
+// resolve follows the aliases which the type of a schema is declared as,
+// returning the schema declaring its type, and whether the type has the
+// methods.
+func (d *deepCopier) resolve(schema Schema) (Schema, bool) {
+	for i := 0; i < len(d.types); i++ {
+		td, found := d.types[schema.TypeDecl()]
+		if !found {
+			break
+		}
+		if !td.IsAlias() {
+			return schema, true
+		}
+		schema = td.Schema
+	}
+	return schema, false
+}
+
+// copyValue returns the statements making dst, a copy of src, share no memory
+// with src, for values of a schema, or pointers to them. The statements copy
+// src before assigning dst, so that both may be the same variable.
+func (d *deepCopier) copyValue(schema Schema, pointer bool, dst, src string, depth int) string {
+	schema, methods := d.resolve(schema)
+	if pointer {
+		c := fmt.Sprintf("c%d", depth)
+		if methods {
+			return fmt.Sprintf("if %s != nil {\n%s := %s.DeepCopy()\n%s = &%s\n}", src, c, src, dst, c)
+		}
+		statements := []string{fmt.Sprintf("%s := *%s", c, src)}
+		if inner := d.copyResolved(schema, c, c, depth+1); inner != "" {
+			statements = append(statements, inner)
+		}
+		statements = append(statements, fmt.Sprintf("%s = &%s", dst, c))
+		return fmt.Sprintf("if %s != nil {\n%s\n}", src, strings.Join(statements, "\n"))
+	}
+	if methods {
+		return fmt.Sprintf("%s = %s.DeepCopy()", dst, src)
+	}
+	return d.copyResolved(schema, dst, src, depth)
+}
+
+// copyResolved is copyValue for the values of a schema declaring their type.
+func (d *deepCopier) copyResolved(schema Schema, dst, src string, depth int) string {
+	var statements []string
+	add := func(statement string) {
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	switch {
+	case schema.IsRef() || schema.TimeFormat != "":
+		// Types from other packages are copied as they are.
+	case len(schema.TupleElements) != 0:
+		for _, element := range schema.TupleElements {
+			add(d.copyValue(element.Schema, element.Optional, dst+"."+element.GoName, src+"."+element.GoName, depth))
+		}
+		if schema.TupleRest != nil {
+			add(d.copySlice(*schema.TupleRest, dst+".Rest", src+".Rest", depth))
+		}
+	case schema.ArrayType != nil:
+		add(d.copySlice(*schema.ArrayType, dst, src, depth))
+	case strings.HasPrefix(schema.GoType, "map["):
+		elem, pointer := mapElement(schema)
+		add(d.copyMap(elem, pointer, dst, src, depth))
+	case schema.NullableType != nil:
+		add(d.copyNullable(*schema.NullableType, dst, src, depth))
+	case strings.HasPrefix(schema.GoType, "struct"):
+		for _, p := range schema.Properties {
+			name := p.structFieldName()
+			add(d.copyValue(p.Schema, strings.HasPrefix(p.structFieldType(), "*"), dst+"."+name, src+"."+name, depth))
+		}
+		if schema.HasAdditionalProperties {
+			elem, pointer := mapElement(schema)
+			add(d.copyMap(elem, pointer, dst+".AdditionalProperties", src+".AdditionalProperties", depth))
+		}
+		if len(schema.UnionElements) != 0 {
+			add(fmt.Sprintf("if %s.union != nil {\n%s.union = append(%s.union[:0:0], %s.union...)\n}", src, dst, src, src))
+		}
+	case kubernetesMetaTypes[schema.GoType]:
+		// The Kubernetes object metadata has DeepCopy methods of its own.
+		add(fmt.Sprintf("%s = *%s.DeepCopy()", dst, src))
+	case schema.GoType == "[]byte" || schema.GoType == "json.RawMessage":
+		add(fmt.Sprintf("if %s != nil {\n%s = append(%s[:0:0], %s...)\n}", src, dst, src, src))
+	case schema.GoType == "interface{}":
+		d.copiesJSON = true
+		add(fmt.Sprintf("%s = deepCopyJSON(%s)", dst, src))
+	case schema.GoType == "Decimal" && globalState.options.OutputOptions.DecimalType == "big":
+		// A big.Float copied by assignment shares its mantissa. Copy gives it
+		// one of its own, with the precision, mode and accuracy of the source.
+		add(fmt.Sprintf("%s.Float = *new(big.Float).Copy(&%s.Float)", dst, src))
+	}
+	return strings.Join(statements, "\n")
+}
+
+// copySlice returns the statements copying a slice, and its items.
+func (d *deepCopier) copySlice(item Schema, dst, src string, depth int) string {
+	i := fmt.Sprintf("i%d", depth)
+	inner := d.copyValue(item, false, fmt.Sprintf("%s[%s]", dst, i), fmt.Sprintf("%s[%s]", dst, i), depth+1)
+	if inner == "" && goVersionAtLeast(21) {
+		return fmt.Sprintf("%s = slices.Clone(%s)", dst, src)
+	}
+	statements := []string{fmt.Sprintf("%s = append(%s[:0:0], %s...)", dst, src, src)}
+	if inner != "" {
+		statements = append(statements, fmt.Sprintf("for %s := range %s {\n%s\n}", i, dst, inner))
+	}
+	return fmt.Sprintf("if %s != nil {\n%s\n}", src, strings.Join(statements, "\n"))
+}
+
+// copyMap returns the statements copying a map, and its values.
+func (d *deepCopier) copyMap(elem Schema, pointer bool, dst, src string, depth int) string {
+	m, k, v := fmt.Sprintf("m%d", depth), fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+	elemType := elem.TypeDecl()
+	if pointer {
+		elemType = "*" + elemType
+	}
+	inner := d.copyValue(elem, pointer, v, v, depth+1)
+	if inner == "" && !pointer && goVersionAtLeast(21) {
+		return fmt.Sprintf("%s = maps.Clone(%s)", dst, src)
+	}
+	body := fmt.Sprintf("%s[%s] = %s", m, k, v)
+	if inner != "" {
+		body = inner + "\n" + body
+	}
+	return fmt.Sprintf("if %s != nil {\n%s := make(map[string]%s, len(%s))\nfor %s, %s := range %s {\n%s\n}\n%s = %s\n}",
+		src, m, elemType, src, k, v, src, body, dst, m)
+}
+
+// copyNullable returns the statements copying a Nullable, and the array or
+// map it holds.
+func (d *deepCopier) copyNullable(wrapped Schema, dst, src string, depth int) string {
+	n, k, v := fmt.Sprintf("n%d", depth), fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+	body := fmt.Sprintf("%s[%s] = %s", n, k, v)
+	if inner := d.copyValue(wrapped, false, v, v, depth+1); inner != "" {
+		body = inner + "\n" + body
+	}
+	return fmt.Sprintf("if %s != nil {\n%s := make(%s[%s], len(%s))\nfor %s, %s := range %s {\n%s\n}\n%s = %s\n}",
+		src, n, nullableTypeName, wrapped.TypeDecl(), src, k, v, src, body, dst, n)
+}
+
+// mapElement returns the schema of the values of a map, or of the additional
+// properties of an object, and whether they're pointers.
+func mapElement(schema Schema) (Schema, bool) {
+	if schema.AdditionalPropertiesType == nil {
+		return Schema{GoType: "interface{}"}, false
+	}
+	return *schema.AdditionalPropertiesType, strings.HasPrefix(additionalPropertiesType(schema), "*")
+}
+
+// equalValue returns the statements returning false when x and y differ, for
+// values of a schema, or pointers to them.
+func (d *deepCopier) equalValue(schema Schema, pointer bool, x, y string, depth int) string {
+	schema, methods := d.resolve(schema)
+	if !pointer {
+		if methods {
+			return fmt.Sprintf("if !%s.Equal(%s) {\nreturn false\n}", x, y)
+		}
+		return d.equalResolved(schema, x, y, depth)
+	}
+
+	statements := []string{fmt.Sprintf("if (%s == nil) != (%s == nil) {\nreturn false\n}", x, y)}
+	differ, ok := fmt.Sprintf("!%s.Equal(*%s)", x, y), methods
+	if !methods {
+		differ, ok = d.differ(schema, x, y, true)
+	}
+	if ok {
+		statements = append(statements, fmt.Sprintf("if %s != nil && %s {\nreturn false\n}", x, differ))
+	} else {
+		xv, yv := fmt.Sprintf("x%d", depth), fmt.Sprintf("y%d", depth)
+		statements = append(statements, fmt.Sprintf("if %s != nil {\n%s, %s := *%s, *%s\n%s\n}",
+			x, xv, yv, x, y, d.equalResolved(schema, xv, yv, depth+1)))
+	}
+	return strings.Join(statements, "\n")
+}
+
+// equalResolved is equalValue for the values of a schema declaring their
+// type.
+func (d *deepCopier) equalResolved(schema Schema, x, y string, depth int) string {
+	if differ, ok := d.differ(schema, x, y, false); ok {
+		return fmt.Sprintf("if %s {\nreturn false\n}", differ)
+	}
+
+	var statements []string
+	switch {
+	case len(schema.TupleElements) != 0:
+		for _, element := range schema.TupleElements {
+			statements = append(statements, d.equalValue(element.Schema, element.Optional, x+"."+element.GoName, y+"."+element.GoName, depth))
+		}
+		if schema.TupleRest != nil {
+			statements = append(statements, d.equalSlice(*schema.TupleRest, x+".Rest", y+".Rest", depth))
+		}
+	case schema.ArrayType != nil:
+		statements = append(statements, d.equalSlice(*schema.ArrayType, x, y, depth))
+	case strings.HasPrefix(schema.GoType, "map["):
+		elem, pointer := mapElement(schema)
+		statements = append(statements, d.equalMap(elem, pointer, x, y, depth))
+	default:
+		for _, p := range schema.Properties {
+			name := p.structFieldName()
+			statements = append(statements, d.equalValue(p.Schema, strings.HasPrefix(p.structFieldType(), "*"), x+"."+name, y+"."+name, depth))
+		}
+		if schema.HasAdditionalProperties {
+			elem, pointer := mapElement(schema)
+			statements = append(statements, d.equalMap(elem, pointer, x+".AdditionalProperties", y+".AdditionalProperties", depth))
+		}
+		if len(schema.UnionElements) != 0 {
+			statements = append(statements, fmt.Sprintf("if !bytes.Equal(%s.union, %s.union) {\nreturn false\n}", x, y))
+		}
+	}
+	return strings.Join(statements, "\n")
+}
+
+// equalSlice returns the statements returning false when two slices differ.
+func (d *deepCopier) equalSlice(item Schema, x, y string, depth int) string {
+	if d.comparable(item) && goVersionAtLeast(21) {
+		return fmt.Sprintf("if !slices.Equal(%s, %s) {\nreturn false\n}", x, y)
+	}
+	i := fmt.Sprintf("i%d", depth)
+	return fmt.Sprintf("if len(%s) != len(%s) {\nreturn false\n}\nfor %s := range %s {\n%s\n}",
+		x, y, i, x, d.equalValue(item, false, fmt.Sprintf("%s[%s]", x, i), fmt.Sprintf("%s[%s]", y, i), depth+1))
+}
+
+// equalMap returns the statements returning false when two maps differ.
+func (d *deepCopier) equalMap(elem Schema, pointer bool, x, y string, depth int) string {
+	if !pointer && d.comparable(elem) && goVersionAtLeast(21) {
+		return fmt.Sprintf("if !maps.Equal(%s, %s) {\nreturn false\n}", x, y)
+	}
+	k, v, w := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth), fmt.Sprintf("w%d", depth)
+	return fmt.Sprintf("if len(%s) != len(%s) {\nreturn false\n}\nfor %s, %s := range %s {\n%s, ok := %s[%s]\nif !ok {\nreturn false\n}\n%s\n}",
+		x, y, k, v, x, w, y, k, d.equalValue(elem, pointer, v, w, depth+1))
+}
+
+// comparable returns whether the values of a schema are compared with ==,
+// and so can be with slices.Equal and maps.Equal.
+func (d *deepCopier) comparable(schema Schema) bool {
+	schema, methods := d.resolve(schema)
+	return !methods && !schema.IsRef() && schema.TimeFormat == "" && comparableTypes[schema.GoType]
+}
+
+// differ returns the expression telling whether x and y, values of a schema
+// declaring their type or pointers to them, differ. It returns false for
+// structs, slices and maps, which are compared field by field, or item by
+// item.
+func (d *deepCopier) differ(schema Schema, x, y string, pointer bool) (string, bool) {
+	deref := func(value string) string {
+		if pointer {
+			return "*" + value
+		}
+		return value
+	}
+	switch {
+	case schema.IsRef():
+		// Types from other packages are compared by reflection.
+	case schema.TimeFormat != "":
+		return fmt.Sprintf("!%s.Time.Equal(%s.Time)", x, y), true
+	case len(schema.TupleElements) != 0 || schema.ArrayType != nil ||
+		strings.HasPrefix(schema.GoType, "map[") || strings.HasPrefix(schema.GoType, "struct"):
+		return "", false
+	case schema.GoType == "time.Time":
+		return fmt.Sprintf("!%s.Equal(%s)", x, deref(y)), true
+	case schema.GoType == "Date" || schema.GoType == "openapi_types.Date":
+		return fmt.Sprintf("!%s.Time.Equal(%s.Time)", x, y), true
+	case schema.GoType == "Decimal" && globalState.options.OutputOptions.DecimalType == "big":
+		return fmt.Sprintf("%s.Cmp(&%s.Float) != 0", x, y), true
+	case schema.GoType == "Decimal":
+		return fmt.Sprintf("!%s.Equal(%s.Decimal)", x, y), true
+	case schema.GoType == "URL":
+		return fmt.Sprintf("%s.String() != %s.String()", x, y), true
+	case schema.GoType == "[]byte" || schema.GoType == "json.RawMessage":
+		return fmt.Sprintf("!bytes.Equal(%s, %s)", deref(x), deref(y)), true
+	case comparableTypes[schema.GoType]:
+		return fmt.Sprintf("%s != %s", deref(x), deref(y)), true
+	}
+	return fmt.Sprintf("!reflect.DeepEqual(%s, %s)", deref(x), deref(y)), true
+}
+
+// GenerateDeepCopyMethods generates the DeepCopy and Equal methods of the
+// types.
+func GenerateDeepCopyMethods(t *template.Template, types []TypeDefinition) (string, error) {
+	methods, copiesJSON, err := DescribeDeepCopyMethods(types)
+	if err != nil {
+		return "", err
+	}
+	context := struct {
+		Types      []DeepCopyMethods
+		CopiesJSON bool
+	}{
+		Types:      methods,
+		CopiesJSON: copiesJSON,
+	}
+	return GenerateTemplates([]string{"deep-copy.tmpl"}, t, context)
+}
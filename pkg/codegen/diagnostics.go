@@ -0,0 +1,126 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Diagnostic describes a problem with the spec found during generation.
+type Diagnostic struct {
+	OperationID string // The operation concerned, if any
+	Path        string // The JSON pointer to the problem in the spec, like /paths/~1pets/get/responses/200
+	Reason      string // What the problem is
+	Warning     bool   // Whether generation carried on despite the problem
+}
+
+func (d Diagnostic) Error() string {
+	var b strings.Builder
+	if d.Warning {
+		b.WriteString("warning: ")
+	}
+	if d.OperationID != "" {
+		fmt.Fprintf(&b, "operation %s: ", d.OperationID)
+	}
+	if d.Path != "" {
+		fmt.Fprintf(&b, "%s: ", d.Path)
+	}
+	b.WriteString(d.Reason)
+	return b.String()
+}
+
+// Diagnostics is the report of all the problems found during generation. It
+// is returned as an error when any of them prevents generation.
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	lines := make([]string, len(d))
+	for i, diagnostic := range d {
+		lines[i] = diagnostic.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Errors returns the diagnostics which prevent generation.
+func (d Diagnostics) Errors() Diagnostics {
+	var result Diagnostics
+	for _, diagnostic := range d {
+		if !diagnostic.Warning {
+			result = append(result, diagnostic)
+		}
+	}
+	return result
+}
+
+// Warnings returns the diagnostics which generation carried on despite.
+func (d Diagnostics) Warnings() Diagnostics {
+	var result Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Warning {
+			result = append(result, diagnostic)
+		}
+	}
+	return result
+}
+
+// operationPath returns the JSON pointer locating an operation in the spec, to
+// which the given reference tokens are appended.
+func operationPath(op *OperationDefinition, tokens ...string) string {
+	return jsonPointer(append([]string{"paths", op.Path, strings.ToLower(op.Method)}, tokens...)...)
+}
+
+// diagnosticsMutex guards globalState.diagnostics, which templates executed
+// concurrently add warnings to.
+var diagnosticsMutex sync.Mutex
+
+// warn records a warning about the spec, to be reported once generation is
+// done. The templates may look at the same schema more than once, so a
+// warning is only recorded once.
+func warn(op *OperationDefinition, path string, format string, args ...interface{}) {
+	diagnostic := Diagnostic{
+		Path:    path,
+		Reason:  fmt.Sprintf(format, args...),
+		Warning: true,
+	}
+	if op != nil {
+		diagnostic.OperationID = op.OperationId
+	}
+	diagnosticsMutex.Lock()
+	defer diagnosticsMutex.Unlock()
+	for _, recorded := range globalState.diagnostics {
+		if recorded == diagnostic {
+			return
+		}
+	}
+	globalState.diagnostics = append(globalState.diagnostics, diagnostic)
+}
+
+// checkOperations looks for the problems with the operations which would stop
+// template execution, so that all of them are reported at once.
+func checkOperations(ops []OperationDefinition, opts Configuration) Diagnostics {
+	var result Diagnostics
+	if !opts.Generate.Client {
+		return nil
+	}
+	for i := range ops {
+		op := &ops[i]
+		typeDefinitions, err := op.GetResponseTypeDefinitions()
+		if err != nil {
+			result = append(result, Diagnostic{
+				OperationID: op.OperationId,
+				Path:        operationPath(op, "responses"),
+				Reason:      err.Error(),
+			})
+		}
+		for _, td := range typeDefinitions {
+			if _, err := strictDecoding(td); err != nil {
+				result = append(result, Diagnostic{
+					OperationID: op.OperationId,
+					Path:        operationPath(op, "responses", td.ResponseName),
+					Reason:      err.Error(),
+				})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,274 @@
+package codegen
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// BreakingChanges compares the spec with a previous version of it, and
+// returns a warning locating, with a JSON pointer into the current spec, each
+// change which breaks the code generated for the previous version, or the
+// code using it:
+//   - removed operations, component schemas and properties, whose methods,
+//     types and fields disappear
+//   - enums which lost values, whose constants disappear
+//   - properties and parameters which became required, whose fields and
+//     arguments are no longer pointers
+//
+// Schemas declared with references are compared where they're declared.
+func BreakingChanges(previous, current *openapi3.T) Diagnostics {
+	d := differ{seen: make(map[[2]*openapi3.Schema]bool)}
+
+	if previous.Components != nil {
+		var schemas openapi3.Schemas
+		if current.Components != nil {
+			schemas = current.Components.Schemas
+		}
+		for _, name := range SortedSchemaKeys(previous.Components.Schemas) {
+			pointer := []string{"components", "schemas", name}
+			if schemas[name] == nil {
+				d.warn("", pointer, "schema %s was removed", name)
+				continue
+			}
+			d.diffSchema("", previous.Components.Schemas[name], schemas[name], true, pointer...)
+		}
+	}
+
+	for _, requestPath := range SortedPathsKeys(previous.Paths) {
+		previousOps := previous.Paths[requestPath].Operations()
+		var currentOps map[string]*openapi3.Operation
+		if pathItem := current.Paths[requestPath]; pathItem != nil {
+			currentOps = pathItem.Operations()
+			d.diffParameters("", parametersByKey(previous.Paths[requestPath].Parameters), pathItem.Parameters, []string{"paths", requestPath, "parameters"})
+		}
+		for _, opName := range SortedOperationsKeys(previousOps) {
+			pointer := []string{"paths", requestPath, strings.ToLower(opName)}
+			previousOp := previousOps[opName]
+			if currentOps[opName] == nil {
+				d.warn(previousOp.OperationID, pointer, "operation was removed")
+				continue
+			}
+			d.diffOperation(previous.Paths[requestPath], previousOp, currentOps[opName], pointer)
+		}
+	}
+
+	return d.diagnostics
+}
+
+// differ accumulates the breaking changes found by BreakingChanges.
+type differ struct {
+	diagnostics Diagnostics
+	// The pairs of schemas already compared, which recursive schemas lead
+	// back to.
+	seen map[[2]*openapi3.Schema]bool
+}
+
+func (d *differ) warn(operationID string, pointer []string, format string, args ...interface{}) {
+	d.diagnostics = append(d.diagnostics, Diagnostic{
+		OperationID: operationID,
+		Path:        jsonPointer(pointer...),
+		Reason:      fmt.Sprintf(format, args...),
+		Warning:     true,
+	})
+}
+
+func (d *differ) diffOperation(previousItem *openapi3.PathItem, previousOp, currentOp *openapi3.Operation, pointer []string) {
+	operationID := currentOp.OperationID
+
+	// Parameters may have been declared on the path as well as on the
+	// operation.
+	previousParams := parametersByKey(previousItem.Parameters, previousOp.Parameters)
+	d.diffParameters(operationID, previousParams, currentOp.Parameters, append(pointer, "parameters"))
+
+	if previousOp.RequestBody != nil && currentOp.RequestBody != nil && currentOp.RequestBody.Ref == "" &&
+		previousOp.RequestBody.Value != nil && currentOp.RequestBody.Value != nil {
+		d.diffContent(operationID, previousOp.RequestBody.Value.Content, currentOp.RequestBody.Value.Content, append(pointer, "requestBody"))
+	}
+
+	for _, responseName := range SortedResponsesKeys(previousOp.Responses) {
+		previousResponse, currentResponse := previousOp.Responses[responseName], currentOp.Responses[responseName]
+		if currentResponse == nil || currentResponse.Ref != "" || previousResponse.Value == nil || currentResponse.Value == nil {
+			continue
+		}
+		d.diffContent(operationID, previousResponse.Value.Content, currentResponse.Value.Content, append(pointer, "responses", responseName))
+	}
+}
+
+func (d *differ) diffParameters(operationID string, previousParams map[string]*openapi3.Parameter, params openapi3.Parameters, pointer []string) {
+	for i, paramOrRef := range params {
+		param := paramOrRef.Value
+		if param == nil {
+			continue
+		}
+		paramPointer := append(pointer, strconv.Itoa(i))
+		previousParam := previousParams[param.In+":"+param.Name]
+		if previousParam == nil {
+			if param.Required {
+				d.warn(operationID, paramPointer, "required %s parameter %s was added", param.In, param.Name)
+			}
+			continue
+		}
+		if param.Required && !previousParam.Required {
+			d.warn(operationID, paramPointer, "%s parameter %s became required", param.In, param.Name)
+		}
+		if paramOrRef.Ref == "" {
+			d.diffSchema(operationID, previousParam.Schema, param.Schema, false, append(paramPointer, "schema")...)
+		}
+	}
+}
+
+// parametersByKey indexes parameters by their location and name, which
+// identify them.
+func parametersByKey(lists ...openapi3.Parameters) map[string]*openapi3.Parameter {
+	result := make(map[string]*openapi3.Parameter)
+	for _, params := range lists {
+		for _, param := range params {
+			if param.Value != nil {
+				result[param.Value.In+":"+param.Value.Name] = param.Value
+			}
+		}
+	}
+	return result
+}
+
+func (d *differ) diffContent(operationID string, previous, current openapi3.Content, pointer []string) {
+	for _, contentType := range SortedContentKeys(previous) {
+		if current[contentType] == nil {
+			continue
+		}
+		d.diffSchema(operationID, previous[contentType].Schema, current[contentType].Schema, false, append(pointer, "content", contentType, "schema")...)
+	}
+}
+
+// diffSchema compares a schema with its previous version, and the schemas
+// it's made of. Unless it's a component, as told by declared, it's skipped
+// when it's a reference, since it's compared where it's declared then.
+func (d *differ) diffSchema(operationID string, previousOrRef, currentOrRef *openapi3.SchemaRef, declared bool, pointer ...string) {
+	if previousOrRef == nil || currentOrRef == nil || previousOrRef.Value == nil || currentOrRef.Value == nil {
+		return
+	}
+	if !declared && currentOrRef.Ref != "" {
+		return
+	}
+	previous, current := previousOrRef.Value, currentOrRef.Value
+	pair := [2]*openapi3.Schema{previous, current}
+	if d.seen[pair] {
+		return
+	}
+	d.seen[pair] = true
+
+	if len(current.Enum) != 0 {
+		for _, value := range previous.Enum {
+			if !containsValue(current.Enum, value) {
+				d.warn(operationID, append(pointer, "enum"), "enum value %v was removed", value)
+			}
+		}
+	}
+
+	for _, name := range current.Required {
+		if StringInArray(name, previous.Required) {
+			continue
+		}
+		if previous.Properties[name] != nil {
+			d.warn(operationID, append(pointer, "required"), "property %s became required", name)
+		} else {
+			d.warn(operationID, append(pointer, "required"), "required property %s was added", name)
+		}
+	}
+
+	for _, name := range SortedSchemaKeys(previous.Properties) {
+		if current.Properties[name] == nil {
+			d.warn(operationID, append(pointer, "properties"), "property %s was removed", name)
+			continue
+		}
+		d.diffSchema(operationID, previous.Properties[name], current.Properties[name], false, append(pointer, "properties", name)...)
+	}
+	d.diffSchema(operationID, previous.Items, current.Items, false, append(pointer, "items")...)
+	d.diffSchema(operationID, previous.AdditionalProperties.Schema, current.AdditionalProperties.Schema, false, append(pointer, "additionalProperties")...)
+	for i := 0; i < len(previous.AllOf) && i < len(current.AllOf); i++ {
+		d.diffSchema(operationID, previous.AllOf[i], current.AllOf[i], false, append(pointer, "allOf", strconv.Itoa(i))...)
+	}
+	for i := 0; i < len(previous.OneOf) && i < len(current.OneOf); i++ {
+		d.diffSchema(operationID, previous.OneOf[i], current.OneOf[i], false, append(pointer, "oneOf", strconv.Itoa(i))...)
+	}
+	for i := 0; i < len(previous.AnyOf) && i < len(current.AnyOf); i++ {
+		d.diffSchema(operationID, previous.AnyOf[i], current.AnyOf[i], false, append(pointer, "anyOf", strconv.Itoa(i))...)
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoEmbeddedSpec is returned by EmbeddedSpec for code generated without
+// the embedded spec.
+var ErrNoEmbeddedSpec = errors.New("no embedded spec found")
+
+// EmbeddedSpec returns the spec embedded in code generated with the
+// embedded-spec option, so that it can be compared with a newer version.
+func EmbeddedSpec(code []byte) (*openapi3.T, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+
+	var encoded strings.Builder
+	found := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "swaggerSpec" || len(spec.Values) != 1 {
+			return true
+		}
+		lit, ok := spec.Values[0].(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		for _, elt := range lit.Elts {
+			if part, ok := elt.(*ast.BasicLit); ok && part.Kind == token.STRING {
+				s, err := strconv.Unquote(part.Value)
+				if err == nil {
+					encoded.WriteString(s)
+				}
+			}
+		}
+		found = true
+		return false
+	})
+	if !found {
+		return nil, ErrNoEmbeddedSpec
+	}
+
+	zipped, err := base64.StdEncoding.DecodeString(encoded.String())
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	specJSON, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	return loader.LoadFromData(specJSON)
+}
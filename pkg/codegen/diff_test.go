@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	previous, err := util.LoadSwagger("test_specs/diff/previous.yaml")
+	require.NoError(t, err)
+	current, err := util.LoadSwagger("test_specs/diff/current.yaml")
+	require.NoError(t, err)
+
+	var found []string
+	for _, diagnostic := range BreakingChanges(previous, current) {
+		assert.True(t, diagnostic.Warning)
+		found = append(found, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Error: schema Error was removed",
+		"warning: /components/schemas/Pet/required: property tag became required",
+		"warning: /components/schemas/Pet/required: required property owner was added",
+		"warning: /components/schemas/Pet/properties: property color was removed",
+		"warning: /components/schemas/Pet/properties/status/enum: enum value pending was removed",
+		"warning: operation listPets: /paths/~1pets/get/parameters/0: query parameter limit became required",
+		"warning: operation deletePet: /paths/~1pets~1{id}/delete: operation was removed",
+	}, found)
+
+	// A spec doesn't break itself
+	assert.Empty(t, BreakingChanges(current, current))
+}
+
+func TestEmbeddedSpec(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/diff/current.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			EmbeddedSpec: true,
+		},
+	})
+	require.NoError(t, err)
+
+	embedded, err := EmbeddedSpec([]byte(code))
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", embedded.Info.Version)
+	assert.Contains(t, embedded.Components.Schemas, "Pet")
+
+	code, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = EmbeddedSpec([]byte(code))
+	assert.ErrorIs(t, err, ErrNoEmbeddedSpec)
+}
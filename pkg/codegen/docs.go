@@ -0,0 +1,389 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DocsFile is a page of the Markdown reference of the API, with the docs
+// option.
+type DocsFile struct {
+	FileName string // The name of the page, in the docs directory
+	Content  string
+}
+
+// DocsContext is passed to the templates of the reference.
+type DocsContext struct {
+	ModuleName  string
+	PackageName string
+	Title       string
+	Version     string
+	Description string
+	ServerURL   string // The server the code samples send the requests to
+	Tags        []DocsTag
+	Models      []DocsModel
+}
+
+// DocsTag is the page of the operations of a tag, or of those without one.
+type DocsTag struct {
+	Name        string
+	Description string
+	FileName    string
+	Operations  []DocsOperation
+}
+
+// DocsOperation is the section of an operation in the page of its tag.
+type DocsOperation struct {
+	OperationId string
+	Anchor      string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Params      []DocsField
+	Bodies      []DocsField // The request bodies, by content type in the Name
+	Responses   []DocsResponse
+	Sample      string // The Go code calling the operation with the generated client, if any
+}
+
+// DocsResponse is a response of an operation.
+type DocsResponse struct {
+	StatusCode  string
+	Description string
+	Contents    []DocsField // The contents, by content type in the Name
+}
+
+// DocsModel is the section of a type in the page of the models.
+type DocsModel struct {
+	Name        string
+	Anchor      string
+	Description string
+	Type        string // The type, when it isn't an object with fields
+	Fields      []DocsField
+	EnumValues  []string
+}
+
+// DocsField is a row of a table of the reference: a property, a parameter,
+// or a content of a body.
+type DocsField struct {
+	Name        string
+	In          string
+	Type        string // The type, in Markdown, linked to the models
+	Required    bool
+	Description string // The description, escaped for the cells of the tables
+}
+
+// docsOtherTag names the page of the operations without tags.
+const docsOtherTag = "Other"
+
+// docsModelsFile is the page of the models.
+const docsModelsFile = "models.md"
+
+// docsIdentifier matches the identifiers in Go types, the names of the types
+// linked to the models among them.
+var docsIdentifier = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?`)
+
+// docsSlug matches the characters replaced in the file names of the tags.
+var docsSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GenerateDocs generates the Markdown reference of the operations and models
+// of the spec, with the docs option: a README.md indexing the pages, a page
+// of the operations of each tag, with code samples calling them with the
+// generated client, and a page of the models, which the types link to.
+func GenerateDocs(t *template.Template, spec *openapi3.T, ops []OperationDefinition) ([]DocsFile, error) {
+	opts := globalState.options
+	types := globalState.componentTypes
+
+	context := DocsContext{
+		PackageName: opts.PackageName,
+		ServerURL:   "https://api.example.com",
+	}
+	context.ModuleName, _ = generatorVersion(opts.NoVCSVersionOverride)
+	if spec.Info != nil {
+		context.Title = spec.Info.Title
+		context.Version = spec.Info.Version
+		context.Description = strings.TrimSpace(spec.Info.Description)
+	}
+	if len(spec.Servers) != 0 && spec.Servers[0].URL != "" {
+		context.ServerURL = spec.Servers[0].URL
+	}
+
+	d := docs{models: make(map[string]bool)}
+	var models []TypeDefinition
+	for _, td := range types {
+		if !d.models[td.TypeName] {
+			d.models[td.TypeName] = true
+			models = append(models, td)
+		}
+	}
+	for _, td := range models {
+		context.Models = append(context.Models, d.model(td))
+	}
+
+	tags := make(map[string]*DocsTag)
+	var tagNames []string
+	addTag := func(name, description string) *DocsTag {
+		if tag, ok := tags[name]; ok {
+			return tag
+		}
+		tags[name] = &DocsTag{Name: name, Description: description, FileName: docsFileName(name)}
+		tagNames = append(tagNames, name)
+		return tags[name]
+	}
+	for _, tag := range spec.Tags {
+		addTag(tag.Name, strings.TrimSpace(tag.Description))
+	}
+	for i := range ops {
+		op := d.operation(&ops[i], opts.PackageName)
+		names := ops[i].Spec.Tags
+		if len(names) == 0 {
+			names = []string{docsOtherTag}
+		}
+		for _, name := range names {
+			tag := addTag(name, "")
+			tag.Operations = append(tag.Operations, op)
+		}
+	}
+	// The tags the spec doesn't declare follow those it does, in the order of
+	// their operations, and the operations without tags come last.
+	sort.SliceStable(tagNames, func(i, j int) bool {
+		return tagNames[j] == docsOtherTag && tagNames[i] != docsOtherTag
+	})
+	seenFiles := make(map[string]string)
+	for _, name := range tagNames {
+		tag := tags[name]
+		if len(tag.Operations) == 0 {
+			continue
+		}
+		if other, ok := seenFiles[tag.FileName]; ok {
+			return nil, fmt.Errorf("the pages of the tags %s and %s have the same name %s", other, name, tag.FileName)
+		}
+		seenFiles[tag.FileName] = name
+		context.Tags = append(context.Tags, *tag)
+	}
+
+	files := []DocsFile{}
+	index, err := executeDocsTemplate(t, "docs-index", context)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, DocsFile{FileName: "README.md", Content: index})
+	for _, tag := range context.Tags {
+		page, err := executeDocsTemplate(t, "docs-tag", struct {
+			DocsContext
+			Tag DocsTag
+		}{context, tag})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, DocsFile{FileName: tag.FileName, Content: page})
+	}
+	if len(context.Models) != 0 {
+		page, err := executeDocsTemplate(t, "docs-models", context)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, DocsFile{FileName: docsModelsFile, Content: page})
+	}
+	return files, nil
+}
+
+// executeDocsTemplate renders a page of the reference.
+func executeDocsTemplate(t *template.Template, name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("error generating the %s page of the reference: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// docsFileName returns the name of the page of a tag, like pet-store.md for
+// Pet store.
+func docsFileName(tag string) string {
+	slug := strings.Trim(docsSlug.ReplaceAllString(strings.ToLower(tag), "-"), "-")
+	if slug == "" || slug == "readme" || slug == "models" {
+		slug = "tag-" + slug
+	}
+	return slug + ".md"
+}
+
+// docsAnchor returns the anchor of a heading, as Markdown renderers make them.
+func docsAnchor(heading string) string {
+	return strings.ToLower(heading)
+}
+
+// docs renders the types of the reference, linked to the models.
+type docs struct {
+	models map[string]bool // The names of the types in the page of the models
+}
+
+// goType returns a Go type in Markdown, linking the models in it to their
+// section.
+func (d docs) goType(goType string) string {
+	if goType == "" {
+		return ""
+	}
+	if strings.HasPrefix(goType, "struct") {
+		return "`object`"
+	}
+	var b strings.Builder
+	code := func(s string) {
+		if s != "" {
+			b.WriteString("`" + s + "`")
+		}
+	}
+	last := 0
+	for _, match := range docsIdentifier.FindAllStringIndex(goType, -1) {
+		name := goType[match[0]:match[1]]
+		if !d.models[name] {
+			continue
+		}
+		code(goType[last:match[0]])
+		fmt.Fprintf(&b, "[`%s`](%s#%s)", name, docsModelsFile, docsAnchor(name))
+		last = match[1]
+	}
+	code(goType[last:])
+	return b.String()
+}
+
+// docsCell escapes text for a cell of a table, on a single line.
+func docsCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// model returns the section of a type in the page of the models.
+func (d docs) model(td TypeDefinition) DocsModel {
+	s := td.Schema
+	model := DocsModel{
+		Name:        td.TypeName,
+		Anchor:      docsAnchor(td.TypeName),
+		Description: strings.TrimSpace(s.Description),
+	}
+	if s.OAPISchema != nil {
+		for _, value := range s.OAPISchema.Enum {
+			model.EnumValues = append(model.EnumValues, fmt.Sprintf("%v", value))
+		}
+	}
+	for _, p := range s.Properties {
+		if p.JsonIgnored() {
+			continue
+		}
+		description := p.Description
+		if p.Deprecated {
+			description = strings.TrimSpace("Deprecated. " + description)
+		}
+		model.Fields = append(model.Fields, DocsField{
+			Name:        p.JsonFieldName,
+			Type:        d.goType(p.Schema.TypeDecl()),
+			Required:    p.Required,
+			Description: docsCell(description),
+		})
+	}
+	switch {
+	case len(s.UnionElements) != 0:
+		elements := make([]string, len(s.UnionElements))
+		for i, element := range s.UnionElements {
+			elements[i] = d.goType(string(element))
+		}
+		model.Type = "One of " + strings.Join(elements, ", ")
+	case len(model.Fields) == 0:
+		model.Type = d.goType(s.TypeDecl())
+	}
+	if s.HasAdditionalProperties && s.AdditionalPropertiesType != nil && len(model.Fields) != 0 {
+		model.Type = "Additional properties of " + d.goType(s.AdditionalPropertiesType.TypeDecl())
+	}
+	return model
+}
+
+// operation returns the section of an operation in the page of its tag.
+func (d docs) operation(op *OperationDefinition, packageName string) DocsOperation {
+	doc := DocsOperation{
+		OperationId: op.OperationId,
+		Anchor:      docsAnchor(op.OperationId),
+		Method:      op.Method,
+		Path:        op.Path,
+		Summary:     strings.TrimSpace(op.Summary),
+		Description: strings.TrimSpace(op.Spec.Description),
+		Deprecated:  op.Spec.Deprecated,
+	}
+	for _, params := range [][]ParameterDefinition{op.PathParams, op.QueryParams, op.HeaderParams, op.CookieParams} {
+		for _, param := range params {
+			doc.Params = append(doc.Params, DocsField{
+				Name:        param.ParamName,
+				In:          param.In,
+				Type:        d.goType(param.TypeDef()),
+				Required:    param.Required,
+				Description: docsCell(param.Spec.Description),
+			})
+		}
+	}
+	for _, body := range op.Bodies {
+		doc.Bodies = append(doc.Bodies, DocsField{
+			Name:     body.ContentType,
+			Type:     d.goType(body.Schema.TypeDecl()),
+			Required: body.Required,
+		})
+	}
+	for _, response := range op.Responses {
+		r := DocsResponse{StatusCode: response.StatusCode, Description: docsCell(response.Description)}
+		for _, content := range response.Contents {
+			r.Contents = append(r.Contents, DocsField{Name: content.ContentType, Type: d.goType(content.Schema.TypeDecl())})
+		}
+		doc.Responses = append(doc.Responses, r)
+	}
+	if globalState.options.Generate.Client {
+		doc.Sample = docsSample(op, packageName)
+	}
+	return doc
+}
+
+// docsSample returns the Go code calling an operation with the client with
+// responses, and reading its successful JSON response, if any.
+func docsSample(op *OperationDefinition, packageName string) string {
+	var args []string
+	for _, param := range op.PathParams {
+		args = append(args, param.GoVariableName())
+	}
+	if op.RequiresParamObject() {
+		args = append(args, fmt.Sprintf("&%s.%sParams{}", packageName, op.OperationId))
+	}
+	method := op.OperationId
+	if op.HasBody() {
+		method += "WithBody"
+		body := op.Bodies[0]
+		for _, b := range op.Bodies {
+			if b.Default {
+				body = b
+			}
+		}
+		if body.IsSupportedByClient() {
+			method = op.OperationId + body.Suffix()
+			args = append(args, fmt.Sprintf("%s.%s%sRequestBody{}", packageName, op.OperationId, body.NameTag))
+		} else {
+			args = append(args, strconv.Quote(body.ContentType), "body")
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "resp, err := client.%sWithResponse(%s)\n", method, strings.Join(append([]string{"ctx"}, args...), ", "))
+	b.WriteString("if err != nil {\n\treturn err\n}\n")
+	if tds, err := op.GetResponseTypeDefinitions(); err == nil {
+		for _, td := range tds {
+			if strings.HasPrefix(td.ResponseName, "2") && strings.HasPrefix(td.TypeName, "JSON") {
+				fmt.Fprintf(&b, "if resp.%s != nil {\n\tfmt.Println(*resp.%s)\n}\n", td.TypeName, td.TypeName)
+				return b.String()
+			}
+		}
+	}
+	b.WriteString("fmt.Println(resp.StatusCode())\n")
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EncryptedType describes a struct type with encrypted properties, whose
+// values the clients and servers encrypt and decrypt with the FieldEncrypter
+// as they send and receive them.
+type EncryptedType struct {
+	TypeName   string
+	Properties []string // The JSON names of the encrypted properties
+}
+
+// Encrypted returns whether the values of the property are encrypted, from
+// the x-encrypted of its schema.
+func (p Property) Encrypted() bool {
+	if p.Schema.OAPISchema == nil {
+		return false
+	}
+	extension, ok := p.Schema.OAPISchema.Extensions[extEncrypted]
+	if !ok {
+		return false
+	}
+	encrypted, err := extParseEncrypted(extension)
+	return err == nil && encrypted
+}
+
+// hasEncryptedProperties returns whether the schema is an object with
+// encrypted properties, whose type gets the encryptedProperties method.
+func (s Schema) hasEncryptedProperties() bool {
+	for _, p := range s.Properties {
+		if p.Encrypted() {
+			return true
+		}
+	}
+	return false
+}
+
+// specEncrypted returns whether a property of the schemas of the spec is
+// declared with x-encrypted, for which the clients and servers encrypt and
+// decrypt the bodies they send and receive.
+func specEncrypted(spec *openapi3.T) bool {
+	encrypted := false
+	_ = walkSwagger(spec, func(ref RefWrapper) (bool, error) {
+		if schemaRef, ok := ref.SourceRef.(*openapi3.SchemaRef); ok && schemaRef.Value != nil {
+			for _, p := range schemaRef.Value.Properties {
+				if p.Value == nil {
+					continue
+				}
+				if extension, ok := p.Value.Extensions[extEncrypted]; ok {
+					e, err := extParseEncrypted(extension)
+					encrypted = encrypted || err == nil && e
+				}
+			}
+		}
+		return ref.Ref == "" && !encrypted, nil
+	})
+	return encrypted
+}
+
+// GenerateEncryption generates the encryptedProperties methods of the struct
+// types with encrypted properties, and the functions encrypting and
+// decrypting the bodies holding them with the FieldEncrypter.
+func GenerateEncryption(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var types []EncryptedType
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		for _, p := range td.Schema.Properties {
+			if p.Schema.OAPISchema == nil {
+				continue
+			}
+			if extension, ok := p.Schema.OAPISchema.Extensions[extEncrypted]; ok {
+				if _, err := extParseEncrypted(extension); err != nil {
+					return "", fmt.Errorf("invalid value for %q of %s.%s: %w", extEncrypted, td.TypeName, p.JsonFieldName, err)
+				}
+			}
+		}
+		if seen[td.TypeName] || td.IsAlias() || td.Schema.IsRef() ||
+			!strings.HasPrefix(td.Schema.GoType, "struct") || !td.Schema.hasEncryptedProperties() {
+			continue
+		}
+		seen[td.TypeName] = true
+		encrypted := EncryptedType{TypeName: td.TypeName}
+		for _, p := range td.Schema.Properties {
+			if p.Encrypted() && !p.JsonIgnored() {
+				encrypted.Properties = append(encrypted.Properties, p.JsonFieldName)
+			}
+		}
+		types = append(types, encrypted)
+	}
+	if !globalState.encrypted {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"encryption.tmpl"}, t, types)
+}
@@ -2,6 +2,10 @@ package codegen
 
 import (
 	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -22,8 +26,89 @@ const (
 	extEnumVarNames      = "x-enum-varnames"
 	extEnumNames         = "x-enumNames"
 	extDeprecationReason = "x-deprecated-reason"
+	// extRateLimit declares a client-side rate limit for an operation, or for
+	// all operations sharing a tag when set on a tag object.
+	extRateLimit = "x-ratelimit"
+	// extMiddleware names the server middlewares applied to an operation.
+	extMiddleware = "x-middleware"
+	// extGoTimeFormat declares a type formatting the times of a string schema
+	// with a custom layout.
+	extGoTimeFormat = "x-go-time-format"
+	// extLongRunning declares the operation polled for the completion of an
+	// operation answering 202 Accepted, and its terminal states.
+	extLongRunning = "x-long-running"
+	// extCacheable declares that the client may cache the responses of a GET
+	// operation, when generated with the client-cache option.
+	extCacheable = "x-cacheable"
+	// extBatch declares an operation sending the requests of other operations
+	// at once, in a JSON envelope.
+	extBatch = "x-batch"
+	// extDbTable names the database table of a model, with the db-tags option.
+	extDbTable = "x-db-table"
+	// extDbColumn overrides the database column of a property, with the
+	// db-tags option, or leaves it out of the table when it's "-".
+	extDbColumn = "x-db-column"
+	// extHotPath declares a model whose JSON is encoded and decoded without
+	// reflection, by generated MarshalJSON and UnmarshalJSON methods.
+	extHotPath = "x-hot-path"
+	// extServerTransport declares how a server is reached, "unix" for a Unix
+	// domain socket, generating the client with WithUnixSocket.
+	extServerTransport = "x-server-transport"
+	// extStrictDecoding declares a schema whose JSON responses the client
+	// decodes strictly, rejecting unknown fields, while StrictDecoding is on.
+	extStrictDecoding = "x-strict-decoding"
+	// extEquivalentTo declares the types of other packages, like those of
+	// other versions of the API, with the same JSON shape as a schema,
+	// generating the functions converting it to and from them.
+	extEquivalentTo = "x-equivalent-to"
+	// extAPIVersion declares how the client sends the version of the API with
+	// every request, on the spec, or the version of an operation.
+	extAPIVersion = "x-api-version"
+	// extSensitive declares a parameter, header or property whose values
+	// WithCapture redacts from the captured requests and responses.
+	extSensitive = "x-sensitive"
+	// extVolatile declares a parameter or a property whose values change from
+	// run to run, like timestamps, which cassettes don't match requests on.
+	extVolatile = "x-volatile"
+	// extEncrypted declares a property whose values the clients and servers
+	// encrypt and decrypt with the FieldEncrypter as they send and receive them.
+	extEncrypted = "x-encrypted"
+	// extAsync declares the channels of the messages a service publishes and
+	// subscribes to, as the channels and components of an AsyncAPI document.
+	extAsync = "x-async"
+	// extMaxBodySize limits the size of the request bodies of an operation
+	// which the server reads.
+	extMaxBodySize = "x-max-body-size"
+	// extRequestTimeout sets the deadline of the contexts of the requests of
+	// an operation in the server.
+	extRequestTimeout = "x-request-timeout"
+	// extHeaderStyle declares how the values of an array header parameter are
+	// sent, on a single line separated by commas, or on a line each.
+	extHeaderStyle = "x-header-style"
+	// extImplements declares the interfaces a schema implements, which are
+	// generated with the getters of the fields their types have in common, or
+	// the methods named by the interface-methods output option.
+	extImplements = "x-implements"
 )
 
+// The values of extHeaderStyle.
+const (
+	headerStyleSimple   = "simple"
+	headerStyleRepeated = "repeated"
+)
+
+func extParseHeaderStyle(extPropValue interface{}) (string, error) {
+	style, err := extString(extPropValue)
+	if err != nil {
+		return "", err
+	}
+	switch style {
+	case headerStyleSimple, headerStyleRepeated:
+		return style, nil
+	}
+	return "", fmt.Errorf("unknown header style %q, expected %q or %q", style, headerStyleSimple, headerStyleRepeated)
+}
+
 func extString(extPropValue interface{}) (string, error) {
 	str, ok := extPropValue.(string)
 	if !ok {
@@ -99,3 +184,335 @@ func extParseEnumVarNames(extPropValue interface{}) ([]string, error) {
 func extParseDeprecationReason(extPropValue interface{}) (string, error) {
 	return extString(extPropValue)
 }
+
+func extParseRateLimit(extPropValue interface{}) (*RateLimitDefinition, error) {
+	m, ok := extPropValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	rps, ok := m["requests-per-second"].(float64)
+	if !ok || rps <= 0 {
+		return nil, fmt.Errorf("requests-per-second must be a positive number, got: %v", m["requests-per-second"])
+	}
+	rateLimit := &RateLimitDefinition{
+		RequestsPerSecond: rps,
+		Burst:             1,
+	}
+	if v, found := m["burst"]; found {
+		burst, ok := v.(float64)
+		if !ok || burst < 1 || burst != float64(int(burst)) {
+			return nil, fmt.Errorf("burst must be a positive integer, got: %v", v)
+		}
+		rateLimit.Burst = int(burst)
+	}
+	return rateLimit, nil
+}
+
+func extParseMiddleware(extPropValue interface{}) ([]string, error) {
+	namesI, ok := extPropValue.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	names := make([]string, len(namesI))
+	for i, v := range namesI {
+		vs, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert type: %T", v)
+		}
+		if vs == "" {
+			return nil, fmt.Errorf("middleware names must not be empty")
+		}
+		names[i] = vs
+	}
+	return names, nil
+}
+
+func extParseCacheable(extPropValue interface{}) (bool, error) {
+	cacheable, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return cacheable, nil
+}
+
+func extParseServerTransport(extPropValue interface{}) (string, error) {
+	transport, err := extString(extPropValue)
+	if err != nil {
+		return "", err
+	}
+	switch transport {
+	case "tcp", "unix":
+		return transport, nil
+	default:
+		return "", fmt.Errorf("unknown server transport %q, must be \"tcp\" or \"unix\"", transport)
+	}
+}
+
+func extParseStrictDecoding(extPropValue interface{}) (bool, error) {
+	strict, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return strict, nil
+}
+
+func extParseHotPath(extPropValue interface{}) (bool, error) {
+	hotPath, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return hotPath, nil
+}
+
+func extParseLongRunning(extPropValue interface{}) (*LongRunningDefinition, error) {
+	m, ok := extPropValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	longRunning := &LongRunningDefinition{
+		StatusProperty: "status",
+		LocationHeader: "Location",
+	}
+	for key, value := range m {
+		switch key {
+		case "operation", "status", "location-header":
+			s, ok := value.(string)
+			if !ok || s == "" {
+				return nil, fmt.Errorf("%s must be a non-empty string, got: %v", key, value)
+			}
+			switch key {
+			case "operation":
+				longRunning.PollOperationId = s
+			case "status":
+				longRunning.StatusProperty = s
+			case "location-header":
+				longRunning.LocationHeader = s
+			}
+		case "terminal-states":
+			states, ok := value.([]interface{})
+			if !ok || len(states) == 0 {
+				return nil, fmt.Errorf("terminal-states must be a non-empty list of strings, got: %v", value)
+			}
+			for _, state := range states {
+				s, ok := state.(string)
+				if !ok {
+					return nil, fmt.Errorf("terminal-states must be a non-empty list of strings, got: %v", value)
+				}
+				longRunning.TerminalStates = append(longRunning.TerminalStates, s)
+			}
+		default:
+			return nil, fmt.Errorf("unknown field %s", key)
+		}
+	}
+	if longRunning.PollOperationId == "" {
+		return nil, fmt.Errorf("operation names the operation to poll, and is required")
+	}
+	if len(longRunning.TerminalStates) == 0 {
+		return nil, fmt.Errorf("terminal-states lists the statuses ending the polling, and is required")
+	}
+	return longRunning, nil
+}
+
+func extParseBatch(extPropValue interface{}) (*BatchDefinition, error) {
+	m, ok := extPropValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	batch := &BatchDefinition{}
+	for key, value := range m {
+		switch key {
+		case "operations":
+			ids, ok := value.([]interface{})
+			if !ok || len(ids) == 0 {
+				return nil, fmt.Errorf("operations must be a non-empty list of operation IDs, got: %v", value)
+			}
+			for _, id := range ids {
+				s, ok := id.(string)
+				if !ok || s == "" {
+					return nil, fmt.Errorf("operations must be a non-empty list of operation IDs, got: %v", value)
+				}
+				batch.OperationIds = append(batch.OperationIds, s)
+			}
+		case "max-requests":
+			n, ok := value.(float64)
+			if !ok || n < 1 || n != float64(int(n)) {
+				return nil, fmt.Errorf("max-requests must be a positive integer, got: %v", value)
+			}
+			batch.MaxRequests = int(n)
+		default:
+			return nil, fmt.Errorf("unknown field %s", key)
+		}
+	}
+	if len(batch.OperationIds) == 0 {
+		return nil, fmt.Errorf("operations lists the operations which can be batched, and is required")
+	}
+	return batch, nil
+}
+
+func extParseEquivalentTo(extPropValue interface{}) ([]EquivalentType, error) {
+	values, ok := extPropValue.([]interface{})
+	if !ok {
+		values = []interface{}{extPropValue}
+	}
+	equivalents := make([]EquivalentType, 0, len(values))
+	for _, value := range values {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to convert type: %T", value)
+		}
+		var equivalent EquivalentType
+		for key, value := range m {
+			s, ok := value.(string)
+			if !ok || s == "" {
+				return nil, fmt.Errorf("%s must be a non-empty string, got: %v", key, value)
+			}
+			switch key {
+			case "path":
+				equivalent.Import.Path = s
+			case "name":
+				equivalent.Import.Name = s
+			case "type":
+				equivalent.TypeName = s
+			default:
+				return nil, fmt.Errorf("unknown field %s", key)
+			}
+		}
+		if equivalent.Import.Path == "" {
+			return nil, fmt.Errorf("path is the import path of the package of the equivalent type, and is required")
+		}
+		if equivalent.Import.Name == "" {
+			name, err := equivalentPackageName(equivalent.Import.Path)
+			if err != nil {
+				return nil, err
+			}
+			equivalent.Import.Name = name
+		}
+		equivalents = append(equivalents, equivalent)
+	}
+	return equivalents, nil
+}
+
+func extParseImplements(extPropValue interface{}) ([]string, error) {
+	values, ok := extPropValue.([]interface{})
+	if !ok {
+		values = []interface{}{extPropValue}
+	}
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert type: %T", value)
+		}
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("the interface name %q isn't an exported Go identifier", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func extParseAPIVersion(extPropValue interface{}) (APIVersionOptions, error) {
+	m, ok := extPropValue.(map[string]interface{})
+	if !ok {
+		return APIVersionOptions{}, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var options APIVersionOptions
+	for key, value := range m {
+		s, ok := value.(string)
+		if !ok || s == "" {
+			return APIVersionOptions{}, fmt.Errorf("%s must be a non-empty string, got: %v", key, value)
+		}
+		switch key {
+		case "header":
+			options.Header = s
+		case "path-prefix":
+			options.PathPrefix = s
+		case "version":
+			options.Version = s
+		default:
+			return APIVersionOptions{}, fmt.Errorf("unknown field %s", key)
+		}
+	}
+	if err := options.Validate(); err != nil {
+		return APIVersionOptions{}, err
+	}
+	return options, nil
+}
+
+func extParseOperationAPIVersion(extPropValue interface{}) (string, error) {
+	version, err := extString(extPropValue)
+	if err != nil {
+		return "", err
+	}
+	if version == "" {
+		return "", fmt.Errorf("the version must not be empty")
+	}
+	return version, nil
+}
+
+func extParseSensitive(extPropValue interface{}) (bool, error) {
+	sensitive, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return sensitive, nil
+}
+
+func extParseVolatile(extPropValue interface{}) (bool, error) {
+	volatile, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return volatile, nil
+}
+
+func extParseEncrypted(extPropValue interface{}) (bool, error) {
+	encrypted, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return encrypted, nil
+}
+
+// bodySizeUnits are the units of the sizes of x-max-body-size, of 1024 times
+// the previous one.
+var bodySizeUnits = []string{"B", "KB", "MB", "GB"}
+
+func extParseMaxBodySize(extPropValue interface{}) (int64, error) {
+	switch v := extPropValue.(type) {
+	case float64:
+		if v <= 0 || v != float64(int64(v)) {
+			return 0, fmt.Errorf("the size must be a positive number of bytes, got: %v", v)
+		}
+		return int64(v), nil
+	case string:
+		s := strings.ToUpper(strings.TrimSpace(v))
+		for i := len(bodySizeUnits) - 1; i >= 0; i-- {
+			if !strings.HasSuffix(s, bodySizeUnits[i]) {
+				continue
+			}
+			n, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(s, bodySizeUnits[i])), 10, 64)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("the size must be a positive number of B, KB, MB or GB, got: %q", v)
+			}
+			return n << (10 * i), nil
+		}
+		return 0, fmt.Errorf("the size must be a positive number of B, KB, MB or GB, got: %q", v)
+	}
+	return 0, fmt.Errorf("failed to convert type: %T", extPropValue)
+}
+
+func extParseRequestTimeout(extPropValue interface{}) (string, error) {
+	timeout, err := extString(extPropValue)
+	if err != nil {
+		return "", err
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return "", err
+	}
+	if d <= 0 {
+		return "", fmt.Errorf("the timeout must be positive, got: %q", timeout)
+	}
+	return timeout, nil
+}
@@ -108,3 +108,310 @@ func Test_extParsePropGoTypeSkipOptionalPointer(t *testing.T) {
 		})
 	}
 }
+
+func Test_extParseRateLimit(t *testing.T) {
+	type args struct {
+		extPropValue json.RawMessage
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *RateLimitDefinition
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    args{json.RawMessage(`{"requests-per-second": 2.5, "burst": 5}`)},
+			want:    &RateLimitDefinition{RequestsPerSecond: 2.5, Burst: 5},
+			wantErr: false,
+		},
+		{
+			name:    "burst defaults to one",
+			args:    args{json.RawMessage(`{"requests-per-second": 10}`)},
+			want:    &RateLimitDefinition{RequestsPerSecond: 10, Burst: 1},
+			wantErr: false,
+		},
+		{
+			name:    "missing rate error",
+			args:    args{json.RawMessage(`{"burst": 5}`)},
+			wantErr: true,
+		},
+		{
+			name:    "fractional burst error",
+			args:    args{json.RawMessage(`{"requests-per-second": 1, "burst": 1.5}`)},
+			wantErr: true,
+		},
+		{
+			name:    "type conversion error",
+			args:    args{json.RawMessage(`10`)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// kin-openapi no longer returns these as RawMessage
+			var extPropValue interface{}
+			if tt.args.extPropValue != nil {
+				err := json.Unmarshal(tt.args.extPropValue, &extPropValue)
+				assert.NoError(t, err)
+			}
+			got, err := extParseRateLimit(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extParseMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.RawMessage
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			value: json.RawMessage(`["auth", "audit"]`),
+			want:  []string{"auth", "audit"},
+		},
+		{
+			name:    "empty name error",
+			value:   json.RawMessage(`["auth", ""]`),
+			wantErr: true,
+		},
+		{
+			name:    "type conversion error",
+			value:   json.RawMessage(`"auth"`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extPropValue interface{}
+			err := json.Unmarshal(tt.value, &extPropValue)
+			assert.NoError(t, err)
+			got, err := extParseMiddleware(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extParseLongRunning(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.RawMessage
+		want    *LongRunningDefinition
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			value: json.RawMessage(`{"operation": "getJob", "status": "state", "location-header": "Operation-Location", "terminal-states": ["done"]}`),
+			want: &LongRunningDefinition{
+				PollOperationId: "getJob",
+				LocationHeader:  "Operation-Location",
+				StatusProperty:  "state",
+				TerminalStates:  []string{"done"},
+			},
+		},
+		{
+			name:  "status and location header defaults",
+			value: json.RawMessage(`{"operation": "getJob", "terminal-states": ["succeeded", "failed"]}`),
+			want: &LongRunningDefinition{
+				PollOperationId: "getJob",
+				LocationHeader:  "Location",
+				StatusProperty:  "status",
+				TerminalStates:  []string{"succeeded", "failed"},
+			},
+		},
+		{
+			name:    "missing operation error",
+			value:   json.RawMessage(`{"terminal-states": ["done"]}`),
+			wantErr: true,
+		},
+		{
+			name:    "missing terminal states error",
+			value:   json.RawMessage(`{"operation": "getJob", "terminal-states": []}`),
+			wantErr: true,
+		},
+		{
+			name:    "unknown field error",
+			value:   json.RawMessage(`{"operation": "getJob", "terminal-states": ["done"], "interval": 5}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extPropValue interface{}
+			err := json.Unmarshal(tt.value, &extPropValue)
+			assert.NoError(t, err)
+			got, err := extParseLongRunning(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extParseMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.RawMessage
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:  "bytes",
+			value: json.RawMessage(`4096`),
+			want:  4096,
+		},
+		{
+			name:  "units",
+			value: json.RawMessage(`"10MB"`),
+			want:  10 << 20,
+		},
+		{
+			name:  "lower case units",
+			value: json.RawMessage(`"512 kb"`),
+			want:  512 << 10,
+		},
+		{
+			name:    "fraction error",
+			value:   json.RawMessage(`1.5`),
+			wantErr: true,
+		},
+		{
+			name:    "negative error",
+			value:   json.RawMessage(`-1`),
+			wantErr: true,
+		},
+		{
+			name:    "unknown unit error",
+			value:   json.RawMessage(`"10TB"`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extPropValue interface{}
+			err := json.Unmarshal(tt.value, &extPropValue)
+			assert.NoError(t, err)
+			got, err := extParseMaxBodySize(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extParseRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.RawMessage
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			value: json.RawMessage(`"1m30s"`),
+			want:  "1m30s",
+		},
+		{
+			name:    "invalid duration error",
+			value:   json.RawMessage(`"soon"`),
+			wantErr: true,
+		},
+		{
+			name:    "zero error",
+			value:   json.RawMessage(`"0s"`),
+			wantErr: true,
+		},
+		{
+			name:    "type conversion error",
+			value:   json.RawMessage(`30`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extPropValue interface{}
+			err := json.Unmarshal(tt.value, &extPropValue)
+			assert.NoError(t, err)
+			got, err := extParseRequestTimeout(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extParseBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.RawMessage
+		want    *BatchDefinition
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			value: json.RawMessage(`{"operations": ["getUser", "updateUser"], "max-requests": 20}`),
+			want: &BatchDefinition{
+				OperationIds: []string{"getUser", "updateUser"},
+				MaxRequests:  20,
+			},
+		},
+		{
+			name:  "any number of requests by default",
+			value: json.RawMessage(`{"operations": ["getUser"]}`),
+			want: &BatchDefinition{
+				OperationIds: []string{"getUser"},
+			},
+		},
+		{
+			name:    "missing operations error",
+			value:   json.RawMessage(`{"max-requests": 20}`),
+			wantErr: true,
+		},
+		{
+			name:    "invalid max requests error",
+			value:   json.RawMessage(`{"operations": ["getUser"], "max-requests": 0.5}`),
+			wantErr: true,
+		},
+		{
+			name:    "unknown field error",
+			value:   json.RawMessage(`{"operations": ["getUser"], "format": "multipart"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extPropValue interface{}
+			err := json.Unmarshal(tt.value, &extPropValue)
+			assert.NoError(t, err)
+			got, err := extParseBatch(extPropValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The orders of the fields of the generated structs, set with the field-order
+// output option.
+const (
+	FieldOrderAlphabetical = "alphabetical"
+	FieldOrderSpec         = "spec"
+	FieldOrderAlignment    = "alignment"
+)
+
+// propertyNames returns the names of the properties of a schema in the order
+// of their struct fields: that of the spec for the spec field order, with the
+// properties whose order is unknown last, and alphabetical otherwise, which
+// the alignment field order then sorts by alignment.
+func propertyNames(schema *openapi3.Schema) []string {
+	names := SortedSchemaKeys(schema.Properties)
+	if globalState.options.OutputOptions.FieldOrder != FieldOrderSpec {
+		return names
+	}
+
+	ordered := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range util.PropertyOrder(schema) {
+		if _, found := schema.Properties[name]; found && !seen[name] {
+			ordered = append(ordered, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range names {
+		if !seen[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// allOfPropertyOrder returns the order of the properties of the schema made
+// of the given ones: theirs, one after the other, each property where it
+// first appears.
+func allOfPropertyOrder(allOf []*openapi3.SchemaRef) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range allOf {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		order := allOfPropertyOrder(ref.Value.AllOf)
+		own := util.PropertyOrder(ref.Value)
+		if own == nil {
+			own = SortedSchemaKeys(ref.Value.Properties)
+		}
+		for _, name := range append(order, own...) {
+			if !seen[name] {
+				names = append(names, name)
+				seen[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// alignProperties sorts properties by the alignment of their fields, largest
+// first, so that their structs need as little padding as possible. Fields of
+// the same alignment keep their order.
+func alignProperties(properties []Property) {
+	sort.SliceStable(properties, func(i, j int) bool {
+		return fieldAlignment(properties[i]) > fieldAlignment(properties[j])
+	})
+}
+
+// typeAlignments are the alignments of the Go types of fields smaller than a
+// word; the others are aligned to a word.
+var typeAlignments = map[string]int{
+	"bool":               1,
+	"byte":               1,
+	"int8":               1,
+	"uint8":              1,
+	"openapi_types.UUID": 1,
+	"int16":              2,
+	"uint16":             2,
+	"int32":              4,
+	"uint32":             4,
+	"float32":            4,
+	"rune":               4,
+}
+
+// fieldAlignment returns the alignment of the struct field of a property, on
+// 64 bit platforms.
+func fieldAlignment(p Property) int {
+	goType := p.structFieldType()
+	if alignment, found := typeAlignments[goType]; found {
+		return alignment
+	}
+	if strings.ContainsAny(goType, "*[]{}. ") || p.Schema.OAPISchema == nil {
+		return 8
+	}
+	// A type of the spec, like an int32 enum
+	return schemaAlignment(p.Schema.OAPISchema)
+}
+
+// schemaAlignment returns the alignment of the Go type of a schema which
+// isn't an object.
+func schemaAlignment(schema *openapi3.Schema) int {
+	if len(schema.Properties) != 0 || len(schema.AllOf) != 0 || len(schema.AnyOf) != 0 || len(schema.OneOf) != 0 {
+		return 8
+	}
+	switch schema.Type {
+	case "boolean":
+		return 1
+	case "integer":
+		switch schema.Format {
+		case "int8", "uint8":
+			return 1
+		case "int16", "uint16":
+			return 2
+		case "int32", "uint32":
+			return 4
+		}
+	case "number":
+		if schema.Format == "float" {
+			return 4
+		}
+	case "string":
+		if schema.Format == "uuid" {
+			if _, mapped := globalState.options.OutputOptions.FormatMapping["uuid"]; !mapped {
+				return 1
+			}
+		}
+	}
+	return 8
+}
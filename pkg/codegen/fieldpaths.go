@@ -0,0 +1,193 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// SchemaFieldPaths describes the paths of the marked values of the JSON of a
+// component schema.
+type SchemaFieldPaths struct {
+	Ref    string     // The reference of the component, like #/components/schemas/Account
+	Fields [][]string // The paths of the marked values of its JSON
+}
+
+// fieldPathsFinder finds the paths of the values of the JSON of schemas which
+// are marked, like with x-sensitive. The paths are made of the names of the
+// properties leading to the values, and * for the items of arrays and the
+// values of maps. A path may end with the reference of a component holding
+// marked values, whose paths continue it, so that those of recursive schemas
+// are found at any depth.
+type fieldPathsFinder struct {
+	extension  string                               // The extension marking the values, for the errors
+	marked     func(*openapi3.Schema) (bool, error) // Whether the values of a schema are marked
+	components map[string][][]string                // The paths of the components, keyed by reference
+	finding    map[string]bool                      // The components whose paths are being found
+}
+
+func newFieldPathsFinder(extension string, marked func(*openapi3.Schema) (bool, error)) *fieldPathsFinder {
+	return &fieldPathsFinder{
+		extension:  extension,
+		marked:     marked,
+		components: make(map[string][][]string),
+		finding:    make(map[string]bool),
+	}
+}
+
+// contentFields returns the paths of the marked values of the JSON media
+// types of a request or a response.
+func (f *fieldPathsFinder) contentFields(content openapi3.Content) ([][]string, error) {
+	var fields [][]string
+	for _, contentType := range SortedContentKeys(content) {
+		mediaType := content[contentType]
+		if !util.IsMediaTypeJson(contentType) || mediaType == nil {
+			continue
+		}
+		found, err := f.fields(nil, mediaType.Schema)
+		if err != nil {
+			return nil, err
+		}
+		fields = appendUniqueFields(fields, found...)
+	}
+	return fields, nil
+}
+
+// fields returns the paths of the marked values of a schema, at path.
+func (f *fieldPathsFinder) fields(path []string, schema *openapi3.SchemaRef) ([][]string, error) {
+	if schema == nil || schema.Value == nil {
+		return nil, nil
+	}
+	if strings.HasPrefix(schema.Ref, "#/components/schemas/") {
+		if !f.finding[schema.Ref] {
+			if _, ok := f.components[schema.Ref]; !ok {
+				f.finding[schema.Ref] = true
+				fields, err := f.inlineFields(nil, schema.Value)
+				delete(f.finding, schema.Ref)
+				if err != nil {
+					return nil, fmt.Errorf("error finding the fields of %s: %w", schema.Ref, err)
+				}
+				f.components[schema.Ref] = fields
+			}
+			if len(f.components[schema.Ref]) == 0 {
+				return nil, nil
+			}
+		}
+		// The paths of a component being found are referenced too, and dropped
+		// along with those of the components found to hold no marked value
+		return [][]string{appendPath(path, schema.Ref)}, nil
+	}
+	return f.inlineFields(path, schema.Value)
+}
+
+// inlineFields returns the paths of the marked values of the schema itself,
+// at path.
+func (f *fieldPathsFinder) inlineFields(path []string, schema *openapi3.Schema) ([][]string, error) {
+	marked, err := f.marked(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q at %s: %w", f.extension, strings.Join(append([]string{"$"}, path...), "."), err)
+	}
+	if marked {
+		return [][]string{appendPath(path)}, nil
+	}
+
+	var fields [][]string
+	add := func(path []string, schema *openapi3.SchemaRef) error {
+		found, err := f.fields(path, schema)
+		fields = appendUniqueFields(fields, found...)
+		return err
+	}
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		if err := add(appendPath(path, name), schema.Properties[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(appendPath(path, "*"), schema.Items); err != nil {
+		return nil, err
+	}
+	if err := add(appendPath(path, "*"), schema.AdditionalProperties.Schema); err != nil {
+		return nil, err
+	}
+	for _, schemas := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, sub := range schemas {
+			if err := add(path, sub); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fields, nil
+}
+
+// componentFields returns the paths of the marked values of the components
+// which the paths found reference, leaving out the references of the
+// components which hold none from them, and from the lists of paths given.
+func (f *fieldPathsFinder) componentFields(lists ...*[][]string) []SchemaFieldPaths {
+	// A component holds marked values when it has paths other than
+	// references, or references one holding some
+	holding := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for ref, fields := range f.components {
+			if holding[ref] {
+				continue
+			}
+			for _, field := range fields {
+				last := field[len(field)-1:]
+				if len(last) == 0 || !strings.HasPrefix(last[0], "#/") || holding[last[0]] {
+					holding[ref], changed = true, true
+					break
+				}
+			}
+		}
+	}
+	keep := func(fields [][]string) [][]string {
+		var kept [][]string
+		for _, field := range fields {
+			if len(field) == 0 || !strings.HasPrefix(field[len(field)-1], "#/") || holding[field[len(field)-1]] {
+				kept = append(kept, field)
+			}
+		}
+		return kept
+	}
+	for _, list := range lists {
+		*list = keep(*list)
+	}
+
+	refs := make([]string, 0, len(holding))
+	for ref := range holding {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	components := make([]SchemaFieldPaths, 0, len(refs))
+	for _, ref := range refs {
+		components = append(components, SchemaFieldPaths{Ref: ref, Fields: keep(f.components[ref])})
+	}
+	return components
+}
+
+// appendPath returns a copy of path with the elements appended.
+func appendPath(path []string, elems ...string) []string {
+	return append(append(make([]string, 0, len(path)+len(elems)), path...), elems...)
+}
+
+// appendUniqueFields appends the paths of fields which fields doesn't hold
+// yet.
+func appendUniqueFields(fields [][]string, more ...[]string) [][]string {
+	for _, field := range more {
+		found := false
+		for _, f := range fields {
+			if len(f) == len(field) && strings.Join(f, "\x00") == strings.Join(field, "\x00") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
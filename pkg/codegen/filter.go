@@ -11,6 +11,22 @@ func filterOperationsByTag(swagger *openapi3.T, opts Configuration) {
 	}
 }
 
+// filterOperationsByOperationId removes the operations left out by the
+// include-operation-ids and exclude-operation-ids output options.
+func filterOperationsByOperationId(swagger *openapi3.T, opts Configuration) {
+	include, exclude := opts.OutputOptions.IncludeOperationIds, opts.OutputOptions.ExcludeOperationIds
+	if len(include) == 0 && len(exclude) == 0 {
+		return
+	}
+	for _, pathItem := range swagger.Paths {
+		for name, op := range pathItem.Operations() {
+			if StringInArray(op.OperationID, exclude) || (len(include) > 0 && !StringInArray(op.OperationID, include)) {
+				pathItem.SetOperation(name, nil)
+			}
+		}
+	}
+}
+
 func excludeOperationsWithTags(paths openapi3.Paths, tags []string) {
 	includeOperationsWithTags(paths, tags, true)
 }
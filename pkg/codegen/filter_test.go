@@ -67,3 +67,35 @@ func TestFilterOperationsByTag(t *testing.T) {
 		assert.NotContains(t, code, `"/cat"`)
 	})
 }
+
+func TestFilterOperationsByOperationId(t *testing.T) {
+	t.Run("include operation ids", func(t *testing.T) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneSpecTestFixture))
+		assert.NoError(t, err)
+
+		filterOperationsByOperationId(swagger, Configuration{
+			OutputOptions: OutputOptions{
+				IncludeOperationIds: []string{"getCatStatus"},
+			},
+		})
+		assert.NotNil(t, swagger.Paths["/cat"].Get)
+		assert.Nil(t, swagger.Paths["/dog"].Get)
+
+		pruneUnusedComponents(swagger)
+		assert.Len(t, swagger.Components.Schemas, 3)
+		assert.NotContains(t, swagger.Components.Schemas, "DogAlive")
+	})
+
+	t.Run("exclude operation ids", func(t *testing.T) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneSpecTestFixture))
+		assert.NoError(t, err)
+
+		filterOperationsByOperationId(swagger, Configuration{
+			OutputOptions: OutputOptions{
+				ExcludeOperationIds: []string{"getCatStatus"},
+			},
+		})
+		assert.Nil(t, swagger.Paths["/cat"].Get)
+		assert.NotNil(t, swagger.Paths["/dog"].Get)
+	})
+}
@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Fingerprint returns a hash of everything the code generated for the spec
+// with the given configuration depends on: the spec with the documents it
+// references, the configuration, including any user templates, and the
+// generator with its built-in templates. Generating again can be skipped
+// while it doesn't change.
+func Fingerprint(spec *openapi3.T, opts Configuration) (string, error) {
+	h := sha256.New()
+
+	specJSON, err := spec.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("error marshaling spec: %w", err)
+	}
+	h.Write(specJSON)
+	// The JSON of the spec only has the references to the values of other
+	// documents, which are generated unless they're in the import mapping.
+	if err := hashRefs(h, reflect.ValueOf(spec), make(map[uintptr]bool)); err != nil {
+		return "", fmt.Errorf("error marshaling referenced document: %w", err)
+	}
+
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling configuration: %w", err)
+	}
+	h.Write(optsJSON)
+
+	hashGenerator(h)
+	// The templates are those of the generator, unless it's used as a library
+	// by a program which vendored them, so they're hashed too.
+	err = fs.WalkDir(templates, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		contents, err := templates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s:%d:", path, len(contents))
+		h.Write(contents)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading templates: %w", err)
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashGenerator hashes the generator: its executable, which changes with its
+// code and that of its dependencies whatever their versions say, or else its
+// build information.
+func hashGenerator(h io.Writer) {
+	if path, err := os.Executable(); err == nil {
+		if f, err := os.Open(path); err == nil {
+			defer f.Close()
+			if _, err := io.Copy(h, f); err == nil {
+				return
+			}
+		}
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprint(h, bi.String())
+	}
+}
+
+// hashRefs hashes the values of the references found in v, a part of a spec,
+// as they were resolved, be they in the spec or in other documents. The maps
+// are walked in the order of their keys, for the hash to be stable.
+func hashRefs(h io.Writer, v reflect.Value, visited map[uintptr]bool) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return nil
+		}
+		visited[v.Pointer()] = true
+		return hashRefs(h, v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return hashRefs(h, v.Elem(), visited)
+	case reflect.Struct:
+		ref, value := v.FieldByName("Ref"), v.FieldByName("Value")
+		if ref.Kind() == reflect.String && ref.String() != "" && value.IsValid() && value.CanInterface() {
+			data, err := json.Marshal(value.Interface())
+			if err != nil {
+				return fmt.Errorf("%s: %w", ref.String(), err)
+			}
+			fmt.Fprintf(h, "%s:%d:", ref.String(), len(data))
+			h.Write(data)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := hashRefs(h, v.Field(i), visited); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if err := hashRefs(h, v.MapIndex(key), visited); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := hashRefs(h, v.Index(i), visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
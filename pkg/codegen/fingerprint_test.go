@@ -0,0 +1,91 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestFingerprint(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/servers.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+
+	fingerprint, err := Fingerprint(swagger, opts)
+	require.NoError(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", fingerprint)
+
+	// It is stable
+	again, err := Fingerprint(swagger, opts)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, again)
+
+	// It changes with the configuration
+	opts.Generate.Client = true
+	withClient, err := Fingerprint(swagger, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, withClient)
+
+	// And with the spec
+	swagger.Info.Title += " v2"
+	renamed, err := Fingerprint(swagger, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, withClient, renamed)
+}
+
+func TestFingerprintExternalDocuments(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	petsPath := filepath.Join(dir, "pets.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(`openapi: 3.0.1
+info: {title: Pets, version: "1"}
+paths: {}
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        pet: {$ref: "pets.yaml#/components/schemas/Pet"}
+`), 0o600))
+	pets := `openapi: 3.0.1
+info: {title: Pets, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+	fingerprint := func() string {
+		// Not with the default reader, which caches the documents it read
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		loader.ReadFromURIFunc = openapi3.ReadFromFile
+		swagger, err := loader.LoadFromFile(specPath)
+		require.NoError(t, err)
+		fingerprint, err := Fingerprint(swagger, Configuration{PackageName: "api", Generate: GenerateOptions{Models: true}})
+		require.NoError(t, err)
+		return fingerprint
+	}
+	require.NoError(t, os.WriteFile(petsPath, []byte(pets), 0o600))
+	before := fingerprint()
+	assert.Equal(t, before, fingerprint())
+
+	// It changes with the documents the spec references, not only the spec
+	require.NoError(t, os.WriteFile(petsPath, []byte(pets+"        age: {type: integer}\n"), 0o600))
+	assert.NotEqual(t, before, fingerprint())
+}
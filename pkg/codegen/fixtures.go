@@ -0,0 +1,283 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"golang.org/x/tools/imports"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// FixturesContext is passed to the template of the fixtures package.
+type FixturesContext struct {
+	ModuleName  string
+	PackageName string
+	ImportPath  string // The import path of the generated package, which the fixtures import
+	ImportAlias string // The name the fixtures import the generated package as, when the import path doesn't end with it
+	Fixtures    []Fixture
+}
+
+// Fixture is a function of the fixtures package, returning an example of the
+// spec as a value of its type.
+type Fixture struct {
+	Name    string // The name of the function, like PetExample
+	Type    string // The type of the value, qualified with the generated package
+	Doc     string // What the function returns, like the example of the Pet schema
+	Example string // The example in JSON, as a Go string literal
+}
+
+// goIdentifier matches the identifiers in Go types, the names of the
+// generated types among them, which the packages importing the generated one
+// qualify.
+var goIdentifier = regexp.MustCompile(`\.?[A-Za-z_][A-Za-z0-9_]*`)
+
+// GenerateFixtures generates the fixtures package, with the fixtures option,
+// whose functions return the examples of the spec as values of the generated
+// types: those of the schemas, and those of the request bodies and responses
+// of the operations. The schemas without an example get one put together
+// from those of their properties, like in the contract tests. The examples
+// which don't validate against their schemas have no fixture.
+func GenerateFixtures(t *template.Template, spec *openapi3.T, ops []OperationDefinition) (string, error) {
+	opts := globalState.options
+	context := FixturesContext{PackageName: opts.PackageName}
+	var err error
+	context.ImportPath, context.ImportAlias, err = generatedPackageImport("fixtures")
+	if err != nil {
+		return "", err
+	}
+	context.ModuleName, _ = generatorVersion(opts.NoVCSVersionOverride)
+
+	types := globalState.componentTypes
+	f := fixtures{names: make(map[string]bool), types: make(map[string]bool), packageName: context.PackageName}
+	for _, td := range types {
+		f.types[td.TypeName] = true
+	}
+	for _, op := range ops {
+		for _, td := range op.TypeDefinitions {
+			f.types[td.TypeName] = true
+		}
+		for _, body := range op.Bodies {
+			if body.IsSupported() {
+				f.types[body.TypeDef(op.OperationId).TypeName] = true
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if seen[td.TypeName] || td.Schema.OAPISchema == nil {
+			continue
+		}
+		seen[td.TypeName] = true
+		schema := &openapi3.SchemaRef{Value: td.Schema.OAPISchema}
+		if example := schemaExample(schema, false, make(map[*openapi3.Schema]bool)); example != nil {
+			f.add(td.TypeName+"Example", td.TypeName, fmt.Sprintf("the example of the %s schema", td.JsonName), schema, nil, example)
+		}
+	}
+
+	for _, op := range ops {
+		for _, body := range op.Bodies {
+			if !body.IsSupported() || !util.IsMediaTypeJson(body.ContentType) || op.Spec.RequestBody == nil || op.Spec.RequestBody.Value == nil {
+				continue
+			}
+			name := op.OperationId + "Request"
+			if !body.Default {
+				name = op.OperationId + body.NameTag + "Request"
+			}
+			mediaType := op.Spec.RequestBody.Value.Content.Get(body.ContentType)
+			for _, example := range mediaTypeExamples(mediaType, true) {
+				doc := fmt.Sprintf("the example of the %s request body of %s", body.ContentType, op.OperationId)
+				if example.name != "" {
+					doc = fmt.Sprintf("the %s example of the %s request body of %s", example.name, body.ContentType, op.OperationId)
+				}
+				f.add(name+example.suffix+"Example", body.TypeDef(op.OperationId).TypeName, doc, mediaType.Schema, openapi3.VisitAsRequest(), example.value)
+			}
+		}
+		for _, response := range op.Responses {
+			responseRef := op.Spec.Responses[response.StatusCode]
+			if responseRef == nil || responseRef.Value == nil {
+				continue
+			}
+			for _, content := range response.Contents {
+				if !util.IsMediaTypeJson(content.ContentType) {
+					continue
+				}
+				name := op.OperationId + ToCamelCase(response.StatusCode) + "Response"
+				if len(response.Contents) > 1 {
+					name = op.OperationId + ToCamelCase(response.StatusCode) + content.NameTagOrContentType() + "Response"
+				}
+				goType := content.Schema.TypeDecl()
+				mediaType := responseRef.Value.Content.Get(content.ContentType)
+				for _, example := range mediaTypeExamples(mediaType, false) {
+					if strings.HasPrefix(goType, "struct") {
+						warn(&op, jsonPointer("paths", op.Path, strings.ToLower(op.Method), "responses", response.StatusCode), "the example of the %s response has no fixture, as its type is an inline struct", content.ContentType)
+						break
+					}
+					doc := fmt.Sprintf("the example of the %s %s response of %s", response.StatusCode, content.ContentType, op.OperationId)
+					if example.name != "" {
+						doc = fmt.Sprintf("the %s example of the %s %s response of %s", example.name, response.StatusCode, content.ContentType, op.OperationId)
+					}
+					f.add(name+example.suffix+"Example", goType, doc, mediaType.Schema, openapi3.VisitAsResponse(), example.value)
+				}
+			}
+		}
+	}
+	context.Fixtures = f.fixtures
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "fixtures.tmpl", context); err != nil {
+		return "", fmt.Errorf("error generating the fixtures: %w", err)
+	}
+	out, err := imports.Process("fixtures.go", buf.Bytes(), nil)
+	if err != nil {
+		return "", fmt.Errorf("error formatting the fixtures: %w", err)
+	}
+	if opts.OutputOptions.ZeroDependencies {
+		target, ok := sharedRuntimeTarget()
+		if !ok {
+			target.qualifier = context.ImportAlias
+			if target.qualifier == "" {
+				target.qualifier = assumedPackageName(context.ImportPath)
+			}
+		}
+		if out, err = dropRuntimeImports(out, target, make(map[string]bool)); err != nil {
+			return "", fmt.Errorf("error in the fixtures: %w", err)
+		}
+	}
+	if out, err = rewriteImports(out); err != nil {
+		return "", fmt.Errorf("error rewriting the imports of the fixtures: %w", err)
+	}
+	return string(out), nil
+}
+
+// fixtures collects the functions of the fixtures package.
+type fixtures struct {
+	fixtures    []Fixture
+	names       map[string]bool // The names of the functions
+	types       map[string]bool // The names of the generated types
+	packageName string
+}
+
+// add adds the function returning an example as a value of a Go type,
+// unless its type isn't one the fixtures can name, or the example doesn't
+// validate against its schema, with the given option for the request and
+// response bodies, if any.
+func (f *fixtures) add(name, goType, doc string, schema *openapi3.SchemaRef, visitAs openapi3.SchemaValidationOption, example interface{}) {
+	encoded, err := json.Marshal(example)
+	if err != nil {
+		warn(nil, "", "%s has no fixture, as it can't be encoded: %s", doc, err)
+		return
+	}
+	if schema != nil && schema.Value != nil {
+		var value interface{}
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			warn(nil, "", "%s has no fixture, as it can't be decoded: %s", doc, err)
+			return
+		}
+		var opts []openapi3.SchemaValidationOption
+		if visitAs != nil {
+			opts = append(opts, visitAs)
+		}
+		if err := schema.Value.VisitJSON(value, opts...); err != nil {
+			reason, _, _ := strings.Cut(err.Error(), "\n")
+			warn(nil, "", "%s has no fixture, as it doesn't match its schema: %s", doc, reason)
+			return
+		}
+	}
+	qualified, ok := f.qualify(goType)
+	if !ok {
+		warn(nil, "", "%s has no fixture, as its type %s is from another package", doc, goType)
+		return
+	}
+	if f.names[name] {
+		warn(nil, "", "%s has no fixture, as %s is already taken", doc, name)
+		return
+	}
+	f.names[name] = true
+	literal := "`" + string(encoded) + "`"
+	if strings.Contains(literal[1:len(literal)-1], "`") {
+		literal = strconv.Quote(string(encoded))
+	}
+	f.fixtures = append(f.fixtures, Fixture{Name: name, Type: qualified, Doc: doc, Example: literal})
+}
+
+// qualify returns a Go type with the generated types in it qualified with
+// the generated package, or false when it has types of other packages.
+func (f *fixtures) qualify(goType string) (string, bool) {
+	return qualifyGoType(goType, f.packageName, f.types)
+}
+
+// generatedPackageImport returns the import path of the generated package,
+// which the packages written next to it, like the fixtures, import, and the
+// name to import it as, when its path doesn't end with it.
+func generatedPackageImport(importer string) (string, string, error) {
+	opts := globalState.options
+	importPath := opts.OutputOptions.ScaffoldImportPath
+	if importPath == "" {
+		return "", "", fmt.Errorf("the %s import the generated package, so its import path must be set with scaffold-import-path", importer)
+	}
+	if path.Base(importPath) != opts.PackageName {
+		return importPath, opts.PackageName, nil
+	}
+	return importPath, "", nil
+}
+
+// qualifyGoType returns a Go type with the given generated types in it
+// qualified with the generated package, or false when it has types of other
+// packages, or inline structs.
+func qualifyGoType(goType, packageName string, types map[string]bool) (string, bool) {
+	ok := true
+	qualified := goIdentifier.ReplaceAllStringFunc(goType, func(name string) string {
+		switch {
+		case strings.HasPrefix(name, "."):
+			ok = false
+		case types[name]:
+			return packageName + "." + name
+		}
+		return name
+	})
+	return qualified, ok && !strings.Contains(goType, "struct")
+}
+
+// namedExample is an example of a media type, and the suffix of the name of
+// its fixture, from its name among the examples.
+type namedExample struct {
+	name   string
+	suffix string
+	value  interface{}
+}
+
+// mediaTypeExamples returns the examples of a media type: its example, its
+// named examples, or else the example of its schema, for a request or a
+// response, like mediaTypeExample.
+func mediaTypeExamples(mediaType *openapi3.MediaType, request bool) []namedExample {
+	if mediaType == nil {
+		return nil
+	}
+	if mediaType.Example != nil {
+		return []namedExample{{value: mediaType.Example}}
+	}
+	if len(mediaType.Examples) != 0 {
+		var examples []namedExample
+		for _, name := range sortedExampleKeys(mediaType.Examples) {
+			example := mediaType.Examples[name]
+			if example == nil || example.Value == nil || example.Value.Value == nil {
+				continue
+			}
+			examples = append(examples, namedExample{name: name, suffix: SchemaNameToTypeName(name), value: example.Value.Value})
+		}
+		return examples
+	}
+	if value := schemaExample(mediaType.Schema, request, make(map[*openapi3.Schema]bool)); value != nil {
+		return []namedExample{{value: value}}
+	}
+	return nil
+}
@@ -0,0 +1,470 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"golang.org/x/tools/imports"
+)
+
+// GeneratorsContext is passed to the template of the generators package.
+type GeneratorsContext struct {
+	ModuleName  string
+	PackageName string
+	ImportPath  string // The import path of the generated package, which the generators import
+	ImportAlias string // The name the generators import the generated package as, when the import path doesn't end with it
+	Generators  []ModelGenerator
+}
+
+// ModelGenerator is a function of the generators package, returning a rapid
+// generator of the valid values of a model type.
+type ModelGenerator struct {
+	TypeName string // The name of the type, and of the function, like Pet
+	Type     string // The type, qualified with the generated package
+	Draw     string // The statements drawing value, of the type, with t
+}
+
+// dateRange is the number of days from 1970 of the times drawn, up to 2100.
+const dateRange = 47482
+
+// GenerateGenerators generates the generators package, with the generators
+// option, whose functions return pgregory.net/rapid generators of the valid
+// values of the model types: within the ranges, lengths and item counts of
+// their schemas, among their enum values, and matching their patterns, for
+// property-based and fuzz tests of the services built on the types.
+func GenerateGenerators(t *template.Template, spec *openapi3.T) (string, error) {
+	opts := globalState.options
+	context := GeneratorsContext{PackageName: opts.PackageName}
+	var err error
+	context.ImportPath, context.ImportAlias, err = generatedPackageImport("generators")
+	if err != nil {
+		return "", err
+	}
+	context.ModuleName, _ = generatorVersion(opts.NoVCSVersionOverride)
+
+	types := globalState.componentTypes
+	g := modelGenerators{types: make(map[string]TypeDefinition), names: make(map[string]bool), packageName: context.PackageName}
+	var unique []TypeDefinition
+	for _, td := range types {
+		if _, found := g.types[td.TypeName]; !found {
+			g.types[td.TypeName] = td
+			g.names[td.TypeName] = true
+			unique = append(unique, td)
+		}
+	}
+
+	// The types made of types without a generator have none either, until
+	// none is left out.
+	g.available = make(map[string]bool)
+	for _, td := range unique {
+		g.available[td.TypeName] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		context.Generators = nil
+		for _, td := range unique {
+			if !g.available[td.TypeName] {
+				continue
+			}
+			generator, reason := g.generator(td)
+			if reason != "" {
+				warn(nil, jsonPointer("components", "schemas", td.JsonName), "%s has no generator, as %s", td.TypeName, reason)
+				g.available[td.TypeName] = false
+				changed = true
+				break
+			}
+			context.Generators = append(context.Generators, generator)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "generators.tmpl", context); err != nil {
+		return "", fmt.Errorf("error generating the generators: %w", err)
+	}
+	out, err := imports.Process("generators.go", buf.Bytes(), nil)
+	if err != nil {
+		return "", fmt.Errorf("error formatting the generators: %w", err)
+	}
+	if out, err = rewriteImports(out); err != nil {
+		return "", fmt.Errorf("error rewriting the imports of the generators: %w", err)
+	}
+	return string(out), nil
+}
+
+// modelGenerators writes the generators of the model types, whose functions
+// call each other for the types they're made of.
+type modelGenerators struct {
+	types       map[string]TypeDefinition
+	names       map[string]bool // The names of the types
+	available   map[string]bool // Whether the types have a generator
+	packageName string
+	// Why the type being written has no generator, when it has none
+	reason string
+}
+
+// generator returns the generator of a type, or why it has none.
+func (g *modelGenerators) generator(td TypeDefinition) (ModelGenerator, string) {
+	g.reason = ""
+	typ, ok := qualifyGoType(td.TypeName, g.packageName, g.names)
+	if !ok {
+		return ModelGenerator{}, "its type is unknown"
+	}
+	// The values of the types defined as others are converted.
+	named := typ
+	if td.IsAlias() {
+		named = ""
+	}
+	draw := g.fill(td.Schema, "value", strconv.Quote(td.TypeName), named, 0)
+	return ModelGenerator{TypeName: td.TypeName, Type: typ, Draw: draw}, g.reason
+}
+
+// fail records why the type being written has no generator.
+func (g *modelGenerators) fail(format string, args ...interface{}) string {
+	if g.reason == "" {
+		g.reason = fmt.Sprintf(format, args...)
+	}
+	return ""
+}
+
+// fill returns the statements assigning dst a value drawn for a schema,
+// labelled label in the failures, converted to the named type when it's set.
+func (g *modelGenerators) fill(schema Schema, dst, label, named string, depth int) string {
+	name := schema.TypeDecl()
+	if _, declared := g.types[name]; declared || schema.IsRef() {
+		if !g.available[name] {
+			if _, found := g.types[name]; found {
+				return g.fail("%s has none", name)
+			}
+			return g.fail("the type %s is from another package", name)
+		}
+		return g.assign(dst, fmt.Sprintf("%s().Draw(t, %s)", name, label), named)
+	}
+
+	s := schema.OAPISchema
+	switch {
+	case s != nil && len(s.Enum) != 0:
+		values := make([]string, 0, len(s.Enum))
+		for _, value := range s.Enum {
+			if literal, ok := goLiteral(value); ok {
+				values = append(values, literal)
+			}
+		}
+		if len(values) == 0 {
+			return g.fail("its enum values aren't scalars")
+		}
+		return fmt.Sprintf("sample(%s, t, %s, %s)", address(dst), label, strings.Join(values, ", "))
+	case len(schema.TupleElements) != 0:
+		// The optional items are left out, which arrays may end before.
+		var statements []string
+		for _, element := range schema.TupleElements {
+			if !element.Optional {
+				statements = append(statements, g.fill(element.Schema, selector(dst)+"."+element.GoName, strconv.Quote(element.GoName), "", depth+1))
+			}
+		}
+		return strings.Join(statements, "\n")
+	case schema.ArrayType != nil:
+		return g.fillSlice(*schema.ArrayType, s, dst, label, depth)
+	case strings.HasPrefix(schema.GoType, "map["):
+		return g.fillMap(schema, dst, label, depth)
+	case strings.HasPrefix(schema.GoType, "struct"):
+		var statements []string
+		for _, p := range schema.Properties {
+			if p.JsonIgnored() {
+				continue
+			}
+			field := selector(dst) + "." + p.structFieldName()
+			fieldLabel := strconv.Quote(p.JsonFieldName)
+			if !strings.HasPrefix(p.structFieldType(), "*") {
+				statements = append(statements, g.fill(p.Schema, field, fieldLabel, "", depth+1))
+				continue
+			}
+			// The optional and nullable properties are drawn present or not.
+			pointer := fmt.Sprintf("p%d", depth)
+			statements = append(statements, fmt.Sprintf("if rapid.Bool().Draw(t, %s) {\n%s := alloc(&%s)\n%s\n}",
+				strconv.Quote("has "+p.JsonFieldName), pointer, field, g.fill(p.Schema, "*"+pointer, fieldLabel, "", depth+1)))
+		}
+		if len(schema.UnionElements) != 0 {
+			statements = append(statements, g.fillUnion(schema.UnionElements, selector(dst), label, depth))
+		}
+		return strings.Join(statements, "\n")
+	}
+	return g.fillScalar(schema, dst, label, named)
+}
+
+// fillSlice returns the statements assigning dst a slice of items drawn for a
+// schema, as many as its minItems and maxItems allow, up to 4 more than the
+// least.
+func (g *modelGenerators) fillSlice(item Schema, s *openapi3.Schema, dst, label string, depth int) string {
+	least, most := 0, -1
+	if s != nil {
+		least = int(s.MinItems)
+		if s.MaxItems != nil {
+			most = int(*s.MaxItems)
+		}
+	}
+	if most < 0 {
+		most = least + 4
+	}
+	i := fmt.Sprintf("i%d", depth)
+	return fmt.Sprintf("grow(%s, rapid.IntRange(%d, %d).Draw(t, %s))\nfor %s := range %s {\n%s\n}",
+		address(dst), least, most, strconv.Quote("len "+unquote(label)), i, indexed(dst), g.fill(item, fmt.Sprintf("%s[%s]", indexed(dst), i), label, "", depth+1))
+}
+
+// fillMap returns the statements assigning dst a map of values drawn for the
+// additional properties of a schema, as many as its minProperties and
+// maxProperties allow, up to 4 more than the least.
+func (g *modelGenerators) fillMap(schema Schema, dst, label string, depth int) string {
+	least, most := 0, -1
+	if s := schema.OAPISchema; s != nil {
+		least = int(s.MinProps)
+		if s.MaxProps != nil {
+			most = int(*s.MaxProps)
+		}
+	}
+	if most < 0 {
+		most = least + 4
+	}
+	elem, pointer := mapElement(schema)
+	k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+	fillValue := g.fill(elem, v, label, "", depth+1)
+	if pointer {
+		fillValue = fmt.Sprintf("p%d := alloc(&%s)\n%s", depth, v, g.fill(elem, fmt.Sprintf("*p%d", depth), label, "", depth+1))
+	}
+	keys := fmt.Sprintf("rapid.SliceOfNDistinct(rapid.StringN(1, 16, -1), %d, %d, rapid.ID[string]).Draw(t, %s)", least, most, strconv.Quote("keys "+unquote(label)))
+	return fmt.Sprintf("initMap(%s)\nfor _, %s := range %s {\n%s := elem(%s)\n%s\n%s[%s] = %s\n}",
+		address(dst), k, keys, v, indexed(dst), fillValue, indexed(dst), k, v)
+}
+
+// fillUnion returns the statements setting the union of dst to a value
+// drawn for one of its elements.
+func (g *modelGenerators) fillUnion(elements []UnionElement, dst, label string, depth int) string {
+	e := fmt.Sprintf("e%d", depth)
+	cases := make([]string, 0, len(elements))
+	for i, element := range elements {
+		name := element.String()
+		var draw string
+		switch _, declared := g.types[name]; {
+		case declared && g.available[name]:
+			draw = fmt.Sprintf("%s := %s().Draw(t, %s)", e, name, label)
+		case declared:
+			return g.fail("%s has none", name)
+		default:
+			typ, ok := qualifyGoType(name, g.packageName, g.names)
+			if !ok {
+				return g.fail("the type %s is from another package", name)
+			}
+			draw = fmt.Sprintf("var %s %s\n%s", e, typ, g.fillScalar(Schema{GoType: name}, e, label, ""))
+		}
+		cases = append(cases, fmt.Sprintf("case %d:\n%s\nif err := %s.From%s(%s); err != nil {\npanic(err)\n}", i, draw, dst, element.Method(), e))
+	}
+	return fmt.Sprintf("switch rapid.IntRange(0, %d).Draw(t, %s) {\n%s\n}", len(elements)-1, strconv.Quote("element "+unquote(label)), strings.Join(cases, "\n"))
+}
+
+// rapidIntegers are the rapid generators of the values of the integer types,
+// named like them.
+var rapidIntegers = map[string]string{
+	"int": "Int", "int8": "Int8", "int16": "Int16", "int32": "Int32", "int64": "Int64",
+	"uint": "Uint", "uint8": "Uint8", "uint16": "Uint16", "uint32": "Uint32", "uint64": "Uint64",
+	"float32": "Float32", "float64": "Float64",
+}
+
+// fillScalar returns the statement assigning dst a scalar drawn for a
+// schema. The values of the types of other packages which it doesn't know
+// are left zero.
+func (g *modelGenerators) fillScalar(schema Schema, dst, label, named string) string {
+	s := schema.OAPISchema
+	if s == nil {
+		s = &openapi3.Schema{}
+	}
+	switch schema.GoType {
+	case "string":
+		var expr string
+		if s.Pattern != "" {
+			if _, err := regexp.Compile(s.Pattern); err != nil {
+				return g.fail("its pattern %q isn't a Go regular expression", s.Pattern)
+			}
+			expr = fmt.Sprintf("rapid.StringMatching(%s)", goStringLiteral(s.Pattern))
+			if s.MinLength != 0 || s.MaxLength != nil {
+				most := math.MaxInt32
+				if s.MaxLength != nil {
+					most = int(*s.MaxLength)
+				}
+				expr += fmt.Sprintf(".Filter(func(v string) bool {\nn := utf8.RuneCountInString(v)\nreturn n >= %d && n <= %d\n})", s.MinLength, most)
+			}
+		} else {
+			most := -1
+			if s.MaxLength != nil {
+				most = int(*s.MaxLength)
+			}
+			expr = fmt.Sprintf("rapid.StringN(%d, %d, -1)", s.MinLength, most)
+		}
+		return g.assign(dst, fmt.Sprintf("%s.Draw(t, %s)", expr, label), named)
+	case "bool":
+		return g.assign(dst, fmt.Sprintf("rapid.Bool().Draw(t, %s)", label), named)
+	case "[]byte":
+		most := -1
+		if s.MaxLength != nil {
+			most = int(*s.MaxLength)
+		}
+		return g.assign(dst, fmt.Sprintf("rapid.SliceOfN(rapid.Byte(), %d, %d).Draw(t, %s)", s.MinLength, most, label), named)
+	case "time.Time":
+		return g.assign(dst, fmt.Sprintf("time.Unix(rapid.Int64Range(0, %d).Draw(t, %s), 0).UTC()", dateRange*86400, label), named)
+	case "openapi_types.Date":
+		return g.assign(dst, fmt.Sprintf("openapi_types.Date{Time: time.Unix(rapid.Int64Range(0, %d).Draw(t, %s)*86400, 0).UTC()}", dateRange, label), named)
+	case "openapi_types.Email":
+		return g.assign(dst, fmt.Sprintf("openapi_types.Email(rapid.StringMatching(`^[a-z0-9]{1,16}@example\\.com$`).Draw(t, %s))", label), named)
+	case "openapi_types.UUID":
+		return fmt.Sprintf("copy(%s[:], rapid.SliceOfN(rapid.Byte(), 16, 16).Draw(t, %s))", indexed(dst), label)
+	}
+	gen, ok := rapidIntegers[schema.GoType]
+	if !ok {
+		return ""
+	}
+	min, max := numberBounds(s, schema.GoType)
+	switch {
+	case min != "" && max != "":
+		gen += fmt.Sprintf("Range(%s, %s)", min, max)
+	case min != "":
+		gen += fmt.Sprintf("Min(%s)", min)
+	case max != "":
+		gen += fmt.Sprintf("Max(%s)", max)
+	default:
+		gen += "()"
+	}
+	if strings.HasPrefix(gen, "Float") {
+		var excluded []string
+		if s.ExclusiveMin && s.Min != nil {
+			excluded = append(excluded, fmt.Sprintf("v != %s", min))
+		}
+		if s.ExclusiveMax && s.Max != nil {
+			excluded = append(excluded, fmt.Sprintf("v != %s", max))
+		}
+		if len(excluded) != 0 {
+			gen += fmt.Sprintf(".Filter(func(v %s) bool {\nreturn %s\n})", schema.GoType, strings.Join(excluded, " && "))
+		}
+	}
+	return g.assign(dst, fmt.Sprintf("rapid.%s.Draw(t, %s)", gen, label), named)
+}
+
+// integerLimits are the least and the greatest values of the integer types
+// which the bounds of their schemas are clamped to.
+var integerLimits = map[string][2]float64{
+	"int": {math.MinInt64, math.MaxInt64}, "int8": {math.MinInt8, math.MaxInt8}, "int16": {math.MinInt16, math.MaxInt16},
+	"int32": {math.MinInt32, math.MaxInt32}, "int64": {math.MinInt64, math.MaxInt64},
+	"uint": {0, math.MaxUint64}, "uint8": {0, math.MaxUint8}, "uint16": {0, math.MaxUint16},
+	"uint32": {0, math.MaxUint32}, "uint64": {0, math.MaxUint64},
+}
+
+// numberBounds returns the least and the greatest of the numbers of a
+// schema, as Go literals of a type, or empty when it has none. The exclusive
+// bounds of the integers are those next to them, while the floats are
+// filtered.
+func numberBounds(s *openapi3.Schema, goType string) (string, string) {
+	limits, integer := integerLimits[goType]
+	format := func(value float64) string {
+		if integer {
+			// The limits of the 64-bit types aren't floats
+			switch {
+			case value <= limits[0] && limits[0] == 0:
+				return "0"
+			case value <= limits[0]:
+				return "math.Min" + UppercaseFirstCharacter(goType)
+			case value >= limits[1]:
+				return "math.Max" + UppercaseFirstCharacter(goType)
+			}
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	var min, max string
+	if s.Min != nil {
+		value := *s.Min
+		if integer {
+			value = math.Ceil(value)
+			if s.ExclusiveMin && value == *s.Min {
+				value++
+			}
+		}
+		min = format(value)
+	}
+	if s.Max != nil {
+		value := *s.Max
+		if integer {
+			value = math.Floor(value)
+			if s.ExclusiveMax && value == *s.Max {
+				value--
+			}
+		}
+		max = format(value)
+	}
+	return min, max
+}
+
+// address returns the address of dst, which is a variable, a field, an
+// item, or a pointer dereferenced.
+func address(dst string) string {
+	if strings.HasPrefix(dst, "*") {
+		return dst[1:]
+	}
+	return "&" + dst
+}
+
+// indexed returns dst to index it, parenthesized when it's a pointer
+// dereferenced.
+func indexed(dst string) string {
+	if strings.HasPrefix(dst, "*") {
+		return "(" + dst + ")"
+	}
+	return dst
+}
+
+// selector returns dst to select its fields, the pointer itself when it's a
+// pointer dereferenced.
+func selector(dst string) string {
+	return strings.TrimPrefix(dst, "*")
+}
+
+// assign returns the statement assigning dst a value, converted to the named
+// type when it's set.
+func (g *modelGenerators) assign(dst, value, named string) string {
+	if named != "" {
+		value = fmt.Sprintf("%s(%s)", named, value)
+	}
+	return fmt.Sprintf("%s = %s", dst, value)
+}
+
+// goLiteral returns the Go literal of a scalar JSON value, untyped so that it
+// converts to the type of an enum.
+func goLiteral(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value), true
+	case bool, float64, int, int64, json.Number:
+		return fmt.Sprint(value), true
+	}
+	return "", false
+}
+
+// goStringLiteral returns the Go literal of a string, raw unless it has
+// backticks.
+func goStringLiteral(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return "`" + value + "`"
+}
+
+// unquote returns the string of a Go string literal.
+func unquote(literal string) string {
+	value, err := strconv.Unquote(literal)
+	if err != nil {
+		return literal
+	}
+	return value
+}
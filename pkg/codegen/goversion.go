@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// goVersion matches the versions of Go 1 of the go-version output option.
+var goVersion = regexp.MustCompile(`^1\.(\d+)(?:\.\d+)?$`)
+
+// goVersionFeature is an option generating code which needs a later version
+// of Go than the rest.
+type goVersionFeature struct {
+	name    string // The option, like optional-params
+	minor   int    // The minor version of Go 1 it needs, like 18
+	needs   string // What it needs of that version, like generics
+	enabled func(Configuration) bool
+}
+
+// goVersionFeatures are the options which the go-version output option
+// rules out when it targets an earlier version of Go than they need.
+var goVersionFeatures = []goVersionFeature{
+	{"optional-params", 18, "generics", func(o Configuration) bool { return o.OutputOptions.OptionalParams }},
+	{"patch-bodies", 18, "generics", func(o Configuration) bool { return o.OutputOptions.PatchBodies }},
+	{"asyncapi", 18, "generics", func(o Configuration) bool { return o.AsyncAPI != "" }},
+	{"fixtures", 18, "generics", func(o Configuration) bool { return o.Generate.Fixtures }},
+	{"generators", 18, "generics", func(o Configuration) bool { return o.Generate.Generators }},
+	{"nullable-collections", 18, "generics", func(o Configuration) bool { return o.OutputOptions.NullableCollections }},
+	{"client-cache", 18, "strings.Cut", func(o Configuration) bool { return o.OutputOptions.ClientCache != nil }},
+	{"fuzz", 18, "fuzzing", func(o Configuration) bool { return o.Generate.Fuzz }},
+	{"security-middleware", 20, "errors.Join", func(o Configuration) bool { return o.Generate.SecurityMiddleware }},
+}
+
+// goMinorVersion returns the minor version of a version of Go 1, like 21 for
+// 1.21 or 1.21.3, and whether it's one.
+func goMinorVersion(v string) (int, bool) {
+	match := goVersion.FindStringSubmatch(v)
+	if match == nil {
+		return 0, false
+	}
+	minor, err := strconv.Atoi(match[1])
+	return minor, err == nil
+}
+
+// goVersionAtLeast returns whether the go-version output option targets the
+// minor version of Go 1, like 18 for 1.18, or a later one. The features of
+// the later versions are only used when it's set, so that the generated code
+// doesn't change otherwise.
+func goVersionAtLeast(minor int) bool {
+	target, ok := goMinorVersion(globalState.options.OutputOptions.GoVersion)
+	return ok && target >= minor
+}
+
+// checkGoVersion returns an error when the go-version output option targets
+// an earlier version of Go than a feature needs.
+func checkGoVersion(target string, feature string, minor int, needs string) error {
+	if v, ok := goMinorVersion(target); ok && v < minor {
+		return fmt.Errorf("%s: needs go 1.%d or later, which has %s, but the go-version is %s", feature, minor, needs, target)
+	}
+	return nil
+}
+
+// useAny replaces the empty interfaces of the types of Go code with any, for
+// Go 1.18 or later, other than the interface types it declares.
+func useAny(code []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		// The interfaces declared as types, which only lack methods for now,
+		// are left alone.
+		if _, declared := c.Parent().(*ast.TypeSpec); declared {
+			return true
+		}
+		if i, ok := c.Node().(*ast.InterfaceType); ok && len(i.Methods.List) == 0 {
+			c.Replace(&ast.Ident{NamePos: i.Pos(), Name: "any"})
+		}
+		return true
+	}, nil)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
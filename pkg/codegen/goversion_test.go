@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// goMinorPackages are the packages of the standard library added after Go
+// 1.17, by the minor version of Go which added them.
+var goMinorPackages = map[string]int{
+	"cmp":      21,
+	"log/slog": 21,
+	"maps":     21,
+	"slices":   21,
+}
+
+// goMinorFuncs are the identifiers of the standard library added after Go
+// 1.17 to packages which existed before, by the minor version of Go which
+// added them.
+var goMinorFuncs = map[string]int{
+	"bytes.Cut":                  18,
+	"strings.Cut":                18,
+	"testing.F":                  18,
+	"atomic.Bool":                19,
+	"atomic.Int64":               19,
+	"atomic.Pointer":             19,
+	"fmt.Appendf":                19,
+	"http.MaxBytesError":         19,
+	"url.JoinPath":               19,
+	"bytes.CutPrefix":            20,
+	"context.WithCancelCause":    20,
+	"errors.Join":                20,
+	"http.NewResponseController": 20,
+	"strings.CutPrefix":          20,
+	"strings.CutSuffix":          20,
+	"time.DateOnly":              20,
+	"context.AfterFunc":          21,
+	"context.WithoutCancel":      21,
+	"errors.ErrUnsupported":      21,
+	"sync.OnceFunc":              21,
+	"sync.OnceValue":             21,
+}
+
+// goMinorNeeded returns the minor version of Go 1 which Go code needs at
+// least, from the generics and the packages and identifiers of the standard
+// library it uses, with what needs it.
+func goMinorNeeded(t *testing.T, code string) (int, string) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, 0)
+	require.NoError(t, err)
+
+	minor, needs := 17, ""
+	need := func(m int, what string) {
+		if m > minor {
+			minor, needs = m, what
+		}
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if m, ok := goMinorPackages[path]; ok {
+			need(m, path)
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncType:
+			if n.TypeParams != nil {
+				need(18, "generics")
+			}
+		case *ast.TypeSpec:
+			if n.TypeParams != nil {
+				need(18, "generics")
+			}
+		case *ast.Ident:
+			if n.Name == "any" || n.Name == "comparable" {
+				need(18, n.Name)
+			}
+		case *ast.SelectorExpr:
+			if pkg, ok := n.X.(*ast.Ident); ok {
+				name := pkg.Name + "." + n.Sel.Name
+				if m, ok := goMinorFuncs[name]; ok {
+					need(m, name)
+				}
+			}
+		}
+		return true
+	})
+	return minor, needs
+}
+
+func TestGoVersionGates(t *testing.T) {
+	minors := map[string]int{"x-sensitive": 21}
+	for _, feature := range goVersionFeatures {
+		minors[feature.name] = feature.minor
+	}
+
+	tests := []struct {
+		feature string
+		spec    string
+		opts    Configuration
+	}{
+		{"optional-params", "optional-params.yaml", Configuration{
+			Generate:      GenerateOptions{ChiServer: true, Client: true, Models: true},
+			OutputOptions: OutputOptions{OptionalParams: true},
+		}},
+		{"patch-bodies", "patch-bodies.yaml", Configuration{
+			Generate:      GenerateOptions{Client: true, Models: true},
+			OutputOptions: OutputOptions{PatchBodies: true},
+		}},
+		{"asyncapi", "asyncapi.yaml", Configuration{
+			Generate: GenerateOptions{Models: true},
+			AsyncAPI: "test_specs/asyncapi-events.yaml",
+		}},
+		{"fixtures", "fixtures.yaml", Configuration{
+			Generate:      GenerateOptions{Models: true, Client: true, Fixtures: true},
+			OutputOptions: OutputOptions{ScaffoldImportPath: "example.com/petstore/api"},
+		}},
+		{"generators", "generators.yaml", Configuration{
+			Generate:      GenerateOptions{Models: true, Generators: true},
+			OutputOptions: OutputOptions{SkipPrune: true, ScaffoldImportPath: "example.com/petstore/api"},
+		}},
+		{"nullable-collections", "nullable-collections.yaml", Configuration{
+			Generate:      GenerateOptions{Models: true, DeepCopy: true},
+			OutputOptions: OutputOptions{NullableCollections: true},
+		}},
+		{"client-cache", "client-cache.yaml", Configuration{
+			Generate:      GenerateOptions{Client: true, Models: true},
+			OutputOptions: OutputOptions{ClientCache: &ClientCacheOptions{Operations: []string{"getProduct"}}},
+		}},
+		{"fuzz", "fuzz.yaml", Configuration{
+			Generate: GenerateOptions{Models: true, ChiServer: true, Fuzz: true},
+		}},
+		{"security-middleware", "security.yaml", Configuration{
+			Generate: GenerateOptions{Models: true, ChiServer: true, SecurityMiddleware: true},
+		}},
+		{"x-sensitive", "capture.yaml", Configuration{
+			Generate: GenerateOptions{Models: true, Client: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.feature, func(t *testing.T) {
+			minor, ok := minors[tt.feature]
+			require.True(t, ok, "no gate for %s", tt.feature)
+
+			swagger, err := util.LoadSwagger("test_specs/" + tt.spec)
+			require.NoError(t, err)
+
+			// At its gate, the feature generates code compiling with that
+			// version
+			opts := tt.opts
+			opts.PackageName = "api"
+			opts.OutputOptions.GoVersion = fmt.Sprintf("1.%d", minor)
+			require.NoError(t, opts.Validate())
+			output, err := GenerateOutput(swagger, opts)
+			require.NoError(t, err)
+			for name, code := range map[string]string{
+				"code":       output.Code,
+				"self-test":  output.SelfTest,
+				"fixtures":   output.Fixtures,
+				"generators": output.Generators,
+			} {
+				if code == "" {
+					continue
+				}
+				needed, needs := goMinorNeeded(t, code)
+				assert.LessOrEqual(t, needed, minor, "the %s of %s needs go 1.%d for %s", name, tt.feature, needed, needs)
+			}
+
+			// Before it, it's ruled out
+			opts.OutputOptions.GoVersion = fmt.Sprintf("1.%d", minor-1)
+			err = opts.Validate()
+			if err == nil {
+				_, err = GenerateOutput(swagger, opts)
+			}
+			assert.ErrorContains(t, err, tt.feature+": needs go 1.")
+		})
+	}
+}
@@ -0,0 +1,609 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExportGraphQL approximates the spec with a GraphQL schema, in SDL, to
+// bootstrap a gateway layer in front of the API:
+//   - object component schemas become object types, and input types named
+//     <Type>Input when operations take them, string enums become enums,
+//     whose values are in upper snake case, and unions of object schemas
+//     become unions
+//   - the GET operations become fields of the Query type, and the others
+//     fields of the Mutation type, with arguments of their path and query
+//     parameters and their JSON body, returning the type of their successful
+//     JSON response, or Boolean
+//
+// It returns a warning locating, with a JSON pointer, each construct which
+// has no GraphQL equivalent, and was left out or approximated.
+func ExportGraphQL(spec *openapi3.T) (string, Diagnostics, error) {
+	e := graphQLExporter{
+		scalars:  make(map[string]bool),
+		named:    make(map[*openapi3.Schema]string),
+		inputs:   make(map[*openapi3.Schema]string),
+		inline:   make(map[graphQLInline]string),
+		pointers: make(map[*openapi3.Schema][]string),
+		declared: make(map[string]string),
+	}
+
+	// The named types are found first, so that references resolve to them.
+	var components openapi3.Schemas
+	if spec.Components != nil {
+		components = spec.Components.Schemas
+	}
+	for _, name := range SortedSchemaKeys(components) {
+		sref := components[name]
+		if sref.Ref != "" || sref.Value == nil || graphQLKindOf(sref.Value) == graphQLFieldKind {
+			// References to other components are resolved where they're used.
+			continue
+		}
+		typeName := SchemaNameToTypeName(name)
+		if err := e.declare(typeName, "schema "+name); err != nil {
+			return "", nil, err
+		}
+		e.named[sref.Value] = typeName
+		e.pointers[sref.Value] = []string{"components", "schemas", name}
+	}
+	// The unions are declared first, so that those approximated as the JSON
+	// scalar are known to the fields.
+	for _, kind := range []graphQLKind{graphQLUnionKind, graphQLEnumKind, graphQLObjectKind} {
+		for _, name := range SortedSchemaKeys(components) {
+			sref := components[name]
+			typeName, found := e.named[sref.Value]
+			if sref.Ref != "" || !found || graphQLKindOf(sref.Value) != kind {
+				continue
+			}
+			pointer := []string{"components", "schemas", name}
+			switch kind {
+			case graphQLEnumKind:
+				e.enum(typeName, sref.Value, pointer)
+			case graphQLObjectKind:
+				e.object("type", typeName, sref.Value, pointer, false)
+			case graphQLUnionKind:
+				e.union(typeName, sref.Value, pointer)
+			}
+		}
+	}
+
+	query := &graphQLType{keyword: "type", name: "Query"}
+	mutation := &graphQLType{keyword: "type", name: "Mutation"}
+	for _, root := range []*graphQLType{query, mutation} {
+		if err := e.declare(root.name, "the root type "+root.name); err != nil {
+			return "", nil, err
+		}
+	}
+	operations := make(map[string]string)
+	for _, requestPath := range SortedPathsKeys(spec.Paths) {
+		pathItem := spec.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			field, err := e.operation(requestPath, opName, pathItem, pathOps[opName])
+			if err != nil {
+				return "", nil, err
+			}
+			if other, found := operations[field.name]; found {
+				return "", nil, fmt.Errorf("operations %s and %s %s have the same GraphQL field %s", other, opName, requestPath, field.name)
+			}
+			operations[field.name] = opName + " " + requestPath
+			if opName == "GET" {
+				query.fields = append(query.fields, field)
+			} else {
+				mutation.fields = append(mutation.fields, field)
+			}
+		}
+	}
+	return e.write(query, mutation), e.diagnostics, nil
+}
+
+// graphQLKind is what a schema is exported as, where it's declared.
+type graphQLKind int
+
+const (
+	graphQLFieldKind  graphQLKind = iota // A field type, like a scalar, a list or a map
+	graphQLEnumKind                      // An enum, for string enums
+	graphQLObjectKind                    // An object type, for objects with properties
+	graphQLUnionKind                     // A union, for unions
+)
+
+func graphQLKindOf(schema *openapi3.Schema) graphQLKind {
+	switch {
+	case len(schema.OneOf) != 0 || len(schema.AnyOf) != 0:
+		return graphQLUnionKind
+	case schema.Type == "string" && len(schema.Enum) != 0:
+		return graphQLEnumKind
+	case len(protoProperties(schema)) != 0:
+		return graphQLObjectKind
+	default:
+		return graphQLFieldKind
+	}
+}
+
+// graphQLScalars describe the custom scalars which the schema declares when
+// its fields use them.
+var graphQLScalars = map[string]string{
+	"Date":     "A date, like 2024-01-31",
+	"DateTime": "A date and time, in RFC 3339 format",
+	"Int64":    "A 64-bit integer",
+	"JSON":     "Any JSON value",
+}
+
+// graphQLNameChars matches the characters which GraphQL names can't hold.
+var graphQLNameChars = regexp.MustCompile(`[^_0-9A-Za-z]`)
+
+// graphQLExporter accumulates the definitions and warnings of ExportGraphQL.
+type graphQLExporter struct {
+	diagnostics Diagnostics
+	// The custom scalars the fields use.
+	scalars map[string]bool
+	// The types of the component schemas declared as object types, enums and
+	// unions.
+	named map[*openapi3.Schema]string
+	// The input types of the object schemas which operations take.
+	inputs map[*openapi3.Schema]string
+	// The types of the inline schemas, declared once for each schema, and
+	// whether it's an input.
+	inline map[graphQLInline]string
+	// Where the named schemas are declared.
+	pointers map[*openapi3.Schema][]string
+	// What declares each type name, to report collisions.
+	declared map[string]string
+	// The types, enums and unions, in the order they're declared.
+	decls []*graphQLType
+	// The operation being exported, if any.
+	operationID string
+}
+
+// graphQLInline is an inline schema, as the type of fields or arguments.
+type graphQLInline struct {
+	schema *openapi3.Schema
+	input  bool
+}
+
+// graphQLType is an object type, an input type, an enum or a union.
+type graphQLType struct {
+	keyword     string // type, input, enum or union
+	name        string
+	description string
+	fields      []graphQLField // The fields of the object and input types
+	values      []string       // The values of the enums, and the members of the unions
+}
+
+// graphQLField is a field, or an argument of one.
+type graphQLField struct {
+	name        string
+	description string
+	typ         string
+	args        []graphQLField
+}
+
+// warn warns about a construct, once, as the schemas of the input types are
+// those of the object types too.
+func (e *graphQLExporter) warn(pointer []string, format string, args ...interface{}) {
+	diagnostic := Diagnostic{
+		OperationID: e.operationID,
+		Path:        jsonPointer(pointer...),
+		Reason:      fmt.Sprintf(format, args...),
+		Warning:     true,
+	}
+	for _, other := range e.diagnostics {
+		if other.Path == diagnostic.Path && other.Reason == diagnostic.Reason {
+			return
+		}
+	}
+	e.diagnostics = append(e.diagnostics, diagnostic)
+}
+
+// declare records a type name, failing when it's taken.
+func (e *graphQLExporter) declare(name, by string) error {
+	if other, found := e.declared[name]; found {
+		return fmt.Errorf("%s and %s have the same GraphQL name %s", other, by, name)
+	}
+	e.declared[name] = by
+	return nil
+}
+
+// declareInline records the name of the type of an inline schema, suffixed
+// with a number when it's taken.
+func (e *graphQLExporter) declareInline(name, by string) string {
+	unique := name
+	for i := 2; e.declare(unique, by) != nil; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	return unique
+}
+
+// unmapped warns about a schema without a GraphQL equivalent, which is
+// approximated as the JSON scalar.
+func (e *graphQLExporter) unmapped(pointer []string, reason string) string {
+	e.warn(pointer, "%s, so it's approximated as the JSON scalar", reason)
+	e.scalars["JSON"] = true
+	return "JSON"
+}
+
+// fieldType returns the type of a field or an argument of the given schema,
+// nullable, declaring the types and enums of its inline objects and enums
+// named after owner and the field. Input types are declared for the objects
+// of the arguments.
+func (e *graphQLExporter) fieldType(owner, name string, sref *openapi3.SchemaRef, pointer []string, input bool) string {
+	if sref == nil || sref.Value == nil {
+		return e.unmapped(pointer, "the schema is missing")
+	}
+	schema := sref.Value
+	if typeName, found := e.named[schema]; found {
+		switch kind := graphQLKindOf(schema); {
+		case input && kind == graphQLObjectKind:
+			return e.input(typeName, schema)
+		case input && kind == graphQLUnionKind && typeName != "JSON":
+			return e.unmapped(pointer, "unions can't be arguments")
+		}
+		return typeName
+	}
+
+	switch {
+	case len(schema.OneOf) != 0 || len(schema.AnyOf) != 0:
+		return e.unmapped(pointer, "inline unions have no GraphQL equivalent")
+	case len(schema.AllOf) == 1 && len(schema.Properties) == 0:
+		return e.fieldType(owner, name, schema.AllOf[0], appendPointer(pointer, "allOf", "0"), input)
+	case schema.Type == "array":
+		items := e.fieldType(owner, name+"Item", schema.Items, appendPointer(pointer, "items"), input)
+		if schema.Items == nil || schema.Items.Value == nil || !schema.Items.Value.Nullable {
+			items += "!"
+		}
+		return "[" + items + "]"
+	case schema.Type == "object" || len(schema.Properties) != 0 || len(schema.AllOf) != 0:
+		if len(protoProperties(schema)) != 0 {
+			if typeName, found := e.inline[graphQLInline{schema, input}]; found {
+				return typeName
+			}
+			keyword := "type"
+			if input {
+				keyword = "input"
+			}
+			typeName := e.declareInline(owner+ToCamelCase(name), "the "+strings.Join(pointer, "/")+" schema")
+			e.inline[graphQLInline{schema, input}] = typeName
+			e.object(keyword, typeName, schema, pointer, input)
+			return typeName
+		}
+		e.scalars["JSON"] = true
+		if schema.AdditionalProperties.Schema != nil {
+			e.warn(pointer, "maps have no GraphQL equivalent, so it's approximated as the JSON scalar")
+		}
+		return "JSON"
+	case schema.Type == "string" && len(schema.Enum) != 0:
+		// Enums are the types of both fields and arguments.
+		if typeName, found := e.inline[graphQLInline{schema, false}]; found {
+			return typeName
+		}
+		typeName := e.declareInline(owner+ToCamelCase(name), "the "+strings.Join(pointer, "/")+" schema")
+		e.inline[graphQLInline{schema, false}] = typeName
+		e.enum(typeName, schema, pointer)
+		return typeName
+	case schema.Type == "string":
+		switch schema.Format {
+		case "date-time":
+			e.scalars["DateTime"] = true
+			return "DateTime"
+		case "date":
+			e.scalars["Date"] = true
+			return "Date"
+		}
+		return "String"
+	case schema.Type == "integer":
+		if schema.Format == "int64" {
+			e.scalars["Int64"] = true
+			return "Int64"
+		}
+		return "Int"
+	case schema.Type == "number":
+		return "Float"
+	case schema.Type == "boolean":
+		return "Boolean"
+	default:
+		return e.unmapped(pointer, "the schema has no type")
+	}
+}
+
+// input returns the input type of a component object schema, named
+// <Type>Input, declaring it on first use.
+func (e *graphQLExporter) input(typeName string, schema *openapi3.Schema) string {
+	if inputName, found := e.inputs[schema]; found {
+		return inputName
+	}
+	inputName := typeName + "Input"
+	pointer := e.pointers[schema]
+	if err := e.declare(inputName, "the input type of "+typeName); err != nil {
+		inputName = e.declareInline(inputName, "the input type of "+typeName)
+	}
+	e.inputs[schema] = inputName
+	e.object("input", inputName, schema, pointer, true)
+	return inputName
+}
+
+// object declares the object or input type of an object, with a field per
+// property. Additional properties are left out.
+func (e *graphQLExporter) object(keyword, name string, schema *openapi3.Schema, pointer []string, input bool) {
+	object := &graphQLType{keyword: keyword, name: name, description: schema.Description}
+	e.decls = append(e.decls, object)
+	if schema.AdditionalProperties.Schema != nil && !input {
+		e.warn(pointer, "the additional properties of %s are left out, as GraphQL types only have fields", name)
+	}
+	properties := protoProperties(schema)
+	required := protoRequired(schema)
+	for _, property := range SortedSchemaKeys(properties) {
+		propertyPointer := appendPointer(pointer, "properties", property)
+		sref := properties[property]
+		typ := e.fieldType(name, property, sref, propertyPointer, input)
+		if required[property] && (sref.Value == nil || !sref.Value.Nullable) {
+			typ += "!"
+		}
+		// Referenced schemas are described where they're declared.
+		var description string
+		if sref.Ref == "" && sref.Value != nil {
+			description = sref.Value.Description
+		}
+		e.addField(&object.fields, name, property, typ, description, propertyPointer)
+	}
+}
+
+// addField adds the field of a property, or the argument of a parameter, to
+// fields, with the characters GraphQL names can't hold replaced by _.
+func (e *graphQLExporter) addField(fields *[]graphQLField, owner, jsonName, typ, description string, pointer []string) {
+	name := graphQLNameChars.ReplaceAllString(jsonName, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if name != jsonName {
+		e.warn(pointer, "%s isn't a valid GraphQL name, so it's renamed %s in %s", jsonName, name, owner)
+	}
+	for _, other := range *fields {
+		if other.name == name {
+			e.warn(pointer, "%s has the same GraphQL name %s as another one in %s, so it's left out", jsonName, name, owner)
+			return
+		}
+	}
+	*fields = append(*fields, graphQLField{name: name, description: description, typ: typ})
+}
+
+// union declares the union of the object schemas a union is made of, or
+// approximates it as the JSON scalar when some aren't objects.
+func (e *graphQLExporter) union(name string, schema *openapi3.Schema, pointer []string) {
+	alternatives, keyword := schema.OneOf, "oneOf"
+	if len(alternatives) == 0 {
+		alternatives, keyword = schema.AnyOf, "anyOf"
+	}
+	union := &graphQLType{keyword: "union", name: name, description: schema.Description}
+	for i, alternative := range alternatives {
+		member, found := "", false
+		if alternative.Value != nil && graphQLKindOf(alternative.Value) == graphQLObjectKind {
+			member, found = e.named[alternative.Value]
+		}
+		if !found {
+			e.warn(appendPointer(pointer, keyword, strconv.Itoa(i)), "the members of GraphQL unions are object types, so %s is approximated as the JSON scalar", name)
+			e.named[schema] = "JSON"
+			e.scalars["JSON"] = true
+			delete(e.declared, name)
+			return
+		}
+		union.values = append(union.values, member)
+	}
+	if len(schema.Properties) != 0 {
+		e.warn(pointer, "the properties of %s are left out, as only its members make its union", name)
+	}
+	e.decls = append(e.decls, union)
+}
+
+// enum declares the enum of a string enum, whose values are in upper snake
+// case.
+func (e *graphQLExporter) enum(name string, schema *openapi3.Schema, pointer []string) {
+	enum := &graphQLType{keyword: "enum", name: name, description: schema.Description}
+	for i, value := range schema.Enum {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		valueName := strings.ToUpper(toSnakeCase(s))
+		if valueName == "" || (valueName[0] >= '0' && valueName[0] <= '9') || valueName == "TRUE" || valueName == "FALSE" || valueName == "NULL" {
+			valueName = "_" + valueName
+		}
+		if StringInArray(valueName, enum.values) {
+			e.warn(appendPointer(pointer, "enum", strconv.Itoa(i)), "enum value %q has the same GraphQL name %s as another one, so it's left out", s, valueName)
+			continue
+		}
+		enum.values = append(enum.values, valueName)
+	}
+	e.decls = append(e.decls, enum)
+}
+
+// operation returns the field of the root type of an operation.
+func (e *graphQLExporter) operation(requestPath, opName string, pathItem *openapi3.PathItem, op *openapi3.Operation) (graphQLField, error) {
+	pointer := []string{"paths", requestPath, strings.ToLower(opName)}
+	name := ToCamelCase(op.OperationID)
+	if op.OperationID == "" {
+		var err error
+		if name, err = generateDefaultOperationID(opName, requestPath, ToCamelCase); err != nil {
+			return graphQLField{}, err
+		}
+	}
+	name = LowercaseFirstCharacter(name)
+	typeName := UppercaseFirstCharacter(name)
+	e.operationID = op.OperationID
+	defer func() { e.operationID = "" }()
+
+	field := graphQLField{name: name, description: op.Summary}
+	if field.description == "" {
+		field.description = op.Description
+	}
+
+	// The parameters of the operation override those of the path.
+	var params []*openapi3.ParameterRef
+	var paramPointers [][]string
+	for i, param := range op.Parameters {
+		params = append(params, param)
+		paramPointers = append(paramPointers, appendPointer(pointer, "parameters", strconv.Itoa(i)))
+	}
+	for i, param := range pathItem.Parameters {
+		if param.Value != nil && op.Parameters.GetByInAndName(param.Value.In, param.Value.Name) == nil {
+			params = append(params, param)
+			paramPointers = append(paramPointers, []string{"paths", requestPath, "parameters", strconv.Itoa(i)})
+		}
+	}
+	for i, param := range params {
+		p := param.Value
+		if p == nil {
+			continue
+		}
+		switch p.In {
+		case openapi3.ParameterInPath, openapi3.ParameterInQuery:
+			schema := p.Schema
+			if schema == nil {
+				for _, contentType := range SortedContentKeys(p.Content) {
+					schema = p.Content[contentType].Schema
+					break
+				}
+			}
+			typ := e.fieldType(typeName, p.Name, schema, appendPointer(paramPointers[i], "schema"), true)
+			if p.Required {
+				typ += "!"
+			}
+			e.addField(&field.args, name, p.Name, typ, p.Description, paramPointers[i])
+		default:
+			e.warn(paramPointers[i], "the %s parameter %s is left out of the arguments of %s, as GraphQL fields only take arguments", p.In, p.Name, name)
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		bodyPointer := appendPointer(pointer, "requestBody")
+		content := op.RequestBody.Value.Content
+		if contentType, mediaType := protoJSONContent(content); mediaType != nil {
+			typ := e.fieldType(typeName, "Body", mediaType.Schema, appendPointer(bodyPointer, "content", contentType, "schema"), true)
+			if op.RequestBody.Value.Required {
+				typ += "!"
+			}
+			e.addField(&field.args, name, "body", typ, op.RequestBody.Value.Description, bodyPointer)
+		} else if len(content) != 0 {
+			e.warn(bodyPointer, "the %s body is left out of the arguments of %s, as only JSON bodies are exported", strings.Join(SortedContentKeys(content), ", "), name)
+		}
+	}
+
+	field.typ = "Boolean"
+	for _, code := range SortedResponsesKeys(op.Responses) {
+		if len(code) != 3 || code[0] != '2' {
+			continue
+		}
+		response := op.Responses[code].Value
+		if response == nil {
+			break
+		}
+		responsePointer := appendPointer(pointer, "responses", code)
+		contentType, mediaType := protoJSONContent(response.Content)
+		if mediaType == nil {
+			if len(response.Content) != 0 {
+				e.warn(responsePointer, "the %s response of %s is left out, as only JSON responses are exported", strings.Join(SortedContentKeys(response.Content), ", "), name)
+			}
+			break
+		}
+		field.typ = e.fieldType(typeName, "Response", mediaType.Schema, appendPointer(responsePointer, "content", contentType, "schema"), false)
+		break
+	}
+	return field, nil
+}
+
+func (e *graphQLExporter) write(roots ...*graphQLType) string {
+	var b strings.Builder
+	b.WriteString("# Generated by oapi-codegen, approximating an OpenAPI spec. Review it before\n")
+	b.WriteString("# relying on it, as its names and types differ from those of the JSON.\n")
+
+	var scalars []string
+	for scalar := range e.scalars {
+		scalars = append(scalars, scalar)
+	}
+	sort.Strings(scalars)
+	for _, scalar := range scalars {
+		b.WriteString("\n")
+		writeGraphQLDescription(&b, "", graphQLScalars[scalar])
+		fmt.Fprintf(&b, "scalar %s\n", scalar)
+	}
+	for _, root := range roots {
+		if len(root.fields) != 0 {
+			b.WriteString("\n")
+			root.write(&b)
+		}
+	}
+	for _, decl := range e.decls {
+		b.WriteString("\n")
+		decl.write(&b)
+	}
+	return b.String()
+}
+
+func (t *graphQLType) write(b *strings.Builder) {
+	writeGraphQLDescription(b, "", t.description)
+	switch t.keyword {
+	case "union":
+		fmt.Fprintf(b, "union %s = %s\n", t.name, strings.Join(t.values, " | "))
+		return
+	case "enum":
+		fmt.Fprintf(b, "enum %s {\n", t.name)
+		for _, value := range t.values {
+			fmt.Fprintf(b, "  %s\n", value)
+		}
+		b.WriteString("}\n")
+		return
+	}
+	fmt.Fprintf(b, "%s %s {\n", t.keyword, t.name)
+	for _, field := range t.fields {
+		writeGraphQLDescription(b, "  ", field.description)
+		b.WriteString("  " + field.name)
+		described := false
+		for _, arg := range field.args {
+			described = described || strings.TrimSpace(arg.description) != ""
+		}
+		switch {
+		case described:
+			// The arguments are on lines of their own, after their
+			// descriptions.
+			b.WriteString("(\n")
+			for _, arg := range field.args {
+				writeGraphQLDescription(b, "    ", arg.description)
+				fmt.Fprintf(b, "    %s: %s\n", arg.name, arg.typ)
+			}
+			b.WriteString("  )")
+		case len(field.args) != 0:
+			args := make([]string, len(field.args))
+			for i, arg := range field.args {
+				args[i] = arg.name + ": " + arg.typ
+			}
+			b.WriteString("(" + strings.Join(args, ", ") + ")")
+		}
+		fmt.Fprintf(b, ": %s\n", field.typ)
+	}
+	b.WriteString("}\n")
+}
+
+// writeGraphQLDescription writes a description as a block string.
+func writeGraphQLDescription(b *strings.Builder, indent, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(description, `"""`, `\"""`)
+	if !strings.Contains(description, "\n") {
+		fmt.Fprintf(b, "%s\"\"\"%s\"\"\"\n", indent, description)
+		return
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		if line = strings.TrimRight(line, " \t"); line != "" {
+			b.WriteString(indent + line)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+}
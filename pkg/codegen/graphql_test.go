@@ -0,0 +1,117 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestExportGraphQL(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/proto.yaml")
+	require.NoError(t, err)
+
+	sdl, diagnostics, err := ExportGraphQL(swagger)
+	require.NoError(t, err)
+
+	// The custom scalars are declared when fields use them
+	assert.Contains(t, sdl, "\"\"\"A date and time, in RFC 3339 format\"\"\"\nscalar DateTime\n")
+	assert.Contains(t, sdl, "scalar Int64\n")
+	assert.Contains(t, sdl, "scalar JSON\n")
+
+	// GET operations are queries, and the others mutations
+	assert.Contains(t, sdl, `type Query {
+  """Returns the pets"""
+  findPets(tags: [String!], limit: Int): [Pet!]
+}`)
+	assert.Contains(t, sdl, `type Mutation {
+  addPet(body: NewPetInput!): Pet
+  deletePet(petId: Int64!): Boolean
+  uploadPhoto(petId: Int64!): Boolean
+}`)
+
+	// Objects are types, merging allOf, with input types for the arguments,
+	// and string enums are enums
+	assert.Contains(t, sdl, `type Pet {
+  born: DateTime
+  grid: [[Int!]!]
+  id: Int64!
+  kind: Kind
+  labels: JSON
+  """The name of the pet"""
+  name: String!
+  owner: NewPetOwner
+  photoUrls: [String!]
+  size: NewPetSize
+}`)
+	assert.Contains(t, sdl, "input NewPetInput {\n")
+	assert.Contains(t, sdl, "  owner: NewPetInputOwner\n")
+	assert.Contains(t, sdl, "input NewPetInputOwner {\n  name: String\n}")
+	assert.Contains(t, sdl, "\"\"\"The kind of a pet\"\"\"\nenum Kind {\n  CAT\n  DOG\n}")
+	assert.Contains(t, sdl, "enum NewPetSize {\n  SMALL\n  EXTRA_LARGE\n}")
+
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Animal/oneOf/1: the members of GraphQL unions are object types, so Animal is approximated as the JSON scalar",
+		"warning: /components/schemas/NewPet/properties/labels: maps have no GraphQL equivalent, so it's approximated as the JSON scalar",
+		"warning: /components/schemas/Pet/properties/labels: maps have no GraphQL equivalent, so it's approximated as the JSON scalar",
+		"warning: operation findPets: /paths/~1pets/get/parameters/2: the header parameter X-Request-ID is left out of the arguments of findPets, as GraphQL fields only take arguments",
+		"warning: operation uploadPhoto: /paths/~1pets~1{petId}/put/requestBody: the image/png body is left out of the arguments of uploadPhoto, as only JSON bodies are exported",
+	}, got)
+}
+
+func TestExportGraphQLUnions(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/graphql.yaml")
+	require.NoError(t, err)
+
+	sdl, diagnostics, err := ExportGraphQL(swagger)
+	require.NoError(t, err)
+
+	// Unions of objects are unions, which can't be arguments
+	assert.Contains(t, sdl, "union Animal = Cat | Dog\n")
+	assert.Contains(t, sdl, "  addAnimal(body: JSON!): Animal\n")
+
+	// Described arguments are on lines of their own
+	assert.Contains(t, sdl, `  getAnimal(
+    """The ID of the animal"""
+    id: String!
+    since: Date
+  ): Animal`)
+
+	// Names are made valid, and descriptions kept
+	assert.Contains(t, sdl, `"""
+A dog.
+Good boy.
+"""
+type Dog {
+  name: String
+}`)
+	assert.Contains(t, sdl, "type Cat {\n  lives_left: Int\n  name: String!\n}")
+
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Cat/properties/lives-left: lives-left isn't a valid GraphQL name, so it's renamed lives_left in Cat",
+		"warning: operation addAnimal: /paths/~1animals/post/requestBody/content/application~1json/schema: unions can't be arguments, so it's approximated as the JSON scalar",
+	}, got)
+}
+
+func TestExportGraphQLCollisions(t *testing.T) {
+	swagger := &openapi3.T{
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"Query": openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema()).NewRef(),
+			},
+		},
+	}
+	_, _, err := ExportGraphQL(swagger)
+	assert.EqualError(t, err, "schema Query and the root type Query have the same GraphQL name Query")
+}
@@ -0,0 +1,499 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// HotPathMethods describes the MarshalJSON and UnmarshalJSON methods of a
+// hot-path type, which encode and decode its JSON without reflection.
+type HotPathMethods struct {
+	TypeName  string // The name of the type, like Pet
+	Marshal   string // The statements of appendJSON, appending the JSON of a to buf
+	Unmarshal string // The statements of decodeJSON, decoding a from l
+}
+
+// hotPathKind is how the hot-path methods encode and decode the values of a
+// type.
+type hotPathKind int
+
+const (
+	hotPathFallback  hotPathKind = iota // With encoding/json, for the types the methods don't know
+	hotPathString                       // As strings
+	hotPathInt                          // As signed integers
+	hotPathUint                         // As unsigned integers
+	hotPathFloat                        // As floating point numbers
+	hotPathBool                         // As booleans
+	hotPathStruct                       // With the hot-path methods of the type
+	hotPathMarshaler                    // With the MarshalJSON and UnmarshalJSON methods of the type
+	hotPathArray                        // Item by item
+	hotPathMap                          // Value by value
+)
+
+// hotPathValue describes how the hot-path methods encode and decode the
+// values of a schema.
+type hotPathValue struct {
+	kind        hotPathKind
+	goType      string  // The Go type of the values, like Pet or []string
+	bits        int     // The size of the integers and floating point numbers, 0 for int and uint
+	elem        *Schema // The schema of the items of an array, or of the values of a map
+	elemPointer bool    // Whether the values of a map are pointers
+	structure   Schema  // The schema declaring the structure of the type
+}
+
+// hotPathMarshalers are the types whose own MarshalJSON and UnmarshalJSON
+// methods encode and decode them without reflection.
+var hotPathMarshalers = map[string]bool{
+	"time.Time": true, "Date": true, "openapi_types.Date": true,
+}
+
+// hotPathEmptiness holds the expressions telling whether the values of the
+// types encoding/json knows nothing of are non-empty, for omitempty, and ""
+// for those which never are.
+var hotPathEmptiness = map[string]string{
+	"interface{}": "%s != nil", "json.RawMessage": "len(%s) != 0", "[]byte": "len(%s) != 0",
+	"openapi_types.Email": `%s != ""`, "Email": `%s != ""`,
+	"openapi_types.UUID": "", "uuid.UUID": "", "Decimal": "", "Date": "", "openapi_types.Date": "",
+	"time.Time": "", "netip.Addr": "", "civil.Date": "", "civil.DateTime": "", "civil.Time": "",
+}
+
+// hotPathCoder describes the hot-path methods of the types, which call those
+// of the other hot-path types their fields hold.
+type hotPathCoder struct {
+	types map[string]TypeDefinition
+	hot   map[string]bool
+	// Whether appendJSON declares err, and b, for the values whose encoding
+	// may fail, and which are encoded into a slice of their own
+	err, b bool
+}
+
+// DescribeHotPathMethods describes the hot-path methods of the struct types
+// declared with x-hot-path among the given types, or of all of them with the
+// hot-path-marshalers option, but those declaring x-hot-path: false.
+func DescribeHotPathMethods(types []TypeDefinition) []HotPathMethods {
+	c := hotPathCoder{types: make(map[string]TypeDefinition), hot: make(map[string]bool)}
+	for _, td := range types {
+		c.types[td.TypeName] = td
+	}
+
+	var hot []TypeDefinition
+	structures := make(map[string]Schema)
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if td.IsAlias() || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		enabled, declared, err := hotPathEnabled(td)
+		if err != nil {
+			warn(nil, jsonPointer("components", "schemas", td.JsonName), "the %s of %s isn't a boolean", extHotPath, td.TypeName)
+			continue
+		}
+		if !enabled {
+			continue
+		}
+
+		// Types defined as other types have their structure, but not their
+		// methods.
+		schema := td.Schema
+		for i := 0; i < len(c.types); i++ {
+			defined, found := c.types[schema.TypeDecl()]
+			if !found || defined.TypeName == td.TypeName {
+				break
+			}
+			schema = defined.Schema
+		}
+		if reason := c.unsupported(schema); reason != "" {
+			if declared {
+				warn(nil, jsonPointer("components", "schemas", td.JsonName), "%s %s, so it has no hot-path marshalers", td.TypeName, reason)
+			}
+			continue
+		}
+		c.hot[td.TypeName] = true
+		structures[td.TypeName] = schema
+		hot = append(hot, td)
+	}
+
+	var result []HotPathMethods
+	for _, td := range hot {
+		c.err, c.b = false, false
+		schema := structures[td.TypeName]
+		marshal := c.marshalStruct(schema)
+		result = append(result, HotPathMethods{
+			TypeName:  td.TypeName,
+			Marshal:   marshal,
+			Unmarshal: c.unmarshalStruct(schema),
+		})
+	}
+	return result
+}
+
+// hotPathEnabled returns whether a type has hot-path methods, with x-hot-path
+// or the hot-path-marshalers option, and whether x-hot-path declares them.
+func hotPathEnabled(td TypeDefinition) (enabled, declared bool, err error) {
+	enabled = globalState.options.OutputOptions.HotPathMarshalers
+	if td.Schema.OAPISchema == nil {
+		return enabled, false, nil
+	}
+	extension, ok := td.Schema.OAPISchema.Extensions[extHotPath]
+	if !ok {
+		return enabled, false, nil
+	}
+	hotPath, err := extParseHotPath(extension)
+	if err != nil {
+		return false, false, err
+	}
+	return hotPath, hotPath, nil
+}
+
+// unsupported returns why the hot-path methods can't encode the struct of a
+// schema, or "" when they can.
+func (c *hotPathCoder) unsupported(schema Schema) string {
+	if !strings.HasPrefix(schema.GoType, "struct") || schema.IsRef() {
+		return "isn't an object"
+	}
+	if schema.HasAdditionalProperties || len(schema.UnionElements) != 0 || len(schema.TupleElements) != 0 {
+		return "has JSON methods of its own"
+	}
+	for _, p := range schema.Properties {
+		if p.JsonIgnored() || !p.OmitEmpty() || strings.HasPrefix(p.structFieldType(), "*") {
+			continue
+		}
+		if _, ok := c.nonEmpty(p.Schema, ""); !ok {
+			return fmt.Sprintf("has the %s field, whose emptiness the hot-path marshalers can't tell", p.structFieldName())
+		}
+	}
+	return ""
+}
+
+// value describes how the values of a schema are encoded and decoded.
+func (c *hotPathCoder) value(schema Schema) hotPathValue {
+	v := hotPathValue{goType: schema.TypeDecl()}
+
+	// Follow the aliases to the type declaring the methods, and then the
+	// types it's defined as to the one declaring its structure.
+	named := false
+	for i := 0; i < len(c.types); i++ {
+		td, found := c.types[schema.TypeDecl()]
+		if !found {
+			break
+		}
+		if !td.IsAlias() && !named {
+			if c.hot[td.TypeName] {
+				v.kind = hotPathStruct
+				return v
+			}
+			named = true
+		}
+		schema = td.Schema
+	}
+	v.structure = schema
+
+	if schema.IsRef() || schema.TimeFormat != "" ||
+		schema.HasAdditionalProperties || len(schema.UnionElements) != 0 || len(schema.TupleElements) != 0 {
+		return v
+	}
+	switch goType := schema.GoType; {
+	case goType == "string":
+		v.kind = hotPathString
+	case goType == "bool":
+		v.kind = hotPathBool
+	case goType == "int" || goType == "int8" || goType == "int16" || goType == "int32" || goType == "int64":
+		v.kind, v.bits = hotPathInt, typeBits(goType, "int")
+	case goType == "uint" || goType == "uint8" || goType == "uint16" || goType == "uint32" || goType == "uint64":
+		v.kind, v.bits = hotPathUint, typeBits(goType, "uint")
+	case goType == "float32" || goType == "float64":
+		v.kind, v.bits = hotPathFloat, typeBits(goType, "float")
+	case hotPathMarshalers[goType] || schema.NullableType != nil:
+		if !named {
+			v.kind = hotPathMarshaler
+		}
+	case schema.ArrayType != nil && goType == "[]"+schema.ArrayType.TypeDecl():
+		v.kind, v.elem = hotPathArray, schema.ArrayType
+	case strings.HasPrefix(goType, "map[string]"):
+		elem, pointer := mapElement(schema)
+		elemType := elem.TypeDecl()
+		if pointer {
+			elemType = "*" + elemType
+		}
+		if goType == "map[string]"+elemType {
+			v.kind, v.elem, v.elemPointer = hotPathMap, &elem, pointer
+		}
+	}
+	return v
+}
+
+// typeBits returns the size of an integer or floating point type, like 32
+// for int32, or 0 for int and uint.
+func typeBits(goType, prefix string) int {
+	bits, _ := strconv.Atoi(strings.TrimPrefix(goType, prefix))
+	return bits
+}
+
+// nonEmpty returns the expression telling whether x, a value of a schema, is
+// left in its JSON when its field is omitempty, "" when it always is, and
+// whether it's known.
+func (c *hotPathCoder) nonEmpty(schema Schema, x string) (string, bool) {
+	v := c.value(schema)
+	switch v.kind {
+	case hotPathString:
+		return fmt.Sprintf(`%s != ""`, x), true
+	case hotPathInt, hotPathUint, hotPathFloat:
+		return fmt.Sprintf("%s != 0", x), true
+	case hotPathBool:
+		return x, true
+	case hotPathArray, hotPathMap:
+		return fmt.Sprintf("len(%s) != 0", x), true
+	case hotPathStruct:
+		return "", true
+	}
+	if strings.HasPrefix(v.structure.GoType, "struct") || v.structure.hasMethods() {
+		return "", true
+	}
+	if strings.HasPrefix(v.structure.GoType, "[]") || strings.HasPrefix(v.structure.GoType, "map[") || v.structure.NullableType != nil {
+		return fmt.Sprintf("len(%s) != 0", x), true
+	}
+	if emptiness, ok := hotPathEmptiness[v.structure.GoType]; ok && !v.structure.IsRef() {
+		if emptiness == "" {
+			return "", true
+		}
+		return fmt.Sprintf(emptiness, x), true
+	}
+	return "", false
+}
+
+// hotPathKey returns the Go literal of the comma, name and colon preceding
+// the value of a field in the JSON of a struct.
+func hotPathKey(name string) string {
+	quoted, _ := json.Marshal(name)
+	key := "," + string(quoted) + ":"
+	if strconv.CanBackquote(key) {
+		return "`" + key + "`"
+	}
+	return strconv.Quote(key)
+}
+
+// marshalStruct returns the statements of appendJSON, appending the JSON of
+// a, of a struct schema, to buf. Each field is written with a comma before
+// it, and that of the first one is replaced by the brace beginning the
+// object.
+func (c *hotPathCoder) marshalStruct(schema Schema) string {
+	var fields []string
+	for _, p := range schema.Properties {
+		if p.JsonIgnored() {
+			continue
+		}
+		field := "a." + p.structFieldName()
+		key := fmt.Sprintf("buf = append(buf, %s...)", hotPathKey(p.JsonFieldName))
+		pointer := strings.HasPrefix(p.structFieldType(), "*")
+		switch {
+		case pointer && p.OmitEmpty():
+			fields = append(fields, fmt.Sprintf("if %s != nil {\n%s\n%s\n}", field, key, c.marshalValue(p.Schema, "*"+field, 0)))
+		case pointer:
+			fields = append(fields, key+"\n"+c.marshalElem(p.Schema, true, field, 0))
+		case p.OmitEmpty():
+			if nonEmpty, _ := c.nonEmpty(p.Schema, field); nonEmpty != "" {
+				fields = append(fields, fmt.Sprintf("if %s {\n%s\n%s\n}", nonEmpty, key, c.marshalValue(p.Schema, field, 0)))
+				break
+			}
+			fallthrough
+		default:
+			fields = append(fields, key+"\n"+c.marshalValue(p.Schema, field, 0))
+		}
+	}
+	if len(fields) == 0 {
+		return "return append(buf, '{', '}'), nil"
+	}
+
+	var statements []string
+	if c.b {
+		statements = append(statements, "var b []byte")
+	}
+	if c.err {
+		statements = append(statements, "var err error")
+	}
+	statements = append(statements, "start := len(buf)")
+	statements = append(statements, fields...)
+	statements = append(statements,
+		"if len(buf) == start {\nreturn append(buf, '{', '}'), nil\n}",
+		"buf[start] = '{'",
+		"return append(buf, '}'), nil")
+	return strings.Join(statements, "\n")
+}
+
+// marshalElem returns the statements appending the JSON of x, a value of a
+// schema, or a pointer to one, to buf.
+func (c *hotPathCoder) marshalElem(schema Schema, pointer bool, x string, depth int) string {
+	if !pointer || c.value(schema).kind == hotPathFallback {
+		return c.marshalValue(schema, x, depth)
+	}
+	return fmt.Sprintf("if %s == nil {\nbuf = append(buf, \"null\"...)\n} else {\n%s\n}", x, c.marshalValue(schema, "*"+x, depth))
+}
+
+// marshalValue returns the statements appending the JSON of x, a value of a
+// schema, to buf.
+func (c *hotPathCoder) marshalValue(schema Schema, x string, depth int) string {
+	v := c.value(schema)
+	switch v.kind {
+	case hotPathString:
+		return fmt.Sprintf("buf = appendJSONString(buf, %s)", convert("string", v.goType, x))
+	case hotPathInt:
+		return fmt.Sprintf("buf = strconv.AppendInt(buf, %s, 10)", convert("int64", v.goType, x))
+	case hotPathUint:
+		return fmt.Sprintf("buf = strconv.AppendUint(buf, %s, 10)", convert("uint64", v.goType, x))
+	case hotPathFloat:
+		c.err = true
+		return fmt.Sprintf("if buf, err = appendJSONFloat(buf, %s, %d); err != nil {\nreturn nil, err\n}", convert("float64", v.goType, x), v.bits)
+	case hotPathBool:
+		return fmt.Sprintf("buf = strconv.AppendBool(buf, %s)", convert("bool", v.goType, x))
+	case hotPathStruct:
+		c.err = true
+		return fmt.Sprintf("if buf, err = %s.appendJSON(buf); err != nil {\nreturn nil, err\n}", strings.TrimPrefix(x, "*"))
+	case hotPathMarshaler:
+		c.err, c.b = true, true
+		return fmt.Sprintf("if b, err = %s.MarshalJSON(); err != nil {\nreturn nil, err\n}\nbuf = append(buf, b...)", strings.TrimPrefix(x, "*"))
+	case hotPathArray:
+		i, item := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+		return fmt.Sprintf("if %s == nil {\nbuf = append(buf, \"null\"...)\n} else {\nbuf = append(buf, '[')\nfor %s, %s := range %s {\nif %s > 0 {\nbuf = append(buf, ',')\n}\n%s\n}\nbuf = append(buf, ']')\n}",
+			x, i, item, x, i, c.marshalValue(*v.elem, item, depth+1))
+	case hotPathMap:
+		keys, i, k := fmt.Sprintf("keys%d", depth), fmt.Sprintf("i%d", depth), fmt.Sprintf("k%d", depth)
+		m := x
+		if strings.HasPrefix(m, "*") {
+			m = "(" + m + ")"
+		}
+		return fmt.Sprintf("if %s == nil {\nbuf = append(buf, \"null\"...)\n} else {\n"+
+			"%s := make([]string, 0, len(%s))\nfor %s := range %s {\n%s = append(%s, %s)\n}\nsort.Strings(%s)\n"+
+			"buf = append(buf, '{')\nfor %s, %s := range %s {\nif %s > 0 {\nbuf = append(buf, ',')\n}\nbuf = appendJSONString(buf, %s)\nbuf = append(buf, ':')\n%s\n}\nbuf = append(buf, '}')\n}",
+			x, keys, x, k, x, keys, keys, k, keys,
+			i, k, keys, i, k, c.marshalElem(*v.elem, v.elemPointer, m+"["+k+"]", depth+1))
+	}
+	c.err, c.b = true, true
+	return fmt.Sprintf("if b, err = json.Marshal(%s); err != nil {\nreturn nil, err\n}\nbuf = append(buf, b...)", x)
+}
+
+// unmarshalStruct returns the statements of decodeJSON, decoding the fields
+// of a, of a struct schema, from l.
+func (c *hotPathCoder) unmarshalStruct(schema Schema) string {
+	var names, cases []string
+	for _, p := range schema.Properties {
+		if p.JsonIgnored() {
+			continue
+		}
+		field := "a." + p.structFieldName()
+		pointer := strings.HasPrefix(p.structFieldType(), "*")
+		names = append(names, strconv.Quote(p.JsonFieldName))
+		cases = append(cases, fmt.Sprintf("case %s:\n%s", strconv.Quote(p.JsonFieldName), c.unmarshalElem(p.Schema, pointer, field, 0)))
+	}
+	cases = append(cases, "default:\nl.Skip()")
+	// The keys match the fields like with encoding/json, case-insensitively
+	// unless one matches exactly.
+	return fmt.Sprintf("if l.Null() {\nreturn\n}\nl.Begin('{')\nfor i := 0; l.More('}', i); i++ {\nswitch l.Field(l.Key()%s) {\n%s\n}\n}",
+		strings.Join(append([]string{""}, names...), ", "), strings.Join(cases, "\n"))
+}
+
+// unmarshalElem returns the statements decoding dst, a value of a schema, or
+// a pointer to one, from l.
+func (c *hotPathCoder) unmarshalElem(schema Schema, pointer bool, dst string, depth int) string {
+	v := c.value(schema)
+	if !pointer || v.kind == hotPathFallback {
+		return c.decodeValue(schema, dst, depth, true)
+	}
+	return fmt.Sprintf("if l.Null() {\n%s = nil\n} else {\nif %s == nil {\n%s = new(%s)\n}\n%s\n}",
+		dst, dst, dst, v.goType, c.decodeValue(schema, "*"+dst, depth, false))
+}
+
+// decodeValue returns the statements decoding dst, a value of a schema,
+// from l, and its null unless it was read before. The nulls decode to nothing
+// but the nil slices and maps, as with encoding/json.
+func (c *hotPathCoder) decodeValue(schema Schema, dst string, depth int, null bool) string {
+	v := c.value(schema)
+	addr := "&" + dst
+	if strings.HasPrefix(dst, "*") {
+		addr = dst[1:]
+	}
+	ifNotNull := func(statement string) string {
+		if !null {
+			return statement
+		}
+		return fmt.Sprintf("if !l.Null() {\n%s\n}", statement)
+	}
+	ifNull := func(statement string) string {
+		if !null {
+			return statement
+		}
+		return fmt.Sprintf("if l.Null() {\n%s = nil\n} else {\n%s\n}", dst, statement)
+	}
+	switch v.kind {
+	case hotPathString:
+		return ifNotNull(dst + " = " + convert(v.goType, "string", "l.String()"))
+	case hotPathInt:
+		return ifNotNull(dst + " = " + convert(v.goType, "int64", fmt.Sprintf("l.Int(%s)", bitsArgument(v.bits))))
+	case hotPathUint:
+		return ifNotNull(dst + " = " + convert(v.goType, "uint64", fmt.Sprintf("l.Uint(%s)", bitsArgument(v.bits))))
+	case hotPathFloat:
+		return ifNotNull(dst + " = " + convert(v.goType, "float64", fmt.Sprintf("l.Float(%d)", v.bits)))
+	case hotPathBool:
+		return ifNotNull(dst + " = " + convert(v.goType, "bool", "l.Bool()"))
+	case hotPathStruct:
+		return fmt.Sprintf("%s.decodeJSON(l)", strings.TrimPrefix(dst, "*"))
+	case hotPathMarshaler:
+		if v.structure.NullableType != nil {
+			// A Nullable decodes its null itself.
+			return fmt.Sprintf("l.Unmarshaler(%s)", addr)
+		}
+		return ifNotNull(fmt.Sprintf("l.Unmarshaler(%s)", addr))
+	case hotPathArray:
+		i, item := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+		s := dst
+		if strings.HasPrefix(s, "*") {
+			s = "(" + s + ")"
+		}
+		return ifNull(fmt.Sprintf("%s = %s[:0]\nif %s == nil {\n%s = %s{}\n}\n"+
+			"l.Begin('[')\nfor %s := 0; l.More(']', %s); %s++ {\nvar %s %s\n%s\n%s = append(%s, %s)\n}",
+			dst, s, dst, dst, v.goType,
+			i, i, i, item, v.elem.TypeDecl(), c.decodeValue(*v.elem, item, depth+1, true), dst, dst, item))
+	case hotPathMap:
+		i, k, value := fmt.Sprintf("i%d", depth), fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		elemType := v.elem.TypeDecl()
+		if v.elemPointer {
+			elemType = "*" + elemType
+		}
+		m := dst
+		if strings.HasPrefix(m, "*") {
+			m = "(" + m + ")"
+		}
+		return ifNull(fmt.Sprintf("if %s == nil {\n%s = make(%s)\n}\n"+
+			"l.Begin('{')\nfor %s := 0; l.More('}', %s); %s++ {\n%s := string(l.Key())\nvar %s %s\n%s\n%s[%s] = %s\n}",
+			dst, dst, v.goType,
+			i, i, i, k, value, elemType, c.unmarshalElem(*v.elem, v.elemPointer, value, depth+1), m, k, value))
+	}
+	return fmt.Sprintf("l.Unmarshal(%s)", addr)
+}
+
+// convert returns the conversion of x, of type from, to type to, or x when
+// the types are the same.
+func convert(to, from, x string) string {
+	if to == from {
+		return x
+	}
+	return fmt.Sprintf("%s(%s)", to, x)
+}
+
+// bitsArgument returns the size of integers passed to the jsonLexer, which is
+// that of int for int and uint.
+func bitsArgument(bits int) string {
+	if bits == 0 {
+		return "strconv.IntSize"
+	}
+	return strconv.Itoa(bits)
+}
+
+// GenerateHotPathMethods generates the MarshalJSON and UnmarshalJSON methods
+// of the hot-path types.
+func GenerateHotPathMethods(t *template.Template, types []TypeDefinition) (string, error) {
+	return GenerateTemplates([]string{"hot-path.tmpl"}, t, DescribeHotPathMethods(types))
+}
@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// rewriteImports points the imports of Go code at other paths with the
+// import-rewrites output option, like the fork of a package in a vendored or
+// proxied module, and renames them with the import-aliases one, along with
+// the references to them. The aliases are keyed by the paths before they're
+// rewritten.
+func rewriteImports(code []byte) ([]byte, error) {
+	rewrites := globalState.options.OutputOptions.ImportRewrites
+	aliases := globalState.options.OutputOptions.ImportAliases
+	if len(rewrites) == 0 && len(aliases) == 0 {
+		return code, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	renamed := make(map[string]string)
+	names := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := assumedPackageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		newPath, newName := rewriteImportPath(importPath, rewrites), name
+		if alias, ok := aliases[importPath]; ok && name != "_" && name != "." {
+			newName = alias
+		}
+		spec.Path.Value = strconv.Quote(newPath)
+		if newName != name {
+			renamed[name] = newName
+		}
+		// The package keeps its name at its new path, which may not tell it.
+		if spec.Name != nil || newName != assumedPackageName(newPath) {
+			spec.Name = ast.NewIdent(newName)
+		}
+		if newName == "_" || newName == "." {
+			continue
+		}
+		if other, found := names[newName]; found {
+			return nil, fmt.Errorf("the imports of %s and %s are both named %s, which import-aliases can rename", other, newPath, newName)
+		}
+		names[newName] = newPath
+	}
+
+	// The references to packages are the identifiers which the parser doesn't
+	// resolve.
+	ast.Inspect(file, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := selector.X.(*ast.Ident); ok && id.Obj == nil {
+				if newName, ok := renamed[id.Name]; ok {
+					id.Name = newName
+				}
+			}
+		}
+		return true
+	})
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// rewriteImportPath returns an import path rewritten with the longest of the
+// given prefixes it starts with, as whole path elements.
+func rewriteImportPath(importPath string, rewrites map[string]string) string {
+	var from string
+	for prefix := range rewrites {
+		if (importPath == prefix || strings.HasPrefix(importPath, prefix+"/")) && len(prefix) > len(from) {
+			from = prefix
+		}
+	}
+	if from == "" {
+		return importPath
+	}
+	return rewrites[from] + strings.TrimPrefix(importPath, from)
+}
+
+// assumedPackageName returns the name of the package at an import path, as
+// goimports assumes it, like echo for github.com/labstack/echo/v4, or yaml
+// for gopkg.in/yaml.v2.
+func assumedPackageName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
@@ -27,7 +27,9 @@ import (
 // GenerateInlinedSpec generates a gzipped, base64 encoded JSON representation of the
 // swagger definition, which we embed inside the generated code.
 func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T) (string, error) {
-	// ensure that any external file references are embedded into the embedded spec
+	// ensure that any external file references are embedded into the embedded spec,
+	// without mixing up components of different documents sharing a name
+	Bundle(swagger)
 	swagger.InternalizeRefs(context.Background(), nil)
 	// Marshal to json
 	encoded, err := swagger.MarshalJSON()
@@ -0,0 +1,171 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// ModelInterface describes an interface which the schemas declaring it with
+// x-implements implement.
+type ModelInterface struct {
+	Name            string                 // The name of the interface, like Resource
+	TypeNames       []string               // The types implementing it, in the order of the spec
+	Methods         []string               // The methods of the interface, like GetId() string
+	Marker          string                 // The unexported method of the interface, like isResource, when it has no other
+	Implementations []GetterImplementation // The getters the types get for it, which they didn't for another interface
+}
+
+// GetterImplementation is the getter of a field of a type.
+type GetterImplementation struct {
+	TypeName string // The type, like Pet
+	InterfaceGetter
+}
+
+// InterfaceGetter describes the getter of a field which the types
+// implementing an interface have in common.
+type InterfaceGetter struct {
+	Name     string // The name of the getter, like GetId
+	Field    string // The name of the field, like Id
+	Type     string // The type of the field
+	JsonName string // The name of the property of the field, like id
+}
+
+// Implementers returns the types implementing the interface, listed for its
+// doc comment, like Cat and Dog.
+func (i ModelInterface) Implementers() string {
+	return enumerate(i.TypeNames)
+}
+
+// enumerate lists names for a doc comment, like Cat, Dog and Fish.
+func enumerate(names []string) string {
+	switch n := len(names); n {
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:n-1], ", ") + " and " + names[n-1]
+	}
+}
+
+// DescribeInterfaces describes the interfaces declared with x-implements by
+// the given types. An interface has the methods named for it by the
+// interface-methods output option, which the types implement by hand, or
+// else getters of the fields which all its types have, with the same name
+// and type, which are generated, or else an unexported marker method.
+func DescribeInterfaces(types []TypeDefinition) ([]ModelInterface, error) {
+	defined := make(map[string]TypeDefinition)
+	implementers := make(map[string][]TypeDefinition)
+	for _, td := range types {
+		if _, found := defined[td.TypeName]; found {
+			continue
+		}
+		defined[td.TypeName] = td
+		if td.IsAlias() || td.Schema.OAPISchema == nil {
+			continue
+		}
+		extension, ok := td.Schema.OAPISchema.Extensions[extImplements]
+		if !ok {
+			continue
+		}
+		names, err := extParseImplements(extension)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q of %s: %w", extImplements, td.TypeName, err)
+		}
+		for _, name := range names {
+			implementers[name] = append(implementers[name], td)
+		}
+	}
+
+	names := make([]string, 0, len(implementers))
+	for name := range implementers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// The getters of the types, shared between their interfaces, like
+	// Pet.GetId
+	implemented := make(map[string]bool)
+	var result []ModelInterface
+	for _, name := range names {
+		if _, found := defined[name]; found {
+			return nil, fmt.Errorf("the interface %s of %s collides with the type %s", name, extImplements, name)
+		}
+		i := ModelInterface{Name: name}
+		for _, td := range implementers[name] {
+			i.TypeNames = append(i.TypeNames, td.TypeName)
+		}
+		if methods, ok := globalState.options.OutputOptions.InterfaceMethods[name]; ok {
+			i.Methods = methods
+			result = append(result, i)
+			continue
+		}
+
+		getters := commonGetters(implementers[name])
+		if len(getters) == 0 {
+			warn(nil, jsonPointer("components", "schemas", implementers[name][0].JsonName), "the types implementing %s have no field in common, so it only has a marker method", name)
+		}
+		for _, getter := range getters {
+			for _, td := range implementers[name] {
+				for _, field := range fieldNames(td.Schema) {
+					if field == getter.Name {
+						return nil, fmt.Errorf("the %s getter of the %s interface collides with the %s field of %s, which x-go-name can rename", getter.Name, name, field, td.TypeName)
+					}
+				}
+			}
+			i.Methods = append(i.Methods, fmt.Sprintf("%s() %s", getter.Name, getter.Type))
+			for _, typeName := range i.TypeNames {
+				if !implemented[typeName+"."+getter.Name] {
+					implemented[typeName+"."+getter.Name] = true
+					i.Implementations = append(i.Implementations, GetterImplementation{TypeName: typeName, InterfaceGetter: getter})
+				}
+			}
+		}
+		if len(i.Methods) == 0 {
+			i.Marker = "is" + name
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
+
+// commonGetters returns the getters of the fields which all the given struct
+// types have, with the same name and type, in the order of the first.
+func commonGetters(types []TypeDefinition) []InterfaceGetter {
+	var getters []InterfaceGetter
+	for _, td := range types {
+		if !strings.HasPrefix(td.Schema.GoType, "struct") || td.Schema.IsRef() {
+			return nil
+		}
+	}
+	for _, p := range types[0].Schema.Properties {
+		if p.JsonIgnored() {
+			continue
+		}
+		field, typ := p.structFieldName(), p.structFieldType()
+		common := true
+		for _, td := range types[1:] {
+			found := false
+			for _, other := range td.Schema.Properties {
+				if !other.JsonIgnored() && other.structFieldName() == field && other.structFieldType() == typ {
+					found = true
+					break
+				}
+			}
+			common = common && found
+		}
+		if common {
+			getters = append(getters, InterfaceGetter{Name: "Get" + field, Field: field, Type: typ, JsonName: p.JsonFieldName})
+		}
+	}
+	return getters
+}
+
+// GenerateInterfaces generates the interfaces declared with x-implements, and
+// the getters implementing them.
+func GenerateInterfaces(t *template.Template, types []TypeDefinition) (string, error) {
+	interfaces, err := DescribeInterfaces(types)
+	if err != nil {
+		return "", err
+	}
+	return GenerateTemplates([]string{"interfaces.tmpl"}, t, interfaces)
+}
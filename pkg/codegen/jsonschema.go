@@ -0,0 +1,241 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// jsonSchemaDialect is the JSON Schema draft of the exported schemas.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaFile is the JSON Schema of a component schema, exported with the
+// json-schema option.
+type JSONSchemaFile struct {
+	TypeName string // The name of the Go type of the schema, like Pet
+	FileName string // The name of the file of the JSON Schema, like Pet.schema.json
+	Schema   []byte // The indented JSON Schema
+}
+
+// GoLiteral returns the compact JSON Schema as a Go string literal.
+func (f JSONSchemaFile) GoLiteral() (string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, f.Schema); err != nil {
+		return "", err
+	}
+	if strconv.CanBackquote(compact.String()) {
+		return "`" + compact.String() + "`", nil
+	}
+	return strconv.Quote(compact.String()), nil
+}
+
+// ExportJSONSchemas exports each component schema of the spec, but the
+// excluded ones, as a standalone JSON Schema of draft 2020-12, whose $defs
+// hold the component schemas it references, in the alphabetical order of
+// their names.
+//
+// OpenAPI keywords translate to their JSON Schema equivalents: nullable adds
+// null to the types, the boolean exclusiveMinimum and exclusiveMaximum become
+// numbers, and example becomes examples. Those without one, like
+// discriminator and xml, and the extensions, are left out.
+func ExportJSONSchemas(spec *openapi3.T, excludeSchemas []string) ([]JSONSchemaFile, error) {
+	if spec.Components == nil {
+		return nil, nil
+	}
+	var files []JSONSchemaFile
+	for _, name := range SortedSchemaKeys(spec.Components.Schemas) {
+		if StringInArray(name, excludeSchemas) {
+			continue
+		}
+		sref := spec.Components.Schemas[name]
+		typeName, err := renameSchema(name, sref)
+		if err != nil {
+			return nil, fmt.Errorf("error making name for components/schemas/%s: %w", name, err)
+		}
+
+		e := jsonSchemaExporter{
+			components: spec.Components.Schemas,
+			root:       name,
+			defs:       make(map[string]interface{}),
+			converting: make(map[*openapi3.Schema]bool),
+		}
+		schema := e.convert(&openapi3.SchemaRef{Value: sref.Value, Ref: sref.Ref})
+		for len(e.pending) != 0 {
+			def := e.pending[0]
+			e.pending = e.pending[1:]
+			e.defs[def] = e.convert(&openapi3.SchemaRef{Value: e.components[def].Value})
+		}
+
+		document := map[string]interface{}{
+			"$schema": jsonSchemaDialect,
+			"$id":     typeName + ".schema.json",
+			"title":   name,
+		}
+		if len(e.defs) != 0 {
+			document["$defs"] = e.defs
+		}
+		if object, ok := schema.(map[string]interface{}); ok {
+			for keyword, value := range object {
+				document[keyword] = value
+			}
+		}
+		buf, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling the JSON Schema of %s: %w", name, err)
+		}
+		files = append(files, JSONSchemaFile{
+			TypeName: typeName,
+			FileName: typeName + ".schema.json",
+			Schema:   append(buf, '\n'),
+		})
+	}
+	return files, nil
+}
+
+// jsonSchemaExporter converts the schemas of a component schema to JSON
+// Schema.
+type jsonSchemaExporter struct {
+	components openapi3.Schemas
+	root       string                    // The name of the component schema exported
+	defs       map[string]interface{}    // The component schemas it references, by name
+	pending    []string                  // The referenced component schemas yet to convert
+	converting map[*openapi3.Schema]bool // The schemas being converted, to stop at cycles through other documents
+}
+
+// convert returns the JSON Schema of a schema.
+func (e *jsonSchemaExporter) convert(sref *openapi3.SchemaRef) interface{} {
+	if sref == nil || sref.Value == nil {
+		return map[string]interface{}{}
+	}
+	if name, found := strings.CutPrefix(sref.Ref, "#/components/schemas/"); found && e.components[name] != nil {
+		if name == e.root {
+			return map[string]interface{}{"$ref": "#"}
+		}
+		if _, defined := e.defs[name]; !defined {
+			e.defs[name] = nil
+			e.pending = append(e.pending, name)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + name}
+	}
+
+	schema := sref.Value
+	if e.converting[schema] {
+		// A cycle through another document, which has no name here
+		return map[string]interface{}{}
+	}
+	e.converting[schema] = true
+	defer delete(e.converting, schema)
+
+	out := make(map[string]interface{})
+	set := func(keyword string, value interface{}, present bool) {
+		if present {
+			out[keyword] = value
+		}
+	}
+
+	set("title", schema.Title, schema.Title != "")
+	set("description", schema.Description, schema.Description != "")
+	set("format", schema.Format, schema.Format != "")
+	set("default", schema.Default, schema.Default != nil)
+	set("examples", []interface{}{schema.Example}, schema.Example != nil)
+	set("deprecated", true, schema.Deprecated)
+	set("readOnly", true, schema.ReadOnly)
+	set("writeOnly", true, schema.WriteOnly)
+	set("enum", schema.Enum, len(schema.Enum) != 0)
+
+	set("multipleOf", derefFloat(schema.MultipleOf), schema.MultipleOf != nil)
+	if schema.Min != nil {
+		if schema.ExclusiveMin {
+			out["exclusiveMinimum"] = *schema.Min
+		} else {
+			out["minimum"] = *schema.Min
+		}
+	}
+	if schema.Max != nil {
+		if schema.ExclusiveMax {
+			out["exclusiveMaximum"] = *schema.Max
+		} else {
+			out["maximum"] = *schema.Max
+		}
+	}
+	set("minLength", schema.MinLength, schema.MinLength != 0)
+	set("maxLength", derefUint64(schema.MaxLength), schema.MaxLength != nil)
+	set("pattern", schema.Pattern, schema.Pattern != "")
+
+	if prefix := prefixItems(schema); len(prefix) != 0 {
+		out["prefixItems"] = e.convertAll(prefix)
+	}
+	set("items", e.convert(schema.Items), schema.Items != nil)
+	set("minItems", schema.MinItems, schema.MinItems != 0)
+	set("maxItems", derefUint64(schema.MaxItems), schema.MaxItems != nil)
+	set("uniqueItems", true, schema.UniqueItems)
+
+	if len(schema.Properties) != 0 {
+		properties := make(map[string]interface{}, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = e.convert(property)
+		}
+		out["properties"] = properties
+	}
+	set("required", schema.Required, len(schema.Required) != 0)
+	if schema.AdditionalProperties.Has != nil && !*schema.AdditionalProperties.Has {
+		out["additionalProperties"] = false
+	} else if schema.AdditionalProperties.Schema != nil {
+		out["additionalProperties"] = e.convert(schema.AdditionalProperties.Schema)
+	}
+	set("minProperties", schema.MinProps, schema.MinProps != 0)
+	set("maxProperties", derefUint64(schema.MaxProps), schema.MaxProps != nil)
+
+	set("allOf", e.convertAll(schema.AllOf), len(schema.AllOf) != 0)
+	set("oneOf", e.convertAll(schema.OneOf), len(schema.OneOf) != 0)
+	set("anyOf", e.convertAll(schema.AnyOf), len(schema.AnyOf) != 0)
+	set("not", e.convert(schema.Not), schema.Not != nil)
+
+	switch {
+	case schema.Type != "" && schema.Nullable:
+		out["type"] = []string{schema.Type, "null"}
+		if len(schema.Enum) != 0 {
+			out["enum"] = append(append([]interface{}{}, schema.Enum...), nil)
+		}
+	case schema.Type != "":
+		out["type"] = schema.Type
+	case schema.Nullable:
+		return map[string]interface{}{
+			"anyOf": []interface{}{out, map[string]interface{}{"type": "null"}},
+		}
+	}
+	return out
+}
+
+func (e *jsonSchemaExporter) convertAll(refs openapi3.SchemaRefs) []interface{} {
+	schemas := make([]interface{}, len(refs))
+	for i, ref := range refs {
+		schemas[i] = e.convert(ref)
+	}
+	return schemas
+}
+
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
+func derefUint64(u *uint64) uint64 {
+	if u == nil {
+		return 0
+	}
+	return *u
+}
+
+// GenerateJSONSchemaRegistry generates the JSONSchemaRegistry of the JSON
+// Schemas of the component schemas.
+func GenerateJSONSchemaRegistry(t *template.Template, files []JSONSchemaFile) (string, error) {
+	return GenerateTemplates([]string{"json-schema.tmpl"}, t, files)
+}
@@ -0,0 +1,264 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// KubernetesResource describes the runtime.Object methods of a Kubernetes
+// resource, an object with apiVersion, kind and metadata properties.
+type KubernetesResource struct {
+	TypeName          string
+	APIVersionField   string // The name of the field of the apiVersion
+	APIVersionPointer bool   // Whether the field of the apiVersion is a pointer
+	KindField         string // The name of the field of the kind
+	KindPointer       bool   // Whether the field of the kind is a pointer
+}
+
+// KubernetesList describes the list type of a Kubernetes resource, which
+// the spec doesn't declare, for the resource to be registered with a
+// runtime.Scheme along with it.
+type KubernetesList struct {
+	TypeName string // The name of the list, like WidgetList
+	ItemType string // The name of the resource
+}
+
+// kubernetesMetaTypes are the types of the Kubernetes object metadata, which
+// the DeepCopy methods copy with their own.
+var kubernetesMetaTypes = map[string]bool{
+	"metav1.ObjectMeta": true, "metav1.ListMeta": true, "metav1.Time": true,
+}
+
+// kubernetesObjectMethods are the methods of the resources, which their
+// fields can't be named as.
+var kubernetesObjectMethods = []string{"GetObjectKind", "GroupVersionKind", "SetGroupVersionKind", "DeepCopyObject", "DeepCopyInto"}
+
+// plainEnumValue matches the enum values written as they are in kubebuilder
+// markers, the others being quoted.
+var plainEnumValue = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// kubernetesResource returns the apiVersion and kind properties of a schema
+// which is a Kubernetes resource, an object with string apiVersion and kind
+// properties, and a metadata one.
+func kubernetesResource(s Schema) (apiVersion, kind Property, ok bool) {
+	if !strings.HasPrefix(s.GoType, "struct") || s.IsRef() {
+		return Property{}, Property{}, false
+	}
+	var metadata bool
+	for _, p := range s.Properties {
+		switch {
+		case p.JsonFieldName == "apiVersion" && p.Schema.TypeDecl() == "string":
+			apiVersion = p
+		case p.JsonFieldName == "kind" && p.Schema.TypeDecl() == "string":
+			kind = p
+		case p.JsonFieldName == "metadata":
+			metadata = true
+		}
+	}
+	return apiVersion, kind, metadata && apiVersion.JsonFieldName != "" && kind.JsonFieldName != ""
+}
+
+// KubebuilderMarkers returns the kubebuilder markers of the type, those of
+// the constraints of its schema, and those declaring the resources as the
+// roots of custom resources, with the kubernetes-crd option.
+func (t TypeDefinition) KubebuilderMarkers() []string {
+	if !globalState.options.Generate.KubernetesCRD || t.IsAlias() || t.Schema.IsRef() || t.Schema.OAPISchema == nil {
+		return nil
+	}
+	var markers []string
+	if _, _, ok := kubernetesResource(t.Schema); ok {
+		markers = append(markers, "+kubebuilder:object:root=true")
+		for _, p := range t.Schema.Properties {
+			if p.JsonFieldName == "status" {
+				markers = append(markers, "+kubebuilder:subresource:status")
+			}
+		}
+	}
+	return append(markers, kubebuilderMarkers(t.Schema.OAPISchema)...)
+}
+
+// KubebuilderMarkers returns the kubebuilder markers of the field of the
+// property, with the kubernetes-crd option: whether it's optional, and the
+// constraints of its schema, unless it's a reference, whose type has them.
+func (p Property) KubebuilderMarkers() []string {
+	if !globalState.options.Generate.KubernetesCRD {
+		return nil
+	}
+	markers := []string{"+optional"}
+	if p.Required {
+		markers = []string{"+required"}
+	}
+	if p.Nullable {
+		markers = append(markers, "+nullable")
+	}
+	if p.Schema.IsRef() || p.Schema.OAPISchema == nil || componentSchema(p.Schema.OAPISchema) {
+		return markers
+	}
+	return append(markers, kubebuilderMarkers(p.Schema.OAPISchema)...)
+}
+
+// componentSchema returns whether a schema is one of the components of the
+// spec, which properties reference.
+func componentSchema(schema *openapi3.Schema) bool {
+	if globalState.spec == nil || globalState.spec.Components == nil {
+		return false
+	}
+	for _, ref := range globalState.spec.Components.Schemas {
+		if ref.Value == schema {
+			return true
+		}
+	}
+	return false
+}
+
+// kubernetesObjectMeta returns whether the metadata of a resource is the
+// metav1.ObjectMeta of a single object, rather than the metav1.ListMeta of a
+// list.
+func kubernetesObjectMeta(s Schema) bool {
+	for _, p := range s.Properties {
+		if p.JsonFieldName == "metadata" {
+			return p.Schema.TypeDecl() == "metav1.ObjectMeta"
+		}
+	}
+	return false
+}
+
+// kubebuilderMarkers returns the kubebuilder markers of the constraints of a
+// schema, and of those of its items, unless they're a reference.
+func kubebuilderMarkers(schema *openapi3.Schema) []string {
+	markers := constraintMarkers(schema, "")
+	if schema.Items != nil && schema.Items.Value != nil && !componentSchema(schema.Items.Value) {
+		markers = append(markers, constraintMarkers(schema.Items.Value, "items:")...)
+	}
+	return markers
+}
+
+// constraintMarkers returns the kubebuilder validation markers of the
+// constraints of a schema, with prefix after validation:, like items:.
+func constraintMarkers(schema *openapi3.Schema, prefix string) []string {
+	var markers []string
+	add := func(name string, value string) {
+		markers = append(markers, fmt.Sprintf("+kubebuilder:validation:%s%s=%s", prefix, name, value))
+	}
+	if len(schema.Enum) != 0 {
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprint(value)
+			if s, ok := value.(string); ok && !plainEnumValue.MatchString(s) {
+				values[i] = strconv.Quote(s)
+			}
+		}
+		add("Enum", strings.Join(values, ";"))
+	}
+	if schema.Format != "" {
+		add("Format", schema.Format)
+	}
+	if schema.Min != nil {
+		add("Minimum", strconv.FormatFloat(*schema.Min, 'f', -1, 64))
+		if schema.ExclusiveMin {
+			add("ExclusiveMinimum", "true")
+		}
+	}
+	if schema.Max != nil {
+		add("Maximum", strconv.FormatFloat(*schema.Max, 'f', -1, 64))
+		if schema.ExclusiveMax {
+			add("ExclusiveMaximum", "true")
+		}
+	}
+	if schema.MultipleOf != nil {
+		add("MultipleOf", strconv.FormatFloat(*schema.MultipleOf, 'f', -1, 64))
+	}
+	if schema.MinLength != 0 {
+		add("MinLength", strconv.FormatUint(schema.MinLength, 10))
+	}
+	if schema.MaxLength != nil {
+		add("MaxLength", strconv.FormatUint(*schema.MaxLength, 10))
+	}
+	if schema.Pattern != "" {
+		// Patterns are raw strings, unless a backtick or a newline would end
+		// them, or the marker.
+		pattern := "`" + schema.Pattern + "`"
+		if strings.ContainsAny(schema.Pattern, "`\n") {
+			pattern = strconv.Quote(schema.Pattern)
+		}
+		add("Pattern", pattern)
+	}
+	if schema.MinItems != 0 {
+		add("MinItems", strconv.FormatUint(schema.MinItems, 10))
+	}
+	if schema.MaxItems != nil {
+		add("MaxItems", strconv.FormatUint(*schema.MaxItems, 10))
+	}
+	if schema.UniqueItems {
+		add("UniqueItems", "true")
+	}
+	if schema.MinProps != 0 {
+		add("MinProperties", strconv.FormatUint(schema.MinProps, 10))
+	}
+	if schema.MaxProps != nil {
+		add("MaxProperties", strconv.FormatUint(*schema.MaxProps, 10))
+	}
+	if prefix == "" && schema.Default != nil {
+		if value, err := json.Marshal(schema.Default); err == nil {
+			markers = append(markers, "+kubebuilder:default="+string(value))
+		}
+	}
+	return markers
+}
+
+// GenerateKubernetesObjects generates the runtime.Object methods of the
+// Kubernetes resources among the given types, with the kubernetes-crd option,
+// and the list types of those whose lists the spec doesn't declare, like
+// WidgetList for Widget.
+func GenerateKubernetesObjects(t *template.Template, types []TypeDefinition) (string, error) {
+	declared := make(map[string]bool)
+	for _, td := range types {
+		declared[td.TypeName] = true
+	}
+	var resources []KubernetesResource
+	var lists []KubernetesList
+	seen := make(map[string]bool)
+	for _, td := range types {
+		if td.IsAlias() || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		apiVersion, kind, ok := kubernetesResource(td.Schema)
+		if !ok {
+			continue
+		}
+		for _, name := range fieldNames(td.Schema) {
+			if StringInArray(name, kubernetesObjectMethods) {
+				return "", fmt.Errorf("the %s method of %s collides with its %s field, which x-go-name can rename", name, td.TypeName, name)
+			}
+		}
+		resources = append(resources, KubernetesResource{
+			TypeName:          td.TypeName,
+			APIVersionField:   apiVersion.structFieldName(),
+			APIVersionPointer: strings.HasPrefix(apiVersion.structFieldType(), "*"),
+			KindField:         kind.structFieldName(),
+			KindPointer:       strings.HasPrefix(kind.structFieldType(), "*"),
+		})
+		if list := td.TypeName + "List"; kubernetesObjectMeta(td.Schema) && !declared[list] {
+			lists = append(lists, KubernetesList{TypeName: list, ItemType: td.TypeName})
+		}
+	}
+	if len(resources) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Resources []KubernetesResource
+		Lists     []KubernetesList
+	}{
+		Resources: resources,
+		Lists:     lists,
+	}
+	return GenerateTemplates([]string{"kubernetes.tmpl"}, t, context)
+}
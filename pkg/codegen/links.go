@@ -0,0 +1,280 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LinkDefinition describes a link declared on a response of an operation,
+// which the response type of the client with responses follows with a method
+// calling the linked operation.
+type LinkDefinition struct {
+	MethodName     string         // The name of the method following the link, like GetPetOwner
+	LinkName       string         // The name of the link in the spec
+	Status         string         // The status of the response declaring the link, like 200, 2XX or default
+	Description    string         // The description of the link, if any
+	Target         string         // The ID of the linked operation
+	RequiresParams bool           // Whether the linked operation takes a params struct
+	PathArgs       []LinkArgument // The path parameters of the linked operation, in the order of its path
+	Params         []LinkArgument // The parameters of the linked operation set in its params struct
+	HasStatusCheck bool           // Whether the method checks the status of the response, which isn't default
+	StatusClass    bool           // Whether Status is a class of statuses, like 2XX
+}
+
+// LinkArgument describes how a parameter of a linked operation is mapped from
+// the response declaring the link.
+type LinkArgument struct {
+	Param   ParameterDefinition // The parameter of the linked operation
+	Source  string              // Where the value comes from, like $response.body, or empty for a constant
+	Name    string              // The name of the header or parameter, or the JSON pointer into the body
+	Pattern string              // The pattern of the path of the request, capturing the path parameter of $request.path
+	Value   string              // The JSON of a constant
+}
+
+// StatusValue returns the status code, or the hundreds of the class of status
+// codes, which the response following the link must have.
+func (l LinkDefinition) StatusValue() string {
+	if l.StatusClass {
+		return l.Status[:1]
+	}
+	return l.Status
+}
+
+// linkMethodsReserved are the names of the fields and methods of the response
+// types, which the methods following links can't take.
+var linkMethodsReserved = []string{"Body", "HTTPResponse", "Status", "StatusCode", "Success", "Error", "RateLimit", "ETag"}
+
+// resolveLinks describes the links of the responses of the operations for
+// the client with responses. The links which can't be followed, like those
+// to operations with a request body, are reported as warnings and skipped.
+func resolveLinks(ops []OperationDefinition, toCamelCaseFunc func(string) string) {
+	if !globalState.options.Generate.Client {
+		return
+	}
+	byID := make(map[string]*OperationDefinition, len(ops))
+	byPath := make(map[string]*OperationDefinition, len(ops))
+	for i := range ops {
+		byID[ops[i].OperationId] = &ops[i]
+		byPath[operationPath(&ops[i])] = &ops[i]
+	}
+	for i := range ops {
+		op := &ops[i]
+		if op.Spec == nil {
+			continue
+		}
+		statuses := SortedResponsesKeys(op.Spec.Responses)
+		names := map[string]bool{}
+		for _, status := range statuses {
+			response := op.Spec.Responses[status]
+			if response == nil || response.Value == nil {
+				continue
+			}
+			for _, linkName := range SortedLinksKeys(response.Value.Links) {
+				path := operationPath(op, "responses", status, "links", linkName)
+				link, ok := describeLink(op, status, linkName, path, byID, byPath, toCamelCaseFunc)
+				if !ok {
+					continue
+				}
+				if names[link.MethodName] {
+					warn(op, path, "the link %s is declared on another response of the operation already, so it isn't followed from the %s response", linkName, status)
+					continue
+				}
+				names[link.MethodName] = true
+				op.Links = append(op.Links, link)
+			}
+		}
+	}
+}
+
+// describeLink describes a link of a response of an operation, and whether it
+// can be followed.
+func describeLink(op *OperationDefinition, status, linkName, path string, byID, byPath map[string]*OperationDefinition, toCamelCaseFunc func(string) string) (LinkDefinition, bool) {
+	linkRef := op.Spec.Responses[status].Value.Links[linkName]
+	if linkRef == nil || linkRef.Value == nil {
+		return LinkDefinition{}, false
+	}
+	link := linkRef.Value
+
+	methodName := ToCamelCase(linkName)
+	if methodName == "" || StringInArray(methodName, linkMethodsReserved) || strings.HasPrefix(methodName, "Decode") {
+		warn(op, path, "the link %s would be followed by a method named %q, which the response type has already, so it isn't generated", linkName, methodName)
+		return LinkDefinition{}, false
+	}
+	if base := globalState.options.OutputOptions.ClientResponseBase; base != nil && methodName == base.FieldName() {
+		warn(op, path, "the link %s would be followed by a method named %q, which the response type has already, so it isn't generated", linkName, methodName)
+		return LinkDefinition{}, false
+	}
+
+	var target *OperationDefinition
+	switch {
+	case link.OperationID != "":
+		targetID := toCamelCaseFunc(link.OperationID)
+		target = byID[typeNamePrefix(targetID)+targetID]
+	case strings.HasPrefix(link.OperationRef, "#"):
+		target = byPath[strings.TrimPrefix(link.OperationRef, "#")]
+	}
+	if target == nil {
+		warn(op, path, "the operation of the link %s isn't an operation of the spec, so it isn't followed", linkName)
+		return LinkDefinition{}, false
+	}
+	if target.HasBody() {
+		warn(op, path, "the operation %s of the link %s has a request body, so it isn't followed", target.OperationId, linkName)
+		return LinkDefinition{}, false
+	}
+
+	result := LinkDefinition{
+		MethodName:     methodName,
+		LinkName:       linkName,
+		Status:         status,
+		Target:         target.OperationId,
+		RequiresParams: target.RequiresParamObject(),
+		HasStatusCheck: status != "default",
+		StatusClass:    len(status) == 3 && strings.HasSuffix(strings.ToUpper(status), "XX"),
+	}
+	names := make([]string, 0, len(link.Parameters))
+	for name := range link.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		param := linkParameter(target, name)
+		if param == nil {
+			warn(op, path, "the operation %s of the link %s has no parameter %s, so it isn't followed", target.OperationId, linkName, name)
+			return LinkDefinition{}, false
+		}
+		argument, err := linkArgument(op, *param, link.Parameters[name])
+		if err != nil {
+			warn(op, path, "the parameter %s of the link %s %s, so it isn't followed", name, linkName, err)
+			return LinkDefinition{}, false
+		}
+		if param.In == "path" {
+			result.PathArgs = append(result.PathArgs, argument)
+		} else {
+			result.Params = append(result.Params, argument)
+		}
+	}
+	for _, param := range target.PathParams {
+		if !linkArgumentFor(result.PathArgs, param) {
+			warn(op, path, "the link %s doesn't map the path parameter %s of %s, so it isn't followed", linkName, param.ParamName, target.OperationId)
+			return LinkDefinition{}, false
+		}
+	}
+	for _, param := range target.AllParams() {
+		if param.In != "path" && param.Required && !linkArgumentFor(result.Params, param) {
+			warn(op, path, "the link %s doesn't map the required parameter %s of %s, so it isn't followed", linkName, param.ParamName, target.OperationId)
+			return LinkDefinition{}, false
+		}
+	}
+	// The path arguments are passed in the order of the path.
+	sort.SliceStable(result.PathArgs, func(i, j int) bool {
+		return linkParamIndex(target.PathParams, result.PathArgs[i].Param) < linkParamIndex(target.PathParams, result.PathArgs[j].Param)
+	})
+	return result, true
+}
+
+// linkParameter returns the parameter of an operation named by a link, which
+// may qualify it with its location, like path.id.
+func linkParameter(op *OperationDefinition, name string) *ParameterDefinition {
+	in := ""
+	if i := strings.Index(name, "."); i > 0 {
+		switch name[:i] {
+		case "path", "query", "header", "cookie":
+			in, name = name[:i], name[i+1:]
+		}
+	}
+	for _, param := range op.AllParams() {
+		if param.ParamName == name && (in == "" || param.In == in) {
+			return &param
+		}
+	}
+	return nil
+}
+
+// linkArgument describes how a parameter of a linked operation is mapped from
+// the value of a link parameter, a runtime expression or a constant.
+func linkArgument(op *OperationDefinition, param ParameterDefinition, value interface{}) (LinkArgument, error) {
+	argument := LinkArgument{Param: param}
+	expression, ok := value.(string)
+	if !ok || !strings.HasPrefix(expression, "$") {
+		if ok && strings.Contains(expression, "{$") {
+			return LinkArgument{}, fmt.Errorf("embeds runtime expressions in a string, which aren't supported")
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return LinkArgument{}, fmt.Errorf("has an invalid value: %w", err)
+		}
+		argument.Value = string(data)
+		return argument, nil
+	}
+
+	switch {
+	case expression == "$statusCode", expression == "$method", expression == "$url":
+		argument.Source = expression
+	case expression == "$response.body" || strings.HasPrefix(expression, "$response.body#"):
+		argument.Source = "$response.body"
+		argument.Name = strings.TrimPrefix(strings.TrimPrefix(expression, "$response.body"), "#")
+		if argument.Name != "" && !strings.HasPrefix(argument.Name, "/") {
+			return LinkArgument{}, fmt.Errorf("maps %s, which has an invalid JSON pointer", expression)
+		}
+	case strings.HasPrefix(expression, "$response.header."):
+		argument.Source, argument.Name = "$response.header", strings.TrimPrefix(expression, "$response.header.")
+	case strings.HasPrefix(expression, "$request.header."):
+		argument.Source, argument.Name = "$request.header", strings.TrimPrefix(expression, "$request.header.")
+	case strings.HasPrefix(expression, "$request.query."):
+		argument.Source, argument.Name = "$request.query", strings.TrimPrefix(expression, "$request.query.")
+	case strings.HasPrefix(expression, "$request.path."):
+		argument.Source, argument.Name = "$request.path", strings.TrimPrefix(expression, "$request.path.")
+		if ParameterDefinitions(op.PathParams).FindByName(argument.Name) == nil {
+			return LinkArgument{}, fmt.Errorf("maps the path parameter %s, which %s doesn't have", argument.Name, op.OperationId)
+		}
+		argument.Pattern = capturingPathPattern(op, op.RequestPath(), []string{argument.Name})
+	default:
+		return LinkArgument{}, fmt.Errorf("maps %s, which isn't a supported runtime expression", expression)
+	}
+	return argument, nil
+}
+
+// linkArgumentFor returns whether one of the arguments maps a parameter.
+func linkArgumentFor(arguments []LinkArgument, param ParameterDefinition) bool {
+	for _, argument := range arguments {
+		if argument.Param.ParamName == param.ParamName && argument.Param.In == param.In {
+			return true
+		}
+	}
+	return false
+}
+
+// linkParamIndex returns the index of a parameter among the path parameters.
+func linkParamIndex(params []ParameterDefinition, param ParameterDefinition) int {
+	for i, p := range params {
+		if p.ParamName == param.ParamName {
+			return i
+		}
+	}
+	return len(params)
+}
+
+// linksFromBody returns whether any of the links of an operation maps a value
+// from the body of its response.
+func linksFromBody(op *OperationDefinition) bool {
+	for _, link := range op.Links {
+		for _, argument := range append(link.PathArgs[:len(link.PathArgs):len(link.PathArgs)], link.Params...) {
+			if argument.Source == "$response.body" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasLinks returns whether any of the operations has links to follow.
+func hasLinks(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		if len(op.Links) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// Lint checks the spec for constructs which the generator can't handle well,
+// and returns a warning locating each of them with a JSON pointer:
+//   - operations without an operationId, which get one derived from their
+//     method and path
+//   - operationIds which clash once turned into Go identifiers
+//   - anonymous inline schemas, which end up as anonymous structs or get types
+//     named after their location in the spec
+//   - request and response content types no types are generated for
+//   - oneOf schemas without a discriminator, which can't be told apart when
+//     unmarshaling
+func Lint(spec *openapi3.T) Diagnostics {
+	l := linter{operationIDs: make(map[string]string)}
+
+	if spec.Components != nil {
+		for _, name := range SortedSchemaKeys(spec.Components.Schemas) {
+			l.lintSchema("", spec.Components.Schemas[name], true, "components", "schemas", name)
+		}
+		for _, name := range SortedParameterKeys(spec.Components.Parameters) {
+			l.lintParameter("", spec.Components.Parameters[name], "components", "parameters", name)
+		}
+		for _, name := range SortedRequestBodyKeys(spec.Components.RequestBodies) {
+			l.lintRequestBody("", spec.Components.RequestBodies[name], "components", "requestBodies", name)
+		}
+		for _, name := range SortedResponsesKeys(spec.Components.Responses) {
+			l.lintResponse("", spec.Components.Responses[name], "components", "responses", name)
+		}
+	}
+
+	for _, requestPath := range SortedPathsKeys(spec.Paths) {
+		pathItem := spec.Paths[requestPath]
+		for i, param := range pathItem.Parameters {
+			l.lintParameter("", param, "paths", requestPath, "parameters", strconv.Itoa(i))
+		}
+		pathOps := pathItem.Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			l.lintOperation(requestPath, opName, pathOps[opName])
+		}
+	}
+
+	return l.diagnostics
+}
+
+// linter accumulates the warnings found by Lint.
+type linter struct {
+	diagnostics Diagnostics
+	// The location of the first operation using each operation ID, as it is
+	// turned into a Go identifier.
+	operationIDs map[string]string
+}
+
+func (l *linter) warn(operationID string, pointer []string, format string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		OperationID: operationID,
+		Path:        jsonPointer(pointer...),
+		Reason:      fmt.Sprintf(format, args...),
+		Warning:     true,
+	})
+}
+
+func (l *linter) lintOperation(requestPath, opName string, op *openapi3.Operation) {
+	pointer := []string{"paths", requestPath, strings.ToLower(opName)}
+
+	operationID := ToCamelCase(op.OperationID)
+	if op.OperationID == "" {
+		operationID, _ = generateDefaultOperationID(opName, requestPath, ToCamelCase)
+		l.warn("", pointer, "operation has no operationId, so it will be named %s; set one to choose its name", operationID)
+	}
+	if first, ok := l.operationIDs[operationID]; ok {
+		l.warn(operationID, pointer, "operationId clashes with the one of %s, so the generated code won't compile", first)
+	} else {
+		l.operationIDs[operationID] = jsonPointer(pointer...)
+	}
+
+	for i, param := range op.Parameters {
+		l.lintParameter(operationID, param, append(pointer, "parameters", strconv.Itoa(i))...)
+	}
+	l.lintRequestBody(operationID, op.RequestBody, append(pointer, "requestBody")...)
+	for _, responseName := range SortedResponsesKeys(op.Responses) {
+		l.lintResponse(operationID, op.Responses[responseName], append(pointer, "responses", responseName)...)
+	}
+}
+
+func (l *linter) lintParameter(operationID string, paramOrRef *openapi3.ParameterRef, pointer ...string) {
+	if paramOrRef == nil || paramOrRef.Ref != "" || paramOrRef.Value == nil {
+		return
+	}
+	param := paramOrRef.Value
+	l.lintSchema(operationID, param.Schema, false, append(pointer, "schema")...)
+	for _, contentType := range SortedContentKeys(param.Content) {
+		l.lintSchema(operationID, param.Content[contentType].Schema, false, append(pointer, "content", contentType, "schema")...)
+	}
+}
+
+func (l *linter) lintRequestBody(operationID string, bodyOrRef *openapi3.RequestBodyRef, pointer ...string) {
+	if bodyOrRef == nil || bodyOrRef.Ref != "" || bodyOrRef.Value == nil {
+		return
+	}
+	l.lintContent(operationID, bodyOrRef.Value.Content, true, pointer)
+}
+
+func (l *linter) lintResponse(operationID string, responseOrRef *openapi3.ResponseRef, pointer ...string) {
+	if responseOrRef == nil || responseOrRef.Ref != "" || responseOrRef.Value == nil {
+		return
+	}
+	l.lintContent(operationID, responseOrRef.Value.Content, false, pointer)
+}
+
+// lintContent checks the media types of a request body or response. The
+// schemas of request bodies get types named after their operation, unlike
+// those of responses.
+func (l *linter) lintContent(operationID string, content openapi3.Content, named bool, pointer []string) {
+	for _, contentType := range SortedContentKeys(content) {
+		contentPointer := append(pointer, "content", contentType)
+		if !isTypedContentType(contentType) {
+			l.warn(operationID, contentPointer, "no types are generated for content type %q, so its bodies are passed as raw data", contentType)
+			continue
+		}
+		l.lintSchema(operationID, content[contentType].Schema, named, append(contentPointer, "schema")...)
+	}
+}
+
+// lintSchema checks a schema, and the inline schemas it's made of. Schemas
+// declared with references are checked where they're declared instead. named
+// tells whether the generator gives the schema a type named after what
+// declares it, like component schemas.
+func (l *linter) lintSchema(operationID string, schemaOrRef *openapi3.SchemaRef, named bool, pointer ...string) {
+	if schemaOrRef == nil || schemaOrRef.Ref != "" || schemaOrRef.Value == nil {
+		return
+	}
+	schema := schemaOrRef.Value
+
+	if !named && isAnonymousSchema(schema) {
+		l.warn(operationID, pointer, "inline schema gets an anonymous struct or a type named after its location; declare it under #/components/schemas to name it")
+	}
+	if len(schema.OneOf) > 1 && schema.Discriminator == nil {
+		l.warn(operationID, pointer, "oneOf has no discriminator, so the variant of a value can't be determined when unmarshaling it")
+	}
+
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		l.lintSchema(operationID, schema.Properties[name], false, append(pointer, "properties", name)...)
+	}
+	l.lintSchema(operationID, schema.Items, false, append(pointer, "items")...)
+	l.lintSchema(operationID, schema.AdditionalProperties.Schema, false, append(pointer, "additionalProperties")...)
+	// The members of allOf are merged into the schema, rather than getting
+	// types of their own.
+	for i, member := range schema.AllOf {
+		l.lintSchema(operationID, member, true, append(pointer, "allOf", strconv.Itoa(i))...)
+	}
+	for i, member := range schema.OneOf {
+		l.lintSchema(operationID, member, false, append(pointer, "oneOf", strconv.Itoa(i))...)
+	}
+	for i, member := range schema.AnyOf {
+		l.lintSchema(operationID, member, false, append(pointer, "anyOf", strconv.Itoa(i))...)
+	}
+}
+
+// isAnonymousSchema tells whether the generator needs a type of its own for a
+// schema, which it can't name well unless the schema is declared as a
+// component.
+func isAnonymousSchema(schema *openapi3.Schema) bool {
+	return len(schema.Properties) != 0 ||
+		len(schema.OneOf) != 0 ||
+		len(schema.AnyOf) != 0 ||
+		schema.AdditionalProperties.Schema != nil ||
+		(schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has)
+}
+
+// isTypedContentType tells whether types are generated for the bodies of the
+// given content type, as in GenerateBodyDefinitions.
+func isTypedContentType(contentType string) bool {
+	return util.IsMediaTypeJson(contentType) ||
+		strings.HasPrefix(contentType, "multipart/") ||
+		contentType == "application/x-www-form-urlencoded" ||
+		contentType == "text/plain"
+}
+
+// jsonPointer returns the JSON pointer made of the given reference tokens, as
+// defined by RFC 6901.
+func jsonPointer(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestLint(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/lint.yaml")
+	require.NoError(t, err)
+
+	diagnostics := Lint(swagger)
+	assert.Empty(t, diagnostics.Errors())
+
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Kind: oneOf has no discriminator, so the variant of a value can't be determined when unmarshaling it",
+		"warning: /components/schemas/Pet/properties/owner: inline schema gets an anonymous struct or a type named after its location; declare it under #/components/schemas to name it",
+		"warning: operation ListPets: /paths/~1pets/get/responses/200/content/application~1json/schema: inline schema gets an anonymous struct or a type named after its location; declare it under #/components/schemas to name it",
+		"warning: /paths/~1pets/post: operation has no operationId, so it will be named PostPets; set one to choose its name",
+		`warning: operation PostPets: /paths/~1pets/post/requestBody/content/application~1octet-stream: no types are generated for content type "application/octet-stream", so its bodies are passed as raw data`,
+		"warning: operation ListPets: /paths/~1pets~1list/get: operationId clashes with the one of /paths/~1pets/get, so the generated code won't compile",
+	}, got)
+}
+
+func TestJsonPointer(t *testing.T) {
+	assert.Equal(t, "", jsonPointer())
+	assert.Equal(t, "/paths/~1pets~1{id}/get", jsonPointer("paths", "/pets/{id}", "get"))
+	assert.Equal(t, "/components/schemas/a~0b", jsonPointer("components", "schemas", "a~b"))
+}
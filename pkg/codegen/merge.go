@@ -0,0 +1,312 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// MergeSource is one of the specs merged into a single one by Merge.
+type MergeSource struct {
+	Name   string      // The name of the spec in errors, like the path of its file
+	Spec   *openapi3.T // The spec, which Merge modifies
+	Prefix string      // The prefix of the names of its components and of its operation IDs, if any
+}
+
+// Merge merges the paths and components of several specs into one, so that
+// they're generated as a single package, like for a gateway aggregating
+// several APIs. Each spec is bundled first, and, when it has a prefix, the
+// names of its components and its operation IDs are prefixed with it, like
+// UsersPet for the Pet schema of a spec prefixed with Users.
+//
+// The paths and operation IDs of the specs must be distinct, and so must be
+// their components, unless they are identical, in which case they're merged
+// into one. The merged spec has the info and servers of the first spec, and
+// the operations of each spec keep its security requirements.
+func Merge(sources []MergeSource) (*openapi3.T, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no spec to merge")
+	}
+	first := sources[0].Spec
+	merged := &openapi3.T{
+		Extensions:   first.Extensions,
+		OpenAPI:      first.OpenAPI,
+		Components:   &openapi3.Components{},
+		Info:         first.Info,
+		Paths:        openapi3.Paths{},
+		Servers:      first.Servers,
+		ExternalDocs: first.ExternalDocs,
+	}
+	m := merger{
+		spec:       merged,
+		paths:      make(map[string]string),
+		operations: make(map[string]string),
+		components: make(map[string]mergedComponent),
+	}
+	for _, source := range sources {
+		Bundle(source.Spec)
+		moveSecurityToOperations(source.Spec)
+		if source.Prefix != "" {
+			prefixSpec(source.Spec, source.Prefix)
+		}
+		if err := m.merge(source); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// merger tracks where the paths, operations and components of the merged spec
+// come from.
+type merger struct {
+	spec       *openapi3.T
+	paths      map[string]string          // The name of the spec of each path
+	operations map[string]string          // The name of the spec of each operation ID
+	components map[string]mergedComponent // The components by kind and name, like schemas/Pet
+}
+
+// mergedComponent is a component of the merged spec, and the spec it comes
+// from.
+type mergedComponent struct {
+	source string
+	value  interface{}
+}
+
+func (m *merger) merge(source MergeSource) error {
+	spec := source.Spec
+	for _, requestPath := range SortedPathsKeys(spec.Paths) {
+		if previous, ok := m.paths[requestPath]; ok {
+			return fmt.Errorf("the path %s of %s is in %s already", requestPath, source.Name, previous)
+		}
+		m.paths[requestPath] = source.Name
+		pathItem := spec.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			opID := pathOps[opName].OperationID
+			if opID == "" {
+				continue
+			}
+			if previous, ok := m.operations[opID]; ok {
+				return fmt.Errorf("the operation %s of %s is in %s already, so prefix the operations of one of them", opID, source.Name, previous)
+			}
+			m.operations[opID] = source.Name
+		}
+		m.spec.Paths[requestPath] = pathItem
+	}
+
+	if spec.Components != nil {
+		maps := componentMaps(spec.Components)
+		mergedMaps := componentMaps(m.spec.Components)
+		for _, kind := range sortedComponentKinds(maps) {
+			from, to := maps[kind], mergedMaps[kind]
+			for _, name := range sortedMapKeys(from) {
+				value := from.MapIndex(reflect.ValueOf(name))
+				key := kind + "/" + name
+				if previous, ok := m.components[key]; ok {
+					if !sameComponent(previous.value, value.Interface()) {
+						return fmt.Errorf("the component %s of %s differs from the one of %s, so prefix the components of one of them", key, source.Name, previous.source)
+					}
+					continue
+				}
+				m.components[key] = mergedComponent{source: source.Name, value: value.Interface()}
+				if to.IsNil() {
+					to.Set(reflect.MakeMap(to.Type()))
+				}
+				to.SetMapIndex(reflect.ValueOf(name), value)
+			}
+		}
+	}
+
+	for _, tag := range spec.Tags {
+		if m.spec.Tags.Get(tag.Name) == nil {
+			m.spec.Tags = append(m.spec.Tags, tag)
+		}
+	}
+	return nil
+}
+
+// moveSecurityToOperations sets the security requirements of a spec on its
+// operations which don't have their own, since the merged spec has those of
+// none.
+func moveSecurityToOperations(spec *openapi3.T) {
+	if spec.Security == nil {
+		return
+	}
+	for _, pathItem := range spec.Paths {
+		for _, op := range pathItem.Operations() {
+			if op.Security == nil {
+				security := append(openapi3.SecurityRequirements{}, spec.Security...)
+				op.Security = &security
+			}
+		}
+	}
+	spec.Security = nil
+}
+
+// prefixSpec prefixes the names of the components of a spec, and its
+// operation IDs, and points the references of the spec at the renamed
+// components.
+func prefixSpec(spec *openapi3.T, prefix string) {
+	prefixed := func(name string) string {
+		return prefix + UppercaseFirstCharacter(name)
+	}
+
+	if spec.Components != nil {
+		for _, components := range componentMaps(spec.Components) {
+			if components.IsNil() {
+				continue
+			}
+			renamed := reflect.MakeMap(components.Type())
+			iter := components.MapRange()
+			for iter.Next() {
+				renamed.SetMapIndex(reflect.ValueOf(prefixed(iter.Key().String())), iter.Value())
+			}
+			components.Set(renamed)
+		}
+	}
+
+	prefixRef := func(ref *string) {
+		pointer, ok := strings.CutPrefix(*ref, "#/components/")
+		if !ok {
+			return
+		}
+		// The pointer is the kind of the component, its name, and possibly
+		// a pointer into it.
+		tokens := strings.SplitN(pointer, "/", 3)
+		if len(tokens) < 2 {
+			return
+		}
+		tokens[1] = prefixed(tokens[1])
+		*ref = "#/components/" + strings.Join(tokens, "/")
+	}
+	_ = walkSwagger(spec, func(ref RefWrapper) (bool, error) {
+		switch v := ref.SourceRef.(type) {
+		case *openapi3.SchemaRef:
+			prefixRef(&v.Ref)
+			if v.Ref == "" && v.Value != nil && v.Value.Discriminator != nil {
+				addImplicitMapping(v.Value)
+				for value, target := range v.Value.Discriminator.Mapping {
+					if strings.HasPrefix(target, "#") {
+						prefixRef(&target)
+					} else {
+						target = prefixed(target)
+					}
+					v.Value.Discriminator.Mapping[value] = target
+				}
+			}
+		case *openapi3.ParameterRef:
+			prefixRef(&v.Ref)
+		case *openapi3.RequestBodyRef:
+			prefixRef(&v.Ref)
+		case *openapi3.ResponseRef:
+			prefixRef(&v.Ref)
+		case *openapi3.HeaderRef:
+			prefixRef(&v.Ref)
+		case *openapi3.SecuritySchemeRef:
+			prefixRef(&v.Ref)
+		case *openapi3.ExampleRef:
+			prefixRef(&v.Ref)
+		case *openapi3.CallbackRef:
+			prefixRef(&v.Ref)
+		case *openapi3.LinkRef:
+			prefixRef(&v.Ref)
+			if v.Ref == "" && v.Value != nil && v.Value.OperationID != "" {
+				v.Value.OperationID = prefixed(v.Value.OperationID)
+			}
+		}
+		return ref.Ref == "", nil
+	})
+
+	for _, pathItem := range spec.Paths {
+		for _, op := range pathItem.Operations() {
+			if op.OperationID != "" {
+				op.OperationID = prefixed(op.OperationID)
+			}
+			if op.Security != nil {
+				for i, requirement := range *op.Security {
+					(*op.Security)[i] = prefixSecurityRequirement(requirement, prefixed)
+				}
+			}
+		}
+	}
+}
+
+// addImplicitMapping adds the values which the discriminator of a schema maps
+// implicitly, the names of the component schemas of its oneOf and anyOf, to its
+// mapping, so that they keep their values once the components are renamed.
+func addImplicitMapping(schema *openapi3.Schema) {
+	mapped := make(map[string]bool, len(schema.Discriminator.Mapping))
+	for _, target := range schema.Discriminator.Mapping {
+		mapped[target] = true
+	}
+	for _, element := range append(append(openapi3.SchemaRefs{}, schema.OneOf...), schema.AnyOf...) {
+		name, ok := strings.CutPrefix(element.Ref, "#/components/schemas/")
+		if !ok || strings.Contains(name, "/") || mapped[element.Ref] || mapped[name] {
+			continue
+		}
+		if schema.Discriminator.Mapping == nil {
+			schema.Discriminator.Mapping = make(map[string]string)
+		}
+		if _, ok := schema.Discriminator.Mapping[name]; !ok {
+			schema.Discriminator.Mapping[name] = element.Ref
+			mapped[element.Ref] = true
+		}
+	}
+}
+
+// prefixSecurityRequirement returns a security requirement naming the
+// prefixed security schemes.
+func prefixSecurityRequirement(requirement openapi3.SecurityRequirement, prefixed func(string) string) openapi3.SecurityRequirement {
+	result := make(openapi3.SecurityRequirement, len(requirement))
+	for name, scopes := range requirement {
+		result[prefixed(name)] = scopes
+	}
+	return result
+}
+
+// componentMaps returns the settable maps of the components of a spec by
+// kind, like schemas, which are all keyed by name.
+func componentMaps(components *openapi3.Components) map[string]reflect.Value {
+	maps := make(map[string]reflect.Value)
+	value := reflect.ValueOf(components).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		kind, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if kind == "" || kind == "-" || field.Type.Kind() != reflect.Map || field.Type.Key().Kind() != reflect.String {
+			continue
+		}
+		maps[kind] = value.Field(i)
+	}
+	return maps
+}
+
+func sortedComponentKinds(maps map[string]reflect.Value) []string {
+	kinds := make([]string, 0, len(maps))
+	for kind := range maps {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func sortedMapKeys(m reflect.Value) []string {
+	keys := make([]string, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sameComponent returns whether two components are identical, which is when
+// they have the same JSON.
+func sameComponent(a, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/deepmap/oapi-codegen/pkg/util"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -42,6 +43,9 @@ func mergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 			return Schema{}, fmt.Errorf("error merging schemas for AllOf: %w", err)
 		}
 	}
+	if globalState.options.OutputOptions.FieldOrder == FieldOrderSpec {
+		util.SetPropertyOrder(&schema, allOfPropertyOrder(allOf))
+	}
 	return GenerateGoSchema(openapi3.NewSchemaRef("", &schema), path)
 }
 
@@ -58,13 +62,16 @@ func valueWithPropagatedRef(ref *openapi3.SchemaRef) (openapi3.Schema, error) {
 	}
 	remoteComponent := pathParts[0]
 
-	// remote ref
+	// remote ref, whose properties are copied rather than updated in place,
+	// since the spec is shared by the schemas translated concurrently
 	schema := *ref.Value
-	for _, value := range schema.Properties {
+	schema.Properties = make(openapi3.Schemas, len(ref.Value.Properties))
+	for name, value := range ref.Value.Properties {
 		if len(value.Ref) > 0 && value.Ref[0] == '#' {
 			// local reference, should propagate remote
-			value.Ref = remoteComponent + value.Ref
+			value = openapi3.NewSchemaRef(remoteComponent+value.Ref, value.Value)
 		}
+		schema.Properties[name] = value
 	}
 
 	return schema, nil
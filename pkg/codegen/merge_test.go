@@ -0,0 +1,121 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func loadMergeSources(t *testing.T, prefixes ...string) []MergeSource {
+	var sources []MergeSource
+	for i, name := range []string{"users.yaml", "billing.yaml"} {
+		spec, err := util.LoadSwagger("test_specs/merge/" + name)
+		require.NoError(t, err)
+		source := MergeSource{Name: name, Spec: spec}
+		if i < len(prefixes) {
+			source.Prefix = prefixes[i]
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+func TestMerge(t *testing.T) {
+	merged, err := Merge(loadMergeSources(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Users", merged.Info.Title)
+	assert.Len(t, merged.Paths, 3)
+	assert.Len(t, merged.Components.Schemas, 7)
+	assert.Len(t, merged.Components.Responses, 1)
+	// The security of the users spec stays on its operations only
+	assert.Nil(t, merged.Security)
+	assert.Equal(t, &openapi3.SecurityRequirements{{"ApiKey": {}}}, merged.Paths["/users/{id}"].Get.Security)
+	assert.Nil(t, merged.Paths["/invoices/{id}"].Get.Security)
+
+	code, err := Generate(merged, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, "type Invoice struct {")
+	assert.Contains(t, code, "type User struct {")
+	assert.Contains(t, code, "GetUser(w http.ResponseWriter, r *http.Request, id string)")
+	assert.Contains(t, code, "GetInvoice(w http.ResponseWriter, r *http.Request, id string)")
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestMergePrefixes(t *testing.T) {
+	merged, err := Merge(loadMergeSources(t, "Users", "Billing"))
+	require.NoError(t, err)
+
+	components := merged.Components
+	assert.Contains(t, components.Schemas, "UsersUser")
+	assert.Contains(t, components.Schemas, "UsersError")
+	assert.Contains(t, components.Schemas, "BillingError")
+	assert.Contains(t, components.SecuritySchemes, "UsersApiKey")
+	assert.Equal(t, "#/components/schemas/BillingPayment", components.Schemas["BillingInvoice"].Value.Properties["payment"].Ref)
+	assert.Equal(t, map[string]string{
+		"card":     "#/components/schemas/BillingCard",
+		"transfer": "BillingTransfer",
+	}, components.Schemas["BillingPayment"].Value.Discriminator.Mapping)
+	// The values mapped implicitly, the names of the schemas, are kept
+	assert.Equal(t, map[string]string{
+		"Card":     "#/components/schemas/BillingCard",
+		"Transfer": "#/components/schemas/BillingTransfer",
+	}, components.Schemas["BillingRefund"].Value.Discriminator.Mapping)
+
+	op := merged.Paths["/users"].Post
+	assert.Equal(t, "UsersCreateUser", op.OperationID)
+	assert.Equal(t, "#/components/responses/UsersError", op.Responses["default"].Ref)
+	assert.Equal(t, "UsersGetUser", op.Responses["201"].Value.Links["GetUser"].Value.OperationID)
+	assert.Equal(t, &openapi3.SecurityRequirements{{"UsersApiKey": {}}}, op.Security)
+
+	code, err := Generate(merged, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, "type BillingInvoice struct {")
+	assert.Contains(t, code, "Payment BillingPayment `json:\"payment\"`")
+	assert.Contains(t, code, `case "Card":`)
+	assert.NotContains(t, code, `case "BillingCard":`)
+	assert.Contains(t, code, "UsersGetUser(w http.ResponseWriter, r *http.Request, id string)")
+	assert.Contains(t, code, "UsersApiKeyScopes = \"UsersApiKey.Scopes\"")
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestMergeCollisions(t *testing.T) {
+	users, err := util.LoadSwagger("test_specs/merge/users.yaml")
+	require.NoError(t, err)
+	again, err := util.LoadSwagger("test_specs/merge/users.yaml")
+	require.NoError(t, err)
+	_, err = Merge([]MergeSource{{Name: "users.yaml", Spec: users}, {Name: "more-users.yaml", Spec: again}})
+	assert.EqualError(t, err, "the path /users of more-users.yaml is in users.yaml already")
+
+	// Prefixing the components isn't enough for the operations of the
+	// same paths
+	again.Paths = openapi3.Paths{"/v2/users/{id}": again.Paths["/users/{id}"]}
+	_, err = Merge([]MergeSource{{Name: "users.yaml", Spec: users}, {Name: "more-users.yaml", Spec: again}})
+	assert.EqualError(t, err, "the operation getUser of more-users.yaml is in users.yaml already, so prefix the operations of one of them")
+
+	sources := loadMergeSources(t)
+	sources[1].Spec.Components.Schemas["Error"].Value.Properties["code"] = openapi3.NewIntegerSchema().NewRef()
+	_, err = Merge(sources)
+	assert.EqualError(t, err, "the component schemas/Error of billing.yaml differs from the one of users.yaml, so prefix the components of one of them")
+
+	_, err = Merge(nil)
+	assert.Error(t, err)
+}
@@ -89,25 +89,29 @@ func (pd *ParameterDefinition) Style() string {
 		switch in {
 		case "path", "header":
 			return "simple"
-		case "query", "cookie":
-			return "form"
 		default:
-			panic("unknown parameter format")
+			// DescribeParameters only accepts query and cookie parameters otherwise
+			return "form"
 		}
 	}
 	return style
 }
 
 func (pd *ParameterDefinition) Explode() bool {
+	// Exploding doesn't change how primitives are serialized, but the runtime
+	// only binds exploded values of types implementing runtime.Binder, which
+	// the civil dates and times, and the netip addresses, don't.
+	if strings.HasPrefix(pd.Schema.GoType, "civil.") || strings.HasPrefix(pd.Schema.GoType, "netip.") {
+		return false
+	}
 	if pd.Spec.Explode == nil {
 		in := pd.Spec.In
 		switch in {
 		case "path", "header":
 			return false
-		case "query", "cookie":
-			return true
 		default:
-			panic("unknown parameter format")
+			// DescribeParameters only accepts query and cookie parameters otherwise
+			return true
 		}
 	}
 	return *pd.Spec.Explode
@@ -134,6 +138,107 @@ func (pd ParameterDefinition) GoName() string {
 	return SchemaNameToTypeName(goName)
 }
 
+// IsArray returns whether the parameter holds an array, whose values a header
+// may carry over several lines.
+func (pd ParameterDefinition) IsArray() bool {
+	return pd.Spec.Schema != nil && pd.Spec.Schema.Value != nil && pd.Spec.Schema.Value.Type == "array"
+}
+
+// IsObject returns whether the parameter holds an object, declared as such or
+// by its properties.
+func (pd ParameterDefinition) IsObject() bool {
+	if pd.Spec.Schema == nil || pd.Spec.Schema.Value == nil {
+		return false
+	}
+	schema := pd.Spec.Schema.Value
+	return schema.Type == "object" || (schema.Type == "" && len(schema.Properties) > 0)
+}
+
+// UnquotedProperties returns the properties of the object parameter which are
+// numbers or booleans, whose values aren't quoted in JSON.
+func (pd ParameterDefinition) UnquotedProperties() []string {
+	var result []string
+	for _, name := range SortedSchemaKeys(pd.Spec.Schema.Value.Properties) {
+		switch pd.Spec.Schema.Value.Properties[name].Value.Type {
+		case "integer", "number", "boolean":
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// ExpectedType describes the type of the parameter for its ParamError, with
+// the param-errors output option: the type and format of its schema, or the
+// JSON content it holds.
+func (pd ParameterDefinition) ExpectedType() string {
+	if pd.Spec.Schema != nil {
+		return describeSchemaType(pd.Spec.Schema.Value)
+	}
+	if pd.IsJson() {
+		for _, content := range pd.Spec.Content {
+			if content.Schema != nil {
+				return "JSON " + describeSchemaType(content.Schema.Value)
+			}
+		}
+		return "JSON"
+	}
+	return "string"
+}
+
+// describeSchemaType describes the type of a schema in words, like "integer
+// (int32)", "array of string" or "string, one of a, b".
+func describeSchemaType(schema *openapi3.Schema) string {
+	if schema == nil {
+		return "any"
+	}
+	description := schema.Type
+	switch {
+	case schema.Type == "array" && schema.Items != nil:
+		return "array of " + describeSchemaType(schema.Items.Value)
+	case schema.Type == "" && len(schema.Properties) > 0:
+		description = "object"
+	case schema.Type == "":
+		description = "any"
+	}
+	if schema.Format != "" {
+		description += " (" + schema.Format + ")"
+	}
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprint(value)
+		}
+		description += ", one of " + strings.Join(values, ", ")
+	}
+	return description
+}
+
+// IsOptionalParam returns whether the parameter is held by an OptionalParam,
+// telling apart the parameter left out, sent without a value, and sent with
+// an empty one, with the optional-params output option. These are the query
+// parameters allowing empty values, and the nullable query and header
+// parameters, besides objects.
+func (pd ParameterDefinition) IsOptionalParam() bool {
+	if !globalState.options.OutputOptions.OptionalParams || pd.Spec.Schema == nil || pd.Spec.Schema.Value == nil || pd.IsObject() {
+		return false
+	}
+	switch pd.In {
+	case "query":
+		return pd.Spec.AllowEmptyValue || pd.Spec.Schema.Value.Nullable
+	case "header":
+		return pd.Spec.Schema.Value.Nullable
+	}
+	return false
+}
+
+// IsRepeatedHeader returns whether the client sends the values of the array
+// header parameter on a line each, as declared by x-header-style, rather than
+// on a single line separated by commas.
+func (pd ParameterDefinition) IsRepeatedHeader() bool {
+	style, _ := extParseHeaderStyle(pd.Spec.Extensions[extHeaderStyle])
+	return style == headerStyleRepeated
+}
+
 func (pd ParameterDefinition) IndirectOptional() bool {
 	return !pd.Required && !pd.Schema.SkipOptionalPointer
 }
@@ -157,6 +262,12 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 	for _, paramOrRef := range params {
 		param := paramOrRef.Value
 
+		switch param.In {
+		case "path", "query", "header", "cookie":
+		default:
+			return nil, fmt.Errorf("parameter (%s) has unknown location %q", param.Name, param.In)
+		}
+
 		goType, err := paramToGoType(param, append(path, param.Name))
 		if err != nil {
 			return nil, fmt.Errorf("error generating type for param (%s): %s",
@@ -171,6 +282,16 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 			Schema:    goType,
 		}
 
+		if ext, ok := param.Extensions[extHeaderStyle]; ok {
+			style, err := extParseHeaderStyle(ext)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q on param (%s): %w", extHeaderStyle, param.Name, err)
+			}
+			if style == headerStyleRepeated && (param.In != "header" || !pd.IsArray()) {
+				return nil, fmt.Errorf("param (%s) has %q %q, but only array header params can be repeated", param.Name, extHeaderStyle, style)
+			}
+		}
+
 		// If this is a reference to a predefined type, simply use the reference
 		// name as the type. $ref: "#/components/schemas/custom_type" becomes
 		// "CustomType".
@@ -205,23 +326,243 @@ func DescribeSecurityDefinition(securityRequirements openapi3.SecurityRequiremen
 	return outDefs
 }
 
+// RateLimitDefinition describes a client-side rate limit declared via the
+// x-ratelimit extension.
+type RateLimitDefinition struct {
+	Key               string  // The limiter to draw from; op: and the operation ID, or tag: and the tag name for limits set on a tag
+	RequestsPerSecond float64 // The sustained request rate
+	Burst             int     // The maximum number of requests allowed at once
+}
+
+// describeRateLimit resolves the rate limit of an operation. A limit declared
+// on the operation wins over one declared on any of its tags, and operations
+// limited through a tag share a single limiter keyed by the tag name. The keys
+// are prefixed with op: or tag:, so that an operation and a tag of the same
+// name have limiters of their own.
+func describeRateLimit(operationID string, op *openapi3.Operation, tags openapi3.Tags) (*RateLimitDefinition, error) {
+	if ext, ok := op.Extensions[extRateLimit]; ok {
+		rateLimit, err := extParseRateLimit(ext)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", extRateLimit, err)
+		}
+		rateLimit.Key = "op:" + operationID
+		return rateLimit, nil
+	}
+	for _, tagName := range op.Tags {
+		tag := tags.Get(tagName)
+		if tag == nil {
+			continue
+		}
+		if ext, ok := tag.Extensions[extRateLimit]; ok {
+			rateLimit, err := extParseRateLimit(ext)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q on tag %s: %w", extRateLimit, tagName, err)
+			}
+			rateLimit.Key = "tag:" + tagName
+			return rateLimit, nil
+		}
+	}
+	return nil, nil
+}
+
+// LongRunningDefinition describes how the completion of a long-running
+// operation, which answers 202 Accepted with the URL of its status, is waited
+// for, as declared via the x-long-running extension.
+type LongRunningDefinition struct {
+	PollOperationId string   // The operation polled at the status URL, which answers with the status
+	LocationHeader  string   // The header of the 202 response holding the status URL, Location by default
+	StatusProperty  string   // The property of the poll response holding the status, status by default
+	TerminalStates  []string // The statuses ending the polling
+
+	PollResponseField string // The field of the poll response type holding its JSON 200 response, like JSON200
+	StatusField       string // The Go field of the status in the JSON 200 response
+	StatusPointer     bool   // Whether that field is a pointer
+}
+
+// TerminalStatesList returns the terminal states as a list for comments, like
+// succeeded or failed.
+func (l LongRunningDefinition) TerminalStatesList() string {
+	if len(l.TerminalStates) == 1 {
+		return l.TerminalStates[0]
+	}
+	return strings.Join(l.TerminalStates[:len(l.TerminalStates)-1], ", ") + " or " + l.TerminalStates[len(l.TerminalStates)-1]
+}
+
+// resolveLongRunning resolves the poll operations of the long-running
+// operations, along with the fields of their responses holding the status,
+// and marks them as polled.
+func resolveLongRunning(ops []OperationDefinition, toCamelCaseFunc func(string) string) error {
+	byID := make(map[string]*OperationDefinition, len(ops))
+	for i := range ops {
+		byID[ops[i].OperationId] = &ops[i]
+	}
+	for i := range ops {
+		op := &ops[i]
+		longRunning := op.LongRunning
+		if longRunning == nil {
+			continue
+		}
+		if op.Spec.Responses["202"] == nil {
+			return fmt.Errorf("%s is declared long-running with %q, but has no 202 response", op.OperationId, extLongRunning)
+		}
+		pollID := toCamelCaseFunc(longRunning.PollOperationId)
+		pollID = typeNamePrefix(pollID) + pollID
+		poll := byID[pollID]
+		if poll == nil {
+			return fmt.Errorf("the operation %s polled for %s is missing", longRunning.PollOperationId, op.OperationId)
+		}
+		longRunning.PollOperationId = poll.OperationId
+		if poll.HasBody() {
+			return fmt.Errorf("the operation %s polled for %s must not have a body", poll.OperationId, op.OperationId)
+		}
+
+		tds, err := poll.GetResponseTypeDefinitions()
+		if err != nil {
+			return err
+		}
+		for _, td := range tds {
+			if td.ResponseName != "200" || !util.IsMediaTypeJson(td.ContentTypeName) {
+				continue
+			}
+			// The properties of referenced schemas are those of their
+			// declaration.
+			schema := poll.Spec.Responses["200"].Value.Content[td.ContentTypeName].Schema.Value
+			goSchema, err := GenerateGoSchema(openapi3.NewSchemaRef("", schema), []string{poll.OperationId, "200"})
+			if err != nil {
+				return err
+			}
+			for _, p := range goSchema.Properties {
+				if p.JsonFieldName != longRunning.StatusProperty {
+					continue
+				}
+				if p.Schema.OAPISchema == nil || p.Schema.OAPISchema.Type != "string" {
+					return fmt.Errorf("the status property %s of the response of %s polled for %s must be a string", p.JsonFieldName, poll.OperationId, op.OperationId)
+				}
+				longRunning.PollResponseField = td.TypeName
+				longRunning.StatusField = p.structFieldName()
+				longRunning.StatusPointer = strings.HasPrefix(p.structFieldType(), "*")
+			}
+			break
+		}
+		if longRunning.PollResponseField == "" {
+			return fmt.Errorf("the operation %s polled for %s has no JSON 200 response with the status property %s", poll.OperationId, op.OperationId, longRunning.StatusProperty)
+		}
+		poll.Polled = true
+	}
+	return nil
+}
+
+// resolveCacheable marks the operations listed by the client-cache option as
+// cacheable, which must be GET operations.
+func resolveCacheable(ops []OperationDefinition, toCamelCaseFunc func(string) string) error {
+	cache := globalState.options.OutputOptions.ClientCache
+	if cache == nil {
+		return nil
+	}
+	byID := make(map[string]*OperationDefinition, len(ops))
+	for i := range ops {
+		byID[ops[i].OperationId] = &ops[i]
+	}
+	for _, id := range cache.Operations {
+		goID := toCamelCaseFunc(id)
+		goID = typeNamePrefix(goID) + goID
+		op := byID[goID]
+		if op == nil {
+			return fmt.Errorf("the operation %s of the client-cache option is missing", id)
+		}
+		if op.Method != "GET" {
+			return fmt.Errorf("the operation %s of the client-cache option must be a GET operation", id)
+		}
+		op.Cacheable = true
+	}
+	return nil
+}
+
+// BatchDefinition describes an operation sending the requests of other
+// operations at once, in a JSON envelope of the form
+// {"requests": [{"id", "method", "url", "headers", "body"}]}, answered with
+// {"responses": [{"id", "status", "headers", "body"}]}.
+type BatchDefinition struct {
+	OperationIds []string // The IDs of the operations which can be batched
+	MaxRequests  int      // How many requests a batch holds at most, or 0 for any number
+
+	Operations []OperationDefinition // The operations which can be batched
+}
+
+// resolveBatches resolves the operations which the batch operations send,
+// which must take JSON bodies, if any. Batch operations must take a JSON
+// body, and no parameters.
+func resolveBatches(ops []OperationDefinition, toCamelCaseFunc func(string) string) error {
+	byID := make(map[string]*OperationDefinition, len(ops))
+	for i := range ops {
+		byID[ops[i].OperationId] = &ops[i]
+	}
+	for i := range ops {
+		op := &ops[i]
+		batch := op.Batch
+		if batch == nil {
+			continue
+		}
+		if len(op.JSONBodies()) == 0 || len(op.PathParams) != 0 || op.RequiresParamObject() {
+			return fmt.Errorf("%s is declared a batch operation with %q, but doesn't take a JSON body without parameters", op.OperationId, extBatch)
+		}
+		for _, id := range batch.OperationIds {
+			goID := toCamelCaseFunc(id)
+			goID = typeNamePrefix(goID) + goID
+			batched := byID[goID]
+			if batched == nil {
+				return fmt.Errorf("the operation %s batched by %s is missing", id, op.OperationId)
+			}
+			if batched.Batch != nil {
+				return fmt.Errorf("the operation %s batched by %s must not be a batch operation", batched.OperationId, op.OperationId)
+			}
+			if batched.HasBody() && len(batched.JSONBodies()) == 0 {
+				return fmt.Errorf("the operation %s batched by %s must take a JSON body, if any", batched.OperationId, op.OperationId)
+			}
+			batch.Operations = append(batch.Operations, *batched)
+		}
+	}
+	return nil
+}
+
 // OperationDefinition describes an Operation
 type OperationDefinition struct {
 	OperationId string // The operation_id description from Swagger, used to generate function names
 
-	PathParams          []ParameterDefinition // Parameters in the path, eg, /path/:param
-	HeaderParams        []ParameterDefinition // Parameters in HTTP headers
-	QueryParams         []ParameterDefinition // Parameters in the query, /path?param
-	CookieParams        []ParameterDefinition // Parameters in cookies
-	TypeDefinitions     []TypeDefinition      // These are all the types we need to define for this operation
-	SecurityDefinitions []SecurityDefinition  // These are the security providers
-	BodyRequired        bool
-	Bodies              []RequestBodyDefinition // The list of bodies for which to generate handlers.
-	Responses           []ResponseDefinition    // The list of responses that can be accepted by handlers.
-	Summary             string                  // Summary string from Swagger, used to generate a comment
-	Method              string                  // GET, POST, DELETE, etc.
-	Path                string                  // The Swagger path for the operation, like /resource/{id}
-	Spec                *openapi3.Operation
+	PathParams           []ParameterDefinition           // Parameters in the path, eg, /path/:param
+	HeaderParams         []ParameterDefinition           // Parameters in HTTP headers
+	QueryParams          []ParameterDefinition           // Parameters in the query, /path?param
+	CookieParams         []ParameterDefinition           // Parameters in cookies
+	TypeDefinitions      []TypeDefinition                // These are all the types we need to define for this operation
+	SecurityDefinitions  []SecurityDefinition            // These are the security providers
+	SecurityRequirements []SecurityRequirementDefinition // The alternative security requirements, any of which grants access
+	BodyRequired         bool
+	Bodies               []RequestBodyDefinition // The list of bodies for which to generate handlers.
+	Responses            []ResponseDefinition    // The list of responses that can be accepted by handlers.
+	Summary              string                  // Summary string from Swagger, used to generate a comment
+	Method               string                  // GET, POST, DELETE, etc.
+	Path                 string                  // The Swagger path for the operation, like /resource/{id}
+	RateLimit            *RateLimitDefinition    // The client-side rate limit, if declared via x-ratelimit
+	Servers              []ServerDefinition      // Servers overriding the top level ones for this operation, if any
+	Middlewares          []string                // Names of the server middlewares applied to this operation, from x-middleware
+	LongRunning          *LongRunningDefinition  // How to wait for the completion of the operation, if declared via x-long-running
+	Polled               bool                    // Whether the operation is polled for the completion of long-running ones
+	Cacheable            bool                    // Whether the client caches the responses of this GET operation, from x-cacheable or the client-cache option
+	Batch                *BatchDefinition        // The operations whose requests this one sends at once, if declared via x-batch
+	APIVersion           *APIVersionDefinition   // How the client sends the version of the API with the requests, if declared via x-api-version or the api-version option
+	Links                []LinkDefinition        // The links of the responses which the client with responses follows
+	MaxBodySize          int64                   // The size of the largest request body the server reads, in bytes, if declared via x-max-body-size
+	RequestTimeout       string                  // The timeout of the contexts of the requests in the server, like 30s, if declared via x-request-timeout
+	Spec                 *openapi3.Operation
+}
+
+// RequestPath returns the path which the client sends the requests of the
+// operation to, with the prefix of its API version, if any.
+func (o *OperationDefinition) RequestPath() string {
+	if o.APIVersion != nil {
+		return o.APIVersion.PathPrefix + o.Path
+	}
+	return o.Path
 }
 
 // Params returns the list of all parameters except Path parameters. Path parameters
@@ -254,6 +595,129 @@ func (o *OperationDefinition) HasBody() bool {
 	return o.Spec.RequestBody != nil
 }
 
+// HasBinaryBody returns whether the operation accepts a binary body, for
+// which the client has upload variants.
+func (o *OperationDefinition) HasBinaryBody() bool {
+	for _, body := range o.Bodies {
+		if body.Binary {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONBodies returns the JSON bodies of the operation which the client
+// supports, which batches send.
+func (o *OperationDefinition) JSONBodies() []RequestBodyDefinition {
+	var result []RequestBodyDefinition
+	for _, body := range o.Bodies {
+		if body.IsJSON() && body.IsSupportedByClient() {
+			result = append(result, body)
+		}
+	}
+	return result
+}
+
+// HasOptionalQueryParams returns whether any query parameter of the operation
+// is held by an OptionalParam.
+func (o *OperationDefinition) HasOptionalQueryParams() bool {
+	for _, param := range o.QueryParams {
+		if param.IsOptionalParam() {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOptionalParams returns whether any parameter of the operations is held by
+// an OptionalParam.
+func hasOptionalParams(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		for _, param := range op.Params() {
+			if param.IsOptionalParam() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasObjectHeaderParams returns whether any operation has an object header
+// parameter, which the servers bind with bindHeaderObject.
+func hasObjectHeaderParams(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		for _, param := range op.HeaderParams {
+			if param.IsObject() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CallAllBody returns the default body of the operation when the client
+// supports it, which the calls of its CallAll function hold, or nil when they
+// hold a body of any content type instead.
+func (o *OperationDefinition) CallAllBody() *RequestBodyDefinition {
+	for _, body := range o.Bodies {
+		if body.Default && body.IsSupportedByClient() {
+			return &body
+		}
+	}
+	return nil
+}
+
+// checkCallAllTypes returns an error when the Call or Result type of the
+// CallAll function of an operation collides with the type of a schema.
+func checkCallAllTypes(ops []OperationDefinition) error {
+	schemas := make(map[string]string)
+	if spec := globalState.spec; spec != nil && spec.Components != nil {
+		for _, name := range SortedSchemaKeys(spec.Components.Schemas) {
+			schemas[SchemaNameToTypeName(name)] = name
+		}
+	}
+	for _, op := range ops {
+		for _, typeName := range []string{op.OperationId + "Call", op.OperationId + "Result"} {
+			if name, ok := schemas[typeName]; ok {
+				return fmt.Errorf("the %s type of the client-call-all option collides with the %s schema", typeName, name)
+			}
+		}
+	}
+	return nil
+}
+
+// HasETag returns whether any response of the operation declares an ETag
+// header, which the response type exposes.
+func (o *OperationDefinition) HasETag() bool {
+	for _, response := range o.Spec.Responses {
+		if response.Value == nil {
+			continue
+		}
+		for name := range response.Value.Headers {
+			if strings.EqualFold(name, "ETag") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ConditionalHeaders returns the conditional headers among If-Match and
+// If-None-Match which the operation accepts, for which the client has
+// helpers sending them.
+func (o *OperationDefinition) ConditionalHeaders() []string {
+	var result []string
+	for _, header := range []string{"If-Match", "If-None-Match"} {
+		for _, param := range o.HeaderParams {
+			if strings.EqualFold(param.ParamName, header) {
+				result = append(result, header)
+				break
+			}
+		}
+	}
+	return result
+}
+
 // SummaryAsComment returns the Operations summary as a multi line comment
 func (o *OperationDefinition) SummaryAsComment() string {
 	if o.Summary == "" {
@@ -378,6 +842,14 @@ type RequestBodyDefinition struct {
 
 	// Contains encoding options for formdata
 	Encoding map[string]RequestBodyEncoding
+
+	// Whether the body is binary data, with a string schema of format
+	// binary, which the client uploads from a reader of a given size.
+	Binary bool
+
+	// The JSON Merge Patch type of the body, when it's the merge patch of an
+	// object.
+	MergePatch *MergePatchType
 }
 
 // TypeDef returns the Go type definition for a request body
@@ -527,8 +999,6 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 
 // OperationDefinitions returns all operations for a swagger definition.
 func OperationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]OperationDefinition, error) {
-	var operations []OperationDefinition
-
 	var toCamelCaseFunc func(string) string
 	if initialismOverrides {
 		toCamelCaseFunc = ToCamelCaseWithInitialism
@@ -536,6 +1006,17 @@ func OperationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]Oper
 		toCamelCaseFunc = ToCamelCase
 	}
 
+	// The operations are described concurrently, and kept in the order of
+	// their paths and methods.
+	type pathOperation struct {
+		requestPath  string
+		pathItem     *openapi3.PathItem
+		globalParams []ParameterDefinition
+		opName       string
+		op           *openapi3.Operation
+	}
+	var pathOperations []pathOperation
+
 	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
 		pathItem := swagger.Paths[requestPath]
 		// These are parameters defined for all methods on a given path. They
@@ -549,96 +1030,192 @@ func OperationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]Oper
 		// Each path can have a number of operations, POST, GET, OPTIONS, etc.
 		pathOps := pathItem.Operations()
 		for _, opName := range SortedOperationsKeys(pathOps) {
-			op := pathOps[opName]
-			if pathItem.Servers != nil {
-				op.Servers = &pathItem.Servers
-			}
-			// We rely on OperationID to generate function names, it's required
-			if op.OperationID == "" {
-				op.OperationID, err = generateDefaultOperationID(opName, requestPath, toCamelCaseFunc)
-				if err != nil {
-					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
-						opName, requestPath, err)
-				}
-			} else {
-				op.OperationID = toCamelCaseFunc(op.OperationID)
-			}
-			op.OperationID = typeNamePrefix(op.OperationID) + op.OperationID
+			pathOperations = append(pathOperations, pathOperation{
+				requestPath:  requestPath,
+				pathItem:     pathItem,
+				globalParams: globalParams,
+				opName:       opName,
+				op:           pathOps[opName],
+			})
+		}
+	}
 
-			// These are parameters defined for the specific path method that
-			// we're iterating over.
-			localParams, err := DescribeParameters(op.Parameters, []string{op.OperationID + "Params"})
-			if err != nil {
-				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
-					opName, requestPath, err)
-			}
-			// All the parameters required by a handler are the union of the
-			// global parameters and the local parameters.
-			allParams, err := CombineOperationParameters(globalParams, localParams)
-			if err != nil {
-				return nil, err
-			}
+	operations := make([]OperationDefinition, len(pathOperations))
+	err := runParallel(len(pathOperations), func(i int) error {
+		po := pathOperations[i]
+		opDef, err := describeOperation(swagger, po.requestPath, po.pathItem, po.globalParams, po.opName, po.op, toCamelCaseFunc)
+		operations[i] = opDef
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveLongRunning(operations, toCamelCaseFunc); err != nil {
+		return nil, err
+	}
+	if err := resolveCacheable(operations, toCamelCaseFunc); err != nil {
+		return nil, err
+	}
+	if err := resolveBatches(operations, toCamelCaseFunc); err != nil {
+		return nil, err
+	}
+	resolveLinks(operations, toCamelCaseFunc)
+	return operations, nil
+}
 
-			// Order the path parameters to match the order as specified in
-			// the path, not in the swagger spec, and validate that the parameter
-			// names match, as downstream code depends on that.
-			pathParams := FilterParameterDefinitionByType(allParams, "path")
-			pathParams, err = SortParamsByPath(requestPath, pathParams)
-			if err != nil {
-				return nil, err
-			}
+// describeOperation turns an operation of the spec into the definition used
+// for code generation.
+func describeOperation(swagger *openapi3.T, requestPath string, pathItem *openapi3.PathItem, globalParams []ParameterDefinition,
+	opName string, op *openapi3.Operation, toCamelCaseFunc func(string) string) (OperationDefinition, error) {
+	var err error
+	// Servers declared on the path apply to all of its operations,
+	// unless the operation declares its own.
+	if pathItem.Servers != nil && op.Servers == nil {
+		op.Servers = &pathItem.Servers
+	}
+	// We rely on OperationID to generate function names, it's required
+	if op.OperationID == "" {
+		op.OperationID, err = generateDefaultOperationID(opName, requestPath, toCamelCaseFunc)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("error generating default OperationID for %s/%s: %s",
+				opName, requestPath, err)
+		}
+	} else {
+		op.OperationID = toCamelCaseFunc(op.OperationID)
+	}
+	op.OperationID = typeNamePrefix(op.OperationID) + op.OperationID
 
-			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(op.OperationID, op.RequestBody)
-			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions: %w", err)
-			}
+	// These are parameters defined for the specific path method that
+	// we're iterating over.
+	localParams, err := DescribeParameters(op.Parameters, []string{op.OperationID + "Params"})
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error describing global parameters for %s/%s: %s",
+			opName, requestPath, err)
+	}
+	// All the parameters required by a handler are the union of the
+	// global parameters and the local parameters.
+	allParams, err := CombineOperationParameters(globalParams, localParams)
+	if err != nil {
+		return OperationDefinition{}, err
+	}
 
-			responseDefinitions, err := GenerateResponseDefinitions(op.OperationID, op.Responses)
-			if err != nil {
-				return nil, fmt.Errorf("error generating response definitions: %w", err)
-			}
+	// Order the path parameters to match the order as specified in
+	// the path, not in the swagger spec, and validate that the parameter
+	// names match, as downstream code depends on that.
+	pathParams := FilterParameterDefinitionByType(allParams, "path")
+	pathParams, err = SortParamsByPath(requestPath, pathParams)
+	if err != nil {
+		return OperationDefinition{}, err
+	}
 
-			opDef := OperationDefinition{
-				PathParams:   pathParams,
-				HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
-				QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
-				CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
-				OperationId:  toCamelCaseFunc(op.OperationID),
-				// Replace newlines in summary.
-				Summary:         op.Summary,
-				Method:          opName,
-				Path:            requestPath,
-				Spec:            op,
-				Bodies:          bodyDefinitions,
-				Responses:       responseDefinitions,
-				TypeDefinitions: typeDefinitions,
-			}
+	bodyDefinitions, typeDefinitions, err := generateBodyDefinitions(op.OperationID, opName, op.RequestBody)
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error generating body definitions: %w", err)
+	}
 
-			// check for overrides of SecurityDefinitions.
-			// See: "Step 2. Applying security:" from the spec:
-			// https://swagger.io/docs/specification/authentication/
-			if op.Security != nil {
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
-			} else {
-				// use global securityDefinitions
-				// globalSecurityDefinitions contains the top-level securityDefinitions.
-				// They are the default securityPermissions which are injected into each
-				// path, except for the case where a path explicitly overrides them.
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+	responseDefinitions, err := GenerateResponseDefinitions(op.OperationID, op.Responses)
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error generating response definitions: %w", err)
+	}
 
-			}
+	opDef := OperationDefinition{
+		PathParams:   pathParams,
+		HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
+		QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
+		CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
+		OperationId:  toCamelCaseFunc(op.OperationID),
+		// Replace newlines in summary.
+		Summary:         op.Summary,
+		Method:          opName,
+		Path:            requestPath,
+		Spec:            op,
+		Bodies:          bodyDefinitions,
+		Responses:       responseDefinitions,
+		TypeDefinitions: typeDefinitions,
+	}
 
-			if op.RequestBody != nil {
-				opDef.BodyRequired = op.RequestBody.Value.Required
-			}
+	// check for overrides of SecurityDefinitions.
+	// See: "Step 2. Applying security:" from the spec:
+	// https://swagger.io/docs/specification/authentication/
+	if op.Security != nil {
+		opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
+		opDef.SecurityRequirements = DescribeSecurityRequirements(*op.Security)
+	} else {
+		// use global securityDefinitions
+		// globalSecurityDefinitions contains the top-level securityDefinitions.
+		// They are the default securityPermissions which are injected into each
+		// path, except for the case where a path explicitly overrides them.
+		opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+		opDef.SecurityRequirements = DescribeSecurityRequirements(swagger.Security)
+	}
 
-			// Generate all the type definitions needed for this operation
-			opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
+	if op.RequestBody != nil {
+		opDef.BodyRequired = op.RequestBody.Value.Required
+	}
 
-			operations = append(operations, opDef)
+	if op.Servers != nil {
+		opDef.Servers = DescribeServers(*op.Servers)
+	}
+
+	opDef.RateLimit, err = describeRateLimit(opDef.OperationId, op, swagger.Tags)
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error describing rate limit for %s: %w", opDef.OperationId, err)
+	}
+
+	if ext, ok := op.Extensions[extMiddleware]; ok {
+		opDef.Middlewares, err = extParseMiddleware(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extMiddleware, opDef.OperationId, err)
 		}
 	}
-	return operations, nil
+
+	if ext, ok := op.Extensions[extMaxBodySize]; ok {
+		opDef.MaxBodySize, err = extParseMaxBodySize(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extMaxBodySize, opDef.OperationId, err)
+		}
+	}
+
+	if ext, ok := op.Extensions[extRequestTimeout]; ok {
+		opDef.RequestTimeout, err = extParseRequestTimeout(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extRequestTimeout, opDef.OperationId, err)
+		}
+	}
+
+	if ext, ok := op.Extensions[extLongRunning]; ok {
+		opDef.LongRunning, err = extParseLongRunning(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extLongRunning, opDef.OperationId, err)
+		}
+	}
+
+	if ext, ok := op.Extensions[extCacheable]; ok {
+		opDef.Cacheable, err = extParseCacheable(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extCacheable, opDef.OperationId, err)
+		}
+		if opDef.Cacheable && opName != "GET" {
+			return OperationDefinition{}, fmt.Errorf("%s is declared cacheable with %q, but only GET operations are", opDef.OperationId, extCacheable)
+		}
+	}
+
+	if ext, ok := op.Extensions[extBatch]; ok {
+		opDef.Batch, err = extParseBatch(ext)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q on %s: %w", extBatch, opDef.OperationId, err)
+		}
+	}
+
+	opDef.APIVersion, err = operationAPIVersion(opDef.OperationId, op)
+	if err != nil {
+		return OperationDefinition{}, err
+	}
+
+	// Generate all the type definitions needed for this operation
+	opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
+
+	return opDef, nil
 }
 
 func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFunc func(string) string) (string, error) {
@@ -661,9 +1238,22 @@ func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFu
 	return toCamelCaseFunc(operationId), nil
 }
 
+// isBinarySchema returns whether the schema is a string of format binary.
+func isBinarySchema(schemaOrRef *openapi3.SchemaRef) bool {
+	return schemaOrRef != nil && schemaOrRef.Value != nil &&
+		schemaOrRef.Value.Type == "string" && schemaOrRef.Value.Format == "binary"
+}
+
 // GenerateBodyDefinitions turns the Swagger body definitions into a list of our body
 // definitions which will be used for code generation.
 func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
+	return generateBodyDefinitions(operationID, "", bodyOrRef)
+}
+
+// generateBodyDefinitions is GenerateBodyDefinitions for an operation of the
+// given method, whose JSON bodies are merge patches for PATCH with the
+// patch-tri-state-fields output option.
+func generateBodyDefinitions(operationID, method string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
 	if bodyOrRef == nil {
 		return nil, nil, nil
 	}
@@ -693,12 +1283,28 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 			bd := RequestBodyDefinition{
 				Required:    body.Required,
 				ContentType: contentType,
+				Binary:      isBinarySchema(content.Schema),
 			}
 			bodyDefinitions = append(bodyDefinitions, bd)
 			continue
 		}
 
 		bodyTypeName := operationID + tag + "Body"
+		patchSchema, mergePatch, patch, err := patchBodySchema(bodyTypeName, method, contentType, content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
+		}
+		if patch {
+			bodyDefinitions = append(bodyDefinitions, RequestBodyDefinition{
+				Required:    body.Required,
+				Schema:      patchSchema,
+				NameTag:     tag,
+				ContentType: contentType,
+				MergePatch:  mergePatch,
+			})
+			continue
+		}
+
 		bodySchema, err := GenerateGoSchema(content.Schema, []string{bodyTypeName})
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
@@ -892,6 +1498,12 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 				Schema:   param.Schema,
 			})
 		}
+		if param.IsOptionalParam() {
+			pSchema = Schema{
+				GoType:              "OptionalParam[" + pSchema.TypeDecl() + "]",
+				SkipOptionalPointer: true,
+			}
+		}
 		prop := Property{
 			Description:   param.Spec.Description,
 			JsonFieldName: param.ParamName,
@@ -918,7 +1530,11 @@ func GenerateTypesForOperations(t *template.Template, ops []OperationDefinition)
 	var buf bytes.Buffer
 	w := bufio.NewWriter(&buf)
 
-	addTypes, err := GenerateTemplates([]string{"param-types.tmpl", "request-bodies.tmpl"}, t, ops)
+	templates := []string{"param-types.tmpl", "request-bodies.tmpl"}
+	if hasOptionalParams(ops) {
+		templates = append(templates, "optional-params.tmpl")
+	}
+	addTypes, err := GenerateTemplates(templates, t, ops)
 	if err != nil {
 		return "", fmt.Errorf("error generating type boilerplate for operations: %w", err)
 	}
@@ -961,7 +1577,11 @@ func GenerateIrisServer(t *template.Template, operations []OperationDefinition)
 // GenerateChiServer generates all the go code for the ServerInterface as well as
 // all the wrapper functions around our handlers.
 func GenerateChiServer(t *template.Template, operations []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"chi/chi-interface.tmpl", "chi/chi-middleware.tmpl", "chi/chi-handler.tmpl"}, t, operations)
+	templates := []string{"chi/chi-interface.tmpl", "chi/chi-middleware.tmpl", "chi/chi-handler.tmpl"}
+	if globalState.options.OutputOptions.ChiRender {
+		templates = append(templates, "chi/chi-render.tmpl")
+	}
+	return GenerateTemplates(templates, t, operations)
 }
 
 // GenerateFiberServer generates all the go code for the ServerInterface as well as
@@ -1007,6 +1627,9 @@ func GenerateStrictServer(t *template.Template, operations []OperationDefinition
 	if opts.Generate.IrisServer {
 		templates = append(templates, "strict/strict-iris-interface.tmpl", "strict/strict-iris.tmpl")
 	}
+	if opts.OutputOptions.ContextHandlers {
+		templates = append(templates, "strict/strict-context.tmpl")
+	}
 
 	return GenerateTemplates(templates, t, operations)
 }
@@ -1018,13 +1641,78 @@ func GenerateStrictResponses(t *template.Template, responses []ResponseDefinitio
 // GenerateClient uses the template engine to generate the function which registers our wrappers
 // as Echo path handlers.
 func GenerateClient(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client.tmpl"}, t, ops)
+	templates := []string{"client.tmpl"}
+	if globalState.options.OutputOptions.ClientCircuitBreaker != nil {
+		templates = append(templates, "client-circuit-breaker.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientCache != nil {
+		templates = append(templates, "client-cache.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientBufferPool != nil {
+		templates = append(templates, "client-buffer-pool.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientTransportOptions || globalState.options.OutputOptions.ClientUnixSocket {
+		templates = append(templates, "client-transport.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientCapture {
+		templates = append(templates, "client-capture.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientCassettes {
+		templates = append(templates, "client-cassette.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientHooks {
+		templates = append(templates, "client-hooks.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientServices {
+		templates = append(templates, "client-services.tmpl")
+	}
+	return GenerateTemplates(templates, t, ops)
+}
+
+// GenerateRequestBuilders generates the New<Operation>Request functions which
+// build an http.Request for each operation, without the client which sends them.
+func GenerateRequestBuilders(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"request-builders.tmpl"}, t, ops)
+}
+
+// GeneratePathBuilders generates the Path<Operation> functions which build the
+// path of the requests of each operation from its path parameters.
+func GeneratePathBuilders(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"path-builders.tmpl"}, t, ops)
 }
 
 // GenerateClientWithResponses generates a client which extends the basic client which does response
 // unmarshaling.
 func GenerateClientWithResponses(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client-with-responses.tmpl"}, t, ops)
+	templates := []string{"client-with-responses.tmpl"}
+	if globalState.options.OutputOptions.ClientErrorTypes {
+		templates = append(templates, "client-errors.tmpl")
+	}
+	if strictDecodingOperations(ops) {
+		templates = append(templates, "strict-decoding.tmpl")
+	}
+	if len(batchOperations(ops)) != 0 {
+		templates = append(templates, "client-batch.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientServices {
+		templates = append(templates, "client-with-responses-services.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientResponseBase != nil {
+		templates = append(templates, "client-response-base.tmpl")
+	}
+	if hasLinks(ops) {
+		templates = append(templates, "client-links.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientCoalescing {
+		templates = append(templates, "client-coalescing.tmpl")
+	}
+	if globalState.options.OutputOptions.ClientCallAll {
+		if err := checkCallAllTypes(ops); err != nil {
+			return "", err
+		}
+		templates = append(templates, "client-call-all.tmpl")
+	}
+	return GenerateTemplates(templates, t, ops)
 }
 
 // GenerateTemplates used to generate templates
@@ -1035,7 +1723,7 @@ func GenerateTemplates(templates []string, t *template.Template, ops interface{}
 		w := bufio.NewWriter(&buf)
 
 		if err := t.ExecuteTemplate(w, tmpl, ops); err != nil {
-			return "", fmt.Errorf("error generating %s: %s", tmpl, err)
+			return "", fmt.Errorf("error generating %s: %w", tmpl, err)
 		}
 		if err := w.Flush(); err != nil {
 			return "", fmt.Errorf("error flushing output buffer for %s: %s", tmpl, err)
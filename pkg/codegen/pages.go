@@ -0,0 +1,165 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// pageItems is the property of the list envelopes holding their items.
+const pageItems = "items"
+
+// PageDefinition describes the generic Page type, which the list envelopes
+// of the same shape are declared as, with the type of their items.
+type PageDefinition struct {
+	Fields    []string // The fields of the envelopes, the items being of type T
+	TypeNames []string // The envelopes declared as a Page, in the order of the spec
+}
+
+// Envelopes returns the envelopes declared as a Page, listed for its doc
+// comment.
+func (p PageDefinition) Envelopes() string {
+	return enumerate(p.TypeNames)
+}
+
+// DescribePages finds the list envelopes among the given types, the structs
+// with an array of items and other fields, and declares those sharing the
+// most common shape, other than the type of their items, as instances of a
+// generic Page type, rather than as near-identical structs. The envelopes
+// with methods of other options, like DeepCopy, are left as structs, with a
+// warning. It returns nil when fewer than two envelopes share a shape.
+func DescribePages(types []TypeDefinition) *PageDefinition {
+	var shapes []string
+	envelopes := make(map[string][]int)
+	for i, td := range types {
+		shape, ok := pageShape(td)
+		if !ok {
+			continue
+		}
+		if envelopes[shape] == nil {
+			shapes = append(shapes, shape)
+		}
+		envelopes[shape] = append(envelopes[shape], i)
+	}
+	// The most common shape, the first in the spec breaking ties
+	sort.SliceStable(shapes, func(i, j int) bool {
+		return len(envelopes[shapes[i]]) > len(envelopes[shapes[j]])
+	})
+	if len(shapes) == 0 || len(envelopes[shapes[0]]) < 2 {
+		return nil
+	}
+
+	page := &PageDefinition{Fields: strings.Split(shapes[0], "\n")}
+	for _, i := range envelopes[shapes[0]] {
+		if methods := pageMethods(types[i]); methods != "" {
+			warn(nil, jsonPointer("components", "schemas", types[i].JsonName), "%s has %s, which an instance of Page can't have, so it's declared as a struct", types[i].TypeName, methods)
+			continue
+		}
+		page.TypeNames = append(page.TypeNames, types[i].TypeName)
+	}
+	if len(page.TypeNames) < 2 {
+		return nil
+	}
+	for _, shape := range shapes[1:] {
+		if len(envelopes[shape]) > 1 {
+			warn(nil, jsonPointer("components", "schemas", types[envelopes[shape][0]].JsonName), "the list envelopes of %s have another shape than those declared as a Page, so they're declared as structs", types[envelopes[shape][0]].TypeName)
+		}
+	}
+	return page
+}
+
+// pageShape returns the fields of a list envelope, its items being of type T,
+// and whether the type is one. Envelopes with methods, or extensions
+// generating them, are left as structs, since an instance of a generic type
+// can't have methods of its own.
+func pageShape(td TypeDefinition) (string, bool) {
+	schema := td.Schema
+	if td.IsAlias() || schema.IsRef() || !strings.HasPrefix(schema.GoType, "struct") || schema.hasMethods() ||
+		len(schema.Properties) < 2 || len(schema.GetAdditionalTypeDefs()) != 0 || schema.OAPISchema == nil {
+		return "", false
+	}
+	for name := range schema.OAPISchema.Extensions {
+		if name != extGoTypeName {
+			return "", false
+		}
+	}
+	if globalState.xmlSchemas[schema.OAPISchema] {
+		return "", false
+	}
+
+	found := false
+	properties := make([]Property, len(schema.Properties))
+	for i, p := range schema.Properties {
+		if p.JsonFieldName == pageItems {
+			if p.Schema.IsRef() || p.Schema.ArrayType == nil || p.Recursive {
+				return "", false
+			}
+			found = true
+			p.Schema = Schema{GoType: "[]T"}
+		}
+		p.Description = ""
+		properties[i] = p
+	}
+	if !found {
+		return "", false
+	}
+	return strings.Join(GenFieldsFromProperties(properties), "\n"), true
+}
+
+// pageMethods returns the methods which the other options generate for a list
+// envelope, if any, and which it would lose as an alias of an instance of
+// Page.
+func pageMethods(td TypeDefinition) string {
+	var methods []string
+	if globalState.options.Generate.DeepCopy {
+		methods = append(methods, "DeepCopy and Equal methods")
+	}
+	if enabled, _, err := hotPathEnabled(td); enabled || err != nil {
+		methods = append(methods, "hot-path marshalers")
+	}
+	return strings.Join(methods, " and ")
+}
+
+// declarePages declares the list envelopes among the given types as
+// instances of the Page type, for the go-version output option targeting Go
+// 1.18 or later, which has generics.
+func declarePages(types []TypeDefinition) (*PageDefinition, error) {
+	if !goVersionAtLeast(18) {
+		return nil, nil
+	}
+	page := DescribePages(types)
+	if page == nil {
+		return nil, nil
+	}
+	for _, td := range types {
+		if td.TypeName == "Page" {
+			return nil, fmt.Errorf("the generated Page type collides with the Page type of the spec, which x-go-type-name can rename")
+		}
+	}
+	declared := make(map[string]bool)
+	for _, typeName := range page.TypeNames {
+		declared[typeName] = true
+	}
+	for i, td := range types {
+		if !declared[td.TypeName] {
+			continue
+		}
+		for _, p := range td.Schema.Properties {
+			if p.JsonFieldName == pageItems {
+				types[i].Schema.GoType = "Page[" + p.Schema.ArrayType.TypeDecl() + "]"
+			}
+		}
+		types[i].Schema.Properties = nil
+		types[i].Schema.DefineViaAlias = true
+	}
+	return page, nil
+}
+
+// GeneratePage generates the generic Page type of the list envelopes.
+func GeneratePage(t *template.Template, page *PageDefinition) (string, error) {
+	if page == nil {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"page.tmpl"}, t, page)
+}
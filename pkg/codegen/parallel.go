@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelism returns the number of workers generating code concurrently.
+func parallelism() int {
+	if n := globalState.options.OutputOptions.Parallelism; n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// runParallel calls fn with each index from 0 to n-1 on a pool of workers.
+// Callers store the results by index, so that they are merged in the same
+// order whatever the scheduling. When calls fail, the error for the lowest
+// index is returned.
+func runParallel(n int, fn func(i int) error) error {
+	workers := parallelism()
+	if workers > n {
+		workers = n
+	}
+
+	errs := make([]error, n)
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package codegen
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// The media types of the patch documents which the patch-bodies output option
+// generates types of their own for.
+const (
+	mergePatchMediaType = "application/merge-patch+json"
+	jsonPatchMediaType  = "application/json-patch+json"
+)
+
+// jsonPatchType is the generated type of JSON Patch documents.
+const jsonPatchType = "JSONPatch"
+
+// MergePatchType describes the JSON Merge Patch document type of an object
+// schema, whose fields are left out, null or set.
+type MergePatchType struct {
+	TypeName string            // The name of the type, like PetMergePatch
+	Target   string            // The type which the document patches, empty for an inline schema
+	Fields   []MergePatchField // The fields of the document
+}
+
+// MergePatchField is a field of a MergePatchType.
+type MergePatchField struct {
+	GoName   string // The name of the field, like Name
+	JsonName string // The name of the property, like name
+	GoType   string // The type of the values of the field, like string
+}
+
+// patchBodySchema returns the schema of a request body of an operation of the
+// given method which is a JSON Patch or JSON Merge Patch document, along with
+// the merge patch type of the latter, or false when the body keeps the type of
+// its schema. With the patch-bodies output option, the bodies of the JSON Patch
+// and JSON Merge Patch media types are such documents, and with the
+// patch-tri-state-fields one, the other JSON bodies of PATCH operations are
+// merge patches too. Schemas other than objects with properties keep their
+// types.
+func patchBodySchema(bodyTypeName, method, contentType string, content *openapi3.MediaType) (Schema, *MergePatchType, bool, error) {
+	outputOptions := globalState.options.OutputOptions
+	switch {
+	case outputOptions.PatchBodies && contentType == jsonPatchMediaType:
+		return Schema{GoType: jsonPatchType, RefType: jsonPatchType, DefineViaAlias: true}, nil, true, nil
+	case outputOptions.PatchBodies && contentType == mergePatchMediaType:
+	case outputOptions.PatchTriStateFields && method == http.MethodPatch && util.IsMediaTypeJson(contentType) && contentType != jsonPatchMediaType:
+	default:
+		return Schema{}, nil, false, nil
+	}
+	if content.Schema == nil || content.Schema.Value == nil {
+		return Schema{}, nil, false, nil
+	}
+
+	// The merge patch of a component is shared by the operations patching it,
+	// and its fields have the types of those of the component.
+	typeName, target := bodyTypeName, ""
+	if IsGoTypeReference(content.Schema.Ref) {
+		refType, err := RefPathToGoType(content.Schema.Ref)
+		if err != nil {
+			return Schema{}, nil, false, fmt.Errorf("error turning reference (%s) into a Go type: %w", content.Schema.Ref, err)
+		}
+		if strings.Contains(refType, ".") {
+			// The types of the fields of an external schema are in another package
+			return Schema{}, nil, false, nil
+		}
+		typeName, target = refType+"MergePatch", refType
+	}
+	schema, err := GenerateGoSchema(&openapi3.SchemaRef{Value: content.Schema.Value}, []string{strings.TrimSuffix(typeName, "MergePatch")})
+	if err != nil {
+		return Schema{}, nil, false, fmt.Errorf("error generating merge patch of %s: %w", typeName, err)
+	}
+	if len(schema.Properties) == 0 || schema.HasAdditionalProperties || len(schema.UnionElements) != 0 {
+		return Schema{}, nil, false, nil
+	}
+
+	mergePatch := &MergePatchType{TypeName: typeName, Target: target}
+	for _, p := range schema.Properties {
+		// A client can't change read-only properties
+		if p.ReadOnly {
+			continue
+		}
+		mergePatch.Fields = append(mergePatch.Fields, MergePatchField{
+			GoName:   p.structFieldName(),
+			JsonName: p.JsonFieldName,
+			GoType:   p.Schema.TypeDecl(),
+		})
+	}
+	patchSchema := Schema{GoType: typeName, RefType: typeName, DefineViaAlias: true}
+	if target == "" {
+		// The types of the fields of an inline schema, like its enums, are
+		// declared with the operation
+		patchSchema.AdditionalTypes = schema.GetAdditionalTypeDefs()
+	}
+	return patchSchema, mergePatch, true, nil
+}
+
+// GeneratePatchBoilerplate generates the JSON Patch type, when an operation
+// takes a JSON Patch body, and the JSON Merge Patch types of the operations.
+func GeneratePatchBoilerplate(t *template.Template, ops []OperationDefinition) (string, error) {
+	context := struct {
+		JSONPatch    bool
+		MergePatches []MergePatchType
+	}{}
+	seen := make(map[string]bool)
+	for _, op := range ops {
+		for _, body := range op.Bodies {
+			if body.Schema.RefType == jsonPatchType && body.ContentType == jsonPatchMediaType {
+				context.JSONPatch = true
+			}
+			if body.MergePatch == nil || seen[body.MergePatch.TypeName] {
+				continue
+			}
+			seen[body.MergePatch.TypeName] = true
+			context.MergePatches = append(context.MergePatches, *body.MergePatch)
+		}
+	}
+	if !context.JSONPatch && len(context.MergePatches) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"patch.tmpl"}, t, context)
+}
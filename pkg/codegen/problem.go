@@ -0,0 +1,22 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GenerateProblem generates the Problem type of the RFC 7807 documents which
+// the servers respond with when they can't bind a request, and the functions
+// writing them.
+func GenerateProblem(t *template.Template, spec *openapi3.T) (string, error) {
+	if spec.Components != nil {
+		for _, name := range SortedSchemaKeys(spec.Components.Schemas) {
+			if SchemaNameToTypeName(name) == "Problem" {
+				return "", fmt.Errorf("the Problem type of the problem-responses option collides with the %s schema", name)
+			}
+		}
+	}
+	return GenerateTemplates([]string{"problem.tmpl"}, t, nil)
+}
@@ -0,0 +1,290 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SyntheticName records the name given to an inline schema moved into the
+// components of the spec.
+type SyntheticName struct {
+	Path string // The JSON pointer to the inline schema, like /paths/~1pets/post/requestBody/content/application~1json/schema
+	Name string // The name of the schema in the components, like CreatePetRequest
+}
+
+// PromoteInlineSchemas moves the inline object schemas of the spec, and the
+// inline schemas composing others with allOf, anyOf or oneOf, into its
+// components, and points references at them, so that they're generated as
+// named types rather than anonymous structs.
+//
+// Their names are made of the operation or component they're declared in,
+// their location, and the path of properties leading to them, like
+// CreatePetRequest for the body of createPet, GetPet200Response for its 200
+// response, ListPetsFilterParam for its filter parameter, PetOwnerAddress for
+// the address property of the owner property of Pet, or NotFoundResponse,
+// PetBodyRequest and LimitParam for the NotFound response, the PetBody request
+// body and the Limit parameter of the components. Array items add Item to the
+// name, additional properties AdditionalProperties, and the schemas of anyOf
+// and oneOf their index, like PetKindOneOf0, while the properties of those of
+// allOf are named like those of the schema they're merged into. When a body or
+// a response has several media types, the name of the media type follows the
+// location. x-go-type-name names a schema otherwise, and names which are
+// already taken get a numeric suffix, like CreatePetRequest2, which the
+// schemas nested in them share.
+//
+// Schemas are promoted in the order of the spec, component schemas first, then
+// component responses, request bodies and parameters, and then operations
+// sorted by path and method, so that names are stable as long as the spec
+// doesn't change. It returns the names given.
+func PromoteInlineSchemas(spec *openapi3.T) []SyntheticName {
+	return ExtractInlineSchemas(spec, 0)
+}
+
+// ExtractInlineSchemas works like PromoteInlineSchemas, but only promotes the
+// inline schemas nested in more than maxDepth levels of anonymous structs,
+// counting their own, so that the structs nest maxDepth deep at most. The
+// properties of the component schemas are one level deep, like the schemas
+// of the parameters, bodies and responses, and their properties two. The
+// schemas nested in the promoted ones count from their type, which is named.
+func ExtractInlineSchemas(spec *openapi3.T, maxDepth int) []SyntheticName {
+	if spec.Components == nil {
+		spec.Components = &openapi3.Components{}
+	}
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = make(openapi3.Schemas)
+	}
+	p := &promoter{
+		spec:     spec,
+		maxDepth: maxDepth,
+		taken:    make(map[string]bool),
+		promoted: make(map[*openapi3.Schema]string),
+	}
+	components := spec.Components.Schemas
+	for name := range components {
+		p.taken[name] = true
+		p.taken[SchemaNameToTypeName(name)] = true
+	}
+	// The component responses, bodies and parameters declare types too
+	for name, response := range spec.Components.Responses {
+		p.takeType(renameResponse(name, response))
+	}
+	for name, body := range spec.Components.RequestBodies {
+		p.takeType(renameRequestBody(name, body))
+	}
+	for name, param := range spec.Components.Parameters {
+		p.takeType(renameParameter(name, param))
+	}
+
+	for _, name := range SortedSchemaKeys(components) {
+		p.nested(components[name], jsonPointer("components", "schemas", name), SchemaNameToTypeName(name), 1)
+	}
+	for _, name := range SortedResponsesKeys(spec.Components.Responses) {
+		p.response(spec.Components.Responses[name], jsonPointer("components", "responses", name), SchemaNameToTypeName(name))
+	}
+	for _, name := range SortedRequestBodyKeys(spec.Components.RequestBodies) {
+		body := spec.Components.RequestBodies[name]
+		if body.Ref == "" && body.Value != nil {
+			p.content(body.Value.Content, jsonPointer("components", "requestBodies", name, "content"), SchemaNameToTypeName(name)+"Request")
+		}
+	}
+	for _, name := range SortedParameterKeys(spec.Components.Parameters) {
+		p.parameter(spec.Components.Parameters[name], jsonPointer("components", "parameters", name), SchemaNameToTypeName(name)+"Param")
+	}
+	for _, requestPath := range SortedPathsKeys(spec.Paths) {
+		pathItem := spec.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			opName := ToCamelCase(op.OperationID)
+			if op.OperationID == "" {
+				opName, _ = generateDefaultOperationID(method, requestPath, ToCamelCase)
+			}
+			pointer := func(tokens ...string) string {
+				return jsonPointer(append([]string{"paths", requestPath, strings.ToLower(method)}, tokens...)...)
+			}
+
+			for i, param := range op.Parameters {
+				p.parameter(param, pointer("parameters", strconv.Itoa(i)), paramName(opName, param))
+			}
+			// Parameters shared by the operations of a path are named after
+			// the first one.
+			for i, param := range pathItem.Parameters {
+				p.parameter(param, jsonPointer("paths", requestPath, "parameters", strconv.Itoa(i)), paramName(opName, param))
+			}
+			if body := op.RequestBody; body != nil && body.Ref == "" && body.Value != nil {
+				p.content(body.Value.Content, pointer("requestBody", "content"), opName+"Request")
+			}
+			for _, code := range SortedResponsesKeys(op.Responses) {
+				response := op.Responses[code]
+				if response.Ref != "" || response.Value == nil {
+					continue
+				}
+				p.response(response, pointer("responses", code), opName+ToCamelCase(code))
+			}
+		}
+	}
+	return p.names
+}
+
+// promoteInlineSchemas promotes the inline schemas of the spec for the
+// promote-inline-schemas option, or those nested too deep for the
+// max-inline-depth one, keeping the names given for the report.
+func promoteInlineSchemas(spec *openapi3.T, maxDepth int) {
+	globalState.syntheticNames = ExtractInlineSchemas(spec, maxDepth)
+}
+
+// promoter tracks the components of the spec while PromoteInlineSchemas adds
+// to them.
+type promoter struct {
+	spec *openapi3.T
+	// The levels of anonymous structs the inline schemas may nest in, or 0
+	// to promote them all
+	maxDepth int
+	// The names of the component schemas, and of their types
+	taken map[string]bool
+	// The names given to the schemas promoted so far, which are shared
+	// between the places referencing them
+	promoted map[*openapi3.Schema]string
+	names    []SyntheticName
+}
+
+// takeType records the name of a type declared for a component other than a
+// schema, which the promoted schemas can't take.
+func (p *promoter) takeType(name string, err error) {
+	if err == nil {
+		p.taken[name] = true
+	}
+}
+
+// parameter promotes the inline schema of a parameter, named name.
+func (p *promoter) parameter(param *openapi3.ParameterRef, pointer string, name string) {
+	if param == nil || param.Ref != "" || param.Value == nil {
+		return
+	}
+	p.schema(param.Value.Schema, pointer+"/schema", name, 1)
+	p.content(param.Value.Content, pointer+"/content", name)
+}
+
+// paramName returns the name of the inline schema of a parameter of an
+// operation, like ListPetsFilterParam.
+func paramName(opName string, param *openapi3.ParameterRef) string {
+	if param == nil || param.Value == nil {
+		return ""
+	}
+	return opName + ToCamelCase(param.Value.Name) + "Param"
+}
+
+// response promotes the inline schemas of the media types and the headers of
+// a response, named after name, like GetPet200 for the 200 response of
+// getPet.
+func (p *promoter) response(response *openapi3.ResponseRef, pointer string, name string) {
+	if response == nil || response.Ref != "" || response.Value == nil {
+		return
+	}
+	p.content(response.Value.Content, pointer+"/content", name+"Response")
+	for _, header := range SortedHeadersKeys(response.Value.Headers) {
+		headerRef := response.Value.Headers[header]
+		if headerRef.Ref != "" || headerRef.Value == nil {
+			continue
+		}
+		p.schema(headerRef.Value.Schema, pointer+jsonPointer("headers", header, "schema"), name+ToCamelCase(header)+"Header", 1)
+	}
+}
+
+// content promotes the inline schemas of the media types of a body, adding
+// the names of the media types to theirs when there are several.
+func (p *promoter) content(content openapi3.Content, pointer string, name string) {
+	keys := SortedContentKeys(content)
+	for _, contentType := range keys {
+		mediaName := name
+		if len(keys) > 1 {
+			mediaName += mediaTypeToCamelCase(contentType)
+		}
+		p.schema(content[contentType].Schema, pointer+jsonPointer(contentType, "schema"), mediaName, 1)
+	}
+}
+
+// schema promotes an inline schema, if it's an object or a composition
+// nested deeper than the maximum depth, along with the inline schemas nested
+// in it. Its depth is the levels of anonymous structs it would nest in.
+func (p *promoter) schema(ref *openapi3.SchemaRef, pointer string, name string, depth int) {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return
+	}
+	schema := ref.Value
+	if promoted, ok := p.promoted[schema]; ok {
+		ref.Ref = "#/components/schemas/" + promoted
+		return
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return
+	}
+	if len(schema.Properties) == 0 && len(schema.AllOf) == 0 && len(schema.AnyOf) == 0 && len(schema.OneOf) == 0 {
+		p.nested(ref, pointer, name, depth)
+		return
+	}
+	if p.maxDepth != 0 && depth <= p.maxDepth {
+		// The schemas nested in it are a level deeper.
+		p.nested(ref, pointer, name, depth+1)
+		return
+	}
+
+	if typeName, ok := schema.Extensions[extGoTypeName]; ok {
+		if s, err := extString(typeName); err == nil && s != "" {
+			// The component is named after it instead, which would otherwise
+			// declare the type twice.
+			name = s
+			delete(schema.Extensions, extGoTypeName)
+		}
+	}
+	unique := name
+	for i := 2; p.taken[unique] || p.taken[SchemaNameToTypeName(unique)]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	p.taken[unique] = true
+	p.taken[SchemaNameToTypeName(unique)] = true
+	p.promoted[schema] = unique
+	p.names = append(p.names, SyntheticName{Path: pointer, Name: unique})
+	p.spec.Components.Schemas[unique] = &openapi3.SchemaRef{Value: schema}
+
+	p.nested(ref, pointer, unique, 1)
+	ref.Ref = "#/components/schemas/" + unique
+}
+
+// nested promotes the inline schemas of the properties, items and additional
+// properties of a schema, which are depth levels deep, and of the schemas it's
+// made of.
+func (p *promoter) nested(ref *openapi3.SchemaRef, pointer string, name string, depth int) {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return
+	}
+	schema := ref.Value
+	for _, property := range SortedSchemaKeys(schema.Properties) {
+		p.schema(schema.Properties[property], pointer+jsonPointer("properties", property), name+ToCamelCase(property), depth)
+	}
+	p.schema(schema.Items, pointer+"/items", name+"Item", depth)
+	p.schema(schema.AdditionalProperties.Schema, pointer+"/additionalProperties", name+"AdditionalProperties", depth)
+	// The schemas of allOf are merged into the schema, so their properties
+	// are as deep as its own.
+	for i, member := range schema.AllOf {
+		p.nested(member, pointer+"/allOf/"+strconv.Itoa(i), name, depth)
+	}
+	// Those of anyOf and oneOf are types of their own, named after their
+	// index, and promoted as such without a maximum depth.
+	for _, composition := range []struct {
+		keyword string
+		members openapi3.SchemaRefs
+	}{{"anyOf", schema.AnyOf}, {"oneOf", schema.OneOf}} {
+		keyword := composition.keyword
+		for i, member := range composition.members {
+			memberPointer, memberName := pointer+"/"+keyword+"/"+strconv.Itoa(i), name+UppercaseFirstCharacter(keyword)+strconv.Itoa(i)
+			if p.maxDepth == 0 {
+				p.schema(member, memberPointer, memberName, depth)
+			} else {
+				p.nested(member, memberPointer, memberName, 1)
+			}
+		}
+	}
+}
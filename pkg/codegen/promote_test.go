@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestPromoteInlineSchemas(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/inline-schemas.yaml")
+	require.NoError(t, err)
+
+	names := PromoteInlineSchemas(swagger)
+	assert.Equal(t, []SyntheticName{
+		{"/components/schemas/Pet/properties/owner", "PetOwner"},
+		{"/components/schemas/Pet/properties/owner/properties/address", "PetOwnerAddress"},
+		{"/components/schemas/Pet/properties/tags/items", "PetTagsItem"},
+		{"/paths/~1pets/parameters/0/content/application~1json/schema", "ListPetsFilterParam"},
+		{"/paths/~1pets/get/responses/200/content/application~1json/schema", "ListPets200Response"},
+		{"/paths/~1pets/get/responses/200/content/application~1json/schema/properties/page", "ListPets200ResponsePage"},
+		// CreatePetRequest is the name of a component already
+		{"/paths/~1pets/post/requestBody/content/application~1json/schema", "CreatePetRequest2"},
+		{"/paths/~1pets/post/requestBody/content/application~1json/schema/properties/owner", "CreatePetRequest2Owner"},
+		{"/paths/~1pets/post/responses/default/content/application~1json/schema", "Problem"},
+	}, names)
+
+	// The inline schemas are referenced from where they were declared
+	schemas := swagger.Components.Schemas
+	body := swagger.Paths["/pets"].Post.RequestBody.Value.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/CreatePetRequest2", body.Ref)
+	assert.Same(t, body.Value, schemas["CreatePetRequest2"].Value)
+	assert.Equal(t, "#/components/schemas/PetOwnerAddress", schemas["PetOwner"].Value.Properties["address"].Ref)
+	// Schemas which aren't objects stay inline
+	assert.Empty(t, schemas["Pet"].Value.Properties["name"].Ref)
+}
+
+func TestPromoteInlineSchemasGenerate(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/inline-schemas.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			ChiServer:    true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			PromoteInlineSchemas: true,
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Len(t, output.SyntheticNames, 9)
+
+	code := output.Code
+	assert.Contains(t, code, "type CreatePetRequest2 struct {")
+	assert.Contains(t, code, "type CreatePetJSONRequestBody = CreatePetRequest2")
+	assert.Contains(t, code, "Owner *PetOwner      `json:\"owner,omitempty\"`")
+	assert.Contains(t, code, "Filter *ListPetsFilterParam `form:\"filter,omitempty\" json:\"filter,omitempty\"`")
+	assert.Contains(t, code, "JSON200      *ListPets200Response")
+	assert.Contains(t, code, "type Problem struct {")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// Nothing is promoted without the option
+	swagger, err = util.LoadSwagger("test_specs/inline-schemas.yaml")
+	require.NoError(t, err)
+	output, err = GenerateOutput(swagger, Configuration{PackageName: "api", Generate: GenerateOptions{Models: true}})
+	require.NoError(t, err)
+	assert.Empty(t, output.SyntheticNames)
+	assert.NotContains(t, output.Code, "PetOwner")
+}
+
+func TestExtractInlineSchemas(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/inline-schemas.yaml")
+	require.NoError(t, err)
+
+	// Only the schemas nested in another anonymous struct are promoted
+	names := ExtractInlineSchemas(swagger, 1)
+	assert.Equal(t, []SyntheticName{
+		{"/components/schemas/Pet/properties/owner/properties/address", "PetOwnerAddress"},
+		{"/paths/~1pets/get/responses/200/content/application~1json/schema/properties/page", "ListPets200ResponsePage"},
+		{"/paths/~1pets/post/requestBody/content/application~1json/schema/properties/owner", "CreatePetRequestOwner"},
+	}, names)
+
+	schemas := swagger.Components.Schemas
+	owner := schemas["Pet"].Value.Properties["owner"]
+	assert.Empty(t, owner.Ref)
+	assert.Equal(t, "#/components/schemas/PetOwnerAddress", owner.Value.Properties["address"].Ref)
+	assert.Empty(t, schemas["Pet"].Value.Properties["tags"].Value.Items.Ref)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			MaxInlineDepth: 1,
+		},
+	}
+	require.NoError(t, opts.Validate())
+
+	swagger, err = util.LoadSwagger("test_specs/inline-schemas.yaml")
+	require.NoError(t, err)
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Len(t, output.SyntheticNames, 3)
+
+	code := output.Code
+	assert.Contains(t, code, "type PetOwnerAddress struct {")
+	assert.Contains(t, code, "Address *PetOwnerAddress `json:\"address,omitempty\"`")
+	assert.Contains(t, code, "Page *ListPets200ResponsePage `json:\"page,omitempty\"`")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	opts.OutputOptions.PromoteInlineSchemas = true
+	assert.Error(t, opts.Validate())
+	opts.OutputOptions.PromoteInlineSchemas = false
+	opts.OutputOptions.MaxInlineDepth = -1
+	assert.Error(t, opts.Validate())
+}
+
+func TestPromoteInlineSchemasOfComponents(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/inline-schemas-components.yaml")
+	require.NoError(t, err)
+
+	// The component responses, request bodies and parameters have theirs
+	// promoted too, and so do the schemas they're made of
+	names := PromoteInlineSchemas(swagger)
+	assert.Equal(t, []SyntheticName{
+		{"/components/schemas/Pet/allOf/1/properties/owner", "PetOwner"},
+		{"/components/schemas/Toy/oneOf/0", "ToyOneOf0"},
+		{"/components/schemas/Toy/oneOf/0/properties/ball", "ToyOneOf0Ball"},
+		{"/components/responses/Pets/content/application~1json/schema", "PetsResponse"},
+		{"/components/responses/Pets/content/application~1json/schema/properties/page", "PetsResponsePage"},
+		{"/components/requestBodies/PetBody/content/application~1json/schema", "PetBodyRequest"},
+		{"/components/requestBodies/PetBody/content/application~1json/schema/properties/owner", "PetBodyRequestOwner"},
+		{"/components/parameters/Filter/content/application~1json/schema", "FilterParam"},
+	}, names)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			PromoteInlineSchemas: true,
+		},
+	}
+	swagger, err = util.LoadSwagger("test_specs/inline-schemas-components.yaml")
+	require.NoError(t, err)
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	code := output.Code
+	assert.Contains(t, code, "type PetsResponse struct {")
+	assert.Contains(t, code, "type PetBodyRequest struct {")
+	assert.Contains(t, code, "type ToyOneOf0 struct {")
+	assert.Contains(t, code, "Owner *PetOwner `json:\"owner,omitempty\"`")
+
+	checkLint(t, "test.gen.go", []byte(code))
+
+	// With a maximum depth, only those nested too deep are
+	swagger, err = util.LoadSwagger("test_specs/inline-schemas-components.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []SyntheticName{
+		{"/components/responses/Pets/content/application~1json/schema/properties/page", "PetsResponsePage"},
+		{"/components/requestBodies/PetBody/content/application~1json/schema/properties/owner", "PetBodyRequestOwner"},
+	}, ExtractInlineSchemas(swagger, 1))
+
+	opts.OutputOptions = OutputOptions{MaxInlineDepth: 1}
+	swagger, err = util.LoadSwagger("test_specs/inline-schemas-components.yaml")
+	require.NoError(t, err)
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.Code, "Page *PetsResponsePage `json:\"page,omitempty\"`")
+
+	checkLint(t, "test.gen.go", []byte(output.Code))
+}
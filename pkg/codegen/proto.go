@@ -0,0 +1,707 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// ExportProto approximates the spec with the proto3 definitions of a gRPC
+// service in the given package, whose rpcs are annotated with the
+// google.api.http options of gRPC-gateway, to bootstrap a migration between
+// REST and gRPC:
+//   - object component schemas become messages, whose fields are numbered in
+//     the alphabetical order of the properties, and string enums become enums,
+//     whose values are prefixed with the enum name, after an UNSPECIFIED zero
+//   - unions of component schemas become messages with a oneof
+//   - each operation becomes an rpc taking a <Operation>Request message of its
+//     path and query parameters and its JSON body, and returning the message of
+//     its successful JSON response, if any
+//
+// It returns a warning locating, with a JSON pointer, each construct which
+// has no proto equivalent, and was left out or approximated.
+func ExportProto(spec *openapi3.T, packageName string) (string, Diagnostics, error) {
+	if packageName == "" {
+		return "", nil, errors.New("the proto package needs a name")
+	}
+	e := protoExporter{
+		imports:  make(map[string]bool),
+		named:    make(map[*openapi3.Schema]protoType),
+		declared: make(map[string]string),
+		rpcs:     make(map[string]string),
+	}
+
+	// The named types are found first, so that references resolve to them.
+	var components openapi3.Schemas
+	if spec.Components != nil {
+		components = spec.Components.Schemas
+	}
+	for _, name := range SortedSchemaKeys(components) {
+		sref := components[name]
+		if sref.Ref != "" || sref.Value == nil {
+			// References to other components are resolved where they're used.
+			continue
+		}
+		typeName := SchemaNameToTypeName(name)
+		var t protoType
+		switch kind := protoKindOf(sref.Value); kind {
+		case protoEnumKind:
+			t = protoType{name: typeName, scalar: true}
+		case protoMessageKind, protoUnionKind:
+			t = protoType{name: typeName}
+		default:
+			continue
+		}
+		if err := e.declare(typeName, "schema "+name); err != nil {
+			return "", nil, err
+		}
+		e.named[sref.Value] = t
+	}
+	var declarations []protoDecl
+	for _, name := range SortedSchemaKeys(components) {
+		sref := components[name]
+		if sref.Ref != "" || sref.Value == nil {
+			continue
+		}
+		pointer := []string{"components", "schemas", name}
+		typeName := SchemaNameToTypeName(name)
+		switch protoKindOf(sref.Value) {
+		case protoEnumKind:
+			declarations = append(declarations, e.enum(typeName, sref.Value, pointer))
+		case protoMessageKind:
+			declarations = append(declarations, e.message(typeName, sref.Value, pointer))
+		case protoUnionKind:
+			declarations = append(declarations, e.union(typeName, sref.Value, pointer))
+		}
+	}
+
+	var rpcs []protoRPC
+	for _, requestPath := range SortedPathsKeys(spec.Paths) {
+		pathItem := spec.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			rpc, err := e.rpc(requestPath, opName, pathItem, pathOps[opName])
+			if err != nil {
+				return "", nil, err
+			}
+			rpcs = append(rpcs, rpc)
+		}
+	}
+	e.decls = append(e.decls, declarations...)
+
+	service := "API"
+	if spec.Info != nil && ToCamelCase(spec.Info.Title) != "" {
+		service = SchemaNameToTypeName(spec.Info.Title)
+	}
+	if !strings.HasSuffix(service, "Service") {
+		service += "Service"
+	}
+	var description string
+	if spec.Info != nil {
+		description = spec.Info.Description
+	}
+	return e.write(packageName, service, description, rpcs), e.diagnostics, nil
+}
+
+// protoKind is what a schema is exported as, where it's declared.
+type protoKind int
+
+const (
+	protoFieldKind   protoKind = iota // A field type, like a scalar, an array or a map
+	protoEnumKind                     // An enum, for string enums
+	protoMessageKind                  // A message, for objects with properties
+	protoUnionKind                    // A message with a oneof, for unions
+)
+
+func protoKindOf(schema *openapi3.Schema) protoKind {
+	switch {
+	case len(schema.OneOf) != 0 || len(schema.AnyOf) != 0:
+		return protoUnionKind
+	case schema.Type == "string" && len(schema.Enum) != 0:
+		return protoEnumKind
+	case len(protoProperties(schema)) != 0:
+		return protoMessageKind
+	default:
+		return protoFieldKind
+	}
+}
+
+// protoProperties returns the properties of an object, including those of
+// the schemas it's made of with allOf.
+func protoProperties(schema *openapi3.Schema) openapi3.Schemas {
+	properties := make(openapi3.Schemas)
+	for _, part := range schema.AllOf {
+		if part.Value != nil {
+			for name, property := range protoProperties(part.Value) {
+				properties[name] = property
+			}
+		}
+	}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	return properties
+}
+
+// protoRequired returns the required properties of an object, including
+// those of the schemas it's made of with allOf.
+func protoRequired(schema *openapi3.Schema) map[string]bool {
+	required := make(map[string]bool)
+	for _, part := range schema.AllOf {
+		if part.Value != nil {
+			for name := range protoRequired(part.Value) {
+				required[name] = true
+			}
+		}
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	return required
+}
+
+// protoExporter accumulates the definitions and warnings of ExportProto.
+type protoExporter struct {
+	diagnostics Diagnostics
+	imports     map[string]bool
+	// The types of the component schemas declared as enums and messages.
+	named map[*openapi3.Schema]protoType
+	// What declares each top-level name, to report collisions.
+	declared map[string]string
+	// The path and method of the operation of each rpc, to report collisions.
+	rpcs map[string]string
+	// The top-level messages and enums.
+	decls []protoDecl
+	// The operation being exported, if any.
+	operationID string
+}
+
+// protoType is the type of a field.
+type protoType struct {
+	name     string // The type, like int64, Pet or map<string, Pet>
+	repeated bool   // Whether the field is repeated
+	scalar   bool   // Whether the type is a scalar or an enum, which can be optional
+}
+
+// protoDecl is a message or an enum declaration.
+type protoDecl interface {
+	write(b *strings.Builder, indent string)
+}
+
+type protoMessage struct {
+	name        string
+	description string
+	nested      []protoDecl  // The messages and enums declared within the message
+	fields      []protoField // The fields, numbered from 1 in this order
+	oneof       bool         // Whether the fields are the alternatives of a oneof named value
+}
+
+type protoField struct {
+	name        string
+	description string
+	label       string // optional or repeated, if any
+	typ         string
+	jsonName    string // The JSON name, when it's not the default one of the field
+}
+
+type protoEnum struct {
+	name        string
+	description string
+	values      []string // The value names, numbered from 0 in this order
+}
+
+// protoRPC is an rpc, with the google.api.http rule mapping it to its
+// operation.
+type protoRPC struct {
+	name         string
+	description  string
+	request      string
+	response     string
+	method       string // The lower case HTTP method of the rule
+	path         string // The path template of the rule, with field names as variables
+	body         string // The request field of the body, if any
+	responseBody string // The response field of the body, if it's not the whole response
+}
+
+func (e *protoExporter) warn(pointer []string, format string, args ...interface{}) {
+	e.diagnostics = append(e.diagnostics, Diagnostic{
+		OperationID: e.operationID,
+		Path:        jsonPointer(pointer...),
+		Reason:      fmt.Sprintf(format, args...),
+		Warning:     true,
+	})
+}
+
+// declare records the top-level name of a message or enum, failing when
+// it's taken.
+func (e *protoExporter) declare(name, by string) error {
+	if other, found := e.declared[name]; found {
+		return fmt.Errorf("%s and %s have the same proto name %s", other, by, name)
+	}
+	e.declared[name] = by
+	return nil
+}
+
+// unmapped warns about a schema without a proto equivalent, which is
+// approximated as google.protobuf.Value.
+func (e *protoExporter) unmapped(pointer []string, reason string) protoType {
+	e.warn(pointer, "%s, so it's approximated as google.protobuf.Value", reason)
+	e.imports["google/protobuf/struct.proto"] = true
+	return protoType{name: "google.protobuf.Value"}
+}
+
+// fieldType returns the type of a field of the given schema, declaring the
+// messages and enums of its inline objects and enums in parent, named after
+// the field.
+func (e *protoExporter) fieldType(parent *protoMessage, name string, sref *openapi3.SchemaRef, pointer []string) protoType {
+	if sref == nil || sref.Value == nil {
+		return e.unmapped(pointer, "the schema is missing")
+	}
+	schema := sref.Value
+	if t, found := e.named[schema]; found {
+		return t
+	}
+
+	switch {
+	case len(schema.OneOf) != 0 || len(schema.AnyOf) != 0:
+		return e.unmapped(pointer, "inline unions have no proto equivalent")
+	case len(schema.AllOf) == 1 && len(schema.Properties) == 0:
+		return e.fieldType(parent, name, schema.AllOf[0], appendPointer(pointer, "allOf", "0"))
+	case schema.Type == "array":
+		items := e.fieldType(parent, name+"Item", schema.Items, appendPointer(pointer, "items"))
+		if items.repeated || strings.HasPrefix(items.name, "map<") {
+			t := e.unmapped(pointer, "arrays of arrays and maps have no proto equivalent")
+			t.repeated = true
+			return t
+		}
+		items.repeated = true
+		items.scalar = false
+		return items
+	case schema.Type == "object" || len(schema.Properties) != 0 || len(schema.AllOf) != 0:
+		if len(protoProperties(schema)) != 0 {
+			message := e.message(ToCamelCase(name), schema, pointer)
+			parent.nested = append(parent.nested, message)
+			return protoType{name: message.name}
+		}
+		if values := schema.AdditionalProperties.Schema; values != nil {
+			value := e.fieldType(parent, name+"Value", values, appendPointer(pointer, "additionalProperties"))
+			if value.repeated || strings.HasPrefix(value.name, "map<") {
+				return e.unmapped(pointer, "maps of arrays and maps have no proto equivalent")
+			}
+			return protoType{name: fmt.Sprintf("map<string, %s>", value.name)}
+		}
+		e.imports["google/protobuf/struct.proto"] = true
+		return protoType{name: "google.protobuf.Struct"}
+	case schema.Type == "string" && len(schema.Enum) != 0:
+		enum := e.enum(ToCamelCase(name), schema, pointer)
+		parent.nested = append(parent.nested, enum)
+		return protoType{name: enum.name, scalar: true}
+	case schema.Type == "string":
+		switch schema.Format {
+		case "date-time":
+			e.imports["google/protobuf/timestamp.proto"] = true
+			return protoType{name: "google.protobuf.Timestamp"}
+		case "byte", "binary":
+			return protoType{name: "bytes", scalar: true}
+		}
+		return protoType{name: "string", scalar: true}
+	case schema.Type == "integer":
+		if schema.Format == "int32" {
+			return protoType{name: "int32", scalar: true}
+		}
+		return protoType{name: "int64", scalar: true}
+	case schema.Type == "number":
+		if schema.Format == "float" {
+			return protoType{name: "float", scalar: true}
+		}
+		return protoType{name: "double", scalar: true}
+	case schema.Type == "boolean":
+		return protoType{name: "bool", scalar: true}
+	default:
+		return e.unmapped(pointer, "the schema has no type")
+	}
+}
+
+// message returns the message of an object, with a field per property.
+// Additional properties are left out.
+func (e *protoExporter) message(name string, schema *openapi3.Schema, pointer []string) *protoMessage {
+	message := &protoMessage{name: name, description: schema.Description}
+	if schema.AdditionalProperties.Schema != nil {
+		e.warn(pointer, "the additional properties of %s are left out, as messages can't have both fields and a map", name)
+	}
+	properties := protoProperties(schema)
+	required := protoRequired(schema)
+	for _, property := range SortedSchemaKeys(properties) {
+		propertyPointer := appendPointer(pointer, "properties", property)
+		t := e.fieldType(message, property, properties[property], propertyPointer)
+		// Referenced schemas are described where they're declared.
+		var description string
+		if sref := properties[property]; sref.Ref == "" && sref.Value != nil {
+			description = sref.Value.Description
+		}
+		e.addField(message, property, t, required[property], description, propertyPointer)
+	}
+	return message
+}
+
+// addField adds the field of a property, or of a parameter, to a message,
+// named in snake case.
+func (e *protoExporter) addField(message *protoMessage, jsonName string, t protoType, required bool, description string, pointer []string) {
+	field := protoField{
+		name:        protoFieldName(jsonName),
+		description: description,
+		typ:         t.name,
+	}
+	if t.repeated {
+		field.label = "repeated"
+	} else if t.scalar && !required {
+		field.label = "optional"
+	}
+	if protoJSONName(field.name) != jsonName {
+		field.jsonName = jsonName
+	}
+	for _, other := range message.fields {
+		if other.name == field.name {
+			e.warn(pointer, "%s has the same field name %s as another property, so it's left out", jsonName, field.name)
+			return
+		}
+	}
+	message.fields = append(message.fields, field)
+}
+
+// union returns the message of a union, with a oneof of its alternatives,
+// named after their component schemas.
+func (e *protoExporter) union(name string, schema *openapi3.Schema, pointer []string) *protoMessage {
+	message := &protoMessage{name: name, description: schema.Description, oneof: true}
+	alternatives, keyword := schema.OneOf, "oneOf"
+	if len(alternatives) == 0 {
+		alternatives, keyword = schema.AnyOf, "anyOf"
+		e.warn(pointer, "%s allows several of its alternatives at once, unlike its oneof", name)
+	}
+	if len(schema.Properties) != 0 {
+		e.warn(pointer, "the properties of %s are left out, as only its alternatives make its oneof", name)
+	}
+	e.warn(pointer, "%s is encoded as an object with a field named after its alternative, unlike the union", name)
+	for i, alternative := range alternatives {
+		alternativePointer := appendPointer(pointer, keyword, strconv.Itoa(i))
+		fieldName := fmt.Sprintf("option_%d", i+1)
+		if alternative.Ref != "" {
+			fieldName = toSnakeCase(alternative.Ref[strings.LastIndex(alternative.Ref, "/")+1:])
+		}
+		t := e.fieldType(message, fieldName, alternative, alternativePointer)
+		if t.repeated || strings.HasPrefix(t.name, "map<") {
+			t = e.unmapped(alternativePointer, "arrays and maps can't be alternatives of a oneof")
+		}
+		t.scalar = false
+		e.addField(message, fieldName, t, true, "", alternativePointer)
+	}
+	return message
+}
+
+// enum returns the enum of a string enum, whose value names are prefixed
+// with the upper snake case name of the enum, after an UNSPECIFIED zero
+// value, as proto3 requires.
+func (e *protoExporter) enum(name string, schema *openapi3.Schema, pointer []string) *protoEnum {
+	prefix := strings.ToUpper(toSnakeCase(name)) + "_"
+	enum := &protoEnum{
+		name:        name,
+		description: schema.Description,
+		values:      []string{prefix + "UNSPECIFIED"},
+	}
+	for i, value := range schema.Enum {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		valueName := prefix + strings.ToUpper(toSnakeCase(s))
+		if s == "" {
+			valueName = prefix + "EMPTY"
+		}
+		if StringInArray(valueName, enum.values) {
+			e.warn(appendPointer(pointer, "enum", strconv.Itoa(i)), "enum value %q has the same proto name %s as another one, so it's left out", s, valueName)
+			continue
+		}
+		enum.values = append(enum.values, valueName)
+	}
+	return enum
+}
+
+// rpc returns the rpc of an operation, declaring its request message, and
+// its response message unless it returns a named message, or nothing.
+func (e *protoExporter) rpc(requestPath, opName string, pathItem *openapi3.PathItem, op *openapi3.Operation) (protoRPC, error) {
+	pointer := []string{"paths", requestPath, strings.ToLower(opName)}
+	name := ToCamelCase(op.OperationID)
+	if op.OperationID == "" {
+		var err error
+		if name, err = generateDefaultOperationID(opName, requestPath, ToCamelCase); err != nil {
+			return protoRPC{}, err
+		}
+	}
+	e.operationID = op.OperationID
+	defer func() { e.operationID = "" }()
+
+	rpc := protoRPC{
+		name:        name,
+		description: op.Summary,
+		request:     name + "Request",
+		method:      strings.ToLower(opName),
+		path:        requestPath,
+	}
+	if rpc.description == "" {
+		rpc.description = op.Description
+	}
+	if other, found := e.rpcs[name]; found {
+		return protoRPC{}, fmt.Errorf("operations %s and %s %s have the same rpc name %s", other, opName, requestPath, name)
+	}
+	e.rpcs[name] = opName + " " + requestPath
+	if err := e.declare(rpc.request, "operation "+name); err != nil {
+		return protoRPC{}, err
+	}
+
+	request := &protoMessage{name: rpc.request}
+	e.decls = append(e.decls, request)
+
+	// The parameters of the operation override those of the path.
+	var params []*openapi3.ParameterRef
+	var paramPointers [][]string
+	for i, param := range op.Parameters {
+		params = append(params, param)
+		paramPointers = append(paramPointers, appendPointer(pointer, "parameters", strconv.Itoa(i)))
+	}
+	for i, param := range pathItem.Parameters {
+		if param.Value != nil && op.Parameters.GetByInAndName(param.Value.In, param.Value.Name) == nil {
+			params = append(params, param)
+			paramPointers = append(paramPointers, []string{"paths", requestPath, "parameters", strconv.Itoa(i)})
+		}
+	}
+	for i, param := range params {
+		p := param.Value
+		if p == nil {
+			continue
+		}
+		switch p.In {
+		case openapi3.ParameterInPath, openapi3.ParameterInQuery:
+			schema := p.Schema
+			if schema == nil {
+				for _, contentType := range SortedContentKeys(p.Content) {
+					schema = p.Content[contentType].Schema
+					break
+				}
+			}
+			t := e.fieldType(request, p.Name, schema, appendPointer(paramPointers[i], "schema"))
+			e.addField(request, p.Name, t, p.Required, p.Description, paramPointers[i])
+			if p.In == openapi3.ParameterInPath {
+				rpc.path = strings.ReplaceAll(rpc.path, "{"+p.Name+"}", "{"+protoFieldName(p.Name)+"}")
+			}
+		default:
+			e.warn(paramPointers[i], "the %s parameter %s is left out of %s, as gRPC passes it as metadata", p.In, p.Name, request.name)
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		bodyPointer := appendPointer(pointer, "requestBody")
+		content := op.RequestBody.Value.Content
+		if contentType, mediaType := protoJSONContent(content); mediaType != nil {
+			t := e.fieldType(request, "Body", mediaType.Schema, appendPointer(bodyPointer, "content", contentType, "schema"))
+			e.addField(request, "body", t, op.RequestBody.Value.Required, op.RequestBody.Value.Description, bodyPointer)
+			rpc.body = "body"
+		} else if len(content) != 0 {
+			e.warn(bodyPointer, "the %s body is left out of %s, as only JSON bodies are exported", strings.Join(SortedContentKeys(content), ", "), request.name)
+		}
+	}
+
+	rpc.response = "google.protobuf.Empty"
+	for _, code := range SortedResponsesKeys(op.Responses) {
+		if len(code) != 3 || code[0] != '2' {
+			continue
+		}
+		response := op.Responses[code].Value
+		if response == nil {
+			break
+		}
+		responsePointer := appendPointer(pointer, "responses", code)
+		contentType, mediaType := protoJSONContent(response.Content)
+		if mediaType == nil {
+			if len(response.Content) != 0 {
+				e.warn(responsePointer, "the %s response is left out of %s, as only JSON responses are exported", strings.Join(SortedContentKeys(response.Content), ", "), name)
+			}
+			break
+		}
+		if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+			if t, found := e.named[mediaType.Schema.Value]; found && !t.scalar {
+				rpc.response = t.name
+				return rpc, nil
+			}
+		}
+		rpc.response = name + "Response"
+		if err := e.declare(rpc.response, "operation "+name); err != nil {
+			return protoRPC{}, err
+		}
+		message := &protoMessage{name: rpc.response}
+		t := e.fieldType(message, "Body", mediaType.Schema, appendPointer(responsePointer, "content", contentType, "schema"))
+		var description string
+		if response.Description != nil {
+			description = *response.Description
+		}
+		e.addField(message, "body", t, true, description, responsePointer)
+		e.decls = append(e.decls, message)
+		rpc.responseBody = "body"
+		return rpc, nil
+	}
+	e.imports["google/protobuf/empty.proto"] = true
+	return rpc, nil
+}
+
+// protoJSONContent returns the first JSON media type of the content, if any.
+func protoJSONContent(content openapi3.Content) (string, *openapi3.MediaType) {
+	for _, contentType := range SortedContentKeys(content) {
+		if util.IsMediaTypeJson(contentType) {
+			return contentType, content[contentType]
+		}
+	}
+	return "", nil
+}
+
+// protoFieldName converts a property or parameter name to a field name, in
+// snake case.
+func protoFieldName(name string) string {
+	field := toSnakeCase(name)
+	if field == "" || !unicode.IsLetter(rune(field[0])) {
+		field = "field_" + field
+	}
+	return field
+}
+
+// protoJSONName returns the JSON name protoc gives a field, in lower camel
+// case.
+func protoJSONName(field string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// appendPointer returns a copy of a JSON pointer, extended with the tokens.
+func appendPointer(pointer []string, tokens ...string) []string {
+	return append(append([]string(nil), pointer...), tokens...)
+}
+
+func (e *protoExporter) write(packageName, service, description string, rpcs []protoRPC) string {
+	var b strings.Builder
+	b.WriteString("// Generated by oapi-codegen, approximating an OpenAPI spec. Review it before\n")
+	b.WriteString("// relying on it, as the field numbers follow the order of the properties.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", packageName)
+
+	imports := []string{"google/api/annotations.proto"}
+	for imported := range e.imports {
+		imports = append(imports, imported)
+	}
+	sort.Strings(imports)
+	for _, imported := range imports {
+		fmt.Fprintf(&b, "import %q;\n", imported)
+	}
+	b.WriteString("\n")
+
+	writeProtoComment(&b, "", description)
+	fmt.Fprintf(&b, "service %s {\n", service)
+	for i, rpc := range rpcs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeProtoComment(&b, "  ", rpc.description)
+		fmt.Fprintf(&b, "  rpc %s(%s) returns (%s) {\n", rpc.name, rpc.request, rpc.response)
+		b.WriteString("    option (google.api.http) = {\n")
+		switch rpc.method {
+		case "get", "put", "post", "delete", "patch":
+			fmt.Fprintf(&b, "      %s: %q\n", rpc.method, rpc.path)
+		default:
+			fmt.Fprintf(&b, "      custom: {\n        kind: %q\n        path: %q\n      }\n", strings.ToUpper(rpc.method), rpc.path)
+		}
+		if rpc.body != "" {
+			fmt.Fprintf(&b, "      body: %q\n", rpc.body)
+		}
+		if rpc.responseBody != "" {
+			fmt.Fprintf(&b, "      response_body: %q\n", rpc.responseBody)
+		}
+		b.WriteString("    };\n  }\n")
+	}
+	b.WriteString("}\n")
+
+	for _, decl := range e.decls {
+		b.WriteString("\n")
+		decl.write(&b, "")
+	}
+	return b.String()
+}
+
+func (m *protoMessage) write(b *strings.Builder, indent string) {
+	writeProtoComment(b, indent, m.description)
+	fmt.Fprintf(b, "%smessage %s {\n", indent, m.name)
+	for _, decl := range m.nested {
+		decl.write(b, indent+"  ")
+		b.WriteString("\n")
+	}
+	fieldIndent := indent + "  "
+	if m.oneof {
+		fmt.Fprintf(b, "%soneof value {\n", fieldIndent)
+		fieldIndent += "  "
+	}
+	for i, field := range m.fields {
+		writeProtoComment(b, fieldIndent, field.description)
+		b.WriteString(fieldIndent)
+		if field.label != "" {
+			b.WriteString(field.label + " ")
+		}
+		fmt.Fprintf(b, "%s %s = %d", field.typ, field.name, i+1)
+		if field.jsonName != "" {
+			fmt.Fprintf(b, " [json_name = %q]", field.jsonName)
+		}
+		b.WriteString(";\n")
+	}
+	if m.oneof {
+		fmt.Fprintf(b, "%s  }\n", indent)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func (en *protoEnum) write(b *strings.Builder, indent string) {
+	writeProtoComment(b, indent, en.description)
+	fmt.Fprintf(b, "%senum %s {\n", indent, en.name)
+	for i, value := range en.values {
+		fmt.Fprintf(b, "%s  %s = %d;\n", indent, value, i)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// writeProtoComment writes a description as a comment, line by line.
+func writeProtoComment(b *strings.Builder, indent, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(b, "%s//", indent)
+		if line = strings.TrimRight(line, " \t"); line != "" {
+			b.WriteString(" " + line)
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestExportProto(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/proto.yaml")
+	require.NoError(t, err)
+
+	_, _, err = ExportProto(swagger, "")
+	assert.EqualError(t, err, "the proto package needs a name")
+
+	proto, diagnostics, err := ExportProto(swagger, "petstore")
+	require.NoError(t, err)
+	assert.Contains(t, proto, "syntax = \"proto3\";\n\npackage petstore;\n\nimport \"google/api/annotations.proto\";\nimport \"google/protobuf/empty.proto\";")
+
+	// Each operation is an rpc, mapped to it by a google.api.http rule
+	assert.Contains(t, proto, "// Manages the pets of a store.\nservice PetStoreService {")
+	assert.Contains(t, proto, `  // Returns the pets
+  rpc FindPets(FindPetsRequest) returns (FindPetsResponse) {
+    option (google.api.http) = {
+      get: "/pets"
+      response_body: "body"
+    };
+  }`)
+	assert.Contains(t, proto, `  rpc AddPet(AddPetRequest) returns (Pet) {
+    option (google.api.http) = {
+      post: "/pets"
+      body: "body"
+    };
+  }`)
+	assert.Contains(t, proto, "  rpc DeletePet(DeletePetRequest) returns (google.protobuf.Empty) {\n    option (google.api.http) = {\n      delete: \"/pets/{pet_id}\"")
+	assert.Contains(t, proto, "message FindPetsRequest {\n  repeated string tags = 1;\n  optional int32 limit = 2;\n}")
+	assert.Contains(t, proto, "message FindPetsResponse {\n  // The pets\n  repeated Pet body = 1;\n}")
+	assert.Contains(t, proto, "message DeletePetRequest {\n  int64 pet_id = 1;\n}")
+
+	// Objects are messages, merging allOf, and string enums are enums
+	assert.Contains(t, proto, `message Pet {
+  message Owner {
+    optional string name = 1;
+  }
+
+  enum Size {
+    SIZE_UNSPECIFIED = 0;
+    SIZE_SMALL = 1;
+    SIZE_EXTRA_LARGE = 2;
+  }
+
+  google.protobuf.Timestamp born = 1;
+  repeated google.protobuf.Value grid = 2;
+  int64 id = 3;
+  optional Kind kind = 4;
+  map<string, string> labels = 5;
+  // The name of the pet
+  string name = 6;
+  Owner owner = 7;
+  repeated string photo_urls = 8;
+  optional Size size = 9;
+}`)
+	assert.Contains(t, proto, "// The kind of a pet\nenum Kind {\n  KIND_UNSPECIFIED = 0;\n  KIND_CAT = 1;\n  KIND_DOG = 2;\n}")
+	assert.Contains(t, proto, "message Animal {\n  oneof value {\n    Pet pet = 1;\n    Kind kind = 2;\n  }\n}")
+
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Animal: Animal is encoded as an object with a field named after its alternative, unlike the union",
+		"warning: /components/schemas/NewPet/properties/grid: arrays of arrays and maps have no proto equivalent, so it's approximated as google.protobuf.Value",
+		"warning: /components/schemas/Pet/properties/grid: arrays of arrays and maps have no proto equivalent, so it's approximated as google.protobuf.Value",
+		"warning: operation findPets: /paths/~1pets/get/parameters/2: the header parameter X-Request-ID is left out of FindPetsRequest, as gRPC passes it as metadata",
+		"warning: operation uploadPhoto: /paths/~1pets~1{petId}/put/requestBody: the image/png body is left out of UploadPhotoRequest, as only JSON bodies are exported",
+	}, got)
+}
+
+func TestExportProtoCollisions(t *testing.T) {
+	swagger := &openapi3.T{
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"AddPetRequest": openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema()).NewRef(),
+			},
+		},
+		Paths: openapi3.Paths{
+			"/pets": &openapi3.PathItem{
+				Post: &openapi3.Operation{OperationID: "addPet", Responses: openapi3.NewResponses()},
+			},
+		},
+	}
+	_, _, err := ExportProto(swagger, "petstore")
+	assert.EqualError(t, err, "schema AddPetRequest and operation AddPet have the same proto name AddPetRequest")
+}
+
+func TestProtoJSONName(t *testing.T) {
+	assert.Equal(t, "photoUrls", protoJSONName("photo_urls"))
+	assert.Equal(t, "id", protoJSONName("id"))
+}
@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ProvenanceDefinition describes what the code was generated from, recorded
+// in the header of the output file and returned by the generated
+// GeneratedFrom function.
+type ProvenanceDefinition struct {
+	Generator        string // The module path of the generator
+	GeneratorVersion string // The version of the generator
+	SpecTitle        string // The info.title of the spec
+	SpecVersion      string // The info.version of the spec
+	SpecHash         string // The SHA-256 hash of the spec, as sha256:<hex>
+	ConfigHash       string // The SHA-256 hash of the configuration, as sha256:<hex>
+	GeneratedAt      int64  // The Unix time of the generation, with the timestamp option, or 0
+}
+
+// specProvenance returns what the code is generated from, hashing the spec
+// before generation changes it, or nil without the provenance output option.
+func specProvenance(spec *openapi3.T, opts Configuration) (*ProvenanceDefinition, error) {
+	options := opts.OutputOptions.Provenance
+	if options == nil {
+		return nil, nil
+	}
+
+	specJSON, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling spec: %w", err)
+	}
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling configuration: %w", err)
+	}
+
+	provenance := &ProvenanceDefinition{
+		SpecHash:   sha256Hash(specJSON),
+		ConfigHash: sha256Hash(optsJSON),
+	}
+	provenance.Generator, provenance.GeneratorVersion = generatorVersion(opts.NoVCSVersionOverride)
+	if spec.Info != nil {
+		provenance.SpecTitle = spec.Info.Title
+		provenance.SpecVersion = spec.Info.Version
+	}
+	if options.Timestamp {
+		provenance.GeneratedAt = time.Now().Unix()
+	}
+	return provenance, nil
+}
+
+// sha256Hash returns the SHA-256 hash of data, as sha256:<hex>.
+func sha256Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// GeneratedTime returns the time of the generation, formatted for the header
+// of the output file, or "" when it isn't recorded.
+func (p ProvenanceDefinition) GeneratedTime() string {
+	if p.GeneratedAt == 0 {
+		return ""
+	}
+	return time.Unix(p.GeneratedAt, 0).UTC().Format(time.RFC3339)
+}
+
+// GenerateProvenance generates the Provenance type, and the GeneratedFrom
+// function returning what the code was generated from.
+func GenerateProvenance(t *template.Template) (string, error) {
+	return GenerateTemplates([]string{"provenance.tmpl"}, t, globalState.provenance)
+}
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -139,6 +140,9 @@ func walkSchemaRef(ref *openapi3.SchemaRef, doFn func(RefWrapper) (bool, error))
 
 	_ = walkSchemaRef(ref.Value.Not, doFn)
 	_ = walkSchemaRef(ref.Value.Items, doFn)
+	for _, ref := range prefixItems(ref.Value) {
+		_ = walkSchemaRef(ref, doFn)
+	}
 
 	for _, ref := range ref.Value.Properties {
 		_ = walkSchemaRef(ref, doFn)
@@ -476,12 +480,95 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	return countRemoved
 }
 
+// pruneUnusedComponents removes the components which the operations of the
+// spec don't reach, through the components they reference, so that those
+// referencing each other, but unused, go too.
 func pruneUnusedComponents(swagger *openapi3.T) {
-	for {
-		refs := findComponentRefs(swagger)
-		countRemoved := removeOrphanedComponents(swagger, refs)
-		if countRemoved < 1 {
-			break
+	removeOrphanedComponents(swagger, findReachableComponentRefs(swagger))
+}
+
+// findReachableComponentRefs returns the references to the components which
+// the operations of the spec reach, following the references of the
+// components they reference in turn.
+func findReachableComponentRefs(swagger *openapi3.T) []string {
+	var refs []string
+	reached := make(map[string]bool)
+	var pending []string
+	collect := func(ref RefWrapper) (bool, error) {
+		if ref.Ref == "" {
+			return true, nil
+		}
+		component := componentRef(ref.Ref)
+		if component == "" {
+			// Another document, or not a component
+			refs = append(refs, ref.Ref)
+		} else if !reached[component] {
+			reached[component] = true
+			refs = append(refs, component)
+			pending = append(pending, component)
+		}
+		return false, nil
+	}
+
+	for _, p := range swagger.Paths {
+		for _, param := range p.Parameters {
+			_ = walkParameterRef(param, collect)
 		}
+		for _, op := range p.Operations() {
+			_ = walkOperation(op, collect)
+		}
+	}
+	// The payloads of the messages of the AsyncAPI documents
+	for _, op := range globalState.asyncOperations {
+		_ = walkSchemaRef(op.payload, collect)
 	}
+
+	for len(pending) != 0 {
+		ref := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		_ = walkComponent(swagger.Components, ref, collect)
+	}
+	return refs
+}
+
+// componentRef returns the reference to the component a reference points
+// into, like #/components/schemas/Pet for #/components/schemas/Pet/properties/id,
+// or "" when it doesn't point into a component of the spec.
+func componentRef(ref string) string {
+	parts := strings.SplitN(ref, "/", 5)
+	if len(parts) < 4 || parts[0] != "#" || parts[1] != "components" {
+		return ""
+	}
+	return strings.Join(parts[:4], "/")
+}
+
+// walkComponent walks the component of the spec a reference made by
+// componentRef points to.
+func walkComponent(components *openapi3.Components, ref string, doFn func(RefWrapper) (bool, error)) error {
+	if components == nil {
+		return nil
+	}
+	parts := strings.Split(ref, "/")
+	kind, name := parts[2], parts[3]
+	switch kind {
+	case "schemas":
+		return walkSchemaRef(components.Schemas[name], doFn)
+	case "parameters":
+		return walkParameterRef(components.Parameters[name], doFn)
+	case "headers":
+		return walkHeaderRef(components.Headers[name], doFn)
+	case "requestBodies":
+		return walkRequestBodyRef(components.RequestBodies[name], doFn)
+	case "responses":
+		return walkResponseRef(components.Responses[name], doFn)
+	case "securitySchemes":
+		return walkSecuritySchemeRef(components.SecuritySchemes[name], doFn)
+	case "examples":
+		return walkExampleRef(components.Examples[name], doFn)
+	case "links":
+		return walkLinkRef(components.Links[name], doFn)
+	case "callbacks":
+		return walkCallbackRef(components.Callbacks[name], doFn)
+	}
+	return nil
 }
@@ -136,6 +136,84 @@ func TestPruningUnusedComponents(t *testing.T) {
 	assert.Len(t, swagger.Components.Callbacks, 0)
 }
 
+func TestPruningUnreachableComponents(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneReachabilityTestFixture))
+	assert.NoError(t, err)
+
+	pruneUnusedComponents(swagger)
+
+	// Reached through the operation, other schemas, and a reference into a schema
+	assert.Contains(t, swagger.Components.Schemas, "Pet")
+	assert.Contains(t, swagger.Components.Schemas, "Owner")
+	assert.Contains(t, swagger.Components.Schemas, "Tag")
+	assert.Contains(t, swagger.Components.Parameters, "Limit")
+	// Referencing each other, but unused
+	assert.NotContains(t, swagger.Components.Schemas, "Parent")
+	assert.NotContains(t, swagger.Components.Schemas, "Child")
+	assert.Len(t, swagger.Components.Schemas, 3)
+}
+
+const pruneReachabilityTestFixture = `
+openapi: 3.0.1
+info:
+  title: Reachability
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: "#/components/parameters/Limit"
+      responses:
+        200:
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: "#/components/schemas/Owner"
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: "#/components/schemas/Pet"
+        tags:
+          $ref: "#/components/schemas/Tag/properties/names"
+    Tag:
+      type: object
+      properties:
+        names:
+          type: array
+          items:
+            type: string
+    Parent:
+      type: object
+      properties:
+        child:
+          $ref: "#/components/schemas/Child"
+    Child:
+      type: object
+      properties:
+        parent:
+          $ref: "#/components/schemas/Parent"
+`
+
 const pruneComprehensiveTestFixture = `
 openapi: 3.0.1
 
@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// containment is an edge of the graph of the component schemas whose types
+// contain others by value.
+type containment struct {
+	to string // The name of the contained component schema
+	// The property whose field contains it, or nil when the type is the other
+	// one, through a $ref or allOf, which no pointer can break
+	property *openapi3.SchemaRef
+}
+
+// recursiveProperties finds the properties of the component schemas whose
+// types would contain themselves by value, like a required next property of a
+// linked list node, which Go rejects as invalid recursive types. The fields of
+// those properties are generated as pointers. Properties are chosen in the
+// order of the schemas, so that the field pointing back to the first schema
+// of a cycle becomes a pointer.
+//
+// Cycles which no property breaks, like schemas composing themselves with
+// allOf, are errors.
+func recursiveProperties(spec *openapi3.T) (map[*openapi3.SchemaRef]bool, error) {
+	if spec.Components == nil {
+		return nil, nil
+	}
+	schemas := spec.Components.Schemas
+	graph := make(map[string][]containment, len(schemas))
+	for name, ref := range schemas {
+		graph[name] = containedSchemas(ref)
+	}
+
+	recursive := make(map[*openapi3.SchemaRef]bool)
+	for {
+		cycle := findCycle(graph, SortedSchemaKeys(schemas))
+		if cycle == nil {
+			return recursive, nil
+		}
+		// The last property of the cycle is the one pointing back to where
+		// the search started.
+		broken := -1
+		for i := len(cycle) - 1; i >= 0 && broken < 0; i-- {
+			if cycle[i].property != nil {
+				broken = i
+			}
+		}
+		if broken < 0 {
+			names := []string{cycle[len(cycle)-1].to}
+			for _, edge := range cycle {
+				names = append(names, edge.to)
+			}
+			return nil, fmt.Errorf("schema %s contains itself through %s, with no property which could be a pointer",
+				names[0], strings.Join(names, " -> "))
+		}
+		recursive[cycle[broken].property] = true
+
+		// The property no longer contains the schema.
+		from := cycle[len(cycle)-1].to
+		if broken > 0 {
+			from = cycle[broken-1].to
+		}
+		edges := graph[from]
+		for i, edge := range edges {
+			if edge.property == cycle[broken].property {
+				graph[from] = append(edges[:i:i], edges[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// findCycle returns the edges of the first cycle of the graph found visiting
+// the schemas in order, or nil if there's none. The last edge leads back to
+// the schema the first one starts from.
+func findCycle(graph map[string][]containment, names []string) []containment {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(names))
+	var path []containment
+	var visit func(name string) []containment
+	visit = func(name string) []containment {
+		state[name] = visiting
+		for _, edge := range graph[name] {
+			path = append(path, edge)
+			switch state[edge.to] {
+			case visiting:
+				// The cycle starts at the edge leaving the schema reached.
+				for i := len(path) - 1; i > 0; i-- {
+					if path[i-1].to == edge.to {
+						return path[i:]
+					}
+				}
+				return path
+			case 0:
+				if cycle := visit(edge.to); cycle != nil {
+					return cycle
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range names {
+		if state[name] == 0 {
+			path = path[:0]
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// containedSchemas returns the component schemas which the type of a schema
+// contains by value, as fields which aren't pointers, or by being them.
+// Arrays, maps and unions hold their values indirectly, and contain nothing.
+func containedSchemas(ref *openapi3.SchemaRef) []containment {
+	if ref == nil {
+		return nil
+	}
+	if ref.Ref != "" {
+		// The types of other documents are in other packages.
+		if name, ok := localSchemaName(ref.Ref); ok {
+			return []containment{{to: name}}
+		}
+		return nil
+	}
+	schema := ref.Value
+	if schema == nil {
+		return nil
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return nil
+	}
+
+	var result []containment
+	for _, part := range schema.AllOf {
+		result = append(result, containedSchemas(part)...)
+	}
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		property := schema.Properties[name]
+		if property.Value == nil || !propertyByValue(property.Value, StringInArray(name, schema.Required)) {
+			continue
+		}
+		if target, ok := localSchemaName(property.Ref); ok {
+			result = append(result, containment{to: target, property: property})
+			continue
+		}
+		// Inline objects are structs within the struct.
+		result = append(result, containedSchemas(property)...)
+	}
+	return result
+}
+
+// propertyByValue returns whether the field of a property is generated
+// without a pointer, like Property.GoTypeDef decides.
+func propertyByValue(schema *openapi3.Schema, required bool) bool {
+	if extension, ok := schema.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+		if skip, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil && skip {
+			return true
+		}
+	}
+	readOnlyPointer := schema.ReadOnly && (!required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
+	return required && !schema.Nullable && !readOnlyPointer && !schema.WriteOnly
+}
+
+// localSchemaName returns the name of the component schema a $ref of the spec
+// itself references.
+func localSchemaName(ref string) (string, bool) {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	return ref[len(prefix):], true
+}
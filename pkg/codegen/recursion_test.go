@@ -0,0 +1,91 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestRecursiveSchemas(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/recursive.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			ChiServer:    true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Arrays and maps need no pointer
+	assert.Contains(t, code, "Children []Tree           `json:\"children\"`")
+	// Required properties containing their own struct become pointers
+	assert.Contains(t, code, "Next  *List `json:\"next\"`")
+	assert.Contains(t, code, "Left *Expr `json:\"left\"`")
+	assert.Contains(t, code, "Manager *Employee `json:\"manager\"`")
+	// The property pointing back to the first schema of a cycle does
+	assert.Contains(t, code, "B B `json:\"b\"`")
+	assert.Contains(t, code, "A *A `json:\"a\"`")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestRecursiveSchemasUnresolvable(t *testing.T) {
+	specs := map[string]string{
+		"allOf of itself": `
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Loop}
+paths: {}
+components:
+  schemas:
+    Loop:
+      allOf:
+        - $ref: '#/components/schemas/Loop'
+        - type: object
+          properties:
+            name:
+              type: string
+`,
+		"aliases of each other": `
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Loop}
+paths: {}
+components:
+  schemas:
+    A:
+      allOf:
+        - $ref: '#/components/schemas/B'
+    B:
+      allOf:
+        - $ref: '#/components/schemas/A'
+`,
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+			require.NoError(t, err)
+
+			_, err = Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate:    GenerateOptions{Models: true},
+				OutputOptions: OutputOptions{
+					SkipPrune: true,
+				},
+			})
+			assert.ErrorContains(t, err, "with no property which could be a pointer")
+		})
+	}
+}
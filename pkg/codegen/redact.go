@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"strings"
+	"text/template"
+)
+
+// RedactedType describes the String and LogValue methods of a struct type
+// with sensitive properties, which redact their values.
+type RedactedType struct {
+	TypeName string
+	Fields   []RedactedField
+}
+
+// RedactedField is a field of a RedactedType.
+type RedactedField struct {
+	Name      string // The name of the field, which String prints
+	Key       string // The JSON name of the field, the key of its LogValue attribute
+	Pointer   bool   // Whether the field is a pointer, whose value is printed
+	Sensitive bool   // Whether the value of the field is redacted
+}
+
+// Sensitive returns whether the values of the property are sensitive, from
+// its x-sensitive, which is that of the parameter for the fields of the
+// parameter objects, or those of its schema.
+func (p Property) Sensitive() bool {
+	if extension, ok := p.Extensions[extSensitive]; ok {
+		sensitive, _ := extParseSensitive(extension)
+		return sensitive
+	}
+	if p.Schema.OAPISchema == nil {
+		return false
+	}
+	sensitive, _ := sensitiveSchema(p.Schema.OAPISchema)
+	return sensitive
+}
+
+// sensitiveAdditionalProperties returns whether the values of the additional
+// properties of the schema are sensitive, from the x-sensitive of their
+// schema.
+func (s Schema) sensitiveAdditionalProperties() bool {
+	if !s.HasAdditionalProperties || s.AdditionalPropertiesType == nil || s.AdditionalPropertiesType.OAPISchema == nil {
+		return false
+	}
+	sensitive, _ := sensitiveSchema(s.AdditionalPropertiesType.OAPISchema)
+	return sensitive
+}
+
+// hasSensitiveProperties returns whether the schema is an object with
+// sensitive properties, or sensitive additional ones, whose type gets the
+// String and LogValue methods.
+func (s Schema) hasSensitiveProperties() bool {
+	for _, p := range s.Properties {
+		if p.Sensitive() {
+			return true
+		}
+	}
+	return s.sensitiveAdditionalProperties()
+}
+
+// GenerateRedaction generates the String and LogValue methods of the struct
+// types with sensitive properties, printing and logging them with the values
+// of those redacted.
+func GenerateRedaction(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var types []RedactedType
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		if seen[td.TypeName] || td.IsAlias() || td.Schema.IsRef() || len(td.Schema.UnionElements) != 0 ||
+			!strings.HasPrefix(td.Schema.GoType, "struct") || !td.Schema.hasSensitiveProperties() {
+			continue
+		}
+		seen[td.TypeName] = true
+		redacted := RedactedType{TypeName: td.TypeName}
+		for _, p := range td.Schema.Properties {
+			if p.JsonIgnored() {
+				continue
+			}
+			redacted.Fields = append(redacted.Fields, RedactedField{
+				Name:      p.structFieldName(),
+				Key:       p.JsonFieldName,
+				Pointer:   strings.HasPrefix(p.structFieldType(), "*"),
+				Sensitive: p.Sensitive(),
+			})
+		}
+		if td.Schema.HasAdditionalProperties {
+			redacted.Fields = append(redacted.Fields, RedactedField{
+				Name:      "AdditionalProperties",
+				Key:       "additionalProperties",
+				Sensitive: td.Schema.sensitiveAdditionalProperties(),
+			})
+		}
+		types = append(types, redacted)
+	}
+	if len(types) == 0 {
+		return "", nil
+	}
+	if err := checkGoVersion(globalState.options.OutputOptions.GoVersion, "x-sensitive", 21, "log/slog"); err != nil {
+		return "", err
+	}
+	return GenerateTemplates([]string{"redaction.tmpl"}, t, types)
+}
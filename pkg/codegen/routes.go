@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// RouteDefinition describes an entry of the route table of the operations.
+type RouteDefinition struct {
+	OperationId string     // The ID of the operation, the value of its Operation constant
+	Method      string     // GET, POST, DELETE, etc.
+	Path        string     // The path template, like /pets/{id}
+	Tags        []string   // The tags of the operation, in the order of the spec
+	Scopes      [][]string // The scopes named by each of the security requirements of the operation, any of which suffice
+	PathPattern string     // The regular expression matching the paths of the requests, capturing the path parameters
+	PathParams  []string   // The names of the path parameters, in the order of the path
+}
+
+// describeRoutes returns the route table of the operations, those of the
+// most specific paths first: those with more segments, and then those with
+// fewer parameters, like /pets/mine rather than /pets/{id}.
+func describeRoutes(ops []OperationDefinition) []RouteDefinition {
+	routes := make([]RouteDefinition, 0, len(ops))
+	for _, op := range ops {
+		route := RouteDefinition{
+			OperationId: op.OperationId,
+			Method:      op.Method,
+			Path:        op.Path,
+			Scopes:      op.ScopeAlternatives(),
+		}
+		if op.Spec != nil {
+			route.Tags = op.Spec.Tags
+		}
+		for _, param := range pathParamRegexp.FindAllString(op.Path, -1) {
+			route.PathParams = append(route.PathParams, param[1:len(param)-1])
+		}
+		route.PathPattern = capturingPathPattern(&op, op.Path, route.PathParams)
+		routes = append(routes, route)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		si, sj := strings.Count(routes[i].Path, "/"), strings.Count(routes[j].Path, "/")
+		if si != sj {
+			return si > sj
+		}
+		return len(routes[i].PathParams) < len(routes[j].PathParams)
+	})
+	return routes
+}
+
+// GenerateRoutes generates the route table of the operations, with
+// RouteForRequest matching requests with them.
+func GenerateRoutes(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"routes.tmpl"}, t, describeRoutes(ops))
+}
+
+// GenerateOperationIDs generates the constants of the IDs of the operations,
+// shared by the route table and the hooks of the client.
+func GenerateOperationIDs(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"operation-ids.tmpl"}, t, ops)
+}
@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestDescribeRoutes(t *testing.T) {
+	routes := describeRoutes([]OperationDefinition{
+		{OperationId: "GetPet", Method: "GET", Path: "/pets/{id}"},
+		{OperationId: "ListPets", Method: "GET", Path: "/pets"},
+		{OperationId: "ListMyPets", Method: "GET", Path: "/pets/mine"},
+		{OperationId: "GetOwnerPet", Method: "GET", Path: "/owners/{ownerId}/pets/{id}"},
+	})
+
+	// The most specific paths come first
+	var ids []string
+	for _, route := range routes {
+		ids = append(ids, route.OperationId)
+	}
+	assert.Equal(t, []string{"GetOwnerPet", "ListMyPets", "GetPet", "ListPets"}, ids)
+
+	assert.Equal(t, []string{"ownerId", "id"}, routes[0].PathParams)
+	assert.Equal(t, `^/owners/([^/]*)/pets/([^/]*)$`, routes[0].PathPattern)
+	assert.Equal(t, `^/pets$`, routes[3].PathPattern)
+
+	// The paths may start with the base paths of the servers, and are escaped
+	routes = describeRoutes([]OperationDefinition{{
+		OperationId: "GetFile",
+		Method:      "GET",
+		Path:        "/my files/{name}",
+		Servers: []ServerDefinition{
+			{URL: "https://api.example.com/v1/"},
+			{URL: "https://{host}/{version}", Variables: []ServerVariableDefinition{{Name: "version", Default: "v2", Enum: []string{"v2", "v3"}}}},
+			{URL: "https://api.example.com"},
+		},
+	}})
+	assert.Equal(t, `^(?:/(?:v2|v3)|/v1)?/my%20files/([^/]*)$`, routes[0].PathPattern)
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/routes.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Routes: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, `OperationListMyPets    = "ListMyPets"`)
+	assert.Contains(t, code, "func RouteForRequest(r *http.Request) (Route, bool) {")
+	assert.Contains(t, code, `Path:        "/owners/{ownerId}/pets",`)
+	assert.Contains(t, code, `Tags:        []string{"pets", "admin"},`)
+	assert.Contains(t, code, `Scopes:      [][]string{{"pets:read", "pets:write"}},`)
+	assert.Contains(t, code, `pathParams:  []string{"ownerId"},`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
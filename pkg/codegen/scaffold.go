@@ -0,0 +1,179 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// Scaffold is the skeleton of the implementation of the server interface,
+// with the scaffold option. It's written once, and is then only completed
+// with the handlers of the operations the spec gains.
+type Scaffold struct {
+	Handlers string           // The handlers.go file, with the Server implementing the server interface
+	Methods  []ScaffoldMethod // The handlers of the Server, in the order of the operations
+	Main     string           // The main.go file serving the Server, when the import path of the generated package is known
+}
+
+// ScaffoldMethod is a handler of the scaffolded Server.
+type ScaffoldMethod struct {
+	Name string // The name of the method, the ID of its operation
+	Code string // The declaration of the method, with its TODO body
+}
+
+// ScaffoldContext is passed to the templates of the scaffold.
+type ScaffoldContext struct {
+	ModuleName  string
+	PackageName string
+	ImportPath  string // The import path of the generated package, which main.go imports
+	ImportAlias string // The name main.go imports the generated package as, when the import path doesn't end with it
+	Interface   string // The server interface the Server implements, ServerInterface or StrictServerInterface
+	Operations  []OperationDefinition
+}
+
+// scaffoldReceiver is the type the scaffolded handlers are methods of.
+const scaffoldReceiver = "Server"
+
+// GenerateScaffold generates the handlers.go file implementing the server
+// interface with TODO bodies, and the main.go file serving it.
+func GenerateScaffold(t *template.Template, ops []OperationDefinition) (Scaffold, error) {
+	opts := globalState.options
+	context := ScaffoldContext{
+		PackageName: opts.PackageName,
+		ImportPath:  opts.OutputOptions.ScaffoldImportPath,
+		Interface:   "ServerInterface",
+		Operations:  ops,
+	}
+	context.ModuleName, _ = generatorVersion(opts.NoVCSVersionOverride)
+	if path.Base(context.ImportPath) != context.PackageName {
+		context.ImportAlias = context.PackageName
+	}
+	if opts.Generate.Strict {
+		context.Interface = "StrictServerInterface"
+	}
+
+	var scaffold Scaffold
+	var err error
+	scaffold.Handlers, err = executeScaffoldTemplate(t, "scaffold-handlers", "handlers.go", context)
+	if err != nil {
+		return Scaffold{}, err
+	}
+	for i, op := range ops {
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, "scaffold-method", &ops[i]); err != nil {
+			return Scaffold{}, fmt.Errorf("error generating the scaffolded %s handler: %w", op.OperationId, err)
+		}
+		scaffold.Methods = append(scaffold.Methods, ScaffoldMethod{
+			Name: op.OperationId,
+			Code: buf.String(),
+		})
+	}
+	if context.ImportPath != "" {
+		scaffold.Main, err = executeScaffoldTemplate(t, "scaffold-main", "main.go", context)
+		if err != nil {
+			return Scaffold{}, err
+		}
+	}
+	return scaffold, nil
+}
+
+// checkScaffold returns an error when the generated code declares the Server
+// or NewServer of the scaffold, like the type of a schema named Server, since
+// the package wouldn't compile once the scaffold is written next to it.
+func checkScaffold(code string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	scaffolded := []string{scaffoldReceiver, "New" + scaffoldReceiver}
+	for _, decl := range file.Decls {
+		var names []string
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names = append(names, decl.Name.Name)
+			}
+		}
+		for _, name := range names {
+			if StringInArray(name, scaffolded) {
+				return fmt.Errorf("the generated code declares %s, like the scaffold, which can't be written next to it; rename the schema or the operation declaring it", name)
+			}
+		}
+	}
+	return nil
+}
+
+// executeScaffoldTemplate executes a template of the scaffold, and formats the
+// file it generates.
+func executeScaffoldTemplate(t *template.Template, name, fileName string, context ScaffoldContext) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, context); err != nil {
+		return "", fmt.Errorf("error generating the scaffolded %s: %w", fileName, err)
+	}
+	out, err := imports.Process(fileName, buf.Bytes(), nil)
+	if err != nil {
+		return "", fmt.Errorf("error formatting the scaffolded %s: %w", fileName, err)
+	}
+	if out, err = rewriteImports(out); err != nil {
+		return "", fmt.Errorf("error rewriting the imports of the scaffolded %s: %w", fileName, err)
+	}
+	return string(out), nil
+}
+
+// Complete returns an existing handlers.go file with the handlers it lacks
+// appended, those of the operations added to the spec since it was written.
+// The handlers it has are left alone, whatever their signatures.
+func (s Scaffold) Complete(existing []byte) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "handlers.go", existing, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the handlers: %w", err)
+	}
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		receiver := fn.Recv.List[0].Type
+		if star, ok := receiver.(*ast.StarExpr); ok {
+			receiver = star.X
+		}
+		if ident, ok := receiver.(*ast.Ident); ok && ident.Name == scaffoldReceiver {
+			declared[fn.Name.Name] = true
+		}
+	}
+
+	var missing []string
+	for _, method := range s.Methods {
+		if !declared[method.Name] {
+			missing = append(missing, method.Code)
+		}
+	}
+	if len(missing) == 0 {
+		return existing, nil
+	}
+	code := strings.TrimRight(string(existing), "\n") + "\n" + strings.Join(missing, "")
+	out, err := imports.Process("handlers.go", []byte(code), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting the handlers: %w", err)
+	}
+	return out, nil
+}
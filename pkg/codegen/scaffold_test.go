@@ -0,0 +1,142 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestGenerateScaffold(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/problem.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Models:    true,
+			Scaffold:  true,
+		},
+		OutputOptions: OutputOptions{
+			ScaffoldImportPath: "example.com/petstore/api",
+		},
+	}
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	scaffold := output.Scaffold
+
+	assert.Contains(t, scaffold.Handlers, "package api\n")
+	assert.Contains(t, scaffold.Handlers, "var _ ServerInterface = (*Server)(nil)")
+	assert.Contains(t, scaffold.Handlers, `// GetPet handles GET /pets/{id}.
+func (s *Server) GetPet(w http.ResponseWriter, r *http.Request, id int64) {
+	// TODO: implement GetPet
+	w.WriteHeader(http.StatusNotImplemented)
+}`)
+	require.Len(t, scaffold.Methods, 3)
+	assert.Equal(t, "ListPets", scaffold.Methods[0].Name)
+
+	assert.Contains(t, scaffold.Main, "package main\n")
+	assert.Contains(t, scaffold.Main, "\t\"example.com/petstore/api\"\n")
+	assert.Contains(t, scaffold.Main, `log.Fatal(http.ListenAndServe(":8080", api.HandlerFromMux(handler, r)))`)
+
+	// The strict server is scaffolded instead with strict-server
+	opts.Generate.Strict = true
+	output, err = GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, output.Scaffold.Handlers, "var _ StrictServerInterface = (*Server)(nil)")
+	assert.Contains(t, output.Scaffold.Handlers, "func (s *Server) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {")
+	assert.Contains(t, output.Scaffold.Main, "handler := api.NewStrictHandler(api.NewServer(), nil)")
+}
+
+func TestScaffoldCollisions(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Servers}
+paths:
+  /servers:
+    get:
+      operationId: listServers
+      responses:
+        "200":
+          description: The servers
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Server'
+components:
+  schemas:
+    Server:
+      type: object
+      properties:
+        name:
+          type: string
+`))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Models:    true,
+			Scaffold:  true,
+		},
+	}
+	_, err = GenerateOutput(swagger, opts)
+	assert.EqualError(t, err, "the generated code declares Server, like the scaffold, which can't be written next to it; rename the schema or the operation declaring it")
+
+	// Without the scaffold, the schema is generated
+	opts.Generate.Scaffold = false
+	_, err = GenerateOutput(swagger, opts)
+	assert.NoError(t, err)
+}
+
+func TestScaffoldComplete(t *testing.T) {
+	scaffold := Scaffold{
+		Methods: []ScaffoldMethod{
+			{Name: "ListPets", Code: "\n// ListPets handles GET /pets.\nfunc (s *Server) ListPets(w http.ResponseWriter, r *http.Request) {\n\t// TODO: implement ListPets\n}\n"},
+			{Name: "AddPet", Code: "\n// AddPet handles POST /pets.\nfunc (s *Server) AddPet(w http.ResponseWriter, r *http.Request) {\n\t// TODO: implement AddPet\n}\n"},
+		},
+	}
+	existing := []byte(`package api
+
+import "net/http"
+
+type Server struct{}
+
+func (s Server) ListPets(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("implemented"))
+}
+`)
+
+	// Only the missing handlers are added
+	completed, err := scaffold.Complete(existing)
+	require.NoError(t, err)
+	assert.Contains(t, string(completed), `w.Write([]byte("implemented"))`)
+	assert.NotContains(t, string(completed), "TODO: implement ListPets")
+	assert.Contains(t, string(completed), "func (s *Server) AddPet(w http.ResponseWriter, r *http.Request) {\n\t// TODO: implement AddPet\n}\n")
+
+	// Completed handlers stay as they are
+	again, err := scaffold.Complete(completed)
+	require.NoError(t, err)
+	assert.Equal(t, string(completed), string(again))
+
+	_, err = scaffold.Complete([]byte("package api\nfunc {"))
+	assert.Error(t, err)
+}
+
+func TestScaffoldNeedsServer(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Scaffold: true,
+		},
+	}
+	assert.EqualError(t, opts.Validate(), "the scaffold needs a chi, gorilla, echo, gin, fiber or iris server")
+}
@@ -3,6 +3,7 @@ package codegen
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -18,16 +19,22 @@ type Schema struct {
 
 	EnumValues map[string]string // Enum values
 
+	TimeFormat string // The layout of the times of a type declared with x-go-time-format
+
 	Properties               []Property       // For an object, the fields with names
 	HasAdditionalProperties  bool             // Whether we support additional properties
 	AdditionalPropertiesType *Schema          // And if we do, their type
 	AdditionalTypes          []TypeDefinition // We may need to generate auxiliary helper types, stored here
+	MapConstraints           *MapConstraints  // The constraints on the properties of a map, or of an object with additional properties, checked by Validate
 
 	SkipOptionalPointer bool // Some types don't need a * in front when they're optional
 
 	Description string // The description of the element
 
 	UnionElements []UnionElement // Possible elements of oneOf/anyOf union
+	TupleElements []TupleElement // The positional fields of a tuple, declared with prefixItems
+	TupleRest     *Schema        // The type of the items of a tuple after its positional fields, if it has items
+	NullableType  *Schema        // The array or map which a Nullable wraps, with the nullable-collections option
 	Discriminator *Discriminator // Describes which value is stored in a union
 
 	// If this is set, the schema will declare a type via alias, eg,
@@ -43,6 +50,13 @@ func (s Schema) IsRef() bool {
 	return s.RefType != ""
 }
 
+// hasMethods returns whether methods are generated for the type of the
+// schema, which needs a name of its own when the schema is declared inline.
+func (s Schema) hasMethods() bool {
+	return s.HasAdditionalProperties || len(s.UnionElements) != 0 || s.MapConstraints != nil || len(s.TupleElements) != 0 ||
+		s.hasSensitiveProperties() || s.hasEncryptedProperties()
+}
+
 func (s Schema) TypeDecl() string {
 	if s.IsRef() {
 		return s.RefType
@@ -86,6 +100,9 @@ type Property struct {
 	NeedsFormTag  bool
 	Extensions    map[string]interface{}
 	Deprecated    bool
+	// Whether the type of the property contains the type of the struct it's a
+	// field of, so that the field has to be a pointer
+	Recursive bool
 }
 
 func (p Property) GoFieldName() string {
@@ -94,6 +111,9 @@ func (p Property) GoFieldName() string {
 
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
+	if p.Recursive {
+		return "*" + typeDef
+	}
 	if !p.Schema.SkipOptionalPointer &&
 		(!p.Required || p.Nullable ||
 			(p.ReadOnly && (!p.Required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
@@ -104,6 +124,60 @@ func (p Property) GoTypeDef() string {
 	return typeDef
 }
 
+// structFieldName returns the name of the struct field of the property,
+// which x-go-name overrides.
+func (p Property) structFieldName() string {
+	if _, ok := p.Extensions[extGoName]; ok {
+		if extGoFieldName, err := extParseGoFieldName(p.Extensions[extGoName]); err == nil {
+			return extGoFieldName
+		}
+	}
+	return p.GoFieldName()
+}
+
+// structFieldType returns the type of the struct field of the property.
+func (p Property) structFieldType() string {
+	// Check x-go-type-skip-optional-pointer, which will override if the type
+	// should be a pointer or not when the field is optional.
+	if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+		if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
+			p.Schema.SkipOptionalPointer = skipOptionalPointer
+		}
+	}
+	return p.GoTypeDef()
+}
+
+// OmitEmpty returns whether the field of the property is left out of JSON when
+// empty, which x-omitempty overrides.
+func (p Property) OmitEmpty() bool {
+	omitEmpty := !p.Nullable &&
+		(!p.Required || p.ReadOnly || p.WriteOnly) &&
+		(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
+	if p.Schema.NullableType != nil {
+		// A Nullable left out is empty, while null isn't.
+		omitEmpty = !p.Required
+	}
+
+	// Support x-omitempty
+	if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
+		if extOmitEmpty, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
+			omitEmpty = extOmitEmpty
+		}
+	}
+	return omitEmpty
+}
+
+// JsonIgnored returns whether the field of the property is left out of JSON
+// entirely, as x-go-json-ignore requests.
+func (p Property) JsonIgnored() bool {
+	if _, ok := p.Extensions[extPropGoJsonIgnore]; ok {
+		if goJsonIgnore, err := extParseGoJsonIgnore(p.Extensions[extPropGoJsonIgnore]); err == nil && goJsonIgnore {
+			return true
+		}
+	}
+	return false
+}
+
 // EnumDefinition holds type information for enum
 type EnumDefinition struct {
 	// Schema is the scheme of a type which has a list of enum values, eg, the
@@ -136,6 +210,16 @@ func (e *EnumDefinition) GetValues() map[string]string {
 	return newValues
 }
 
+// ConstantNames returns the names of the constants of the enum, sorted.
+func (e *EnumDefinition) ConstantNames() []string {
+	var names []string
+	for name := range e.GetValues() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type Constants struct {
 	// SecuritySchemeProviderNames holds all provider names for security schemes.
 	SecuritySchemeProviderNames []string
@@ -270,6 +354,11 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 		if err != nil {
 			return outSchema, fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
 		}
+		// "decimal" is short for the Decimal type chosen by the decimal-type
+		// option.
+		if typeName == "decimal" && globalState.options.OutputOptions.DecimalType != "" {
+			typeName = "Decimal"
+		}
 		outSchema.GoType = typeName
 		outSchema.DefineViaAlias = true
 
@@ -314,6 +403,9 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			// If the schema has additional properties, we need to special case
 			// a lot of behaviors.
 			outSchema.HasAdditionalProperties = SchemaHasAdditionalProperties(schema)
+			if outSchema.HasAdditionalProperties {
+				outSchema.MapConstraints = mapConstraints(schema, path)
+			}
 
 			// Until we have a concrete additional properties type, we default to
 			// any schema.
@@ -328,7 +420,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating type for additional properties: %w", err)
 				}
-				if additionalSchema.HasAdditionalProperties || len(additionalSchema.UnionElements) != 0 {
+				if additionalSchema.hasMethods() && additionalSchema.RefType == "" {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
@@ -363,7 +455,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			}
 
 			// We've got an object with some properties.
-			for _, pName := range SortedSchemaKeys(schema.Properties) {
+			for _, pName := range propertyNames(schema) {
 				p := schema.Properties[pName]
 				propertyPath := append(path, pName)
 				pSchema, err := GenerateGoSchema(p, propertyPath)
@@ -373,7 +465,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 
 				required := StringInArray(pName, schema.Required)
 
-				if (pSchema.HasAdditionalProperties || len(pSchema.UnionElements) != 0) && pSchema.RefType == "" {
+				if pSchema.hasMethods() && pSchema.RefType == "" {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
@@ -389,6 +481,9 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 
 					pSchema.RefType = typeName
 				}
+				if p.Value.Nullable {
+					pSchema = nullableCollection(pSchema)
+				}
 				description := ""
 				if p.Value != nil {
 					description = p.Value.Description
@@ -403,9 +498,13 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					WriteOnly:     p.Value.WriteOnly,
 					Extensions:    p.Value.Extensions,
 					Deprecated:    p.Value.Deprecated,
+					Recursive:     globalState.recursiveProperties[p],
 				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 			}
+			if globalState.options.OutputOptions.FieldOrder == FieldOrderAlignment {
+				alignProperties(outSchema.Properties)
+			}
 
 			if schema.AnyOf != nil {
 				if err := generateUnion(&outSchema, schema.AnyOf, schema.Discriminator, path); err != nil {
@@ -497,6 +596,37 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				typeName = SchemaNameToTypeName(PathToTypeName(path))
 			}
 
+			typeDef := TypeDefinition{
+				TypeName: typeName,
+				JsonName: strings.Join(path, "."),
+				Schema:   outSchema,
+			}
+			outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, typeDef)
+			outSchema.RefType = typeName
+		}
+	} else if extension, ok := schema.Extensions[extGoTimeFormat]; ok && t == "string" {
+		layout, err := extString(extension)
+		if err != nil {
+			return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTimeFormat, err)
+		}
+		if layout == "" {
+			return outSchema, fmt.Errorf("empty value for %q", extGoTimeFormat)
+		}
+		// The time is a field rather than embedded, so that the runtime doesn't
+		// mistake the type for a time.Time, or a date, when binding parameters.
+		outSchema.TimeFormat = layout
+		outSchema.GoType = "struct {\nTime time.Time\n}"
+		if len(path) > 1 { // handle additional type only on non-toplevel types
+			var typeName string
+			if extension, ok := schema.Extensions[extGoTypeName]; ok {
+				typeName, err = extString(extension)
+				if err != nil {
+					return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
+				}
+			} else {
+				typeName = SchemaNameToTypeName(PathToTypeName(path))
+			}
+
 			typeDef := TypeDefinition{
 				TypeName: typeName,
 				JsonName: strings.Join(path, "."),
@@ -522,13 +652,17 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 
 	switch t {
 	case "array":
+		// Arrays whose first items have schemas of their own are tuples.
+		if items := prefixItems(schema); len(items) != 0 {
+			return tupleToGoType(schema, items, path, outSchema)
+		}
 		// For arrays, we'll get the type of the Items and throw a
 		// [] in front of it.
 		arrayType, err := GenerateGoSchema(schema.Items, path)
 		if err != nil {
 			return fmt.Errorf("error generating type for array: %w", err)
 		}
-		if (arrayType.HasAdditionalProperties || len(arrayType.UnionElements) != 0) && arrayType.RefType == "" {
+		if arrayType.hasMethods() && arrayType.RefType == "" {
 			// If we have items which have additional properties or union values,
 			// but are not a pre-defined type, we need to define a type
 			// for them, which will be based on the field names we followed
@@ -581,6 +715,8 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 			outSchema.GoType = "float64"
 		} else if f == "float" || f == "" {
 			outSchema.GoType = "float32"
+		} else if f == "decimal" && globalState.options.OutputOptions.DecimalType != "" {
+			outSchema.GoType = "Decimal"
 		} else {
 			return fmt.Errorf("invalid number format: %s", f)
 		}
@@ -593,15 +729,18 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 		outSchema.DefineViaAlias = true
 	case "string":
 		// Special case string formats here.
+		if goType, ok := globalState.options.OutputOptions.FormatMapping[f]; ok {
+			outSchema.GoType = goType
+			outSchema.DefineViaAlias = true
+			return nil
+		}
 		switch f {
 		case "byte":
 			outSchema.GoType = "[]byte"
 		case "email":
 			outSchema.GoType = "openapi_types.Email"
-		case "date":
-			outSchema.GoType = "openapi_types.Date"
-		case "date-time":
-			outSchema.GoType = "time.Time"
+		case "date", "date-time", "time":
+			outSchema.GoType = timeGoType(f)
 		case "json":
 			outSchema.GoType = "json.RawMessage"
 			outSchema.SkipOptionalPointer = true
@@ -620,6 +759,63 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 	return nil
 }
 
+// nullableTypeName is the name of the type wrapping the nullable arrays and
+// maps with the nullable-collections output option.
+const nullableTypeName = "Nullable"
+
+// nullableCollection returns the schema of a nullable property, which wraps
+// the array or map of the schema in Nullable with the nullable-collections
+// output option, so that null round-trips apart from an empty array or map
+// and from the property being left out.
+func nullableCollection(schema Schema) Schema {
+	if !globalState.options.OutputOptions.NullableCollections || len(schema.TupleElements) != 0 || schema.OAPISchema == nil {
+		return schema
+	}
+	isArray := schema.OAPISchema.Type == "array" && len(prefixItems(schema.OAPISchema)) == 0
+	if !isArray && !strings.HasPrefix(schema.GoType, "map[") {
+		return schema
+	}
+	wrapped := schema
+	return Schema{
+		GoType:              nullableTypeName + "[" + schema.TypeDecl() + "]",
+		NullableType:        &wrapped,
+		SkipOptionalPointer: true,
+		DefineViaAlias:      true,
+		AdditionalTypes:     schema.AdditionalTypes,
+		Description:         schema.Description,
+		OAPISchema:          schema.OAPISchema,
+	}
+}
+
+// timeGoType returns the Go type of the date, date-time or time string
+// format, as chosen by the time-types output option.
+func timeGoType(format string) string {
+	timeTypes := globalState.options.OutputOptions.TimeTypes
+	switch format {
+	case "date":
+		switch timeTypes.Date {
+		case "date":
+			return "Date"
+		case "time":
+			return "time.Time"
+		case "civil":
+			return "civil.Date"
+		default:
+			return "openapi_types.Date"
+		}
+	case "date-time":
+		if timeTypes.DateTime == "civil" {
+			return "civil.DateTime"
+		}
+		return "time.Time"
+	default:
+		if timeTypes.Time == "civil" {
+			return "civil.Time"
+		}
+		return "string"
+	}
+}
+
 // SchemaDescriptor describes a Schema, a type definition.
 type SchemaDescriptor struct {
 	Fields                   []FieldDescriptor
@@ -638,16 +834,17 @@ type FieldDescriptor struct {
 // GenFieldsFromProperties produce corresponding field names with JSON annotations,
 // given a list of schema descriptors
 func GenFieldsFromProperties(props []Property) []string {
+	return genFieldsFromProperties(props, false)
+}
+
+// genFieldsFromProperties is GenFieldsFromProperties, also annotating the
+// fields with xml tags when xmlTags is set.
+func genFieldsFromProperties(props []Property, xmlTags bool) []string {
 	var fields []string
 	for i, p := range props {
 		field := ""
 
-		goFieldName := p.GoFieldName()
-		if _, ok := p.Extensions[extGoName]; ok {
-			if extGoFieldName, err := extParseGoFieldName(p.Extensions[extGoName]); err == nil {
-				goFieldName = extGoFieldName
-			}
-		}
+		goFieldName := p.structFieldName()
 
 		// Add a comment to a field in case we have one, otherwise skip.
 		if p.Description != "" {
@@ -671,30 +868,21 @@ func GenFieldsFromProperties(props []Property) []string {
 			field += fmt.Sprintf("%s\n", DeprecationComment(deprecationReason))
 		}
 
-		// Check x-go-type-skip-optional-pointer, which will override if the type
-		// should be a pointer or not when the field is optional.
-		if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
-			if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
-				p.Schema.SkipOptionalPointer = skipOptionalPointer
+		// Add the kubebuilder markers of the kubernetes-crd option
+		if markers := p.KubebuilderMarkers(); len(markers) != 0 {
+			if i != 0 && field == "" {
+				field += "\n"
 			}
-		}
-
-		field += fmt.Sprintf("    %s %s", goFieldName, p.GoTypeDef())
-
-		omitEmpty := !p.Nullable &&
-			(!p.Required || p.ReadOnly || p.WriteOnly) &&
-			(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
-
-		// Support x-omitempty
-		if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
-			if extOmitEmpty, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
-				omitEmpty = extOmitEmpty
+			for _, marker := range markers {
+				field += fmt.Sprintf("// %s\n", marker)
 			}
 		}
 
+		field += fmt.Sprintf("    %s %s", goFieldName, p.structFieldType())
+
 		fieldTags := make(map[string]string)
 
-		if !omitEmpty {
+		if !p.OmitEmpty() {
 			fieldTags["json"] = p.JsonFieldName
 			if p.NeedsFormTag {
 				fieldTags["form"] = p.JsonFieldName
@@ -707,12 +895,28 @@ func GenFieldsFromProperties(props []Property) []string {
 		}
 
 		// Support x-go-json-ignore
-		if _, ok := p.Extensions[extPropGoJsonIgnore]; ok {
-			if goJsonIgnore, err := extParseGoJsonIgnore(p.Extensions[extPropGoJsonIgnore]); err == nil && goJsonIgnore {
-				fieldTags["json"] = "-"
+		if p.JsonIgnored() {
+			fieldTags["json"] = "-"
+		}
+
+		if xmlTags {
+			if p.JsonIgnored() {
+				fieldTags["xml"] = "-"
+			} else {
+				fieldTags["xml"] = p.xmlTag(p.OmitEmpty())
 			}
 		}
 
+		// Mirror the json tag into the tags of the mirror-json-tags option
+		for _, tag := range globalState.options.OutputOptions.MirrorJsonTags {
+			fieldTags[tag] = fieldTags["json"]
+		}
+
+		// Support x-db-column, with the db-tags option
+		if tag, value, ok := dbFieldTag(p); ok {
+			fieldTags[tag] = value
+		}
+
 		// Support x-oapi-codegen-extra-tags
 		if extension, ok := p.Extensions[extPropExtraTags]; ok {
 			if tags, err := extExtraTags(extension); err == nil {
@@ -748,13 +952,33 @@ func additionalPropertiesType(schema Schema) string {
 func GenStructFromSchema(schema Schema) string {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
+	xmlTags := globalState.xmlSchemas[schema.OAPISchema]
+	if xmlTags {
+		if field := xmlNameField(schema.OAPISchema); field != "" {
+			objectParts = append(objectParts, field)
+		}
+	}
 	// Append all the field definitions
-	objectParts = append(objectParts, GenFieldsFromProperties(schema.Properties)...)
+	objectParts = append(objectParts, genFieldsFromProperties(schema.Properties, xmlTags)...)
 	// Close the struct
 	if schema.HasAdditionalProperties {
+		fieldTags := map[string]string{"json": "-"}
+		for _, tag := range globalState.options.OutputOptions.MirrorJsonTags {
+			fieldTags[tag] = "-"
+		}
+		if xmlTags {
+			fieldTags["xml"] = "-"
+		}
+		if tag, value, ok := dbIgnoredFieldTag(); ok {
+			fieldTags[tag] = value
+		}
+		var tags []string
+		for _, k := range SortedStringKeys(fieldTags) {
+			tags = append(tags, fmt.Sprintf(`%s:"%s"`, k, fieldTags[k]))
+		}
 		objectParts = append(objectParts,
-			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`",
-				additionalPropertiesType(schema)))
+			fmt.Sprintf("AdditionalProperties map[string]%s `%s`",
+				additionalPropertiesType(schema), strings.Join(tags, " ")))
 	}
 	if len(schema.UnionElements) != 0 {
 		objectParts = append(objectParts, "union json.RawMessage")
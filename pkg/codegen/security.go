@@ -0,0 +1,236 @@
+package codegen
+
+import (
+	"fmt"
+	"net/textproto"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecuritySchemeDefinition describes a security scheme declared in the
+// components of the spec, and where requests carry its credential.
+type SecuritySchemeDefinition struct {
+	Name      string // The name of the scheme in components.securitySchemes
+	Type      string // apiKey, http, oauth2, openIdConnect or mutualTLS
+	In        string // Where an apiKey is passed: header, query or cookie
+	ParamName string // The name of the header, query parameter or cookie carrying an apiKey
+	Scheme    string // The lower cased authorization scheme of an http scheme, like bearer or basic
+	// The scopes of an oauth2 or openIdConnect scheme, declared by its flows
+	// or required by operations, sorted by name.
+	Scopes []SecurityScopeDefinition
+}
+
+// SecurityScopeDefinition describes a scope of an oauth2 or openIdConnect
+// security scheme.
+type SecurityScopeDefinition struct {
+	Name   string // The scope, like pets:write
+	GoName string // The name of its constant, like OauthScopePetsWrite
+}
+
+// SecurityRequirementDefinition is one of the alternative security
+// requirements of an operation, which is met when all of its schemes are.
+type SecurityRequirementDefinition []SecurityDefinition
+
+// DescribeSecuritySchemes converts the security schemes of the spec into a
+// list of SecuritySchemeDefinition, sorted by name.
+func DescribeSecuritySchemes(schemes openapi3.SecuritySchemes) []SecuritySchemeDefinition {
+	names := make([]string, 0, len(schemes))
+	for name, scheme := range schemes {
+		if scheme != nil && scheme.Value != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := make([]SecuritySchemeDefinition, 0, len(names))
+	for _, name := range names {
+		scheme := schemes[name].Value
+		def := SecuritySchemeDefinition{
+			Name:      name,
+			Type:      scheme.Type,
+			In:        scheme.In,
+			ParamName: scheme.Name,
+			Scheme:    strings.ToLower(scheme.Scheme),
+		}
+		if flows := scheme.Flows; flows != nil {
+			var scopes []string
+			for _, flow := range []*openapi3.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+				if flow == nil {
+					continue
+				}
+				for scope := range flow.Scopes {
+					scopes = append(scopes, scope)
+				}
+			}
+			def.addScopes(scopes)
+		}
+		result = append(result, def)
+	}
+	return result
+}
+
+// addScopes adds the scopes missing from the definition, keeping them sorted.
+func (d *SecuritySchemeDefinition) addScopes(scopes []string) {
+	known := make(map[string]bool, len(d.Scopes))
+	for _, scope := range d.Scopes {
+		known[scope.Name] = true
+	}
+	for _, scope := range scopes {
+		if known[scope] {
+			continue
+		}
+		known[scope] = true
+		d.Scopes = append(d.Scopes, SecurityScopeDefinition{
+			Name:   scope,
+			GoName: SchemaNameToTypeName(d.Name) + "Scope" + SchemaNameToTypeName(scope),
+		})
+	}
+	sort.Slice(d.Scopes, func(i, j int) bool {
+		return d.Scopes[i].Name < d.Scopes[j].Name
+	})
+}
+
+// ScopeAlternatives returns the scopes named by each of the security
+// requirements of the operation, sorted and without duplicates, in the order
+// of the requirements. As meeting any of the requirements grants access, the
+// scopes of any one of them suffice, and those of a requirement naming none
+// are empty. It's nil when no requirement names any scopes.
+func (o *OperationDefinition) ScopeAlternatives() [][]string {
+	var result [][]string
+	scoped := false
+	for _, requirement := range o.SecurityRequirements {
+		scopes := []string{}
+		seen := make(map[string]bool)
+		for _, def := range requirement {
+			for _, scope := range def.Scopes {
+				if !seen[scope] {
+					seen[scope] = true
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+		sort.Strings(scopes)
+		scoped = scoped || len(scopes) > 0
+		result = append(result, scopes)
+	}
+	if !scoped {
+		return nil
+	}
+	return result
+}
+
+// RequiredScopes returns the scopes of the only security requirement of the
+// operation, when it has a single one naming scopes, which are then required.
+// With several alternative requirements, no scopes are required by all of
+// them, and it returns nil.
+func (o *OperationDefinition) RequiredScopes() []string {
+	alternatives := o.ScopeAlternatives()
+	if len(alternatives) != 1 {
+		return nil
+	}
+	return alternatives[0]
+}
+
+// credentialHeaders returns the names of the request headers carrying
+// credentials: those of HTTP authentication and cookies, and those of the
+// apiKey security schemes of the spec passed in headers, sorted.
+func credentialHeaders() []string {
+	seen := map[string]bool{"Authorization": true, "Cookie": true, "Proxy-Authorization": true}
+	if spec := globalState.spec; spec != nil && spec.Components != nil {
+		for _, scheme := range DescribeSecuritySchemes(spec.Components.SecuritySchemes) {
+			if scheme.Type == "apiKey" && scheme.In == "header" {
+				seen[textproto.CanonicalMIMEHeaderKey(scheme.ParamName)] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DescribeSecurityRequirements converts security requirements into a list of
+// SecurityRequirementDefinition, in the order of the spec. An empty
+// requirement, which makes authentication optional, is kept as such.
+func DescribeSecurityRequirements(securityRequirements openapi3.SecurityRequirements) []SecurityRequirementDefinition {
+	result := make([]SecurityRequirementDefinition, 0, len(securityRequirements))
+	for _, sr := range securityRequirements {
+		requirement := make(SecurityRequirementDefinition, 0, len(sr))
+		for _, k := range SortedSecurityRequirementKeys(sr) {
+			requirement = append(requirement, SecurityDefinition{ProviderName: k, Scopes: sr[k]})
+		}
+		result = append(result, requirement)
+	}
+	return result
+}
+
+// SecurityContext is the data passed to the security template.
+type SecurityContext struct {
+	Schemes    []SecuritySchemeDefinition // The security schemes declared in the spec
+	Operations []OperationDefinition      // The operations, with their security requirements
+}
+
+// ScopedOperations returns the operations whose security requirements name
+// any scopes.
+func (c SecurityContext) ScopedOperations() []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range c.Operations {
+		if op.ScopeAlternatives() != nil {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// ScopeRequiringOperations returns the operations with a single security
+// requirement, which names scopes.
+func (c SecurityContext) ScopeRequiringOperations() []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range c.Operations {
+		if len(op.RequiredScopes()) > 0 {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// GenerateSecurityMiddleware generates the middleware enforcing the security
+// requirements of the operations.
+func GenerateSecurityMiddleware(t *template.Template, spec *openapi3.T, ops []OperationDefinition) (string, error) {
+	context := SecurityContext{
+		Operations: ops,
+	}
+	if spec.Components != nil {
+		context.Schemes = DescribeSecuritySchemes(spec.Components.SecuritySchemes)
+	}
+
+	// OpenID Connect scopes are only known from the operations requiring them
+	goNames := make(map[string]string)
+	for i := range context.Schemes {
+		scheme := &context.Schemes[i]
+		if scheme.Type != "oauth2" && scheme.Type != "openIdConnect" {
+			continue
+		}
+		for _, op := range ops {
+			for _, requirement := range op.SecurityRequirements {
+				for _, def := range requirement {
+					if def.ProviderName == scheme.Name {
+						scheme.addScopes(def.Scopes)
+					}
+				}
+			}
+		}
+		for _, scope := range scheme.Scopes {
+			if other, found := goNames[scope.GoName]; found {
+				return "", fmt.Errorf("scopes %s and %s have the same Go name %s", other, scope.Name, scope.GoName)
+			}
+			goNames[scope.GoName] = scope.Name
+		}
+	}
+	return GenerateTemplates([]string{"security.tmpl"}, t, context)
+}
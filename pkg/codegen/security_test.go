@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeSecuritySchemes(t *testing.T) {
+	schemes := openapi3.SecuritySchemes{
+		"oauth": {Value: &openapi3.SecurityScheme{Type: "oauth2", Flows: &openapi3.OAuthFlows{
+			ClientCredentials: &openapi3.OAuthFlow{Scopes: map[string]string{"pets:write": "", "pets:read": ""}},
+			Implicit:          &openapi3.OAuthFlow{Scopes: map[string]string{"pets:read": ""}},
+		}}},
+		"apiKey":     {Value: &openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}},
+		"bearerAuth": {Value: &openapi3.SecurityScheme{Type: "http", Scheme: "Bearer"}},
+		"unresolved": {Ref: "#/components/securitySchemes/missing"},
+	}
+
+	assert.Equal(t, []SecuritySchemeDefinition{
+		{Name: "apiKey", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+		{Name: "bearerAuth", Type: "http", Scheme: "bearer"},
+		{Name: "oauth", Type: "oauth2", Scopes: []SecurityScopeDefinition{
+			{Name: "pets:read", GoName: "OauthScopePetsRead"},
+			{Name: "pets:write", GoName: "OauthScopePetsWrite"},
+		}},
+	}, DescribeSecuritySchemes(schemes))
+}
+
+func TestScopeAlternatives(t *testing.T) {
+	op := OperationDefinition{
+		SecurityRequirements: DescribeSecurityRequirements(openapi3.SecurityRequirements{
+			{"oauth": {"pets:write", "pets:read"}, "oidc": {"pets:read"}},
+			{"oidc": {"openid", "pets:read"}},
+			{"apiKey": {}},
+		}),
+	}
+	assert.Equal(t, [][]string{{"pets:read", "pets:write"}, {"openid", "pets:read"}, {}}, op.ScopeAlternatives())
+	// No scopes are required by all the alternatives
+	assert.Empty(t, op.RequiredScopes())
+
+	op.SecurityRequirements = op.SecurityRequirements[:1]
+	assert.Equal(t, []string{"pets:read", "pets:write"}, op.RequiredScopes())
+
+	op.SecurityRequirements = DescribeSecurityRequirements(openapi3.SecurityRequirements{{"apiKey": {}}, {}})
+	assert.Nil(t, op.ScopeAlternatives())
+	assert.Empty(t, (&OperationDefinition{}).ScopeAlternatives())
+}
+
+func TestDescribeSecurityRequirements(t *testing.T) {
+	requirements := openapi3.SecurityRequirements{
+		{"oauth": {"pets:write"}, "apiKey": {}},
+		{"bearerAuth": {}},
+		{},
+	}
+
+	assert.Equal(t, []SecurityRequirementDefinition{
+		{{ProviderName: "apiKey", Scopes: []string{}}, {ProviderName: "oauth", Scopes: []string{"pets:write"}}},
+		{{ProviderName: "bearerAuth", Scopes: []string{}}},
+		{},
+	}, DescribeSecurityRequirements(requirements))
+
+	assert.Empty(t, DescribeSecurityRequirements(openapi3.SecurityRequirements{}))
+}
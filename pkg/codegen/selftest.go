@@ -0,0 +1,673 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// selfTestServerPath is the path of the server the requests of the
+// self-test are built for, which the request builders keep as the prefix of
+// the paths of operations.
+const selfTestServerPath = "/api"
+
+// SelfTestExample is a type whose schema has an example, which must
+// round-trip through the type.
+type SelfTestExample struct {
+	TypeName string
+	Example  string // The example, as JSON
+}
+
+// SelfTestEnum is an enum type, whose constants must marshal to the values of
+// its schema.
+type SelfTestEnum struct {
+	TypeName  string
+	Constants []string // The names of the constants, sorted
+	Values    string   // The values of the schema, as a JSON array
+}
+
+// SelfTestRequest is an operation, whose request builder must produce URLs
+// matching its path.
+type SelfTestRequest struct {
+	OperationId string
+	Builder     string // The name of the request builder taking any body
+	Method      string
+	PathPattern string // A regular expression matching the paths of the requests
+}
+
+// SelfTestFuzzTarget is an operation, whose requests are fuzzed through the
+// generated server, and whose JSON body is fuzzed through its type.
+type SelfTestFuzzTarget struct {
+	OperationId string
+	Method      string
+	PathArgs    []string             // The fuzzed values of the path parameters, in the order of the path
+	URL         string               // A Go expression building the URL of the request from PathArgs, up to its query
+	HeaderArgs  []SelfTestFuzzHeader // The fuzzed headers
+	HasBody     bool
+	ContentType string   // The content type of the fuzzed body, if any
+	BodyType    string   // The type the fuzzed body is decoded into, if it's JSON
+	Seed        []string // The Go literals of the arguments of the seed built from the examples
+}
+
+// SelfTestFuzzHeader is a header parameter of a fuzz target.
+type SelfTestFuzzHeader struct {
+	Name string
+	Arg  string
+}
+
+// SelfTestContract is an operation, whose example request, built by the
+// client, must be accepted by the server, and whose example response, written
+// by the server, must be parsed by the client.
+type SelfTestContract struct {
+	OperationId         string
+	Builder             string   // The name of the request builder taking any body
+	Parser              string   // The name of the response parser
+	PathArgs            []string // The examples of the path parameters, as JSON
+	Params              string   // The examples of the other parameters, as a JSON object, if the operation takes them
+	ContentType         string   // The content type of the request body, if any
+	Body                string   // The example request body
+	SkipBody            bool     // Whether the request body isn't JSON, so it's left empty and isn't validated
+	Status              int      // The status of the example response
+	ResponseContentType string
+	Response            string // The example response body
+	SkipResponse        bool   // Whether the response body isn't JSON, so it's left empty and isn't validated
+}
+
+// SelfTestContext is the data passed to the self-test template.
+type SelfTestContext struct {
+	PackageName       string
+	ModuleName        string
+	Version           string
+	Server            string
+	Examples          []SelfTestExample
+	Enums             []SelfTestEnum
+	Requests          []SelfTestRequest
+	Operations        []OperationDefinition // The operations the test servers implement
+	FuzzTargets       []SelfTestFuzzTarget
+	Contracts         []SelfTestContract
+	FuzzServer        bool // Whether the fuzz targets send requests through the generated server
+	ExternalImports   []string
+	AdditionalImports []AdditionalImport
+}
+
+// GenerateSelfTest generates tests of the code generated for the spec: the
+// examples of the schemas must unmarshal into their types and marshal back to
+// the same JSON, the enum constants must marshal to the values of their
+// schemas, and the request builders must produce URLs matching the paths of
+// their operations. With the fuzz option, it also generates fuzz tests sending
+// requests through the generated server, and decoding bodies into their
+// types, seeded from the examples of the spec. With the contract-test option,
+// it also generates a test sending example requests built by the client to
+// the server, whose example responses are parsed by the client, validating
+// both against the spec.
+func GenerateSelfTest(t *template.Template, spec *openapi3.T, ops []OperationDefinition, externalImports []string) (string, error) {
+	opts := globalState.options
+	context := SelfTestContext{
+		PackageName:       opts.PackageName,
+		Server:            "https://example.com" + selfTestServerPath,
+		ExternalImports:   externalImports,
+		AdditionalImports: opts.AdditionalImports,
+	}
+	context.ModuleName, context.Version = generatorVersion(opts.NoVCSVersionOverride)
+
+	if opts.Generate.SelfTest && opts.Generate.Models {
+		types := globalState.componentTypes
+		seen := make(map[string]bool)
+		for _, tp := range types {
+			if seen[tp.TypeName] || tp.Schema.OAPISchema == nil || tp.Schema.OAPISchema.Example == nil {
+				continue
+			}
+			seen[tp.TypeName] = true
+			example, err := json.Marshal(tp.Schema.OAPISchema.Example)
+			if err != nil {
+				return "", fmt.Errorf("error marshaling example of %s: %w", tp.TypeName, err)
+			}
+			context.Examples = append(context.Examples, SelfTestExample{
+				TypeName: tp.TypeName,
+				Example:  string(example),
+			})
+		}
+
+		enumTypes := append([]TypeDefinition{}, types...)
+		for _, op := range ops {
+			enumTypes = append(enumTypes, op.TypeDefinitions...)
+		}
+		for _, enum := range enumDefinitions(enumTypes) {
+			if enum.Schema.OAPISchema == nil {
+				continue
+			}
+			values, err := json.Marshal(enum.Schema.OAPISchema.Enum)
+			if err != nil {
+				return "", fmt.Errorf("error marshaling values of %s: %w", enum.TypeName, err)
+			}
+			context.Enums = append(context.Enums, SelfTestEnum{
+				TypeName:  enum.TypeName,
+				Constants: enum.ConstantNames(),
+				Values:    string(values),
+			})
+		}
+	}
+
+	if opts.Generate.SelfTest && (opts.Generate.Client || opts.Generate.RequestBuilders) {
+		for i := range ops {
+			op := &ops[i]
+			builder := "New" + op.OperationId + "Request"
+			if op.HasBody() {
+				builder += "WithBody"
+			}
+			context.Requests = append(context.Requests, SelfTestRequest{
+				OperationId: op.OperationId,
+				Builder:     builder,
+				Method:      op.Method,
+				PathPattern: "^" + regexp.QuoteMeta(selfTestServerPath) + pathPattern(op.RequestPath()) + "$",
+			})
+		}
+	}
+
+	if opts.Generate.Fuzz {
+		g := opts.Generate
+		context.FuzzServer = g.ChiServer || g.GorillaServer || g.EchoServer || g.GinServer || g.FiberServer
+		if context.FuzzServer {
+			context.Operations = ops
+		}
+		for i := range ops {
+			target := fuzzTarget(&ops[i], opts.Generate.Models)
+			if context.FuzzServer || target.BodyType != "" {
+				context.FuzzTargets = append(context.FuzzTargets, target)
+			}
+		}
+	}
+
+	if opts.Generate.ContractTest {
+		context.Operations = ops
+		for i := range ops {
+			contract, err := contractOf(&ops[i])
+			if err != nil {
+				return "", err
+			}
+			context.Contracts = append(context.Contracts, contract)
+		}
+	}
+
+	return GenerateTemplates([]string{"self-test.tmpl"}, t, context)
+}
+
+// contractOf returns the contract of an operation, whose request and
+// response are built from the examples of the spec, or from placeholders
+// matching the schemas where there are none.
+func contractOf(op *OperationDefinition) (SelfTestContract, error) {
+	contract := SelfTestContract{
+		OperationId: op.OperationId,
+		Builder:     "New" + op.OperationId + "Request",
+		Parser:      "Parse" + op.OperationId + "Response",
+	}
+
+	for _, param := range op.PathParams {
+		example, err := json.Marshal(parameterValue(param.Spec, true))
+		if err != nil {
+			return contract, fmt.Errorf("error marshaling example of parameter %s of %s: %w", param.ParamName, op.OperationId, err)
+		}
+		contract.PathArgs = append(contract.PathArgs, string(example))
+	}
+
+	if op.RequiresParamObject() {
+		params := make(map[string]interface{})
+		for _, param := range op.Params() {
+			if value := parameterValue(param.Spec, param.Required); value != nil {
+				params[param.ParamName] = value
+			}
+		}
+		example, err := json.Marshal(params)
+		if err != nil {
+			return contract, fmt.Errorf("error marshaling example parameters of %s: %w", op.OperationId, err)
+		}
+		contract.Params = string(example)
+	}
+
+	if op.HasBody() {
+		contract.Builder += "WithBody"
+		contract.ContentType = op.Bodies[0].ContentType
+		contract.SkipBody = true
+		for _, def := range op.Bodies {
+			if !def.IsJSON() {
+				continue
+			}
+			body, err := json.Marshal(mediaTypeExample(op.Spec.RequestBody.Value.Content[def.ContentType], true))
+			if err != nil {
+				return contract, fmt.Errorf("error marshaling example body of %s: %w", op.OperationId, err)
+			}
+			contract.ContentType, contract.Body, contract.SkipBody = def.ContentType, string(body), false
+			break
+		}
+	}
+
+	// The response is the first successful one, else the default one.
+	contract.Status = http.StatusOK
+	var response *openapi3.Response
+	names := SortedResponsesKeys(op.Spec.Responses)
+	for _, name := range names {
+		if strings.HasPrefix(name, "2") {
+			if status, err := strconv.Atoi(name); err == nil {
+				contract.Status = status
+			}
+			response = op.Spec.Responses[name].Value
+			break
+		}
+	}
+	if response == nil && op.Spec.Responses["default"] != nil {
+		response = op.Spec.Responses["default"].Value
+	}
+	if response == nil && len(names) > 0 {
+		if status, err := strconv.Atoi(names[0]); err == nil {
+			contract.Status = status
+		}
+		response = op.Spec.Responses[names[0]].Value
+	}
+
+	if response != nil && len(response.Content) > 0 {
+		contentTypes := SortedContentKeys(response.Content)
+		contract.ResponseContentType = contentTypes[0]
+		contract.SkipResponse = true
+		for _, contentType := range contentTypes {
+			if !util.IsMediaTypeJson(contentType) {
+				continue
+			}
+			body, err := json.Marshal(mediaTypeExample(response.Content[contentType], false))
+			if err != nil {
+				return contract, fmt.Errorf("error marshaling example response of %s: %w", op.OperationId, err)
+			}
+			contract.ResponseContentType, contract.Response, contract.SkipResponse = contentType, string(body), false
+			break
+		}
+	}
+
+	return contract, nil
+}
+
+// parameterValue returns the example of a parameter, or of its schema, else
+// a placeholder matching its schema if it's required, else nil.
+func parameterValue(param *openapi3.Parameter, required bool) interface{} {
+	if example := parameterExampleValue(param); example != nil {
+		return example
+	}
+	if !required {
+		return nil
+	}
+	schema := param.Schema
+	if schema == nil {
+		for _, contentType := range SortedContentKeys(param.Content) {
+			schema = param.Content[contentType].Schema
+			break
+		}
+	}
+	return schemaExample(schema, true, make(map[*openapi3.Schema]bool))
+}
+
+// mediaTypeExample returns the example of a body, or of its schema, else a
+// placeholder matching its schema, for a request or a response.
+func mediaTypeExample(mediaType *openapi3.MediaType, request bool) interface{} {
+	if mediaType == nil {
+		return nil
+	}
+	if mediaType.Example != nil {
+		return mediaType.Example
+	}
+	for _, name := range sortedExampleKeys(mediaType.Examples) {
+		if ex := mediaType.Examples[name]; ex.Value != nil && ex.Value.Value != nil {
+			return ex.Value.Value
+		}
+	}
+	return schemaExample(mediaType.Schema, request, make(map[*openapi3.Schema]bool))
+}
+
+// schemaExample returns the example of a schema, else its default or first
+// enum value, else a placeholder matching it: objects get their required
+// properties, but the read-only ones in requests and the write-only ones in
+// responses, arrays one item, or one for each of their prefixItems, and
+// scalars a value of their format and bounds.
+// Recursive schemas stop at the schemas in seen.
+func schemaExample(schemaRef *openapi3.SchemaRef, request bool, seen map[*openapi3.Schema]bool) interface{} {
+	if schemaRef == nil || schemaRef.Value == nil || seen[schemaRef.Value] {
+		return nil
+	}
+	schema := schemaRef.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+	seen[schema] = true
+	defer delete(seen, schema)
+
+	if len(schema.OneOf) > 0 {
+		return schemaExample(schema.OneOf[0], request, seen)
+	}
+	if len(schema.AnyOf) > 0 {
+		return schemaExample(schema.AnyOf[0], request, seen)
+	}
+	if len(schema.AllOf) > 0 {
+		object := make(map[string]interface{})
+		for _, part := range schema.AllOf {
+			if properties, ok := schemaExample(part, request, seen).(map[string]interface{}); ok {
+				for name, value := range properties {
+					object[name] = value
+				}
+			}
+		}
+		return object
+	}
+
+	switch schema.Type {
+	case "string":
+		if layout, err := extString(schema.Extensions[extGoTimeFormat]); err == nil && layout != "" {
+			return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Format(layout)
+		}
+		switch schema.Format {
+		case "date":
+			return "2000-01-01"
+		case "date-time":
+			return "2000-01-01T00:00:00Z"
+		case "time":
+			return "00:00:00"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		case "uri":
+			return "https://example.com/"
+		case "ipv4":
+			return "192.0.2.1"
+		case "ipv6":
+			return "2001:db8::1"
+		case "byte":
+			return ""
+		}
+		if schema.MinLength > 1 {
+			return strings.Repeat("x", int(schema.MinLength))
+		}
+		return "x"
+	case "integer", "number":
+		value := 1.0
+		if schema.Min != nil {
+			value = *schema.Min
+			if schema.ExclusiveMin {
+				value++
+			}
+		}
+		if schema.Type == "integer" {
+			return int64(math.Ceil(value))
+		}
+		return value
+	case "boolean":
+		return true
+	case "array":
+		if prefix := prefixItems(schema); len(prefix) > 0 {
+			items := make([]interface{}, 0, len(prefix))
+			for _, item := range prefix {
+				items = append(items, schemaExample(item, request, seen))
+			}
+			return items
+		}
+		items := make([]interface{}, 0, 1)
+		if item := schemaExample(schema.Items, request, seen); item != nil {
+			items = append(items, item)
+		}
+		return items
+	}
+
+	object := make(map[string]interface{})
+	for _, name := range schema.Required {
+		property := schema.Properties[name]
+		if property != nil && property.Value != nil && (request && property.Value.ReadOnly || !request && property.Value.WriteOnly) {
+			continue
+		}
+		if value := schemaExample(property, request, seen); value != nil {
+			object[name] = value
+		}
+	}
+	return object
+}
+
+// fuzzTarget returns the fuzz target of an operation, whose body is decoded
+// into its type when models are generated. Its seed takes the examples of the
+// parameters and body.
+func fuzzTarget(op *OperationDefinition, models bool) SelfTestFuzzTarget {
+	target := SelfTestFuzzTarget{
+		OperationId: op.OperationId,
+		Method:      op.Method,
+	}
+
+	pathParams := make(map[string]*ParameterDefinition)
+	for i := range op.PathParams {
+		pathParams[op.PathParams[i].ParamName] = &op.PathParams[i]
+	}
+	// The literal parts of the path are merged, with the server before
+	// them and the start of the query after them.
+	var parts []string
+	literal := "http://example.com"
+	last := 0
+	for _, loc := range pathParamRegexp.FindAllStringIndex(op.Path, -1) {
+		literal += op.Path[last:loc[0]]
+		last = loc[1]
+		param := pathParams[op.Path[loc[0]+1:loc[1]-1]]
+		if param == nil {
+			continue
+		}
+		arg := fmt.Sprintf("path%d", len(target.PathArgs))
+		target.PathArgs = append(target.PathArgs, arg)
+		target.Seed = append(target.Seed, fmt.Sprintf("%q", parameterExample(param.Spec)))
+		parts = append(parts, fmt.Sprintf("%q", literal), "url.PathEscape("+arg+")")
+		literal = ""
+	}
+	parts = append(parts, fmt.Sprintf("%q", literal+op.Path[last:]+"?"))
+	target.URL = strings.Join(parts, " + ")
+
+	query := make(url.Values)
+	for _, param := range op.QueryParams {
+		if example := parameterExample(param.Spec); example != "" {
+			query.Set(param.ParamName, example)
+		}
+	}
+	target.Seed = append(target.Seed, fmt.Sprintf("%q", query.Encode()))
+
+	for i, param := range op.HeaderParams {
+		arg := fmt.Sprintf("header%d", i)
+		target.HeaderArgs = append(target.HeaderArgs, SelfTestFuzzHeader{Name: param.ParamName, Arg: arg})
+		target.Seed = append(target.Seed, fmt.Sprintf("%q", parameterExample(param.Spec)))
+	}
+
+	if op.HasBody() {
+		target.HasBody = true
+		var body string
+		for _, def := range op.Bodies {
+			if !def.IsJSON() {
+				continue
+			}
+			target.ContentType = def.ContentType
+			if models && def.NameTag != "" {
+				target.BodyType = op.OperationId + def.NameTag + "RequestBody"
+			}
+			body = bodyExample(op.Spec.RequestBody.Value.Content[def.ContentType])
+			break
+		}
+		if target.ContentType == "" {
+			target.ContentType = op.Bodies[0].ContentType
+		}
+		target.Seed = append(target.Seed, fmt.Sprintf("[]byte(%q)", body))
+	}
+
+	return target
+}
+
+// parameterExample returns the example of a parameter, or of its schema,
+// formatted as its value in a request, or "" if it has none.
+func parameterExample(param *openapi3.Parameter) string {
+	switch v := parameterExampleValue(param).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool, float64, int, int64:
+		return fmt.Sprint(v)
+	default:
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(buf)
+	}
+}
+
+// parameterExampleValue returns the example of a parameter, or of its schema,
+// or nil if it has none.
+func parameterExampleValue(param *openapi3.Parameter) interface{} {
+	if param.Example != nil {
+		return param.Example
+	}
+	for _, name := range sortedExampleKeys(param.Examples) {
+		if ex := param.Examples[name]; ex.Value != nil && ex.Value.Value != nil {
+			return ex.Value.Value
+		}
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		return param.Schema.Value.Example
+	}
+	return nil
+}
+
+func sortedExampleKeys(examples openapi3.Examples) []string {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bodyExample returns the example of a JSON body, or of its schema, as JSON,
+// or "{}" if it has none.
+func bodyExample(mediaType *openapi3.MediaType) string {
+	var example interface{}
+	if mediaType != nil {
+		example = mediaType.Example
+		if example == nil && mediaType.Schema != nil && mediaType.Schema.Value != nil {
+			example = mediaType.Schema.Value.Example
+		}
+	}
+	if example == nil {
+		return "{}"
+	}
+	buf, err := json.Marshal(example)
+	if err != nil {
+		return "{}"
+	}
+	return string(buf)
+}
+
+// pathParamRegexp matches the parameters of a path template.
+var pathParamRegexp = regexp.MustCompile(`{[^}]*}`)
+
+// pathPattern returns a regular expression matching the paths of requests to
+// a path template, whatever the values of its parameters.
+func pathPattern(path string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range pathParamRegexp.FindAllStringIndex(path, -1) {
+		b.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		b.WriteString(`[^/]*`)
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(path[last:]))
+	return b.String()
+}
+
+// capturingPathPattern returns a regular expression matching the escaped
+// paths of requests of an operation to a path template, capturing the values
+// of the given parameters, still escaped. It's anchored at both ends, the
+// paths starting with the base path of one of the servers of the operation,
+// or none.
+func capturingPathPattern(op *OperationDefinition, path string, captured []string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	if bases := serverBasePathPatterns(op); len(bases) != 0 {
+		b.WriteString("(?:" + strings.Join(bases, "|") + ")?")
+	}
+	last := 0
+	for _, loc := range pathParamRegexp.FindAllStringIndex(path, -1) {
+		b.WriteString(escapedPathPattern(path[last:loc[0]]))
+		if StringInArray(path[loc[0]+1:loc[1]-1], captured) {
+			b.WriteString(`([^/]*)`)
+		} else {
+			b.WriteString(`[^/]*`)
+		}
+		last = loc[1]
+	}
+	b.WriteString(escapedPathPattern(path[last:]))
+	return b.String() + "$"
+}
+
+// escapedPathPattern returns a regular expression matching the escaped form
+// of a literal part of a path.
+func escapedPathPattern(path string) string {
+	return regexp.QuoteMeta((&url.URL{Path: path}).EscapedPath())
+}
+
+// serverBasePathPatterns returns the regular expressions matching the base
+// paths of the servers of an operation, those overriding the servers of the
+// spec or else those, sorted, the values of their variables being their
+// defaults or enums.
+func serverBasePathPatterns(op *OperationDefinition) []string {
+	servers := op.Servers
+	if len(servers) == 0 && globalState.spec != nil {
+		servers = DescribeServers(globalState.spec.Servers)
+	}
+	var patterns []string
+	for _, server := range servers {
+		base := server.URL
+		if i := strings.Index(base, "://"); i >= 0 {
+			base = base[i+len("://"):]
+			if i = strings.Index(base, "/"); i < 0 {
+				continue
+			}
+			base = base[i:]
+		}
+		base = strings.TrimRight(base, "/")
+		if !strings.HasPrefix(base, "/") {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, loc := range pathParamRegexp.FindAllStringIndex(base, -1) {
+			b.WriteString(escapedPathPattern(base[last:loc[0]]))
+			var values []string
+			for _, variable := range server.Variables {
+				if variable.Name == base[loc[0]+1:loc[1]-1] {
+					values = appendUnique(values, escapedPathPattern(variable.Default))
+					for _, value := range variable.Enum {
+						values = appendUnique(values, escapedPathPattern(value))
+					}
+				}
+			}
+			b.WriteString("(?:" + strings.Join(values, "|") + ")")
+			last = loc[1]
+		}
+		b.WriteString(escapedPathPattern(base[last:]))
+		patterns = appendUnique(patterns, b.String())
+	}
+	sort.Strings(patterns)
+	return patterns
+}
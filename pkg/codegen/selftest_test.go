@@ -0,0 +1,176 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestGenerateSelfTest(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/self-test.yaml")
+	require.NoError(t, err)
+
+	output, err := GenerateOutput(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Client:   true,
+			SelfTest: true,
+		},
+	})
+	require.NoError(t, err)
+
+	test := output.SelfTest
+	assert.Contains(t, test, "package api")
+
+	// The examples round-trip through their types
+	assert.Contains(t, test, `{"Pet", "{\"born\":\"2020-02-29\",\"id\":\"0b3a3e1c-4c7a-4fa5-9d7e-3c0c3b0b1a2d\",\"name\":\"Rex\",\"size\":2,\"status\":\"available\"}", new(Pet)},`)
+	assert.Contains(t, test, `{"PetStatus", "\"sold\"", new(PetStatus)},`)
+
+	// The enum constants are among the values of their schemas
+	assert.Contains(t, test, `{"PetStatus", []interface{}{Available, Pending, Sold}, "[\"available\",\"pending\",\"sold\"]"},`)
+	assert.Contains(t, test, `{"PetSize", []interface{}{N1, N2, N3}, "[1,2,3]"},`)
+
+	// The request builders produce the paths of their operations
+	assert.Contains(t, test, `{"AddPet", NewAddPetRequestWithBody, "POST", "^/api/pets$"},`)
+	assert.Contains(t, test, `{"GetPetPhoto", NewGetPetPhotoRequest, "GET", "^/api/pets/[^/]*/photos/[^/]*\\.[^/]*$"},`)
+
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "test.gen_test.go", []byte(test))
+}
+
+func TestPathPattern(t *testing.T) {
+	assert.Equal(t, `/pets`, pathPattern("/pets"))
+	assert.Equal(t, `/pets/[^/]*/photos/[^/]*\.[^/]*`, pathPattern("/pets/{id}/photos/{photoId}.{format}"))
+}
+
+func TestGenerateFuzz(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/fuzz.yaml")
+	require.NoError(t, err)
+
+	output, err := GenerateOutput(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:             true,
+			ChiServer:          true,
+			SecurityMiddleware: true,
+			Fuzz:               true,
+		},
+	})
+	require.NoError(t, err)
+
+	test := output.SelfTest
+	// Only the fuzz tests are generated without the self-test option
+	assert.NotContains(t, test, "func TestGeneratedEnums(")
+
+	// The requests go through the generated server, seeded from the examples
+	assert.Contains(t, test, "func (fuzzServer) UpdatePet(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {")
+	assert.Contains(t, test, "handler := serverHandler(fuzzServer{})")
+	// Through the security requirements and the middlewares, which would
+	// panic without the functions of their options
+	assert.Contains(t, test, "return HandlerWithOptions(si, ChiServerOptions{\n\t\tAuthenticationFunc: func(ctx context.Context, scheme string, scopes []string) error {\n\t\t\treturn nil\n\t\t},\n")
+	assert.Contains(t, test, "\"audit\": func(next http.Handler) http.Handler { return next },")
+	assert.Contains(t, test, `f.Add("limit=10", "abc")`)
+	assert.Contains(t, test, `req.Header.Set("X-Request-Id", header0)`)
+	assert.Contains(t, test, `f.Add("0b3a3e1c-4c7a-4fa5-9d7e-3c0c3b0b1a2d", "", []byte("{}"))`)
+	assert.Contains(t, test, `http.NewRequest("PUT", "http://example.com/pets/"+url.PathEscape(path0)+"?"+query, bytes.NewReader(body))`)
+
+	// The bodies are decoded into their types
+	assert.Contains(t, test, `f.Add("", []byte("{\"born\":\"2020-02-29\",\"name\":\"Rex\"}"))`)
+	assert.Contains(t, test, "var value AddPetJSONRequestBody")
+
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "test.gen_test.go", []byte(test))
+}
+
+func TestGenerateFuzzStrict(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/fuzz.yaml")
+	require.NoError(t, err)
+
+	output, err := GenerateOutput(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			EchoServer: true,
+			Strict:     true,
+			Fuzz:       true,
+		},
+	})
+	require.NoError(t, err)
+
+	test := output.SelfTest
+	assert.Contains(t, test, "func (fuzzServer) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {")
+	assert.Contains(t, test, "handler := serverHandler(NewStrictHandler(fuzzServer{}, nil))")
+	assert.Contains(t, test, "RegisterHandlersWithMiddlewares(e, si, \"\", map[string]echo.MiddlewareFunc{\n\t\t\"audit\": func(next echo.HandlerFunc) echo.HandlerFunc { return next },\n\t})")
+
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "test.gen_test.go", []byte(test))
+}
+
+func TestGenerateContractTest(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/contract.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			EmbeddedSpec: true,
+			ContractTest: true,
+		},
+	}
+	assert.ErrorContains(t, opts.Validate(), "the contract test needs")
+	opts.Generate.GinServer = true
+	require.NoError(t, opts.Validate())
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+
+	test := output.SelfTest
+	assert.Contains(t, test, "func TestContract(t *testing.T) {")
+	assert.Contains(t, test, "server := httptest.NewServer(serverHandler(contractServer{}))")
+	assert.Contains(t, test, `writeContractResponse(c.Writer, "GetPet")`)
+
+	// Required parameters and properties without examples get placeholders
+	assert.Contains(t, test, `{"ListPets", NewListPetsRequest, ParseListPetsResponse, []string{}, "{\"X-Request-Id\":\"00000000-0000-0000-0000-000000000000\",\"limit\":1,\"tags\":[\"dog\",\"cat\"]}", "", "", false, 200, false},`)
+	assert.Contains(t, test, `{"AddPet", NewAddPetRequestWithBody, ParseAddPetResponse, []string{}, "", "application/json", "{\"kind\":\"dog\",\"name\":\"xx\"}", false, 201, false},`)
+	assert.Contains(t, test, `{"DeletePet", NewDeletePetRequest, ParseDeletePetResponse, []string{"\"x\"", "\"2000-01-01\""}, "", "", "", false, 200, false},`)
+
+	// The responses are the examples, else placeholders, and non-JSON ones
+	// are left empty
+	assert.Contains(t, test, `"GetPet":     {200, "application/json", "{\"id\":7,\"kind\":\"dog\",\"name\":\"Rex\"}"},`)
+	assert.Contains(t, test, `"ListPets":   {200, "application/json", "[{\"id\":1,\"kind\":\"dog\",\"name\":\"xx\"}]"},`)
+	assert.Contains(t, test, `"GetPetName": {200, "text/plain", ""},`)
+	assert.Contains(t, test, `{"GetPetName", NewGetPetNameRequest, ParseGetPetNameResponse, []string{"1"}, "", "", "", false, 200, true},`)
+
+	checkLint(t, "test.gen.go", []byte(output.Code))
+	checkLint(t, "test.gen_test.go", []byte(test))
+}
+
+func TestSchemaExample(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/contract.yaml")
+	require.NoError(t, err)
+
+	// Recursive schemas stop at themselves
+	newPet := swagger.Components.Schemas["NewPet"]
+	newPet.Value.Required = append(newPet.Value.Required, "parent")
+	assert.Equal(t, map[string]interface{}{"name": "xx", "kind": "dog"}, schemaExample(newPet, true, make(map[*openapi3.Schema]bool)))
+
+	// Read-only properties are left out of requests, and write-only ones out
+	// of responses
+	schema := openapi3.NewObjectSchema().
+		WithProperty("id", &openapi3.Schema{Type: "integer", ReadOnly: true}).
+		WithProperty("password", &openapi3.Schema{Type: "string", WriteOnly: true, Format: "email"})
+	schema.Required = []string{"id", "password"}
+	assert.Equal(t, map[string]interface{}{"password": "user@example.com"}, schemaExample(schema.NewRef(), true, make(map[*openapi3.Schema]bool)))
+	assert.Equal(t, map[string]interface{}{"id": int64(1)}, schemaExample(schema.NewRef(), false, make(map[*openapi3.Schema]bool)))
+
+	// Times follow their custom layout
+	startsAt := &openapi3.Schema{Type: "string", Extensions: map[string]interface{}{"x-go-time-format": "02/01/2006 15:04"}}
+	assert.Equal(t, "01/01/2000 00:00", schemaExample(startsAt.NewRef(), true, make(map[*openapi3.Schema]bool)))
+}
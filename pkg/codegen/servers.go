@@ -0,0 +1,114 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultUnixSocketServer is the URL which the client sends the requests to a
+// Unix domain socket to, unless the spec declares another one.
+const defaultUnixSocketServer = "http://unix"
+
+// ServerDefinition describes an entry of a servers section of the spec.
+type ServerDefinition struct {
+	URL         string                     // The URL template, eg, https://{environment}.example.com/v1
+	Description string                     // Description of the server from the spec
+	Variables   []ServerVariableDefinition // Variables used in the URL template, sorted by name
+}
+
+// ServerVariableDefinition describes a variable substituted into a server URL
+// template.
+type ServerVariableDefinition struct {
+	Name    string
+	Default string
+	Enum    []string
+}
+
+// DescribeServers converts a servers section into a list of ServerDefinition,
+// in the order of the spec.
+func DescribeServers(servers openapi3.Servers) []ServerDefinition {
+	var result []ServerDefinition
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		def := ServerDefinition{
+			URL:         server.URL,
+			Description: server.Description,
+		}
+		names := make([]string, 0, len(server.Variables))
+		for name := range server.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			variable := server.Variables[name]
+			def.Variables = append(def.Variables, ServerVariableDefinition{
+				Name:    name,
+				Default: variable.Default,
+				Enum:    variable.Enum,
+			})
+		}
+		result = append(result, def)
+	}
+	return result
+}
+
+// ServerURLsContext is the data passed to the servers template.
+type ServerURLsContext struct {
+	Servers    []ServerDefinition    // The servers declared at the top level of the spec
+	Operations []OperationDefinition // The operations, some of which may override the servers
+}
+
+// GenerateServerURLs generates the registry of servers declared in the spec,
+// along with the servers overridden by paths and operations.
+func GenerateServerURLs(t *template.Template, spec *openapi3.T, ops []OperationDefinition) (string, error) {
+	context := ServerURLsContext{
+		Servers:    DescribeServers(spec.Servers),
+		Operations: ops,
+	}
+	return GenerateTemplates([]string{"servers.tmpl"}, t, context)
+}
+
+// unixSocketServer returns the URL of the first server of the spec, at the top
+// level or overriding it on a path or operation, declared reached over a Unix
+// domain socket with x-server-transport, or "" when there's none. The client
+// sends requests to it, http://unix for one with variables.
+func unixSocketServer(spec *openapi3.T) (string, error) {
+	servers := append(openapi3.Servers{}, spec.Servers...)
+	for _, path := range SortedPathsKeys(spec.Paths) {
+		pathItem := spec.Paths[path]
+		servers = append(servers, pathItem.Servers...)
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			if op := pathOps[method]; op.Servers != nil {
+				servers = append(servers, *op.Servers...)
+			}
+		}
+	}
+	unixServer := ""
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		ext, ok := server.Extensions[extServerTransport]
+		if !ok {
+			continue
+		}
+		transport, err := extParseServerTransport(ext)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q on the server %s: %w", extServerTransport, server.URL, err)
+		}
+		if transport == "unix" && unixServer == "" {
+			unixServer = server.URL
+			if strings.Contains(unixServer, "{") {
+				unixServer = defaultUnixSocketServer
+			}
+		}
+	}
+	return unixServer, nil
+}
@@ -0,0 +1,37 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeServers(t *testing.T) {
+	servers := openapi3.Servers{
+		{
+			URL:         "https://{environment}.example.com/{version}",
+			Description: "Main API",
+			Variables: map[string]*openapi3.ServerVariable{
+				"version":     {Default: "v1"},
+				"environment": {Default: "api", Enum: []string{"api", "staging"}},
+			},
+		},
+		nil,
+		{URL: "http://localhost:8080"},
+	}
+
+	assert.Equal(t, []ServerDefinition{
+		{
+			URL:         "https://{environment}.example.com/{version}",
+			Description: "Main API",
+			Variables: []ServerVariableDefinition{
+				{Name: "environment", Default: "api", Enum: []string{"api", "staging"}},
+				{Name: "version", Default: "v1"},
+			},
+		},
+		{URL: "http://localhost:8080"},
+	}, DescribeServers(servers))
+
+	assert.Nil(t, DescribeServers(nil))
+}
@@ -16,9 +16,13 @@ package codegen
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/util"
 	"golang.org/x/text/cases"
@@ -102,19 +106,19 @@ func genResponsePayload(operationID string) string {
 }
 
 // genResponseUnmarshal generates unmarshaling steps for structured response payloads
-func genResponseUnmarshal(op *OperationDefinition) string {
+func genResponseUnmarshal(op *OperationDefinition) (string, error) {
 	var handledCaseClauses = make(map[string]string)
 	var unhandledCaseClauses = make(map[string]string)
 
 	// Get the type definitions from the operation:
-	typeDefinitions, err := op.GetResponseTypeDefinitions()
+	typeDefinitions, err := getResponseTypeDefinitions(op)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	if len(typeDefinitions) == 0 {
 		// No types.
-		return ""
+		return "", nil
 	}
 
 	// Add a case for each possible response:
@@ -129,7 +133,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 
 		// We can't do much without a value:
 		if responseRef.Value == nil {
-			fmt.Fprintf(os.Stderr, "Response %s.%s has nil value\n", op.OperationId, typeDefinition.ResponseName)
+			warn(op, operationPath(op, "responses", typeDefinition.ResponseName), "response has no value, skipping it")
 			continue
 		}
 
@@ -165,12 +169,31 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 			case StringInArray(contentTypeName, contentTypesJSON) || util.IsMediaTypeJson(contentTypeName):
 				if typeDefinition.ContentTypeName == contentTypeName {
 					caseAction := fmt.Sprintf("var dest %s\n"+
-						"if err := json.Unmarshal(bodyBytes, &dest); err != nil { \n"+
-						" return nil, err \n"+
+						"if err := %s(bodyBytes, &dest); err != nil { \n"+
+						" return nil, %s \n"+
 						"}\n"+
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
+						responseJSONUnmarshal(typeDefinition),
+						decodeError(op),
 						typeDefinition.TypeName)
+					if globalState.encrypted {
+						// The encrypted properties are decrypted first
+						caseAction = fmt.Sprintf("var dest %s\n"+
+							"data, err := decryptResponseBody(rsp, bodyBytes, &dest)\n"+
+							"if err == nil {\n"+
+							" err = %s(data, &dest)\n"+
+							"}\n"+
+							"if err != nil { \n"+
+							" return nil, %s \n"+
+							"}\n"+
+							"response.%s = &dest",
+							typeDefinition.Schema.TypeDecl(),
+							responseJSONUnmarshal(typeDefinition),
+							decodeError(op),
+							typeDefinition.TypeName)
+					}
+					caseAction += dropBody(op)
 
 					if jsonCount > 1 {
 						caseKey, caseClause := buildUnmarshalCaseStrict(typeDefinition, caseAction, contentTypeName)
@@ -186,11 +209,13 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				if typeDefinition.ContentTypeName == contentTypeName {
 					caseAction := fmt.Sprintf("var dest %s\n"+
 						"if err := yaml.Unmarshal(bodyBytes, &dest); err != nil { \n"+
-						" return nil, err \n"+
+						" return nil, %s \n"+
 						"}\n"+
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
+						decodeError(op),
 						typeDefinition.TypeName)
+					caseAction += dropBody(op)
 					caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "yaml")
 					handledCaseClauses[caseKey] = caseClause
 				}
@@ -200,11 +225,27 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				if typeDefinition.ContentTypeName == contentTypeName {
 					caseAction := fmt.Sprintf("var dest %s\n"+
 						"if err := xml.Unmarshal(bodyBytes, &dest); err != nil { \n"+
-						" return nil, err \n"+
+						" return nil, %s \n"+
 						"}\n"+
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
+						decodeError(op),
 						typeDefinition.TypeName)
+					// XML documents have a single root, so arrays come wrapped in
+					// an element, whatever their items are named.
+					if schema := typeDefinition.Schema.OAPISchema; schema != nil && schema.Type == "array" {
+						caseAction = fmt.Sprintf("var dest struct {\n"+
+							"Items %s `xml:\",any\"`\n"+
+							"}\n"+
+							"if err := xml.Unmarshal(bodyBytes, &dest); err != nil { \n"+
+							" return nil, %s \n"+
+							"}\n"+
+							"response.%s = &dest.Items",
+							typeDefinition.Schema.TypeDecl(),
+							decodeError(op),
+							typeDefinition.TypeName)
+					}
+					caseAction += dropBody(op)
 					caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "xml")
 					handledCaseClauses[caseKey] = caseClause
 				}
@@ -220,7 +261,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 
 	if len(handledCaseClauses)+len(unhandledCaseClauses) == 0 {
 		// switch would be empty.
-		return ""
+		return "", nil
 	}
 
 	// Now build the switch statement in order of most-to-least specific:
@@ -237,7 +278,38 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 	}
 	fmt.Fprintf(buffer, "}\n")
 
-	return buffer.String()
+	return buffer.String(), nil
+}
+
+// dropBody returns the statement dropping the body of a response to op once
+// it's decoded, with the drop-body response decoding. The responses of the
+// operations with links mapping values from their bodies keep it, for the
+// methods following them.
+func dropBody(op *OperationDefinition) string {
+	if responseDecoding() == ResponseDecodingDropBody && !linksFromBody(op) {
+		return "\nresponse.Body = nil"
+	}
+	return ""
+}
+
+// decodeError returns the error returned when the body of a response to op
+// can't be decoded, wrapped in a *DecodeError with the client-error-types
+// option.
+func decodeError(op *OperationDefinition) string {
+	if globalState.options.OutputOptions.ClientErrorTypes {
+		return fmt.Sprintf("newDecodeError(%q, rsp, err)", op.OperationId)
+	}
+	return "err"
+}
+
+// transportError returns the error returned when the request of the operation
+// with the given ID fails, wrapped in a *TransportError with the
+// client-error-types option.
+func transportError(operationID string) string {
+	if globalState.options.OutputOptions.ClientErrorTypes {
+		return fmt.Sprintf("&TransportError{OperationID: %q, Err: err}", operationID)
+	}
+	return "err"
 }
 
 // buildUnmarshalCase builds an unmarshaling case clause for different content-types:
@@ -260,12 +332,63 @@ func genResponseTypeName(operationID string) string {
 	return fmt.Sprintf("%s%s", UppercaseFirstCharacter(operationID), responseTypeSuffix)
 }
 
-func getResponseTypeDefinitions(op *OperationDefinition) []ResponseTypeDefinition {
+func getResponseTypeDefinitions(op *OperationDefinition) ([]ResponseTypeDefinition, error) {
 	td, err := op.GetResponseTypeDefinitions()
 	if err != nil {
-		panic(err)
+		return nil, Diagnostic{
+			OperationID: op.OperationId,
+			Path:        operationPath(op, "responses"),
+			Reason:      err.Error(),
+		}
+	}
+	return td, nil
+}
+
+// ResponseAccessors describes the Success and Error methods of the response
+// type of an operation, which return the decoded body of its successful, or
+// error, response.
+type ResponseAccessors struct {
+	SuccessType string                   // The type Success returns, empty when it isn't generated
+	Success     []ResponseTypeDefinition // The fields of the 2xx responses
+	ErrorType   string                   // The type Error returns, empty when it isn't generated
+	Errors      []ResponseTypeDefinition // The fields of the 4xx, 5xx and default responses
+}
+
+// getResponseAccessors describes the Success and Error methods of the
+// response type of an operation, which are only generated when the decoded
+// bodies of the 2xx responses, or of the error ones, all have the same type.
+func getResponseAccessors(op *OperationDefinition) (ResponseAccessors, error) {
+	tds, err := getResponseTypeDefinitions(op)
+	if err != nil {
+		return ResponseAccessors{}, err
 	}
-	return td
+	var accessors ResponseAccessors
+	for _, td := range tds {
+		switch {
+		case strings.HasPrefix(td.ResponseName, "2"):
+			accessors.Success = append(accessors.Success, td)
+		case strings.HasPrefix(td.ResponseName, "4"), strings.HasPrefix(td.ResponseName, "5"), td.ResponseName == "default":
+			accessors.Errors = append(accessors.Errors, td)
+		}
+	}
+	accessors.SuccessType = commonResponseType(accessors.Success)
+	accessors.ErrorType = commonResponseType(accessors.Errors)
+	return accessors, nil
+}
+
+// commonResponseType returns the type of the decoded bodies of responses, or
+// "" if there are none, or they don't all have the same type.
+func commonResponseType(tds []ResponseTypeDefinition) string {
+	if len(tds) == 0 {
+		return ""
+	}
+	common := tds[0].Schema.TypeDecl()
+	for _, td := range tds[1:] {
+		if td.Schema.TypeDecl() != common {
+			return ""
+		}
+	}
+	return common
 }
 
 // Return the statusCode comparison clause from the response name.
@@ -289,11 +412,443 @@ func toStringArray(sarr []string) string {
 	return `[]string{` + s + `}`
 }
 
+// toStringArrays is toStringArray for a slice of slices.
+func toStringArrays(sarrs [][]string) string {
+	elems := make([]string, len(sarrs))
+	for i, sarr := range sarrs {
+		elems[i] = strings.TrimPrefix(toStringArray(sarr), "[]string")
+	}
+	return `[][]string{` + strings.Join(elems, ", ") + `}`
+}
+
 func stripNewLines(s string) string {
 	r := strings.NewReplacer("\n", "")
 	return r.Replace(s)
 }
 
+// rateLimits returns the distinct rate limiters used by the given operations,
+// sorted by key, so that the client can construct each of them once.
+func rateLimits(ops []OperationDefinition) []RateLimitDefinition {
+	byKey := make(map[string]RateLimitDefinition)
+	for _, op := range ops {
+		if op.RateLimit != nil {
+			byKey[op.RateLimit.Key] = *op.RateLimit
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]RateLimitDefinition, len(keys))
+	for i, k := range keys {
+		result[i] = byKey[k]
+	}
+	return result
+}
+
+// MiddlewareDefinition describes a server middleware named in the
+// x-middleware extension of one or more operations.
+type MiddlewareDefinition struct {
+	Name   string // The name of the middleware in the spec
+	GoName string // The name used in generated identifiers, like WithAuthMiddleware
+}
+
+// middlewares returns the distinct middlewares named by the given operations,
+// sorted by name.
+func middlewares(ops []OperationDefinition) ([]MiddlewareDefinition, error) {
+	byGoName := make(map[string]string)
+	for _, op := range ops {
+		for _, name := range op.Middlewares {
+			goName := SchemaNameToTypeName(name)
+			if other, found := byGoName[goName]; found && other != name {
+				return nil, fmt.Errorf("middlewares %q and %q have the same Go name %s", other, name, goName)
+			}
+			byGoName[goName] = name
+		}
+	}
+	result := make([]MiddlewareDefinition, 0, len(byGoName))
+	for goName, name := range byGoName {
+		result = append(result, MiddlewareDefinition{Name: name, GoName: goName})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// operationsWithServers returns the operations which override the top level
+// servers of the spec with their own.
+func operationsWithServers(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if len(op.Servers) > 0 {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// operationsWithBinaryBodies returns the operations accepting binary bodies.
+func operationsWithBinaryBodies(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if op.HasBinaryBody() {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// longRunningOperations returns the operations declared long-running, for
+// which the client waits for the completion.
+func longRunningOperations(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if op.LongRunning != nil {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// conditionalOperations returns the operations accepting If-Match or
+// If-None-Match headers.
+func conditionalOperations(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if len(op.ConditionalHeaders()) != 0 {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// coalescedOperations returns the GET operations, whose concurrent calls with
+// the same parameters the CoalescingClient shares.
+func coalescedOperations(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if op.Method == "GET" && !op.HasBody() {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// batchOperations returns the batch operations.
+func batchOperations(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	for _, op := range ops {
+		if op.Batch != nil {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// batchedOperations returns the operations which any batch operation sends,
+// once each.
+func batchedOperations(ops []OperationDefinition) []OperationDefinition {
+	var result []OperationDefinition
+	seen := make(map[string]bool)
+	for _, op := range batchOperations(ops) {
+		for _, batched := range op.Batch.Operations {
+			if !seen[batched.OperationId] {
+				seen[batched.OperationId] = true
+				result = append(result, batched)
+			}
+		}
+	}
+	return result
+}
+
+// ClientService describes the operations of a tag, which the client returns
+// from a method named after it with the client-services option.
+type ClientService struct {
+	Tag        string                // The name of the tag, like pets
+	GoName     string                // The name of the method returning the operations, like Pets
+	Operations []OperationDefinition // The operations of the tag, in the order of the client
+}
+
+// clientServices returns the services of the tags of the operations, sorted by
+// name, failing when the methods returning them collide with each other or
+// with the operations.
+func clientServices(ops []OperationDefinition) ([]ClientService, error) {
+	byTag := make(map[string]*ClientService)
+	operationIDs := make(map[string]bool)
+	for _, op := range ops {
+		operationIDs[op.OperationId] = true
+		if op.Spec == nil {
+			continue
+		}
+		for _, tag := range op.Spec.Tags {
+			service, ok := byTag[tag]
+			if !ok {
+				service = &ClientService{Tag: tag, GoName: SchemaNameToTypeName(tag)}
+				byTag[tag] = service
+			}
+			service.Operations = append(service.Operations, op)
+		}
+	}
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	services := make([]ClientService, 0, len(tags))
+	tagsByName := make(map[string]string)
+	for _, tag := range tags {
+		service := byTag[tag]
+		if other, ok := tagsByName[service.GoName]; ok {
+			return nil, fmt.Errorf("the services of the tags %q and %q are both named %s", other, tag, service.GoName)
+		}
+		if operationIDs[service.GoName] {
+			return nil, fmt.Errorf("the service of the tag %q is named %s, like an operation", tag, service.GoName)
+		}
+		tagsByName[service.GoName] = tag
+		services = append(services, *service)
+	}
+	return services, nil
+}
+
+// checkClientServices fails when the methods returning the services of the
+// tags collide with the other fields and methods of the clients in the
+// generated code, or their interfaces with the other types. Code which
+// doesn't parse is left to the formatting to report.
+func checkClientServices(code string, ops []OperationDefinition) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+	if err != nil {
+		return nil
+	}
+	clients := []string{globalState.options.OutputOptions.ClientTypeName, "ClientWithResponses"}
+	fields := make(map[string]map[string]bool)
+	methods := make(map[string]map[string]int)
+	types := make(map[string]int)
+	for _, client := range clients {
+		fields[client] = make(map[string]bool)
+		methods[client] = make(map[string]int)
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				types[typeSpec.Name.Name]++
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok || fields[typeSpec.Name.Name] == nil {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						fields[typeSpec.Name.Name][name.Name] = true
+					}
+					if len(field.Names) == 0 {
+						fields[typeSpec.Name.Name][embeddedName(field.Type)] = true
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			if receiver := embeddedName(decl.Recv.List[0].Type); methods[receiver] != nil {
+				methods[receiver][decl.Name.Name]++
+			}
+		}
+	}
+
+	services, err := clientServices(ops)
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		for _, client := range clients {
+			if fields[client][service.GoName] {
+				return fmt.Errorf("the service of the tag %q is named %s, like a field of %s", service.Tag, service.GoName, client)
+			}
+			if methods[client][service.GoName] > 1 {
+				return fmt.Errorf("the service of the tag %q is named %s, like a method of %s", service.Tag, service.GoName, client)
+			}
+		}
+		for _, name := range []string{service.GoName + "Service", service.GoName + "ServiceWithResponses"} {
+			if types[name] > 1 {
+				return fmt.Errorf("the service of the tag %q is declared as %s, like another type", service.Tag, name)
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedName returns the name of the type of an embedded field or of a
+// receiver, without its pointer or package.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// allowReservedParamNames returns the names of the parameters whose values
+// may contain reserved characters, which shouldn't be percent-encoded.
+func allowReservedParamNames(params []ParameterDefinition) []string {
+	var names []string
+	for _, p := range params {
+		if p.Spec != nil && p.Spec.AllowReserved {
+			names = append(names, p.ParamName)
+		}
+	}
+	return names
+}
+
+// allowReservedOperations returns whether any of the operations has a query
+// parameter allowing reserved characters.
+func allowReservedOperations(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		if len(allowReservedParamNames(op.QueryParams)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encrypted returns whether the models have encrypted properties, which the
+// clients and servers encrypt and decrypt the JSON bodies of.
+func encrypted() bool {
+	return globalState.encrypted
+}
+
+// jsonUnmarshal returns the function unmarshaling JSON in the generated code,
+// which decodes numbers as json.Number with the json-number option.
+func jsonUnmarshal() string {
+	if globalState.options.OutputOptions.JsonNumber {
+		return "unmarshalJSONNumbers"
+	}
+	return "json.Unmarshal"
+}
+
+// responseJSONUnmarshal returns the function unmarshaling a JSON response of
+// the given type, which rejects unknown fields while StrictDecoding is on for
+// the schemas declared with x-strict-decoding, or all of them with the
+// strict-decoding option.
+func responseJSONUnmarshal(td ResponseTypeDefinition) string {
+	if strict, _ := strictDecoding(td); strict {
+		return "unmarshalJSONStrict"
+	}
+	return jsonUnmarshal()
+}
+
+// strictDecoding reports whether the client decodes the JSON responses of the
+// given type strictly, from the x-strict-decoding of their schema, or that of
+// the items of an array.
+func strictDecoding(td ResponseTypeDefinition) (bool, error) {
+	if globalState.options.OutputOptions.StrictDecoding {
+		return true, nil
+	}
+	schema := td.Schema.OAPISchema
+	if schema != nil && schema.Type == "array" && schema.Items != nil && schema.Items.Value != nil {
+		if _, ok := schema.Extensions[extStrictDecoding]; !ok {
+			schema = schema.Items.Value
+		}
+	}
+	if schema == nil {
+		return false, nil
+	}
+	extension, ok := schema.Extensions[extStrictDecoding]
+	if !ok {
+		return false, nil
+	}
+	strict, err := extParseStrictDecoding(extension)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %q: %w", extStrictDecoding, err)
+	}
+	return strict, nil
+}
+
+// strictDecodingOperations reports whether the client decodes a response of
+// the operations strictly.
+func strictDecodingOperations(ops []OperationDefinition) bool {
+	for i := range ops {
+		typeDefinitions, err := getResponseTypeDefinitions(&ops[i])
+		if err != nil {
+			continue
+		}
+		for _, td := range typeDefinitions {
+			if strict, _ := strictDecoding(td); strict {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// responseDecoding returns how the client with responses decodes the bodies
+// of the responses, "eager", "lazy" or "drop-body".
+func responseDecoding() string {
+	if decoding := globalState.options.OutputOptions.ClientResponseDecoding; decoding != "" {
+		return decoding
+	}
+	return ResponseDecodingEager
+}
+
+// echoContextType returns the type of the context passed to echo handlers,
+// which is a struct pointer as of echo v5.
+func echoContextType() string {
+	if globalState.options.OutputOptions.EchoVersion == 5 {
+		return "*echo.Context"
+	}
+	return "echo.Context"
+}
+
+// echoRouteType returns the type returned by the route registration methods
+// of echo.
+func echoRouteType() string {
+	if globalState.options.OutputOptions.EchoVersion == 5 {
+		return "echo.RouteInfo"
+	}
+	return "*echo.Route"
+}
+
+// unixSocketServerURL returns the URL which the client sends requests to a Unix
+// domain socket to, from x-server-transport.
+func unixSocketServerURL() string {
+	return globalState.unixSocketServer
+}
+
+// durationLiteral converts a duration string such as "1m30s" into a Go
+// expression of type time.Duration, eg: "90 * time.Second".
+func durationLiteral(s string) (string, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return "", err
+	}
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name), nil
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d), nil
+}
+
 // TemplateFunctions is passed to the template engine, and we can call each
 // function here by keyName from the template code.
 var TemplateFunctions = template.FuncMap{
@@ -301,6 +856,7 @@ var TemplateFunctions = template.FuncMap{
 	"genParamTypes":              genParamTypes,
 	"genParamNames":              genParamNames,
 	"genParamFmtString":          ReplacePathParamsWithStr,
+	"genOperationPath":           ReplacePathParamsWithVars,
 	"swaggerUriToIrisUri":        SwaggerUriToIrisUri,
 	"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
 	"swaggerUriToFiberUri":       SwaggerUriToFiberUri,
@@ -315,10 +871,39 @@ var TemplateFunctions = template.FuncMap{
 	"genResponseTypeName":        genResponseTypeName,
 	"genResponseUnmarshal":       genResponseUnmarshal,
 	"getResponseTypeDefinitions": getResponseTypeDefinitions,
+	"getResponseAccessors":       getResponseAccessors,
 	"toStringArray":              toStringArray,
+	"toStringArrays":             toStringArrays,
+	"credentialHeaders":          credentialHeaders,
 	"lower":                      strings.ToLower,
+	"join":                       strings.Join,
+	"trimPrefix":                 func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"inc":                        func(i int) int { return i + 1 },
 	"title":                      titleCaser.String,
 	"stripNewLines":              stripNewLines,
+	"docsCell":                   docsCell,
 	"sanitizeGoIdentity":         SanitizeGoIdentity,
 	"toGoComment":                StringWithTypeNameToGoComment,
+	"rateLimits":                 rateLimits,
+	"durationLiteral":            durationLiteral,
+	"allowReservedParamNames":    allowReservedParamNames,
+	"allowReservedOperations":    allowReservedOperations,
+	"jsonUnmarshal":              jsonUnmarshal,
+	"encrypted":                  encrypted,
+	"echoContextType":            echoContextType,
+	"responseDecoding":           responseDecoding,
+	"echoRouteType":              echoRouteType,
+	"operationsWithServers":      operationsWithServers,
+	"operationsWithBinaryBodies": operationsWithBinaryBodies,
+	"longRunningOperations":      longRunningOperations,
+	"conditionalOperations":      conditionalOperations,
+	"batchOperations":            batchOperations,
+	"clientServices":             clientServices,
+	"captureRedactions":          captureRedactions,
+	"cassetteMatchers":           cassetteMatchers,
+	"batchedOperations":          batchedOperations,
+	"middlewares":                middlewares,
+	"unixSocketServer":           unixSocketServerURL,
+	"transportError":             transportError,
+	"coalescedOperations":        coalescedOperations,
 }
@@ -0,0 +1,298 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TerraformModel describes the Terraform Plugin Framework model of an object
+// type, with conversions from and to the type.
+type TerraformModel struct {
+	TypeName string           // The name of the API type, like Pet
+	Fields   []TerraformField // The fields of the model, of the properties which have a Terraform type
+}
+
+// ModelName returns the name of the model, like PetModel.
+func (m TerraformModel) ModelName() string {
+	return m.TypeName + "Model"
+}
+
+// TerraformField describes a field of a Terraform model, named like the
+// field of the API type it converts.
+type TerraformField struct {
+	Name      string // The Go name of the field
+	Attribute string // The name of the attribute, in its tfsdk tag
+	Type      string // The Go type of the field, like types.String or []PetModel
+	ToModel   string // The statements setting the field of the model m from the API value v
+	FromModel string // The statements setting the field of the API value result from the model m
+}
+
+// terraformScalar describes the Terraform Plugin Framework type of a scalar.
+type terraformScalar struct {
+	tfType string // The attribute type, like types.String
+	name   string // The name in its functions, like String for types.StringValue and ValueString
+	goType string // The Go type of its values, like string
+}
+
+var (
+	terraformString  = terraformScalar{"types.String", "String", "string"}
+	terraformInt64   = terraformScalar{"types.Int64", "Int64", "int64"}
+	terraformFloat64 = terraformScalar{"types.Float64", "Float64", "float64"}
+	terraformBool    = terraformScalar{"types.Bool", "Bool", "bool"}
+)
+
+// terraformModels describes the models of the object types, which are the
+// ones with properties, and neither additional properties nor unions.
+type terraformModels struct {
+	models map[string]*TerraformModel
+	types  map[string]TypeDefinition
+}
+
+// DescribeTerraformModels describes the Terraform models of the object types
+// among the given ones. Properties without a Terraform type are left out of
+// the models, with a warning.
+func DescribeTerraformModels(types []TypeDefinition) ([]TerraformModel, error) {
+	d := terraformModels{
+		models: make(map[string]*TerraformModel),
+		types:  make(map[string]TypeDefinition),
+	}
+	var ordered []*TerraformModel
+	for _, td := range types {
+		d.types[td.TypeName] = td
+		schema := td.Schema
+		if schema.IsRef() || !strings.HasPrefix(schema.GoType, "struct") || len(schema.Properties) == 0 ||
+			schema.HasAdditionalProperties || len(schema.UnionElements) != 0 {
+			continue
+		}
+		model := &TerraformModel{TypeName: td.TypeName}
+		d.models[td.TypeName] = model
+		ordered = append(ordered, model)
+	}
+	for _, model := range ordered {
+		if _, found := d.types[model.ModelName()]; found {
+			return nil, fmt.Errorf("the Terraform model %s collides with the %s type of the spec, which x-go-type-name can rename", model.ModelName(), model.ModelName())
+		}
+	}
+
+	result := make([]TerraformModel, 0, len(ordered))
+	for _, model := range ordered {
+		td := d.types[model.TypeName]
+		attributes := make(map[string]string)
+		for _, p := range td.Schema.Properties {
+			if p.JsonIgnored() {
+				continue
+			}
+			field, ok := d.field(p)
+			if !ok {
+				warn(nil, jsonPointer("components", "schemas", td.JsonName, "properties", p.JsonFieldName),
+					"property %s has no Terraform type, so %s leaves it out", p.JsonFieldName, model.ModelName())
+				continue
+			}
+			if other, found := attributes[field.Attribute]; found {
+				return nil, fmt.Errorf("properties %s and %s of %s have the same Terraform attribute name %s", other, p.JsonFieldName, td.TypeName, field.Attribute)
+			}
+			attributes[field.Attribute] = p.JsonFieldName
+			model.Fields = append(model.Fields, field)
+		}
+		result = append(result, *model)
+	}
+	return result, nil
+}
+
+// field describes the model field of a property, returning false when its
+// type has no Terraform equivalent.
+func (d terraformModels) field(p Property) (TerraformField, bool) {
+	name := p.structFieldName()
+	field := TerraformField{
+		Name:      name,
+		Attribute: toSnakeCase(p.JsonFieldName),
+	}
+	pointer := strings.HasPrefix(p.structFieldType(), "*")
+	v, result, m := "v."+name, "result."+name, "m."+name
+
+	// The items of arrays, which are never pointers
+	if items := p.Schema.ArrayType; items != nil && !p.Schema.IsRef() {
+		value := v
+		if pointer {
+			value = "*" + v
+		}
+		setResult := fmt.Sprintf("%s = items", result)
+		if pointer {
+			setResult = fmt.Sprintf("%s = &items", result)
+		}
+		if model := d.model(*items); model != nil {
+			field.Type = "[]" + model.ModelName()
+			field.ToModel = fmt.Sprintf("if %s != nil {\nfor _, item := range %s {\n%s = append(%s, New%s(item))\n}\n}",
+				v, value, m, m, model.ModelName())
+			field.FromModel = fmt.Sprintf("if %s != nil {\nitems := make([]%s, 0, len(%s))\nfor _, item := range %s {\nvalue, err := item.ToAPI()\nif err != nil {\nreturn result, err\n}\nitems = append(items, value)\n}\n%s\n}",
+				m, items.TypeDecl(), m, m, setResult)
+			return field, true
+		}
+		scalar, toTF, fromTF, ok := d.scalar(*items, p.JsonFieldName)
+		if !ok {
+			return field, false
+		}
+		field.Type = "[]" + scalar.tfType
+		field.ToModel = fmt.Sprintf("if %s != nil {\nfor _, item := range %s {\n%s = append(%s, %s)\n}\n}",
+			v, value, m, m, toTF("item"))
+		field.FromModel = fmt.Sprintf("if %s != nil {\nitems := make([]%s, 0, len(%s))\nfor _, item := range %s {\n%s\n}\n%s\n}",
+			m, items.TypeDecl(), m, m, assignValue(fromTF("item"), "items = append(items, %s)"), setResult)
+		return field, true
+	}
+
+	if model := d.model(p.Schema); model != nil {
+		field.Type = "*" + model.ModelName()
+		if pointer {
+			field.ToModel = fmt.Sprintf("if %s != nil {\nmodel := New%s(*%s)\n%s = &model\n}", v, model.ModelName(), v, m)
+		} else {
+			field.ToModel = fmt.Sprintf("model := New%s(%s)\n%s = &model", model.ModelName(), v, m)
+		}
+		setResult := fmt.Sprintf("%s = value", result)
+		if pointer {
+			setResult = fmt.Sprintf("%s = &value", result)
+		}
+		field.FromModel = fmt.Sprintf("if %s != nil {\nvalue, err := %s.ToAPI()\nif err != nil {\nreturn result, err\n}\n%s\n}", m, m, setResult)
+		return field, true
+	}
+
+	scalar, toTF, fromTF, ok := d.scalar(p.Schema, p.JsonFieldName)
+	if !ok {
+		return field, false
+	}
+	field.Type = scalar.tfType
+	if pointer {
+		field.ToModel = fmt.Sprintf("%s = types.%sNull()\nif %s != nil {\n%s = %s\n}", m, scalar.name, v, m, toTF("*"+v))
+		field.FromModel = fmt.Sprintf("if !%s.IsNull() && !%s.IsUnknown() {\n%s\n%s = &value\n}", m, m, fromTF(m), result)
+	} else {
+		field.ToModel = fmt.Sprintf("%s = %s", m, toTF(v))
+		field.FromModel = assignValue(fromTF(m), result+" = %s")
+		if strings.Contains(field.FromModel, "\n") {
+			// The variables of conversions which can fail are scoped.
+			field.FromModel = "{\n" + field.FromModel + "\n}"
+		}
+	}
+	return field, true
+}
+
+// model returns the model of the type of a schema, if it has one.
+func (d terraformModels) model(schema Schema) *TerraformModel {
+	name, _ := d.resolve(schema)
+	return d.models[name]
+}
+
+// resolve follows the named types which the type of a schema is declared
+// as, returning the last one, if any, and the schema declaring it.
+func (d terraformModels) resolve(schema Schema) (string, Schema) {
+	var name string
+	for i := 0; i < len(d.types); i++ {
+		td, found := d.types[schema.TypeDecl()]
+		if !found {
+			break
+		}
+		name, schema = td.TypeName, td.Schema
+	}
+	return name, schema
+}
+
+// scalar returns the Terraform type of a scalar schema, with functions
+// returning the expression converting a Go value to it, and the statements
+// converting a Terraform value back, to a variable named value, returning an
+// error for those which can fail. It returns false for schemas without a
+// Terraform type.
+func (d terraformModels) scalar(schema Schema, attribute string) (terraformScalar, func(string) string, func(string) string, bool) {
+	goType := schema.TypeDecl()
+	_, resolved := d.resolve(schema)
+	underlying := resolved.GoType
+	if len(resolved.EnumValues) != 0 && resolved.OAPISchema != nil {
+		switch resolved.OAPISchema.Type {
+		case "string":
+			underlying = "string"
+		case "integer":
+			underlying = "int64"
+		case "number":
+			underlying = "float64"
+		case "boolean":
+			underlying = "bool"
+		}
+	}
+
+	var scalar terraformScalar
+	switch underlying {
+	case "string", "openapi_types.Email":
+		scalar = terraformString
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		scalar = terraformInt64
+	case "float32", "float64":
+		scalar = terraformFloat64
+	case "bool":
+		scalar = terraformBool
+	case "time.Time":
+		return terraformString, func(value string) string {
+				return fmt.Sprintf("types.StringValue(%s.Format(time.RFC3339Nano))", operand(value))
+			}, func(value string) string {
+				return fmt.Sprintf("value, err := time.Parse(time.RFC3339Nano, %s.ValueString())\nif err != nil {\nreturn result, fmt.Errorf(\"invalid %s: %%w\", err)\n}", value, attribute)
+			}, true
+	case "openapi_types.UUID", "uuid.UUID":
+		return terraformString, func(value string) string {
+				return fmt.Sprintf("types.StringValue(%s.String())", operand(value))
+			}, func(value string) string {
+				return fmt.Sprintf("value, err := uuid.Parse(%s.ValueString())\nif err != nil {\nreturn result, fmt.Errorf(\"invalid %s: %%w\", err)\n}", value, attribute)
+			}, true
+	default:
+		return terraformScalar{}, nil, nil, false
+	}
+
+	return scalar, func(value string) string {
+			if goType != scalar.goType {
+				value = fmt.Sprintf("%s(%s)", scalar.goType, value)
+			}
+			return fmt.Sprintf("types.%sValue(%s)", scalar.name, value)
+		}, func(value string) string {
+			value = fmt.Sprintf("%s.Value%s()", value, scalar.name)
+			if goType != scalar.goType {
+				value = fmt.Sprintf("%s(%s)", goType, value)
+			}
+			return fmt.Sprintf("value := %s", value)
+		}, true
+}
+
+// assignValue completes the statements converting a Terraform value to a
+// variable named value with the assignment of the format, which the value is
+// given to. Conversions which can't fail are assigned directly.
+func assignValue(statements, format string) string {
+	if expr, ok := strings.CutPrefix(statements, "value := "); ok && !strings.Contains(expr, "\n") {
+		return fmt.Sprintf(format, expr)
+	}
+	return statements + "\n" + fmt.Sprintf(format, "value")
+}
+
+// operand parenthesizes a dereference, so that a method can be called on it.
+func operand(value string) string {
+	if strings.HasPrefix(value, "*") {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+// toSnakeCase converts a property name, like photoUrls, to a Terraform
+// attribute name, like photo_urls, made of lower case letters, digits and
+// underscores.
+func toSnakeCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, toKebabCase(s))
+}
+
+// GenerateTerraformModels generates the Terraform Plugin Framework models of
+// the object types, with their conversions.
+func GenerateTerraformModels(t *template.Template, types []TypeDefinition) (string, error) {
+	models, err := DescribeTerraformModels(types)
+	if err != nil {
+		return "", err
+	}
+	return GenerateTemplates([]string{"terraform.tmpl"}, t, models)
+}
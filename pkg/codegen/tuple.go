@@ -0,0 +1,226 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// keywordPrefixItems declares the schemas of the first items of an array, in
+// OpenAPI 3.1. kin-openapi doesn't know the keyword, so it's found among the
+// extensions of the schema, until parsePrefixItems parses it.
+const keywordPrefixItems = "prefixItems"
+
+// TupleElement describes the field of a tuple type holding an item at a given
+// position of an array declared with prefixItems.
+type TupleElement struct {
+	GoName   string // The name of the field, from x-go-name or the title of the item, or like Item0
+	Schema   Schema // The schema of the item
+	Optional bool   // Whether arrays may end before the item, as minItems doesn't cover it, in which case the field is a pointer
+}
+
+// GoTypeDef returns the type of the field of the element.
+func (e TupleElement) GoTypeDef() string {
+	if e.Optional {
+		return "*" + e.Schema.TypeDecl()
+	}
+	return e.Schema.TypeDecl()
+}
+
+// prefixItems returns the schemas of the first items of an array, as parsed
+// by parsePrefixItems.
+func prefixItems(schema *openapi3.Schema) []*openapi3.SchemaRef {
+	items, _ := schema.Extensions[keywordPrefixItems].([]*openapi3.SchemaRef)
+	return items
+}
+
+// parsePrefixItems parses the prefixItems of the schemas of the spec, which
+// kin-openapi leaves as they are in the document, and resolves the references
+// to component schemas they make, so that the rest of the generator walks them
+// like the other schemas.
+func parsePrefixItems(spec *openapi3.T) error {
+	var err error
+	_ = walkSwagger(spec, func(ref RefWrapper) (bool, error) {
+		// What references point to is walked where it's declared.
+		if err != nil || ref.Ref != "" {
+			return false, nil
+		}
+		schemaRef, ok := ref.SourceRef.(*openapi3.SchemaRef)
+		if !ok || schemaRef.Value == nil {
+			return true, nil
+		}
+		raw, ok := schemaRef.Value.Extensions[keywordPrefixItems]
+		if !ok {
+			return true, nil
+		}
+		if _, parsed := raw.([]*openapi3.SchemaRef); parsed {
+			return true, nil
+		}
+		var items []*openapi3.SchemaRef
+		items, err = parseSchemaRefs(spec, raw)
+		if err != nil {
+			err = fmt.Errorf("invalid %s: %w", keywordPrefixItems, err)
+			return false, nil
+		}
+		schemaRef.Value.Extensions[keywordPrefixItems] = items
+		return true, nil
+	})
+	return err
+}
+
+// parseSchemaRefs parses a list of schemas left as they are in the document,
+// resolving the references to component schemas they make.
+func parseSchemaRefs(spec *openapi3.T, raw interface{}) ([]*openapi3.SchemaRef, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var refs []*openapi3.SchemaRef
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if err := resolveSchemaRef(spec, ref); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// resolveSchemaRef points the references of a schema, and of the schemas it's
+// made of, to the component schemas they reference.
+func resolveSchemaRef(spec *openapi3.T, ref *openapi3.SchemaRef) error {
+	if ref == nil {
+		return nil
+	}
+	if ref.Ref != "" {
+		name, ok := localSchemaName(ref.Ref)
+		if !ok {
+			return fmt.Errorf("reference %s isn't to a component schema of the spec", ref.Ref)
+		}
+		var component *openapi3.SchemaRef
+		if spec.Components != nil {
+			component = spec.Components.Schemas[name]
+		}
+		if component == nil {
+			return fmt.Errorf("reference %s is to a missing schema", ref.Ref)
+		}
+		ref.Value = component.Value
+		return nil
+	}
+	schema := ref.Value
+	if schema == nil {
+		return nil
+	}
+	refs := []*openapi3.SchemaRef{schema.Items, schema.Not, schema.AdditionalProperties.Schema}
+	for _, list := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		refs = append(refs, list...)
+	}
+	for _, property := range schema.Properties {
+		refs = append(refs, property)
+	}
+	for _, ref := range refs {
+		if err := resolveSchemaRef(spec, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tupleToGoType generates the struct of a tuple, with a field for each of the
+// items declared with prefixItems, and a Rest field for the items after them
+// when the schema has items.
+func tupleToGoType(schema *openapi3.Schema, items []*openapi3.SchemaRef, path []string, outSchema *Schema) error {
+	names := make(map[string]bool)
+	for i, item := range items {
+		itemPath := append(path, "Item"+strconv.Itoa(i))
+		itemSchema, err := GenerateGoSchema(item, itemPath)
+		if err != nil {
+			return fmt.Errorf("error generating type for item %d: %w", i, err)
+		}
+		if itemSchema.hasMethods() && itemSchema.RefType == "" {
+			typeName := PathToTypeName(itemPath)
+			itemSchema.AdditionalTypes = append(itemSchema.AdditionalTypes, TypeDefinition{
+				TypeName: typeName,
+				JsonName: strings.Join(itemPath, "."),
+				Schema:   itemSchema,
+			})
+			itemSchema.RefType = typeName
+		}
+
+		name := "Item" + strconv.Itoa(i)
+		if item.Ref == "" && item.Value != nil {
+			if extension, ok := item.Value.Extensions[extGoName]; ok {
+				if goName, err := extParseGoFieldName(extension); err == nil && goName != "" {
+					name = goName
+				}
+			} else if item.Value.Title != "" {
+				name = SchemaNameToTypeName(item.Value.Title)
+			}
+		}
+		if names[name] || name == "Rest" {
+			name = "Item" + strconv.Itoa(i)
+		}
+		names[name] = true
+
+		outSchema.TupleElements = append(outSchema.TupleElements, TupleElement{
+			GoName:   name,
+			Schema:   itemSchema,
+			Optional: uint64(i) >= schema.MinItems,
+		})
+		outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, itemSchema.AdditionalTypes...)
+	}
+
+	fields := make([]string, 0, len(outSchema.TupleElements)+1)
+	for _, element := range outSchema.TupleElements {
+		fields = append(fields, fmt.Sprintf("%s %s", element.GoName, element.GoTypeDef()))
+	}
+	if schema.Items != nil {
+		rest, err := GenerateGoSchema(schema.Items, path)
+		if err != nil {
+			return fmt.Errorf("error generating type for items: %w", err)
+		}
+		if rest.hasMethods() && rest.RefType == "" {
+			typeName := PathToTypeName(append(path, "Item"))
+			rest.AdditionalTypes = append(rest.AdditionalTypes, TypeDefinition{
+				TypeName: typeName,
+				JsonName: strings.Join(append(path, "Item"), "."),
+				Schema:   rest,
+			})
+			rest.RefType = typeName
+		}
+		outSchema.TupleRest = &rest
+		outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, rest.AdditionalTypes...)
+		fields = append(fields, fmt.Sprintf("Rest []%s", rest.TypeDecl()))
+	}
+	outSchema.GoType = "struct {\n" + strings.Join(fields, "\n") + "\n}"
+	return nil
+}
+
+// GenerateTupleBoilerplate generates the JSON marshaling of the tuple types,
+// to and from the array form.
+func GenerateTupleBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		if len(td.Schema.TupleElements) == 0 || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		filteredTypes = append(filteredTypes, td)
+	}
+	if len(filteredTypes) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+	return GenerateTemplates([]string{"tuple.tmpl"}, t, context)
+}
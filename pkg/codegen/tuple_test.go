@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestTuples(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/tuples.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			ChiServer:    true,
+			EmbeddedSpec: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Items are named after their x-go-name or title, and the ones beyond
+	// minItems are optional
+	assert.Contains(t, code, "type Point struct {\n\tX float32\n\tY float32\n\tZ *float32\n}")
+	assert.Contains(t, code, "func (t Point) MarshalJSON() ([]byte, error) {")
+	assert.Contains(t, code, "func (t *Point) UnmarshalJSON(b []byte) error {")
+	assert.Contains(t, code, `return fmt.Errorf("%d items, fewer than the minimum of 2", len(items))`)
+	assert.Contains(t, code, `return fmt.Errorf("%d items, more than the 3 of the tuple", len(items))`)
+
+	// Items after the prefixItems go to Rest, and inline items with methods
+	// get types of their own. Without minItems, every item is optional
+	assert.Contains(t, code, "Item0 *string")
+	assert.Contains(t, code, "Item1 *Pet")
+	assert.Contains(t, code, "Item2 *Entry_Item2")
+	assert.Contains(t, code, "Rest  []string")
+	assert.Contains(t, code, "func (a Entry_Item2) MarshalJSON() ([]byte, error) {")
+
+	// Inline tuples are named after their property
+	assert.Contains(t, code, "Meta *Segment_Meta `json:\"meta,omitempty\"`")
+	assert.Contains(t, code, "func (t *Segment_Meta) UnmarshalJSON(b []byte) error {")
+	assert.Contains(t, code, "type Segment_Meta struct {\n\tItem0 *string\n\tItem1 *bool\n}")
+	assert.Contains(t, code, "size := 0\n\tif t.Item0 != nil {\n\t\tsize = 1\n\t}")
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
+
+func TestTuplesInvalidPrefixItems(t *testing.T) {
+	spec := `
+openapi: "3.1.0"
+info: {version: 1.0.0, title: Tuples}
+paths: {}
+components:
+  schemas:
+    Pair:
+      type: array
+      prefixItems:
+        - $ref: 'other.yaml#/components/schemas/Pet'
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+	})
+	assert.ErrorContains(t, err, "invalid prefixItems: reference other.yaml#/components/schemas/Pet isn't to a component schema of the spec")
+}
@@ -0,0 +1,284 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tsIdentifier matches the property names written as they are in TypeScript,
+// the others being quoted.
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsGoTypes are the TypeScript types of the JSON values of the Go types which
+// aren't generated from a schema, like those of the formats.
+var tsGoTypes = map[string]string{
+	"string":                 "string",
+	"bool":                   "boolean",
+	"int":                    "number",
+	"int8":                   "number",
+	"int16":                  "number",
+	"int32":                  "number",
+	"uint":                   "number",
+	"uint8":                  "number",
+	"uint16":                 "number",
+	"uint32":                 "number",
+	"float32":                "number",
+	"float64":                "number",
+	"json.Number":            "number",
+	"time.Time":              "string",
+	"openapi_types.Date":     "string",
+	"openapi_types.Email":    "string",
+	"openapi_types.UUID":     "string",
+	"openapi_types.File":     "string",
+	"[]byte":                 "string",
+	"interface{}":            "unknown",
+	"json.RawMessage":        "unknown",
+	"map[string]interface{}": "Record<string, unknown>",
+}
+
+// tsInt64Types are the Go types of the integers which a number of JavaScript
+// can't hold exactly beyond 2^53, typed with the typescript-int64 option for
+// the frontends decoding them into something else.
+var tsInt64Types = map[string]bool{"int64": true, "uint64": true}
+
+// tsInt64Type returns the TypeScript type of the int64 and uint64 values,
+// number, which JSON.parse decodes them into, unless the typescript-int64
+// option says otherwise.
+func tsInt64Type() string {
+	if typ := globalState.options.OutputOptions.TypeScriptInt64; typ != "" {
+		return typ
+	}
+	return "number"
+}
+
+// GenerateTypeScript returns the TypeScript declarations of the given types,
+// with the typescript option, named like them, and with the same optional
+// and nullable properties, to keep the types of a frontend in step with
+// those of the Go code.
+func GenerateTypeScript(types []TypeDefinition) string {
+	e := tsEmitter{types: make(map[string]bool)}
+	var unique []TypeDefinition
+	for _, td := range types {
+		if !e.types[td.TypeName] {
+			e.types[td.TypeName] = true
+			unique = append(unique, td)
+		}
+	}
+
+	module, version := generatorVersion(globalState.options.NoVCSVersionOverride)
+	fmt.Fprintf(&e.b, "// Code generated by %s version %s DO NOT EDIT.\n", module, version)
+	for _, td := range unique {
+		e.b.WriteString("\n")
+		e.declare(td)
+	}
+	return e.b.String()
+}
+
+// tsEmitter writes the TypeScript declarations of types.
+type tsEmitter struct {
+	b     strings.Builder
+	types map[string]bool // The names of the declared types
+}
+
+// declare writes the declaration of a type, an interface for the objects,
+// and an alias for the others.
+func (e *tsEmitter) declare(td TypeDefinition) {
+	s := td.Schema
+	e.comment("", s.Description, s.OAPISchema != nil && s.OAPISchema.Deprecated)
+	if !td.IsAlias() && len(s.Properties) != 0 && !s.HasAdditionalProperties && len(s.UnionElements) == 0 {
+		fmt.Fprintf(&e.b, "export interface %s {\n", td.TypeName)
+		e.properties(s.Properties, "  ")
+		e.b.WriteString("}\n")
+		return
+	}
+	typ := e.structure(s)
+	if td.IsAlias() || s.IsRef() {
+		typ = e.schemaType(s)
+	}
+	fmt.Fprintf(&e.b, "export type %s = %s;\n", td.TypeName, typ)
+}
+
+// properties writes the properties of an object, optional unless they're
+// required, and null unless they're nullable, like the fields of the struct.
+func (e *tsEmitter) properties(properties []Property, indent string) {
+	for _, p := range properties {
+		if p.JsonIgnored() {
+			continue
+		}
+		e.comment(indent, p.Description, p.Deprecated)
+		name := p.JsonFieldName
+		if !tsIdentifier.MatchString(name) {
+			name = strconv.Quote(name)
+		}
+		optional := ""
+		if !p.Required {
+			optional = "?"
+		}
+		typ := e.schemaType(p.Schema)
+		if p.Nullable {
+			typ += " | null"
+		}
+		fmt.Fprintf(&e.b, "%s%s%s: %s;\n", indent, name, optional, typ)
+	}
+}
+
+// comment writes the description of a declaration, as a JSDoc comment.
+func (e *tsEmitter) comment(indent, description string, deprecated bool) {
+	description = strings.TrimSpace(description)
+	if description == "" && !deprecated {
+		return
+	}
+	lines := strings.Split(strings.ReplaceAll(description, "*/", "*\\/"), "\n")
+	if description == "" {
+		lines = nil
+	}
+	if deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(&e.b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(&e.b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(&e.b, "%s * %s\n", indent, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(&e.b, "%s */\n", indent)
+}
+
+// schemaType returns the TypeScript type of a schema, the name of its type
+// when it's declared.
+func (e *tsEmitter) schemaType(s Schema) string {
+	if name := strings.TrimPrefix(s.TypeDecl(), "*"); e.types[name] {
+		return name
+	}
+	return e.structure(s)
+}
+
+// structure returns the TypeScript type of the structure of a schema, which
+// is the declaration of its type.
+func (e *tsEmitter) structure(s Schema) string {
+	switch {
+	case len(s.EnumValues) != 0 && s.OAPISchema != nil && len(s.OAPISchema.Enum) != 0:
+		values := make([]string, len(s.OAPISchema.Enum))
+		for i, value := range s.OAPISchema.Enum {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return "unknown"
+			}
+			values[i] = string(encoded)
+		}
+		return strings.Join(values, " | ")
+	case s.NullableType != nil:
+		return e.schemaType(*s.NullableType)
+	case s.ArrayType != nil:
+		return tsArray(e.schemaType(*s.ArrayType))
+	case len(s.TupleElements) != 0:
+		elements := make([]string, 0, len(s.TupleElements)+1)
+		for _, element := range s.TupleElements {
+			typ := e.schemaType(element.Schema)
+			if element.Optional {
+				typ = tsParenthesized(typ) + "?"
+			}
+			elements = append(elements, typ)
+		}
+		if s.TupleRest != nil {
+			elements = append(elements, "..."+tsArray(e.schemaType(*s.TupleRest)))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case len(s.UnionElements) != 0 || len(s.Properties) != 0 || s.HasAdditionalProperties:
+		var parts []string
+		if len(s.Properties) != 0 {
+			var b strings.Builder
+			b.WriteString("{ ")
+			for _, p := range s.Properties {
+				if p.JsonIgnored() {
+					continue
+				}
+				name := p.JsonFieldName
+				if !tsIdentifier.MatchString(name) {
+					name = strconv.Quote(name)
+				}
+				if !p.Required {
+					name += "?"
+				}
+				typ := e.schemaType(p.Schema)
+				if p.Nullable {
+					typ += " | null"
+				}
+				fmt.Fprintf(&b, "%s: %s; ", name, typ)
+			}
+			b.WriteString("}")
+			parts = append(parts, b.String())
+		}
+		if s.HasAdditionalProperties {
+			value := "unknown"
+			if s.AdditionalPropertiesType != nil {
+				value = e.schemaType(*s.AdditionalPropertiesType)
+			}
+			parts = append(parts, "Record<string, "+value+">")
+		}
+		if len(s.UnionElements) != 0 {
+			elements := make([]string, len(s.UnionElements))
+			for i, element := range s.UnionElements {
+				elements[i] = e.goType(string(element))
+			}
+			union := strings.Join(elements, " | ")
+			if len(parts) != 0 {
+				union = "(" + union + ")"
+			}
+			parts = append(parts, union)
+		}
+		return strings.Join(parts, " & ")
+	case s.OAPISchema != nil:
+		switch s.OAPISchema.Type {
+		case "string":
+			return "string"
+		case "integer":
+			if tsInt64Types[strings.TrimPrefix(s.GoType, "*")] {
+				return tsInt64Type()
+			}
+			return "number"
+		case "number":
+			return "number"
+		case "boolean":
+			return "boolean"
+		}
+	}
+	return e.goType(s.GoType)
+}
+
+// goType returns the TypeScript type of the JSON values of a Go type.
+func (e *tsEmitter) goType(goType string) string {
+	goType = strings.TrimPrefix(goType, "*")
+	switch {
+	case e.types[goType]:
+		return goType
+	case tsInt64Types[goType]:
+		return tsInt64Type()
+	case tsGoTypes[goType] != "":
+		return tsGoTypes[goType]
+	case strings.HasPrefix(goType, "[]"):
+		return tsArray(e.goType(goType[2:]))
+	case strings.HasPrefix(goType, "map[string]"):
+		return "Record<string, " + e.goType(strings.TrimPrefix(goType, "map[string]")) + ">"
+	}
+	return "unknown"
+}
+
+// tsArray returns the type of the arrays of a type.
+func tsArray(typ string) string {
+	return tsParenthesized(typ) + "[]"
+}
+
+// tsParenthesized parenthesizes the unions and intersections, to apply [] or ?
+// to them as a whole.
+func tsParenthesized(typ string) string {
+	if strings.Contains(typ, " | ") || strings.Contains(typ, " & ") {
+		return "(" + typ + ")"
+	}
+	return typ
+}
@@ -298,6 +298,17 @@ func SortedRequestBodyKeys(dict map[string]*openapi3.RequestBodyRef) []string {
 	return keys
 }
 
+func SortedLinksKeys(dict openapi3.Links) []string {
+	keys := make([]string, len(dict))
+	i := 0
+	for key := range dict {
+		keys[i] = key
+		i++
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func SortedSecurityRequirementKeys(sr openapi3.SecurityRequirement) []string {
 	keys := make([]string, len(sr))
 	i := 0
@@ -521,6 +532,27 @@ func ReplacePathParamsWithStr(uri string) string {
 	return pathParamRE.ReplaceAllString(uri, "%s")
 }
 
+// ReplacePathParamsWithVars converts a path URI into a Go expression which
+// concatenates the escaped literal parts of the path with the variables
+// holding the already escaped path parameters, in order, eg:
+//
+//	/pets/{id}/toys -> "/pets/" + pathParam0 + "/toys"
+func ReplacePathParamsWithVars(uri string) string {
+	var parts []string
+	last := 0
+	for i, loc := range pathParamRE.FindAllStringIndex(uri, -1) {
+		if literal := uri[last:loc[0]]; literal != "" {
+			parts = append(parts, strconv.Quote((&url.URL{Path: literal}).EscapedPath()))
+		}
+		parts = append(parts, fmt.Sprintf("pathParam%d", i))
+		last = loc[1]
+	}
+	if literal := uri[last:]; literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote((&url.URL{Path: literal}).EscapedPath()))
+	}
+	return strings.Join(parts, " + ")
+}
+
 // SortParamsByPath reorders the given parameter definitions to match those in the path URI.
 func SortParamsByPath(path string, in []ParameterDefinition) ([]ParameterDefinition, error) {
 	pathParams := OrderedParamsFromUri(path)
@@ -602,14 +634,10 @@ func SanitizeGoIdentity(str string) string {
 
 	str = string(sanitized)
 
-	if IsGoKeyword(str) || IsPredeclaredGoIdentifier(str) {
+	if str == "" || IsGoKeyword(str) || IsPredeclaredGoIdentifier(str) {
 		str = "_" + str
 	}
 
-	if !IsValidGoIdentity(str) {
-		panic("here is a bug")
-	}
-
 	return str
 }
 
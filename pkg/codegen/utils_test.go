@@ -327,6 +327,16 @@ func TestReplacePathParamsWithStr(t *testing.T) {
 	assert.EqualValues(t, "/path/%s/%s/%s/foo", result)
 }
 
+func TestReplacePathParamsWithVars(t *testing.T) {
+	assert.Equal(t, `"/path/" + pathParam0 + "/" + pathParam1 + "/" + pathParam2 + "/foo"`,
+		ReplacePathParamsWithVars("/path/{param1}/{.param2}/{;param3*}/foo"))
+	assert.Equal(t, `"/pets/" + pathParam0`, ReplacePathParamsWithVars("/pets/{id}"))
+	assert.Equal(t, `pathParam0 + "." + pathParam1`, ReplacePathParamsWithVars("{name}.{ext}"))
+	assert.Equal(t, `"/100%25/with%20space"`, ReplacePathParamsWithVars("/100%/with space"))
+	assert.Equal(t, `"/"`, ReplacePathParamsWithVars("/"))
+	assert.Equal(t, `""`, ReplacePathParamsWithVars(""))
+}
+
 func TestStringToGoComment(t *testing.T) {
 	testCases := []struct {
 		input    string
@@ -467,6 +477,22 @@ func TestSchemaNameToTypeName(t *testing.T) {
 	}
 }
 
+func TestSanitizeGoIdentity(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"name":   "name",
+		"my-arg": "my_arg",
+		"type":   "_type",
+		"string": "_string",
+		"":       "_",
+	} {
+		got := SanitizeGoIdentity(in)
+		assert.Equal(t, want, got)
+		assert.True(t, IsValidGoIdentity(got))
+	}
+}
+
 func TestTypeDefinitionsEquivalent(t *testing.T) {
 	def1 := TypeDefinition{TypeName: "name", Schema: Schema{
 		OAPISchema: &openapi3.Schema{},
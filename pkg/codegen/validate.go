@@ -0,0 +1,460 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// MapConstraints describes the constraints of the spec on the property names
+// and the number of properties of a map, or of an object with additional
+// properties, which the Validate method of its type checks.
+type MapConstraints struct {
+	PropertyNames string  // The pattern the property names match, from propertyNames
+	MinProperties uint64  // The minimum number of properties, from minProperties
+	MaxProperties *uint64 // The maximum number of properties, from maxProperties
+	// Whether the values have constraints of their own, so that Validate
+	// validates them too
+	ValidateValues bool
+}
+
+// mapConstraints returns the constraints of an object schema with additional
+// properties, or nil if it has none. Patterns which aren't valid Go regular
+// expressions, like those with lookarounds, are left out with a warning.
+func mapConstraints(schema *openapi3.Schema, path []string) *MapConstraints {
+	constraints := MapConstraints{
+		PropertyNames:  propertyNamesPattern(schema),
+		MinProperties:  schema.MinProps,
+		MaxProperties:  schema.MaxProps,
+		ValidateValues: schema.AdditionalProperties.Schema != nil && hasMapConstraints(schema.AdditionalProperties.Schema.Value),
+	}
+	if constraints.PropertyNames != "" {
+		if _, err := regexp.Compile(constraints.PropertyNames); err != nil {
+			warn(nil, "", "the propertyNames pattern %q of %s isn't a valid Go regular expression, so it isn't checked: %v",
+				constraints.PropertyNames, strings.Join(path, "."), err)
+			constraints.PropertyNames = ""
+		}
+	}
+	if constraints == (MapConstraints{}) {
+		return nil
+	}
+	return &constraints
+}
+
+// hasMapConstraints returns whether a schema is a map, or an object with
+// additional properties, whose type has a Validate method.
+func hasMapConstraints(schema *openapi3.Schema) bool {
+	if schema == nil || !SchemaHasAdditionalProperties(schema) || schema.AllOf != nil {
+		return false
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return false
+	}
+	return propertyNamesPattern(schema) != "" || schema.MinProps != 0 || schema.MaxProps != nil ||
+		(schema.AdditionalProperties.Schema != nil && hasMapConstraints(schema.AdditionalProperties.Schema.Value))
+}
+
+// propertyNamesPattern returns the pattern of the propertyNames keyword of a
+// schema. OpenAPI 3.0 doesn't define the keyword, so it's found among the
+// extensions.
+func propertyNamesPattern(schema *openapi3.Schema) string {
+	propertyNames, ok := schema.Extensions["propertyNames"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	pattern, _ := propertyNames["pattern"].(string)
+	return pattern
+}
+
+// GenerateMapValidation generates the Validate methods of the types with map
+// constraints. Aliases get the methods of the types they alias.
+func GenerateMapValidation(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		if td.Schema.MapConstraints == nil || td.IsAlias() || seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		filteredTypes = append(filteredTypes, td)
+	}
+	if len(filteredTypes) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+	return GenerateTemplates([]string{"map-validation.tmpl"}, t, context)
+}
+
+// StructValidation describes the validateRequest method of a struct type,
+// with which the client-validation output option checks the request bodies.
+type StructValidation struct {
+	TypeName string
+	Fields   []FieldValidation
+	Validate bool // Whether the type has a Validate method too, for its map constraints
+}
+
+// FieldValidation describes the checks of a field of a StructValidation.
+type FieldValidation struct {
+	Name        string       // The name of the field
+	Key         string       // The JSON name of the field, naming it in the errors
+	Required    bool         // Whether the field is required, and nil when it's missing
+	Pointer     bool         // Whether the field is a pointer, whose value is checked once set
+	Checks      []ValueCheck // The checks of the value of the field, v
+	ItemChecks  []ValueCheck // The checks of the items of an array, item
+	Nested      bool         // Whether the value may have a validateRequest or Validate method of its own
+	NestedItems bool         // Whether the items may have one
+}
+
+// HasValueChecks returns whether the value of the field is checked, once set.
+func (f FieldValidation) HasValueChecks() bool {
+	return len(f.Checks) != 0 || len(f.ItemChecks) != 0 || f.Nested || f.NestedItems
+}
+
+// localTypeRegexp matches the names of the types of the package.
+var localTypeRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isLocalNamedType returns whether a Go type is one of the named types of
+// the package, which may have validation methods.
+func isLocalNamedType(goType string) bool {
+	return localTypeRegexp.MatchString(goType) && !clientCheckedTypes[goType] &&
+		goType != "bool" && goType != "byte" && goType != "rune" && goType != "any"
+}
+
+// fieldValidation returns the checks of the field of a property against the
+// constraints of the spec: its presence when it's required, the constraints
+// of valueChecks on its value and the items of an array, and the validation
+// methods of the types of those. The read-only properties aren't checked,
+// since the clients don't send them.
+func fieldValidation(p Property, path []string) FieldValidation {
+	if p.JsonIgnored() || p.ReadOnly {
+		return FieldValidation{}
+	}
+	fieldType := p.structFieldType()
+	valueType := strings.TrimPrefix(fieldType, "*")
+	field := FieldValidation{
+		Name:    p.structFieldName(),
+		Key:     p.JsonFieldName,
+		Pointer: valueType != fieldType,
+	}
+	field.Required = p.Required && !p.Nullable && (field.Pointer || valueType == "interface{}" ||
+		strings.HasPrefix(valueType, "[]") || strings.HasPrefix(valueType, "map["))
+	// The Nullable wrappers of the nullable-collections option aren't checked
+	if p.Schema.NullableType != nil {
+		return field
+	}
+	path = append(path, p.JsonFieldName)
+	field.Checks = valueChecks(p.Schema.OAPISchema, "v", path)
+	field.Nested = isLocalNamedType(valueType)
+	if schema := p.Schema.OAPISchema; schema != nil && schema.Type == "array" && schema.Items != nil && !field.Nested {
+		field.ItemChecks = valueChecks(schema.Items.Value, "item", append(path, "items"))
+		field.NestedItems = strings.HasPrefix(valueType, "[]") && isLocalNamedType(strings.TrimPrefix(valueType, "[]"))
+	}
+	return field
+}
+
+// GenerateStructValidation generates the validateRequest methods of the struct
+// types with constraints on their properties, those of the components and of
+// the inline request bodies, with which the client-validation output option
+// checks the request bodies.
+func GenerateStructValidation(t *template.Template, typeDefs []TypeDefinition, ops []OperationDefinition) (string, error) {
+	if !globalState.options.OutputOptions.ClientValidation {
+		return "", nil
+	}
+	typeDefs = append([]TypeDefinition(nil), typeDefs...)
+	for _, op := range ops {
+		for _, td := range op.TypeDefinitions {
+			// The parameters are checked by validate{{opid}}Params
+			if td.TypeName != op.OperationId+"Params" {
+				typeDefs = append(typeDefs, td)
+			}
+		}
+	}
+	var types []StructValidation
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		if seen[td.TypeName] || td.IsAlias() || td.Schema.IsRef() || len(td.Schema.UnionElements) != 0 ||
+			!strings.HasPrefix(td.Schema.GoType, "struct") {
+			continue
+		}
+		seen[td.TypeName] = true
+		validation := StructValidation{TypeName: td.TypeName, Validate: td.Schema.MapConstraints != nil}
+		for _, p := range td.Schema.Properties {
+			if field := fieldValidation(p, []string{td.TypeName}); field.Required || field.HasValueChecks() {
+				validation.Fields = append(validation.Fields, field)
+			}
+		}
+		if len(validation.Fields) != 0 {
+			types = append(types, validation)
+		}
+	}
+	if len(types) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"struct-validation.tmpl"}, t, types)
+}
+
+// ValueCheck is a constraint of the spec on a parameter, which the client
+// checks before sending a request with the client-validation output option.
+type ValueCheck struct {
+	Invalid string // The Go condition under which the value doesn't satisfy the constraint
+	Message string // What the value must satisfy, like "must be at least 1"
+}
+
+// clientCheckedTypes are the Go types, or the underlying types of the named
+// ones, whose values the client checks.
+var clientCheckedTypes = map[string]bool{
+	"string": true,
+	"int":    true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// ValueChecks returns the checks of the constraints of the spec on the value
+// of the parameter, v, which the client makes before sending a request with
+// the client-validation output option.
+func (pd ParameterDefinition) ValueChecks() []ValueCheck {
+	if pd.Spec.Schema == nil {
+		return nil
+	}
+	return valueChecks(pd.Spec.Schema.Value, "v", []string{pd.ParamName})
+}
+
+// ItemChecks returns the checks of the constraints of the spec on the items
+// of an array parameter, item.
+func (pd ParameterDefinition) ItemChecks() []ValueCheck {
+	if !pd.IsArray() || pd.Spec.Schema.Value.Items == nil {
+		return nil
+	}
+	return valueChecks(pd.Spec.Schema.Value.Items.Value, "item", []string{pd.ParamName, "items"})
+}
+
+// HasClientChecks returns whether the client checks the value of the
+// parameter, or of its items, with the client-validation output option.
+func (pd ParameterDefinition) HasClientChecks() bool {
+	return len(pd.ValueChecks()) != 0 || len(pd.ItemChecks()) != 0
+}
+
+// ClientCheckedParams returns the parameters of the operation whose values
+// the client checks with the client-validation output option, in the order
+// of the path, query, header and cookie parameters.
+func (o OperationDefinition) ClientCheckedParams() []ParameterDefinition {
+	if !globalState.options.OutputOptions.ClientValidation {
+		return nil
+	}
+	var result []ParameterDefinition
+	for _, params := range [][]ParameterDefinition{o.PathParams, o.QueryParams, o.HeaderParams, o.CookieParams} {
+		for _, param := range params {
+			if param.HasClientChecks() {
+				result = append(result, param)
+			}
+		}
+	}
+	return result
+}
+
+// valueChecks returns the checks of the constraints of a schema on the value
+// of expr: the number of items of an array, and the length, pattern, bounds
+// and enum of a string or a number. Values of types other than slices and
+// the builtin strings and numbers, or named types of them, aren't checked.
+func valueChecks(schema *openapi3.Schema, expr string, path []string) []ValueCheck {
+	if schema == nil {
+		return nil
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return nil
+	}
+	var checks []ValueCheck
+	if schema.Type == "array" {
+		if schema.MinItems != 0 {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("len(%s) < %d", expr, schema.MinItems),
+				Message: fmt.Sprintf("must have at least %d items", schema.MinItems),
+			})
+		}
+		if schema.MaxItems != nil {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("len(%s) > %d", expr, *schema.MaxItems),
+				Message: fmt.Sprintf("must have at most %d items", *schema.MaxItems),
+			})
+		}
+		return checks
+	}
+
+	goSchema, err := GenerateGoSchema(&openapi3.SchemaRef{Value: schema}, path)
+	if err != nil || !clientCheckedTypes[goSchema.GoType] {
+		return nil
+	}
+	goType := goSchema.GoType
+	if goType == "string" {
+		if schema.MinLength != 0 {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("utf8.RuneCountInString(string(%s)) < %d", expr, schema.MinLength),
+				Message: fmt.Sprintf("must be at least %d characters long", schema.MinLength),
+			})
+		}
+		if schema.MaxLength != nil {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("utf8.RuneCountInString(string(%s)) > %d", expr, *schema.MaxLength),
+				Message: fmt.Sprintf("must be at most %d characters long", *schema.MaxLength),
+			})
+		}
+		if schema.Pattern != "" {
+			if _, err := regexp.Compile(schema.Pattern); err != nil {
+				warn(nil, "", "the pattern %q of %s isn't a valid Go regular expression, so the client doesn't check it: %v",
+					schema.Pattern, strings.Join(path, "."), err)
+			} else {
+				checks = append(checks, ValueCheck{
+					Invalid: fmt.Sprintf("!matchesPattern(%q, string(%s))", schema.Pattern, expr),
+					Message: fmt.Sprintf("must match the pattern %s", schema.Pattern),
+				})
+			}
+		}
+	} else {
+		checks = append(checks, boundChecks(schema, goType, expr, path)...)
+	}
+
+	// With unknown-enum-values, the client sends back the values which servers
+	// added to enums.
+	if len(schema.Enum) != 0 && !globalState.options.OutputOptions.UnknownEnumValues {
+		var conds, values []string
+		for _, value := range schema.Enum {
+			var literal string
+			switch value := value.(type) {
+			case string:
+				if goType != "string" {
+					return checks
+				}
+				literal = strconv.Quote(value)
+			case float64:
+				if goType == "string" || (isIntegerType(goType) && value != math.Trunc(value)) {
+					return checks
+				}
+				literal = numberLiteral(value, isIntegerType(goType))
+			default:
+				return checks
+			}
+			conds = append(conds, expr+" == "+literal)
+			values = append(values, fmt.Sprint(value))
+		}
+		checks = append(checks, ValueCheck{
+			Invalid: "!(" + strings.Join(conds, " || ") + ")",
+			Message: "must be one of " + strings.Join(values, ", "),
+		})
+	}
+	return checks
+}
+
+// numberRanges are the ranges of the values of the Go number types.
+var numberRanges = map[string][2]float64{
+	"int":     {math.MinInt64, math.MaxInt64},
+	"int8":    {math.MinInt8, math.MaxInt8},
+	"int16":   {math.MinInt16, math.MaxInt16},
+	"int32":   {math.MinInt32, math.MaxInt32},
+	"int64":   {math.MinInt64, math.MaxInt64},
+	"uint":    {0, math.MaxUint64},
+	"uint8":   {0, math.MaxUint8},
+	"uint16":  {0, math.MaxUint16},
+	"uint32":  {0, math.MaxUint32},
+	"uint64":  {0, math.MaxUint64},
+	"float32": {-math.MaxFloat32, math.MaxFloat32},
+	"float64": {-math.MaxFloat64, math.MaxFloat64},
+}
+
+func isIntegerType(goType string) bool {
+	return goType != "float32" && goType != "float64"
+}
+
+// boundChecks returns the checks of the minimum, maximum and multipleOf of a
+// number schema on the value of expr, of the Go number type goType. Integers
+// are compared with the bounds rounded to integers. The bounds which all the
+// values of the type satisfy aren't checked, while those which none does
+// reject every value, with a warning.
+func boundChecks(schema *openapi3.Schema, goType string, expr string, path []string) []ValueCheck {
+	var checks []ValueCheck
+	limits := numberRanges[goType]
+	integer := isIntegerType(goType)
+	if schema.Min != nil {
+		min, message := *schema.Min, "must be at least %s"
+		op := "<"
+		if schema.ExclusiveMin {
+			message, op = "must be greater than %s", "<="
+		}
+		if integer {
+			if schema.ExclusiveMin {
+				min = math.Floor(min) + 1
+			} else {
+				min = math.Ceil(min)
+			}
+			op = "<"
+		}
+		message = fmt.Sprintf(message, strconv.FormatFloat(*schema.Min, 'f', -1, 64))
+		if min > limits[1] || (min == limits[1] && schema.ExclusiveMin && !integer) {
+			checks = append(checks, unsatisfiableCheck(expr, "minimum", *schema.Min, goType, message, path))
+		} else if min > limits[0] {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("%s %s %s", expr, op, numberLiteral(min, integer)),
+				Message: message,
+			})
+		}
+	}
+	if schema.Max != nil {
+		max, message := *schema.Max, "must be at most %s"
+		op := ">"
+		if schema.ExclusiveMax {
+			message, op = "must be less than %s", ">="
+		}
+		if integer {
+			if schema.ExclusiveMax {
+				max = math.Ceil(max) - 1
+			} else {
+				max = math.Floor(max)
+			}
+			op = ">"
+		}
+		message = fmt.Sprintf(message, strconv.FormatFloat(*schema.Max, 'f', -1, 64))
+		if max < limits[0] || (max == limits[0] && schema.ExclusiveMax && !integer) {
+			checks = append(checks, unsatisfiableCheck(expr, "maximum", *schema.Max, goType, message, path))
+		} else if max < limits[1] {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("%s %s %s", expr, op, numberLiteral(max, integer)),
+				Message: message,
+			})
+		}
+	}
+	if schema.MultipleOf != nil && integer {
+		if multipleOf := *schema.MultipleOf; multipleOf > 1 && multipleOf == math.Trunc(multipleOf) && multipleOf < limits[1] {
+			checks = append(checks, ValueCheck{
+				Invalid: fmt.Sprintf("%s%%%s != 0", expr, numberLiteral(multipleOf, true)),
+				Message: fmt.Sprintf("must be a multiple of %s", numberLiteral(multipleOf, true)),
+			})
+		}
+	}
+	return checks
+}
+
+// unsatisfiableCheck returns the check of a bound which no value of expr, of
+// the Go number type goType, satisfies, rejecting them all, with a warning.
+func unsatisfiableCheck(expr string, bound string, value float64, goType string, message string, path []string) ValueCheck {
+	warn(nil, "", "the %s %s of %s is beyond the range of its Go type %s, so no value satisfies it, and all are rejected",
+		bound, strconv.FormatFloat(value, 'f', -1, 64), strings.Join(path, "."), goType)
+	return ValueCheck{Invalid: fmt.Sprintf("%s > 0 || %s <= 0", expr, expr), Message: message}
+}
+
+// numberLiteral returns the Go literal of a number, without an exponent for
+// an integer.
+func numberLiteral(value float64, integer bool) string {
+	if integer {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
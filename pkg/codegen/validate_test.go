@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+func TestMapConstraints(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/map-constraints.yaml")
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+
+	output, err := GenerateOutput(swagger, opts)
+	require.NoError(t, err)
+	code := output.Code
+
+	// Maps of references, nested maps and imported types
+	assert.Contains(t, code, "type PetsByName map[string]Pet")
+	assert.Contains(t, code, "type Nested map[string]map[string]Pet")
+	assert.Contains(t, code, "type External map[string]decimal.Decimal")
+	assert.Contains(t, code, `"github.com/shopspring/decimal"`)
+
+	// Constrained maps are validated
+	assert.Contains(t, code, `var labelsPropertyNames = regexp.MustCompile("^[a-z][a-z0-9-]*$")`)
+	assert.Contains(t, code, "func (a Labels) Validate() error {")
+	assert.Contains(t, code, `return fmt.Errorf("%d properties, fewer than the minimum of 1", count)`)
+	assert.Contains(t, code, `return fmt.Errorf("%d properties, more than the maximum of 3", count)`)
+	assert.NotContains(t, code, "func (a PetsByName) Validate() error {")
+
+	// So are the property names of objects with additional properties, and
+	// the values which have constraints of their own
+	assert.Contains(t, code, "func (a Inventory) Validate() error {")
+	assert.Contains(t, code, "if err := properties[name].Validate(); err != nil {")
+
+	// Inline constrained maps get types of their own
+	assert.Contains(t, code, "Counts               *Inventory_Counts")
+	assert.Contains(t, code, "func (a Inventory_Counts) Validate() error {")
+	assert.Contains(t, code, "type Rows map[string]Rows_AdditionalProperties")
+
+	// Patterns Go can't compile are left out
+	assert.NotContains(t, code, "func (a Bad) Validate() error {")
+	require.Len(t, output.Diagnostics, 1)
+	assert.Contains(t, output.Diagnostics[0].Reason, `the propertyNames pattern "^(?!x-)" of Bad isn't a valid Go regular expression`)
+
+	checkLint(t, "test.gen.go", []byte(code))
+}
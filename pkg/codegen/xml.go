@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// isMediaTypeXML returns whether a content type is XML, like application/xml
+// or application/atom+xml.
+func isMediaTypeXML(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	return StringInArray(mediaType, contentTypesXML) || strings.HasSuffix(mediaType, "+xml")
+}
+
+// xmlSchemas returns the schemas of the XML request and response bodies of
+// the operations of the spec, and the schemas they're made of, whose types get
+// xml tags.
+func xmlSchemas(spec *openapi3.T) map[*openapi3.Schema]bool {
+	schemas := make(map[*openapi3.Schema]bool)
+	var walk func(schemaRef *openapi3.SchemaRef)
+	walk = func(schemaRef *openapi3.SchemaRef) {
+		if schemaRef == nil || schemaRef.Value == nil || schemas[schemaRef.Value] {
+			return
+		}
+		schema := schemaRef.Value
+		schemas[schema] = true
+		for _, property := range schema.Properties {
+			walk(property)
+		}
+		walk(schema.Items)
+		walk(schema.AdditionalProperties.Schema)
+		for _, list := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+			for _, part := range list {
+				walk(part)
+			}
+		}
+	}
+	walkContent := func(content openapi3.Content) {
+		for contentType, mediaType := range content {
+			if isMediaTypeXML(contentType) {
+				walk(mediaType.Schema)
+			}
+		}
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, op := range pathItem.Operations() {
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				walkContent(op.RequestBody.Value.Content)
+			}
+			for _, response := range op.Responses {
+				if response.Value != nil {
+					walkContent(response.Value.Content)
+				}
+			}
+		}
+	}
+	return schemas
+}
+
+// xmlTag returns the xml tag of the field of a property, following the xml
+// object of its schema: the element, or attribute, is named and namespaced as
+// it says, and arrays are wrapped in an element when it says so, their items
+// being named by the xml object of the items.
+func (p Property) xmlTag(omitEmpty bool) string {
+	var xmlObject *openapi3.XML
+	var items *openapi3.Schema
+	if schema := p.Schema.OAPISchema; schema != nil {
+		xmlObject = schema.XML
+		if schema.Type == "array" && schema.Items != nil {
+			items = schema.Items.Value
+		}
+	}
+	if xmlObject == nil {
+		xmlObject = &openapi3.XML{}
+	}
+
+	name := p.JsonFieldName
+	if xmlObject.Name != "" {
+		name = xmlObject.Name
+	}
+	namespace := xmlObject.Namespace
+
+	if items != nil {
+		itemName := p.JsonFieldName
+		if items.XML != nil && items.XML.Name != "" {
+			itemName = items.XML.Name
+		}
+		if items.XML != nil && items.XML.Namespace != "" {
+			namespace = items.XML.Namespace
+		}
+		// The name of the array only applies to the element wrapping it.
+		if xmlObject.Wrapped {
+			itemName = name + ">" + itemName
+		}
+		name = itemName
+	}
+
+	tag := name
+	if namespace != "" {
+		tag = namespace + " " + tag
+	}
+	if xmlObject.Attribute && items == nil {
+		tag += ",attr"
+	}
+	if omitEmpty {
+		tag += ",omitempty"
+	}
+	return tag
+}
+
+// xmlNameField returns the XMLName field naming the root element of the type
+// of an object schema, when its xml object names it, or "".
+func xmlNameField(schema *openapi3.Schema) string {
+	if schema == nil || schema.XML == nil || schema.XML.Name == "" {
+		return ""
+	}
+	name := schema.XML.Name
+	if schema.XML.Namespace != "" {
+		name = schema.XML.Namespace + " " + name
+	}
+	return fmt.Sprintf("XMLName xml.Name `json:\"-\" xml:\"%s\"`", name)
+}
@@ -0,0 +1,293 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+)
+
+// runtimeReplacement is what the runtime file generated with the
+// zero-dependencies output option has in place of a helper or type of the
+// runtime packages.
+type runtimeReplacement struct {
+	name string // The name of the replacement
+	part string // The part of the runtime file declaring it
+}
+
+// runtimeReplacements are the replacements of the helpers and types of the
+// runtime packages which the generated clients and models use, by import path
+// and name.
+var runtimeReplacements = map[string]map[string]runtimeReplacement{
+	"github.com/oapi-codegen/runtime": {
+		"StyleParamWithLocation": {"styleParamWithLocation", "styleParamWithLocation"},
+		"ParamLocationUndefined": {"paramLocationUndefined", "styleParamWithLocation"},
+		"ParamLocationQuery":     {"paramLocationQuery", "styleParamWithLocation"},
+		"ParamLocationPath":      {"paramLocationPath", "styleParamWithLocation"},
+		"ParamLocationHeader":    {"paramLocationHeader", "styleParamWithLocation"},
+		"ParamLocationCookie":    {"paramLocationCookie", "styleParamWithLocation"},
+		"MarshalForm":            {"marshalForm", "marshalForm"},
+		"JsonMerge":              {"jsonMerge", "jsonMerge"},
+	},
+	"github.com/oapi-codegen/runtime/types": {
+		"Date":  {"Date", "Date"},
+		"Email": {"Email", "Email"},
+		"UUID":  {"UUID", "UUID"},
+		"File":  {"File", "File"},
+	},
+}
+
+// RuntimeContext is passed to the template of the runtime file.
+type RuntimeContext struct {
+	ModuleName  string
+	Version     string
+	PackageName string
+	Shared      bool            // Whether the runtime is the shared runtime package
+	Uses        map[string]bool // The parts of the runtime file which the code uses
+}
+
+// runtimeTarget is where the references of Go code to the runtime packages
+// point with zero-dependencies.
+type runtimeTarget struct {
+	qualifier  string // The name of the package declaring the replacements, unless it's the package of the code
+	importPath string // The import path of the package, which the code imports if it refers to it
+	exported   bool   // Whether the replacements keep the names of the runtime packages, in the shared runtime
+}
+
+// sharedRuntimeTarget returns the shared runtime package as the target of
+// the references to the runtime packages, if there's one.
+func sharedRuntimeTarget() (runtimeTarget, bool) {
+	importPath := globalState.options.OutputOptions.SharedRuntime.ImportPath
+	if importPath == "" {
+		return runtimeTarget{}, false
+	}
+	return runtimeTarget{qualifier: assumedPackageName(importPath), importPath: importPath, exported: true}, true
+}
+
+// exportedRuntimeName matches the names of the runtime file which the shared
+// runtime exports, under the names of the runtime packages.
+var exportedRuntimeName = regexp.MustCompile(`\b(styleParamWithLocation|paramLocation\w*|marshalForm|jsonMerge)\b`)
+
+// dropRuntimeImports points the references of Go code to the helpers and
+// types of the runtime packages at their replacements, with the
+// zero-dependencies output option, and drops the imports of the runtime
+// packages. The parts of the runtime file the replacements are in are added
+// to uses.
+func dropRuntimeImports(code []byte, target runtimeTarget, uses map[string]bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var runtimeImports []*ast.ImportSpec
+	byName := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := runtimeReplacements[importPath]; !ok {
+			continue
+		}
+		name := assumedPackageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		runtimeImports = append(runtimeImports, spec)
+		byName[name] = importPath
+	}
+
+	// The references to packages are the identifiers which the parser doesn't
+	// resolve.
+	var referenced bool
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		selector, ok := c.Node().(*ast.SelectorExpr)
+		if !ok || err != nil {
+			return err == nil
+		}
+		id, ok := selector.X.(*ast.Ident)
+		if !ok || id.Obj != nil {
+			return true
+		}
+		importPath, ok := byName[id.Name]
+		if !ok {
+			return true
+		}
+		replacement, ok := runtimeReplacements[importPath][selector.Sel.Name]
+		if !ok {
+			err = fmt.Errorf("zero-dependencies: the generated code uses %s.%s, which has no replacement in the generated runtime", importPath, selector.Sel.Name)
+			return false
+		}
+		uses[replacement.part] = true
+		referenced = true
+		name := replacement.name
+		if target.exported {
+			name = selector.Sel.Name
+		}
+		if target.qualifier == "" {
+			c.Replace(&ast.Ident{NamePos: selector.Pos(), Name: name})
+		} else {
+			id.Name, selector.Sel.Name = target.qualifier, name
+		}
+		return false
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The import of a runtime package becomes that of the target, if any,
+	// keeping its place among the imports.
+	for i, spec := range runtimeImports {
+		if i == 0 && referenced && target.importPath != "" && !importsPath(file, target.importPath) {
+			spec.Name, spec.Path.Value = nil, strconv.Quote(target.importPath)
+			continue
+		}
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		astutil.DeleteNamedImport(fset, file, name, importPath)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importsPath returns whether a Go file imports the given path.
+func importsPath(file *ast.File, importPath string) bool {
+	for _, spec := range file.Imports {
+		if spec.Path.Value == strconv.Quote(importPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStandardImports fails unless Go code only imports the standard
+// library, or else the given package, for zero-dependencies.
+func checkStandardImports(code []byte, allowed string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		// The paths of the standard library have no domain.
+		if importPath != allowed && strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			return fmt.Errorf("zero-dependencies: the generated code imports %s, which isn't in the standard library", importPath)
+		}
+	}
+	return nil
+}
+
+// GenerateRuntime generates the runtime file of the package, with the
+// zero-dependencies output option, declaring the given parts, unless there
+// are none. Its declarations mustn't collide with those of the code. With a
+// shared runtime, it's the shared runtime package instead, declaring all the
+// parts, with the names of the runtime packages.
+func GenerateRuntime(t *template.Template, uses map[string]bool, code []byte) (string, error) {
+	opts := globalState.options
+	context := RuntimeContext{PackageName: opts.PackageName, Uses: uses}
+	if target, ok := sharedRuntimeTarget(); ok {
+		context.PackageName, context.Shared = target.qualifier, true
+		context.Uses = make(map[string]bool)
+		for _, replacements := range runtimeReplacements {
+			for _, replacement := range replacements {
+				context.Uses[replacement.part] = true
+			}
+		}
+	}
+	if len(context.Uses) == 0 {
+		return "", nil
+	}
+	context.ModuleName, context.Version = generatorVersion(opts.NoVCSVersionOverride)
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "runtime.tmpl", context); err != nil {
+		return "", fmt.Errorf("error generating the runtime: %w", err)
+	}
+	runtime := buf.Bytes()
+	if context.Shared {
+		runtime = exportedRuntimeName.ReplaceAllFunc(runtime, func(name []byte) []byte {
+			return []byte(UppercaseFirstCharacter(string(name)))
+		})
+	}
+	out, err := imports.Process("runtime.go", runtime, nil)
+	if err != nil {
+		return "", fmt.Errorf("error formatting the runtime: %w", err)
+	}
+	if goVersionAtLeast(18) {
+		if out, err = useAny(out); err != nil {
+			return "", fmt.Errorf("error replacing the empty interfaces of the runtime: %w", err)
+		}
+	}
+	if context.Shared {
+		return string(out), nil
+	}
+
+	declared, err := topLevelNames(code)
+	if err != nil {
+		return "", err
+	}
+	names, err := topLevelNames(out)
+	if err != nil {
+		return "", err
+	}
+	var collisions []string
+	for name := range names {
+		if declared[name] {
+			collisions = append(collisions, name)
+		}
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return "", fmt.Errorf("zero-dependencies: the generated runtime declares %s, colliding with the generated code, whose types x-go-type-name can rename", enumerate(collisions))
+	}
+	return string(out), nil
+}
+
+// topLevelNames returns the names of the package-level declarations of Go
+// code, other than the methods.
+func topLevelNames(code []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DocumentCache keeps the documents read by the loads sharing it, like those
+// of the schemas which many specs reference, so that each is read once.
+type DocumentCache struct {
+	mu        sync.Mutex
+	documents map[string][]byte
+	hits      int
+}
+
+// NewDocumentCache returns an empty cache.
+func NewDocumentCache() *DocumentCache {
+	return &DocumentCache{documents: make(map[string][]byte)}
+}
+
+// LoadSwagger loads a spec like LoadSwaggerWithCircularReferenceCountAndRemoteOptions,
+// reading the documents in the cache from it. A nil cache reads them all.
+func (c *DocumentCache) LoadSwagger(filePath string, circularReferenceCount int, remote RemoteOptions) (*openapi3.T, error) {
+	return loadSwaggerWithCircularReferenceCount(filePath, circularReferenceCount, remote, c)
+}
+
+// Stats returns the number of documents in the cache, and the number of
+// times they were read from it rather than from their files or URLs.
+func (c *DocumentCache) Stats() (documents, hits int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.documents), c.hits
+}
+
+// wrap returns the function reading the documents of a loader through the
+// cache, which is a no-op for a nil cache. The documents are only shared by
+// the loads with the same remote options and host, since the headers change
+// what the servers answer.
+func (c *DocumentCache) wrap(remote RemoteOptions, host string, read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	if c == nil {
+		return read
+	}
+	if read == nil {
+		read = openapi3.DefaultReadFromURI
+	}
+	scope := remote.cacheScope(host)
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		key := scope + cacheKey(location)
+		c.mu.Lock()
+		data, ok := c.documents[key]
+		if ok {
+			c.hits++
+		}
+		c.mu.Unlock()
+		if ok {
+			return data, nil
+		}
+
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.documents[key] = data
+		c.mu.Unlock()
+		return data, nil
+	}
+}
+
+// cacheKey identifies a document by its URL, or the absolute path of its file,
+// since the loads may be relative to different directories.
+func cacheKey(location *url.URL) string {
+	if location.Scheme == "" && location.Host == "" {
+		if path, err := filepath.Abs(filepath.FromSlash(location.Path)); err == nil {
+			return path
+		}
+	}
+	return location.String()
+}
+
+// cacheScope returns the prefix of the keys of the documents read with the
+// options from the given host, a digest of them so that the cache doesn't
+// hold the values of the headers.
+func (o RemoteOptions) cacheScope(host string) string {
+	names := make([]string, 0, len(o.Headers))
+	for name := range o.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	h.Write([]byte(host + "\x00" + o.CacheDir + "\x00"))
+	for _, name := range names {
+		h.Write([]byte(name + "\x00" + o.Headers[name] + "\x00"))
+	}
+	return hex.EncodeToString(h.Sum(nil)) + ":"
+}
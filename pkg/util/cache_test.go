@@ -0,0 +1,63 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentCache(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common.yaml"), []byte("type: object\n"), 0o644))
+	spec := `
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Cached}
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: 'common.yaml'
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(spec), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(spec), 0o644))
+
+	cache := NewDocumentCache()
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		swagger, err := cache.LoadSwagger(filepath.Join(dir, name), 0, RemoteOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "object", swagger.Components.Schemas["Pet"].Value.Type)
+	}
+
+	documents, hits := cache.Stats()
+	assert.Equal(t, 3, documents)
+	assert.Equal(t, 1, hits)
+}
+
+func TestDocumentCacheRemoteOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte(`
+openapi: "3.0.1"
+info: {version: 1.0.0, title: ` + r.Header.Get("X-Tenant") + `}
+paths: {}
+`))
+	}))
+	defer server.Close()
+
+	cache := NewDocumentCache()
+	for _, tenant := range []string{"a", "b", "a"} {
+		swagger, err := cache.LoadSwagger(server.URL+"/spec.yaml", 0, RemoteOptions{Headers: map[string]string{"X-Tenant": tenant}})
+		require.NoError(t, err)
+		assert.Equal(t, tenant, swagger.Info.Title)
+	}
+
+	// The loads with other headers don't share the documents
+	documents, hits := cache.Stats()
+	assert.Equal(t, 2, documents)
+	assert.Equal(t, 1, hits)
+}
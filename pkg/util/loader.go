@@ -1,32 +1,66 @@
 package util
 
 import (
+	"net/http"
 	"net/url"
+	"path/filepath"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
+	return LoadSwaggerWithRemoteOptions(filePath, RemoteOptions{})
+}
+
+// LoadSwaggerWithRemoteOptions loads the spec at the given path or URL. When
+// it's a URL, it's fetched, along with the documents it references, as
+// configured by remote.
+func LoadSwaggerWithRemoteOptions(filePath string, remote RemoteOptions) (swagger *openapi3.T, err error) {
+	return loadSwagger(filePath, remote, nil)
+}
 
+func loadSwagger(filePath string, remote RemoteOptions, cache *DocumentCache) (swagger *openapi3.T, err error) {
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	reader := &documentReader{documents: make(map[string][]byte)}
 
 	u, err := url.Parse(filePath)
 	if err == nil && u.Scheme != "" && u.Host != "" {
-		return loader.LoadFromURI(u)
+		loader.ReadFromURIFunc = reader.wrap(cache.wrap(remote, u.Host, openapi3.URIMapCache(openapi3.ReadFromURIs(
+			remote.readFromHTTP(http.DefaultClient, u.Host),
+			openapi3.ReadFromFile,
+		))))
+		swagger, err = loader.LoadFromURI(u)
 	} else {
-		return loader.LoadFromFile(filePath)
+		loader.ReadFromURIFunc = reader.wrap(cache.wrap(remote, "", loader.ReadFromURIFunc))
+		u = &url.URL{Path: filepath.ToSlash(filePath)}
+		swagger, err = loader.LoadFromFile(filePath)
+	}
+	if err != nil {
+		return nil, err
 	}
+	recordPropertyOrders(swagger, reader.documents, u)
+	return swagger, nil
 }
 
 func LoadSwaggerWithCircularReferenceCount(filePath string, circularReferenceCount int) (swagger *openapi3.T, err error) {
+	return LoadSwaggerWithCircularReferenceCountAndRemoteOptions(filePath, circularReferenceCount, RemoteOptions{})
+}
+
+// LoadSwaggerWithCircularReferenceCountAndRemoteOptions combines
+// LoadSwaggerWithCircularReferenceCount and LoadSwaggerWithRemoteOptions.
+func LoadSwaggerWithCircularReferenceCountAndRemoteOptions(filePath string, circularReferenceCount int, remote RemoteOptions) (swagger *openapi3.T, err error) {
+	return loadSwaggerWithCircularReferenceCount(filePath, circularReferenceCount, remote, nil)
+}
+
+func loadSwaggerWithCircularReferenceCount(filePath string, circularReferenceCount int, remote RemoteOptions, cache *DocumentCache) (swagger *openapi3.T, err error) {
 	// get a copy of the existing count
 	existingCircularReferenceCount := openapi3.CircularReferenceCounter
 	if circularReferenceCount > 0 {
 		openapi3.CircularReferenceCounter = circularReferenceCount
 	}
 
-	swagger, err = LoadSwagger(filePath)
+	swagger, err = loadSwagger(filePath, remote, cache)
 
 	if circularReferenceCount > 0 {
 		// and make sure to reset it
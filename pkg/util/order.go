@@ -0,0 +1,306 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v2"
+)
+
+// propertyOrders holds the order of the properties of the schemas loaded by
+// this package, in their documents, which kin-openapi doesn't keep.
+var propertyOrders sync.Map // *openapi3.Schema -> []string
+
+// PropertyOrder returns the names of the properties of a schema in the order
+// of the document it was loaded from, or nil when it's unknown, like for the
+// schemas of specs which weren't loaded by this package.
+func PropertyOrder(schema *openapi3.Schema) []string {
+	order, _ := propertyOrders.Load(schema)
+	names, _ := order.([]string)
+	return names
+}
+
+// SetPropertyOrder records the order of the properties of a schema, like that
+// of a schema made of others.
+func SetPropertyOrder(schema *openapi3.Schema, names []string) {
+	propertyOrders.Store(schema, names)
+}
+
+// RetainPropertyOrders forgets the orders of the properties of the schemas
+// which aren't part of spec, like those of the specs generated before it, so
+// that generating many specs doesn't keep them all in memory.
+func RetainPropertyOrders(spec *openapi3.T) {
+	schemas := make(map[*openapi3.Schema]bool)
+	collectSchemas(reflect.ValueOf(spec), schemas, make(map[uintptr]bool))
+	propertyOrders.Range(func(key, _ interface{}) bool {
+		if !schemas[key.(*openapi3.Schema)] {
+			propertyOrders.Delete(key)
+		}
+		return true
+	})
+}
+
+// schemaType is the type of the schemas collectSchemas collects.
+var schemaType = reflect.TypeOf(&openapi3.Schema{})
+
+// collectSchemas adds the schemas found in v, a part of a spec, to schemas.
+func collectSchemas(v reflect.Value, schemas map[*openapi3.Schema]bool, visited map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		if v.Type() == schemaType {
+			schemas[v.Interface().(*openapi3.Schema)] = true
+		}
+		collectSchemas(v.Elem(), schemas, visited)
+	case reflect.Interface:
+		if !v.IsNil() {
+			collectSchemas(v.Elem(), schemas, visited)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectSchemas(v.Field(i), schemas, visited)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectSchemas(iter.Value(), schemas, visited)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			collectSchemas(v.Index(i), schemas, visited)
+		}
+	}
+}
+
+// documentReader captures the documents a loader reads, so that the order of
+// their properties can be found once they're loaded.
+type documentReader struct {
+	read      openapi3.ReadFromURIFunc
+	documents map[string][]byte
+	mu        sync.Mutex
+}
+
+// wrap returns the function reading the documents of a loader through the
+// reader.
+func (r *documentReader) wrap(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	if read == nil {
+		read = openapi3.DefaultReadFromURI
+	}
+	r.read = read
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := r.read(loader, location)
+		if err == nil {
+			r.mu.Lock()
+			r.documents[location.String()] = data
+			r.mu.Unlock()
+		}
+		return data, err
+	}
+}
+
+// orderWalker walks the documents of a spec along with the spec, recording
+// the order of the properties of its schemas.
+type orderWalker struct {
+	documents map[string][]byte
+	parsed    map[string]yaml.MapSlice
+	visited   map[*openapi3.Schema]bool
+}
+
+// recordPropertyOrders records the order of the properties of the schemas of
+// a spec loaded from the document at root, among the documents read. Schemas
+// whose documents don't parse are left without one.
+func recordPropertyOrders(spec *openapi3.T, documents map[string][]byte, root *url.URL) {
+	w := orderWalker{
+		documents: documents,
+		parsed:    make(map[string]yaml.MapSlice),
+		visited:   make(map[*openapi3.Schema]bool),
+	}
+	location := root.String()
+	doc := w.document(location)
+	if doc == nil {
+		return
+	}
+
+	for p, item := range spec.Paths {
+		itemNode := get(get(doc, "paths"), p)
+		w.parameters(location, get(itemNode, "parameters"), item.Parameters)
+		for method, op := range item.Operations() {
+			opNode := get(itemNode, strings.ToLower(method))
+			w.parameters(location, get(opNode, "parameters"), op.Parameters)
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				w.content(location, get(get(opNode, "requestBody"), "content"), op.RequestBody.Value.Content)
+			}
+			for code, response := range op.Responses {
+				w.response(location, get(get(opNode, "responses"), code), response)
+			}
+		}
+	}
+
+	if components := spec.Components; components != nil {
+		node := get(doc, "components")
+		for name, schema := range components.Schemas {
+			w.schema(location, get(get(node, "schemas"), name), schema)
+		}
+		for name, param := range components.Parameters {
+			w.parameter(location, get(get(node, "parameters"), name), param)
+		}
+		for name, header := range components.Headers {
+			if header.Value != nil {
+				w.parameter(location, get(get(node, "headers"), name), &openapi3.ParameterRef{Value: &header.Value.Parameter})
+			}
+		}
+		for name, body := range components.RequestBodies {
+			if body.Value != nil {
+				w.content(location, get(get(get(node, "requestBodies"), name), "content"), body.Value.Content)
+			}
+		}
+		for name, response := range components.Responses {
+			w.response(location, get(get(node, "responses"), name), response)
+		}
+	}
+}
+
+// document returns the parsed document read from a location, or nil if it
+// wasn't read, or doesn't parse.
+func (w *orderWalker) document(location string) yaml.MapSlice {
+	if doc, found := w.parsed[location]; found {
+		return doc
+	}
+	var doc yaml.MapSlice
+	if data, found := w.documents[location]; found {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			doc = nil
+		}
+	}
+	w.parsed[location] = doc
+	return doc
+}
+
+func (w *orderWalker) parameters(location string, node interface{}, params openapi3.Parameters) {
+	nodes, _ := node.([]interface{})
+	for i, param := range params {
+		if i < len(nodes) {
+			w.parameter(location, nodes[i], param)
+		}
+	}
+}
+
+func (w *orderWalker) parameter(location string, node interface{}, param *openapi3.ParameterRef) {
+	if param == nil || param.Value == nil || param.Ref != "" {
+		return
+	}
+	w.schema(location, get(node, "schema"), param.Value.Schema)
+	w.content(location, get(node, "content"), param.Value.Content)
+}
+
+func (w *orderWalker) response(location string, node interface{}, response *openapi3.ResponseRef) {
+	if response == nil || response.Value == nil || response.Ref != "" {
+		return
+	}
+	w.content(location, get(node, "content"), response.Value.Content)
+	for name, header := range response.Value.Headers {
+		if header.Value != nil && header.Ref == "" {
+			w.parameter(location, get(get(node, "headers"), name), &openapi3.ParameterRef{Value: &header.Value.Parameter})
+		}
+	}
+}
+
+func (w *orderWalker) content(location string, node interface{}, content openapi3.Content) {
+	for mediaType, media := range content {
+		w.schema(location, get(get(node, mediaType), "schema"), media.Schema)
+	}
+}
+
+// schema records the order of the properties of a schema, and of the schemas
+// it's made of, following the references to other documents.
+func (w *orderWalker) schema(location string, node interface{}, ref *openapi3.SchemaRef) {
+	if node == nil || ref == nil || ref.Value == nil || w.visited[ref.Value] {
+		return
+	}
+	if target, ok := get(node, "$ref").(string); ok {
+		location, node = w.resolve(location, target)
+		w.schema(location, node, ref)
+		return
+	}
+	w.visited[ref.Value] = true
+	schema := ref.Value
+
+	if properties, ok := get(node, "properties").(yaml.MapSlice); ok {
+		names := make([]string, 0, len(properties))
+		for _, item := range properties {
+			name := fmt.Sprint(item.Key)
+			names = append(names, name)
+			w.schema(location, item.Value, schema.Properties[name])
+		}
+		SetPropertyOrder(schema, names)
+	}
+	w.schema(location, get(node, "items"), schema.Items)
+	w.schema(location, get(node, "not"), schema.Not)
+	w.schema(location, get(node, "additionalProperties"), schema.AdditionalProperties.Schema)
+	for keyword, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		nodes, _ := get(node, keyword).([]interface{})
+		for i, ref := range refs {
+			if i < len(nodes) {
+				w.schema(location, nodes[i], ref)
+			}
+		}
+	}
+}
+
+// resolve returns the location of the document a reference made from the
+// document at location points to, and the node it points to there.
+func (w *orderWalker) resolve(location, ref string) (string, interface{}) {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if file != "" {
+		base, err := url.Parse(location)
+		if err != nil {
+			return location, nil
+		}
+		target, err := url.Parse(file)
+		if err != nil {
+			return location, nil
+		}
+		if base.Scheme != "" || target.Scheme != "" {
+			location = base.ResolveReference(target).String()
+		} else {
+			location = (&url.URL{Path: path.Join(path.Dir(base.Path), target.Path)}).String()
+		}
+	}
+	var node interface{} = w.document(location)
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		node = get(node, token)
+	}
+	return location, node
+}
+
+// get returns the value of a key of a mapping, or nil when node isn't a
+// mapping or doesn't have the key.
+func get(node interface{}, key string) interface{} {
+	mapping, ok := node.(yaml.MapSlice)
+	if !ok {
+		return nil
+	}
+	for _, item := range mapping {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetainPropertyOrders(t *testing.T) {
+	dir := t.TempDir()
+	spec := `
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Ordered}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        owner:
+          type: object
+          properties:
+            zip: {type: string}
+            city: {type: string}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(spec), 0o644))
+	first, err := LoadSwagger(filepath.Join(dir, "spec.yaml"))
+	require.NoError(t, err)
+	second, err := LoadSwagger(filepath.Join(dir, "spec.yaml"))
+	require.NoError(t, err)
+	firstPet, secondPet := first.Components.Schemas["Pet"].Value, second.Components.Schemas["Pet"].Value
+	assert.Equal(t, []string{"name", "owner"}, PropertyOrder(firstPet))
+	assert.Equal(t, []string{"name", "owner"}, PropertyOrder(secondPet))
+
+	// The orders of the schemas of the spec are kept, nested ones included,
+	// and those of the others forgotten
+	RetainPropertyOrders(second)
+	assert.Nil(t, PropertyOrder(firstPet))
+	assert.Equal(t, []string{"name", "owner"}, PropertyOrder(secondPet))
+	assert.Equal(t, []string{"zip", "city"}, PropertyOrder(secondPet.Properties["owner"].Value))
+}
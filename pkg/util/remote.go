@@ -0,0 +1,165 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RemoteOptions configures how a spec given as a URL, and the documents it
+// references, are fetched.
+type RemoteOptions struct {
+	// Headers are sent with the requests to the host of the spec, to
+	// authenticate them for instance. Environment variables are expanded in
+	// their values, so that secrets can be kept out of configuration files.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// CacheDir is a directory where fetched documents are kept along with
+	// their ETag or Last-Modified date, so that they're only downloaded again
+	// once they changed.
+	CacheDir string `yaml:"cache-dir,omitempty"`
+}
+
+// cacheEntry is a document cached by readFromHTTP.
+type cacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// readFromHTTP returns a reader of HTTP documents which sends the headers to
+// the given host only, so that they don't leak to the other hosts documents
+// may reference or redirect to, and revalidates the documents cached in
+// CacheDir.
+func (o RemoteOptions) readFromHTTP(client *http.Client, host string) openapi3.ReadFromURIFunc {
+	checkRedirect := client.CheckRedirect
+	redirecting := *client
+	redirecting.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		// The headers of the first request are copied to every redirect
+		if req.URL.Host != host {
+			for name := range o.Headers {
+				req.Header.Del(name)
+			}
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	client = &redirecting
+
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "" || location.Host == "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+		req, err := http.NewRequest(http.MethodGet, location.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if location.Host == host {
+			for name, value := range o.Headers {
+				req.Header.Set(name, os.ExpandEnv(value))
+			}
+		}
+
+		cached := o.readCache(location.String())
+		if cached != nil {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			return cached.Body, nil
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, fmt.Errorf("error loading %q: request returned status code %d", location.String(), resp.StatusCode)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := cacheEntry{
+			URL:          location.String(),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		}
+		if err := o.writeCache(entry); err != nil {
+			return nil, fmt.Errorf("error caching %q: %w", location.String(), err)
+		}
+		return body, nil
+	}
+}
+
+// cachePath returns the file caching the document at the given URL.
+func (o RemoteOptions) cachePath(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(o.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readCache returns the cached document at the given URL, if any. Unreadable
+// entries are ignored, since the document is downloaded again then.
+func (o RemoteOptions) readCache(location string) *cacheEntry {
+	if o.CacheDir == "" {
+		return nil
+	}
+	buf, err := os.ReadFile(o.cachePath(location))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(buf, &entry); err != nil || entry.URL != location {
+		return nil
+	}
+	return &entry
+}
+
+// writeCache caches a document, unless it can't be revalidated. The entry is
+// renamed into place so that concurrent runs never read a partial one.
+func (o RemoteOptions) writeCache(entry cacheEntry) error {
+	if o.CacheDir == "" || (entry.ETag == "" && entry.LastModified == "") {
+		return nil
+	}
+	buf, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(o.CacheDir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(o.CacheDir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), o.cachePath(entry.URL))
+}
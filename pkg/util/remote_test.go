@@ -0,0 +1,106 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSwaggerWithRemoteOptions(t *testing.T) {
+	// The owner is served by another host, which mustn't get the headers
+	var ownerAuthorization string
+	owners := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ownerAuthorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("type: object\n"))
+	}))
+	defer owners.Close()
+
+	var authorization string
+	var downloads int
+	specs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`
+openapi: "3.0.1"
+info: {version: 1.0.0, title: Remote}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '` + owners.URL + `/owner.yaml'
+`))
+	}))
+	defer specs.Close()
+
+	t.Setenv("SPEC_TOKEN", "secret")
+	remote := RemoteOptions{
+		Headers:  map[string]string{"Authorization": "Bearer ${SPEC_TOKEN}"},
+		CacheDir: t.TempDir(),
+	}
+
+	for i := 0; i < 2; i++ {
+		swagger, err := LoadSwaggerWithRemoteOptions(specs.URL+"/spec.yaml", remote)
+		require.NoError(t, err)
+		assert.Equal(t, "object", swagger.Components.Schemas["Pet"].Value.Properties["owner"].Value.Type)
+	}
+
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Empty(t, ownerAuthorization)
+	// The second load revalidated the cached spec rather than downloading it
+	assert.Equal(t, 1, downloads)
+}
+
+func TestLoadSwaggerWithRemoteOptionsError(t *testing.T) {
+	specs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer specs.Close()
+
+	_, err := LoadSwaggerWithRemoteOptions(specs.URL+"/spec.yaml", RemoteOptions{})
+	assert.ErrorContains(t, err, "status code 401")
+}
+
+func TestLoadSwaggerWithRemoteOptionsRedirect(t *testing.T) {
+	// The mirror is another host, which mustn't get the headers either
+	var mirrorHeaders http.Header
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorHeaders = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"openapi": "3.0.1", "info": {"version": "1.0.0", "title": "Mirror"}, "paths": {}}`))
+	}))
+	defer mirror.Close()
+
+	specs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, mirror.URL+"/spec.json", http.StatusFound)
+	}))
+	defer specs.Close()
+
+	remote := RemoteOptions{Headers: map[string]string{"Authorization": "Bearer secret", "X-Api-Key": "key"}}
+	swagger, err := LoadSwaggerWithRemoteOptions(specs.URL+"/spec.json", remote)
+	require.NoError(t, err)
+	assert.Equal(t, "Mirror", swagger.Info.Title)
+	require.NotNil(t, mirrorHeaders)
+	assert.Empty(t, mirrorHeaders.Get("Authorization"))
+	assert.Empty(t, mirrorHeaders.Get("X-Api-Key"))
+}
+
+func TestLoadSwaggerWithRemoteOptionsNotOK(t *testing.T) {
+	// Only the 2xx responses hold documents
+	specs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer specs.Close()
+
+	_, err := LoadSwaggerWithRemoteOptions(specs.URL+"/spec.yaml", RemoteOptions{})
+	assert.ErrorContains(t, err, "status code 304")
+}